@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.34.2
-// 	protoc        v4.24.4
+// 	protoc        (unknown)
 // source: extensions.proto
 
 package generated
@@ -53,6 +53,30 @@ var file_extensions_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "varint,5015,opt,name=jsonif_no_deserializer",
 		Filename:      "extensions.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5016,
+		Name:          "jsonif_message_oneof_active_only",
+		Tag:           "varint,5016,opt,name=jsonif_message_oneof_active_only",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5017,
+		Name:          "jsonif_message_keep_unknown_fields",
+		Tag:           "varint,5017,opt,name=jsonif_message_keep_unknown_fields",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5018,
+		Name:          "jsonif_message_strict",
+		Tag:           "varint,5018,opt,name=jsonif_message_strict",
+		Filename:      "extensions.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
 		ExtensionType: (*bool)(nil),
@@ -77,6 +101,110 @@ var file_extensions_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,5014,opt,name=jsonif_name",
 		Filename:      "extensions.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         5015,
+		Name:          "jsonif_default",
+		Tag:           "bytes,5015,opt,name=jsonif_default",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         5016,
+		Name:          "jsonif_min",
+		Tag:           "bytes,5016,opt,name=jsonif_min",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         5017,
+		Name:          "jsonif_max",
+		Tag:           "bytes,5017,opt,name=jsonif_max",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         5018,
+		Name:          "jsonif_min_len",
+		Tag:           "varint,5018,opt,name=jsonif_min_len",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         5019,
+		Name:          "jsonif_max_len",
+		Tag:           "varint,5019,opt,name=jsonif_max_len",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         5020,
+		Name:          "jsonif_pattern",
+		Tag:           "bytes,5020,opt,name=jsonif_pattern",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         5021,
+		Name:          "jsonif_min_items",
+		Tag:           "varint,5021,opt,name=jsonif_min_items",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         5022,
+		Name:          "jsonif_max_items",
+		Tag:           "varint,5022,opt,name=jsonif_max_items",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5023,
+		Name:          "jsonif_required",
+		Tag:           "varint,5023,opt,name=jsonif_required",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5024,
+		Name:          "jsonif_enum_defined_only",
+		Tag:           "varint,5024,opt,name=jsonif_enum_defined_only",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.OneofOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5016,
+		Name:          "jsonif_oneof_active_only",
+		Tag:           "varint,5016,opt,name=jsonif_oneof_active_only",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5016,
+		Name:          "jsonif_enum_as_name",
+		Tag:           "varint,5016,opt,name=jsonif_enum_as_name",
+		Filename:      "extensions.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5016,
+		Name:          "jsonif_file_enum_as_name",
+		Tag:           "varint,5016,opt,name=jsonif_file_enum_as_name",
+		Filename:      "extensions.proto",
+	},
 }
 
 // Extension fields to descriptorpb.MessageOptions.
@@ -97,16 +225,83 @@ var (
 	//
 	// optional bool jsonif_no_deserializer = 5015;
 	E_JsonifNoDeserializer = &file_extensions_proto_extTypes[3]
+	// メッセージ内の全ての oneof に対して jsonif_oneof_active_only と同じ設定をする
+	//
+	// optional bool jsonif_message_oneof_active_only = 5016;
+	E_JsonifMessageOneofActiveOnly = &file_extensions_proto_extTypes[4]
+	// JSON から読み込む際に、知らないキーとその値を保持して、JSON に変換する際にそのまま出力する
+	//
+	// optional bool jsonif_message_keep_unknown_fields = 5017;
+	E_JsonifMessageKeepUnknownFields = &file_extensions_proto_extTypes[5]
+	// JSON から読み込む際に、知らないキーや型の違う値をエラーにする（C++, C, TypeScript のみ）
+	//
+	// optional bool jsonif_message_strict = 5018;
+	E_JsonifMessageStrict = &file_extensions_proto_extTypes[6]
 )
 
 // Extension fields to descriptorpb.FieldOptions.
 var (
 	// optional bool jsonif_optimistic = 5012;
-	E_JsonifOptimistic = &file_extensions_proto_extTypes[4]
+	E_JsonifOptimistic = &file_extensions_proto_extTypes[7]
 	// optional bool jsonif_discard_if_default = 5013;
-	E_JsonifDiscardIfDefault = &file_extensions_proto_extTypes[5]
+	E_JsonifDiscardIfDefault = &file_extensions_proto_extTypes[8]
 	// optional string jsonif_name = 5014;
-	E_JsonifName = &file_extensions_proto_extTypes[6]
+	E_JsonifName = &file_extensions_proto_extTypes[9]
+	// フィールドの初期値。JSON から読み込む際にキーが無かった場合もこの値になる
+	// 数値、bool、文字列、enum のフィールドにだけ指定できる（enum は値の名前か数値で指定する）
+	//
+	// optional string jsonif_default = 5015;
+	E_JsonifDefault = &file_extensions_proto_extTypes[10]
+	// 値の検証ルール。生成される validate 関数で検証する
+	// repeated の場合は各要素、map の場合は各値に対するルールになる（jsonif_min_items, jsonif_max_items を除く）
+	// 数値の範囲（両端を含む）。jsonif_default と同じく文字列で指定する
+	//
+	// optional string jsonif_min = 5016;
+	E_JsonifMin = &file_extensions_proto_extTypes[11]
+	// optional string jsonif_max = 5017;
+	E_JsonifMax = &file_extensions_proto_extTypes[12]
+	// 文字列の長さ（コードポイント数）の範囲。bytes の場合はバイト数
+	//
+	// optional uint32 jsonif_min_len = 5018;
+	E_JsonifMinLen = &file_extensions_proto_extTypes[13]
+	// optional uint32 jsonif_max_len = 5019;
+	E_JsonifMaxLen = &file_extensions_proto_extTypes[14]
+	// 文字列がこの正規表現（ECMAScript の構文）にマッチする部分を含むこと
+	//
+	// optional string jsonif_pattern = 5020;
+	E_JsonifPattern = &file_extensions_proto_extTypes[15]
+	// repeated の要素数、map のエントリ数の範囲
+	//
+	// optional uint32 jsonif_min_items = 5021;
+	E_JsonifMinItems = &file_extensions_proto_extTypes[16]
+	// optional uint32 jsonif_max_items = 5022;
+	E_JsonifMaxItems = &file_extensions_proto_extTypes[17]
+	// optional フィールドは値が設定されていること、メッセージ型のフィールドはデフォルト値ではないこと
+	//
+	// optional bool jsonif_required = 5023;
+	E_JsonifRequired = &file_extensions_proto_extTypes[18]
+	// enum で定義されている値であること
+	//
+	// optional bool jsonif_enum_defined_only = 5024;
+	E_JsonifEnumDefinedOnly = &file_extensions_proto_extTypes[19]
+)
+
+// Extension fields to descriptorpb.OneofOptions.
+var (
+	// optional bool jsonif_oneof_active_only = 5016;
+	E_JsonifOneofActiveOnly = &file_extensions_proto_extTypes[20]
+)
+
+// Extension fields to descriptorpb.EnumOptions.
+var (
+	// optional bool jsonif_enum_as_name = 5016;
+	E_JsonifEnumAsName = &file_extensions_proto_extTypes[21]
+)
+
+// Extension fields to descriptorpb.FileOptions.
+var (
+	// optional bool jsonif_file_enum_as_name = 5016;
+	E_JsonifFileEnumAsName = &file_extensions_proto_extTypes[22]
 )
 
 var File_extensions_proto protoreflect.FileDescriptor
@@ -115,67 +310,164 @@ var file_extensions_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x3a, 0x5f, 0x0a, 0x19, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d,
+	0x72, 0x6f, 0x74, 0x6f, 0x3a, 0x5c, 0x0a, 0x19, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69,
 	0x63, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
 	0x6e, 0x73, 0x18, 0x94, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x6a, 0x73, 0x6f, 0x6e, 0x69,
 	0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74,
-	0x69, 0x63, 0x88, 0x01, 0x01, 0x3a, 0x6d, 0x0a, 0x21, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f,
-	0x69, 0x66, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x95, 0x27, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x1d, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x49, 0x66, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x88, 0x01, 0x01, 0x3a, 0x55, 0x0a, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6e,
-	0x6f, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67,
+	0x69, 0x63, 0x3a, 0x6a, 0x0a, 0x21, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x66, 0x5f,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x95, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x1d, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x44, 0x69,
+	0x73, 0x63, 0x61, 0x72, 0x64, 0x49, 0x66, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x3a, 0x52,
+	0x0a, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x5f, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x96, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4e, 0x6f, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x72, 0x3a, 0x56, 0x0a, 0x16, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x5f,
+	0x64, 0x65, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x96, 0x27,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4e, 0x6f, 0x53, 0x65,
-	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x88, 0x01, 0x01, 0x3a, 0x59, 0x0a, 0x16, 0x6a,
-	0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x5f, 0x64, 0x65, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6a,
-	0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4e, 0x6f, 0x44, 0x65, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69,
-	0x7a, 0x65, 0x72, 0x88, 0x01, 0x01, 0x3a, 0x4e, 0x0a, 0x11, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
-	0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x1d, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x94, 0x27, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x10, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73,
-	0x74, 0x69, 0x63, 0x88, 0x01, 0x01, 0x3a, 0x5c, 0x0a, 0x19, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
-	0x5f, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x66, 0x5f, 0x64, 0x65, 0x66, 0x61,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x97, 0x27,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4e, 0x6f, 0x44, 0x65,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x3a, 0x68, 0x0a, 0x20, 0x6a, 0x73,
+	0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x6e, 0x65,
+	0x6f, 0x66, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x12, 0x1f,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x98, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x4f, 0x6e, 0x6c, 0x79, 0x3a, 0x6c, 0x0a, 0x22, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x99, 0x27, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x1e, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4b, 0x65, 0x65, 0x70, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x3a, 0x54, 0x0a, 0x15, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9a, 0x27, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x13, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x53, 0x74, 0x72, 0x69, 0x63, 0x74, 0x3a, 0x4b, 0x0a, 0x11, 0x6a, 0x73, 0x6f, 0x6e,
+	0x69, 0x66, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x94, 0x27, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x3a, 0x59, 0x0a, 0x19, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f,
+	0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x66, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x95, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
+	0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x49, 0x66, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x3a, 0x3f, 0x0a, 0x0b, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x96,
+	0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4e, 0x61, 0x6d,
+	0x65, 0x3a, 0x45, 0x0a, 0x0e, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x64, 0x65, 0x66, 0x61,
 	0x75, 0x6c, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x95, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x6a, 0x73, 0x6f, 0x6e, 0x69,
-	0x66, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x49, 0x66, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x88, 0x01, 0x01, 0x3a, 0x42, 0x0a, 0x0b, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x96, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x69,
-	0x66, 0x4e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x63, 0x6d, 0x64, 0x2f,
-	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x58, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6e, 0x73, 0x18, 0x97, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6a, 0x73, 0x6f, 0x6e, 0x69,
+	0x66, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x3a, 0x3d, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e,
+	0x69, 0x66, 0x5f, 0x6d, 0x69, 0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x98, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x73,
+	0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x69, 0x6e, 0x3a, 0x3d, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x69,
+	0x66, 0x5f, 0x6d, 0x61, 0x78, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x99, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x73, 0x6f,
+	0x6e, 0x69, 0x66, 0x4d, 0x61, 0x78, 0x3a, 0x44, 0x0a, 0x0e, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
+	0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9a, 0x27, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x3a, 0x44, 0x0a, 0x0e,
+	0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x12, 0x1d,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9b, 0x27,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x61, 0x78, 0x4c,
+	0x65, 0x6e, 0x3a, 0x45, 0x0a, 0x0e, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x9c, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6a, 0x73, 0x6f, 0x6e,
+	0x69, 0x66, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x3a, 0x48, 0x0a, 0x10, 0x6a, 0x73, 0x6f,
+	0x6e, 0x69, 0x66, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9d, 0x27, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x69, 0x6e, 0x49, 0x74,
+	0x65, 0x6d, 0x73, 0x3a, 0x48, 0x0a, 0x10, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e, 0x27, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6a,
+	0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4d, 0x61, 0x78, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x3a, 0x47, 0x0a,
+	0x0f, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x9f, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x3a, 0x57, 0x0a, 0x18, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
+	0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x6e,
+	0x6c, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xa0, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
+	0x45, 0x6e, 0x75, 0x6d, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x3a,
+	0x57, 0x0a, 0x18, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x5f, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x5f,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4f, 0x6e,
+	0x65, 0x6f, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x98, 0x27, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x15, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x3a, 0x4c, 0x0a, 0x13, 0x6a, 0x73, 0x6f, 0x6e,
+	0x69, 0x66, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x61, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x98, 0x27,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x45, 0x6e, 0x75, 0x6d,
+	0x41, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x3a, 0x55, 0x0a, 0x18, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x61, 0x73, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x98, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x69, 0x66, 0x46,
+	0x69, 0x6c, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x41, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x0f, 0x5a,
+	0x0d, 0x63, 0x6d, 0x64, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x58, 0x00,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_extensions_proto_goTypes = []any{
 	(*descriptorpb.MessageOptions)(nil), // 0: google.protobuf.MessageOptions
 	(*descriptorpb.FieldOptions)(nil),   // 1: google.protobuf.FieldOptions
+	(*descriptorpb.OneofOptions)(nil),   // 2: google.protobuf.OneofOptions
+	(*descriptorpb.EnumOptions)(nil),    // 3: google.protobuf.EnumOptions
+	(*descriptorpb.FileOptions)(nil),    // 4: google.protobuf.FileOptions
 }
 var file_extensions_proto_depIdxs = []int32{
-	0, // 0: jsonif_message_optimistic:extendee -> google.protobuf.MessageOptions
-	0, // 1: jsonif_message_discard_if_default:extendee -> google.protobuf.MessageOptions
-	0, // 2: jsonif_no_serializer:extendee -> google.protobuf.MessageOptions
-	0, // 3: jsonif_no_deserializer:extendee -> google.protobuf.MessageOptions
-	1, // 4: jsonif_optimistic:extendee -> google.protobuf.FieldOptions
-	1, // 5: jsonif_discard_if_default:extendee -> google.protobuf.FieldOptions
-	1, // 6: jsonif_name:extendee -> google.protobuf.FieldOptions
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	0, // [0:7] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: jsonif_message_optimistic:extendee -> google.protobuf.MessageOptions
+	0,  // 1: jsonif_message_discard_if_default:extendee -> google.protobuf.MessageOptions
+	0,  // 2: jsonif_no_serializer:extendee -> google.protobuf.MessageOptions
+	0,  // 3: jsonif_no_deserializer:extendee -> google.protobuf.MessageOptions
+	0,  // 4: jsonif_message_oneof_active_only:extendee -> google.protobuf.MessageOptions
+	0,  // 5: jsonif_message_keep_unknown_fields:extendee -> google.protobuf.MessageOptions
+	0,  // 6: jsonif_message_strict:extendee -> google.protobuf.MessageOptions
+	1,  // 7: jsonif_optimistic:extendee -> google.protobuf.FieldOptions
+	1,  // 8: jsonif_discard_if_default:extendee -> google.protobuf.FieldOptions
+	1,  // 9: jsonif_name:extendee -> google.protobuf.FieldOptions
+	1,  // 10: jsonif_default:extendee -> google.protobuf.FieldOptions
+	1,  // 11: jsonif_min:extendee -> google.protobuf.FieldOptions
+	1,  // 12: jsonif_max:extendee -> google.protobuf.FieldOptions
+	1,  // 13: jsonif_min_len:extendee -> google.protobuf.FieldOptions
+	1,  // 14: jsonif_max_len:extendee -> google.protobuf.FieldOptions
+	1,  // 15: jsonif_pattern:extendee -> google.protobuf.FieldOptions
+	1,  // 16: jsonif_min_items:extendee -> google.protobuf.FieldOptions
+	1,  // 17: jsonif_max_items:extendee -> google.protobuf.FieldOptions
+	1,  // 18: jsonif_required:extendee -> google.protobuf.FieldOptions
+	1,  // 19: jsonif_enum_defined_only:extendee -> google.protobuf.FieldOptions
+	2,  // 20: jsonif_oneof_active_only:extendee -> google.protobuf.OneofOptions
+	3,  // 21: jsonif_enum_as_name:extendee -> google.protobuf.EnumOptions
+	4,  // 22: jsonif_file_enum_as_name:extendee -> google.protobuf.FileOptions
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	0,  // [0:23] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_extensions_proto_init() }
@@ -190,7 +482,7 @@ func file_extensions_proto_init() {
 			RawDescriptor: file_extensions_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   0,
-			NumExtensions: 7,
+			NumExtensions: 23,
 			NumServices:   0,
 		},
 		GoTypes:           file_extensions_proto_goTypes,
@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/generated"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// jsonif_default の値を検証して、フィールドの型に合わせた形に正規化する
+// 数値は strconv で読み込めた値を書き直したもの、enum は値の番号になる
+func resolveDefault(field *Field) error {
+	if field.Desc.Options == nil || !proto.HasExtension(field.Desc.Options, generated.E_JsonifDefault) {
+		return nil
+	}
+	value := proto.GetExtension(field.Desc.Options, generated.E_JsonifDefault).(string)
+	if field.Repeated || field.Oneof != nil {
+		return fmt.Errorf("%s: jsonif_default is not supported for repeated, map, oneof or optional fields", field)
+	}
+	var err error
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		value, err = normalizeInt(value, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		value, err = normalizeInt(value, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		value, err = normalizeUint(value, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		value, err = normalizeUint(value, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		value, err = normalizeFloat(value, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		value, err = normalizeFloat(value, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		if value != "true" && value != "false" {
+			err = fmt.Errorf("must be true or false")
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		field.DefaultEnum = findEnumValue(field.Enum, value)
+		if field.DefaultEnum == nil {
+			err = fmt.Errorf("no such value in %s", field.Enum.FullName)
+		} else {
+			value = strconv.Itoa(int(field.DefaultEnum.Number))
+		}
+	default:
+		return fmt.Errorf("%s: jsonif_default is not supported for %s fields", field, strings.ToLower(strings.TrimPrefix(field.Type.String(), "TYPE_")))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid jsonif_default %q: %v", field, proto.GetExtension(field.Desc.Options, generated.E_JsonifDefault), err)
+	}
+	field.HasDefault = true
+	field.Default = value
+	return nil
+}
+
+func normalizeInt(value string, bitSize int) (string, error) {
+	v, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return "", err.(*strconv.NumError).Err
+	}
+	return strconv.FormatInt(v, 10), nil
+}
+
+func normalizeUint(value string, bitSize int) (string, error) {
+	v, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return "", err.(*strconv.NumError).Err
+	}
+	return strconv.FormatUint(v, 10), nil
+}
+
+func normalizeFloat(value string, bitSize int) (string, error) {
+	// 0x1p-2 のような書き方は言語によって使えないので受け付けない
+	if strings.ContainsAny(value, "xX_") {
+		return "", strconv.ErrSyntax
+	}
+	v, err := strconv.ParseFloat(value, bitSize)
+	if err != nil {
+		return "", err.(*strconv.NumError).Err
+	}
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "", fmt.Errorf("must be finite")
+	}
+	return strconv.FormatFloat(v, 'g', -1, bitSize), nil
+}
+
+// 値の名前か番号で enum の値を探す
+func findEnumValue(enum *Enum, value string) *EnumValue {
+	for _, v := range enum.Values {
+		if v.Name == value {
+			return v
+		}
+	}
+	if n, err := strconv.ParseInt(value, 10, 32); err == nil {
+		for _, v := range enum.Values {
+			if int64(v.Number) == n {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// 浮動小数点数として読まれるように、整数の形をしていたら .0 を付ける
+func FloatLiteral(value string) string {
+	if strings.ContainsAny(value, ".eE") {
+		return value
+	}
+	return value + ".0"
+}
+
+// 型に合わせて正規化した値（jsonif_default や jsonif_min の値）の C, C++ のリテラル
+// enum は値の番号になるので、必要なら呼び出し側でキャストする
+func CLiteral(field *Field, value string) string {
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return FloatLiteral(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return FloatLiteral(value) + "f"
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		// -9223372036854775808LL は 9223372036854775808LL の符号反転になってしまうので書けない
+		if value == "-9223372036854775808" {
+			return "(-9223372036854775807LL - 1)"
+		}
+		return value + "LL"
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return value + "U"
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return value + "ULL"
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return CStringLiteral(value)
+	}
+	return value
+}
+
+// C, C++ の文字列リテラルにする
+// ASCII 以外の文字はそのままのバイト列で出力する
+func CStringLiteral(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch c {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '?':
+			// トライグラフにならないようにする
+			b.WriteString(`\?`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				// 8 進数なら後ろに続く文字が数字でも 3 桁で終わる
+				fmt.Fprintf(&b, `\%03o`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
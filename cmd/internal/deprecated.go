@@ -0,0 +1,76 @@
+package internal
+
+// enum に deprecated な値があるかどうか
+func (e *Enum) HasDeprecatedValue() bool {
+	for _, v := range e.Values {
+		if v.Deprecated {
+			return true
+		}
+	}
+	return false
+}
+
+// 生成したコードが deprecated な要素を定義、または参照するかどうか
+// C, C++ では、生成したコード自身が deprecated な要素を参照した時の警告を抑制する必要がある
+func (f *File) UsesDeprecated() bool {
+	for _, enum := range f.Enums {
+		if enum.HasDeprecatedValue() {
+			return true
+		}
+	}
+	for _, msg := range f.Messages {
+		if msg.usesDeprecated() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Message) usesDeprecated() bool {
+	if m.Deprecated {
+		return true
+	}
+	for _, field := range m.Fields {
+		if field.Deprecated {
+			return true
+		}
+		value := field.ValueField()
+		if value.Message != nil && value.Message.Deprecated {
+			return true
+		}
+		if value.Enum != nil && value.Enum.HasDeprecatedValue() {
+			return true
+		}
+	}
+	for _, enum := range m.Enums {
+		if enum.HasDeprecatedValue() {
+			return true
+		}
+	}
+	for _, nested := range m.Messages {
+		if nested.usesDeprecated() {
+			return true
+		}
+	}
+	return false
+}
+
+// C, C++ で deprecated な要素を参照した時の警告を抑制する
+// DisableDeprecatedWarnings と RestoreDeprecatedWarnings は対にして使う
+func DisableDeprecatedWarnings(p *Formatter) {
+	p.P("#if defined(__GNUC__)")
+	p.P("#pragma GCC diagnostic push")
+	p.P("#pragma GCC diagnostic ignored \"-Wdeprecated-declarations\"")
+	p.P("#elif defined(_MSC_VER)")
+	p.P("#pragma warning(push)")
+	p.P("#pragma warning(disable: 4996)")
+	p.P("#endif")
+}
+
+func RestoreDeprecatedWarnings(p *Formatter) {
+	p.P("#if defined(__GNUC__)")
+	p.P("#pragma GCC diagnostic pop")
+	p.P("#elif defined(_MSC_VER)")
+	p.P("#pragma warning(pop)")
+	p.P("#endif")
+}
@@ -0,0 +1,216 @@
+// protoc を使わずに各プラグインの gen を呼び出して、出力をゴールデンファイルと比較するためのパッケージ
+//
+// ゴールデンファイルを更新する場合は -update フラグを付けてテストを実行する。
+// -update を定義していないパッケージも含めて go test ./... で更新する場合は環境変数 UPDATE_GOLDEN=1 を指定する
+package goldentest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/melpon/protoc-gen-jsonif/cmd/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// リポジトリのルートディレクトリ
+func rootDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..")
+}
+
+func toFileDescriptorProto(file linker.File) *descriptorpb.FileDescriptorProto {
+	var fdp *descriptorpb.FileDescriptorProto
+	if res, ok := file.(linker.Result); ok {
+		fdp = proto.Clone(res.FileDescriptorProto()).(*descriptorpb.FileDescriptorProto)
+	} else {
+		fdp = protodesc.ToFileDescriptorProto(file)
+	}
+	// protoc と同じく json_name は必ず埋めておく
+	var setJsonName func(descs []*descriptorpb.DescriptorProto, msgs protoreflect.MessageDescriptors)
+	setJsonName = func(descs []*descriptorpb.DescriptorProto, msgs protoreflect.MessageDescriptors) {
+		for i, desc := range descs {
+			msg := msgs.Get(i)
+			for j, field := range desc.Field {
+				if field.JsonName == nil {
+					field.JsonName = proto.String(msg.Fields().Get(j).JSONName())
+				}
+			}
+			setJsonName(desc.NestedType, msg.Messages())
+		}
+	}
+	setJsonName(fdp.MessageType, file.Messages())
+	return fdp
+}
+
+// test/proto 以下の proto ファイルをコンパイルして、protoc がプラグインに渡すのと同じ CodeGeneratorRequest を作る
+func NewRequest(t *testing.T, parameter string, files ...string) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+
+	root := rootDir()
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: []string{
+				filepath.Join(root, "test", "proto"),
+				filepath.Join(root, "proto"),
+			},
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	compiled, err := compiler.Compile(context.Background(), files...)
+	if err != nil {
+		t.Fatalf("failed to compile %v: %v", files, err)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: files,
+	}
+	if len(parameter) != 0 {
+		req.Parameter = proto.String(parameter)
+	}
+
+	// 依存ファイルが先に来るように並べる
+	added := map[string]bool{}
+	var add func(file linker.File)
+	add = func(file linker.File) {
+		if added[file.Path()] {
+			return
+		}
+		added[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor.(linker.File))
+		}
+		fdp := toFileDescriptorProto(file)
+		// protoc はソースコード情報を file_to_generate にのみ含める
+		if !contains(files, file.Path()) {
+			fdp.SourceCodeInfo = nil
+		}
+		req.ProtoFile = append(req.ProtoFile, fdp)
+	}
+	for _, file := range compiled {
+		add(file)
+	}
+
+	// 拡張オプションが正しく解釈されるように、一度シリアライズしてから読み直す
+	b, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := internal.PrepareRequest(req); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func contains(xs []string, x string) bool {
+	for _, v := range xs {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+// レスポンスのファイルを dir 以下のゴールデンファイルと比較する
+func Check(t *testing.T, dir string, resp *pluginpb.CodeGeneratorResponse) {
+	t.Helper()
+
+	if resp.Error != nil {
+		t.Fatalf("plugin error: %s", *resp.Error)
+	}
+
+	if *update || os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range resp.File {
+			path := filepath.Join(dir, filepath.FromSlash(*file.Name))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(file.GetContent()), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	generated := map[string]bool{}
+	for _, file := range resp.File {
+		generated[*file.Name] = true
+		path := filepath.Join(dir, filepath.FromSlash(*file.Name))
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("%s: golden file not found (run go test with -update or UPDATE_GOLDEN=1)", *file.Name)
+			continue
+		}
+		if diff := diffLines(string(want), file.GetContent()); len(diff) != 0 {
+			t.Errorf("%s: generated code differs from %s (run go test with -update or UPDATE_GOLDEN=1)\n%s", *file.Name, path, diff)
+		}
+	}
+
+	// 出力されなくなったゴールデンファイルが残っていないか確認する
+	var stale []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if !generated[filepath.ToSlash(rel)] {
+			stale = append(stale, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(stale)
+	for _, name := range stale {
+		t.Errorf("%s: golden file exists but was not generated (run go test with -update or UPDATE_GOLDEN=1)", name)
+	}
+}
+
+// 最初に異なっている行の前後を表示する
+func diffLines(want string, got string) string {
+	if want == got {
+		return ""
+	}
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	i := 0
+	for i < len(wantLines) && i < len(gotLines) && wantLines[i] == gotLines[i] {
+		i++
+	}
+	var b strings.Builder
+	for j := i; j < i+5; j++ {
+		if j < len(wantLines) {
+			b.WriteString("- " + wantLines[j] + "\n")
+		}
+	}
+	for j := i; j < i+5; j++ {
+		if j < len(gotLines) {
+			b.WriteString("+ " + gotLines[j] + "\n")
+		}
+	}
+	return fmt.Sprintf("line %d:\n%s", i+1, b.String())
+}
@@ -0,0 +1,71 @@
+package internal
+
+// 各言語の予約語
+//
+// proto のフィールド名などをそのまま識別子として出力するとコンパイルできなくなるので、
+// 各ジェネレータは予約語と一致する名前をエスケープして出力する
+type Keywords map[string]bool
+
+func newKeywords(words ...string) Keywords {
+	k := Keywords{}
+	for _, w := range words {
+		k[w] = true
+	}
+	return k
+}
+
+// 複数の予約語をまとめる
+func (k Keywords) Merge(others ...Keywords) Keywords {
+	r := Keywords{}
+	for w := range k {
+		r[w] = true
+	}
+	for _, o := range others {
+		for w := range o {
+			r[w] = true
+		}
+	}
+	return r
+}
+
+var CppKeywords = newKeywords(
+	"alignas", "alignof", "and", "and_eq", "asm", "auto", "bitand", "bitor", "bool", "break",
+	"case", "catch", "char", "char8_t", "char16_t", "char32_t", "class", "compl", "concept", "const",
+	"consteval", "constexpr", "constinit", "const_cast", "continue", "co_await", "co_return", "co_yield",
+	"decltype", "default", "delete", "do", "double", "dynamic_cast", "else", "enum", "explicit", "export",
+	"extern", "false", "float", "for", "friend", "goto", "if", "inline", "int", "long", "mutable",
+	"namespace", "new", "noexcept", "not", "not_eq", "nullptr", "operator", "or", "or_eq", "private",
+	"protected", "public", "register", "reinterpret_cast", "requires", "return", "short", "signed",
+	"sizeof", "static", "static_assert", "static_cast", "struct", "switch", "template", "this",
+	"thread_local", "throw", "true", "try", "typedef", "typeid", "typename", "union", "unsigned",
+	"using", "virtual", "void", "volatile", "wchar_t", "while", "xor", "xor_eq",
+)
+
+// stdbool.h のマクロも含む
+var CKeywords = newKeywords(
+	"auto", "break", "case", "char", "const", "continue", "default", "do", "double", "else", "enum",
+	"extern", "float", "for", "goto", "if", "inline", "int", "long", "register", "restrict", "return",
+	"short", "signed", "sizeof", "static", "struct", "switch", "typedef", "union", "unsigned", "void",
+	"volatile", "while", "bool", "true", "false",
+)
+
+var CSharpKeywords = newKeywords(
+	"abstract", "as", "base", "bool", "break", "byte", "case", "catch", "char", "checked", "class",
+	"const", "continue", "decimal", "default", "delegate", "do", "double", "else", "enum", "event",
+	"explicit", "extern", "false", "finally", "fixed", "float", "for", "foreach", "goto", "if",
+	"implicit", "in", "int", "interface", "internal", "is", "lock", "long", "namespace", "new", "null",
+	"object", "operator", "out", "override", "params", "private", "protected", "public", "readonly",
+	"ref", "return", "sbyte", "sealed", "short", "sizeof", "stackalloc", "static", "string", "struct",
+	"switch", "this", "throw", "true", "try", "typeof", "uint", "ulong", "unchecked", "unsafe",
+	"ushort", "using", "virtual", "void", "volatile", "while",
+)
+
+// 型名や import のエイリアスに使えない名前（strict mode の予約語と組み込みの型名を含む）
+var TypeScriptKeywords = newKeywords(
+	"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do",
+	"else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "import", "in",
+	"instanceof", "new", "null", "return", "super", "switch", "this", "throw", "true", "try", "typeof",
+	"var", "void", "while", "with", "implements", "interface", "let", "package", "private",
+	"protected", "public", "static", "yield", "await", "any", "bigint", "boolean", "never", "number",
+	"object", "string", "symbol", "undefined", "unknown",
+)
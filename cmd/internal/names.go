@@ -0,0 +1,35 @@
+package internal
+
+import "fmt"
+
+// 生成するコード上の識別子が衝突していないかを調べるためのスコープ
+//
+// proto 上では別の名前でも、各言語の命名規則に変換すると同じ識別子になることがある
+// （a.b.C と a_b.C、ネストした Foo.Bar とトップレベルの Foo_Bar など）ので、
+// 各ジェネレータはコードを生成する前に、生成する識別子をスコープごとに登録して衝突を検出する
+type NameScope struct {
+	names map[string]fmt.Stringer
+}
+
+// name を element が生成する識別子として登録する
+// 別の要素が既に同じ識別子を登録していた場合はエラーを返す
+func (s *NameScope) Add(name string, element fmt.Stringer) error {
+	if s.names == nil {
+		s.names = make(map[string]fmt.Stringer)
+	}
+	if prev, ok := s.names[name]; ok {
+		if prev.String() == element.String() {
+			return nil
+		}
+		return fmt.Errorf("identifier collision: %s and %s both generate %s", prev, element, name)
+	}
+	s.names[name] = element
+	return nil
+}
+
+// 名前空間など、proto の要素に対応しない識別子を登録する時に使う
+type Namespace string
+
+func (n Namespace) String() string {
+	return "namespace " + string(n)
+}
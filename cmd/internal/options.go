@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type optionSpec struct {
+	isBool bool
+	set    func(value string) error
+}
+
+// プラグインパラメータ（--jsonif-*_opt=key=value,flag）の定義
+type OptionSet struct {
+	specs map[string]*optionSpec
+}
+
+func (s *OptionSet) add(name string, spec *optionSpec) {
+	if s.specs == nil {
+		s.specs = make(map[string]*optionSpec)
+	}
+	if _, ok := s.specs[name]; ok {
+		panic(fmt.Sprintf("option %s already defined", name))
+	}
+	s.specs[name] = spec
+}
+
+// key または key=true/false の形で指定できるフラグを定義する
+func (s *OptionSet) Bool(name string, p *bool) {
+	s.add(name, &optionSpec{
+		isBool: true,
+		set: func(value string) error {
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for parameter %s", value, name)
+			}
+			*p = v
+			return nil
+		},
+	})
+}
+
+// key=value の形で指定する文字列を定義する
+// choices を指定した場合、それ以外の値はエラーになる
+func (s *OptionSet) String(name string, p *string, choices ...string) {
+	s.add(name, &optionSpec{
+		isBool: false,
+		set: func(value string) error {
+			if len(choices) != 0 {
+				found := false
+				for _, c := range choices {
+					if c == value {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return fmt.Errorf("invalid value %q for parameter %s (expected one of %s)", value, name, strings.Join(choices, ", "))
+				}
+			}
+			*p = value
+			return nil
+		},
+	})
+}
+
+func (s *OptionSet) names() []string {
+	var names []string
+	for name := range s.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CodeGeneratorRequest.Parameter をパースして、定義済みの変数に値を設定する
+func (s *OptionSet) Parse(parameter string) error {
+	for _, param := range strings.Split(parameter, ",") {
+		param = strings.TrimSpace(param)
+		if len(param) == 0 {
+			continue
+		}
+		name, value, hasValue := strings.Cut(param, "=")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		spec, ok := s.specs[name]
+		if !ok {
+			return fmt.Errorf("unknown parameter %s (available: %s)", name, strings.Join(s.names(), ", "))
+		}
+		if !hasValue {
+			if !spec.isBool {
+				return fmt.Errorf("parameter %s requires a value", name)
+			}
+			value = "true"
+		}
+		if err := spec.set(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 全プラグイン共通のパラメータ
+type CommonOptions struct {
+	// file_to_generate に含まれない依存ファイルも出力する
+	IncludeImports bool
+	// protobuf 標準の JSON マッピング（lowerCamelCase のキー、文字列の int64、enum の名前など）で読み書きする
+	Canonical bool
+	// enum を値の名前の文字列で読み書きする（jsonif_file_enum_as_name や jsonif_enum_as_name の方が優先される）
+	EnumAsName bool
+	// oneof の設定されているフィールドだけを読み書きする（jsonif_message_oneof_active_only や jsonif_oneof_active_only の方が優先される）
+	OneofActiveOnly bool
+	// JSON から読み込む際に、知らないキーや型の違う値をエラーにする（jsonif_message_strict の方が優先される）
+	Strict bool
+	// bytes を URL セーフな base64（パディング無し）で出力する
+	Base64URL bool
+}
+
+func (o *CommonOptions) Register(s *OptionSet) {
+	s.Bool("include_imports", &o.IncludeImports)
+	s.Bool("canonical", &o.Canonical)
+	s.Bool("enum_as_name", &o.EnumAsName)
+	s.Bool("oneof_active_only", &o.OneofActiveOnly)
+	s.Bool("strict", &o.Strict)
+	s.Bool("base64url", &o.Base64URL)
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestOptionSetParse(t *testing.T) {
+	cases := []struct {
+		parameter string
+		flag      bool
+		backend   string
+		err       string
+	}{
+		{"", false, "", ""},
+		{"flag", true, "", ""},
+		{"flag=false,backend=boost", false, "boost", ""},
+		{" flag , backend = nlohmann ", true, "nlohmann", ""},
+		{"backend", false, "", "parameter backend requires a value"},
+		{"backend=x", false, "", `invalid value "x" for parameter backend (expected one of boost, nlohmann)`},
+		{"flag=x", false, "", `invalid value "x" for parameter flag`},
+		{"foo=1", false, "", "unknown parameter foo (available: backend, flag)"},
+	}
+	for _, c := range cases {
+		var flag bool
+		var backend string
+		s := &OptionSet{}
+		s.Bool("flag", &flag)
+		s.String("backend", &backend, "boost", "nlohmann")
+		err := s.Parse(c.parameter)
+		if len(c.err) != 0 {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("Parse(%q): error = %v, want %q", c.parameter, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error %v", c.parameter, err)
+			continue
+		}
+		if flag != c.flag || backend != c.backend {
+			t.Errorf("Parse(%q): got (%v, %q), want (%v, %q)", c.parameter, flag, backend, c.flag, c.backend)
+		}
+	}
+}
+
+func TestFilesToGenerate(t *testing.T) {
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"b.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			{Name: proto.String("a.proto")},
+			{Name: proto.String("b.proto")},
+		},
+	}
+	files := FilesToGenerate(req, &CommonOptions{})
+	if len(files) != 1 || *files[0].Name != "b.proto" {
+		t.Errorf("FilesToGenerate: got %v", files)
+	}
+	files = FilesToGenerate(req, &CommonOptions{IncludeImports: true})
+	if len(files) != 2 {
+		t.Errorf("FilesToGenerate with include_imports: got %v", files)
+	}
+}
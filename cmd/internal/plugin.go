@@ -65,18 +65,8 @@ type Dep struct {
 	Removed bool
 }
 
-type PluginFunc = func(*pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error)
-
-func RunPlugin(gen PluginFunc) error {
-	in, err := ioutil.ReadAll(os.Stdin)
-	if err != nil {
-		return err
-	}
-	req := &pluginpb.CodeGeneratorRequest{}
-	if err := proto.Unmarshal(in, req); err != nil {
-		return err
-	}
-
+// weak な依存や定義の無いファイルを取り除き、proto3 以外のファイルが含まれていたらエラーにする
+func PrepareRequest(req *pluginpb.CodeGeneratorRequest) error {
 	// weak なインポートだったら依存先を見ないようにする
 
 	m := make(map[string]*Dep)
@@ -144,11 +134,12 @@ func RunPlugin(gen PluginFunc) error {
 		}
 	}
 
-	resp, err := gen(req)
-	if err != nil {
-		return err
-	}
+	return nil
+}
+
+type PluginFunc = func(*pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error)
 
+func writeResponse(resp *pluginpb.CodeGeneratorResponse) error {
 	out, err := proto.Marshal(resp)
 	if err != nil {
 		return err
@@ -158,3 +149,50 @@ func RunPlugin(gen PluginFunc) error {
 	}
 	return nil
 }
+
+func RunPlugin(opts *OptionSet, gen PluginFunc) error {
+	in, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(in, req); err != nil {
+		return err
+	}
+
+	// パラメータの間違いは protoc 側でエラーとして表示させる
+	if err := opts.Parse(req.GetParameter()); err != nil {
+		return writeResponse(&pluginpb.CodeGeneratorResponse{
+			Error: proto.String(err.Error()),
+		})
+	}
+
+	if err := PrepareRequest(req); err != nil {
+		return err
+	}
+
+	resp, err := gen(req)
+	if err != nil {
+		return err
+	}
+
+	return writeResponse(resp)
+}
+
+// コードを出力するファイルの一覧を返す
+// 通常は file_to_generate に指定されたファイルのみで、include_imports が指定されている場合は依存ファイルも含める
+func FilesToGenerate(req *pluginpb.CodeGeneratorRequest, opts *CommonOptions) []*descriptorpb.FileDescriptorProto {
+	if opts.IncludeImports {
+		return req.ProtoFile
+	}
+	var files []*descriptorpb.FileDescriptorProto
+	for _, file := range req.ProtoFile {
+		for _, name := range req.FileToGenerate {
+			if *file.Name == name {
+				files = append(files, file)
+				break
+			}
+		}
+	}
+	return files
+}
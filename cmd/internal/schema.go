@@ -0,0 +1,564 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/generated"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// 各言語のジェネレータが共通して利用する、型の参照や jsonif のオプションを解決済みのモデル
+
+type Schema struct {
+	Files []*File
+
+	files    map[string]*File
+	messages map[string]*Message
+	enums    map[string]*Enum
+	// protobuf 標準の JSON マッピングで読み書きする
+	canonical bool
+	// enum を値の名前で読み書きする
+	enumAsName bool
+	// oneof の設定されているフィールドだけを読み書きする
+	oneofActiveOnly bool
+	// 知らないキーや型の違う値をエラーにする
+	strict bool
+	// bytes を URL セーフな base64 で出力する
+	base64url bool
+}
+
+type File struct {
+	Desc *descriptorpb.FileDescriptorProto
+	Name string
+	// パッケージ指定が無い場合は空文字
+	Package string
+	// リクエストに含まれている依存ファイル
+	Dependencies []*File
+	Enums        []*Enum
+	Messages     []*Message
+}
+
+type Message struct {
+	Desc *descriptorpb.DescriptorProto
+	File *File
+	// ネストしている場合は親のメッセージ
+	Parent *Message
+	Name   string
+	// パッケージを含めた名前（先頭に . は付かない）
+	FullName string
+	Fields   []*Field
+	// proto3 optional のための oneof も含む
+	Oneofs   []*Oneof
+	Enums    []*Enum
+	Messages []*Message
+	// proto に書かれたコメントを行ごとに分割したもの
+	Comments []string
+	// map<K, V> のために作られた XxxEntry メッセージの場合 true
+	MapEntry bool
+	// option deprecated = true が指定されている場合 true
+	Deprecated bool
+
+	Optimistic       bool
+	DiscardIfDefault bool
+	NoSerializer     bool
+	NoDeserializer   bool
+	// 知らないキーとその値を保持して、JSON に変換する際にそのまま出力する
+	KeepUnknownFields bool
+	// JSON から読み込む際に、知らないキーや型の違う値をエラーにする
+	Strict bool
+}
+
+type Field struct {
+	Desc   *descriptorpb.FieldDescriptorProto
+	Parent *Message
+	Name   string
+	Number int32
+	Type   descriptorpb.FieldDescriptorProto_Type
+	// repeated の場合 true
+	Repeated bool
+	// proto3 optional の場合 true
+	Optional bool
+	// oneof（proto3 optional のための oneof も含む）に属している場合はその oneof
+	Oneof *Oneof
+	// メッセージ型の場合は参照先のメッセージ
+	Message *Message
+	// enum 型の場合は参照先の enum
+	Enum *Enum
+	// map<K, V> の場合はエントリのキーと値のフィールド（Repeated も true になる）
+	MapKey   *Field
+	MapValue *Field
+	// JSON のキー名。jsonif_name が指定されていればその名前になる
+	// canonical の場合は json_name（指定が無ければ lowerCamelCase）になる
+	JsonKey  string
+	Comments []string
+	// [deprecated = true] が指定されている場合 true
+	Deprecated bool
+
+	Optimistic       bool
+	DiscardIfDefault bool
+	// int64, uint64 などの値を JSON の文字列にする（読み込む時は数値も受け付ける）
+	// ラッパー型の場合は値の型に従う
+	Int64AsString bool
+	// bytes の値を base64 の文字列にする
+	// ラッパー型の場合は値の型に従う
+	Base64 bool
+	// Base64 の場合に、URL セーフな base64（パディング無し）で出力する
+	// 読み込む時はどちらの base64 も受け付ける
+	Base64URL bool
+	// jsonif_default が指定されている場合 true
+	HasDefault bool
+	// jsonif_default の値を型に合わせて正規化したもの（enum の場合は値の番号）
+	Default string
+	// enum 型で jsonif_default が指定されている場合はその値
+	DefaultEnum *EnumValue
+	// 検証ルールが指定されていなければ nil
+	Validation *Validation
+}
+
+type Oneof struct {
+	Desc   *descriptorpb.OneofDescriptorProto
+	Parent *Message
+	Name   string
+	Fields []*Field
+	// proto3 optional のために作られた oneof の場合 true
+	Synthetic bool
+	Comments  []string
+	// 設定されているフィールドだけを出力して、<oneof>_case は出力しない
+	// 読み込む時は、どのフィールドのキーがあるかで判断する（互換性のため <oneof>_case があればそれを使う）
+	// Synthetic の場合は常に true で、<oneof>_case は読み込まない
+	ActiveOnly bool
+}
+
+type Enum struct {
+	Desc   *descriptorpb.EnumDescriptorProto
+	File   *File
+	Parent *Message
+	Name   string
+	// パッケージを含めた名前（先頭に . は付かない）
+	FullName string
+	Values   []*EnumValue
+	Comments []string
+	// JSON では値の名前の文字列にする（読み込む時は数値も受け付ける）
+	AsName bool
+}
+
+type EnumValue struct {
+	Desc     *descriptorpb.EnumValueDescriptorProto
+	Parent   *Enum
+	Name     string
+	Number   int32
+	Comments []string
+	// [deprecated = true] が指定されている場合 true
+	Deprecated bool
+}
+
+func (m *Message) Parents() []*Message {
+	var parents []*Message
+	for p := m.Parent; p != nil; p = p.Parent {
+		parents = append([]*Message{p}, parents...)
+	}
+	return parents
+}
+
+func (e *Enum) Parents() []*Message {
+	if e.Parent == nil {
+		return nil
+	}
+	return append(e.Parent.Parents(), e.Parent)
+}
+
+func (m *Message) String() string {
+	return "message " + m.FullName
+}
+
+func (f *Field) String() string {
+	return "field " + f.Parent.FullName + "." + f.Name
+}
+
+func (o *Oneof) String() string {
+	return "oneof " + o.Parent.FullName + "." + o.Name
+}
+
+func (e *Enum) String() string {
+	return "enum " + e.FullName
+}
+
+func (v *EnumValue) String() string {
+	return "enum value " + v.Parent.FullName + "." + v.Name
+}
+
+// map<K, V> のフィールドかどうか
+func (f *Field) IsMap() bool {
+	return f.MapKey != nil
+}
+
+// メッセージ型のフィールドかどうか
+func (f *Field) IsMessage() bool {
+	return f.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE || f.Type == descriptorpb.FieldDescriptorProto_TYPE_GROUP
+}
+
+func getBoolOption(options proto.Message, xt protoreflect.ExtensionType) (bool, bool) {
+	if !proto.HasExtension(options, xt) {
+		return false, false
+	}
+	return proto.GetExtension(options, xt).(bool), true
+}
+
+// SourceCodeInfo の path をキーにしたコメント
+type commentSet map[string][]string
+
+// descriptor.proto のフィールド番号
+const (
+	fileMessageTypeTag   = 4
+	fileEnumTypeTag      = 5
+	messageFieldTag      = 2
+	messageNestedTypeTag = 3
+	messageEnumTypeTag   = 4
+	messageOneofDeclTag  = 8
+	enumValueTag         = 2
+)
+
+func newCommentSet(fd *descriptorpb.FileDescriptorProto) commentSet {
+	comments := commentSet{}
+	for _, loc := range fd.GetSourceCodeInfo().GetLocation() {
+		// 離れたコメント、先頭のコメント、末尾のコメントの順に、空行で区切って並べる
+		texts := append(append([]string{}, loc.GetLeadingDetachedComments()...), loc.GetLeadingComments(), loc.GetTrailingComments())
+		var lines []string
+		for _, text := range texts {
+			if len(strings.TrimSpace(text)) == 0 {
+				continue
+			}
+			if len(lines) != 0 {
+				lines = append(lines, "")
+			}
+			for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+				// "// foo" の場合は " foo" になっているので、先頭の空白を1つだけ取り除く
+				lines = append(lines, strings.TrimRight(strings.TrimPrefix(line, " "), " \t\r"))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		comments[pathKey(loc.Path)] = lines
+	}
+	return comments
+}
+
+func pathKey(path []int32) string {
+	return fmt.Sprint(path)
+}
+
+func appendPath(path []int32, xs ...int32) []int32 {
+	r := make([]int32, 0, len(path)+len(xs))
+	r = append(r, path...)
+	return append(r, xs...)
+}
+
+func (s *Schema) newEnum(desc *descriptorpb.EnumDescriptorProto, file *File, parent *Message, comments commentSet, path []int32) *Enum {
+	enum := &Enum{
+		Desc:     desc,
+		File:     file,
+		Parent:   parent,
+		Name:     *desc.Name,
+		Comments: comments[pathKey(path)],
+		AsName:   s.canonical || s.enumAsName,
+	}
+	// enum の設定、ファイルの設定、プラグインパラメータの順に優先する
+	if v, ok := getBoolOption(file.Desc.Options, generated.E_JsonifFileEnumAsName); ok {
+		enum.AsName = v
+	}
+	if v, ok := getBoolOption(desc.Options, generated.E_JsonifEnumAsName); ok {
+		enum.AsName = v
+	}
+	enum.FullName = qualify(file.Package, parent, enum.Name)
+	for i, v := range desc.Value {
+		enum.Values = append(enum.Values, &EnumValue{
+			Desc:       v,
+			Parent:     enum,
+			Name:       *v.Name,
+			Number:     *v.Number,
+			Comments:   comments[pathKey(appendPath(path, enumValueTag, int32(i)))],
+			Deprecated: v.GetOptions().GetDeprecated(),
+		})
+	}
+	s.enums[enum.FullName] = enum
+	return enum
+}
+
+func (s *Schema) newMessage(desc *descriptorpb.DescriptorProto, file *File, parent *Message, comments commentSet, path []int32) *Message {
+	msg := &Message{
+		Desc:     desc,
+		File:     file,
+		Parent:   parent,
+		Name:     *desc.Name,
+		Comments: comments[pathKey(path)],
+	}
+	msg.FullName = qualify(file.Package, parent, msg.Name)
+	msg.MapEntry = desc.GetOptions().GetMapEntry()
+	msg.Deprecated = desc.GetOptions().GetDeprecated()
+	msg.Optimistic, _ = getBoolOption(desc.Options, generated.E_JsonifMessageOptimistic)
+	msg.DiscardIfDefault, _ = getBoolOption(desc.Options, generated.E_JsonifMessageDiscardIfDefault)
+	msg.NoSerializer, _ = getBoolOption(desc.Options, generated.E_JsonifNoSerializer)
+	msg.NoDeserializer, _ = getBoolOption(desc.Options, generated.E_JsonifNoDeserializer)
+	msg.KeepUnknownFields, _ = getBoolOption(desc.Options, generated.E_JsonifMessageKeepUnknownFields)
+	msg.Strict = s.strict
+	if v, ok := getBoolOption(desc.Options, generated.E_JsonifMessageStrict); ok {
+		msg.Strict = v
+	}
+
+	for i, enum := range desc.EnumType {
+		msg.Enums = append(msg.Enums, s.newEnum(enum, file, msg, comments, appendPath(path, messageEnumTypeTag, int32(i))))
+	}
+	for i, nested := range desc.NestedType {
+		msg.Messages = append(msg.Messages, s.newMessage(nested, file, msg, comments, appendPath(path, messageNestedTypeTag, int32(i))))
+	}
+	// oneof の設定、メッセージの設定、プラグインパラメータの順に優先する
+	oneofActiveOnly := s.canonical || s.oneofActiveOnly
+	if v, ok := getBoolOption(desc.Options, generated.E_JsonifMessageOneofActiveOnly); ok {
+		oneofActiveOnly = v
+	}
+	for i, oneof := range desc.OneofDecl {
+		activeOnly := oneofActiveOnly
+		if v, ok := getBoolOption(oneof.Options, generated.E_JsonifOneofActiveOnly); ok {
+			activeOnly = v
+		}
+		msg.Oneofs = append(msg.Oneofs, &Oneof{
+			Desc:       oneof,
+			Parent:     msg,
+			Name:       *oneof.Name,
+			Comments:   comments[pathKey(appendPath(path, messageOneofDeclTag, int32(i)))],
+			ActiveOnly: activeOnly,
+		})
+	}
+	for i, fd := range desc.Field {
+		field := &Field{
+			Desc:       fd,
+			Parent:     msg,
+			Name:       *fd.Name,
+			Number:     *fd.Number,
+			Type:       *fd.Type,
+			Repeated:   *fd.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+			Optional:   fd.Proto3Optional != nil && *fd.Proto3Optional,
+			JsonKey:    ToSnakeCase(*fd.Name),
+			Comments:   comments[pathKey(appendPath(path, messageFieldTag, int32(i)))],
+			Deprecated: fd.GetOptions().GetDeprecated(),
+		}
+		if fd.Options != nil && proto.HasExtension(fd.Options, generated.E_JsonifName) {
+			field.JsonKey = proto.GetExtension(fd.Options, generated.E_JsonifName).(string)
+		}
+		// フィールドのオプションが指定されていなければメッセージのオプションに従う
+		field.Optimistic = msg.Optimistic
+		if v, ok := getBoolOption(fd.Options, generated.E_JsonifOptimistic); ok {
+			field.Optimistic = v
+		}
+		field.DiscardIfDefault = msg.DiscardIfDefault
+		if v, ok := getBoolOption(fd.Options, generated.E_JsonifDiscardIfDefault); ok {
+			field.DiscardIfDefault = v
+		}
+		if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			field.Base64 = true
+			field.Base64URL = s.base64url
+		}
+		if s.canonical {
+			s.applyCanonical(field)
+		}
+		if fd.OneofIndex != nil {
+			field.Oneof = msg.Oneofs[*fd.OneofIndex]
+			field.Oneof.Fields = append(field.Oneof.Fields, field)
+			if field.Optional {
+				// optional はキーの有無で設定されているかを表すので、常に設定されているフィールドだけを出力する
+				field.Oneof.Synthetic = true
+				field.Oneof.ActiveOnly = true
+			}
+		}
+		msg.Fields = append(msg.Fields, field)
+	}
+	s.messages[msg.FullName] = msg
+	return msg
+}
+
+// protobuf 標準の JSON マッピングに合わせる
+// キーが無ければデフォルト値として扱い、デフォルト値は出力しない
+func (s *Schema) applyCanonical(field *Field) {
+	if field.Desc.JsonName != nil && !proto.HasExtension(field.Desc.Options, generated.E_JsonifName) {
+		field.JsonKey = *field.Desc.JsonName
+	}
+	field.Optimistic = true
+	field.DiscardIfDefault = true
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		field.Int64AsString = true
+	}
+}
+
+func qualify(pkg string, parent *Message, name string) string {
+	if parent != nil {
+		return parent.FullName + "." + name
+	}
+	if len(pkg) == 0 {
+		return name
+	}
+	return pkg + "." + name
+}
+
+func (s *Schema) resolveFields(msg *Message) error {
+	for _, field := range msg.Fields {
+		switch field.Type {
+		case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+			descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+			m, ok := s.messages[trimDot(*field.Desc.TypeName)]
+			if !ok {
+				return fmt.Errorf("%s.%s: type %s not found", msg.FullName, field.Name, *field.Desc.TypeName)
+			}
+			field.Message = m
+			if m.MapEntry && field.Repeated {
+				field.MapKey = m.Fields[0]
+				field.MapValue = m.Fields[1]
+			}
+			if m.WellKnownType() == WellKnownWrapper {
+				field.Int64AsString = m.WrapperValue().Int64AsString
+				field.Base64 = m.WrapperValue().Base64
+				field.Base64URL = m.WrapperValue().Base64URL
+			}
+		case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+			e, ok := s.enums[trimDot(*field.Desc.TypeName)]
+			if !ok {
+				return fmt.Errorf("%s.%s: type %s not found", msg.FullName, field.Name, *field.Desc.TypeName)
+			}
+			// NullValue は google.protobuf.Value の中でだけ扱える
+			if e.FullName == nullValueName && msg.WellKnownType() == NotWellKnown {
+				return fmt.Errorf("%s.%s: %s is not supported, use google.protobuf.Value instead", msg.FullName, field.Name, nullValueName)
+			}
+			field.Enum = e
+		}
+		if err := resolveDefault(field); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if err := s.resolveFields(nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func trimDot(name string) string {
+	if len(name) != 0 && name[0] == '.' {
+		return name[1:]
+	}
+	return name
+}
+
+// CodeGeneratorRequest.ProtoFile からモデルを作る
+// files は依存ファイルが先に来るように並んでいる必要がある
+func NewSchema(files []*descriptorpb.FileDescriptorProto, opts *CommonOptions) (*Schema, error) {
+	s := &Schema{
+		files:           make(map[string]*File),
+		messages:        make(map[string]*Message),
+		enums:           make(map[string]*Enum),
+		canonical:       opts.Canonical,
+		enumAsName:      opts.EnumAsName,
+		oneofActiveOnly: opts.OneofActiveOnly,
+		strict:          opts.Strict,
+		base64url:       opts.Base64URL,
+	}
+	for _, fd := range files {
+		file := &File{
+			Desc:    fd,
+			Name:    *fd.Name,
+			Package: fd.GetPackage(),
+		}
+		// ファイルが存在してない可能性もあるので、存在するものだけ設定する
+		for _, dep := range fd.Dependency {
+			if f, ok := s.files[dep]; ok {
+				file.Dependencies = append(file.Dependencies, f)
+			}
+		}
+		comments := newCommentSet(fd)
+		for i, enum := range fd.EnumType {
+			file.Enums = append(file.Enums, s.newEnum(enum, file, nil, comments, []int32{fileEnumTypeTag, int32(i)}))
+		}
+		for i, desc := range fd.MessageType {
+			file.Messages = append(file.Messages, s.newMessage(desc, file, nil, comments, []int32{fileMessageTypeTag, int32(i)}))
+		}
+		s.Files = append(s.Files, file)
+		s.files[file.Name] = file
+	}
+	for _, file := range s.Files {
+		for _, msg := range file.Messages {
+			if err := s.resolveFields(msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, file := range s.Files {
+		for _, msg := range file.Messages {
+			if err := s.resolveValidations(msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// 出力対象のファイルをモデルに変換したものを返す
+func (s *Schema) FilesToGenerate(req *pluginpb.CodeGeneratorRequest, opts *CommonOptions) []*File {
+	var files []*File
+	for _, fd := range FilesToGenerate(req, opts) {
+		files = append(files, s.files[*fd.Name])
+	}
+	return files
+}
+
+// トップレベルのメッセージを、フィールドで使っている同じファイルのメッセージが先に来るように並べる
+// C++ や C の構造体は使う前に定義する必要があるので、後ろで宣言されたメッセージを使っていても定義できるようにする
+// 循環している場合は、宣言の順序で先に来るものから並べる
+func (f *File) MessagesInDefinitionOrder() []*Message {
+	var r []*Message
+	visited := map[*Message]bool{}
+	var visit func(msg *Message)
+	visit = func(msg *Message) {
+		if visited[msg] {
+			return
+		}
+		visited[msg] = true
+		for _, dep := range msg.topLevelDependencies(msg) {
+			visit(dep)
+		}
+		r = append(r, msg)
+	}
+	for _, msg := range f.Messages {
+		visit(msg)
+	}
+	return r
+}
+
+// m とネストしたメッセージのフィールドで使っている、top 以外の同じファイルのトップレベルのメッセージ
+func (m *Message) topLevelDependencies(top *Message) []*Message {
+	var deps []*Message
+	for _, field := range m.Fields {
+		if !field.IsMessage() || field.Message.File != m.File {
+			continue
+		}
+		dep := field.Message
+		if parents := dep.Parents(); len(parents) != 0 {
+			dep = parents[0]
+		}
+		if dep != top {
+			deps = append(deps, dep)
+		}
+	}
+	for _, nested := range m.Messages {
+		deps = append(deps, nested.topLevelDependencies(top)...)
+	}
+	return deps
+}
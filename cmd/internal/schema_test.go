@@ -0,0 +1,435 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/generated"
+	"github.com/melpon/protoc-gen-jsonif/cmd/internal"
+	"github.com/melpon/protoc-gen-jsonif/cmd/internal/goldentest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newSchema(t *testing.T, files ...string) *internal.Schema {
+	t.Helper()
+	return newSchemaWithOptions(t, &internal.CommonOptions{}, files...)
+}
+
+func newSchemaWithOptions(t *testing.T, opts *internal.CommonOptions, files ...string) *internal.Schema {
+	t.Helper()
+	req := goldentest.NewRequest(t, "", files...)
+	schema, err := internal.NewSchema(req.ProtoFile, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestSchemaNested(t *testing.T) {
+	schema := newSchema(t, "nested.proto")
+	file := schema.Files[len(schema.Files)-1]
+	if file.Package != "nested.nested" {
+		t.Fatalf("package = %q", file.Package)
+	}
+	test := file.Messages[0]
+	nested := test.Messages[0]
+	if nested.FullName != "nested.nested.Test.NestedMessage" || nested.Parent != test {
+		t.Errorf("nested message = %s", nested.FullName)
+	}
+	if parents := test.Enums[0].Parents(); len(parents) != 1 || parents[0] != test {
+		t.Errorf("nested enum parents = %v", parents)
+	}
+	test2 := file.Messages[1]
+	if test2.Fields[1].Message != nested {
+		t.Errorf("Test2.nested_message does not refer to Test.NestedMessage")
+	}
+	if test2.Fields[2].Enum != test.Enums[0] {
+		t.Errorf("Test2.nested_enum does not refer to Test.NestedEnum")
+	}
+}
+
+func TestSchemaOptional(t *testing.T) {
+	schema := newSchema(t, "optional.proto")
+	file := schema.Files[len(schema.Files)-1]
+	test := file.Messages[1]
+	for _, field := range test.Fields {
+		if !field.Optional || field.Oneof == nil || !field.Oneof.Synthetic {
+			t.Errorf("%s is not a proto3 optional field", field.Name)
+		}
+		// optional はキーの有無で設定されているかを表す
+		if field.Oneof != nil && !field.Oneof.ActiveOnly {
+			t.Errorf("%s: ActiveOnly = false, want true", field.Name)
+		}
+	}
+}
+
+func TestSchemaOptions(t *testing.T) {
+	cases := []struct {
+		file    string
+		field   string
+		jsonKey string
+		optim   bool
+	}{
+		{"jsonfield.proto", "field", "test", false},
+		{"jsonfield.proto", "hoge_field", "hoge_field", false},
+		{"optimistic.proto", "a", "a", true},
+		{"optimistic.proto", "b", "b", false},
+	}
+	for _, c := range cases {
+		schema := newSchema(t, c.file)
+		msg := schema.Files[len(schema.Files)-1].Messages[0]
+		var field *internal.Field
+		for _, f := range msg.Fields {
+			if f.Name == c.field {
+				field = f
+			}
+		}
+		if field == nil {
+			t.Fatalf("%s: field %s not found", c.file, c.field)
+		}
+		if field.JsonKey != c.jsonKey {
+			t.Errorf("%s: %s.JsonKey = %q, want %q", c.file, c.field, field.JsonKey, c.jsonKey)
+		}
+		if field.Optimistic != c.optim {
+			t.Errorf("%s: %s.Optimistic = %v, want %v", c.file, c.field, field.Optimistic, c.optim)
+		}
+	}
+}
+
+func TestSchemaMap(t *testing.T) {
+	schema := newSchema(t, "map.proto")
+	file := schema.Files[len(schema.Files)-1]
+	test := file.Messages[1]
+	for _, nested := range test.Messages {
+		if !nested.MapEntry {
+			t.Errorf("%s is not a map entry", nested.FullName)
+		}
+	}
+	d := test.Fields[3]
+	if !d.IsMap() || d.MapKey.Name != "key" || d.MapValue.Message != file.Messages[0] {
+		t.Errorf("Test.d is not map<string, Message>")
+	}
+}
+
+func TestSchemaCanonical(t *testing.T) {
+	schema := newSchemaWithOptions(t, &internal.CommonOptions{Canonical: true}, "canonical.proto", "canonical_bytes.proto")
+	fields := map[string]*internal.Field{}
+	for _, file := range schema.Files {
+		for _, msg := range file.Messages {
+			for _, f := range msg.Fields {
+				fields[msg.FullName+"."+f.Name] = f
+			}
+		}
+	}
+	cases := []struct {
+		field   string
+		jsonKey string
+		int64   bool
+		base64  bool
+	}{
+		{"canonical.Test.int_value", "intValue", false, false},
+		{"canonical.Test.int64_value", "int64Value", true, false},
+		{"canonical.Test.uint64_value", "uint64Value", true, false},
+		{"canonical.Test.renamed", "customName", false, false},
+		{"canonical.Test.wrapped_int64", "wrappedInt64", true, false},
+		{"canonical.BytesTest.data", "data", false, true},
+		{"canonical.BytesTest.wrapped_data", "wrappedData", false, true},
+	}
+	for _, c := range cases {
+		field := fields[c.field]
+		if field == nil {
+			t.Fatalf("field %s not found", c.field)
+		}
+		if field.JsonKey != c.jsonKey {
+			t.Errorf("%s.JsonKey = %q, want %q", c.field, field.JsonKey, c.jsonKey)
+		}
+		if field.Int64AsString != c.int64 {
+			t.Errorf("%s.Int64AsString = %v, want %v", c.field, field.Int64AsString, c.int64)
+		}
+		if field.Base64 != c.base64 {
+			t.Errorf("%s.Base64 = %v, want %v", c.field, field.Base64, c.base64)
+		}
+		if !field.Optimistic || !field.DiscardIfDefault {
+			t.Errorf("%s is not optimistic and discard_if_default", c.field)
+		}
+	}
+	if color := fields["canonical.Test.color"]; !color.Enum.AsName {
+		t.Errorf("canonical.Color is not serialized as name")
+	}
+	if kind := fields["canonical.Test.kind_name"]; !kind.Oneof.ActiveOnly {
+		t.Errorf("canonical.Test.kind is not active only")
+	}
+
+	// canonical でない場合は何も変わらない
+	schema = newSchema(t, "canonical.proto")
+	test := schema.Files[len(schema.Files)-1].Messages[1]
+	if f := test.Fields[1]; f.JsonKey != "int64_value" || f.Int64AsString || f.DiscardIfDefault {
+		t.Errorf("int64_value = %+v", f)
+	}
+	if test.Fields[6].Enum.AsName || test.Oneofs[0].ActiveOnly {
+		t.Errorf("canonical options are applied without canonical")
+	}
+}
+
+func TestSchemaEnumAsName(t *testing.T) {
+	cases := []struct {
+		opts   *internal.CommonOptions
+		file   string
+		asName map[string]bool
+	}{
+		{&internal.CommonOptions{}, "enum_name.proto", map[string]bool{"enum_name.Color": true, "enum_name.Number": false, "enum_name.Test.Nested": true}},
+		{&internal.CommonOptions{}, "enumpb.proto", map[string]bool{"enumpb.Data": false}},
+		{&internal.CommonOptions{EnumAsName: true}, "enumpb.proto", map[string]bool{"enumpb.Data": true}},
+		{&internal.CommonOptions{EnumAsName: true}, "enum_name.proto", map[string]bool{"enum_name.Number": false}},
+	}
+	for _, c := range cases {
+		schema := newSchemaWithOptions(t, c.opts, c.file)
+		file := schema.Files[len(schema.Files)-1]
+		enums := append([]*internal.Enum{}, file.Enums...)
+		for _, msg := range file.Messages {
+			enums = append(enums, msg.Enums...)
+		}
+		for _, enum := range enums {
+			want, ok := c.asName[enum.FullName]
+			if ok && enum.AsName != want {
+				t.Errorf("%s: %s.AsName = %v, want %v", c.file, enum.FullName, enum.AsName, want)
+			}
+		}
+	}
+}
+
+func TestSchemaOneofActiveOnly(t *testing.T) {
+	cases := []struct {
+		opts       *internal.CommonOptions
+		file       string
+		activeOnly map[string]bool
+	}{
+		{&internal.CommonOptions{}, "oneof_active_only.proto", map[string]bool{"Test.kind": true, "Test.legacy": false, "Test._opt": true, "Test2.kind": true}},
+		{&internal.CommonOptions{}, "oneof.proto", map[string]bool{"Test.test_oneof": false}},
+		{&internal.CommonOptions{OneofActiveOnly: true}, "oneof.proto", map[string]bool{"Test.test_oneof": true}},
+		{&internal.CommonOptions{OneofActiveOnly: true}, "oneof_active_only.proto", map[string]bool{"Test.legacy": false}},
+	}
+	for _, c := range cases {
+		schema := newSchemaWithOptions(t, c.opts, c.file)
+		for _, msg := range schema.Files[len(schema.Files)-1].Messages {
+			for _, oneof := range msg.Oneofs {
+				name := msg.Name + "." + oneof.Name
+				want, ok := c.activeOnly[name]
+				if ok && oneof.ActiveOnly != want {
+					t.Errorf("%s: %s.ActiveOnly = %v, want %v", c.file, name, oneof.ActiveOnly, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSchemaNullValue(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("null_value.proto"),
+		Package:    proto.String("null_value"),
+		Dependency: []string{"google/protobuf/struct.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Test"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("n"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+				TypeName: proto.String(".google.protobuf.NullValue"),
+			}},
+		}},
+		Syntax: proto.String("proto3"),
+	}
+	files := []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(structpb.File_google_protobuf_struct_proto), file}
+	_, err := internal.NewSchema(files, &internal.CommonOptions{})
+	if err == nil || !strings.Contains(err.Error(), "google.protobuf.NullValue is not supported") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestSchemaDefault(t *testing.T) {
+	schema := newSchema(t, "default_value.proto")
+	file := schema.Files[len(schema.Files)-1]
+	want := map[string]string{
+		"f":         "3",
+		"big":       "1e+30",
+		"i64":       "-9223372036854775808",
+		"s":         "hello \"jsonif\"\n",
+		"empty":     "",
+		"color":     "1",
+		"color_num": "2",
+	}
+	for _, field := range file.Messages[1].Fields {
+		if field.Name == "no_default" || field.Name == "inner" {
+			if field.HasDefault {
+				t.Errorf("%s.HasDefault = true", field.Name)
+			}
+			continue
+		}
+		if !field.HasDefault {
+			t.Errorf("%s.HasDefault = false", field.Name)
+		}
+		if v, ok := want[field.Name]; ok && field.Default != v {
+			t.Errorf("%s.Default = %q, want %q", field.Name, field.Default, v)
+		}
+		if field.Name == "color" && (field.DefaultEnum == nil || field.DefaultEnum.Name != "COLOR_RED") {
+			t.Errorf("%s.DefaultEnum = %v", field.Name, field.DefaultEnum)
+		}
+	}
+}
+
+func TestSchemaInvalidDefault(t *testing.T) {
+	cases := []struct {
+		typ   descriptorpb.FieldDescriptorProto_Type
+		label descriptorpb.FieldDescriptorProto_Label
+		value string
+		err   string
+	}{
+		{descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "2147483648", "value out of range"},
+		{descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "-1", "invalid syntax"},
+		{descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "inf", "must be finite"},
+		{descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "0x1p-2", "invalid syntax"},
+		{descriptorpb.FieldDescriptorProto_TYPE_BOOL, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "1", "must be true or false"},
+		{descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "a", "not supported for bytes fields"},
+		{descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, "1", "not supported for repeated"},
+	}
+	for _, c := range cases {
+		options := &descriptorpb.FieldOptions{}
+		proto.SetExtension(options, generated.E_JsonifDefault, c.value)
+		file := &descriptorpb.FileDescriptorProto{
+			Name:    proto.String("invalid_default.proto"),
+			Package: proto.String("invalid_default"),
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Test"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:    proto.String("v"),
+					Number:  proto.Int32(1),
+					Label:   c.label.Enum(),
+					Type:    c.typ.Enum(),
+					Options: options,
+				}},
+			}},
+			Syntax: proto.String("proto3"),
+		}
+		_, err := internal.NewSchema([]*descriptorpb.FileDescriptorProto{file}, &internal.CommonOptions{})
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s %q: err = %v, want %q", c.typ, c.value, err, c.err)
+		}
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	schema := newSchema(t, "validation.proto")
+	file := schema.Files[len(schema.Files)-1]
+	person, team, noRules := file.Messages[0], file.Messages[1], file.Messages[len(file.Messages)-1]
+	if !person.HasValidation() || !team.HasValidation() || noRules.HasValidation() {
+		t.Errorf("HasValidation = %v, %v, %v", person.HasValidation(), team.HasValidation(), noRules.HasValidation())
+	}
+	age := person.Fields[1].Validation
+	if age == nil || age.Min != "0" || age.Max != "150" || age.MinLen != -1 || age.MinItems != -1 {
+		t.Errorf("age.Validation = %+v", age)
+	}
+	tags := person.Fields[4].Validation
+	if tags == nil || tags.MinLen != 1 || tags.MaxItems != 3 || !tags.HasValueRules() {
+		t.Errorf("tags.Validation = %+v", tags)
+	}
+	// map の値の型に合わせて正規化する
+	limits := team.Fields[3].Validation
+	if limits == nil || limits.Min != "-10" || limits.Max != "10" {
+		t.Errorf("limits.Validation = %+v", limits)
+	}
+	if v := team.Fields[0].Validation; v == nil || !v.Required || v.HasValueRules() {
+		t.Errorf("leader.Validation = %+v", v)
+	}
+	if v := team.Fields[2].Validation; v != nil {
+		t.Errorf("by_name.Validation = %+v", v)
+	}
+}
+
+func TestSchemaReachesStrict(t *testing.T) {
+	schema := newSchema(t, "strict.proto")
+	file := schema.Files[len(schema.Files)-1]
+	// Loose は strict ではないが、strict な Person をフィールドに持つ
+	want := map[string]bool{"Person": true, "Address": false, "Config": true, "Loose": true}
+	for _, msg := range file.Messages {
+		if got := msg.ReachesStrict(); got != want[msg.Name] {
+			t.Errorf("%s.ReachesStrict() = %v, want %v", msg.Name, got, want[msg.Name])
+		}
+	}
+}
+
+func TestMessagesInDefinitionOrder(t *testing.T) {
+	schema := newSchema(t, "validation.proto", "nested.proto")
+	// League は後ろで宣言されている Club を使うので、Club の後になる
+	var names []string
+	for _, msg := range schema.Files[len(schema.Files)-2].MessagesInDefinitionOrder() {
+		names = append(names, msg.Name)
+	}
+	if got, want := strings.Join(names, ","), "Person,Team,Club,League,NoRules"; got != want {
+		t.Errorf("validation.proto = %s, want %s", got, want)
+	}
+	// 依存が無ければ宣言の順序のまま
+	file := schema.Files[len(schema.Files)-1]
+	for i, msg := range file.MessagesInDefinitionOrder() {
+		if msg != file.Messages[i] {
+			t.Errorf("nested.proto[%d] = %s, want %s", i, msg.Name, file.Messages[i].Name)
+		}
+	}
+}
+
+func TestSchemaInvalidValidation(t *testing.T) {
+	cases := []struct {
+		typ   descriptorpb.FieldDescriptorProto_Type
+		label descriptorpb.FieldDescriptorProto_Label
+		set   func(options *descriptorpb.FieldOptions)
+		err   string
+	}{
+		{descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifMin, "1")
+		}, "only supported for number fields"},
+		{descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifMax, "-1")
+		}, `invalid jsonif_max "-1"`},
+		{descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifMinLen, uint32(1))
+		}, "only supported for string and bytes fields"},
+		{descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifPattern, "^a")
+		}, "only supported for string fields"},
+		{descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifMaxItems, uint32(1))
+		}, "only supported for repeated and map fields"},
+		{descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifRequired, true)
+		}, "only supported for optional and message fields"},
+		{descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, func(o *descriptorpb.FieldOptions) {
+			proto.SetExtension(o, generated.E_JsonifEnumDefinedOnly, true)
+		}, "only supported for enum fields"},
+	}
+	for _, c := range cases {
+		options := &descriptorpb.FieldOptions{}
+		c.set(options)
+		file := &descriptorpb.FileDescriptorProto{
+			Name:    proto.String("invalid_validation.proto"),
+			Package: proto.String("invalid_validation"),
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Test"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:    proto.String("v"),
+					Number:  proto.Int32(1),
+					Label:   c.label.Enum(),
+					Type:    c.typ.Enum(),
+					Options: options,
+				}},
+			}},
+			Syntax: proto.String("proto3"),
+		}
+		_, err := internal.NewSchema([]*descriptorpb.FileDescriptorProto{file}, &internal.CommonOptions{})
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: err = %v, want %q", c.typ, err, c.err)
+		}
+	}
+}
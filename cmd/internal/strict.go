@@ -0,0 +1,40 @@
+package internal
+
+// strict なメッセージを読み込むかどうか
+// 自身が strict か、strict なメッセージを読み込むメッセージのフィールドを持っていれば true
+// strict ではないメッセージの中にある strict なメッセージも、一番外側のメッセージから検査して、
+// エラーの位置を一番外側のメッセージからの位置にするために使う
+func (m *Message) ReachesStrict() bool {
+	return m.reachesStrict(map[*Message]bool{})
+}
+
+func (m *Message) reachesStrict(visited map[*Message]bool) bool {
+	if visited[m] {
+		return false
+	}
+	visited[m] = true
+	if m.Strict {
+		return true
+	}
+	for _, field := range m.Fields {
+		value := field.ValueField()
+		if value.IsMessage() && value.WellKnownType() == NotWellKnown && value.Message.reachesStrict(visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// ファイルに strict なメッセージを読み込むメッセージがあるかどうか（ネストしたメッセージも含む）
+func (f *File) UsesStrict() bool {
+	return usesStrict(f.Messages)
+}
+
+func usesStrict(msgs []*Message) bool {
+	for _, msg := range msgs {
+		if msg.ReachesStrict() || usesStrict(msg.Messages) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/generated"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// フィールドの値の検証ルール（jsonif_min などのフィールドオプション）
+// repeated の場合は各要素、map の場合は各値に対するルールになる（MinItems, MaxItems を除く）
+type Validation struct {
+	// 数値の範囲（両端を含む）。値の型に合わせて正規化したもので、指定が無ければ空文字
+	Min string
+	Max string
+	// 文字列のコードポイント数（bytes の場合はバイト数）の範囲。指定が無ければ -1
+	MinLen int64
+	MaxLen int64
+	// 文字列がマッチする部分を含む必要がある正規表現（ECMAScript の構文）。指定が無ければ空文字
+	Pattern string
+	// repeated の要素数、map のエントリ数の範囲。指定が無ければ -1
+	MinItems int64
+	MaxItems int64
+	// optional フィールドは値が設定されていること、メッセージ型のフィールドはデフォルト値ではないこと
+	Required bool
+	// enum で定義されている値であること
+	EnumDefinedOnly bool
+}
+
+// 検証に失敗した時のメッセージ（全ての言語で同じ文字列にする）
+func (v *Validation) MinMessage() string {
+	return "must be greater than or equal to " + v.Min
+}
+
+func (v *Validation) MaxMessage() string {
+	return "must be less than or equal to " + v.Max
+}
+
+func (v *Validation) MinLenMessage() string {
+	return fmt.Sprintf("length must be at least %d", v.MinLen)
+}
+
+func (v *Validation) MaxLenMessage() string {
+	return fmt.Sprintf("length must be at most %d", v.MaxLen)
+}
+
+func (v *Validation) PatternMessage() string {
+	return fmt.Sprintf("must match pattern %s", strconv.Quote(v.Pattern))
+}
+
+func (v *Validation) MinItemsMessage() string {
+	return fmt.Sprintf("must have at least %d items", v.MinItems)
+}
+
+func (v *Validation) MaxItemsMessage() string {
+	return fmt.Sprintf("must have at most %d items", v.MaxItems)
+}
+
+const RequiredMessage = "is required"
+const EnumDefinedOnlyMessage = "must be a defined enum value"
+
+// repeated の要素、map の値に対するルールがあるかどうか
+func (v *Validation) HasValueRules() bool {
+	return len(v.Min) != 0 || len(v.Max) != 0 || v.MinLen >= 0 || v.MaxLen >= 0 || len(v.Pattern) != 0 || v.EnumDefinedOnly
+}
+
+// 検証ルールを適用する値のフィールド（map の場合は値のフィールド）
+func (f *Field) ValueField() *Field {
+	if f.IsMap() {
+		return f.MapValue
+	}
+	return f
+}
+
+// validate 関数を生成する必要があるかどうか
+// 検証ルールのあるフィールドか、validate 関数を生成するメッセージのフィールドを持っていれば true
+func (m *Message) HasValidation() bool {
+	return m.hasValidation(map[*Message]bool{})
+}
+
+func (m *Message) hasValidation(visited map[*Message]bool) bool {
+	if visited[m] {
+		return false
+	}
+	visited[m] = true
+	for _, field := range m.Fields {
+		if field.Validation != nil {
+			return true
+		}
+		value := field.ValueField()
+		if value.IsMessage() && value.WellKnownType() == NotWellKnown && value.Message.hasValidation(visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func getUint32Option(options proto.Message, xt protoreflect.ExtensionType) int64 {
+	if !proto.HasExtension(options, xt) {
+		return -1
+	}
+	return int64(proto.GetExtension(options, xt).(uint32))
+}
+
+func getStringOption(options proto.Message, xt protoreflect.ExtensionType) (string, bool) {
+	if !proto.HasExtension(options, xt) {
+		return "", false
+	}
+	return proto.GetExtension(options, xt).(string), true
+}
+
+func isNumberType(t descriptorpb.FieldDescriptorProto_Type) bool {
+	switch t {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+		descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+		descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return false
+	}
+	return true
+}
+
+// 数値の範囲を値の型に合わせて正規化する
+func normalizeNumber(t descriptorpb.FieldDescriptorProto_Type, value string) (string, error) {
+	switch t {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return normalizeInt(value, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return normalizeInt(value, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return normalizeUint(value, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return normalizeUint(value, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return normalizeFloat(value, 32)
+	default:
+		return normalizeFloat(value, 64)
+	}
+}
+
+// 検証ルールのフィールドオプションを読み込んで、フィールドの型に指定できるかを確認する
+// map の値の型を使うので、全てのフィールドの型を解決した後に呼ぶ
+func resolveValidation(field *Field) error {
+	opts := field.Desc.Options
+	v := &Validation{
+		MinLen:   getUint32Option(opts, generated.E_JsonifMinLen),
+		MaxLen:   getUint32Option(opts, generated.E_JsonifMaxLen),
+		MinItems: getUint32Option(opts, generated.E_JsonifMinItems),
+		MaxItems: getUint32Option(opts, generated.E_JsonifMaxItems),
+	}
+	v.Pattern, _ = getStringOption(opts, generated.E_JsonifPattern)
+	v.Required, _ = getBoolOption(opts, generated.E_JsonifRequired)
+	v.EnumDefinedOnly, _ = getBoolOption(opts, generated.E_JsonifEnumDefinedOnly)
+	min, hasMin := getStringOption(opts, generated.E_JsonifMin)
+	max, hasMax := getStringOption(opts, generated.E_JsonifMax)
+	if !hasMin && !hasMax && v.MinLen < 0 && v.MaxLen < 0 && len(v.Pattern) == 0 && v.MinItems < 0 && v.MaxItems < 0 && !v.Required && !v.EnumDefinedOnly {
+		return nil
+	}
+
+	value := field.ValueField()
+	if hasMin || hasMax {
+		if !isNumberType(value.Type) {
+			return fmt.Errorf("%s: jsonif_min and jsonif_max are only supported for number fields", field)
+		}
+		var err error
+		if hasMin {
+			if v.Min, err = normalizeNumber(value.Type, min); err != nil {
+				return fmt.Errorf("%s: invalid jsonif_min %q: %v", field, min, err)
+			}
+		}
+		if hasMax {
+			if v.Max, err = normalizeNumber(value.Type, max); err != nil {
+				return fmt.Errorf("%s: invalid jsonif_max %q: %v", field, max, err)
+			}
+		}
+	}
+	if (v.MinLen >= 0 || v.MaxLen >= 0) && value.Type != descriptorpb.FieldDescriptorProto_TYPE_STRING && value.Type != descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		return fmt.Errorf("%s: jsonif_min_len and jsonif_max_len are only supported for string and bytes fields", field)
+	}
+	if len(v.Pattern) != 0 && value.Type != descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		return fmt.Errorf("%s: jsonif_pattern is only supported for string fields", field)
+	}
+	if (v.MinItems >= 0 || v.MaxItems >= 0) && !field.Repeated {
+		return fmt.Errorf("%s: jsonif_min_items and jsonif_max_items are only supported for repeated and map fields", field)
+	}
+	if v.Required && (field.Repeated || (field.Oneof != nil && !field.Optional) || (!field.Optional && !field.IsMessage())) {
+		return fmt.Errorf("%s: jsonif_required is only supported for optional and message fields", field)
+	}
+	if v.EnumDefinedOnly && value.Type != descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		return fmt.Errorf("%s: jsonif_enum_defined_only is only supported for enum fields", field)
+	}
+	field.Validation = v
+	return nil
+}
+
+func (s *Schema) resolveValidations(msg *Message) error {
+	for _, field := range msg.Fields {
+		if err := resolveValidation(field); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if err := s.resolveValidations(nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package internal
+
+// 各言語の組み込み型に変換する google.protobuf の well-known type
+type WellKnownType int
+
+const (
+	NotWellKnown WellKnownType = iota
+	// google.protobuf.Timestamp（RFC 3339 形式の文字列）
+	WellKnownTimestamp
+	// google.protobuf.Duration（"1.5s" のような文字列）
+	WellKnownDuration
+	// google.protobuf.Int32Value などのラッパー型（値そのもの、または null）
+	WellKnownWrapper
+	// google.protobuf.Struct（任意の JSON のオブジェクト）
+	WellKnownStruct
+	// google.protobuf.Value（任意の JSON の値）
+	WellKnownValue
+	// google.protobuf.ListValue（任意の JSON の配列）
+	WellKnownListValue
+	// google.protobuf.Any（"@type" に型の URL を持つ JSON のオブジェクト）
+	WellKnownAny
+)
+
+// Struct, Value, ListValue のように、JSON の値をそのまま保持する型かどうか
+func (t WellKnownType) IsJsonValue() bool {
+	return t == WellKnownStruct || t == WellKnownValue || t == WellKnownListValue
+}
+
+var wellKnownTypes = map[string]WellKnownType{
+	"google.protobuf.Timestamp":   WellKnownTimestamp,
+	"google.protobuf.Duration":    WellKnownDuration,
+	"google.protobuf.DoubleValue": WellKnownWrapper,
+	"google.protobuf.FloatValue":  WellKnownWrapper,
+	"google.protobuf.Int64Value":  WellKnownWrapper,
+	"google.protobuf.UInt64Value": WellKnownWrapper,
+	"google.protobuf.Int32Value":  WellKnownWrapper,
+	"google.protobuf.UInt32Value": WellKnownWrapper,
+	"google.protobuf.BoolValue":   WellKnownWrapper,
+	"google.protobuf.StringValue": WellKnownWrapper,
+	"google.protobuf.BytesValue":  WellKnownWrapper,
+	"google.protobuf.Struct":      WellKnownStruct,
+	"google.protobuf.Value":       WellKnownValue,
+	"google.protobuf.ListValue":   WellKnownListValue,
+	"google.protobuf.Any":         WellKnownAny,
+}
+
+// google.protobuf.Value の null_value のための enum
+// Value の中でしか使わないので、各言語の型には変換しない
+const nullValueName = "google.protobuf.NullValue"
+
+func (m *Message) WellKnownType() WellKnownType {
+	return wellKnownTypes[m.FullName]
+}
+
+// メッセージ型のフィールドで、参照先が well-known type の場合はその種類を返す
+// map<K, V> の場合は値の型を調べる必要があるので、常に NotWellKnown を返す
+func (f *Field) WellKnownType() WellKnownType {
+	if f.Message == nil || f.IsMap() {
+		return NotWellKnown
+	}
+	return f.Message.WellKnownType()
+}
+
+// ラッパー型の値のフィールド
+func (m *Message) WrapperValue() *Field {
+	return m.Fields[0]
+}
+
+// timestamp.proto のように、well-known type だけを定義しているファイルかどうか
+// このファイルを import していても、生成したコードでは参照しないので import する必要は無い
+func (f *File) IsWellKnown() bool {
+	if len(f.Messages) == 0 {
+		return false
+	}
+	for _, enum := range f.Enums {
+		if enum.FullName != nullValueName {
+			return false
+		}
+	}
+	for _, msg := range f.Messages {
+		if msg.WellKnownType() == NotWellKnown {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,286 @@
+// protoc を使わずに、protoc -o や buf build -o で出力した FileDescriptorSet からコードを生成するコマンド
+//
+// protoc と同じ --<NAME>_out, --<NAME>_opt, --plugin オプションを受け付けて、
+// protoc-gen-<NAME> プラグインに CodeGeneratorRequest を渡して実行する。
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const usage = `Usage: jsonif --descriptor_set_in=FILES [OPTIONS] [PROTO_FILES...]
+
+  --descriptor_set_in=FILES    FileDescriptorSet files (separated by %q)
+  --<NAME>_out=DIR             run protoc-gen-<NAME> and write the files to DIR
+  --<NAME>_opt=PARAM           pass PARAM to protoc-gen-<NAME>
+  --plugin=protoc-gen-<NAME>=PATH
+                               use PATH as protoc-gen-<NAME>
+
+If PROTO_FILES are not specified, the files in the descriptor sets that are not
+imported by any other file are generated.
+`
+
+// 出力するプラグインごとの設定
+type output struct {
+	name   string
+	dir    string
+	params []string
+}
+
+type arguments struct {
+	descriptorSetIn []string
+	plugins         map[string]string
+	outputs         []*output
+	files           []string
+}
+
+func (a *arguments) output(name string) *output {
+	for _, o := range a.outputs {
+		if o.name == name {
+			return o
+		}
+	}
+	o := &output{name: name}
+	a.outputs = append(a.outputs, o)
+	return o
+}
+
+func parseArgs(argv []string) (*arguments, error) {
+	a := &arguments{plugins: map[string]string{}}
+	for _, arg := range argv {
+		if !strings.HasPrefix(arg, "--") {
+			a.files = append(a.files, arg)
+			continue
+		}
+		key, value, hasValue := strings.Cut(arg[2:], "=")
+		if !hasValue {
+			return nil, fmt.Errorf("missing value for %s", arg)
+		}
+		switch {
+		case key == "descriptor_set_in":
+			a.descriptorSetIn = append(a.descriptorSetIn, filepath.SplitList(value)...)
+		case key == "plugin":
+			name, path, ok := strings.Cut(value, "=")
+			if !ok || !strings.HasPrefix(name, "protoc-gen-") {
+				return nil, fmt.Errorf("invalid plugin %q (expected protoc-gen-<NAME>=PATH)", value)
+			}
+			a.plugins[strings.TrimPrefix(name, "protoc-gen-")] = path
+		case strings.HasSuffix(key, "_out"):
+			a.output(strings.TrimSuffix(key, "_out")).dir = value
+		case strings.HasSuffix(key, "_opt"):
+			o := a.output(strings.TrimSuffix(key, "_opt"))
+			o.params = append(o.params, value)
+		default:
+			return nil, fmt.Errorf("unknown option --%s", key)
+		}
+	}
+	if len(a.descriptorSetIn) == 0 {
+		return nil, errors.New("--descriptor_set_in is required")
+	}
+	if len(a.outputs) == 0 {
+		return nil, errors.New("no output specified (use --<NAME>_out=DIR)")
+	}
+	for _, o := range a.outputs {
+		if len(o.dir) == 0 {
+			return nil, fmt.Errorf("--%s_opt is specified without --%s_out", o.name, o.name)
+		}
+	}
+	return a, nil
+}
+
+// FileDescriptorSet を読み込む
+// 複数のファイルに同じ proto ファイルが含まれていた場合は、内容が同じであれば 1 つにまとめる
+func loadDescriptorSets(paths []string) ([]*descriptorpb.FileDescriptorProto, error) {
+	var files []*descriptorpb.FileDescriptorProto
+	found := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(b, set); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		for _, file := range set.File {
+			if f, ok := found[file.GetName()]; ok {
+				if !proto.Equal(f, file) {
+					return nil, fmt.Errorf("%s: %s is defined differently in another descriptor set", path, file.GetName())
+				}
+				continue
+			}
+			found[file.GetName()] = file
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// protoc がプラグインに渡すのと同じく、依存ファイルが先に来るように並べた CodeGeneratorRequest を作る
+func newRequest(files []*descriptorpb.FileDescriptorProto, fileToGenerate []string, parameter string) (*pluginpb.CodeGeneratorRequest, error) {
+	m := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, file := range files {
+		m[file.GetName()] = file
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if len(parameter) != 0 {
+		req.Parameter = proto.String(parameter)
+	}
+	if len(fileToGenerate) == 0 {
+		// protoc --include_imports -o で出力した場合は依存ファイルも含まれているので、
+		// protoc に指定したファイルと同じく、他のファイルから import されていないファイルだけを生成する
+		imported := map[string]bool{}
+		for _, file := range files {
+			for _, dep := range file.Dependency {
+				imported[dep] = true
+			}
+		}
+		for _, file := range files {
+			if !imported[file.GetName()] {
+				fileToGenerate = append(fileToGenerate, file.GetName())
+			}
+		}
+	}
+	for _, name := range fileToGenerate {
+		if _, ok := m[name]; !ok {
+			return nil, fmt.Errorf("%s: not found in descriptor sets", name)
+		}
+	}
+	req.FileToGenerate = fileToGenerate
+
+	added := map[string]bool{}
+	var add func(name string, from string) error
+	add = func(name string, from string) error {
+		if added[name] {
+			return nil
+		}
+		file, ok := m[name]
+		if !ok {
+			return fmt.Errorf("%s: import %s not found in descriptor sets (use --include_imports with protoc -o)", from, name)
+		}
+		added[name] = true
+		for _, dep := range file.Dependency {
+			if err := add(dep, name); err != nil {
+				return err
+			}
+		}
+		req.ProtoFile = append(req.ProtoFile, file)
+		return nil
+	}
+	for _, name := range fileToGenerate {
+		if err := add(name, name); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// プラグインのパスを探す
+// --plugin で指定されていない場合は、jsonif と同じディレクトリ、PATH の順に探す
+func findPlugin(name string, plugins map[string]string) (string, error) {
+	if path, ok := plugins[name]; ok {
+		return path, nil
+	}
+	bin := "protoc-gen-" + name
+	if exe, err := os.Executable(); err == nil {
+		if path, err := exec.LookPath(filepath.Join(filepath.Dir(exe), bin)); err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("%s: program not found or is not executable", bin)
+	}
+	return path, nil
+}
+
+func runPlugin(path string, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	in, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Base(path), err)
+	}
+	resp := &pluginpb.CodeGeneratorResponse{}
+	if err := proto.Unmarshal(out.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("%s: invalid response: %v", filepath.Base(path), err)
+	}
+	return resp, nil
+}
+
+func writeFiles(dir string, resp *pluginpb.CodeGeneratorResponse) error {
+	for _, file := range resp.File {
+		// jsonif のプラグインは挿入ポイントを使わない
+		if len(file.GetInsertionPoint()) != 0 {
+			return fmt.Errorf("%s: insertion points are not supported", file.GetName())
+		}
+		path := filepath.Join(dir, filepath.FromSlash(file.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(file.GetContent()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(argv []string) error {
+	a, err := parseArgs(argv)
+	if err != nil {
+		return err
+	}
+	files, err := loadDescriptorSets(a.descriptorSetIn)
+	if err != nil {
+		return err
+	}
+	for _, o := range a.outputs {
+		req, err := newRequest(files, a.files, strings.Join(o.params, ","))
+		if err != nil {
+			return err
+		}
+		path, err := findPlugin(o.name, a.plugins)
+		if err != nil {
+			return err
+		}
+		resp, err := runPlugin(path, req)
+		if err != nil {
+			return err
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("--%s_out: %s", o.name, resp.GetError())
+		}
+		if err := writeFiles(o.dir, resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" {
+		fmt.Fprintf(os.Stderr, usage, string(filepath.ListSeparator))
+		os.Exit(2)
+	}
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "jsonif: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/internal/goldentest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// JSONIF_TEST_PLUGIN が設定されている場合、テストバイナリ自身が
+// file_to_generate とパラメータを書き出すだけのプラグインとして動作する
+func TestMain(m *testing.M) {
+	if os.Getenv("JSONIF_TEST_PLUGIN") == "1" {
+		in, _ := io.ReadAll(os.Stdin)
+		req := &pluginpb.CodeGeneratorRequest{}
+		if err := proto.Unmarshal(in, req); err != nil {
+			os.Exit(1)
+		}
+		resp := &pluginpb.CodeGeneratorResponse{}
+		if req.GetParameter() == "error" {
+			resp.Error = proto.String("test error")
+		}
+		var names []string
+		for _, file := range req.ProtoFile {
+			names = append(names, file.GetName())
+		}
+		resp.File = append(resp.File, &pluginpb.CodeGeneratorResponse_File{
+			Name:    proto.String("sub/out.txt"),
+			Content: proto.String(strings.Join(req.FileToGenerate, " ") + "\n" + strings.Join(names, " ") + "\n" + req.GetParameter()),
+		})
+		out, _ := proto.Marshal(resp)
+		os.Stdout.Write(out)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func writeDescriptorSet(t *testing.T, files ...string) string {
+	t.Helper()
+	req := goldentest.NewRequest(t, "", files...)
+	b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: req.ProtoFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "set.pb")
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseArgs(t *testing.T) {
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"--jsonif-cpp_out=out"}, "--descriptor_set_in is required"},
+		{[]string{"--descriptor_set_in=a.pb"}, "no output specified (use --<NAME>_out=DIR)"},
+		{[]string{"--descriptor_set_in=a.pb", "--jsonif-cpp_opt=x"}, "--jsonif-cpp_opt is specified without --jsonif-cpp_out"},
+		{[]string{"--descriptor_set_in=a.pb", "--jsonif-cpp_out"}, "missing value for --jsonif-cpp_out"},
+		{[]string{"--descriptor_set_in=a.pb", "--plugin=foo=bar"}, `invalid plugin "foo=bar" (expected protoc-gen-<NAME>=PATH)`},
+		{[]string{"--descriptor_set_in=a.pb", "--foo=bar"}, "unknown option --foo"},
+	}
+	for _, c := range cases {
+		_, err := parseArgs(c.args)
+		if err == nil || err.Error() != c.err {
+			t.Errorf("parseArgs(%v): error = %v, want %q", c.args, err, c.err)
+		}
+	}
+
+	a, err := parseArgs([]string{
+		"--descriptor_set_in=a.pb" + string(filepath.ListSeparator) + "b.pb",
+		"--jsonif-cpp_opt=backend=boost",
+		"--jsonif-cpp_out=out_cpp",
+		"--jsonif-cpp_opt=include_imports",
+		"--jsonif-typescript_out=out_ts",
+		"--plugin=protoc-gen-jsonif-cpp=bin/cpp",
+		"test.proto",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.descriptorSetIn) != 2 || len(a.outputs) != 2 || len(a.files) != 1 {
+		t.Fatalf("unexpected arguments: %+v", a)
+	}
+	if o := a.outputs[0]; o.name != "jsonif-cpp" || o.dir != "out_cpp" || strings.Join(o.params, ",") != "backend=boost,include_imports" {
+		t.Errorf("unexpected output: %+v", o)
+	}
+	if a.plugins["jsonif-cpp"] != "bin/cpp" {
+		t.Errorf("unexpected plugins: %v", a.plugins)
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	req := goldentest.NewRequest(t, "", "importing.proto")
+	// 依存ファイルが後ろにあっても並べ直す
+	var files []*descriptorpb.FileDescriptorProto
+	for i := len(req.ProtoFile) - 1; i >= 0; i-- {
+		files = append(files, req.ProtoFile[i])
+	}
+	r, err := newRequest(files, []string{"importing.proto"}, "include_imports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.GetParameter() != "include_imports" {
+		t.Errorf("parameter = %q", r.GetParameter())
+	}
+	if n := len(r.ProtoFile); n != 2 || r.ProtoFile[0].GetName() != "google/protobuf/timestamp.proto" || r.ProtoFile[1].GetName() != "importing.proto" {
+		t.Errorf("unexpected order of proto_file")
+	}
+
+	if _, err := newRequest(files, []string{"foo.proto"}, ""); err == nil || err.Error() != "foo.proto: not found in descriptor sets" {
+		t.Errorf("error = %v", err)
+	}
+
+	// 生成するファイルを指定しない場合は、import されていないファイルだけを生成する
+	set := goldentest.NewRequest(t, "", "importing.proto", "wellknown.proto").ProtoFile
+	r, err = newRequest(set, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(r.FileToGenerate, " "); got != "importing.proto wellknown.proto" {
+		t.Errorf("file_to_generate = %q", got)
+	}
+	if n := len(r.ProtoFile); n != len(set) {
+		t.Errorf("len(proto_file) = %d, want %d", n, len(set))
+	}
+
+	_, err = newRequest(files[:1], nil, "")
+	if err == nil || err.Error() != "importing.proto: import google/protobuf/timestamp.proto not found in descriptor sets (use --include_imports with protoc -o)" {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv("JSONIF_TEST_PLUGIN", "1")
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := writeDescriptorSet(t, "importing.proto")
+	out := t.TempDir()
+
+	err = run([]string{
+		"--descriptor_set_in=" + set,
+		"--plugin=protoc-gen-test=" + exe,
+		"--test_out=" + out,
+		"--test_opt=a=1",
+		"--test_opt=b",
+		"importing.proto",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(out, "sub", "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "importing.proto\ngoogle/protobuf/timestamp.proto importing.proto\na=1,b"
+	if string(b) != want {
+		t.Errorf("got %q, want %q", b, want)
+	}
+
+	// 生成するファイルを指定しない場合は、依存ファイルを生成しない
+	err = run([]string{
+		"--descriptor_set_in=" + set,
+		"--plugin=protoc-gen-test=" + exe,
+		"--test_out=" + out,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = os.ReadFile(filepath.Join(out, "sub", "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "importing.proto\ngoogle/protobuf/timestamp.proto importing.proto\n"; string(b) != want {
+		t.Errorf("got %q, want %q", b, want)
+	}
+
+	err = run([]string{
+		"--descriptor_set_in=" + set,
+		"--plugin=protoc-gen-test=" + exe,
+		"--test_out=" + out,
+		"--test_opt=error",
+	})
+	if err == nil || err.Error() != "--test_out: test error" {
+		t.Errorf("error = %v", err)
+	}
+}
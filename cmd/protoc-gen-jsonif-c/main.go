@@ -13,6 +13,17 @@ import (
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
+// プラグインパラメータ
+type options struct {
+	internal.CommonOptions
+}
+
+func newOptionSet(opts *options) *internal.OptionSet {
+	s := &internal.OptionSet{}
+	opts.CommonOptions.Register(s)
+	return s
+}
+
 type cFile struct {
 	HTop        internal.Formatter
 	HBottom     internal.Formatter
@@ -39,62 +50,127 @@ func (cpp *cFile) CppString() string {
 	return cpp.CTop.String() + cpp.CppImpl.String() + cpp.CImplTop.String() + cpp.CImpl.String() + cpp.CImplBottom.String() + cpp.CBottom.String()
 }
 
-func toQualifiedName(name string, pkg *string, parents []*descriptorpb.DescriptorProto) (string, error) {
-	qualifiedName := ""
-	if pkg != nil {
-		qualifiedName += strings.ReplaceAll(*pkg, ".", "_") + "_"
-	}
-	for _, parent := range parents {
-		qualifiedName += *parent.Name + "_"
+// ヘッダは C++ からもインクルードされるので、C と C++ の両方の予約語をエスケープする
+var keywords = internal.CKeywords.Merge(internal.CppKeywords)
+
+// 予約語と同じ名前の場合は後ろに _ を付ける
+func escapeName(name string) string {
+	if keywords[name] {
+		return name + "_"
 	}
-	qualifiedName += name
-	return qualifiedName, nil
+	return name
 }
-func toEnumQualifiedName(name string, pkg *string, parents []*descriptorpb.DescriptorProto) (string, error) {
-	qualifiedName := ""
-	if pkg != nil {
-		qualifiedName += strings.ReplaceAll(*pkg, ".", "_")
-	}
-	for _, parent := range parents {
-		if len(qualifiedName) == 0 {
-			qualifiedName += *parent.Name
-		} else {
-			qualifiedName += "_" + *parent.Name
-		}
+
+// C++ の予約語と同じ名前の場合は後ろに _ を付ける（protoc-gen-jsonif-cpp と同じ規則）
+func escapeCppName(name string) string {
+	if internal.CppKeywords[name] {
+		return name + "_"
 	}
-	return qualifiedName, nil
+	return name
+}
+
+// 構造体のメンバ名
+func toFieldName(field *internal.Field) string {
+	return escapeName(internal.ToSnakeCase(field.Name))
+}
+
+// protoc-gen-jsonif-cpp が生成する構造体のメンバ名
+func toCppFieldName(field *internal.Field) string {
+	return escapeCppName(internal.ToSnakeCase(field.Name))
 }
-func toCppQualifiedName(name string, pkg *string, parents []*descriptorpb.DescriptorProto) (string, error) {
-	qualifiedName := ""
-	if pkg != nil {
-		qualifiedName += "::" + strings.ReplaceAll(*pkg, ".", "::")
+
+// pkg.Parent.Name を pkg_Parent_Name に変換する
+func toQualifiedName(fullName string) string {
+	return escapeName(strings.ReplaceAll(fullName, ".", "_"))
+}
+
+// enum の値の名前の前に付けるプレフィックス
+func toEnumQualifiedName(enum *internal.Enum) string {
+	if enum.Parent != nil {
+		return toQualifiedName(enum.Parent.FullName)
 	}
-	for _, parent := range parents {
-		qualifiedName += "::" + *parent.Name
+	return toQualifiedName(enum.File.Package)
+}
+
+// pkg.Parent.Name を ::pkg::Parent::Name に変換する
+func toCppQualifiedName(fullName string) string {
+	xs := strings.Split(fullName, ".")
+	for i, x := range xs {
+		xs[i] = escapeCppName(x)
 	}
-	qualifiedName += "::" + name
-	return qualifiedName, nil
+	return "::" + strings.Join(xs, "::")
 }
 
-func getMessageTypeName(field *descriptorpb.FieldDescriptorProto) (string, error) {
-	if *field.Type != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE &&
-		*field.Type != descriptorpb.FieldDescriptorProto_TYPE_ENUM &&
-		*field.Type != descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+func getMessageTypeName(field *internal.Field) (string, error) {
+	switch {
+	case field.Message != nil:
+		return toQualifiedName(field.Message.FullName), nil
+	case field.Enum != nil:
+		return toQualifiedName(field.Enum.FullName), nil
+	default:
 		return "", errors.New("not message type")
 	}
-	typeName := *field.TypeName
-	if strings.HasPrefix(typeName, ".") {
-		typeName = typeName[1:]
+}
+
+// google.protobuf.Struct, Value, ListValue, Any かどうか
+// C ではこれらの型を JSON の文字列として保持する
+func isJsonValueType(t internal.WellKnownType) bool {
+	return t.IsJsonValue() || t == internal.WellKnownAny
+}
+
+func isJsonValue(field *internal.Field) bool {
+	return isJsonValueType(field.WellKnownType())
+}
+
+// JSON の文字列と変換する C++ の型と、その値が null かどうかを調べる式
+func toCppJsonType(field *internal.Field, v string) (string, string) {
+	if field.WellKnownType() == internal.WellKnownAny {
+		return "::jsonif::any", v + ".value.is_null()"
 	}
-	typeName = strings.ReplaceAll(typeName, ".", "_")
-	return typeName, nil
+	return "::jsonif::json_value", v + ".is_null()"
 }
 
-func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, bool, bool, error) {
-	isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+// char* で保持するフィールドかどうか
+func isString(field *internal.Field) bool {
+	return field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING || isJsonValue(field)
+}
+
+// _init で jsonif_default の値を設定する必要があるメッセージかどうか
+// メンバーとして持っているメッセージに jsonif_default があれば、そのメッセージの _init を呼ぶ必要がある
+func hasDefault(msg *internal.Message, visited map[*internal.Message]bool) bool {
+	if visited[msg] {
+		return false
+	}
+	visited[msg] = true
+	for _, field := range msg.Fields {
+		if field.HasDefault {
+			return true
+		}
+		if !field.Repeated && field.IsMessage() && hasDefault(field.Message, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// struct.proto のように、JSON の文字列として保持する型だけを定義しているファイルかどうか
+// このファイルの型は C の構造体にならないので、ファイルを出力する必要は無い
+func isJsonValueFile(file *internal.File) bool {
+	if !file.IsWellKnown() {
+		return false
+	}
+	for _, msg := range file.Messages {
+		if !isJsonValueType(msg.WellKnownType()) {
+			return false
+		}
+	}
+	return true
+}
+
+func toTypeName(field *internal.Field) (string, bool, bool, error) {
 	typeName := ""
 	needLen := false
-	switch *field.Type {
+	switch field.Type {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
 		typeName = "double"
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
@@ -124,6 +200,11 @@ func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, bool, bool, e
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
 		descriptorpb.FieldDescriptorProto_TYPE_GROUP,
 		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		if isJsonValue(field) {
+			typeName = "char*"
+			needLen = true
+			break
+		}
 		var err error
 		typeName, err = getMessageTypeName(field)
 		if err != nil {
@@ -133,126 +214,434 @@ func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, bool, bool, e
 		return "", false, false, errors.New("invalid type")
 	}
 
-	if isRepeated {
+	if field.Repeated {
 		return fmt.Sprintf("%s*", typeName), true, needLen, nil
 	} else {
 		return typeName, false, needLen, nil
 	}
 }
 
-func genEnum(enum *descriptorpb.EnumDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cFile) error {
-	cpp.Enums.P("// %s", *enum.Name)
+// well-known type は C++ 側では組み込み型になるので、その型名を返す
+func toCppWellKnownTypeName(msg *internal.Message) string {
+	switch msg.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		return "std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>"
+	case internal.WellKnownDuration:
+		return "std::chrono::nanoseconds"
+	case internal.WellKnownWrapper:
+		valueType := ""
+		switch msg.WrapperValue().Type {
+		case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+			valueType = "double"
+		case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+			valueType = "float"
+		case descriptorpb.FieldDescriptorProto_TYPE_INT64:
+			valueType = "int64_t"
+		case descriptorpb.FieldDescriptorProto_TYPE_UINT64:
+			valueType = "uint64_t"
+		case descriptorpb.FieldDescriptorProto_TYPE_INT32:
+			valueType = "int32_t"
+		case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
+			valueType = "uint32_t"
+		case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+			valueType = "bool"
+		default:
+			valueType = "std::string"
+		}
+		return "std::optional<" + valueType + ">"
+	}
+	return ""
+}
 
-	qName, err := toQualifiedName(*enum.Name, pkg, parents)
-	if err != nil {
-		return err
+// proto のコメントを Doxygen 形式で出力する
+func genComment(f *internal.Formatter, comments []string) {
+	for _, line := range comments {
+		if len(line) == 0 {
+			f.P("///")
+		} else {
+			f.P("/// %s", line)
+		}
 	}
-	qEnumName, err := toEnumQualifiedName(*enum.Name, pkg, parents)
-	if err != nil {
-		return err
+}
+
+// repeated でないフィールドの値 src を C++ の値 dst に変換する
+func genValueToCpp(f *internal.Formatter, field *internal.Field, dst string, src string) error {
+	if isJsonValue(field) {
+		// NULL の場合は JSON の null になる
+		typeName, _ := toCppJsonType(field, dst)
+		f.P("if (%s != nullptr) %s = jsonif::from_json<%s>(std::string(%s, %s_len));", src, dst, typeName, src, src)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		f.P("if (%s_len != 0) %s = std::string(%s, %s_len);", src, dst, src, src)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		f.P("if (%s_len != 0) %s = std::string((const char*)%s, %s_len);", src, dst, src, src)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		typeName, err := getMessageTypeName(field)
+		if err != nil {
+			return err
+		}
+		f.P("%s = %s_to_cpp(&%s);", dst, typeName, src)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		f.P("%s = (decltype(%s))%s;", dst, dst, src)
+	} else {
+		f.P("%s = %s;", dst, src)
 	}
+	return nil
+}
+
+// repeated でないフィールドの C++ の値 src を C の値 dst に変換する
+func genValueFromCpp(f *internal.Formatter, field *internal.Field, dst string, src string) error {
+	if isJsonValue(field) {
+		// JSON の null の場合は NULL のままにする
+		_, isNull := toCppJsonType(field, src)
+		f.PI("if (!%s) {", isNull)
+		f.P("std::string json = jsonif::to_json(%s);", src)
+		f.P("%s = strdup(json.c_str());", dst)
+		f.P("%s_len = (int)json.size();", dst)
+		f.PD("}")
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		f.P("if (!%s.empty()) %s = strdup(%s.c_str());", src, dst, src)
+		f.P("%s_len = (int)%s.size();", dst, src)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		f.PI("if (!%s.empty()) {", src)
+		f.P("%s = (uint8_t*)malloc(sizeof(uint8_t) * %s.size());", dst, src)
+		f.P("memcpy(%s, %s.data(), %s.size());", dst, src, src)
+		f.PD("}")
+		f.P("%s_len = (int)%s.size();", dst, src)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		typeName, err := getMessageTypeName(field)
+		if err != nil {
+			return err
+		}
+		f.P("%s_from_cpp(%s, &%s);", typeName, src, dst)
+	} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		f.P("%s = (int)%s;", dst, src)
+	} else {
+		f.P("%s = %s;", dst, src)
+	}
+	return nil
+}
+
+// deprecated な要素の宣言の後ろに付ける属性
+func toDeprecatedAttr(deprecated bool) string {
+	if deprecated {
+		return " JSONIF_C_DEPRECATED"
+	}
+	return ""
+}
+
+func genEnum(enum *internal.Enum, cpp *cFile) error {
+	cpp.Enums.P("// %s", enum.Name)
+	genComment(&cpp.Enums, enum.Comments)
+
+	qName := toQualifiedName(enum.FullName)
+	qEnumName := toEnumQualifiedName(enum)
 
 	cpp.Enums.P("typedef int %s;", qName)
-	for _, v := range enum.Value {
-		cpp.Enums.P("extern const %s %s_%s;", qName, qEnumName, *v.Name)
+	for _, v := range enum.Values {
+		genComment(&cpp.Enums, v.Comments)
+		cpp.Enums.P("extern const %s %s_%s%s;", qName, qEnumName, v.Name, toDeprecatedAttr(v.Deprecated))
 	}
 	cpp.Enums.P("")
 
-	cpp.CppImpl.P("// %s", *enum.Name)
-	for _, v := range enum.Value {
-		cpp.CppImpl.P("const %s %s_%s = %d;", qName, qEnumName, *v.Name, *v.Number)
+	cpp.CppImpl.P("// %s", enum.Name)
+	for _, v := range enum.Values {
+		cpp.CppImpl.P("const %s %s_%s = %d;", qName, qEnumName, v.Name, v.Number)
 	}
 	cpp.CppImpl.P("")
 
 	return nil
 }
 
-func genOneofEnum(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.FieldDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cFile) error {
-	typeName := internal.ToUpperCamel(*oneof.Name) + "Case"
-	qName, err := toQualifiedName(typeName, pkg, parents)
-	if err != nil {
-		return err
-	}
-	cpp.Enums.P("// %s", *oneof.Name)
+func genOneofEnum(oneof *internal.Oneof, cpp *cFile) error {
+	typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+	qName := toQualifiedName(oneof.Parent.FullName + "." + typeName)
+	cpp.Enums.P("// %s", oneof.Name)
+	genComment(&cpp.Enums, oneof.Comments)
 	cpp.Enums.P("typedef int %s;", qName)
 	cpp.Enums.P("extern const %s %s_NOT_SET;", qName, qName)
-	for _, field := range fields {
-		cpp.Enums.P("extern const %s %s_k%s;", qName, qName, internal.ToUpperCamel(*field.Name))
+	for _, field := range oneof.Fields {
+		cpp.Enums.P("extern const %s %s_k%s;", qName, qName, internal.ToUpperCamel(field.Name))
 	}
 	cpp.Enums.P("")
 
-	cpp.CppImpl.P("// %s", *oneof.Name)
+	cpp.CppImpl.P("// %s", oneof.Name)
 	cpp.CppImpl.P("const %s %s_NOT_SET = 0;", qName, qName)
-	for _, field := range fields {
-		cpp.CppImpl.P("const %s %s_k%s = %d;", qName, qName, internal.ToUpperCamel(*field.Name), *field.Number)
+	for _, field := range oneof.Fields {
+		cpp.CppImpl.P("const %s %s_k%s = %d;", qName, qName, internal.ToUpperCamel(field.Name), field.Number)
 	}
 	cpp.CppImpl.P("")
 
 	return nil
 }
 
-func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cFile) error {
+// C の構造体と C++ の構造体を相互に変換する関数を生成する
+func genMessageCpp(msg *internal.Message, cpp *cFile) error {
+	qName := toQualifiedName(msg.FullName)
+	qCppName := toCppQualifiedName(msg.FullName)
+
+	// to_cpp
+	cpp.CppImpl.PI("%s %s_to_cpp(const %s* v) {", qCppName, qName, qName)
+	cpp.CppImpl.P("%s u;", qCppName)
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		cppFieldName := toCppFieldName(field)
+		isRepeated := field.Repeated
+		if !isRepeated {
+			if err := genValueToCpp(&cpp.CppImpl, field, "u."+cppFieldName, "v->"+fieldName); err != nil {
+				return err
+			}
+		}
+		if isRepeated {
+			cpp.CppImpl.PI("for (int i = 0; i < v->%s_len; i++) {", fieldName)
+			if field.IsMap() {
+				entry := fmt.Sprintf("v->%s[i]", fieldName)
+				cpp.CppImpl.P("decltype(u.%s)::key_type key{};", cppFieldName)
+				cpp.CppImpl.P("decltype(u.%s)::mapped_type value{};", cppFieldName)
+				if err := genValueToCpp(&cpp.CppImpl, field.MapKey, "key", entry+"."+toFieldName(field.MapKey)); err != nil {
+					return err
+				}
+				if err := genValueToCpp(&cpp.CppImpl, field.MapValue, "value", entry+"."+toFieldName(field.MapValue)); err != nil {
+					return err
+				}
+				cpp.CppImpl.P("u.%s.emplace(std::move(key), std::move(value));", cppFieldName)
+			} else if isJsonValue(field) {
+				cpp.CppImpl.PI("if (v->%s[i] != nullptr) {", fieldName)
+				typeName, _ := toCppJsonType(field, "")
+				cpp.CppImpl.P("u.%s.push_back(jsonif::from_json<%s>(std::string(v->%s[i], v->%s_lens[i])));", cppFieldName, typeName, fieldName, fieldName)
+				cpp.CppImpl.PDI("} else {")
+				cpp.CppImpl.P("u.%s.push_back(%s());", cppFieldName, typeName)
+				cpp.CppImpl.PD("}")
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+				cpp.CppImpl.PI("if (v->%s_lens[i] != 0) {", fieldName)
+				cpp.CppImpl.P("u.%s.push_back(std::string(v->%s[i], v->%s_lens[i]));", cppFieldName, fieldName, fieldName)
+				cpp.CppImpl.PDI("} else {")
+				cpp.CppImpl.P("u.%s.push_back(\"\");", cppFieldName)
+				cpp.CppImpl.PD("}")
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.CppImpl.PI("if (v->%s_lens[i] != 0) {", fieldName)
+				cpp.CppImpl.P("u.%s.push_back(std::string((const char*)v->%s[i], v->%s_lens[i]));", cppFieldName, fieldName, fieldName)
+				cpp.CppImpl.PDI("} else {")
+				cpp.CppImpl.P("u.%s.push_back(\"\");", cppFieldName)
+				cpp.CppImpl.PD("}")
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				typeName, err := getMessageTypeName(field)
+				if err != nil {
+					return err
+				}
+				cpp.CppImpl.P("u.%s.push_back(%s_to_cpp(&v->%s[i]));", cppFieldName, typeName, fieldName)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+				cpp.CppImpl.P("u.%s.push_back((decltype(u.%s[0]))v->%s[i]);", cppFieldName, cppFieldName, fieldName)
+			} else {
+				cpp.CppImpl.P("u.%s.push_back(v->%s[i]);", cppFieldName, fieldName)
+			}
+			cpp.CppImpl.PD("}")
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		oneofQName := toCppQualifiedName(msg.FullName + "." + typeName)
+		cpp.CppImpl.P("u.%s = (%s)v->%s;", fieldName, oneofQName, fieldName)
+	}
+	cpp.CppImpl.P("return u;")
+	cpp.CppImpl.PD("}")
+
+	// from_cpp
+	cpp.CppImpl.PI("void %s_from_cpp(const %s& u, %s* v) {", qName, qCppName, qName)
+	cpp.CppImpl.P("%s_destroy(v);", qName)
+	// 全てのフィールドを u の値で上書きするので、jsonif_default の値は設定しない
+	cpp.CppImpl.P("memset(v, 0, sizeof(%s));", qName)
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		cppFieldName := toCppFieldName(field)
+		isRepeated := field.Repeated
+		if !isRepeated {
+			if err := genValueFromCpp(&cpp.CppImpl, field, "v->"+fieldName, "u."+cppFieldName); err != nil {
+				return err
+			}
+		}
+		if isRepeated && field.IsMap() {
+			typeName, err := getMessageTypeName(field)
+			if err != nil {
+				return err
+			}
+			cpp.CppImpl.P("v->%s_len = (int)u.%s.size();", fieldName, cppFieldName)
+			cpp.CppImpl.P("v->%s = v->%s_len == 0 ? nullptr : (decltype(v->%s))malloc(sizeof(v->%s[0]) * u.%s.size());",
+				fieldName, fieldName, fieldName, fieldName, cppFieldName)
+			cpp.CppImpl.P("int %s_index = 0;", fieldName)
+			cpp.CppImpl.PI("for (const auto& kv : u.%s) {", cppFieldName)
+			entry := fmt.Sprintf("v->%s[%s_index]", fieldName, fieldName)
+			cpp.CppImpl.P("%s_init(&%s);", typeName, entry)
+			if err := genValueFromCpp(&cpp.CppImpl, field.MapKey, entry+"."+toFieldName(field.MapKey), "kv.first"); err != nil {
+				return err
+			}
+			if err := genValueFromCpp(&cpp.CppImpl, field.MapValue, entry+"."+toFieldName(field.MapValue), "kv.second"); err != nil {
+				return err
+			}
+			cpp.CppImpl.P("%s_index++;", fieldName)
+			cpp.CppImpl.PD("}")
+		} else if isRepeated {
+			cpp.CppImpl.P("v->%s_len = (int)u.%s.size();", fieldName, cppFieldName)
+			cpp.CppImpl.P("v->%s = v->%s_len == 0 ? nullptr : (decltype(v->%s))malloc(sizeof(v->%s[0]) * u.%s.size());",
+				fieldName, fieldName, fieldName, fieldName, cppFieldName)
+			if isString(field) ||
+				field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.CppImpl.P("v->%s_lens = v->%s_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.%s.size());",
+					fieldName, fieldName, cppFieldName)
+			}
+
+			cpp.CppImpl.PI("for (int i = 0; i < (int)u.%s.size(); i++) {", cppFieldName)
+			if isJsonValue(field) {
+				cpp.CppImpl.P("v->%s[i] = nullptr;", fieldName)
+				cpp.CppImpl.P("v->%s_lens[i] = 0;", fieldName)
+				_, isNull := toCppJsonType(field, fmt.Sprintf("u.%s[i]", cppFieldName))
+				cpp.CppImpl.PI("if (!%s) {", isNull)
+				cpp.CppImpl.P("std::string json = jsonif::to_json(u.%s[i]);", cppFieldName)
+				cpp.CppImpl.P("v->%s[i] = strdup(json.c_str());", fieldName)
+				cpp.CppImpl.P("v->%s_lens[i] = (int)json.size();", fieldName)
+				cpp.CppImpl.PD("}")
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+				cpp.CppImpl.P("if (!u.%s[i].empty()) v->%s[i] = strdup(u.%s[i].c_str());", cppFieldName, fieldName, cppFieldName)
+				cpp.CppImpl.P("v->%s_lens[i] = (int)u.%s[i].size();", fieldName, cppFieldName)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.CppImpl.PI("if (!u.%s[i].empty()) {", cppFieldName)
+				cpp.CppImpl.P("v->%s[i] = (uint8_t*)malloc(sizeof(uint8_t) * u.%s[i].size());", fieldName, cppFieldName)
+				cpp.CppImpl.P("memcpy(v->%s[i], u.%s[i].data(), u.%s[i].size());", fieldName, cppFieldName, cppFieldName)
+				cpp.CppImpl.PD("}")
+				cpp.CppImpl.P("v->%s_lens[i] = (int)u.%s[i].size();", fieldName, cppFieldName)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				typeName, err := getMessageTypeName(field)
+				if err != nil {
+					return err
+				}
+				cpp.CppImpl.P("%s_init(&v->%s[i]);", typeName, fieldName)
+				cpp.CppImpl.P("%s_from_cpp(u.%s[i], &v->%s[i]);", typeName, cppFieldName, fieldName)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+				cpp.CppImpl.P("v->%s[i] = (int)u.%s[i];", fieldName, cppFieldName)
+			} else {
+				cpp.CppImpl.P("v->%s[i] = u.%s[i];", fieldName, cppFieldName)
+			}
+			cpp.CppImpl.PD("}")
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		cpp.CppImpl.P("v->%s = (int)u.%s;", fieldName, fieldName)
+	}
+	cpp.CppImpl.PD("}")
+	return nil
+}
+
+// well-known type の C の構造体と C++ の組み込み型を相互に変換する関数を生成する
+func genWellKnownCpp(msg *internal.Message, cpp *cFile) error {
+	qName := toQualifiedName(msg.FullName)
+	qCppName := toCppWellKnownTypeName(msg)
+
+	// to_cpp
+	cpp.CppImpl.PI("%s %s_to_cpp(const %s* v) {", qCppName, qName, qName)
+	switch msg.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		cpp.CppImpl.P("return %s(std::chrono::seconds(v->seconds) + std::chrono::nanoseconds(v->nanos));", qCppName)
+	case internal.WellKnownDuration:
+		cpp.CppImpl.P("return std::chrono::seconds(v->seconds) + std::chrono::nanoseconds(v->nanos);")
+	case internal.WellKnownWrapper:
+		value := msg.WrapperValue()
+		cpp.CppImpl.P("%s u;", qCppName)
+		cpp.CppImpl.PI("if (v->has_value) {")
+		cpp.CppImpl.P("u.emplace();")
+		if err := genValueToCpp(&cpp.CppImpl, value, "*u", "v->"+toFieldName(value)); err != nil {
+			return err
+		}
+		cpp.CppImpl.PD("}")
+		cpp.CppImpl.P("return u;")
+	}
+	cpp.CppImpl.PD("}")
+
+	// from_cpp
+	cpp.CppImpl.PI("void %s_from_cpp(const %s& u, %s* v) {", qName, qCppName, qName)
+	cpp.CppImpl.P("%s_destroy(v);", qName)
+	cpp.CppImpl.P("%s_init(v);", qName)
+	switch msg.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		// nanos は常に 0 以上になるように、秒は負の無限大方向に丸める
+		cpp.CppImpl.P("auto s = std::chrono::floor<std::chrono::seconds>(u.time_since_epoch());")
+		cpp.CppImpl.P("v->seconds = s.count();")
+		cpp.CppImpl.P("v->nanos = (int32_t)(u.time_since_epoch() - s).count();")
+	case internal.WellKnownDuration:
+		// seconds と nanos の符号は同じになる
+		cpp.CppImpl.P("auto s = std::chrono::duration_cast<std::chrono::seconds>(u);")
+		cpp.CppImpl.P("v->seconds = s.count();")
+		cpp.CppImpl.P("v->nanos = (int32_t)(u - s).count();")
+	case internal.WellKnownWrapper:
+		value := msg.WrapperValue()
+		cpp.CppImpl.PI("if (u) {")
+		cpp.CppImpl.P("v->has_value = true;")
+		if err := genValueFromCpp(&cpp.CppImpl, value, "v->"+toFieldName(value), "(*u)"); err != nil {
+			return err
+		}
+		cpp.CppImpl.PD("}")
+	}
+	cpp.CppImpl.PD("}")
+	return nil
+}
+
+func genDescriptor(msg *internal.Message, cpp *cFile) error {
 	// descOptimistic := proto.HasExtension(desc.Options, generated.E_JsonifMessageOptimistic) && proto.GetExtension(desc.Options, generated.E_JsonifMessageOptimistic).(bool)
 	// descDiscard := proto.HasExtension(desc.Options, generated.E_JsonifMessageDiscardIfDefault) && proto.GetExtension(desc.Options, generated.E_JsonifMessageDiscardIfDefault).(bool)
 	// noSerializer := proto.HasExtension(desc.Options, generated.E_JsonifNoSerializer) && proto.GetExtension(desc.Options, generated.E_JsonifNoSerializer).(bool)
 	// noDeserializer := proto.HasExtension(desc.Options, generated.E_JsonifNoDeserializer) && proto.GetExtension(desc.Options, generated.E_JsonifNoDeserializer).(bool)
 
-	for _, enum := range desc.EnumType {
-		if err := genEnum(enum, pkg, append(parents, desc), cpp); err != nil {
+	for _, enum := range msg.Enums {
+		if err := genEnum(enum, cpp); err != nil {
 			return err
 		}
 	}
 
-	for _, nested := range desc.NestedType {
-		if err := genDescriptor(nested, pkg, append(parents, desc), cpp); err != nil {
+	for _, nested := range msg.Messages {
+		if err := genDescriptor(nested, cpp); err != nil {
 			return err
 		}
 	}
 
-	for i, oneof := range desc.OneofDecl {
-		var fields []*descriptorpb.FieldDescriptorProto
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fields = append(fields, field)
-			}
-		}
-		if err := genOneofEnum(oneof, fields, pkg, append(parents, desc), cpp); err != nil {
+	for _, oneof := range msg.Oneofs {
+		if err := genOneofEnum(oneof, cpp); err != nil {
 			return err
 		}
 	}
 
-	qName, err := toQualifiedName(*desc.Name, pkg, parents)
-	if err != nil {
-		return err
-	}
+	qName := toQualifiedName(msg.FullName)
 
-	cpp.Typedefs.P("// %s", *desc.Name)
+	cpp.Typedefs.P("// %s", msg.Name)
+	genComment(&cpp.Typedefs, msg.Comments)
 	cpp.Typedefs.PI("typedef struct {")
 
-	for _, field := range desc.Field {
+	for _, field := range msg.Fields {
 		typeName, isRepeated, needLen, err := toTypeName(field)
 		if err != nil {
 			return err
 		}
-		fieldName := internal.ToSnakeCase(*field.Name)
-		cpp.Typedefs.P("%s %s;", typeName, fieldName)
+		fieldName := toFieldName(field)
+		attr := toDeprecatedAttr(field.Deprecated)
+		genComment(&cpp.Typedefs, field.Comments)
+		cpp.Typedefs.P("%s %s%s;", typeName, fieldName, attr)
 		if isRepeated && needLen {
-			cpp.Typedefs.P("int* %s_lens;", fieldName)
+			cpp.Typedefs.P("int* %s_lens%s;", fieldName, attr)
 		}
 		if isRepeated || needLen {
-			cpp.Typedefs.P("int %s_len;", fieldName)
+			cpp.Typedefs.P("int %s_len%s;", fieldName, attr)
 		}
 	}
 
-	for _, oneof := range desc.OneofDecl {
-		typeName := internal.ToUpperCamel(*oneof.Name) + "Case"
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		qName, err := toQualifiedName(typeName, pkg, append(parents, desc))
-		if err != nil {
-			return err
-		}
+	for _, oneof := range msg.Oneofs {
+		typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		qName := toQualifiedName(msg.FullName + "." + typeName)
 		cpp.Typedefs.P("%s %s;", qName, fieldName)
 	}
+	// ラッパー型は値が null かどうかを持つ
+	if msg.WellKnownType() == internal.WellKnownWrapper {
+		cpp.Typedefs.P("bool has_value;")
+	}
 
 	// for _, field := range desc.Field {
 	// 	typeName, defaultValue, err := toTypeName(field)
@@ -281,7 +670,7 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	// 	return err
 	// }
 
-	cpp.Typedefs.PD("} %s;", qName)
+	cpp.Typedefs.PD("} %s%s;", qName, toDeprecatedAttr(msg.Deprecated))
 	cpp.Typedefs.P("")
 
 	// qName, err := toQualifiedName(*desc.Name, pkg, parents)
@@ -361,47 +750,57 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	cpp.Typedefs.P("int %s_size();", qName)
 	cpp.Typedefs.P("void %s_init(%s* v);", qName, qName)
 	cpp.Typedefs.P("void %s_destroy(%s*);", qName, qName)
-	cpp.Typedefs.P("void %s_copy(const %s* a, %s* b);", qName, qName, qName)
-	cpp.Typedefs.P("bool %s_is_equal(const %s* a, const %s* b);", qName, qName, qName)
-	cpp.Typedefs.P("int %s_to_json_size(const %s*);", qName, qName)
-	cpp.Typedefs.P("void %s_to_json(const %s*, char* json);", qName, qName)
-	cpp.Typedefs.P("void %s_from_json(const char* json, %s*);", qName, qName)
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	// map のエントリは map フィールドの要素としてだけ使うので、単体ではシリアライズしない
+	if !msg.MapEntry {
+		cpp.Typedefs.P("void %s_copy(const %s* a, %s* b);", qName, qName, qName)
+		cpp.Typedefs.P("bool %s_is_equal(const %s* a, const %s* b);", qName, qName, qName)
+		cpp.Typedefs.P("int %s_to_json_size(const %s*);", qName, qName)
+		cpp.Typedefs.P("void %s_to_json(const %s*, char* json);", qName, qName)
+		cpp.Typedefs.P("int %s_from_json(const char* json, %s*);", qName, qName)
+		cpp.Typedefs.P("bool %s_try_from_json(const char* json, %s* v, jsonif_error* error);", qName, qName)
+		if msg.HasValidation() {
+			cpp.Typedefs.P("int %s_validate(const %s* v, jsonif_violation** violations);", qName, qName)
+		}
+	}
+	for _, field := range msg.Fields {
+		name := internal.ToSnakeCase(field.Name)
+		attr := toDeprecatedAttr(field.Deprecated)
+		isRepeated := field.Repeated
 		if !isRepeated {
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.Typedefs.P("void %s_set_%s(%s* v, const char* s);", qName, fieldName, qName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.Typedefs.P("void %s_set_%s(%s* v, const uint8_t* buf, int size);", qName, fieldName, qName)
+			if isString(field) {
+				cpp.Typedefs.P("void %s_set_%s(%s* v, const char* s)%s;", qName, name, qName, attr)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.Typedefs.P("void %s_set_%s(%s* v, const uint8_t* buf, int size)%s;", qName, name, qName, attr)
 			} else {
 				typeName, _, _, err := toTypeName(field)
 				if err != nil {
 					return err
 				}
-				if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-					cpp.Typedefs.P("void %s_set_%s(%s* v, const %s* m);", qName, fieldName, qName, typeName)
+				if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+					cpp.Typedefs.P("void %s_set_%s(%s* v, const %s* m)%s;", qName, name, qName, typeName, attr)
 				} else {
-					cpp.Typedefs.P("void %s_set_%s(%s* v, %s m);", qName, fieldName, qName, typeName)
+					cpp.Typedefs.P("void %s_set_%s(%s* v, %s m)%s;", qName, name, qName, typeName, attr)
 				}
 			}
 		}
 		if isRepeated {
-			cpp.Typedefs.P("void %s_alloc_%s(%s* v, int num);", qName, fieldName, qName)
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.Typedefs.P("void %s_set_%s(%s* v, int n, const char* s);", qName, fieldName, qName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.Typedefs.P("void %s_set_%s(%s* v, int n, const uint8_t* buf, int size);", qName, fieldName, qName)
+			cpp.Typedefs.P("void %s_alloc_%s(%s* v, int num)%s;", qName, name, qName, attr)
+			if field.IsMap() {
+				// map の各要素はエントリの set_key, set_value で設定する
+			} else if isString(field) {
+				cpp.Typedefs.P("void %s_set_%s(%s* v, int n, const char* s)%s;", qName, name, qName, attr)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.Typedefs.P("void %s_set_%s(%s* v, int n, const uint8_t* buf, int size)%s;", qName, name, qName, attr)
 			} else {
 				typeName, _, _, err := toTypeName(field)
 				if err != nil {
 					return err
 				}
 				typeName = strings.ReplaceAll(typeName, "*", "")
-				if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-					cpp.Typedefs.P("void %s_set_%s(%s* v, int n, const %s* m);", qName, fieldName, qName, typeName)
+				if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+					cpp.Typedefs.P("void %s_set_%s(%s* v, int n, const %s* m)%s;", qName, name, qName, typeName, attr)
 				} else {
-					cpp.Typedefs.P("void %s_set_%s(%s* v, int n, %s m);", qName, fieldName, qName, typeName)
+					cpp.Typedefs.P("void %s_set_%s(%s* v, int n, %s m)%s;", qName, name, qName, typeName, attr)
 				}
 			}
 		}
@@ -411,159 +810,46 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	// optional has_<field> declarations
 	// optional clear_<field> declarations
 	// oneof clear_<field> declarations
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		if oneof := field.OneofIndex; oneof != nil {
-			if field.Proto3Optional != nil && *field.Proto3Optional {
-				cpp.Typedefs.P("bool %s_has_%s(const %s* v);", qName, fieldName, qName)
+	for _, field := range msg.Fields {
+		name := internal.ToSnakeCase(field.Name)
+		attr := toDeprecatedAttr(field.Deprecated)
+		if oneof := field.Oneof; oneof != nil {
+			if field.Optional {
+				cpp.Typedefs.P("bool %s_has_%s(const %s* v)%s;", qName, name, qName, attr)
 			}
-			cpp.Typedefs.P("void %s_clear_%s(%s* v);", qName, fieldName, qName)
+			cpp.Typedefs.P("void %s_clear_%s(%s* v)%s;", qName, name, qName, attr)
 		}
 	}
 
 	// oneof clear_<case> declarations
-	for _, oneof := range desc.OneofDecl {
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+	for _, oneof := range msg.Oneofs {
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
 		cpp.Typedefs.P("void %s_clear_%s(%s* v);", qName, fieldName, qName)
 	}
 
-	qCppName, err := toCppQualifiedName(*desc.Name, pkg, parents)
-	// C++ 専用の宣言
-	cpp.HppDefs.P("%s %s_to_cpp(const %s* v);", qCppName, qName, qName)
-	cpp.HppDefs.P("void %s_from_cpp(const %s& u, %s* v);", qName, qCppName, qName)
-
-	// to_cpp
-	cpp.CppImpl.PI("%s %s_to_cpp(const %s* v) {", qCppName, qName, qName)
-	cpp.CppImpl.P("%s u;", qCppName)
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
-		if !isRepeated {
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.CppImpl.P("if (v->%s_len != 0) u.%s = std::string(v->%s, v->%s_len);", fieldName, fieldName, fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CppImpl.P("if (v->%s_len != 0) u.%s = std::string((const char*)v->%s, v->%s_len);", fieldName, fieldName, fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-				typeName, err := getMessageTypeName(field)
-				if err != nil {
-					return err
-				}
-				cpp.CppImpl.P("u.%s = %s_to_cpp(&v->%s);", fieldName, typeName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
-				cpp.CppImpl.P("u.%s = (decltype(u.%s))v->%s;", fieldName, fieldName, fieldName)
-			} else {
-				cpp.CppImpl.P("u.%s = v->%s;", fieldName, fieldName)
-			}
+	qCppName := toCppQualifiedName(msg.FullName)
+	// map のエントリに対応する C++ の構造体は無いので、C++ との変換やシリアライズは生成しない
+	if !msg.MapEntry {
+		// well-known type は C++ の組み込み型と変換する
+		wellKnown := msg.WellKnownType() != internal.NotWellKnown
+		if wellKnown {
+			qCppName = toCppWellKnownTypeName(msg)
 		}
-		if isRepeated {
-			cpp.CppImpl.PI("for (int i = 0; i < v->%s_len; i++) {", fieldName)
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.CppImpl.PI("if (v->%s_lens[i] != 0) {", fieldName)
-				cpp.CppImpl.P("u.%s.push_back(std::string(v->%s[i], v->%s_lens[i]));", fieldName, fieldName, fieldName)
-				cpp.CppImpl.PDI("} else {")
-				cpp.CppImpl.P("u.%s.push_back(\"\");", fieldName)
-				cpp.CppImpl.PD("}")
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CppImpl.PI("if (v->%s_lens[i] != 0) {", fieldName)
-				cpp.CppImpl.P("u.%s.push_back(std::string((const char*)v->%s[i], v->%s_lens[i]));", fieldName, fieldName, fieldName)
-				cpp.CppImpl.PDI("} else {")
-				cpp.CppImpl.P("u.%s.push_back(\"\");", fieldName)
-				cpp.CppImpl.PD("}")
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-				typeName, err := getMessageTypeName(field)
-				if err != nil {
-					return err
-				}
-				cpp.CppImpl.P("u.%s.push_back(%s_to_cpp(&v->%s[i]));", fieldName, typeName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
-				cpp.CppImpl.P("u.%s.push_back((decltype(u.%s[0]))v->%s[i]);", fieldName, fieldName, fieldName)
-			} else {
-				cpp.CppImpl.P("u.%s.push_back(v->%s[i]);", fieldName, fieldName)
-			}
-			cpp.CppImpl.PD("}")
-		}
-	}
-	for _, oneof := range desc.OneofDecl {
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		typeName := internal.ToUpperCamel(*oneof.Name) + "Case"
-		oneofQName, err := toCppQualifiedName(typeName, pkg, append(parents, desc))
-		if err != nil {
-			return err
-		}
-		cpp.CppImpl.P("u.%s = (%s)v->%s;", fieldName, oneofQName, fieldName)
-	}
-	cpp.CppImpl.P("return u;")
-	cpp.CppImpl.PD("}")
 
-	// from_cpp
-	cpp.CppImpl.PI("void %s_from_cpp(const %s& u, %s* v) {", qName, qCppName, qName)
-	cpp.CppImpl.P("%s_destroy(v);", qName)
-	cpp.CppImpl.P("%s_init(v);", qName)
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
-		if !isRepeated {
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.CppImpl.P("if (!u.%s.empty()) v->%s = strdup(u.%s.c_str());", fieldName, fieldName, fieldName)
-				cpp.CppImpl.P("v->%s_len = (int)u.%s.size();", fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CppImpl.PI("if (!u.%s.empty()) {", fieldName)
-				cpp.CppImpl.P("v->%s = (uint8_t*)malloc(sizeof(uint8_t) * u.%s.size());", fieldName, fieldName)
-				cpp.CppImpl.P("memcpy(v->%s, u.%s.data(), u.%s.size());", fieldName, fieldName, fieldName)
-				cpp.CppImpl.PD("}")
-				cpp.CppImpl.P("v->%s_len = (int)u.%s.size();", fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-				typeName, err := getMessageTypeName(field)
-				if err != nil {
-					return err
-				}
-				cpp.CppImpl.P("%s_from_cpp(u.%s, &v->%s);", typeName, fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
-				cpp.CppImpl.P("v->%s = (int)u.%s;", fieldName, fieldName)
-			} else {
-				cpp.CppImpl.P("v->%s = u.%s;", fieldName, fieldName)
-			}
-		}
-		if isRepeated {
-			cpp.CppImpl.P("v->%s_len = (int)u.%s.size();", fieldName, fieldName)
-			cpp.CppImpl.P("v->%s = v->%s_len == 0 ? nullptr : (decltype(v->%s))malloc(sizeof(v->%s[0]) * u.%s.size());",
-				fieldName, fieldName, fieldName, fieldName, fieldName)
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING ||
-				*field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CppImpl.P("v->%s_lens = v->%s_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.%s.size());",
-					fieldName, fieldName, fieldName)
-			}
+		// C++ 専用の宣言
+		cpp.HppDefs.P("%s %s_to_cpp(const %s* v);", qCppName, qName, qName)
+		cpp.HppDefs.P("void %s_from_cpp(const %s& u, %s* v);", qName, qCppName, qName)
 
-			cpp.CppImpl.PI("for (int i = 0; i < (int)u.%s.size(); i++) {", fieldName)
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.CppImpl.P("if (!u.%s[i].empty()) v->%s[i] = strdup(u.%s[i].c_str());", fieldName, fieldName, fieldName)
-				cpp.CppImpl.P("v->%s_lens[i] = (int)u.%s[i].size();", fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CppImpl.PI("if (!u.%s[i].empty()) {", fieldName)
-				cpp.CppImpl.P("v->%s[i] = (uint8_t*)malloc(sizeof(uint8_t) * u.%s[i].size());", fieldName, fieldName)
-				cpp.CppImpl.P("memcpy(v->%s[i], u.%s[i].data(), u.%s[i].size());", fieldName, fieldName, fieldName)
-				cpp.CppImpl.PD("}")
-				cpp.CppImpl.P("v->%s_lens[i] = (int)u.%s[i].size();", fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-				typeName, err := getMessageTypeName(field)
-				if err != nil {
-					return err
-				}
-				cpp.CppImpl.P("%s_init(&v->%s[i]);", typeName, fieldName)
-				cpp.CppImpl.P("%s_from_cpp(u.%s[i], &v->%s[i]);", typeName, fieldName, fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
-				cpp.CppImpl.P("v->%s[i] = (int)u.%s[i];", fieldName, fieldName)
-			} else {
-				cpp.CppImpl.P("v->%s[i] = u.%s[i];", fieldName, fieldName)
+		if wellKnown {
+			if err := genWellKnownCpp(msg, cpp); err != nil {
+				return err
+			}
+		} else {
+			if err := genMessageCpp(msg, cpp); err != nil {
+				return err
 			}
-			cpp.CppImpl.PD("}")
 		}
 	}
-	for _, oneof := range desc.OneofDecl {
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		cpp.CppImpl.P("v->%s = (int)u.%s;", fieldName, fieldName)
-	}
-	cpp.CppImpl.PD("}")
 
 	// size
 	cpp.CImpl.PI("int %s_size() {", qName)
@@ -573,23 +859,40 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	// init
 	cpp.CImpl.PI("void %s_init(%s* v) {", qName, qName)
 	cpp.CImpl.P("memset(v, 0, sizeof(%s));", qName)
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		if field.HasDefault && isString(field) {
+			if len(field.Default) != 0 {
+				cpp.CImpl.P("v->%s = strdup(%s);", fieldName, internal.CLiteral(field, field.Default))
+				cpp.CImpl.P("v->%s_len = %d;", fieldName, len(field.Default))
+			}
+		} else if field.HasDefault {
+			cpp.CImpl.P("v->%s = %s;", fieldName, internal.CLiteral(field, field.Default))
+		} else if !field.Repeated && field.IsMessage() && hasDefault(field.Message, map[*internal.Message]bool{}) {
+			typeName, err := getMessageTypeName(field)
+			if err != nil {
+				return err
+			}
+			cpp.CImpl.P("%s_init(&v->%s);", typeName, fieldName)
+		}
+	}
 	cpp.CImpl.PD("}")
 
 	// destroy
 	cpp.CImpl.PI("void %s_destroy(%s* v) {", qName, qName)
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		isRepeated := field.Repeated
 		if !isRepeated {
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+			if isString(field) {
 				cpp.CImpl.P("if (v->%s) free(v->%s);", fieldName, fieldName)
 				cpp.CImpl.P("v->%s = nullptr;", fieldName)
 				cpp.CImpl.P("v->%s_len = 0;", fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
 				cpp.CImpl.P("if (v->%s) free(v->%s);", fieldName, fieldName)
 				cpp.CImpl.P("v->%s = nullptr;", fieldName)
 				cpp.CImpl.P("v->%s_len = 0;", fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
 				typeName, err := getMessageTypeName(field)
 				if err != nil {
 					return err
@@ -600,8 +903,8 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 			}
 		}
 		if isRepeated {
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING ||
-				*field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			if isString(field) ||
+				field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
 				cpp.CImpl.PI("for (int i = 0; i < v->%s_len; i++) {", fieldName)
 				cpp.CImpl.P("if (v->%s[i]) free(v->%s[i]);", fieldName, fieldName)
 				cpp.CImpl.P("v->%s[i] = nullptr;", fieldName)
@@ -609,7 +912,7 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 				cpp.CImpl.PD("}")
 				cpp.CImpl.P("if (v->%s_lens) free(v->%s_lens);", fieldName, fieldName)
 				cpp.CImpl.P("v->%s_lens = nullptr;", fieldName)
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
 				typeName, err := getMessageTypeName(field)
 				if err != nil {
 					return err
@@ -623,65 +926,118 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 			cpp.CImpl.P("v->%s_len = 0;", fieldName)
 		}
 	}
+	if msg.WellKnownType() == internal.WellKnownWrapper {
+		cpp.CImpl.P("v->has_value = false;")
+	}
 	cpp.CImpl.PD("}")
 
-	// copy
-	cpp.CImpl.PI("void %s_copy(const %s* a, %s* b) {", qName, qName, qName)
-	cpp.CImpl.P("if (a == b) return;")
-	cpp.CImpl.P("int size = %s_to_json_size(a);", qName)
-	cpp.CImpl.P("std::string json(size - 1, 0);")
-	cpp.CImpl.P("%s_to_json(a, &json[0]);", qName)
-	cpp.CImpl.P("%s_from_json(json.c_str(), b);", qName)
-	cpp.CImpl.PD("}")
+	if !msg.MapEntry {
+		// copy
+		cpp.CImpl.PI("void %s_copy(const %s* a, %s* b) {", qName, qName, qName)
+		cpp.CImpl.P("if (a == b) return;")
+		// JSON を経由せずに C++ の型でコピーするので、jsonif_last_error() も変わらない
+		cpp.CImpl.P("%s u = %s_to_cpp(a);", qCppName, qName)
+		cpp.CImpl.P("%s_from_cpp(u, b);", qName)
+		cpp.CImpl.PD("}")
 
-	// is_equal
-	cpp.CImpl.PI("bool %s_is_equal(const %s* a, const %s* b) {", qName, qName, qName)
-	cpp.CImpl.P("if (a == b) return true;")
-	cpp.CImpl.P("%s ua = %s_to_cpp(a);", qCppName, qName)
-	cpp.CImpl.P("%s ub = %s_to_cpp(b);", qCppName, qName)
-	cpp.CImpl.P("return ua == ub;")
-	cpp.CImpl.PD("}")
+		// is_equal
+		cpp.CImpl.PI("bool %s_is_equal(const %s* a, const %s* b) {", qName, qName, qName)
+		cpp.CImpl.P("if (a == b) return true;")
+		cpp.CImpl.P("%s ua = %s_to_cpp(a);", qCppName, qName)
+		cpp.CImpl.P("%s ub = %s_to_cpp(b);", qCppName, qName)
+		cpp.CImpl.P("return ua == ub;")
+		cpp.CImpl.PD("}")
 
-	// to_json_size
-	cpp.CImpl.PI("int %s_to_json_size(const %s* v) {", qName, qName)
-	cpp.CImpl.P("%s u = %s_to_cpp(v);", qCppName, qName)
-	cpp.CImpl.P("return jsonif::to_json(u).size() + 1;")
-	cpp.CImpl.PD("}")
+		// to_json_size
+		cpp.CImpl.PI("int %s_to_json_size(const %s* v) {", qName, qName)
+		cpp.CImpl.P("%s u = %s_to_cpp(v);", qCppName, qName)
+		cpp.CImpl.P("return jsonif::to_json(u).size() + 1;")
+		cpp.CImpl.PD("}")
 
-	// to_json
-	cpp.CImpl.PI("void %s_to_json(const %s* v, char* json) {", qName, qName)
-	cpp.CImpl.P("%s u = %s_to_cpp(v);", qCppName, qName)
-	cpp.CImpl.P("std::string str = jsonif::to_json(u);")
-	cpp.CImpl.P("memcpy(json, str.c_str(), str.size() + 1);")
-	cpp.CImpl.PD("}")
+		// to_json
+		cpp.CImpl.PI("void %s_to_json(const %s* v, char* json) {", qName, qName)
+		cpp.CImpl.P("%s u = %s_to_cpp(v);", qCppName, qName)
+		cpp.CImpl.P("std::string str = jsonif::to_json(u);")
+		cpp.CImpl.P("memcpy(json, str.c_str(), str.size() + 1);")
+		cpp.CImpl.PD("}")
 
-	// from_json
-	cpp.CImpl.PI("void %s_from_json(const char* json, %s* v) {", qName, qName)
-	cpp.CImpl.P("%s u = jsonif::from_json<%s>(json);", qCppName, qCppName)
-	cpp.CImpl.P("%s_from_cpp(u, v);", qName)
-	cpp.CImpl.PD("}")
+		// from_json
+		cpp.CImpl.PI("int %s_from_json(const char* json, %s* v) {", qName, qName)
+		cpp.CImpl.P("jsonif_error* error = &jsonif_last_error_storage().error;")
+		cpp.CImpl.P("jsonif_error_free(error);")
+		cpp.CImpl.P("return %s_try_from_json(json, v, error) ? 0 : -1;", qName)
+		cpp.CImpl.PD("}")
+
+		// try_from_json
+		// C++ の例外を C に伝えないように、全ての例外を受け止める
+		cpp.CImpl.PI("bool %s_try_from_json(const char* json, %s* v, jsonif_error* error) {", qName, qName)
+		cpp.CImpl.PI("try {")
+		if msg.WellKnownType() != internal.NotWellKnown {
+			// well-known type は C++ ではメッセージではないので、jsonif::try_from_json を使えない
+			cpp.CImpl.P("%s u = jsonif::from_json<%s>(json);", qCppName, qCppName)
+		} else {
+			cpp.CImpl.P("%s u;", qCppName)
+			cpp.CImpl.P("jsonif::error e;")
+			cpp.CImpl.PI("if (!jsonif::try_from_json(json, u, e)) {")
+			cpp.CImpl.P("jsonif_set_error(error, e.path.c_str(), e.message.c_str());")
+			cpp.CImpl.P("return false;")
+			cpp.CImpl.PD("}")
+		}
+		cpp.CImpl.P("%s_from_cpp(u, v);", qName)
+		cpp.CImpl.P("return true;")
+		cpp.CImpl.PDI("} catch (const jsonif::parse_error& e) {")
+		cpp.CImpl.P("jsonif_set_error(error, e.path().c_str(), e.message().c_str());")
+		cpp.CImpl.PDI("} catch (const std::exception& e) {")
+		cpp.CImpl.P("jsonif_set_error(error, \"\", e.what());")
+		cpp.CImpl.PDI("} catch (...) {")
+		cpp.CImpl.P("jsonif_set_error(error, \"\", \"unknown error\");")
+		cpp.CImpl.PD("}")
+		cpp.CImpl.P("return false;")
+		cpp.CImpl.PD("}")
+
+		// validate
+		if msg.HasValidation() {
+			cpp.CImpl.PI("int %s_validate(const %s* v, jsonif_violation** violations) {", qName, qName)
+			cpp.CImpl.P("%s u = %s_to_cpp(v);", qCppName, qName)
+			cpp.CImpl.P("std::vector<jsonif::violation> r = jsonif::validate(u);")
+			cpp.CImpl.PI("if (violations != NULL) {")
+			cpp.CImpl.P("*violations = NULL;")
+			cpp.CImpl.PI("if (!r.empty()) {")
+			cpp.CImpl.P("*violations = (jsonif_violation*)malloc(sizeof(jsonif_violation) * r.size());")
+			cpp.CImpl.PI("for (size_t i = 0; i < r.size(); i++) {")
+			cpp.CImpl.P("(*violations)[i].path = strdup(r[i].path.c_str());")
+			cpp.CImpl.P("(*violations)[i].message = strdup(r[i].message.c_str());")
+			cpp.CImpl.PD("}")
+			cpp.CImpl.PD("}")
+			cpp.CImpl.PD("}")
+			cpp.CImpl.P("return (int)r.size();")
+			cpp.CImpl.PD("}")
+		}
+	}
 
 	// set_<field>
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	for _, field := range msg.Fields {
+		name := internal.ToSnakeCase(field.Name)
+		fieldName := toFieldName(field)
+		isRepeated := field.Repeated
 		if !isRepeated {
 			genCase := func() error {
-				if oneof := field.OneofIndex; oneof != nil {
-					oneofTypeName := internal.ToUpperCamel(*desc.OneofDecl[*oneof].Name) + "Case"
-					oneofFieldName := internal.ToSnakeCase(*desc.OneofDecl[*oneof].Name) + "_case"
-					oneofQName, err := toQualifiedName(oneofTypeName, pkg, append(parents, desc))
-					if err != nil {
-						return err
-					}
+				if oneof := field.Oneof; oneof != nil {
+					oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+					oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+					oneofQName := toQualifiedName(msg.FullName + "." + oneofTypeName)
 					cpp.CImpl.P("%s_clear_%s(v);", qName, oneofFieldName)
-					cpp.CImpl.P("v->%s = %s_k%s;", oneofFieldName, oneofQName, internal.ToUpperCamel(*field.Name))
+					cpp.CImpl.P("v->%s = %s_k%s;", oneofFieldName, oneofQName, internal.ToUpperCamel(field.Name))
+				}
+				// ラッパー型は値を設定すると null ではなくなる
+				if msg.WellKnownType() == internal.WellKnownWrapper {
+					cpp.CImpl.P("v->has_value = true;")
 				}
 				return nil
 			}
 
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.CImpl.PI("void %s_set_%s(%s* v, const char* s) {", qName, fieldName, qName)
+			if isString(field) {
+				cpp.CImpl.PI("void %s_set_%s(%s* v, const char* s) {", qName, name, qName)
 				err := genCase()
 				if err != nil {
 					return err
@@ -690,8 +1046,8 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 				cpp.CImpl.P("v->%s_len = s == nullptr ? 0 : strlen(s);", fieldName)
 				cpp.CImpl.P("v->%s = v->%s_len == 0 ? nullptr : strdup(s);", fieldName, fieldName)
 				cpp.CImpl.PD("}")
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CImpl.PI("void %s_set_%s(%s* v, const uint8_t* buf, int size) {", qName, fieldName, qName)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.CImpl.PI("void %s_set_%s(%s* v, const uint8_t* buf, int size) {", qName, name, qName)
 				err := genCase()
 				if err != nil {
 					return err
@@ -709,8 +1065,8 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 				if err != nil {
 					return err
 				}
-				if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-					cpp.CImpl.PI("void %s_set_%s(%s* v, const %s* m) {", qName, fieldName, qName, typeName)
+				if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+					cpp.CImpl.PI("void %s_set_%s(%s* v, const %s* m) {", qName, name, qName, typeName)
 					err := genCase()
 					if err != nil {
 						return err
@@ -718,7 +1074,7 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 					cpp.CImpl.P("%s_copy(m, &v->%s);", typeName, fieldName)
 					cpp.CImpl.PD("}")
 				} else {
-					cpp.CImpl.PI("void %s_set_%s(%s* v, %s m) {", qName, fieldName, qName, typeName)
+					cpp.CImpl.PI("void %s_set_%s(%s* v, %s m) {", qName, name, qName, typeName)
 					err := genCase()
 					if err != nil {
 						return err
@@ -729,7 +1085,7 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 			}
 		}
 		if isRepeated {
-			cpp.CImpl.PI("void %s_alloc_%s(%s* v, int num) {", qName, fieldName, qName)
+			cpp.CImpl.PI("void %s_alloc_%s(%s* v, int num) {", qName, name, qName)
 			cpp.CImpl.P("if (v->%s) free(v->%s);", fieldName, fieldName)
 			cpp.CImpl.P("v->%s = nullptr;", fieldName)
 			cpp.CImpl.P("v->%s_len = 0;", fieldName)
@@ -737,22 +1093,24 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 			cpp.CImpl.P("v->%s = (decltype(v->%s))malloc(sizeof(v->%s[0]) * num);", fieldName, fieldName, fieldName)
 			cpp.CImpl.P("memset(v->%s, 0, sizeof(v->%s[0]) * num);", fieldName, fieldName)
 			cpp.CImpl.P("v->%s_len = num;", fieldName)
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING ||
-				*field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			if isString(field) ||
+				field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
 				cpp.CImpl.P("v->%s_lens = (decltype(v->%s_lens))malloc(sizeof(v->%s_lens[0]) * num);", fieldName, fieldName, fieldName)
 				cpp.CImpl.P("memset(v->%s_lens, 0, sizeof(v->%s_lens[0]) * num);", fieldName, fieldName)
 			}
 			cpp.CImpl.PD("}")
 			cpp.CImpl.PD("}")
 			cpp.CImpl.P("")
-			if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
-				cpp.CImpl.PI("void %s_set_%s(%s* v, int n, const char* s) {", qName, fieldName, qName)
+			if field.IsMap() {
+				// map の各要素はエントリの set_key, set_value で設定する
+			} else if isString(field) {
+				cpp.CImpl.PI("void %s_set_%s(%s* v, int n, const char* s) {", qName, name, qName)
 				cpp.CImpl.P("if (v->%s[n]) free(v->%s[n]);", fieldName, fieldName)
 				cpp.CImpl.P("v->%s_lens[n] = s == nullptr ? 0 : strlen(s);", fieldName)
 				cpp.CImpl.P("v->%s[n] = v->%s_lens[n] == 0 ? nullptr : strdup(s);", fieldName, fieldName)
 				cpp.CImpl.PD("}")
-			} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
-				cpp.CImpl.PI("void %s_set_%s(%s* v, int n, const uint8_t* buf, int size) {", qName, fieldName, qName)
+			} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				cpp.CImpl.PI("void %s_set_%s(%s* v, int n, const uint8_t* buf, int size) {", qName, name, qName)
 				cpp.CImpl.P("if (v->%s[n]) free(v->%s[n]);", fieldName, fieldName)
 				cpp.CImpl.P("v->%s[n] = nullptr;", fieldName)
 				cpp.CImpl.P("v->%s_lens[n] = buf == nullptr ? 0 : size;", fieldName)
@@ -767,12 +1125,12 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 					return err
 				}
 				typeName = strings.ReplaceAll(typeName, "*", "")
-				if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-					cpp.CImpl.PI("void %s_set_%s(%s* v, int n, const %s* m) {", qName, fieldName, qName, typeName)
+				if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+					cpp.CImpl.PI("void %s_set_%s(%s* v, int n, const %s* m) {", qName, name, qName, typeName)
 					cpp.CImpl.P("%s_copy(m, &v->%s[n]);", typeName, fieldName)
 					cpp.CImpl.PD("}")
 				} else {
-					cpp.CImpl.PI("void %s_set_%s(%s* v, int n, %s m) {", qName, fieldName, qName, typeName)
+					cpp.CImpl.PI("void %s_set_%s(%s* v, int n, %s m) {", qName, name, qName, typeName)
 					cpp.CImpl.P("v->%s[n] = m;", fieldName)
 					cpp.CImpl.PD("}")
 				}
@@ -783,22 +1141,19 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	// optional has_<field>
 	// optional clear_<field>
 	// oneof clear_<field>
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		if oneof := field.OneofIndex; oneof != nil {
-			oneofTypeName := internal.ToUpperCamel(*desc.OneofDecl[*oneof].Name) + "Case"
-			oneofFieldName := internal.ToSnakeCase(*desc.OneofDecl[*oneof].Name) + "_case"
-			oneofQName, err := toQualifiedName(oneofTypeName, pkg, append(parents, desc))
-			if err != nil {
-				return err
-			}
-			if field.Proto3Optional != nil && *field.Proto3Optional {
-				cpp.CImpl.PI("bool %s_has_%s(const %s* v) {", qName, fieldName, qName)
-				cpp.CImpl.P("return v->%s == %s_k%s;", oneofFieldName, oneofQName, internal.ToUpperCamel(*field.Name))
+	for _, field := range msg.Fields {
+		name := internal.ToSnakeCase(field.Name)
+		if oneof := field.Oneof; oneof != nil {
+			oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			oneofQName := toQualifiedName(msg.FullName + "." + oneofTypeName)
+			if field.Optional {
+				cpp.CImpl.PI("bool %s_has_%s(const %s* v) {", qName, name, qName)
+				cpp.CImpl.P("return v->%s == %s_k%s;", oneofFieldName, oneofQName, internal.ToUpperCamel(field.Name))
 				cpp.CImpl.PD("}")
 			}
-			cpp.CImpl.PI("void %s_clear_%s(%s* v) {", qName, fieldName, qName)
-			cpp.CImpl.PI("if (v->%s == %s_k%s) {", oneofFieldName, oneofQName, internal.ToUpperCamel(*field.Name))
+			cpp.CImpl.PI("void %s_clear_%s(%s* v) {", qName, name, qName)
+			cpp.CImpl.PI("if (v->%s == %s_k%s) {", oneofFieldName, oneofQName, internal.ToUpperCamel(field.Name))
 			cpp.CImpl.P("%s_clear_%s(v);", qName, oneofFieldName)
 			cpp.CImpl.PD("}")
 			cpp.CImpl.PD("}")
@@ -806,35 +1161,26 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	}
 
 	// oneof clear_<case>
-	for i, oneof := range desc.OneofDecl {
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		typeName := internal.ToUpperCamel(*oneof.Name) + "Case"
-		oneofQName, err := toQualifiedName(typeName, pkg, append(parents, desc))
-		if err != nil {
-			return err
-		}
+	for _, oneof := range msg.Oneofs {
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		oneofQName := toQualifiedName(msg.FullName + "." + typeName)
 		cpp.CImpl.PI("void %s_clear_%s(%s* v) {", qName, fieldName, qName)
 
-		var fields []*descriptorpb.FieldDescriptorProto
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fields = append(fields, field)
-			}
-		}
-		for _, field := range fields {
+		for _, field := range oneof.Fields {
 			// destroy 実装からのコピペ
-			fieldName := internal.ToSnakeCase(*field.Name)
-			isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+			fieldName := toFieldName(field)
+			isRepeated := field.Repeated
 			if !isRepeated {
-				if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+				if isString(field) {
 					cpp.CImpl.P("if (v->%s) free(v->%s);", fieldName, fieldName)
 					cpp.CImpl.P("v->%s = nullptr;", fieldName)
 					cpp.CImpl.P("v->%s_len = 0;", fieldName)
-				} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
 					cpp.CImpl.P("if (v->%s) free(v->%s);", fieldName, fieldName)
 					cpp.CImpl.P("v->%s = nullptr;", fieldName)
 					cpp.CImpl.P("v->%s_len = 0;", fieldName)
-				} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
 					typeName, err := getMessageTypeName(field)
 					if err != nil {
 						return err
@@ -845,15 +1191,15 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 				}
 			}
 			if isRepeated {
-				if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING ||
-					*field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+				if isString(field) ||
+					field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
 					cpp.CImpl.PI("for (int i = 0; i < v->%s_len; i++) {", fieldName)
 					cpp.CImpl.P("if (v->%s[i]) free(v->%s[i]);", fieldName, fieldName)
 					cpp.CImpl.P("v->%s[i] = nullptr;", fieldName)
 					cpp.CImpl.P("v->%s_lens[i] = 0;", fieldName)
 					cpp.CImpl.PD("}")
 					cpp.CImpl.P("if (v->%s_lens) free(v->%s_lens);", fieldName, fieldName)
-				} else if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				} else if field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
 					typeName, err := getMessageTypeName(field)
 					if err != nil {
 						return err
@@ -892,9 +1238,318 @@ func toPreprocessorName(name string) string {
 	return r
 }
 
-func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileDescriptorProto) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+// ファイル内に pred を満たすメッセージ（ネストしたものを含む）があるかどうか
+func hasMessageFunc(msgs []*internal.Message, pred func(msg *internal.Message) bool) bool {
+	for _, msg := range msgs {
+		if pred(msg) || hasMessageFunc(msg.Messages, pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// _validate で返す検証ルールの違反の型
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genViolationType(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_C_VIOLATION_DEFINED")
+	f.P("#define JSONIF_C_VIOLATION_DEFINED")
+	f.P("")
+	f.P("#ifdef __cplusplus")
+	f.P("extern \"C\" {")
+	f.P("#endif")
+	f.P("")
+	f.P("// 検証ルールの違反")
+	f.PI("typedef struct {")
+	f.P("// JSON でのフィールドの位置（people[3].name など）")
+	f.P("char* path;")
+	f.P("char* message;")
+	f.PD("} jsonif_violation;")
+	f.P("")
+	f.P("// _validate で受け取った違反の配列を解放する")
+	f.PI("static inline void jsonif_violations_free(jsonif_violation* violations, int len) {")
+	f.PI("for (int i = 0; i < len; i++) {")
+	f.P("free(violations[i].path);")
+	f.P("free(violations[i].message);")
+	f.PD("}")
+	f.P("free(violations);")
+	f.PD("}")
+	f.P("")
+	f.P("#ifdef __cplusplus")
+	f.P("}")
+	f.P("#endif")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// _try_from_json で返すエラーの型
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genErrorType(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_C_ERROR_DEFINED")
+	f.P("#define JSONIF_C_ERROR_DEFINED")
+	f.P("")
+	f.P("#ifdef __cplusplus")
+	f.P("extern \"C\" {")
+	f.P("#endif")
+	f.P("")
+	f.P("// JSON を読み込めなかった理由")
+	f.PI("typedef struct {")
+	f.P("// JSON での位置（people[3].name など）。位置が分からない場合は空文字列")
+	f.P("char* path;")
+	f.P("char* message;")
+	f.PD("} jsonif_error;")
+	f.P("")
+	f.P("// _try_from_json で受け取ったエラーを解放する")
+	f.PI("static inline void jsonif_error_free(jsonif_error* error) {")
+	f.P("free(error->path);")
+	f.P("free(error->message);")
+	f.P("error->path = NULL;")
+	f.P("error->message = NULL;")
+	f.PD("}")
+	f.P("")
+	f.P("#ifdef __cplusplus")
+	f.P("}")
+	f.P("#endif")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// _from_json で最後に失敗した時のエラーを返す jsonif_last_error
+// 最初にインクルードしたヘッダでだけ定義して、そのファイルの関数 fn を呼ぶ
+// どのファイルの fn も同じ変数を返すので、どのファイルの _from_json のエラーも取得できる
+func genLastError(f *internal.Formatter, fn string) {
+	f.P("const jsonif_error* %s(void);", fn)
+	f.P("")
+	f.P("#ifndef JSONIF_C_LAST_ERROR_DEFINED")
+	f.P("#define JSONIF_C_LAST_ERROR_DEFINED")
+	f.P("")
+	f.P("// 同じスレッドで最後に呼び出した _from_json のエラー")
+	f.P("// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効")
+	f.PI("static inline const jsonif_error* jsonif_last_error(void) {")
+	f.P("return %s();", fn)
+	f.PD("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// Struct, Value, ListValue などの JSON の文字列を読み込んだ木の型と、その木を辿る関数
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genJsonType(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_C_JSON_DEFINED")
+	f.P("#define JSONIF_C_JSON_DEFINED")
+	f.P("")
+	f.P("#ifdef __cplusplus")
+	f.P("extern \"C\" {")
+	f.P("#endif")
+	f.P("")
+	f.P("// JSON の値の種類")
+	f.PI("typedef enum {")
+	f.P("JSONIF_JSON_NULL,")
+	f.P("JSONIF_JSON_BOOL,")
+	f.P("JSONIF_JSON_NUMBER,")
+	f.P("JSONIF_JSON_STRING,")
+	f.P("JSONIF_JSON_ARRAY,")
+	f.P("JSONIF_JSON_OBJECT,")
+	f.PD("} jsonif_json_type;")
+	f.P("")
+	f.P("// Struct, Value, ListValue などの JSON の文字列を読み込んだ値")
+	f.PI("typedef struct jsonif_json {")
+	f.P("jsonif_json_type type;")
+	f.P("bool bool_value;")
+	f.P("// 数値は全て double で保持する")
+	f.P("double number_value;")
+	f.P("char* string_value;")
+	f.P("// 配列の要素、またはオブジェクトの値")
+	f.P("struct jsonif_json* values;")
+	f.P("// オブジェクトのキー。配列の場合は NULL")
+	f.P("char** keys;")
+	f.P("int len;")
+	f.PD("} jsonif_json;")
+	f.P("")
+	f.P("// jsonif_json_parse で読み込んだ値を解放する")
+	f.PI("static inline void jsonif_json_free(jsonif_json* v) {")
+	f.PI("for (int i = 0; i < v->len; i++) {")
+	f.P("jsonif_json_free(&v->values[i]);")
+	f.P("if (v->keys != NULL) free(v->keys[i]);")
+	f.PD("}")
+	f.P("free(v->string_value);")
+	f.P("free(v->values);")
+	f.P("free(v->keys);")
+	f.P("v->type = JSONIF_JSON_NULL;")
+	f.P("v->string_value = NULL;")
+	f.P("v->values = NULL;")
+	f.P("v->keys = NULL;")
+	f.P("v->len = 0;")
+	f.PD("}")
+	f.P("")
+	f.P("// 配列の i 番目の要素。配列でないか範囲外の場合は NULL を返す")
+	f.PI("static inline const jsonif_json* jsonif_json_at(const jsonif_json* v, int i) {")
+	f.P("if (v->type != JSONIF_JSON_ARRAY || i < 0 || i >= v->len) return NULL;")
+	f.P("return &v->values[i];")
+	f.PD("}")
+	f.P("")
+	f.P("// オブジェクトのキー key の値。オブジェクトでないかキーが無い場合は NULL を返す")
+	f.PI("static inline const jsonif_json* jsonif_json_get(const jsonif_json* v, const char* key) {")
+	f.P("if (v->type != JSONIF_JSON_OBJECT) return NULL;")
+	f.PI("for (int i = 0; i < v->len; i++) {")
+	f.P("if (strcmp(v->keys[i], key) == 0) return &v->values[i];")
+	f.PD("}")
+	f.P("return NULL;")
+	f.PD("}")
+	f.P("")
+	f.P("#ifdef __cplusplus")
+	f.P("}")
+	f.P("#endif")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// JSON の文字列と jsonif_json を変換する jsonif_json_parse と jsonif_json_to_string
+// jsonif_last_error と同じく、最初にインクルードしたヘッダでだけ定義して、そのファイルの関数を呼ぶ
+func genJsonFuncs(f *internal.Formatter, parseFn string, toStringFn string) {
+	f.P("bool %s(const char* json, jsonif_json* v);", parseFn)
+	f.P("char* %s(const jsonif_json* v);", toStringFn)
+	f.P("")
+	f.P("#ifndef JSONIF_C_JSON_FUNCS_DEFINED")
+	f.P("#define JSONIF_C_JSON_FUNCS_DEFINED")
+	f.P("")
+	f.P("// JSON の文字列を読み込む。NULL は JSONIF_JSON_NULL になる")
+	f.P("// 読み込めなかった場合は JSONIF_JSON_NULL にして false を返す。読み込んだ値は jsonif_json_free で解放する")
+	f.PI("static inline bool jsonif_json_parse(const char* json, jsonif_json* v) {")
+	f.P("return %s(json, v);", parseFn)
+	f.PD("}")
+	f.P("")
+	f.P("// JSON の文字列にする。JSONIF_JSON_NULL の場合は NULL を返す。返した文字列は free で解放する")
+	f.PI("static inline char* jsonif_json_to_string(const jsonif_json* v) {")
+	f.P("return %s(v);", toStringFn)
+	f.PD("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// jsonif_json と C++ の JSON の値を変換する関数
+func genJsonConverter(f *internal.Formatter) {
+	f.P("// C++ の JSON の値を jsonif_json に変換する")
+	f.PI("static void jsonif_json_from_cpp(const ::jsonif::json_value& u, jsonif_json* v) {")
+	f.P("v->type = JSONIF_JSON_NULL;")
+	f.P("v->bool_value = false;")
+	f.P("v->number_value = 0;")
+	f.P("v->string_value = NULL;")
+	f.P("v->values = NULL;")
+	f.P("v->keys = NULL;")
+	f.P("v->len = 0;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("if (u.is_boolean()) {")
+	f.P("v->type = JSONIF_JSON_BOOL;")
+	f.P("v->bool_value = u.get<bool>();")
+	f.PDI("} else if (u.is_number()) {")
+	f.P("v->type = JSONIF_JSON_NUMBER;")
+	f.P("v->number_value = u.get<double>();")
+	f.PDI("} else if (u.is_string()) {")
+	f.P("v->type = JSONIF_JSON_STRING;")
+	f.P("v->string_value = strdup(u.get_ref<const std::string&>().c_str());")
+	f.PDI("} else if (u.is_array() || u.is_object()) {")
+	f.P("v->type = u.is_array() ? JSONIF_JSON_ARRAY : JSONIF_JSON_OBJECT;")
+	f.P("v->len = (int)u.size();")
+	f.P("v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * u.size());")
+	f.P("if (u.is_object()) v->keys = (char**)malloc(sizeof(char*) * u.size());")
+	f.P("int i = 0;")
+	f.PI("for (auto it = u.begin(); it != u.end(); ++it, ++i) {")
+	f.P("if (u.is_object()) v->keys[i] = strdup(it.key().c_str());")
+	f.P("jsonif_json_from_cpp(*it, &v->values[i]);")
+	f.PD("}")
+	f.PD("}")
+	f.P("#else")
+	f.PI("if (u.is_bool()) {")
+	f.P("v->type = JSONIF_JSON_BOOL;")
+	f.P("v->bool_value = u.get_bool();")
+	f.PDI("} else if (u.is_number()) {")
+	f.P("v->type = JSONIF_JSON_NUMBER;")
+	f.P("v->number_value = u.to_number<double>();")
+	f.PDI("} else if (u.is_string()) {")
+	f.P("v->type = JSONIF_JSON_STRING;")
+	f.P("v->string_value = strdup(std::string(u.get_string().data(), u.get_string().size()).c_str());")
+	f.PDI("} else if (u.is_array()) {")
+	f.P("const auto& a = u.get_array();")
+	f.P("v->type = JSONIF_JSON_ARRAY;")
+	f.P("v->len = (int)a.size();")
+	f.P("v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * a.size());")
+	f.PI("for (int i = 0; i < v->len; i++) {")
+	f.P("jsonif_json_from_cpp(a[i], &v->values[i]);")
+	f.PD("}")
+	f.PDI("} else if (u.is_object()) {")
+	f.P("const auto& o = u.get_object();")
+	f.P("v->type = JSONIF_JSON_OBJECT;")
+	f.P("v->len = (int)o.size();")
+	f.P("v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * o.size());")
+	f.P("v->keys = (char**)malloc(sizeof(char*) * o.size());")
+	f.P("int i = 0;")
+	f.PI("for (const auto& kv : o) {")
+	f.P("v->keys[i] = strdup(std::string(kv.key().data(), kv.key().size()).c_str());")
+	f.P("jsonif_json_from_cpp(kv.value(), &v->values[i]);")
+	f.P("i++;")
+	f.PD("}")
+	f.PD("}")
+	f.P("#endif")
+	f.PD("}")
+	f.P("")
+	f.P("// jsonif_json を C++ の JSON の値に変換する")
+	f.PI("static ::jsonif::json_value jsonif_json_to_cpp(const jsonif_json* v) {")
+	f.PI("if (v->type == JSONIF_JSON_BOOL) {")
+	f.P("return ::jsonif::json_value(v->bool_value);")
+	f.PDI("} else if (v->type == JSONIF_JSON_NUMBER) {")
+	f.P("// 整数の値は小数点を付けずに出力する")
+	f.P("double n = v->number_value;")
+	f.P("if (n >= -9007199254740992.0 && n <= 9007199254740992.0 && (double)(int64_t)n == n) return ::jsonif::json_value((int64_t)n);")
+	f.P("return ::jsonif::json_value(n);")
+	f.PDI("} else if (v->type == JSONIF_JSON_STRING) {")
+	f.P("return ::jsonif::json_value(v->string_value == NULL ? \"\" : v->string_value);")
+	f.PDI("} else if (v->type == JSONIF_JSON_ARRAY) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("::jsonif::json_value a = ::jsonif::json_value::array();")
+	f.P("#else")
+	f.P("boost::json::array a;")
+	f.P("#endif")
+	f.PI("for (int i = 0; i < v->len; i++) {")
+	f.P("a.push_back(jsonif_json_to_cpp(&v->values[i]));")
+	f.PD("}")
+	f.P("return a;")
+	f.PDI("} else if (v->type == JSONIF_JSON_OBJECT) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("::jsonif::json_value o = ::jsonif::json_value::object();")
+	f.P("#else")
+	f.P("boost::json::object o;")
+	f.P("#endif")
+	f.PI("for (int i = 0; i < v->len; i++) {")
+	f.P("o[v->keys[i]] = jsonif_json_to_cpp(&v->values[i]);")
+	f.PD("}")
+	f.P("return o;")
+	f.PD("}")
+	f.P("return ::jsonif::json_value(nullptr);")
+	f.PD("}")
+	f.P("")
+}
+
+// deprecated な要素に付ける属性のマクロ
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genDeprecatedMacro(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_C_DEPRECATED")
+	f.P("#if defined(__GNUC__) || defined(__clang__)")
+	f.P("#define JSONIF_C_DEPRECATED __attribute__((deprecated))")
+	f.P("#else")
+	f.P("#define JSONIF_C_DEPRECATED")
+	f.P("#endif")
+	f.P("#endif")
+	f.P("")
+}
+
+func genFile(file *internal.File) ([]*pluginpb.CodeGeneratorResponse_File, error) {
 	// 拡張子を取り除いて .json.h を付ける
-	fileName := *file.Name
+	fileName := file.Name
 	fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
 	cpphFileName := fileName + ".json.h"
 	hFileName := fileName + ".json.c.h"
@@ -902,46 +1557,87 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 	cppFileName := fileName + ".json.c.cpp"
 
 	depFileNames := []string{}
-	for _, dep := range file.Dependency {
-		// ファイルが存在してない可能性もあるのでチェックする
-		exists := false
-		for _, f := range files {
-			if dep == *f.Name {
-				exists = true
-				break
-			}
-		}
-		if !exists {
+	for _, dep := range file.Dependencies {
+		if isJsonValueFile(dep) {
 			continue
 		}
-
 		// 拡張子を取り除く
-		fileName := dep
+		fileName := dep.Name
 		fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
 		depFileNames = append(depFileNames, fileName)
 	}
 
+	useValidate := hasMessageFunc(file.Messages, func(msg *internal.Message) bool { return msg.HasValidation() })
+	useDeprecated := file.UsesDeprecated()
+	// メッセージがあれば _try_from_json と jsonif_last_error を定義する
+	useError := len(file.Messages) != 0
+	// jsonif_last_error の実体はファイルごとに別の名前で定義する
+	lastErrorFunc := "jsonif_last_error_" + strings.ToLower(toPreprocessorName(file.Name))
+	// JSON の文字列で保持するフィールドがあれば jsonif_json を定義する
+	useJson := hasMessageFunc(file.Messages, func(msg *internal.Message) bool {
+		for _, field := range msg.Fields {
+			if isJsonValue(field) {
+				return true
+			}
+		}
+		return false
+	})
+	// jsonif_json_parse などの実体もファイルごとに別の名前で定義する
+	jsonParseFunc := "jsonif_json_parse_" + strings.ToLower(toPreprocessorName(file.Name))
+	jsonToStringFunc := "jsonif_json_to_string_" + strings.ToLower(toPreprocessorName(file.Name))
+
 	cpp := cFile{}
-	cpp.HTop.P("#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_%s", toPreprocessorName(*file.Name))
-	cpp.HTop.P("#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_%s", toPreprocessorName(*file.Name))
+	cpp.HTop.P("#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_%s", toPreprocessorName(file.Name))
+	cpp.HTop.P("#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_%s", toPreprocessorName(file.Name))
 	cpp.HTop.P("")
 	cpp.HTop.P("#include <stdbool.h>")
 	cpp.HTop.P("#include <stddef.h>")
 	cpp.HTop.P("#include <stdint.h>")
+	if useValidate || useError || useJson {
+		cpp.HTop.P("#include <stdlib.h>")
+	}
+	if useJson {
+		cpp.HTop.P("#include <string.h>")
+	}
 	cpp.HTop.P("")
 	for _, fileName := range depFileNames {
 		cpp.HTop.P("#include \"%s\"", fileName+".json.c.h")
 	}
 	cpp.HTop.P("")
+	if useValidate {
+		genViolationType(&cpp.HTop)
+	}
+	if useError {
+		genErrorType(&cpp.HTop)
+	}
+	if useJson {
+		genJsonType(&cpp.HTop)
+	}
+	if useDeprecated {
+		genDeprecatedMacro(&cpp.HTop)
+		// 生成したコード自身が deprecated な要素を参照した時の警告は出さない
+		internal.DisableDeprecatedWarnings(&cpp.HTop)
+		cpp.HTop.P("")
+	}
 	cpp.HTop.P("#ifdef __cplusplus")
 	cpp.HTop.P("extern \"C\" {")
 	cpp.HTop.P("#endif")
 	cpp.HTop.P("")
+	if useError {
+		genLastError(&cpp.HTop, lastErrorFunc)
+	}
+	if useJson {
+		genJsonFuncs(&cpp.HTop, jsonParseFunc, jsonToStringFunc)
+	}
 	cpp.HBottom.P("")
 	cpp.HBottom.P("#ifdef __cplusplus")
 	cpp.HBottom.P("}")
 	cpp.HBottom.P("#endif")
 	cpp.HBottom.P("")
+	if useDeprecated {
+		internal.RestoreDeprecatedWarnings(&cpp.HBottom)
+		cpp.HBottom.P("")
+	}
 	cpp.HBottom.P("#endif")
 
 	cpp.CTop.P("#include \"%s\"", hFileName)
@@ -955,13 +1651,76 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 		cpp.CTop.P("#include \"%s\"", fileName+".json.c.hpp")
 	}
 	cpp.CTop.P("")
+	if useDeprecated {
+		internal.DisableDeprecatedWarnings(&cpp.CTop)
+		cpp.CTop.P("")
+		cpp.CBottom.P("")
+		internal.RestoreDeprecatedWarnings(&cpp.CBottom)
+	}
+	if useError {
+		// _try_from_json は jsonif::try_from_json を使うので、C++ 用のコードにも生成しておく必要がある
+		cpp.CTop.P("#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)")
+		cpp.CTop.P("#error \"%s requires C++ headers generated with the try_from_json parameter\"", file.Name)
+		cpp.CTop.P("#endif")
+		cpp.CTop.P("")
+		cpp.CTop.P("// error が NULL でなければ、path と message をコピーして設定する")
+		cpp.CTop.PI("static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {")
+		cpp.CTop.P("if (error == NULL) return;")
+		cpp.CTop.P("// 前のエラーが残っていても漏れないように解放してから設定する")
+		cpp.CTop.P("jsonif_error_free(error);")
+		cpp.CTop.P("error->path = strdup(path);")
+		cpp.CTop.P("error->message = strdup(message);")
+		cpp.CTop.PD("}")
+		cpp.CTop.P("")
+	}
+	if useError {
+		cpp.CTop.P("// _from_json で最後に失敗した時のエラー")
+		cpp.CTop.P("// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする")
+		cpp.CTop.PI("struct jsonif_last_error_holder {")
+		cpp.CTop.P("jsonif_error error = {NULL, NULL};")
+		cpp.CTop.P("~jsonif_last_error_holder() { jsonif_error_free(&error); }")
+		cpp.CTop.PD("};")
+		cpp.CTop.PI("inline jsonif_last_error_holder& jsonif_last_error_storage() {")
+		cpp.CTop.P("thread_local jsonif_last_error_holder holder;")
+		cpp.CTop.P("return holder;")
+		cpp.CTop.PD("}")
+		cpp.CTop.P("")
+	}
+	if useJson {
+		genJsonConverter(&cpp.CTop)
+	}
 	cpp.CImplTop.P("extern \"C\" {")
 	cpp.CImplTop.P("")
+	if useError {
+		cpp.CImplTop.PI("const jsonif_error* %s(void) {", lastErrorFunc)
+		cpp.CImplTop.P("return &jsonif_last_error_storage().error;")
+		cpp.CImplTop.PD("}")
+		cpp.CImplTop.P("")
+	}
+	if useJson {
+		cpp.CImplTop.PI("bool %s(const char* json, jsonif_json* v) {", jsonParseFunc)
+		cpp.CImplTop.PI("try {")
+		cpp.CImplTop.P("::jsonif::json_value u;")
+		cpp.CImplTop.P("if (json != nullptr) u = jsonif::from_json<::jsonif::json_value>(std::string(json));")
+		cpp.CImplTop.P("jsonif_json_from_cpp(u, v);")
+		cpp.CImplTop.P("return true;")
+		cpp.CImplTop.PDI("} catch (...) {")
+		cpp.CImplTop.P("jsonif_json_from_cpp(::jsonif::json_value(nullptr), v);")
+		cpp.CImplTop.P("return false;")
+		cpp.CImplTop.PD("}")
+		cpp.CImplTop.PD("}")
+		cpp.CImplTop.PI("char* %s(const jsonif_json* v) {", jsonToStringFunc)
+		cpp.CImplTop.P("if (v->type == JSONIF_JSON_NULL) return NULL;")
+		cpp.CImplTop.P("std::string json = jsonif::to_json(jsonif_json_to_cpp(v));")
+		cpp.CImplTop.P("return strdup(json.c_str());")
+		cpp.CImplTop.PD("}")
+		cpp.CImplTop.P("")
+	}
 	cpp.CImplBottom.P("")
 	cpp.CImplBottom.P("}")
 
-	cpp.HppTop.P("#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_%s", toPreprocessorName(*file.Name))
-	cpp.HppTop.P("#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_%s", toPreprocessorName(*file.Name))
+	cpp.HppTop.P("#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_%s", toPreprocessorName(file.Name))
+	cpp.HppTop.P("#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_%s", toPreprocessorName(file.Name))
 	cpp.HppTop.P("")
 	cpp.HppTop.P("#include \"%s\"", cpphFileName)
 	cpp.HppTop.P("#include \"%s\"", hFileName)
@@ -970,17 +1729,25 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 		cpp.HppTop.P("#include \"%s\"", fileName+".json.c.hpp")
 	}
 	cpp.HppTop.P("")
+	if useDeprecated {
+		internal.DisableDeprecatedWarnings(&cpp.HppTop)
+		cpp.HppTop.P("")
+	}
 	cpp.HppBottom.P("")
+	if useDeprecated {
+		internal.RestoreDeprecatedWarnings(&cpp.HppBottom)
+		cpp.HppBottom.P("")
+	}
 	cpp.HppBottom.P("#endif")
 
-	for _, enum := range file.EnumType {
-		if err := genEnum(enum, file.Package, nil, &cpp); err != nil {
+	for _, enum := range file.Enums {
+		if err := genEnum(enum, &cpp); err != nil {
 			return nil, err
 		}
 	}
 
-	for _, desc := range file.MessageType {
-		if err := genDescriptor(desc, file.Package, nil, &cpp); err != nil {
+	for _, msg := range file.MessagesInDefinitionOrder() {
+		if err := genDescriptor(msg, &cpp); err != nil {
 			return nil, err
 		}
 	}
@@ -1002,11 +1769,144 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 	return resp, nil
 }
 
-func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+func checkEnumNames(enum *internal.Enum, global *internal.NameScope) error {
+	if err := global.Add(toQualifiedName(enum.FullName), enum); err != nil {
+		return err
+	}
+	qEnumName := toEnumQualifiedName(enum)
+	for _, v := range enum.Values {
+		if err := global.Add(qEnumName+"_"+v.Name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkMessageNames(msg *internal.Message, global *internal.NameScope) error {
+	for _, enum := range msg.Enums {
+		if err := checkEnumNames(enum, global); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if err := checkMessageNames(nested, global); err != nil {
+			return err
+		}
+	}
+
+	qName := toQualifiedName(msg.FullName)
+	if err := global.Add(qName, msg); err != nil {
+		return err
+	}
+	for _, fn := range []string{"size", "init", "destroy", "copy", "is_equal", "to_json_size", "to_json", "from_json", "try_from_json", "to_cpp", "from_cpp", "validate"} {
+		if err := global.Add(qName+"_"+fn, msg); err != nil {
+			return err
+		}
+	}
+
+	// 構造体のメンバ
+	members := &internal.NameScope{}
+	for _, field := range msg.Fields {
+		_, isRepeated, needLen, err := toTypeName(field)
+		if err != nil {
+			return err
+		}
+		fieldName := toFieldName(field)
+		names := []string{fieldName}
+		if isRepeated && needLen {
+			names = append(names, fieldName+"_lens")
+		}
+		if isRepeated || needLen {
+			names = append(names, fieldName+"_len")
+		}
+		for _, name := range names {
+			if err := members.Add(name, field); err != nil {
+				return err
+			}
+		}
+
+		// 関数名にはエスケープする前の名前を使う
+		name := internal.ToSnakeCase(field.Name)
+		fns := []string{"set_" + name}
+		if isRepeated {
+			fns = append(fns, "alloc_"+name)
+		}
+		if field.Oneof != nil {
+			fns = append(fns, "clear_"+name)
+			if field.Optional {
+				fns = append(fns, "has_"+name)
+			}
+		}
+		for _, fn := range fns {
+			if err := global.Add(qName+"_"+fn, field); err != nil {
+				return err
+			}
+		}
+	}
+	if msg.WellKnownType() == internal.WellKnownWrapper {
+		if err := members.Add("has_value", msg); err != nil {
+			return err
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		if err := members.Add(fieldName, oneof); err != nil {
+			return err
+		}
+		if err := global.Add(qName+"_clear_"+fieldName, oneof); err != nil {
+			return err
+		}
+		qOneofName := toQualifiedName(msg.FullName + "." + internal.ToUpperCamel(oneof.Name) + "Case")
+		names := []string{qOneofName, qOneofName + "_NOT_SET"}
+		for _, field := range oneof.Fields {
+			names = append(names, qOneofName+"_k"+internal.ToUpperCamel(field.Name))
+		}
+		for _, name := range names {
+			if err := global.Add(name, oneof); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 生成する識別子が衝突していないか調べる
+// C ではネストした型や関数も全てグローバルな名前になるので、全てのファイルをまとめて調べる
+func checkNames(schema *internal.Schema) error {
+	global := &internal.NameScope{}
+	for _, file := range schema.Files {
+		if isJsonValueFile(file) {
+			continue
+		}
+		for _, enum := range file.Enums {
+			if err := checkEnumNames(enum, global); err != nil {
+				return err
+			}
+		}
+		for _, msg := range file.Messages {
+			if err := checkMessageNames(msg, global); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func gen(req *pluginpb.CodeGeneratorRequest, opts *options) (*pluginpb.CodeGeneratorResponse, error) {
 	resp := &pluginpb.CodeGeneratorResponse{}
 	resp.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
-	for _, file := range req.ProtoFile {
-		respFiles, err := genFile(file, req.ProtoFile)
+	schema, err := internal.NewSchema(req.ProtoFile, &opts.CommonOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNames(schema); err != nil {
+		return nil, err
+	}
+	for _, file := range schema.FilesToGenerate(req, &opts.CommonOptions) {
+		if isJsonValueFile(file) {
+			continue
+		}
+		respFiles, err := genFile(file)
 		if err != nil {
 			return nil, err
 		}
@@ -1016,7 +1916,10 @@ func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, e
 }
 
 func main() {
-	err := internal.RunPlugin(gen)
+	opts := &options{}
+	err := internal.RunPlugin(newOptionSet(opts), func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return gen(req, opts)
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
 		os.Exit(1)
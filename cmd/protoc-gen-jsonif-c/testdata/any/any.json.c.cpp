@@ -0,0 +1,573 @@
+#include "any.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "any.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "any.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// C++ の JSON の値を jsonif_json に変換する
+static void jsonif_json_from_cpp(const ::jsonif::json_value& u, jsonif_json* v) {
+  v->type = JSONIF_JSON_NULL;
+  v->bool_value = false;
+  v->number_value = 0;
+  v->string_value = NULL;
+  v->values = NULL;
+  v->keys = NULL;
+  v->len = 0;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (u.is_boolean()) {
+    v->type = JSONIF_JSON_BOOL;
+    v->bool_value = u.get<bool>();
+  } else if (u.is_number()) {
+    v->type = JSONIF_JSON_NUMBER;
+    v->number_value = u.get<double>();
+  } else if (u.is_string()) {
+    v->type = JSONIF_JSON_STRING;
+    v->string_value = strdup(u.get_ref<const std::string&>().c_str());
+  } else if (u.is_array() || u.is_object()) {
+    v->type = u.is_array() ? JSONIF_JSON_ARRAY : JSONIF_JSON_OBJECT;
+    v->len = (int)u.size();
+    v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * u.size());
+    if (u.is_object()) v->keys = (char**)malloc(sizeof(char*) * u.size());
+    int i = 0;
+    for (auto it = u.begin(); it != u.end(); ++it, ++i) {
+      if (u.is_object()) v->keys[i] = strdup(it.key().c_str());
+      jsonif_json_from_cpp(*it, &v->values[i]);
+    }
+  }
+  #else
+  if (u.is_bool()) {
+    v->type = JSONIF_JSON_BOOL;
+    v->bool_value = u.get_bool();
+  } else if (u.is_number()) {
+    v->type = JSONIF_JSON_NUMBER;
+    v->number_value = u.to_number<double>();
+  } else if (u.is_string()) {
+    v->type = JSONIF_JSON_STRING;
+    v->string_value = strdup(std::string(u.get_string().data(), u.get_string().size()).c_str());
+  } else if (u.is_array()) {
+    const auto& a = u.get_array();
+    v->type = JSONIF_JSON_ARRAY;
+    v->len = (int)a.size();
+    v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * a.size());
+    for (int i = 0; i < v->len; i++) {
+      jsonif_json_from_cpp(a[i], &v->values[i]);
+    }
+  } else if (u.is_object()) {
+    const auto& o = u.get_object();
+    v->type = JSONIF_JSON_OBJECT;
+    v->len = (int)o.size();
+    v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * o.size());
+    v->keys = (char**)malloc(sizeof(char*) * o.size());
+    int i = 0;
+    for (const auto& kv : o) {
+      v->keys[i] = strdup(std::string(kv.key().data(), kv.key().size()).c_str());
+      jsonif_json_from_cpp(kv.value(), &v->values[i]);
+      i++;
+    }
+  }
+  #endif
+}
+
+// jsonif_json を C++ の JSON の値に変換する
+static ::jsonif::json_value jsonif_json_to_cpp(const jsonif_json* v) {
+  if (v->type == JSONIF_JSON_BOOL) {
+    return ::jsonif::json_value(v->bool_value);
+  } else if (v->type == JSONIF_JSON_NUMBER) {
+    // 整数の値は小数点を付けずに出力する
+    double n = v->number_value;
+    if (n >= -9007199254740992.0 && n <= 9007199254740992.0 && (double)(int64_t)n == n) return ::jsonif::json_value((int64_t)n);
+    return ::jsonif::json_value(n);
+  } else if (v->type == JSONIF_JSON_STRING) {
+    return ::jsonif::json_value(v->string_value == NULL ? "" : v->string_value);
+  } else if (v->type == JSONIF_JSON_ARRAY) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    ::jsonif::json_value a = ::jsonif::json_value::array();
+    #else
+    boost::json::array a;
+    #endif
+    for (int i = 0; i < v->len; i++) {
+      a.push_back(jsonif_json_to_cpp(&v->values[i]));
+    }
+    return a;
+  } else if (v->type == JSONIF_JSON_OBJECT) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    ::jsonif::json_value o = ::jsonif::json_value::object();
+    #else
+    boost::json::object o;
+    #endif
+    for (int i = 0; i < v->len; i++) {
+      o[v->keys[i]] = jsonif_json_to_cpp(&v->values[i]);
+    }
+    return o;
+  }
+  return ::jsonif::json_value(nullptr);
+}
+
+::anypb::Payload anypb_Payload_to_cpp(const anypb_Payload* v) {
+  ::anypb::Payload u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.count = v->count;
+  return u;
+}
+void anypb_Payload_from_cpp(const ::anypb::Payload& u, anypb_Payload* v) {
+  anypb_Payload_destroy(v);
+  memset(v, 0, sizeof(anypb_Payload));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  v->count = u.count;
+}
+::anypb::Test::Nested anypb_Test_Nested_to_cpp(const anypb_Test_Nested* v) {
+  ::anypb::Test::Nested u;
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags_lens[i] != 0) {
+      u.tags.push_back(std::string(v->tags[i], v->tags_lens[i]));
+    } else {
+      u.tags.push_back("");
+    }
+  }
+  return u;
+}
+void anypb_Test_Nested_from_cpp(const ::anypb::Test::Nested& u, anypb_Test_Nested* v) {
+  anypb_Test_Nested_destroy(v);
+  memset(v, 0, sizeof(anypb_Test_Nested));
+  v->tags_len = (int)u.tags.size();
+  v->tags = v->tags_len == 0 ? nullptr : (decltype(v->tags))malloc(sizeof(v->tags[0]) * u.tags.size());
+  v->tags_lens = v->tags_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.tags.size());
+  for (int i = 0; i < (int)u.tags.size(); i++) {
+    if (!u.tags[i].empty()) v->tags[i] = strdup(u.tags[i].c_str());
+    v->tags_lens[i] = (int)u.tags[i].size();
+  }
+}
+// kind
+const anypb_Test_KindCase anypb_Test_KindCase_NOT_SET = 0;
+const anypb_Test_KindCase anypb_Test_KindCase_kOneofAny = 4;
+const anypb_Test_KindCase anypb_Test_KindCase_kOneofString = 5;
+
+::anypb::Test anypb_Test_to_cpp(const anypb_Test* v) {
+  ::anypb::Test u;
+  if (v->any != nullptr) u.any = jsonif::from_json<::jsonif::any>(std::string(v->any, v->any_len));
+  for (int i = 0; i < v->anys_len; i++) {
+    if (v->anys[i] != nullptr) {
+      u.anys.push_back(jsonif::from_json<::jsonif::any>(std::string(v->anys[i], v->anys_lens[i])));
+    } else {
+      u.anys.push_back(::jsonif::any());
+    }
+  }
+  for (int i = 0; i < v->any_map_len; i++) {
+    decltype(u.any_map)::key_type key{};
+    decltype(u.any_map)::mapped_type value{};
+    if (v->any_map[i].key_len != 0) key = std::string(v->any_map[i].key, v->any_map[i].key_len);
+    if (v->any_map[i].value != nullptr) value = jsonif::from_json<::jsonif::any>(std::string(v->any_map[i].value, v->any_map[i].value_len));
+    u.any_map.emplace(std::move(key), std::move(value));
+  }
+  if (v->oneof_any != nullptr) u.oneof_any = jsonif::from_json<::jsonif::any>(std::string(v->oneof_any, v->oneof_any_len));
+  if (v->oneof_string_len != 0) u.oneof_string = std::string(v->oneof_string, v->oneof_string_len);
+  u.kind_case = (::anypb::Test::KindCase)v->kind_case;
+  return u;
+}
+void anypb_Test_from_cpp(const ::anypb::Test& u, anypb_Test* v) {
+  anypb_Test_destroy(v);
+  memset(v, 0, sizeof(anypb_Test));
+  if (!u.any.value.is_null()) {
+    std::string json = jsonif::to_json(u.any);
+    v->any = strdup(json.c_str());
+    v->any_len = (int)json.size();
+  }
+  v->anys_len = (int)u.anys.size();
+  v->anys = v->anys_len == 0 ? nullptr : (decltype(v->anys))malloc(sizeof(v->anys[0]) * u.anys.size());
+  v->anys_lens = v->anys_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.anys.size());
+  for (int i = 0; i < (int)u.anys.size(); i++) {
+    v->anys[i] = nullptr;
+    v->anys_lens[i] = 0;
+    if (!u.anys[i].value.is_null()) {
+      std::string json = jsonif::to_json(u.anys[i]);
+      v->anys[i] = strdup(json.c_str());
+      v->anys_lens[i] = (int)json.size();
+    }
+  }
+  v->any_map_len = (int)u.any_map.size();
+  v->any_map = v->any_map_len == 0 ? nullptr : (decltype(v->any_map))malloc(sizeof(v->any_map[0]) * u.any_map.size());
+  int any_map_index = 0;
+  for (const auto& kv : u.any_map) {
+    anypb_Test_AnyMapEntry_init(&v->any_map[any_map_index]);
+    if (!kv.first.empty()) v->any_map[any_map_index].key = strdup(kv.first.c_str());
+    v->any_map[any_map_index].key_len = (int)kv.first.size();
+    if (!kv.second.value.is_null()) {
+      std::string json = jsonif::to_json(kv.second);
+      v->any_map[any_map_index].value = strdup(json.c_str());
+      v->any_map[any_map_index].value_len = (int)json.size();
+    }
+    any_map_index++;
+  }
+  if (!u.oneof_any.value.is_null()) {
+    std::string json = jsonif::to_json(u.oneof_any);
+    v->oneof_any = strdup(json.c_str());
+    v->oneof_any_len = (int)json.size();
+  }
+  if (!u.oneof_string.empty()) v->oneof_string = strdup(u.oneof_string.c_str());
+  v->oneof_string_len = (int)u.oneof_string.size();
+  v->kind_case = (int)u.kind_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_any_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+bool jsonif_json_parse_any_proto(const char* json, jsonif_json* v) {
+  try {
+    ::jsonif::json_value u;
+    if (json != nullptr) u = jsonif::from_json<::jsonif::json_value>(std::string(json));
+    jsonif_json_from_cpp(u, v);
+    return true;
+  } catch (...) {
+    jsonif_json_from_cpp(::jsonif::json_value(nullptr), v);
+    return false;
+  }
+}
+char* jsonif_json_to_string_any_proto(const jsonif_json* v) {
+  if (v->type == JSONIF_JSON_NULL) return NULL;
+  std::string json = jsonif::to_json(jsonif_json_to_cpp(v));
+  return strdup(json.c_str());
+}
+
+int anypb_Payload_size() {
+  return sizeof(anypb_Payload);
+}
+void anypb_Payload_init(anypb_Payload* v) {
+  memset(v, 0, sizeof(anypb_Payload));
+}
+void anypb_Payload_destroy(anypb_Payload* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->count, 0, sizeof(v->count));
+}
+void anypb_Payload_copy(const anypb_Payload* a, anypb_Payload* b) {
+  if (a == b) return;
+  ::anypb::Payload u = anypb_Payload_to_cpp(a);
+  anypb_Payload_from_cpp(u, b);
+}
+bool anypb_Payload_is_equal(const anypb_Payload* a, const anypb_Payload* b) {
+  if (a == b) return true;
+  ::anypb::Payload ua = anypb_Payload_to_cpp(a);
+  ::anypb::Payload ub = anypb_Payload_to_cpp(b);
+  return ua == ub;
+}
+int anypb_Payload_to_json_size(const anypb_Payload* v) {
+  ::anypb::Payload u = anypb_Payload_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void anypb_Payload_to_json(const anypb_Payload* v, char* json) {
+  ::anypb::Payload u = anypb_Payload_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int anypb_Payload_from_json(const char* json, anypb_Payload* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return anypb_Payload_try_from_json(json, v, error) ? 0 : -1;
+}
+bool anypb_Payload_try_from_json(const char* json, anypb_Payload* v, jsonif_error* error) {
+  try {
+    ::anypb::Payload u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    anypb_Payload_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void anypb_Payload_set_name(anypb_Payload* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void anypb_Payload_set_count(anypb_Payload* v, int32_t m) {
+  v->count = m;
+}
+int anypb_Test_Nested_size() {
+  return sizeof(anypb_Test_Nested);
+}
+void anypb_Test_Nested_init(anypb_Test_Nested* v) {
+  memset(v, 0, sizeof(anypb_Test_Nested));
+}
+void anypb_Test_Nested_destroy(anypb_Test_Nested* v) {
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags[i]) free(v->tags[i]);
+    v->tags[i] = nullptr;
+    v->tags_lens[i] = 0;
+  }
+  if (v->tags_lens) free(v->tags_lens);
+  v->tags_lens = nullptr;
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+}
+void anypb_Test_Nested_copy(const anypb_Test_Nested* a, anypb_Test_Nested* b) {
+  if (a == b) return;
+  ::anypb::Test::Nested u = anypb_Test_Nested_to_cpp(a);
+  anypb_Test_Nested_from_cpp(u, b);
+}
+bool anypb_Test_Nested_is_equal(const anypb_Test_Nested* a, const anypb_Test_Nested* b) {
+  if (a == b) return true;
+  ::anypb::Test::Nested ua = anypb_Test_Nested_to_cpp(a);
+  ::anypb::Test::Nested ub = anypb_Test_Nested_to_cpp(b);
+  return ua == ub;
+}
+int anypb_Test_Nested_to_json_size(const anypb_Test_Nested* v) {
+  ::anypb::Test::Nested u = anypb_Test_Nested_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void anypb_Test_Nested_to_json(const anypb_Test_Nested* v, char* json) {
+  ::anypb::Test::Nested u = anypb_Test_Nested_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int anypb_Test_Nested_from_json(const char* json, anypb_Test_Nested* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return anypb_Test_Nested_try_from_json(json, v, error) ? 0 : -1;
+}
+bool anypb_Test_Nested_try_from_json(const char* json, anypb_Test_Nested* v, jsonif_error* error) {
+  try {
+    ::anypb::Test::Nested u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    anypb_Test_Nested_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void anypb_Test_Nested_alloc_tags(anypb_Test_Nested* v, int num) {
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  if (num != 0) {
+    v->tags = (decltype(v->tags))malloc(sizeof(v->tags[0]) * num);
+    memset(v->tags, 0, sizeof(v->tags[0]) * num);
+    v->tags_len = num;
+    v->tags_lens = (decltype(v->tags_lens))malloc(sizeof(v->tags_lens[0]) * num);
+    memset(v->tags_lens, 0, sizeof(v->tags_lens[0]) * num);
+  }
+}
+
+void anypb_Test_Nested_set_tags(anypb_Test_Nested* v, int n, const char* s) {
+  if (v->tags[n]) free(v->tags[n]);
+  v->tags_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->tags[n] = v->tags_lens[n] == 0 ? nullptr : strdup(s);
+}
+int anypb_Test_AnyMapEntry_size() {
+  return sizeof(anypb_Test_AnyMapEntry);
+}
+void anypb_Test_AnyMapEntry_init(anypb_Test_AnyMapEntry* v) {
+  memset(v, 0, sizeof(anypb_Test_AnyMapEntry));
+}
+void anypb_Test_AnyMapEntry_destroy(anypb_Test_AnyMapEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = 0;
+}
+void anypb_Test_AnyMapEntry_set_key(anypb_Test_AnyMapEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void anypb_Test_AnyMapEntry_set_value(anypb_Test_AnyMapEntry* v, const char* s) {
+  if (v->value) free(v->value);
+  v->value_len = s == nullptr ? 0 : strlen(s);
+  v->value = v->value_len == 0 ? nullptr : strdup(s);
+}
+int anypb_Test_size() {
+  return sizeof(anypb_Test);
+}
+void anypb_Test_init(anypb_Test* v) {
+  memset(v, 0, sizeof(anypb_Test));
+}
+void anypb_Test_destroy(anypb_Test* v) {
+  if (v->any) free(v->any);
+  v->any = nullptr;
+  v->any_len = 0;
+  for (int i = 0; i < v->anys_len; i++) {
+    if (v->anys[i]) free(v->anys[i]);
+    v->anys[i] = nullptr;
+    v->anys_lens[i] = 0;
+  }
+  if (v->anys_lens) free(v->anys_lens);
+  v->anys_lens = nullptr;
+  if (v->anys) free(v->anys);
+  v->anys = nullptr;
+  v->anys_len = 0;
+  for (int i = 0; i < v->any_map_len; i++) {
+    anypb_Test_AnyMapEntry_destroy(&v->any_map[i]);
+  }
+  if (v->any_map) free(v->any_map);
+  v->any_map = nullptr;
+  v->any_map_len = 0;
+  if (v->oneof_any) free(v->oneof_any);
+  v->oneof_any = nullptr;
+  v->oneof_any_len = 0;
+  if (v->oneof_string) free(v->oneof_string);
+  v->oneof_string = nullptr;
+  v->oneof_string_len = 0;
+}
+void anypb_Test_copy(const anypb_Test* a, anypb_Test* b) {
+  if (a == b) return;
+  ::anypb::Test u = anypb_Test_to_cpp(a);
+  anypb_Test_from_cpp(u, b);
+}
+bool anypb_Test_is_equal(const anypb_Test* a, const anypb_Test* b) {
+  if (a == b) return true;
+  ::anypb::Test ua = anypb_Test_to_cpp(a);
+  ::anypb::Test ub = anypb_Test_to_cpp(b);
+  return ua == ub;
+}
+int anypb_Test_to_json_size(const anypb_Test* v) {
+  ::anypb::Test u = anypb_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void anypb_Test_to_json(const anypb_Test* v, char* json) {
+  ::anypb::Test u = anypb_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int anypb_Test_from_json(const char* json, anypb_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return anypb_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool anypb_Test_try_from_json(const char* json, anypb_Test* v, jsonif_error* error) {
+  try {
+    ::anypb::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    anypb_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void anypb_Test_set_any(anypb_Test* v, const char* s) {
+  if (v->any) free(v->any);
+  v->any_len = s == nullptr ? 0 : strlen(s);
+  v->any = v->any_len == 0 ? nullptr : strdup(s);
+}
+void anypb_Test_alloc_anys(anypb_Test* v, int num) {
+  if (v->anys) free(v->anys);
+  v->anys = nullptr;
+  v->anys_len = 0;
+  if (num != 0) {
+    v->anys = (decltype(v->anys))malloc(sizeof(v->anys[0]) * num);
+    memset(v->anys, 0, sizeof(v->anys[0]) * num);
+    v->anys_len = num;
+    v->anys_lens = (decltype(v->anys_lens))malloc(sizeof(v->anys_lens[0]) * num);
+    memset(v->anys_lens, 0, sizeof(v->anys_lens[0]) * num);
+  }
+}
+
+void anypb_Test_set_anys(anypb_Test* v, int n, const char* s) {
+  if (v->anys[n]) free(v->anys[n]);
+  v->anys_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->anys[n] = v->anys_lens[n] == 0 ? nullptr : strdup(s);
+}
+void anypb_Test_alloc_any_map(anypb_Test* v, int num) {
+  if (v->any_map) free(v->any_map);
+  v->any_map = nullptr;
+  v->any_map_len = 0;
+  if (num != 0) {
+    v->any_map = (decltype(v->any_map))malloc(sizeof(v->any_map[0]) * num);
+    memset(v->any_map, 0, sizeof(v->any_map[0]) * num);
+    v->any_map_len = num;
+  }
+}
+
+void anypb_Test_set_oneof_any(anypb_Test* v, const char* s) {
+  anypb_Test_clear_kind_case(v);
+  v->kind_case = anypb_Test_KindCase_kOneofAny;
+  if (v->oneof_any) free(v->oneof_any);
+  v->oneof_any_len = s == nullptr ? 0 : strlen(s);
+  v->oneof_any = v->oneof_any_len == 0 ? nullptr : strdup(s);
+}
+void anypb_Test_set_oneof_string(anypb_Test* v, const char* s) {
+  anypb_Test_clear_kind_case(v);
+  v->kind_case = anypb_Test_KindCase_kOneofString;
+  if (v->oneof_string) free(v->oneof_string);
+  v->oneof_string_len = s == nullptr ? 0 : strlen(s);
+  v->oneof_string = v->oneof_string_len == 0 ? nullptr : strdup(s);
+}
+void anypb_Test_clear_oneof_any(anypb_Test* v) {
+  if (v->kind_case == anypb_Test_KindCase_kOneofAny) {
+    anypb_Test_clear_kind_case(v);
+  }
+}
+void anypb_Test_clear_oneof_string(anypb_Test* v) {
+  if (v->kind_case == anypb_Test_KindCase_kOneofString) {
+    anypb_Test_clear_kind_case(v);
+  }
+}
+void anypb_Test_clear_kind_case(anypb_Test* v) {
+  if (v->oneof_any) free(v->oneof_any);
+  v->oneof_any = nullptr;
+  v->oneof_any_len = 0;
+  if (v->oneof_string) free(v->oneof_string);
+  v->oneof_string = nullptr;
+  v->oneof_string_len = 0;
+  v->kind_case = anypb_Test_KindCase_NOT_SET;
+}
+
+}
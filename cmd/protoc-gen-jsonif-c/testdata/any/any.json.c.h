@@ -0,0 +1,241 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ANY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ANY_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifndef JSONIF_C_JSON_DEFINED
+#define JSONIF_C_JSON_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON の値の種類
+typedef enum {
+  JSONIF_JSON_NULL,
+  JSONIF_JSON_BOOL,
+  JSONIF_JSON_NUMBER,
+  JSONIF_JSON_STRING,
+  JSONIF_JSON_ARRAY,
+  JSONIF_JSON_OBJECT,
+} jsonif_json_type;
+
+// Struct, Value, ListValue などの JSON の文字列を読み込んだ値
+typedef struct jsonif_json {
+  jsonif_json_type type;
+  bool bool_value;
+  // 数値は全て double で保持する
+  double number_value;
+  char* string_value;
+  // 配列の要素、またはオブジェクトの値
+  struct jsonif_json* values;
+  // オブジェクトのキー。配列の場合は NULL
+  char** keys;
+  int len;
+} jsonif_json;
+
+// jsonif_json_parse で読み込んだ値を解放する
+static inline void jsonif_json_free(jsonif_json* v) {
+  for (int i = 0; i < v->len; i++) {
+    jsonif_json_free(&v->values[i]);
+    if (v->keys != NULL) free(v->keys[i]);
+  }
+  free(v->string_value);
+  free(v->values);
+  free(v->keys);
+  v->type = JSONIF_JSON_NULL;
+  v->string_value = NULL;
+  v->values = NULL;
+  v->keys = NULL;
+  v->len = 0;
+}
+
+// 配列の i 番目の要素。配列でないか範囲外の場合は NULL を返す
+static inline const jsonif_json* jsonif_json_at(const jsonif_json* v, int i) {
+  if (v->type != JSONIF_JSON_ARRAY || i < 0 || i >= v->len) return NULL;
+  return &v->values[i];
+}
+
+// オブジェクトのキー key の値。オブジェクトでないかキーが無い場合は NULL を返す
+static inline const jsonif_json* jsonif_json_get(const jsonif_json* v, const char* key) {
+  if (v->type != JSONIF_JSON_OBJECT) return NULL;
+  for (int i = 0; i < v->len; i++) {
+    if (strcmp(v->keys[i], key) == 0) return &v->values[i];
+  }
+  return NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_any_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_any_proto();
+}
+
+#endif
+
+bool jsonif_json_parse_any_proto(const char* json, jsonif_json* v);
+char* jsonif_json_to_string_any_proto(const jsonif_json* v);
+
+#ifndef JSONIF_C_JSON_FUNCS_DEFINED
+#define JSONIF_C_JSON_FUNCS_DEFINED
+
+// JSON の文字列を読み込む。NULL は JSONIF_JSON_NULL になる
+// 読み込めなかった場合は JSONIF_JSON_NULL にして false を返す。読み込んだ値は jsonif_json_free で解放する
+static inline bool jsonif_json_parse(const char* json, jsonif_json* v) {
+  return jsonif_json_parse_any_proto(json, v);
+}
+
+// JSON の文字列にする。JSONIF_JSON_NULL の場合は NULL を返す。返した文字列は free で解放する
+static inline char* jsonif_json_to_string(const jsonif_json* v) {
+  return jsonif_json_to_string_any_proto(v);
+}
+
+#endif
+
+// kind
+typedef int anypb_Test_KindCase;
+extern const anypb_Test_KindCase anypb_Test_KindCase_NOT_SET;
+extern const anypb_Test_KindCase anypb_Test_KindCase_kOneofAny;
+extern const anypb_Test_KindCase anypb_Test_KindCase_kOneofString;
+
+// Payload
+typedef struct {
+  char* name;
+  int name_len;
+  int32_t count;
+} anypb_Payload;
+
+int anypb_Payload_size();
+void anypb_Payload_init(anypb_Payload* v);
+void anypb_Payload_destroy(anypb_Payload*);
+void anypb_Payload_copy(const anypb_Payload* a, anypb_Payload* b);
+bool anypb_Payload_is_equal(const anypb_Payload* a, const anypb_Payload* b);
+int anypb_Payload_to_json_size(const anypb_Payload*);
+void anypb_Payload_to_json(const anypb_Payload*, char* json);
+int anypb_Payload_from_json(const char* json, anypb_Payload*);
+bool anypb_Payload_try_from_json(const char* json, anypb_Payload* v, jsonif_error* error);
+void anypb_Payload_set_name(anypb_Payload* v, const char* s);
+void anypb_Payload_set_count(anypb_Payload* v, int32_t m);
+
+// Nested
+typedef struct {
+  char** tags;
+  int* tags_lens;
+  int tags_len;
+} anypb_Test_Nested;
+
+int anypb_Test_Nested_size();
+void anypb_Test_Nested_init(anypb_Test_Nested* v);
+void anypb_Test_Nested_destroy(anypb_Test_Nested*);
+void anypb_Test_Nested_copy(const anypb_Test_Nested* a, anypb_Test_Nested* b);
+bool anypb_Test_Nested_is_equal(const anypb_Test_Nested* a, const anypb_Test_Nested* b);
+int anypb_Test_Nested_to_json_size(const anypb_Test_Nested*);
+void anypb_Test_Nested_to_json(const anypb_Test_Nested*, char* json);
+int anypb_Test_Nested_from_json(const char* json, anypb_Test_Nested*);
+bool anypb_Test_Nested_try_from_json(const char* json, anypb_Test_Nested* v, jsonif_error* error);
+void anypb_Test_Nested_alloc_tags(anypb_Test_Nested* v, int num);
+void anypb_Test_Nested_set_tags(anypb_Test_Nested* v, int n, const char* s);
+
+// AnyMapEntry
+typedef struct {
+  char* key;
+  int key_len;
+  char* value;
+  int value_len;
+} anypb_Test_AnyMapEntry;
+
+int anypb_Test_AnyMapEntry_size();
+void anypb_Test_AnyMapEntry_init(anypb_Test_AnyMapEntry* v);
+void anypb_Test_AnyMapEntry_destroy(anypb_Test_AnyMapEntry*);
+void anypb_Test_AnyMapEntry_set_key(anypb_Test_AnyMapEntry* v, const char* s);
+void anypb_Test_AnyMapEntry_set_value(anypb_Test_AnyMapEntry* v, const char* s);
+
+// Test
+typedef struct {
+  char* any;
+  int any_len;
+  char** anys;
+  int* anys_lens;
+  int anys_len;
+  anypb_Test_AnyMapEntry* any_map;
+  int any_map_len;
+  char* oneof_any;
+  int oneof_any_len;
+  char* oneof_string;
+  int oneof_string_len;
+  anypb_Test_KindCase kind_case;
+} anypb_Test;
+
+int anypb_Test_size();
+void anypb_Test_init(anypb_Test* v);
+void anypb_Test_destroy(anypb_Test*);
+void anypb_Test_copy(const anypb_Test* a, anypb_Test* b);
+bool anypb_Test_is_equal(const anypb_Test* a, const anypb_Test* b);
+int anypb_Test_to_json_size(const anypb_Test*);
+void anypb_Test_to_json(const anypb_Test*, char* json);
+int anypb_Test_from_json(const char* json, anypb_Test*);
+bool anypb_Test_try_from_json(const char* json, anypb_Test* v, jsonif_error* error);
+void anypb_Test_set_any(anypb_Test* v, const char* s);
+void anypb_Test_alloc_anys(anypb_Test* v, int num);
+void anypb_Test_set_anys(anypb_Test* v, int n, const char* s);
+void anypb_Test_alloc_any_map(anypb_Test* v, int num);
+void anypb_Test_set_oneof_any(anypb_Test* v, const char* s);
+void anypb_Test_set_oneof_string(anypb_Test* v, const char* s);
+
+void anypb_Test_clear_oneof_any(anypb_Test* v);
+void anypb_Test_clear_oneof_string(anypb_Test* v);
+void anypb_Test_clear_kind_case(anypb_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
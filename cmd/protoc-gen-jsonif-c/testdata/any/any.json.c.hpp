@@ -0,0 +1,15 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ANY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ANY_PROTO
+
+#include "any.json.h"
+#include "any.json.c.h"
+
+
+::anypb::Payload anypb_Payload_to_cpp(const anypb_Payload* v);
+void anypb_Payload_from_cpp(const ::anypb::Payload& u, anypb_Payload* v);
+::anypb::Test::Nested anypb_Test_Nested_to_cpp(const anypb_Test_Nested* v);
+void anypb_Test_Nested_from_cpp(const ::anypb::Test::Nested& u, anypb_Test_Nested* v);
+::anypb::Test anypb_Test_to_cpp(const anypb_Test* v);
+void anypb_Test_from_cpp(const ::anypb::Test& u, anypb_Test* v);
+
+#endif
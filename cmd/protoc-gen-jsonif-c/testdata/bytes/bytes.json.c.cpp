@@ -0,0 +1,167 @@
+#include "bytes.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "bytes.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "bytes.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::bytes::Test bytes_Test_to_cpp(const bytes_Test* v) {
+  ::bytes::Test u;
+  if (v->data_len != 0) u.data = std::string((const char*)v->data, v->data_len);
+  for (int i = 0; i < v->rp_data_len; i++) {
+    if (v->rp_data_lens[i] != 0) {
+      u.rp_data.push_back(std::string((const char*)v->rp_data[i], v->rp_data_lens[i]));
+    } else {
+      u.rp_data.push_back("");
+    }
+  }
+  return u;
+}
+void bytes_Test_from_cpp(const ::bytes::Test& u, bytes_Test* v) {
+  bytes_Test_destroy(v);
+  memset(v, 0, sizeof(bytes_Test));
+  if (!u.data.empty()) {
+    v->data = (uint8_t*)malloc(sizeof(uint8_t) * u.data.size());
+    memcpy(v->data, u.data.data(), u.data.size());
+  }
+  v->data_len = (int)u.data.size();
+  v->rp_data_len = (int)u.rp_data.size();
+  v->rp_data = v->rp_data_len == 0 ? nullptr : (decltype(v->rp_data))malloc(sizeof(v->rp_data[0]) * u.rp_data.size());
+  v->rp_data_lens = v->rp_data_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.rp_data.size());
+  for (int i = 0; i < (int)u.rp_data.size(); i++) {
+    if (!u.rp_data[i].empty()) {
+      v->rp_data[i] = (uint8_t*)malloc(sizeof(uint8_t) * u.rp_data[i].size());
+      memcpy(v->rp_data[i], u.rp_data[i].data(), u.rp_data[i].size());
+    }
+    v->rp_data_lens[i] = (int)u.rp_data[i].size();
+  }
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_bytes_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int bytes_Test_size() {
+  return sizeof(bytes_Test);
+}
+void bytes_Test_init(bytes_Test* v) {
+  memset(v, 0, sizeof(bytes_Test));
+}
+void bytes_Test_destroy(bytes_Test* v) {
+  if (v->data) free(v->data);
+  v->data = nullptr;
+  v->data_len = 0;
+  for (int i = 0; i < v->rp_data_len; i++) {
+    if (v->rp_data[i]) free(v->rp_data[i]);
+    v->rp_data[i] = nullptr;
+    v->rp_data_lens[i] = 0;
+  }
+  if (v->rp_data_lens) free(v->rp_data_lens);
+  v->rp_data_lens = nullptr;
+  if (v->rp_data) free(v->rp_data);
+  v->rp_data = nullptr;
+  v->rp_data_len = 0;
+}
+void bytes_Test_copy(const bytes_Test* a, bytes_Test* b) {
+  if (a == b) return;
+  ::bytes::Test u = bytes_Test_to_cpp(a);
+  bytes_Test_from_cpp(u, b);
+}
+bool bytes_Test_is_equal(const bytes_Test* a, const bytes_Test* b) {
+  if (a == b) return true;
+  ::bytes::Test ua = bytes_Test_to_cpp(a);
+  ::bytes::Test ub = bytes_Test_to_cpp(b);
+  return ua == ub;
+}
+int bytes_Test_to_json_size(const bytes_Test* v) {
+  ::bytes::Test u = bytes_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void bytes_Test_to_json(const bytes_Test* v, char* json) {
+  ::bytes::Test u = bytes_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int bytes_Test_from_json(const char* json, bytes_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return bytes_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool bytes_Test_try_from_json(const char* json, bytes_Test* v, jsonif_error* error) {
+  try {
+    ::bytes::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    bytes_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void bytes_Test_set_data(bytes_Test* v, const uint8_t* buf, int size) {
+  if (v->data) free(v->data);
+  v->data = nullptr;
+  v->data_len = buf == nullptr ? 0 : size;
+  if (v->data_len != 0) {
+    v->data = (uint8_t*)malloc(size);
+    memcpy(v->data, buf, size);
+  }
+}
+void bytes_Test_alloc_rp_data(bytes_Test* v, int num) {
+  if (v->rp_data) free(v->rp_data);
+  v->rp_data = nullptr;
+  v->rp_data_len = 0;
+  if (num != 0) {
+    v->rp_data = (decltype(v->rp_data))malloc(sizeof(v->rp_data[0]) * num);
+    memset(v->rp_data, 0, sizeof(v->rp_data[0]) * num);
+    v->rp_data_len = num;
+    v->rp_data_lens = (decltype(v->rp_data_lens))malloc(sizeof(v->rp_data_lens[0]) * num);
+    memset(v->rp_data_lens, 0, sizeof(v->rp_data_lens[0]) * num);
+  }
+}
+
+void bytes_Test_set_rp_data(bytes_Test* v, int n, const uint8_t* buf, int size) {
+  if (v->rp_data[n]) free(v->rp_data[n]);
+  v->rp_data[n] = nullptr;
+  v->rp_data_lens[n] = buf == nullptr ? 0 : size;
+  if (v->rp_data_lens[n] != 0) {
+    v->rp_data[n] = (uint8_t*)malloc(size);
+    memcpy(v->rp_data[n], buf, size);
+  }
+}
+
+}
@@ -0,0 +1,82 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_BYTES_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_BYTES_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_bytes_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_bytes_proto();
+}
+
+#endif
+
+// Test
+typedef struct {
+  uint8_t* data;
+  int data_len;
+  uint8_t** rp_data;
+  int* rp_data_lens;
+  int rp_data_len;
+} bytes_Test;
+
+int bytes_Test_size();
+void bytes_Test_init(bytes_Test* v);
+void bytes_Test_destroy(bytes_Test*);
+void bytes_Test_copy(const bytes_Test* a, bytes_Test* b);
+bool bytes_Test_is_equal(const bytes_Test* a, const bytes_Test* b);
+int bytes_Test_to_json_size(const bytes_Test*);
+void bytes_Test_to_json(const bytes_Test*, char* json);
+int bytes_Test_from_json(const char* json, bytes_Test*);
+bool bytes_Test_try_from_json(const char* json, bytes_Test* v, jsonif_error* error);
+void bytes_Test_set_data(bytes_Test* v, const uint8_t* buf, int size);
+void bytes_Test_alloc_rp_data(bytes_Test* v, int num);
+void bytes_Test_set_rp_data(bytes_Test* v, int n, const uint8_t* buf, int size);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_BYTES_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_BYTES_PROTO
+
+#include "bytes.json.h"
+#include "bytes.json.c.h"
+
+
+::bytes::Test bytes_Test_to_cpp(const bytes_Test* v);
+void bytes_Test_from_cpp(const ::bytes::Test& u, bytes_Test* v);
+
+#endif
@@ -0,0 +1,458 @@
+#include "canonical.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "canonical.json.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "canonical.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Color
+const canonical_Color canonical_COLOR_UNSPECIFIED = 0;
+const canonical_Color canonical_COLOR_RED = 1;
+const canonical_Color canonical_COLOR_BLUE = 2;
+
+::canonical::Inner canonical_Inner_to_cpp(const canonical_Inner* v) {
+  ::canonical::Inner u;
+  u.value = v->value;
+  return u;
+}
+void canonical_Inner_from_cpp(const ::canonical::Inner& u, canonical_Inner* v) {
+  canonical_Inner_destroy(v);
+  memset(v, 0, sizeof(canonical_Inner));
+  v->value = u.value;
+}
+// kind
+const canonical_Test_KindCase canonical_Test_KindCase_NOT_SET = 0;
+const canonical_Test_KindCase canonical_Test_KindCase_kKindName = 13;
+const canonical_Test_KindCase canonical_Test_KindCase_kKindNumber = 14;
+const canonical_Test_KindCase canonical_Test_KindCase_kKindInner = 15;
+
+// _opt_value
+const canonical_Test_OptValueCase canonical_Test_OptValueCase_NOT_SET = 0;
+const canonical_Test_OptValueCase canonical_Test_OptValueCase_kOptValue = 12;
+
+::canonical::Test canonical_Test_to_cpp(const canonical_Test* v) {
+  ::canonical::Test u;
+  u.int_value = v->int_value;
+  u.int64_value = v->int64_value;
+  u.uint64_value = v->uint64_value;
+  for (int i = 0; i < v->int64_values_len; i++) {
+    u.int64_values.push_back(v->int64_values[i]);
+  }
+  for (int i = 0; i < v->int64_map_len; i++) {
+    decltype(u.int64_map)::key_type key{};
+    decltype(u.int64_map)::mapped_type value{};
+    if (v->int64_map[i].key_len != 0) key = std::string(v->int64_map[i].key, v->int64_map[i].key_len);
+    value = v->int64_map[i].value;
+    u.int64_map.emplace(std::move(key), std::move(value));
+  }
+  if (v->renamed_len != 0) u.renamed = std::string(v->renamed, v->renamed_len);
+  u.color = (decltype(u.color))v->color;
+  for (int i = 0; i < v->colors_len; i++) {
+    u.colors.push_back((decltype(u.colors[0]))v->colors[i]);
+  }
+  for (int i = 0; i < v->color_map_len; i++) {
+    decltype(u.color_map)::key_type key{};
+    decltype(u.color_map)::mapped_type value{};
+    key = v->color_map[i].key;
+    value = (decltype(value))v->color_map[i].value;
+    u.color_map.emplace(std::move(key), std::move(value));
+  }
+  u.inner = canonical_Inner_to_cpp(&v->inner);
+  u.wrapped_int64 = google_protobuf_Int64Value_to_cpp(&v->wrapped_int64);
+  u.opt_value = v->opt_value;
+  if (v->kind_name_len != 0) u.kind_name = std::string(v->kind_name, v->kind_name_len);
+  u.kind_number = v->kind_number;
+  u.kind_inner = canonical_Inner_to_cpp(&v->kind_inner);
+  u.kind_case = (::canonical::Test::KindCase)v->kind_case;
+  u._opt_value_case = (::canonical::Test::OptValueCase)v->_opt_value_case;
+  return u;
+}
+void canonical_Test_from_cpp(const ::canonical::Test& u, canonical_Test* v) {
+  canonical_Test_destroy(v);
+  memset(v, 0, sizeof(canonical_Test));
+  v->int_value = u.int_value;
+  v->int64_value = u.int64_value;
+  v->uint64_value = u.uint64_value;
+  v->int64_values_len = (int)u.int64_values.size();
+  v->int64_values = v->int64_values_len == 0 ? nullptr : (decltype(v->int64_values))malloc(sizeof(v->int64_values[0]) * u.int64_values.size());
+  for (int i = 0; i < (int)u.int64_values.size(); i++) {
+    v->int64_values[i] = u.int64_values[i];
+  }
+  v->int64_map_len = (int)u.int64_map.size();
+  v->int64_map = v->int64_map_len == 0 ? nullptr : (decltype(v->int64_map))malloc(sizeof(v->int64_map[0]) * u.int64_map.size());
+  int int64_map_index = 0;
+  for (const auto& kv : u.int64_map) {
+    canonical_Test_Int64MapEntry_init(&v->int64_map[int64_map_index]);
+    if (!kv.first.empty()) v->int64_map[int64_map_index].key = strdup(kv.first.c_str());
+    v->int64_map[int64_map_index].key_len = (int)kv.first.size();
+    v->int64_map[int64_map_index].value = kv.second;
+    int64_map_index++;
+  }
+  if (!u.renamed.empty()) v->renamed = strdup(u.renamed.c_str());
+  v->renamed_len = (int)u.renamed.size();
+  v->color = (int)u.color;
+  v->colors_len = (int)u.colors.size();
+  v->colors = v->colors_len == 0 ? nullptr : (decltype(v->colors))malloc(sizeof(v->colors[0]) * u.colors.size());
+  for (int i = 0; i < (int)u.colors.size(); i++) {
+    v->colors[i] = (int)u.colors[i];
+  }
+  v->color_map_len = (int)u.color_map.size();
+  v->color_map = v->color_map_len == 0 ? nullptr : (decltype(v->color_map))malloc(sizeof(v->color_map[0]) * u.color_map.size());
+  int color_map_index = 0;
+  for (const auto& kv : u.color_map) {
+    canonical_Test_ColorMapEntry_init(&v->color_map[color_map_index]);
+    v->color_map[color_map_index].key = kv.first;
+    v->color_map[color_map_index].value = (int)kv.second;
+    color_map_index++;
+  }
+  canonical_Inner_from_cpp(u.inner, &v->inner);
+  google_protobuf_Int64Value_from_cpp(u.wrapped_int64, &v->wrapped_int64);
+  v->opt_value = u.opt_value;
+  if (!u.kind_name.empty()) v->kind_name = strdup(u.kind_name.c_str());
+  v->kind_name_len = (int)u.kind_name.size();
+  v->kind_number = u.kind_number;
+  canonical_Inner_from_cpp(u.kind_inner, &v->kind_inner);
+  v->kind_case = (int)u.kind_case;
+  v->_opt_value_case = (int)u._opt_value_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_canonical_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int canonical_Inner_size() {
+  return sizeof(canonical_Inner);
+}
+void canonical_Inner_init(canonical_Inner* v) {
+  memset(v, 0, sizeof(canonical_Inner));
+}
+void canonical_Inner_destroy(canonical_Inner* v) {
+  memset(&v->value, 0, sizeof(v->value));
+}
+void canonical_Inner_copy(const canonical_Inner* a, canonical_Inner* b) {
+  if (a == b) return;
+  ::canonical::Inner u = canonical_Inner_to_cpp(a);
+  canonical_Inner_from_cpp(u, b);
+}
+bool canonical_Inner_is_equal(const canonical_Inner* a, const canonical_Inner* b) {
+  if (a == b) return true;
+  ::canonical::Inner ua = canonical_Inner_to_cpp(a);
+  ::canonical::Inner ub = canonical_Inner_to_cpp(b);
+  return ua == ub;
+}
+int canonical_Inner_to_json_size(const canonical_Inner* v) {
+  ::canonical::Inner u = canonical_Inner_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void canonical_Inner_to_json(const canonical_Inner* v, char* json) {
+  ::canonical::Inner u = canonical_Inner_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int canonical_Inner_from_json(const char* json, canonical_Inner* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return canonical_Inner_try_from_json(json, v, error) ? 0 : -1;
+}
+bool canonical_Inner_try_from_json(const char* json, canonical_Inner* v, jsonif_error* error) {
+  try {
+    ::canonical::Inner u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    canonical_Inner_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void canonical_Inner_set_value(canonical_Inner* v, int32_t m) {
+  v->value = m;
+}
+int canonical_Test_Int64MapEntry_size() {
+  return sizeof(canonical_Test_Int64MapEntry);
+}
+void canonical_Test_Int64MapEntry_init(canonical_Test_Int64MapEntry* v) {
+  memset(v, 0, sizeof(canonical_Test_Int64MapEntry));
+}
+void canonical_Test_Int64MapEntry_destroy(canonical_Test_Int64MapEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  memset(&v->value, 0, sizeof(v->value));
+}
+void canonical_Test_Int64MapEntry_set_key(canonical_Test_Int64MapEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void canonical_Test_Int64MapEntry_set_value(canonical_Test_Int64MapEntry* v, int64_t m) {
+  v->value = m;
+}
+int canonical_Test_ColorMapEntry_size() {
+  return sizeof(canonical_Test_ColorMapEntry);
+}
+void canonical_Test_ColorMapEntry_init(canonical_Test_ColorMapEntry* v) {
+  memset(v, 0, sizeof(canonical_Test_ColorMapEntry));
+}
+void canonical_Test_ColorMapEntry_destroy(canonical_Test_ColorMapEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  memset(&v->value, 0, sizeof(v->value));
+}
+void canonical_Test_ColorMapEntry_set_key(canonical_Test_ColorMapEntry* v, int32_t m) {
+  v->key = m;
+}
+void canonical_Test_ColorMapEntry_set_value(canonical_Test_ColorMapEntry* v, canonical_Color m) {
+  v->value = m;
+}
+int canonical_Test_size() {
+  return sizeof(canonical_Test);
+}
+void canonical_Test_init(canonical_Test* v) {
+  memset(v, 0, sizeof(canonical_Test));
+}
+void canonical_Test_destroy(canonical_Test* v) {
+  memset(&v->int_value, 0, sizeof(v->int_value));
+  memset(&v->int64_value, 0, sizeof(v->int64_value));
+  memset(&v->uint64_value, 0, sizeof(v->uint64_value));
+  if (v->int64_values) free(v->int64_values);
+  v->int64_values = nullptr;
+  v->int64_values_len = 0;
+  for (int i = 0; i < v->int64_map_len; i++) {
+    canonical_Test_Int64MapEntry_destroy(&v->int64_map[i]);
+  }
+  if (v->int64_map) free(v->int64_map);
+  v->int64_map = nullptr;
+  v->int64_map_len = 0;
+  if (v->renamed) free(v->renamed);
+  v->renamed = nullptr;
+  v->renamed_len = 0;
+  memset(&v->color, 0, sizeof(v->color));
+  if (v->colors) free(v->colors);
+  v->colors = nullptr;
+  v->colors_len = 0;
+  for (int i = 0; i < v->color_map_len; i++) {
+    canonical_Test_ColorMapEntry_destroy(&v->color_map[i]);
+  }
+  if (v->color_map) free(v->color_map);
+  v->color_map = nullptr;
+  v->color_map_len = 0;
+  canonical_Inner_destroy(&v->inner);
+  google_protobuf_Int64Value_destroy(&v->wrapped_int64);
+  memset(&v->opt_value, 0, sizeof(v->opt_value));
+  if (v->kind_name) free(v->kind_name);
+  v->kind_name = nullptr;
+  v->kind_name_len = 0;
+  memset(&v->kind_number, 0, sizeof(v->kind_number));
+  canonical_Inner_destroy(&v->kind_inner);
+}
+void canonical_Test_copy(const canonical_Test* a, canonical_Test* b) {
+  if (a == b) return;
+  ::canonical::Test u = canonical_Test_to_cpp(a);
+  canonical_Test_from_cpp(u, b);
+}
+bool canonical_Test_is_equal(const canonical_Test* a, const canonical_Test* b) {
+  if (a == b) return true;
+  ::canonical::Test ua = canonical_Test_to_cpp(a);
+  ::canonical::Test ub = canonical_Test_to_cpp(b);
+  return ua == ub;
+}
+int canonical_Test_to_json_size(const canonical_Test* v) {
+  ::canonical::Test u = canonical_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void canonical_Test_to_json(const canonical_Test* v, char* json) {
+  ::canonical::Test u = canonical_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int canonical_Test_from_json(const char* json, canonical_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return canonical_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool canonical_Test_try_from_json(const char* json, canonical_Test* v, jsonif_error* error) {
+  try {
+    ::canonical::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    canonical_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void canonical_Test_set_int_value(canonical_Test* v, int32_t m) {
+  v->int_value = m;
+}
+void canonical_Test_set_int64_value(canonical_Test* v, int64_t m) {
+  v->int64_value = m;
+}
+void canonical_Test_set_uint64_value(canonical_Test* v, uint64_t m) {
+  v->uint64_value = m;
+}
+void canonical_Test_alloc_int64_values(canonical_Test* v, int num) {
+  if (v->int64_values) free(v->int64_values);
+  v->int64_values = nullptr;
+  v->int64_values_len = 0;
+  if (num != 0) {
+    v->int64_values = (decltype(v->int64_values))malloc(sizeof(v->int64_values[0]) * num);
+    memset(v->int64_values, 0, sizeof(v->int64_values[0]) * num);
+    v->int64_values_len = num;
+  }
+}
+
+void canonical_Test_set_int64_values(canonical_Test* v, int n, int64_t m) {
+  v->int64_values[n] = m;
+}
+void canonical_Test_alloc_int64_map(canonical_Test* v, int num) {
+  if (v->int64_map) free(v->int64_map);
+  v->int64_map = nullptr;
+  v->int64_map_len = 0;
+  if (num != 0) {
+    v->int64_map = (decltype(v->int64_map))malloc(sizeof(v->int64_map[0]) * num);
+    memset(v->int64_map, 0, sizeof(v->int64_map[0]) * num);
+    v->int64_map_len = num;
+  }
+}
+
+void canonical_Test_set_renamed(canonical_Test* v, const char* s) {
+  if (v->renamed) free(v->renamed);
+  v->renamed_len = s == nullptr ? 0 : strlen(s);
+  v->renamed = v->renamed_len == 0 ? nullptr : strdup(s);
+}
+void canonical_Test_set_color(canonical_Test* v, canonical_Color m) {
+  v->color = m;
+}
+void canonical_Test_alloc_colors(canonical_Test* v, int num) {
+  if (v->colors) free(v->colors);
+  v->colors = nullptr;
+  v->colors_len = 0;
+  if (num != 0) {
+    v->colors = (decltype(v->colors))malloc(sizeof(v->colors[0]) * num);
+    memset(v->colors, 0, sizeof(v->colors[0]) * num);
+    v->colors_len = num;
+  }
+}
+
+void canonical_Test_set_colors(canonical_Test* v, int n, canonical_Color m) {
+  v->colors[n] = m;
+}
+void canonical_Test_alloc_color_map(canonical_Test* v, int num) {
+  if (v->color_map) free(v->color_map);
+  v->color_map = nullptr;
+  v->color_map_len = 0;
+  if (num != 0) {
+    v->color_map = (decltype(v->color_map))malloc(sizeof(v->color_map[0]) * num);
+    memset(v->color_map, 0, sizeof(v->color_map[0]) * num);
+    v->color_map_len = num;
+  }
+}
+
+void canonical_Test_set_inner(canonical_Test* v, const canonical_Inner* m) {
+  canonical_Inner_copy(m, &v->inner);
+}
+void canonical_Test_set_wrapped_int64(canonical_Test* v, const google_protobuf_Int64Value* m) {
+  google_protobuf_Int64Value_copy(m, &v->wrapped_int64);
+}
+void canonical_Test_set_opt_value(canonical_Test* v, int32_t m) {
+  canonical_Test_clear__opt_value_case(v);
+  v->_opt_value_case = canonical_Test_OptValueCase_kOptValue;
+  v->opt_value = m;
+}
+void canonical_Test_set_kind_name(canonical_Test* v, const char* s) {
+  canonical_Test_clear_kind_case(v);
+  v->kind_case = canonical_Test_KindCase_kKindName;
+  if (v->kind_name) free(v->kind_name);
+  v->kind_name_len = s == nullptr ? 0 : strlen(s);
+  v->kind_name = v->kind_name_len == 0 ? nullptr : strdup(s);
+}
+void canonical_Test_set_kind_number(canonical_Test* v, int64_t m) {
+  canonical_Test_clear_kind_case(v);
+  v->kind_case = canonical_Test_KindCase_kKindNumber;
+  v->kind_number = m;
+}
+void canonical_Test_set_kind_inner(canonical_Test* v, const canonical_Inner* m) {
+  canonical_Test_clear_kind_case(v);
+  v->kind_case = canonical_Test_KindCase_kKindInner;
+  canonical_Inner_copy(m, &v->kind_inner);
+}
+bool canonical_Test_has_opt_value(const canonical_Test* v) {
+  return v->_opt_value_case == canonical_Test_OptValueCase_kOptValue;
+}
+void canonical_Test_clear_opt_value(canonical_Test* v) {
+  if (v->_opt_value_case == canonical_Test_OptValueCase_kOptValue) {
+    canonical_Test_clear__opt_value_case(v);
+  }
+}
+void canonical_Test_clear_kind_name(canonical_Test* v) {
+  if (v->kind_case == canonical_Test_KindCase_kKindName) {
+    canonical_Test_clear_kind_case(v);
+  }
+}
+void canonical_Test_clear_kind_number(canonical_Test* v) {
+  if (v->kind_case == canonical_Test_KindCase_kKindNumber) {
+    canonical_Test_clear_kind_case(v);
+  }
+}
+void canonical_Test_clear_kind_inner(canonical_Test* v) {
+  if (v->kind_case == canonical_Test_KindCase_kKindInner) {
+    canonical_Test_clear_kind_case(v);
+  }
+}
+void canonical_Test_clear_kind_case(canonical_Test* v) {
+  if (v->kind_name) free(v->kind_name);
+  v->kind_name = nullptr;
+  v->kind_name_len = 0;
+  memset(&v->kind_number, 0, sizeof(v->kind_number));
+  canonical_Inner_destroy(&v->kind_inner);
+  v->kind_case = canonical_Test_KindCase_NOT_SET;
+}
+void canonical_Test_clear__opt_value_case(canonical_Test* v) {
+  memset(&v->opt_value, 0, sizeof(v->opt_value));
+  v->_opt_value_case = canonical_Test_OptValueCase_NOT_SET;
+}
+
+}
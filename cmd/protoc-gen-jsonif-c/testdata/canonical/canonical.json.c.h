@@ -0,0 +1,181 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_CANONICAL_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_CANONICAL_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#include "google/protobuf/wrappers.json.c.h"
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_canonical_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_canonical_proto();
+}
+
+#endif
+
+// Color
+typedef int canonical_Color;
+extern const canonical_Color canonical_COLOR_UNSPECIFIED;
+extern const canonical_Color canonical_COLOR_RED;
+extern const canonical_Color canonical_COLOR_BLUE;
+
+// kind
+typedef int canonical_Test_KindCase;
+extern const canonical_Test_KindCase canonical_Test_KindCase_NOT_SET;
+extern const canonical_Test_KindCase canonical_Test_KindCase_kKindName;
+extern const canonical_Test_KindCase canonical_Test_KindCase_kKindNumber;
+extern const canonical_Test_KindCase canonical_Test_KindCase_kKindInner;
+
+// _opt_value
+typedef int canonical_Test_OptValueCase;
+extern const canonical_Test_OptValueCase canonical_Test_OptValueCase_NOT_SET;
+extern const canonical_Test_OptValueCase canonical_Test_OptValueCase_kOptValue;
+
+// Inner
+typedef struct {
+  int32_t value;
+} canonical_Inner;
+
+int canonical_Inner_size();
+void canonical_Inner_init(canonical_Inner* v);
+void canonical_Inner_destroy(canonical_Inner*);
+void canonical_Inner_copy(const canonical_Inner* a, canonical_Inner* b);
+bool canonical_Inner_is_equal(const canonical_Inner* a, const canonical_Inner* b);
+int canonical_Inner_to_json_size(const canonical_Inner*);
+void canonical_Inner_to_json(const canonical_Inner*, char* json);
+int canonical_Inner_from_json(const char* json, canonical_Inner*);
+bool canonical_Inner_try_from_json(const char* json, canonical_Inner* v, jsonif_error* error);
+void canonical_Inner_set_value(canonical_Inner* v, int32_t m);
+
+// Int64MapEntry
+typedef struct {
+  char* key;
+  int key_len;
+  int64_t value;
+} canonical_Test_Int64MapEntry;
+
+int canonical_Test_Int64MapEntry_size();
+void canonical_Test_Int64MapEntry_init(canonical_Test_Int64MapEntry* v);
+void canonical_Test_Int64MapEntry_destroy(canonical_Test_Int64MapEntry*);
+void canonical_Test_Int64MapEntry_set_key(canonical_Test_Int64MapEntry* v, const char* s);
+void canonical_Test_Int64MapEntry_set_value(canonical_Test_Int64MapEntry* v, int64_t m);
+
+// ColorMapEntry
+typedef struct {
+  int32_t key;
+  canonical_Color value;
+} canonical_Test_ColorMapEntry;
+
+int canonical_Test_ColorMapEntry_size();
+void canonical_Test_ColorMapEntry_init(canonical_Test_ColorMapEntry* v);
+void canonical_Test_ColorMapEntry_destroy(canonical_Test_ColorMapEntry*);
+void canonical_Test_ColorMapEntry_set_key(canonical_Test_ColorMapEntry* v, int32_t m);
+void canonical_Test_ColorMapEntry_set_value(canonical_Test_ColorMapEntry* v, canonical_Color m);
+
+// Test
+typedef struct {
+  int32_t int_value;
+  int64_t int64_value;
+  uint64_t uint64_value;
+  int64_t* int64_values;
+  int int64_values_len;
+  canonical_Test_Int64MapEntry* int64_map;
+  int int64_map_len;
+  char* renamed;
+  int renamed_len;
+  canonical_Color color;
+  canonical_Color* colors;
+  int colors_len;
+  canonical_Test_ColorMapEntry* color_map;
+  int color_map_len;
+  canonical_Inner inner;
+  google_protobuf_Int64Value wrapped_int64;
+  int32_t opt_value;
+  char* kind_name;
+  int kind_name_len;
+  int64_t kind_number;
+  canonical_Inner kind_inner;
+  canonical_Test_KindCase kind_case;
+  canonical_Test_OptValueCase _opt_value_case;
+} canonical_Test;
+
+int canonical_Test_size();
+void canonical_Test_init(canonical_Test* v);
+void canonical_Test_destroy(canonical_Test*);
+void canonical_Test_copy(const canonical_Test* a, canonical_Test* b);
+bool canonical_Test_is_equal(const canonical_Test* a, const canonical_Test* b);
+int canonical_Test_to_json_size(const canonical_Test*);
+void canonical_Test_to_json(const canonical_Test*, char* json);
+int canonical_Test_from_json(const char* json, canonical_Test*);
+bool canonical_Test_try_from_json(const char* json, canonical_Test* v, jsonif_error* error);
+void canonical_Test_set_int_value(canonical_Test* v, int32_t m);
+void canonical_Test_set_int64_value(canonical_Test* v, int64_t m);
+void canonical_Test_set_uint64_value(canonical_Test* v, uint64_t m);
+void canonical_Test_alloc_int64_values(canonical_Test* v, int num);
+void canonical_Test_set_int64_values(canonical_Test* v, int n, int64_t m);
+void canonical_Test_alloc_int64_map(canonical_Test* v, int num);
+void canonical_Test_set_renamed(canonical_Test* v, const char* s);
+void canonical_Test_set_color(canonical_Test* v, canonical_Color m);
+void canonical_Test_alloc_colors(canonical_Test* v, int num);
+void canonical_Test_set_colors(canonical_Test* v, int n, canonical_Color m);
+void canonical_Test_alloc_color_map(canonical_Test* v, int num);
+void canonical_Test_set_inner(canonical_Test* v, const canonical_Inner* m);
+void canonical_Test_set_wrapped_int64(canonical_Test* v, const google_protobuf_Int64Value* m);
+void canonical_Test_set_opt_value(canonical_Test* v, int32_t m);
+void canonical_Test_set_kind_name(canonical_Test* v, const char* s);
+void canonical_Test_set_kind_number(canonical_Test* v, int64_t m);
+void canonical_Test_set_kind_inner(canonical_Test* v, const canonical_Inner* m);
+
+bool canonical_Test_has_opt_value(const canonical_Test* v);
+void canonical_Test_clear_opt_value(canonical_Test* v);
+void canonical_Test_clear_kind_name(canonical_Test* v);
+void canonical_Test_clear_kind_number(canonical_Test* v);
+void canonical_Test_clear_kind_inner(canonical_Test* v);
+void canonical_Test_clear_kind_case(canonical_Test* v);
+void canonical_Test_clear__opt_value_case(canonical_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
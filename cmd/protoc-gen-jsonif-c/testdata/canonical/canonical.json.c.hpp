@@ -0,0 +1,14 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_CANONICAL_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_CANONICAL_PROTO
+
+#include "canonical.json.h"
+#include "canonical.json.c.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+::canonical::Inner canonical_Inner_to_cpp(const canonical_Inner* v);
+void canonical_Inner_from_cpp(const ::canonical::Inner& u, canonical_Inner* v);
+::canonical::Test canonical_Test_to_cpp(const canonical_Test* v);
+void canonical_Test_from_cpp(const ::canonical::Test& u, canonical_Test* v);
+
+#endif
@@ -0,0 +1,240 @@
+#include "canonical_bytes.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "canonical_bytes.json.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "canonical_bytes.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::canonical::BytesTest canonical_BytesTest_to_cpp(const canonical_BytesTest* v) {
+  ::canonical::BytesTest u;
+  if (v->data_len != 0) u.data = std::string((const char*)v->data, v->data_len);
+  for (int i = 0; i < v->datas_len; i++) {
+    if (v->datas_lens[i] != 0) {
+      u.datas.push_back(std::string((const char*)v->datas[i], v->datas_lens[i]));
+    } else {
+      u.datas.push_back("");
+    }
+  }
+  for (int i = 0; i < v->data_map_len; i++) {
+    decltype(u.data_map)::key_type key{};
+    decltype(u.data_map)::mapped_type value{};
+    if (v->data_map[i].key_len != 0) key = std::string(v->data_map[i].key, v->data_map[i].key_len);
+    if (v->data_map[i].value_len != 0) value = std::string((const char*)v->data_map[i].value, v->data_map[i].value_len);
+    u.data_map.emplace(std::move(key), std::move(value));
+  }
+  u.wrapped_data = google_protobuf_BytesValue_to_cpp(&v->wrapped_data);
+  return u;
+}
+void canonical_BytesTest_from_cpp(const ::canonical::BytesTest& u, canonical_BytesTest* v) {
+  canonical_BytesTest_destroy(v);
+  memset(v, 0, sizeof(canonical_BytesTest));
+  if (!u.data.empty()) {
+    v->data = (uint8_t*)malloc(sizeof(uint8_t) * u.data.size());
+    memcpy(v->data, u.data.data(), u.data.size());
+  }
+  v->data_len = (int)u.data.size();
+  v->datas_len = (int)u.datas.size();
+  v->datas = v->datas_len == 0 ? nullptr : (decltype(v->datas))malloc(sizeof(v->datas[0]) * u.datas.size());
+  v->datas_lens = v->datas_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.datas.size());
+  for (int i = 0; i < (int)u.datas.size(); i++) {
+    if (!u.datas[i].empty()) {
+      v->datas[i] = (uint8_t*)malloc(sizeof(uint8_t) * u.datas[i].size());
+      memcpy(v->datas[i], u.datas[i].data(), u.datas[i].size());
+    }
+    v->datas_lens[i] = (int)u.datas[i].size();
+  }
+  v->data_map_len = (int)u.data_map.size();
+  v->data_map = v->data_map_len == 0 ? nullptr : (decltype(v->data_map))malloc(sizeof(v->data_map[0]) * u.data_map.size());
+  int data_map_index = 0;
+  for (const auto& kv : u.data_map) {
+    canonical_BytesTest_DataMapEntry_init(&v->data_map[data_map_index]);
+    if (!kv.first.empty()) v->data_map[data_map_index].key = strdup(kv.first.c_str());
+    v->data_map[data_map_index].key_len = (int)kv.first.size();
+    if (!kv.second.empty()) {
+      v->data_map[data_map_index].value = (uint8_t*)malloc(sizeof(uint8_t) * kv.second.size());
+      memcpy(v->data_map[data_map_index].value, kv.second.data(), kv.second.size());
+    }
+    v->data_map[data_map_index].value_len = (int)kv.second.size();
+    data_map_index++;
+  }
+  google_protobuf_BytesValue_from_cpp(u.wrapped_data, &v->wrapped_data);
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_canonical_bytes_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int canonical_BytesTest_DataMapEntry_size() {
+  return sizeof(canonical_BytesTest_DataMapEntry);
+}
+void canonical_BytesTest_DataMapEntry_init(canonical_BytesTest_DataMapEntry* v) {
+  memset(v, 0, sizeof(canonical_BytesTest_DataMapEntry));
+}
+void canonical_BytesTest_DataMapEntry_destroy(canonical_BytesTest_DataMapEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = 0;
+}
+void canonical_BytesTest_DataMapEntry_set_key(canonical_BytesTest_DataMapEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void canonical_BytesTest_DataMapEntry_set_value(canonical_BytesTest_DataMapEntry* v, const uint8_t* buf, int size) {
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = buf == nullptr ? 0 : size;
+  if (v->value_len != 0) {
+    v->value = (uint8_t*)malloc(size);
+    memcpy(v->value, buf, size);
+  }
+}
+int canonical_BytesTest_size() {
+  return sizeof(canonical_BytesTest);
+}
+void canonical_BytesTest_init(canonical_BytesTest* v) {
+  memset(v, 0, sizeof(canonical_BytesTest));
+}
+void canonical_BytesTest_destroy(canonical_BytesTest* v) {
+  if (v->data) free(v->data);
+  v->data = nullptr;
+  v->data_len = 0;
+  for (int i = 0; i < v->datas_len; i++) {
+    if (v->datas[i]) free(v->datas[i]);
+    v->datas[i] = nullptr;
+    v->datas_lens[i] = 0;
+  }
+  if (v->datas_lens) free(v->datas_lens);
+  v->datas_lens = nullptr;
+  if (v->datas) free(v->datas);
+  v->datas = nullptr;
+  v->datas_len = 0;
+  for (int i = 0; i < v->data_map_len; i++) {
+    canonical_BytesTest_DataMapEntry_destroy(&v->data_map[i]);
+  }
+  if (v->data_map) free(v->data_map);
+  v->data_map = nullptr;
+  v->data_map_len = 0;
+  google_protobuf_BytesValue_destroy(&v->wrapped_data);
+}
+void canonical_BytesTest_copy(const canonical_BytesTest* a, canonical_BytesTest* b) {
+  if (a == b) return;
+  ::canonical::BytesTest u = canonical_BytesTest_to_cpp(a);
+  canonical_BytesTest_from_cpp(u, b);
+}
+bool canonical_BytesTest_is_equal(const canonical_BytesTest* a, const canonical_BytesTest* b) {
+  if (a == b) return true;
+  ::canonical::BytesTest ua = canonical_BytesTest_to_cpp(a);
+  ::canonical::BytesTest ub = canonical_BytesTest_to_cpp(b);
+  return ua == ub;
+}
+int canonical_BytesTest_to_json_size(const canonical_BytesTest* v) {
+  ::canonical::BytesTest u = canonical_BytesTest_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void canonical_BytesTest_to_json(const canonical_BytesTest* v, char* json) {
+  ::canonical::BytesTest u = canonical_BytesTest_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int canonical_BytesTest_from_json(const char* json, canonical_BytesTest* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return canonical_BytesTest_try_from_json(json, v, error) ? 0 : -1;
+}
+bool canonical_BytesTest_try_from_json(const char* json, canonical_BytesTest* v, jsonif_error* error) {
+  try {
+    ::canonical::BytesTest u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    canonical_BytesTest_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void canonical_BytesTest_set_data(canonical_BytesTest* v, const uint8_t* buf, int size) {
+  if (v->data) free(v->data);
+  v->data = nullptr;
+  v->data_len = buf == nullptr ? 0 : size;
+  if (v->data_len != 0) {
+    v->data = (uint8_t*)malloc(size);
+    memcpy(v->data, buf, size);
+  }
+}
+void canonical_BytesTest_alloc_datas(canonical_BytesTest* v, int num) {
+  if (v->datas) free(v->datas);
+  v->datas = nullptr;
+  v->datas_len = 0;
+  if (num != 0) {
+    v->datas = (decltype(v->datas))malloc(sizeof(v->datas[0]) * num);
+    memset(v->datas, 0, sizeof(v->datas[0]) * num);
+    v->datas_len = num;
+    v->datas_lens = (decltype(v->datas_lens))malloc(sizeof(v->datas_lens[0]) * num);
+    memset(v->datas_lens, 0, sizeof(v->datas_lens[0]) * num);
+  }
+}
+
+void canonical_BytesTest_set_datas(canonical_BytesTest* v, int n, const uint8_t* buf, int size) {
+  if (v->datas[n]) free(v->datas[n]);
+  v->datas[n] = nullptr;
+  v->datas_lens[n] = buf == nullptr ? 0 : size;
+  if (v->datas_lens[n] != 0) {
+    v->datas[n] = (uint8_t*)malloc(size);
+    memcpy(v->datas[n], buf, size);
+  }
+}
+void canonical_BytesTest_alloc_data_map(canonical_BytesTest* v, int num) {
+  if (v->data_map) free(v->data_map);
+  v->data_map = nullptr;
+  v->data_map_len = 0;
+  if (num != 0) {
+    v->data_map = (decltype(v->data_map))malloc(sizeof(v->data_map[0]) * num);
+    memset(v->data_map, 0, sizeof(v->data_map[0]) * num);
+    v->data_map_len = num;
+  }
+}
+
+void canonical_BytesTest_set_wrapped_data(canonical_BytesTest* v, const google_protobuf_BytesValue* m) {
+  google_protobuf_BytesValue_copy(m, &v->wrapped_data);
+}
+
+}
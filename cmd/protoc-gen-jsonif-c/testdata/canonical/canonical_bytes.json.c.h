@@ -0,0 +1,102 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_CANONICAL_BYTES_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_CANONICAL_BYTES_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#include "google/protobuf/wrappers.json.c.h"
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_canonical_bytes_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_canonical_bytes_proto();
+}
+
+#endif
+
+// DataMapEntry
+typedef struct {
+  char* key;
+  int key_len;
+  uint8_t* value;
+  int value_len;
+} canonical_BytesTest_DataMapEntry;
+
+int canonical_BytesTest_DataMapEntry_size();
+void canonical_BytesTest_DataMapEntry_init(canonical_BytesTest_DataMapEntry* v);
+void canonical_BytesTest_DataMapEntry_destroy(canonical_BytesTest_DataMapEntry*);
+void canonical_BytesTest_DataMapEntry_set_key(canonical_BytesTest_DataMapEntry* v, const char* s);
+void canonical_BytesTest_DataMapEntry_set_value(canonical_BytesTest_DataMapEntry* v, const uint8_t* buf, int size);
+
+// BytesTest
+typedef struct {
+  uint8_t* data;
+  int data_len;
+  uint8_t** datas;
+  int* datas_lens;
+  int datas_len;
+  canonical_BytesTest_DataMapEntry* data_map;
+  int data_map_len;
+  google_protobuf_BytesValue wrapped_data;
+} canonical_BytesTest;
+
+int canonical_BytesTest_size();
+void canonical_BytesTest_init(canonical_BytesTest* v);
+void canonical_BytesTest_destroy(canonical_BytesTest*);
+void canonical_BytesTest_copy(const canonical_BytesTest* a, canonical_BytesTest* b);
+bool canonical_BytesTest_is_equal(const canonical_BytesTest* a, const canonical_BytesTest* b);
+int canonical_BytesTest_to_json_size(const canonical_BytesTest*);
+void canonical_BytesTest_to_json(const canonical_BytesTest*, char* json);
+int canonical_BytesTest_from_json(const char* json, canonical_BytesTest*);
+bool canonical_BytesTest_try_from_json(const char* json, canonical_BytesTest* v, jsonif_error* error);
+void canonical_BytesTest_set_data(canonical_BytesTest* v, const uint8_t* buf, int size);
+void canonical_BytesTest_alloc_datas(canonical_BytesTest* v, int num);
+void canonical_BytesTest_set_datas(canonical_BytesTest* v, int n, const uint8_t* buf, int size);
+void canonical_BytesTest_alloc_data_map(canonical_BytesTest* v, int num);
+void canonical_BytesTest_set_wrapped_data(canonical_BytesTest* v, const google_protobuf_BytesValue* m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
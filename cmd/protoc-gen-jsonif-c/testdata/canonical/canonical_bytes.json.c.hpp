@@ -0,0 +1,12 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_CANONICAL_BYTES_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_CANONICAL_BYTES_PROTO
+
+#include "canonical_bytes.json.h"
+#include "canonical_bytes.json.c.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+::canonical::BytesTest canonical_BytesTest_to_cpp(const canonical_BytesTest* v);
+void canonical_BytesTest_from_cpp(const ::canonical::BytesTest& u, canonical_BytesTest* v);
+
+#endif
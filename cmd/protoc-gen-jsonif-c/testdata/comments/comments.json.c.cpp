@@ -0,0 +1,275 @@
+#include "comments.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "comments.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "comments.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Kind
+const comments_Kind comments_KIND_UNKNOWN = 0;
+const comments_Kind comments_KIND_FOO = 1;
+
+::comments::Test::Nested comments_Test_Nested_to_cpp(const comments_Test_Nested* v) {
+  ::comments::Test::Nested u;
+  u.flag = v->flag;
+  return u;
+}
+void comments_Test_Nested_from_cpp(const ::comments::Test::Nested& u, comments_Test_Nested* v) {
+  comments_Test_Nested_destroy(v);
+  memset(v, 0, sizeof(comments_Test_Nested));
+  v->flag = u.flag;
+}
+// value
+const comments_Test_ValueCase comments_Test_ValueCase_NOT_SET = 0;
+const comments_Test_ValueCase comments_Test_ValueCase_kA = 4;
+const comments_Test_ValueCase comments_Test_ValueCase_kB = 5;
+
+::comments::Test comments_Test_to_cpp(const comments_Test* v) {
+  ::comments::Test u;
+  u.leading = v->leading;
+  if (v->trailing_len != 0) u.trailing = std::string(v->trailing, v->trailing_len);
+  for (int i = 0; i < v->none_len; i++) {
+    u.none.push_back(v->none[i]);
+  }
+  u.a = v->a;
+  if (v->b_len != 0) u.b = std::string(v->b, v->b_len);
+  u.nested = comments_Test_Nested_to_cpp(&v->nested);
+  u.detached = v->detached;
+  u.both = v->both;
+  u.value_case = (::comments::Test::ValueCase)v->value_case;
+  return u;
+}
+void comments_Test_from_cpp(const ::comments::Test& u, comments_Test* v) {
+  comments_Test_destroy(v);
+  memset(v, 0, sizeof(comments_Test));
+  v->leading = u.leading;
+  if (!u.trailing.empty()) v->trailing = strdup(u.trailing.c_str());
+  v->trailing_len = (int)u.trailing.size();
+  v->none_len = (int)u.none.size();
+  v->none = v->none_len == 0 ? nullptr : (decltype(v->none))malloc(sizeof(v->none[0]) * u.none.size());
+  for (int i = 0; i < (int)u.none.size(); i++) {
+    v->none[i] = u.none[i];
+  }
+  v->a = u.a;
+  if (!u.b.empty()) v->b = strdup(u.b.c_str());
+  v->b_len = (int)u.b.size();
+  comments_Test_Nested_from_cpp(u.nested, &v->nested);
+  v->detached = u.detached;
+  v->both = u.both;
+  v->value_case = (int)u.value_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_comments_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int comments_Test_Nested_size() {
+  return sizeof(comments_Test_Nested);
+}
+void comments_Test_Nested_init(comments_Test_Nested* v) {
+  memset(v, 0, sizeof(comments_Test_Nested));
+}
+void comments_Test_Nested_destroy(comments_Test_Nested* v) {
+  memset(&v->flag, 0, sizeof(v->flag));
+}
+void comments_Test_Nested_copy(const comments_Test_Nested* a, comments_Test_Nested* b) {
+  if (a == b) return;
+  ::comments::Test::Nested u = comments_Test_Nested_to_cpp(a);
+  comments_Test_Nested_from_cpp(u, b);
+}
+bool comments_Test_Nested_is_equal(const comments_Test_Nested* a, const comments_Test_Nested* b) {
+  if (a == b) return true;
+  ::comments::Test::Nested ua = comments_Test_Nested_to_cpp(a);
+  ::comments::Test::Nested ub = comments_Test_Nested_to_cpp(b);
+  return ua == ub;
+}
+int comments_Test_Nested_to_json_size(const comments_Test_Nested* v) {
+  ::comments::Test::Nested u = comments_Test_Nested_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void comments_Test_Nested_to_json(const comments_Test_Nested* v, char* json) {
+  ::comments::Test::Nested u = comments_Test_Nested_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int comments_Test_Nested_from_json(const char* json, comments_Test_Nested* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return comments_Test_Nested_try_from_json(json, v, error) ? 0 : -1;
+}
+bool comments_Test_Nested_try_from_json(const char* json, comments_Test_Nested* v, jsonif_error* error) {
+  try {
+    ::comments::Test::Nested u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    comments_Test_Nested_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void comments_Test_Nested_set_flag(comments_Test_Nested* v, bool m) {
+  v->flag = m;
+}
+int comments_Test_size() {
+  return sizeof(comments_Test);
+}
+void comments_Test_init(comments_Test* v) {
+  memset(v, 0, sizeof(comments_Test));
+}
+void comments_Test_destroy(comments_Test* v) {
+  memset(&v->leading, 0, sizeof(v->leading));
+  if (v->trailing) free(v->trailing);
+  v->trailing = nullptr;
+  v->trailing_len = 0;
+  if (v->none) free(v->none);
+  v->none = nullptr;
+  v->none_len = 0;
+  memset(&v->a, 0, sizeof(v->a));
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  comments_Test_Nested_destroy(&v->nested);
+  memset(&v->detached, 0, sizeof(v->detached));
+  memset(&v->both, 0, sizeof(v->both));
+}
+void comments_Test_copy(const comments_Test* a, comments_Test* b) {
+  if (a == b) return;
+  ::comments::Test u = comments_Test_to_cpp(a);
+  comments_Test_from_cpp(u, b);
+}
+bool comments_Test_is_equal(const comments_Test* a, const comments_Test* b) {
+  if (a == b) return true;
+  ::comments::Test ua = comments_Test_to_cpp(a);
+  ::comments::Test ub = comments_Test_to_cpp(b);
+  return ua == ub;
+}
+int comments_Test_to_json_size(const comments_Test* v) {
+  ::comments::Test u = comments_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void comments_Test_to_json(const comments_Test* v, char* json) {
+  ::comments::Test u = comments_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int comments_Test_from_json(const char* json, comments_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return comments_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool comments_Test_try_from_json(const char* json, comments_Test* v, jsonif_error* error) {
+  try {
+    ::comments::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    comments_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void comments_Test_set_leading(comments_Test* v, int32_t m) {
+  v->leading = m;
+}
+void comments_Test_set_trailing(comments_Test* v, const char* s) {
+  if (v->trailing) free(v->trailing);
+  v->trailing_len = s == nullptr ? 0 : strlen(s);
+  v->trailing = v->trailing_len == 0 ? nullptr : strdup(s);
+}
+void comments_Test_alloc_none(comments_Test* v, int num) {
+  if (v->none) free(v->none);
+  v->none = nullptr;
+  v->none_len = 0;
+  if (num != 0) {
+    v->none = (decltype(v->none))malloc(sizeof(v->none[0]) * num);
+    memset(v->none, 0, sizeof(v->none[0]) * num);
+    v->none_len = num;
+  }
+}
+
+void comments_Test_set_none(comments_Test* v, int n, int32_t m) {
+  v->none[n] = m;
+}
+void comments_Test_set_a(comments_Test* v, int32_t m) {
+  comments_Test_clear_value_case(v);
+  v->value_case = comments_Test_ValueCase_kA;
+  v->a = m;
+}
+void comments_Test_set_b(comments_Test* v, const char* s) {
+  comments_Test_clear_value_case(v);
+  v->value_case = comments_Test_ValueCase_kB;
+  if (v->b) free(v->b);
+  v->b_len = s == nullptr ? 0 : strlen(s);
+  v->b = v->b_len == 0 ? nullptr : strdup(s);
+}
+void comments_Test_set_nested(comments_Test* v, const comments_Test_Nested* m) {
+  comments_Test_Nested_copy(m, &v->nested);
+}
+void comments_Test_set_detached(comments_Test* v, int32_t m) {
+  v->detached = m;
+}
+void comments_Test_set_both(comments_Test* v, int32_t m) {
+  v->both = m;
+}
+void comments_Test_clear_a(comments_Test* v) {
+  if (v->value_case == comments_Test_ValueCase_kA) {
+    comments_Test_clear_value_case(v);
+  }
+}
+void comments_Test_clear_b(comments_Test* v) {
+  if (v->value_case == comments_Test_ValueCase_kB) {
+    comments_Test_clear_value_case(v);
+  }
+}
+void comments_Test_clear_value_case(comments_Test* v) {
+  memset(&v->a, 0, sizeof(v->a));
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  v->value_case = comments_Test_ValueCase_NOT_SET;
+}
+
+}
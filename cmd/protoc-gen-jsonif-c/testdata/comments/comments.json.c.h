@@ -0,0 +1,143 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_COMMENTS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_COMMENTS_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_comments_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_comments_proto();
+}
+
+#endif
+
+// Kind
+/// 列挙型のコメント
+typedef int comments_Kind;
+/// 値のコメント
+extern const comments_Kind comments_KIND_UNKNOWN;
+/// 後置コメント
+extern const comments_Kind comments_KIND_FOO;
+
+// value
+/// oneof のコメント
+typedef int comments_Test_ValueCase;
+extern const comments_Test_ValueCase comments_Test_ValueCase_NOT_SET;
+extern const comments_Test_ValueCase comments_Test_ValueCase_kA;
+extern const comments_Test_ValueCase comments_Test_ValueCase_kB;
+
+// Nested
+/// ネストしたメッセージ
+typedef struct {
+  /// ネストしたメッセージのフィールド
+  bool flag;
+} comments_Test_Nested;
+
+int comments_Test_Nested_size();
+void comments_Test_Nested_init(comments_Test_Nested* v);
+void comments_Test_Nested_destroy(comments_Test_Nested*);
+void comments_Test_Nested_copy(const comments_Test_Nested* a, comments_Test_Nested* b);
+bool comments_Test_Nested_is_equal(const comments_Test_Nested* a, const comments_Test_Nested* b);
+int comments_Test_Nested_to_json_size(const comments_Test_Nested*);
+void comments_Test_Nested_to_json(const comments_Test_Nested*, char* json);
+int comments_Test_Nested_from_json(const char* json, comments_Test_Nested*);
+bool comments_Test_Nested_try_from_json(const char* json, comments_Test_Nested* v, jsonif_error* error);
+void comments_Test_Nested_set_flag(comments_Test_Nested* v, bool m);
+
+// Test
+/// proto のコメントがドキュメントコメントとして出力されるか確認する用
+///
+/// 複数行のコメントや <記号> & */ も扱えること
+typedef struct {
+  /// 前置コメント
+  int32_t leading;
+  /// 後置コメント
+  char* trailing;
+  int trailing_len;
+  int32_t* none;
+  int none_len;
+  /// oneof フィールドのコメント
+  int32_t a;
+  char* b;
+  int b_len;
+  comments_Test_Nested nested;
+  /// 離れたコメント
+  ///
+  /// 離れたコメントの後の前置コメント
+  int32_t detached;
+  /// 前置コメントと
+  ///
+  /// 後置コメントの両方
+  int32_t both;
+  comments_Test_ValueCase value_case;
+} comments_Test;
+
+int comments_Test_size();
+void comments_Test_init(comments_Test* v);
+void comments_Test_destroy(comments_Test*);
+void comments_Test_copy(const comments_Test* a, comments_Test* b);
+bool comments_Test_is_equal(const comments_Test* a, const comments_Test* b);
+int comments_Test_to_json_size(const comments_Test*);
+void comments_Test_to_json(const comments_Test*, char* json);
+int comments_Test_from_json(const char* json, comments_Test*);
+bool comments_Test_try_from_json(const char* json, comments_Test* v, jsonif_error* error);
+void comments_Test_set_leading(comments_Test* v, int32_t m);
+void comments_Test_set_trailing(comments_Test* v, const char* s);
+void comments_Test_alloc_none(comments_Test* v, int num);
+void comments_Test_set_none(comments_Test* v, int n, int32_t m);
+void comments_Test_set_a(comments_Test* v, int32_t m);
+void comments_Test_set_b(comments_Test* v, const char* s);
+void comments_Test_set_nested(comments_Test* v, const comments_Test_Nested* m);
+void comments_Test_set_detached(comments_Test* v, int32_t m);
+void comments_Test_set_both(comments_Test* v, int32_t m);
+
+void comments_Test_clear_a(comments_Test* v);
+void comments_Test_clear_b(comments_Test* v);
+void comments_Test_clear_value_case(comments_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
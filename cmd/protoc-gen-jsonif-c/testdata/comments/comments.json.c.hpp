@@ -0,0 +1,13 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_COMMENTS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_COMMENTS_PROTO
+
+#include "comments.json.h"
+#include "comments.json.c.h"
+
+
+::comments::Test::Nested comments_Test_Nested_to_cpp(const comments_Test_Nested* v);
+void comments_Test_Nested_from_cpp(const ::comments::Test::Nested& u, comments_Test_Nested* v);
+::comments::Test comments_Test_to_cpp(const comments_Test* v);
+void comments_Test_from_cpp(const ::comments::Test& u, comments_Test* v);
+
+#endif
@@ -0,0 +1,279 @@
+#include "default_value.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "default_value.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "default_value.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Color
+const default_value_Color default_value_COLOR_UNSPECIFIED = 0;
+const default_value_Color default_value_COLOR_RED = 1;
+const default_value_Color default_value_COLOR_BLUE = 2;
+
+::default_value::Inner default_value_Inner_to_cpp(const default_value_Inner* v) {
+  ::default_value::Inner u;
+  u.value = v->value;
+  return u;
+}
+void default_value_Inner_from_cpp(const ::default_value::Inner& u, default_value_Inner* v) {
+  default_value_Inner_destroy(v);
+  memset(v, 0, sizeof(default_value_Inner));
+  v->value = u.value;
+}
+::default_value::Test default_value_Test_to_cpp(const default_value_Test* v) {
+  ::default_value::Test u;
+  u.d = v->d;
+  u.f = v->f;
+  u.big = v->big;
+  u.i32 = v->i32;
+  u.i64 = v->i64;
+  u.u32 = v->u32;
+  u.u64 = v->u64;
+  u.b = v->b;
+  if (v->s_len != 0) u.s = std::string(v->s, v->s_len);
+  if (v->empty_len != 0) u.empty = std::string(v->empty, v->empty_len);
+  u.color = (decltype(u.color))v->color;
+  u.color_num = (decltype(u.color_num))v->color_num;
+  u.no_default = v->no_default;
+  u.inner = default_value_Inner_to_cpp(&v->inner);
+  return u;
+}
+void default_value_Test_from_cpp(const ::default_value::Test& u, default_value_Test* v) {
+  default_value_Test_destroy(v);
+  memset(v, 0, sizeof(default_value_Test));
+  v->d = u.d;
+  v->f = u.f;
+  v->big = u.big;
+  v->i32 = u.i32;
+  v->i64 = u.i64;
+  v->u32 = u.u32;
+  v->u64 = u.u64;
+  v->b = u.b;
+  if (!u.s.empty()) v->s = strdup(u.s.c_str());
+  v->s_len = (int)u.s.size();
+  if (!u.empty.empty()) v->empty = strdup(u.empty.c_str());
+  v->empty_len = (int)u.empty.size();
+  v->color = (int)u.color;
+  v->color_num = (int)u.color_num;
+  v->no_default = u.no_default;
+  default_value_Inner_from_cpp(u.inner, &v->inner);
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_default_value_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int default_value_Inner_size() {
+  return sizeof(default_value_Inner);
+}
+void default_value_Inner_init(default_value_Inner* v) {
+  memset(v, 0, sizeof(default_value_Inner));
+  v->value = 10;
+}
+void default_value_Inner_destroy(default_value_Inner* v) {
+  memset(&v->value, 0, sizeof(v->value));
+}
+void default_value_Inner_copy(const default_value_Inner* a, default_value_Inner* b) {
+  if (a == b) return;
+  ::default_value::Inner u = default_value_Inner_to_cpp(a);
+  default_value_Inner_from_cpp(u, b);
+}
+bool default_value_Inner_is_equal(const default_value_Inner* a, const default_value_Inner* b) {
+  if (a == b) return true;
+  ::default_value::Inner ua = default_value_Inner_to_cpp(a);
+  ::default_value::Inner ub = default_value_Inner_to_cpp(b);
+  return ua == ub;
+}
+int default_value_Inner_to_json_size(const default_value_Inner* v) {
+  ::default_value::Inner u = default_value_Inner_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void default_value_Inner_to_json(const default_value_Inner* v, char* json) {
+  ::default_value::Inner u = default_value_Inner_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int default_value_Inner_from_json(const char* json, default_value_Inner* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return default_value_Inner_try_from_json(json, v, error) ? 0 : -1;
+}
+bool default_value_Inner_try_from_json(const char* json, default_value_Inner* v, jsonif_error* error) {
+  try {
+    ::default_value::Inner u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    default_value_Inner_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void default_value_Inner_set_value(default_value_Inner* v, int32_t m) {
+  v->value = m;
+}
+int default_value_Test_size() {
+  return sizeof(default_value_Test);
+}
+void default_value_Test_init(default_value_Test* v) {
+  memset(v, 0, sizeof(default_value_Test));
+  v->d = 1.5;
+  v->f = 3.0f;
+  v->big = 1e+30;
+  v->i32 = -2147483648;
+  v->i64 = (-9223372036854775807LL - 1);
+  v->u32 = 4294967295U;
+  v->u64 = 12345678901234ULL;
+  v->b = true;
+  v->s = strdup("hello \"jsonif\"\n");
+  v->s_len = 15;
+  v->color = 1;
+  v->color_num = 2;
+  default_value_Inner_init(&v->inner);
+}
+void default_value_Test_destroy(default_value_Test* v) {
+  memset(&v->d, 0, sizeof(v->d));
+  memset(&v->f, 0, sizeof(v->f));
+  memset(&v->big, 0, sizeof(v->big));
+  memset(&v->i32, 0, sizeof(v->i32));
+  memset(&v->i64, 0, sizeof(v->i64));
+  memset(&v->u32, 0, sizeof(v->u32));
+  memset(&v->u64, 0, sizeof(v->u64));
+  memset(&v->b, 0, sizeof(v->b));
+  if (v->s) free(v->s);
+  v->s = nullptr;
+  v->s_len = 0;
+  if (v->empty) free(v->empty);
+  v->empty = nullptr;
+  v->empty_len = 0;
+  memset(&v->color, 0, sizeof(v->color));
+  memset(&v->color_num, 0, sizeof(v->color_num));
+  memset(&v->no_default, 0, sizeof(v->no_default));
+  default_value_Inner_destroy(&v->inner);
+}
+void default_value_Test_copy(const default_value_Test* a, default_value_Test* b) {
+  if (a == b) return;
+  ::default_value::Test u = default_value_Test_to_cpp(a);
+  default_value_Test_from_cpp(u, b);
+}
+bool default_value_Test_is_equal(const default_value_Test* a, const default_value_Test* b) {
+  if (a == b) return true;
+  ::default_value::Test ua = default_value_Test_to_cpp(a);
+  ::default_value::Test ub = default_value_Test_to_cpp(b);
+  return ua == ub;
+}
+int default_value_Test_to_json_size(const default_value_Test* v) {
+  ::default_value::Test u = default_value_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void default_value_Test_to_json(const default_value_Test* v, char* json) {
+  ::default_value::Test u = default_value_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int default_value_Test_from_json(const char* json, default_value_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return default_value_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool default_value_Test_try_from_json(const char* json, default_value_Test* v, jsonif_error* error) {
+  try {
+    ::default_value::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    default_value_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void default_value_Test_set_d(default_value_Test* v, double m) {
+  v->d = m;
+}
+void default_value_Test_set_f(default_value_Test* v, float m) {
+  v->f = m;
+}
+void default_value_Test_set_big(default_value_Test* v, double m) {
+  v->big = m;
+}
+void default_value_Test_set_i32(default_value_Test* v, int32_t m) {
+  v->i32 = m;
+}
+void default_value_Test_set_i64(default_value_Test* v, int64_t m) {
+  v->i64 = m;
+}
+void default_value_Test_set_u32(default_value_Test* v, uint32_t m) {
+  v->u32 = m;
+}
+void default_value_Test_set_u64(default_value_Test* v, uint64_t m) {
+  v->u64 = m;
+}
+void default_value_Test_set_b(default_value_Test* v, bool m) {
+  v->b = m;
+}
+void default_value_Test_set_s(default_value_Test* v, const char* s) {
+  if (v->s) free(v->s);
+  v->s_len = s == nullptr ? 0 : strlen(s);
+  v->s = v->s_len == 0 ? nullptr : strdup(s);
+}
+void default_value_Test_set_empty(default_value_Test* v, const char* s) {
+  if (v->empty) free(v->empty);
+  v->empty_len = s == nullptr ? 0 : strlen(s);
+  v->empty = v->empty_len == 0 ? nullptr : strdup(s);
+}
+void default_value_Test_set_color(default_value_Test* v, default_value_Color m) {
+  v->color = m;
+}
+void default_value_Test_set_color_num(default_value_Test* v, default_value_Color m) {
+  v->color_num = m;
+}
+void default_value_Test_set_no_default(default_value_Test* v, int32_t m) {
+  v->no_default = m;
+}
+void default_value_Test_set_inner(default_value_Test* v, const default_value_Inner* m) {
+  default_value_Inner_copy(m, &v->inner);
+}
+
+}
@@ -0,0 +1,130 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_DEFAULT_VALUE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_DEFAULT_VALUE_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_default_value_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_default_value_proto();
+}
+
+#endif
+
+// Color
+typedef int default_value_Color;
+extern const default_value_Color default_value_COLOR_UNSPECIFIED;
+extern const default_value_Color default_value_COLOR_RED;
+extern const default_value_Color default_value_COLOR_BLUE;
+
+// Inner
+typedef struct {
+  int32_t value;
+} default_value_Inner;
+
+int default_value_Inner_size();
+void default_value_Inner_init(default_value_Inner* v);
+void default_value_Inner_destroy(default_value_Inner*);
+void default_value_Inner_copy(const default_value_Inner* a, default_value_Inner* b);
+bool default_value_Inner_is_equal(const default_value_Inner* a, const default_value_Inner* b);
+int default_value_Inner_to_json_size(const default_value_Inner*);
+void default_value_Inner_to_json(const default_value_Inner*, char* json);
+int default_value_Inner_from_json(const char* json, default_value_Inner*);
+bool default_value_Inner_try_from_json(const char* json, default_value_Inner* v, jsonif_error* error);
+void default_value_Inner_set_value(default_value_Inner* v, int32_t m);
+
+// Test
+/// キーが無い場合は jsonif_default の値になる
+/// デフォルト値と同じ値のフィールドは出力しない
+typedef struct {
+  double d;
+  float f;
+  double big;
+  int32_t i32;
+  int64_t i64;
+  uint32_t u32;
+  uint64_t u64;
+  bool b;
+  char* s;
+  int s_len;
+  char* empty;
+  int empty_len;
+  /// enum は値の名前でも数値でも指定できる
+  default_value_Color color;
+  default_value_Color color_num;
+  int32_t no_default;
+  /// メッセージのフィールドも、そのメッセージの jsonif_default の値で初期化される
+  default_value_Inner inner;
+} default_value_Test;
+
+int default_value_Test_size();
+void default_value_Test_init(default_value_Test* v);
+void default_value_Test_destroy(default_value_Test*);
+void default_value_Test_copy(const default_value_Test* a, default_value_Test* b);
+bool default_value_Test_is_equal(const default_value_Test* a, const default_value_Test* b);
+int default_value_Test_to_json_size(const default_value_Test*);
+void default_value_Test_to_json(const default_value_Test*, char* json);
+int default_value_Test_from_json(const char* json, default_value_Test*);
+bool default_value_Test_try_from_json(const char* json, default_value_Test* v, jsonif_error* error);
+void default_value_Test_set_d(default_value_Test* v, double m);
+void default_value_Test_set_f(default_value_Test* v, float m);
+void default_value_Test_set_big(default_value_Test* v, double m);
+void default_value_Test_set_i32(default_value_Test* v, int32_t m);
+void default_value_Test_set_i64(default_value_Test* v, int64_t m);
+void default_value_Test_set_u32(default_value_Test* v, uint32_t m);
+void default_value_Test_set_u64(default_value_Test* v, uint64_t m);
+void default_value_Test_set_b(default_value_Test* v, bool m);
+void default_value_Test_set_s(default_value_Test* v, const char* s);
+void default_value_Test_set_empty(default_value_Test* v, const char* s);
+void default_value_Test_set_color(default_value_Test* v, default_value_Color m);
+void default_value_Test_set_color_num(default_value_Test* v, default_value_Color m);
+void default_value_Test_set_no_default(default_value_Test* v, int32_t m);
+void default_value_Test_set_inner(default_value_Test* v, const default_value_Inner* m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
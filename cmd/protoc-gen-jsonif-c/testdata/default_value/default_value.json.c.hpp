@@ -0,0 +1,13 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_DEFAULT_VALUE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_DEFAULT_VALUE_PROTO
+
+#include "default_value.json.h"
+#include "default_value.json.c.h"
+
+
+::default_value::Inner default_value_Inner_to_cpp(const default_value_Inner* v);
+void default_value_Inner_from_cpp(const ::default_value::Inner& u, default_value_Inner* v);
+::default_value::Test default_value_Test_to_cpp(const default_value_Test* v);
+void default_value_Test_from_cpp(const ::default_value::Test& u, default_value_Test* v);
+
+#endif
@@ -0,0 +1,449 @@
+#include "deprecated.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "deprecated.json.h"
+
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic push
+#pragma GCC diagnostic ignored "-Wdeprecated-declarations"
+#elif defined(_MSC_VER)
+#pragma warning(push)
+#pragma warning(disable: 4996)
+#endif
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "deprecated.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Status
+const deprecated_Status deprecated_STATUS_UNKNOWN = 0;
+const deprecated_Status deprecated_STATUS_ACTIVE = 1;
+const deprecated_Status deprecated_STATUS_ENABLED = 2;
+
+::deprecated::Legacy deprecated_Legacy_to_cpp(const deprecated_Legacy* v) {
+  ::deprecated::Legacy u;
+  u.value = v->value;
+  return u;
+}
+void deprecated_Legacy_from_cpp(const ::deprecated::Legacy& u, deprecated_Legacy* v) {
+  deprecated_Legacy_destroy(v);
+  memset(v, 0, sizeof(deprecated_Legacy));
+  v->value = u.value;
+}
+// choice
+const deprecated_Test_ChoiceCase deprecated_Test_ChoiceCase_NOT_SET = 0;
+const deprecated_Test_ChoiceCase deprecated_Test_ChoiceCase_kNumber = 7;
+const deprecated_Test_ChoiceCase deprecated_Test_ChoiceCase_kText = 8;
+
+// _note
+const deprecated_Test_NoteCase deprecated_Test_NoteCase_NOT_SET = 0;
+const deprecated_Test_NoteCase deprecated_Test_NoteCase_kNote = 6;
+
+::deprecated::Test deprecated_Test_to_cpp(const deprecated_Test* v) {
+  ::deprecated::Test u;
+  u.id = v->id;
+  u.old_id = v->old_id;
+  u.status = (decltype(u.status))v->status;
+  u.legacy = deprecated_Legacy_to_cpp(&v->legacy);
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags_lens[i] != 0) {
+      u.tags.push_back(std::string(v->tags[i], v->tags_lens[i]));
+    } else {
+      u.tags.push_back("");
+    }
+  }
+  if (v->note_len != 0) u.note = std::string(v->note, v->note_len);
+  u.number = v->number;
+  if (v->text_len != 0) u.text = std::string(v->text, v->text_len);
+  u.choice_case = (::deprecated::Test::ChoiceCase)v->choice_case;
+  u._note_case = (::deprecated::Test::NoteCase)v->_note_case;
+  return u;
+}
+void deprecated_Test_from_cpp(const ::deprecated::Test& u, deprecated_Test* v) {
+  deprecated_Test_destroy(v);
+  memset(v, 0, sizeof(deprecated_Test));
+  v->id = u.id;
+  v->old_id = u.old_id;
+  v->status = (int)u.status;
+  deprecated_Legacy_from_cpp(u.legacy, &v->legacy);
+  v->tags_len = (int)u.tags.size();
+  v->tags = v->tags_len == 0 ? nullptr : (decltype(v->tags))malloc(sizeof(v->tags[0]) * u.tags.size());
+  v->tags_lens = v->tags_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.tags.size());
+  for (int i = 0; i < (int)u.tags.size(); i++) {
+    if (!u.tags[i].empty()) v->tags[i] = strdup(u.tags[i].c_str());
+    v->tags_lens[i] = (int)u.tags[i].size();
+  }
+  if (!u.note.empty()) v->note = strdup(u.note.c_str());
+  v->note_len = (int)u.note.size();
+  v->number = u.number;
+  if (!u.text.empty()) v->text = strdup(u.text.c_str());
+  v->text_len = (int)u.text.size();
+  v->choice_case = (int)u.choice_case;
+  v->_note_case = (int)u._note_case;
+}
+::deprecated::Holder deprecated_Holder_to_cpp(const deprecated_Holder* v) {
+  ::deprecated::Holder u;
+  u.status = (decltype(u.status))v->status;
+  for (int i = 0; i < v->statuses_len; i++) {
+    decltype(u.statuses)::key_type key{};
+    decltype(u.statuses)::mapped_type value{};
+    if (v->statuses[i].key_len != 0) key = std::string(v->statuses[i].key, v->statuses[i].key_len);
+    value = (decltype(value))v->statuses[i].value;
+    u.statuses.emplace(std::move(key), std::move(value));
+  }
+  return u;
+}
+void deprecated_Holder_from_cpp(const ::deprecated::Holder& u, deprecated_Holder* v) {
+  deprecated_Holder_destroy(v);
+  memset(v, 0, sizeof(deprecated_Holder));
+  v->status = (int)u.status;
+  v->statuses_len = (int)u.statuses.size();
+  v->statuses = v->statuses_len == 0 ? nullptr : (decltype(v->statuses))malloc(sizeof(v->statuses[0]) * u.statuses.size());
+  int statuses_index = 0;
+  for (const auto& kv : u.statuses) {
+    deprecated_Holder_StatusesEntry_init(&v->statuses[statuses_index]);
+    if (!kv.first.empty()) v->statuses[statuses_index].key = strdup(kv.first.c_str());
+    v->statuses[statuses_index].key_len = (int)kv.first.size();
+    v->statuses[statuses_index].value = (int)kv.second;
+    statuses_index++;
+  }
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_deprecated_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int deprecated_Legacy_size() {
+  return sizeof(deprecated_Legacy);
+}
+void deprecated_Legacy_init(deprecated_Legacy* v) {
+  memset(v, 0, sizeof(deprecated_Legacy));
+}
+void deprecated_Legacy_destroy(deprecated_Legacy* v) {
+  memset(&v->value, 0, sizeof(v->value));
+}
+void deprecated_Legacy_copy(const deprecated_Legacy* a, deprecated_Legacy* b) {
+  if (a == b) return;
+  ::deprecated::Legacy u = deprecated_Legacy_to_cpp(a);
+  deprecated_Legacy_from_cpp(u, b);
+}
+bool deprecated_Legacy_is_equal(const deprecated_Legacy* a, const deprecated_Legacy* b) {
+  if (a == b) return true;
+  ::deprecated::Legacy ua = deprecated_Legacy_to_cpp(a);
+  ::deprecated::Legacy ub = deprecated_Legacy_to_cpp(b);
+  return ua == ub;
+}
+int deprecated_Legacy_to_json_size(const deprecated_Legacy* v) {
+  ::deprecated::Legacy u = deprecated_Legacy_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void deprecated_Legacy_to_json(const deprecated_Legacy* v, char* json) {
+  ::deprecated::Legacy u = deprecated_Legacy_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int deprecated_Legacy_from_json(const char* json, deprecated_Legacy* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return deprecated_Legacy_try_from_json(json, v, error) ? 0 : -1;
+}
+bool deprecated_Legacy_try_from_json(const char* json, deprecated_Legacy* v, jsonif_error* error) {
+  try {
+    ::deprecated::Legacy u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    deprecated_Legacy_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void deprecated_Legacy_set_value(deprecated_Legacy* v, int32_t m) {
+  v->value = m;
+}
+int deprecated_Test_size() {
+  return sizeof(deprecated_Test);
+}
+void deprecated_Test_init(deprecated_Test* v) {
+  memset(v, 0, sizeof(deprecated_Test));
+}
+void deprecated_Test_destroy(deprecated_Test* v) {
+  memset(&v->id, 0, sizeof(v->id));
+  memset(&v->old_id, 0, sizeof(v->old_id));
+  memset(&v->status, 0, sizeof(v->status));
+  deprecated_Legacy_destroy(&v->legacy);
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags[i]) free(v->tags[i]);
+    v->tags[i] = nullptr;
+    v->tags_lens[i] = 0;
+  }
+  if (v->tags_lens) free(v->tags_lens);
+  v->tags_lens = nullptr;
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  if (v->note) free(v->note);
+  v->note = nullptr;
+  v->note_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+  if (v->text) free(v->text);
+  v->text = nullptr;
+  v->text_len = 0;
+}
+void deprecated_Test_copy(const deprecated_Test* a, deprecated_Test* b) {
+  if (a == b) return;
+  ::deprecated::Test u = deprecated_Test_to_cpp(a);
+  deprecated_Test_from_cpp(u, b);
+}
+bool deprecated_Test_is_equal(const deprecated_Test* a, const deprecated_Test* b) {
+  if (a == b) return true;
+  ::deprecated::Test ua = deprecated_Test_to_cpp(a);
+  ::deprecated::Test ub = deprecated_Test_to_cpp(b);
+  return ua == ub;
+}
+int deprecated_Test_to_json_size(const deprecated_Test* v) {
+  ::deprecated::Test u = deprecated_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void deprecated_Test_to_json(const deprecated_Test* v, char* json) {
+  ::deprecated::Test u = deprecated_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int deprecated_Test_from_json(const char* json, deprecated_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return deprecated_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool deprecated_Test_try_from_json(const char* json, deprecated_Test* v, jsonif_error* error) {
+  try {
+    ::deprecated::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    deprecated_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void deprecated_Test_set_id(deprecated_Test* v, int32_t m) {
+  v->id = m;
+}
+void deprecated_Test_set_old_id(deprecated_Test* v, int32_t m) {
+  v->old_id = m;
+}
+void deprecated_Test_set_status(deprecated_Test* v, deprecated_Status m) {
+  v->status = m;
+}
+void deprecated_Test_set_legacy(deprecated_Test* v, const deprecated_Legacy* m) {
+  deprecated_Legacy_copy(m, &v->legacy);
+}
+void deprecated_Test_alloc_tags(deprecated_Test* v, int num) {
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  if (num != 0) {
+    v->tags = (decltype(v->tags))malloc(sizeof(v->tags[0]) * num);
+    memset(v->tags, 0, sizeof(v->tags[0]) * num);
+    v->tags_len = num;
+    v->tags_lens = (decltype(v->tags_lens))malloc(sizeof(v->tags_lens[0]) * num);
+    memset(v->tags_lens, 0, sizeof(v->tags_lens[0]) * num);
+  }
+}
+
+void deprecated_Test_set_tags(deprecated_Test* v, int n, const char* s) {
+  if (v->tags[n]) free(v->tags[n]);
+  v->tags_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->tags[n] = v->tags_lens[n] == 0 ? nullptr : strdup(s);
+}
+void deprecated_Test_set_note(deprecated_Test* v, const char* s) {
+  deprecated_Test_clear__note_case(v);
+  v->_note_case = deprecated_Test_NoteCase_kNote;
+  if (v->note) free(v->note);
+  v->note_len = s == nullptr ? 0 : strlen(s);
+  v->note = v->note_len == 0 ? nullptr : strdup(s);
+}
+void deprecated_Test_set_number(deprecated_Test* v, int32_t m) {
+  deprecated_Test_clear_choice_case(v);
+  v->choice_case = deprecated_Test_ChoiceCase_kNumber;
+  v->number = m;
+}
+void deprecated_Test_set_text(deprecated_Test* v, const char* s) {
+  deprecated_Test_clear_choice_case(v);
+  v->choice_case = deprecated_Test_ChoiceCase_kText;
+  if (v->text) free(v->text);
+  v->text_len = s == nullptr ? 0 : strlen(s);
+  v->text = v->text_len == 0 ? nullptr : strdup(s);
+}
+bool deprecated_Test_has_note(const deprecated_Test* v) {
+  return v->_note_case == deprecated_Test_NoteCase_kNote;
+}
+void deprecated_Test_clear_note(deprecated_Test* v) {
+  if (v->_note_case == deprecated_Test_NoteCase_kNote) {
+    deprecated_Test_clear__note_case(v);
+  }
+}
+void deprecated_Test_clear_number(deprecated_Test* v) {
+  if (v->choice_case == deprecated_Test_ChoiceCase_kNumber) {
+    deprecated_Test_clear_choice_case(v);
+  }
+}
+void deprecated_Test_clear_text(deprecated_Test* v) {
+  if (v->choice_case == deprecated_Test_ChoiceCase_kText) {
+    deprecated_Test_clear_choice_case(v);
+  }
+}
+void deprecated_Test_clear_choice_case(deprecated_Test* v) {
+  memset(&v->number, 0, sizeof(v->number));
+  if (v->text) free(v->text);
+  v->text = nullptr;
+  v->text_len = 0;
+  v->choice_case = deprecated_Test_ChoiceCase_NOT_SET;
+}
+void deprecated_Test_clear__note_case(deprecated_Test* v) {
+  if (v->note) free(v->note);
+  v->note = nullptr;
+  v->note_len = 0;
+  v->_note_case = deprecated_Test_NoteCase_NOT_SET;
+}
+int deprecated_Holder_StatusesEntry_size() {
+  return sizeof(deprecated_Holder_StatusesEntry);
+}
+void deprecated_Holder_StatusesEntry_init(deprecated_Holder_StatusesEntry* v) {
+  memset(v, 0, sizeof(deprecated_Holder_StatusesEntry));
+}
+void deprecated_Holder_StatusesEntry_destroy(deprecated_Holder_StatusesEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  memset(&v->value, 0, sizeof(v->value));
+}
+void deprecated_Holder_StatusesEntry_set_key(deprecated_Holder_StatusesEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void deprecated_Holder_StatusesEntry_set_value(deprecated_Holder_StatusesEntry* v, deprecated_Status m) {
+  v->value = m;
+}
+int deprecated_Holder_size() {
+  return sizeof(deprecated_Holder);
+}
+void deprecated_Holder_init(deprecated_Holder* v) {
+  memset(v, 0, sizeof(deprecated_Holder));
+}
+void deprecated_Holder_destroy(deprecated_Holder* v) {
+  memset(&v->status, 0, sizeof(v->status));
+  for (int i = 0; i < v->statuses_len; i++) {
+    deprecated_Holder_StatusesEntry_destroy(&v->statuses[i]);
+  }
+  if (v->statuses) free(v->statuses);
+  v->statuses = nullptr;
+  v->statuses_len = 0;
+}
+void deprecated_Holder_copy(const deprecated_Holder* a, deprecated_Holder* b) {
+  if (a == b) return;
+  ::deprecated::Holder u = deprecated_Holder_to_cpp(a);
+  deprecated_Holder_from_cpp(u, b);
+}
+bool deprecated_Holder_is_equal(const deprecated_Holder* a, const deprecated_Holder* b) {
+  if (a == b) return true;
+  ::deprecated::Holder ua = deprecated_Holder_to_cpp(a);
+  ::deprecated::Holder ub = deprecated_Holder_to_cpp(b);
+  return ua == ub;
+}
+int deprecated_Holder_to_json_size(const deprecated_Holder* v) {
+  ::deprecated::Holder u = deprecated_Holder_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void deprecated_Holder_to_json(const deprecated_Holder* v, char* json) {
+  ::deprecated::Holder u = deprecated_Holder_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int deprecated_Holder_from_json(const char* json, deprecated_Holder* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return deprecated_Holder_try_from_json(json, v, error) ? 0 : -1;
+}
+bool deprecated_Holder_try_from_json(const char* json, deprecated_Holder* v, jsonif_error* error) {
+  try {
+    ::deprecated::Holder u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    deprecated_Holder_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void deprecated_Holder_set_status(deprecated_Holder* v, deprecated_Status m) {
+  v->status = m;
+}
+void deprecated_Holder_alloc_statuses(deprecated_Holder* v, int num) {
+  if (v->statuses) free(v->statuses);
+  v->statuses = nullptr;
+  v->statuses_len = 0;
+  if (num != 0) {
+    v->statuses = (decltype(v->statuses))malloc(sizeof(v->statuses[0]) * num);
+    memset(v->statuses, 0, sizeof(v->statuses[0]) * num);
+    v->statuses_len = num;
+  }
+}
+
+
+}
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic pop
+#elif defined(_MSC_VER)
+#pragma warning(pop)
+#endif
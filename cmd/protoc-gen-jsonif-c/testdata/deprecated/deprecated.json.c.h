@@ -0,0 +1,194 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_DEPRECATED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_DEPRECATED_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifndef JSONIF_C_DEPRECATED
+#if defined(__GNUC__) || defined(__clang__)
+#define JSONIF_C_DEPRECATED __attribute__((deprecated))
+#else
+#define JSONIF_C_DEPRECATED
+#endif
+#endif
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic push
+#pragma GCC diagnostic ignored "-Wdeprecated-declarations"
+#elif defined(_MSC_VER)
+#pragma warning(push)
+#pragma warning(disable: 4996)
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_deprecated_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_deprecated_proto();
+}
+
+#endif
+
+// Status
+typedef int deprecated_Status;
+extern const deprecated_Status deprecated_STATUS_UNKNOWN;
+extern const deprecated_Status deprecated_STATUS_ACTIVE;
+/// STATUS_ACTIVE を使うこと
+extern const deprecated_Status deprecated_STATUS_ENABLED JSONIF_C_DEPRECATED;
+
+// choice
+typedef int deprecated_Test_ChoiceCase;
+extern const deprecated_Test_ChoiceCase deprecated_Test_ChoiceCase_NOT_SET;
+extern const deprecated_Test_ChoiceCase deprecated_Test_ChoiceCase_kNumber;
+extern const deprecated_Test_ChoiceCase deprecated_Test_ChoiceCase_kText;
+
+// _note
+typedef int deprecated_Test_NoteCase;
+extern const deprecated_Test_NoteCase deprecated_Test_NoteCase_NOT_SET;
+extern const deprecated_Test_NoteCase deprecated_Test_NoteCase_kNote;
+
+// Legacy
+/// Test に置き換えられた古いメッセージ
+typedef struct {
+  int32_t value;
+} deprecated_Legacy JSONIF_C_DEPRECATED;
+
+int deprecated_Legacy_size();
+void deprecated_Legacy_init(deprecated_Legacy* v);
+void deprecated_Legacy_destroy(deprecated_Legacy*);
+void deprecated_Legacy_copy(const deprecated_Legacy* a, deprecated_Legacy* b);
+bool deprecated_Legacy_is_equal(const deprecated_Legacy* a, const deprecated_Legacy* b);
+int deprecated_Legacy_to_json_size(const deprecated_Legacy*);
+void deprecated_Legacy_to_json(const deprecated_Legacy*, char* json);
+int deprecated_Legacy_from_json(const char* json, deprecated_Legacy*);
+bool deprecated_Legacy_try_from_json(const char* json, deprecated_Legacy* v, jsonif_error* error);
+void deprecated_Legacy_set_value(deprecated_Legacy* v, int32_t m);
+
+// Test
+typedef struct {
+  int32_t id;
+  /// id を使うこと
+  int32_t old_id JSONIF_C_DEPRECATED;
+  deprecated_Status status;
+  deprecated_Legacy legacy JSONIF_C_DEPRECATED;
+  char** tags JSONIF_C_DEPRECATED;
+  int* tags_lens JSONIF_C_DEPRECATED;
+  int tags_len JSONIF_C_DEPRECATED;
+  char* note JSONIF_C_DEPRECATED;
+  int note_len JSONIF_C_DEPRECATED;
+  int32_t number;
+  char* text JSONIF_C_DEPRECATED;
+  int text_len JSONIF_C_DEPRECATED;
+  deprecated_Test_ChoiceCase choice_case;
+  deprecated_Test_NoteCase _note_case;
+} deprecated_Test;
+
+int deprecated_Test_size();
+void deprecated_Test_init(deprecated_Test* v);
+void deprecated_Test_destroy(deprecated_Test*);
+void deprecated_Test_copy(const deprecated_Test* a, deprecated_Test* b);
+bool deprecated_Test_is_equal(const deprecated_Test* a, const deprecated_Test* b);
+int deprecated_Test_to_json_size(const deprecated_Test*);
+void deprecated_Test_to_json(const deprecated_Test*, char* json);
+int deprecated_Test_from_json(const char* json, deprecated_Test*);
+bool deprecated_Test_try_from_json(const char* json, deprecated_Test* v, jsonif_error* error);
+void deprecated_Test_set_id(deprecated_Test* v, int32_t m);
+void deprecated_Test_set_old_id(deprecated_Test* v, int32_t m) JSONIF_C_DEPRECATED;
+void deprecated_Test_set_status(deprecated_Test* v, deprecated_Status m);
+void deprecated_Test_set_legacy(deprecated_Test* v, const deprecated_Legacy* m) JSONIF_C_DEPRECATED;
+void deprecated_Test_alloc_tags(deprecated_Test* v, int num) JSONIF_C_DEPRECATED;
+void deprecated_Test_set_tags(deprecated_Test* v, int n, const char* s) JSONIF_C_DEPRECATED;
+void deprecated_Test_set_note(deprecated_Test* v, const char* s) JSONIF_C_DEPRECATED;
+void deprecated_Test_set_number(deprecated_Test* v, int32_t m);
+void deprecated_Test_set_text(deprecated_Test* v, const char* s) JSONIF_C_DEPRECATED;
+
+bool deprecated_Test_has_note(const deprecated_Test* v) JSONIF_C_DEPRECATED;
+void deprecated_Test_clear_note(deprecated_Test* v) JSONIF_C_DEPRECATED;
+void deprecated_Test_clear_number(deprecated_Test* v);
+void deprecated_Test_clear_text(deprecated_Test* v) JSONIF_C_DEPRECATED;
+void deprecated_Test_clear_choice_case(deprecated_Test* v);
+void deprecated_Test_clear__note_case(deprecated_Test* v);
+// StatusesEntry
+typedef struct {
+  char* key;
+  int key_len;
+  deprecated_Status value;
+} deprecated_Holder_StatusesEntry;
+
+int deprecated_Holder_StatusesEntry_size();
+void deprecated_Holder_StatusesEntry_init(deprecated_Holder_StatusesEntry* v);
+void deprecated_Holder_StatusesEntry_destroy(deprecated_Holder_StatusesEntry*);
+void deprecated_Holder_StatusesEntry_set_key(deprecated_Holder_StatusesEntry* v, const char* s);
+void deprecated_Holder_StatusesEntry_set_value(deprecated_Holder_StatusesEntry* v, deprecated_Status m);
+
+// Holder
+/// deprecated なメッセージや enum の値を参照するだけのメッセージ
+typedef struct {
+  deprecated_Status status;
+  deprecated_Holder_StatusesEntry* statuses;
+  int statuses_len;
+} deprecated_Holder;
+
+int deprecated_Holder_size();
+void deprecated_Holder_init(deprecated_Holder* v);
+void deprecated_Holder_destroy(deprecated_Holder*);
+void deprecated_Holder_copy(const deprecated_Holder* a, deprecated_Holder* b);
+bool deprecated_Holder_is_equal(const deprecated_Holder* a, const deprecated_Holder* b);
+int deprecated_Holder_to_json_size(const deprecated_Holder*);
+void deprecated_Holder_to_json(const deprecated_Holder*, char* json);
+int deprecated_Holder_from_json(const char* json, deprecated_Holder*);
+bool deprecated_Holder_try_from_json(const char* json, deprecated_Holder* v, jsonif_error* error);
+void deprecated_Holder_set_status(deprecated_Holder* v, deprecated_Status m);
+void deprecated_Holder_alloc_statuses(deprecated_Holder* v, int num);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic pop
+#elif defined(_MSC_VER)
+#pragma warning(pop)
+#endif
+
+#endif
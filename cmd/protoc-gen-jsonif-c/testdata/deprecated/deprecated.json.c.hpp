@@ -0,0 +1,29 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_DEPRECATED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_DEPRECATED_PROTO
+
+#include "deprecated.json.h"
+#include "deprecated.json.c.h"
+
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic push
+#pragma GCC diagnostic ignored "-Wdeprecated-declarations"
+#elif defined(_MSC_VER)
+#pragma warning(push)
+#pragma warning(disable: 4996)
+#endif
+
+::deprecated::Legacy deprecated_Legacy_to_cpp(const deprecated_Legacy* v);
+void deprecated_Legacy_from_cpp(const ::deprecated::Legacy& u, deprecated_Legacy* v);
+::deprecated::Test deprecated_Test_to_cpp(const deprecated_Test* v);
+void deprecated_Test_from_cpp(const ::deprecated::Test& u, deprecated_Test* v);
+::deprecated::Holder deprecated_Holder_to_cpp(const deprecated_Holder* v);
+void deprecated_Holder_from_cpp(const ::deprecated::Holder& u, deprecated_Holder* v);
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic pop
+#elif defined(_MSC_VER)
+#pragma warning(pop)
+#endif
+
+#endif
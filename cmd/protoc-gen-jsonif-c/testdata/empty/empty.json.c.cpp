@@ -0,0 +1,100 @@
+#include "empty.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "empty.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "empty.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::empty::Test empty_Test_to_cpp(const empty_Test* v) {
+  ::empty::Test u;
+  return u;
+}
+void empty_Test_from_cpp(const ::empty::Test& u, empty_Test* v) {
+  empty_Test_destroy(v);
+  memset(v, 0, sizeof(empty_Test));
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_empty_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int empty_Test_size() {
+  return sizeof(empty_Test);
+}
+void empty_Test_init(empty_Test* v) {
+  memset(v, 0, sizeof(empty_Test));
+}
+void empty_Test_destroy(empty_Test* v) {
+}
+void empty_Test_copy(const empty_Test* a, empty_Test* b) {
+  if (a == b) return;
+  ::empty::Test u = empty_Test_to_cpp(a);
+  empty_Test_from_cpp(u, b);
+}
+bool empty_Test_is_equal(const empty_Test* a, const empty_Test* b) {
+  if (a == b) return true;
+  ::empty::Test ua = empty_Test_to_cpp(a);
+  ::empty::Test ub = empty_Test_to_cpp(b);
+  return ua == ub;
+}
+int empty_Test_to_json_size(const empty_Test* v) {
+  ::empty::Test u = empty_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void empty_Test_to_json(const empty_Test* v, char* json) {
+  ::empty::Test u = empty_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int empty_Test_from_json(const char* json, empty_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return empty_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool empty_Test_try_from_json(const char* json, empty_Test* v, jsonif_error* error) {
+  try {
+    ::empty::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    empty_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+
+}
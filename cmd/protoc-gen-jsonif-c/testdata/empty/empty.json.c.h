@@ -0,0 +1,74 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_EMPTY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_EMPTY_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_empty_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_empty_proto();
+}
+
+#endif
+
+// Test
+typedef struct {
+} empty_Test;
+
+int empty_Test_size();
+void empty_Test_init(empty_Test* v);
+void empty_Test_destroy(empty_Test*);
+void empty_Test_copy(const empty_Test* a, empty_Test* b);
+bool empty_Test_is_equal(const empty_Test* a, const empty_Test* b);
+int empty_Test_to_json_size(const empty_Test*);
+void empty_Test_to_json(const empty_Test*, char* json);
+int empty_Test_from_json(const char* json, empty_Test*);
+bool empty_Test_try_from_json(const char* json, empty_Test* v, jsonif_error* error);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_EMPTY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_EMPTY_PROTO
+
+#include "empty.json.h"
+#include "empty.json.c.h"
+
+
+::empty::Test empty_Test_to_cpp(const empty_Test* v);
+void empty_Test_from_cpp(const ::empty::Test& u, empty_Test* v);
+
+#endif
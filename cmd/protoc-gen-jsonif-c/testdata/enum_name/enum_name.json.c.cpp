@@ -0,0 +1,274 @@
+#include "enum_name.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "enum_name.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "enum_name.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Color
+const enum_name_Color enum_name_COLOR_UNSPECIFIED = 0;
+const enum_name_Color enum_name_COLOR_RED = 1;
+const enum_name_Color enum_name_COLOR_BLUE = 2;
+
+// Number
+const enum_name_Number enum_name_NUMBER_ZERO = 0;
+const enum_name_Number enum_name_NUMBER_ONE = 1;
+
+// Nested
+const enum_name_Test_Nested enum_name_Test_NESTED_FOO = 0;
+const enum_name_Test_Nested enum_name_Test_NESTED_BAR = 1;
+
+// value
+const enum_name_Test_ValueCase enum_name_Test_ValueCase_NOT_SET = 0;
+const enum_name_Test_ValueCase enum_name_Test_ValueCase_kOneofColor = 7;
+const enum_name_Test_ValueCase enum_name_Test_ValueCase_kOneofInt = 8;
+
+// _opt_color
+const enum_name_Test_OptColorCase enum_name_Test_OptColorCase_NOT_SET = 0;
+const enum_name_Test_OptColorCase enum_name_Test_OptColorCase_kOptColor = 6;
+
+::enum_name::Test enum_name_Test_to_cpp(const enum_name_Test* v) {
+  ::enum_name::Test u;
+  u.color = (decltype(u.color))v->color;
+  for (int i = 0; i < v->colors_len; i++) {
+    u.colors.push_back((decltype(u.colors[0]))v->colors[i]);
+  }
+  for (int i = 0; i < v->color_map_len; i++) {
+    decltype(u.color_map)::key_type key{};
+    decltype(u.color_map)::mapped_type value{};
+    if (v->color_map[i].key_len != 0) key = std::string(v->color_map[i].key, v->color_map[i].key_len);
+    value = (decltype(value))v->color_map[i].value;
+    u.color_map.emplace(std::move(key), std::move(value));
+  }
+  u.number = (decltype(u.number))v->number;
+  u.nested = (decltype(u.nested))v->nested;
+  u.opt_color = (decltype(u.opt_color))v->opt_color;
+  u.oneof_color = (decltype(u.oneof_color))v->oneof_color;
+  u.oneof_int = v->oneof_int;
+  u.value_case = (::enum_name::Test::ValueCase)v->value_case;
+  u._opt_color_case = (::enum_name::Test::OptColorCase)v->_opt_color_case;
+  return u;
+}
+void enum_name_Test_from_cpp(const ::enum_name::Test& u, enum_name_Test* v) {
+  enum_name_Test_destroy(v);
+  memset(v, 0, sizeof(enum_name_Test));
+  v->color = (int)u.color;
+  v->colors_len = (int)u.colors.size();
+  v->colors = v->colors_len == 0 ? nullptr : (decltype(v->colors))malloc(sizeof(v->colors[0]) * u.colors.size());
+  for (int i = 0; i < (int)u.colors.size(); i++) {
+    v->colors[i] = (int)u.colors[i];
+  }
+  v->color_map_len = (int)u.color_map.size();
+  v->color_map = v->color_map_len == 0 ? nullptr : (decltype(v->color_map))malloc(sizeof(v->color_map[0]) * u.color_map.size());
+  int color_map_index = 0;
+  for (const auto& kv : u.color_map) {
+    enum_name_Test_ColorMapEntry_init(&v->color_map[color_map_index]);
+    if (!kv.first.empty()) v->color_map[color_map_index].key = strdup(kv.first.c_str());
+    v->color_map[color_map_index].key_len = (int)kv.first.size();
+    v->color_map[color_map_index].value = (int)kv.second;
+    color_map_index++;
+  }
+  v->number = (int)u.number;
+  v->nested = (int)u.nested;
+  v->opt_color = (int)u.opt_color;
+  v->oneof_color = (int)u.oneof_color;
+  v->oneof_int = u.oneof_int;
+  v->value_case = (int)u.value_case;
+  v->_opt_color_case = (int)u._opt_color_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_enum_name_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int enum_name_Test_ColorMapEntry_size() {
+  return sizeof(enum_name_Test_ColorMapEntry);
+}
+void enum_name_Test_ColorMapEntry_init(enum_name_Test_ColorMapEntry* v) {
+  memset(v, 0, sizeof(enum_name_Test_ColorMapEntry));
+}
+void enum_name_Test_ColorMapEntry_destroy(enum_name_Test_ColorMapEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  memset(&v->value, 0, sizeof(v->value));
+}
+void enum_name_Test_ColorMapEntry_set_key(enum_name_Test_ColorMapEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void enum_name_Test_ColorMapEntry_set_value(enum_name_Test_ColorMapEntry* v, enum_name_Color m) {
+  v->value = m;
+}
+int enum_name_Test_size() {
+  return sizeof(enum_name_Test);
+}
+void enum_name_Test_init(enum_name_Test* v) {
+  memset(v, 0, sizeof(enum_name_Test));
+}
+void enum_name_Test_destroy(enum_name_Test* v) {
+  memset(&v->color, 0, sizeof(v->color));
+  if (v->colors) free(v->colors);
+  v->colors = nullptr;
+  v->colors_len = 0;
+  for (int i = 0; i < v->color_map_len; i++) {
+    enum_name_Test_ColorMapEntry_destroy(&v->color_map[i]);
+  }
+  if (v->color_map) free(v->color_map);
+  v->color_map = nullptr;
+  v->color_map_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+  memset(&v->nested, 0, sizeof(v->nested));
+  memset(&v->opt_color, 0, sizeof(v->opt_color));
+  memset(&v->oneof_color, 0, sizeof(v->oneof_color));
+  memset(&v->oneof_int, 0, sizeof(v->oneof_int));
+}
+void enum_name_Test_copy(const enum_name_Test* a, enum_name_Test* b) {
+  if (a == b) return;
+  ::enum_name::Test u = enum_name_Test_to_cpp(a);
+  enum_name_Test_from_cpp(u, b);
+}
+bool enum_name_Test_is_equal(const enum_name_Test* a, const enum_name_Test* b) {
+  if (a == b) return true;
+  ::enum_name::Test ua = enum_name_Test_to_cpp(a);
+  ::enum_name::Test ub = enum_name_Test_to_cpp(b);
+  return ua == ub;
+}
+int enum_name_Test_to_json_size(const enum_name_Test* v) {
+  ::enum_name::Test u = enum_name_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void enum_name_Test_to_json(const enum_name_Test* v, char* json) {
+  ::enum_name::Test u = enum_name_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int enum_name_Test_from_json(const char* json, enum_name_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return enum_name_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool enum_name_Test_try_from_json(const char* json, enum_name_Test* v, jsonif_error* error) {
+  try {
+    ::enum_name::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    enum_name_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void enum_name_Test_set_color(enum_name_Test* v, enum_name_Color m) {
+  v->color = m;
+}
+void enum_name_Test_alloc_colors(enum_name_Test* v, int num) {
+  if (v->colors) free(v->colors);
+  v->colors = nullptr;
+  v->colors_len = 0;
+  if (num != 0) {
+    v->colors = (decltype(v->colors))malloc(sizeof(v->colors[0]) * num);
+    memset(v->colors, 0, sizeof(v->colors[0]) * num);
+    v->colors_len = num;
+  }
+}
+
+void enum_name_Test_set_colors(enum_name_Test* v, int n, enum_name_Color m) {
+  v->colors[n] = m;
+}
+void enum_name_Test_alloc_color_map(enum_name_Test* v, int num) {
+  if (v->color_map) free(v->color_map);
+  v->color_map = nullptr;
+  v->color_map_len = 0;
+  if (num != 0) {
+    v->color_map = (decltype(v->color_map))malloc(sizeof(v->color_map[0]) * num);
+    memset(v->color_map, 0, sizeof(v->color_map[0]) * num);
+    v->color_map_len = num;
+  }
+}
+
+void enum_name_Test_set_number(enum_name_Test* v, enum_name_Number m) {
+  v->number = m;
+}
+void enum_name_Test_set_nested(enum_name_Test* v, enum_name_Test_Nested m) {
+  v->nested = m;
+}
+void enum_name_Test_set_opt_color(enum_name_Test* v, enum_name_Color m) {
+  enum_name_Test_clear__opt_color_case(v);
+  v->_opt_color_case = enum_name_Test_OptColorCase_kOptColor;
+  v->opt_color = m;
+}
+void enum_name_Test_set_oneof_color(enum_name_Test* v, enum_name_Color m) {
+  enum_name_Test_clear_value_case(v);
+  v->value_case = enum_name_Test_ValueCase_kOneofColor;
+  v->oneof_color = m;
+}
+void enum_name_Test_set_oneof_int(enum_name_Test* v, int32_t m) {
+  enum_name_Test_clear_value_case(v);
+  v->value_case = enum_name_Test_ValueCase_kOneofInt;
+  v->oneof_int = m;
+}
+bool enum_name_Test_has_opt_color(const enum_name_Test* v) {
+  return v->_opt_color_case == enum_name_Test_OptColorCase_kOptColor;
+}
+void enum_name_Test_clear_opt_color(enum_name_Test* v) {
+  if (v->_opt_color_case == enum_name_Test_OptColorCase_kOptColor) {
+    enum_name_Test_clear__opt_color_case(v);
+  }
+}
+void enum_name_Test_clear_oneof_color(enum_name_Test* v) {
+  if (v->value_case == enum_name_Test_ValueCase_kOneofColor) {
+    enum_name_Test_clear_value_case(v);
+  }
+}
+void enum_name_Test_clear_oneof_int(enum_name_Test* v) {
+  if (v->value_case == enum_name_Test_ValueCase_kOneofInt) {
+    enum_name_Test_clear_value_case(v);
+  }
+}
+void enum_name_Test_clear_value_case(enum_name_Test* v) {
+  memset(&v->oneof_color, 0, sizeof(v->oneof_color));
+  memset(&v->oneof_int, 0, sizeof(v->oneof_int));
+  v->value_case = enum_name_Test_ValueCase_NOT_SET;
+}
+void enum_name_Test_clear__opt_color_case(enum_name_Test* v) {
+  memset(&v->opt_color, 0, sizeof(v->opt_color));
+  v->_opt_color_case = enum_name_Test_OptColorCase_NOT_SET;
+}
+
+}
@@ -0,0 +1,142 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ENUM_NAME_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ENUM_NAME_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_enum_name_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_enum_name_proto();
+}
+
+#endif
+
+// Color
+typedef int enum_name_Color;
+extern const enum_name_Color enum_name_COLOR_UNSPECIFIED;
+extern const enum_name_Color enum_name_COLOR_RED;
+extern const enum_name_Color enum_name_COLOR_BLUE;
+
+// Number
+/// enum ごとの設定がファイルの設定より優先される
+typedef int enum_name_Number;
+extern const enum_name_Number enum_name_NUMBER_ZERO;
+extern const enum_name_Number enum_name_NUMBER_ONE;
+
+// Nested
+typedef int enum_name_Test_Nested;
+extern const enum_name_Test_Nested enum_name_Test_NESTED_FOO;
+extern const enum_name_Test_Nested enum_name_Test_NESTED_BAR;
+
+// value
+typedef int enum_name_Test_ValueCase;
+extern const enum_name_Test_ValueCase enum_name_Test_ValueCase_NOT_SET;
+extern const enum_name_Test_ValueCase enum_name_Test_ValueCase_kOneofColor;
+extern const enum_name_Test_ValueCase enum_name_Test_ValueCase_kOneofInt;
+
+// _opt_color
+typedef int enum_name_Test_OptColorCase;
+extern const enum_name_Test_OptColorCase enum_name_Test_OptColorCase_NOT_SET;
+extern const enum_name_Test_OptColorCase enum_name_Test_OptColorCase_kOptColor;
+
+// ColorMapEntry
+typedef struct {
+  char* key;
+  int key_len;
+  enum_name_Color value;
+} enum_name_Test_ColorMapEntry;
+
+int enum_name_Test_ColorMapEntry_size();
+void enum_name_Test_ColorMapEntry_init(enum_name_Test_ColorMapEntry* v);
+void enum_name_Test_ColorMapEntry_destroy(enum_name_Test_ColorMapEntry*);
+void enum_name_Test_ColorMapEntry_set_key(enum_name_Test_ColorMapEntry* v, const char* s);
+void enum_name_Test_ColorMapEntry_set_value(enum_name_Test_ColorMapEntry* v, enum_name_Color m);
+
+// Test
+typedef struct {
+  enum_name_Color color;
+  enum_name_Color* colors;
+  int colors_len;
+  enum_name_Test_ColorMapEntry* color_map;
+  int color_map_len;
+  enum_name_Number number;
+  enum_name_Test_Nested nested;
+  enum_name_Color opt_color;
+  enum_name_Color oneof_color;
+  int32_t oneof_int;
+  enum_name_Test_ValueCase value_case;
+  enum_name_Test_OptColorCase _opt_color_case;
+} enum_name_Test;
+
+int enum_name_Test_size();
+void enum_name_Test_init(enum_name_Test* v);
+void enum_name_Test_destroy(enum_name_Test*);
+void enum_name_Test_copy(const enum_name_Test* a, enum_name_Test* b);
+bool enum_name_Test_is_equal(const enum_name_Test* a, const enum_name_Test* b);
+int enum_name_Test_to_json_size(const enum_name_Test*);
+void enum_name_Test_to_json(const enum_name_Test*, char* json);
+int enum_name_Test_from_json(const char* json, enum_name_Test*);
+bool enum_name_Test_try_from_json(const char* json, enum_name_Test* v, jsonif_error* error);
+void enum_name_Test_set_color(enum_name_Test* v, enum_name_Color m);
+void enum_name_Test_alloc_colors(enum_name_Test* v, int num);
+void enum_name_Test_set_colors(enum_name_Test* v, int n, enum_name_Color m);
+void enum_name_Test_alloc_color_map(enum_name_Test* v, int num);
+void enum_name_Test_set_number(enum_name_Test* v, enum_name_Number m);
+void enum_name_Test_set_nested(enum_name_Test* v, enum_name_Test_Nested m);
+void enum_name_Test_set_opt_color(enum_name_Test* v, enum_name_Color m);
+void enum_name_Test_set_oneof_color(enum_name_Test* v, enum_name_Color m);
+void enum_name_Test_set_oneof_int(enum_name_Test* v, int32_t m);
+
+bool enum_name_Test_has_opt_color(const enum_name_Test* v);
+void enum_name_Test_clear_opt_color(enum_name_Test* v);
+void enum_name_Test_clear_oneof_color(enum_name_Test* v);
+void enum_name_Test_clear_oneof_int(enum_name_Test* v);
+void enum_name_Test_clear_value_case(enum_name_Test* v);
+void enum_name_Test_clear__opt_color_case(enum_name_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ENUM_NAME_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ENUM_NAME_PROTO
+
+#include "enum_name.json.h"
+#include "enum_name.json.c.h"
+
+
+::enum_name::Test enum_name_Test_to_cpp(const enum_name_Test* v);
+void enum_name_Test_from_cpp(const ::enum_name::Test& u, enum_name_Test* v);
+
+#endif
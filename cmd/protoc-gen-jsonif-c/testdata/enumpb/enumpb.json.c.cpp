@@ -0,0 +1,16 @@
+#include "enumpb.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "enumpb.json.h"
+
+
+// Data
+const enumpb_Data enumpb_FOO = 0;
+const enumpb_Data enumpb_BAR = 1;
+
+extern "C" {
+
+
+}
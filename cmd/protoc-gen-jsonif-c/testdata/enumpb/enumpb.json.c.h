@@ -0,0 +1,23 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ENUMPB_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ENUMPB_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Data
+typedef int enumpb_Data;
+extern const enumpb_Data enumpb_FOO;
+extern const enumpb_Data enumpb_BAR;
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
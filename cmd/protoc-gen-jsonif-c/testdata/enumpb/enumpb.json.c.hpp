@@ -0,0 +1,9 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ENUMPB_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ENUMPB_PROTO
+
+#include "enumpb.json.h"
+#include "enumpb.json.c.h"
+
+
+
+#endif
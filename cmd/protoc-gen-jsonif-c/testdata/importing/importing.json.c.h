@@ -0,0 +1,77 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_IMPORTING_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_IMPORTING_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#include "google/protobuf/timestamp.json.c.h"
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_importing_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_importing_proto();
+}
+
+#endif
+
+// Test
+typedef struct {
+  google_protobuf_Timestamp t;
+} importing_Test;
+
+int importing_Test_size();
+void importing_Test_init(importing_Test* v);
+void importing_Test_destroy(importing_Test*);
+void importing_Test_copy(const importing_Test* a, importing_Test* b);
+bool importing_Test_is_equal(const importing_Test* a, const importing_Test* b);
+int importing_Test_to_json_size(const importing_Test*);
+void importing_Test_to_json(const importing_Test*, char* json);
+int importing_Test_from_json(const char* json, importing_Test*);
+bool importing_Test_try_from_json(const char* json, importing_Test* v, jsonif_error* error);
+void importing_Test_set_t(importing_Test* v, const google_protobuf_Timestamp* m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,12 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_IMPORTING_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_IMPORTING_PROTO
+
+#include "importing.json.h"
+#include "importing.json.c.h"
+
+#include "google/protobuf/timestamp.json.c.hpp"
+
+::importing::Test importing_Test_to_cpp(const importing_Test* v);
+void importing_Test_from_cpp(const ::importing::Test& u, importing_Test* v);
+
+#endif
@@ -0,0 +1,105 @@
+#include "google/protobuf/timestamp.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "google/protobuf/timestamp.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "google/protobuf/timestamp.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> google_protobuf_Timestamp_to_cpp(const google_protobuf_Timestamp* v) {
+  return std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>(std::chrono::seconds(v->seconds) + std::chrono::nanoseconds(v->nanos));
+}
+void google_protobuf_Timestamp_from_cpp(const std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>& u, google_protobuf_Timestamp* v) {
+  google_protobuf_Timestamp_destroy(v);
+  google_protobuf_Timestamp_init(v);
+  auto s = std::chrono::floor<std::chrono::seconds>(u.time_since_epoch());
+  v->seconds = s.count();
+  v->nanos = (int32_t)(u.time_since_epoch() - s).count();
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_google_protobuf_timestamp_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int google_protobuf_Timestamp_size() {
+  return sizeof(google_protobuf_Timestamp);
+}
+void google_protobuf_Timestamp_init(google_protobuf_Timestamp* v) {
+  memset(v, 0, sizeof(google_protobuf_Timestamp));
+}
+void google_protobuf_Timestamp_destroy(google_protobuf_Timestamp* v) {
+  memset(&v->seconds, 0, sizeof(v->seconds));
+  memset(&v->nanos, 0, sizeof(v->nanos));
+}
+void google_protobuf_Timestamp_copy(const google_protobuf_Timestamp* a, google_protobuf_Timestamp* b) {
+  if (a == b) return;
+  std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> u = google_protobuf_Timestamp_to_cpp(a);
+  google_protobuf_Timestamp_from_cpp(u, b);
+}
+bool google_protobuf_Timestamp_is_equal(const google_protobuf_Timestamp* a, const google_protobuf_Timestamp* b) {
+  if (a == b) return true;
+  std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> ua = google_protobuf_Timestamp_to_cpp(a);
+  std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> ub = google_protobuf_Timestamp_to_cpp(b);
+  return ua == ub;
+}
+int google_protobuf_Timestamp_to_json_size(const google_protobuf_Timestamp* v) {
+  std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> u = google_protobuf_Timestamp_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void google_protobuf_Timestamp_to_json(const google_protobuf_Timestamp* v, char* json) {
+  std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> u = google_protobuf_Timestamp_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int google_protobuf_Timestamp_from_json(const char* json, google_protobuf_Timestamp* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return google_protobuf_Timestamp_try_from_json(json, v, error) ? 0 : -1;
+}
+bool google_protobuf_Timestamp_try_from_json(const char* json, google_protobuf_Timestamp* v, jsonif_error* error) {
+  try {
+    std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> u = jsonif::from_json<std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>>(json);
+    google_protobuf_Timestamp_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void google_protobuf_Timestamp_set_seconds(google_protobuf_Timestamp* v, int64_t m) {
+  v->seconds = m;
+}
+void google_protobuf_Timestamp_set_nanos(google_protobuf_Timestamp* v, int32_t m) {
+  v->nanos = m;
+}
+
+}
@@ -0,0 +1,78 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_GOOGLE_PROTOBUF_TIMESTAMP_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_GOOGLE_PROTOBUF_TIMESTAMP_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_google_protobuf_timestamp_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_google_protobuf_timestamp_proto();
+}
+
+#endif
+
+// Timestamp
+typedef struct {
+  int64_t seconds;
+  int32_t nanos;
+} google_protobuf_Timestamp;
+
+int google_protobuf_Timestamp_size();
+void google_protobuf_Timestamp_init(google_protobuf_Timestamp* v);
+void google_protobuf_Timestamp_destroy(google_protobuf_Timestamp*);
+void google_protobuf_Timestamp_copy(const google_protobuf_Timestamp* a, google_protobuf_Timestamp* b);
+bool google_protobuf_Timestamp_is_equal(const google_protobuf_Timestamp* a, const google_protobuf_Timestamp* b);
+int google_protobuf_Timestamp_to_json_size(const google_protobuf_Timestamp*);
+void google_protobuf_Timestamp_to_json(const google_protobuf_Timestamp*, char* json);
+int google_protobuf_Timestamp_from_json(const char* json, google_protobuf_Timestamp*);
+bool google_protobuf_Timestamp_try_from_json(const char* json, google_protobuf_Timestamp* v, jsonif_error* error);
+void google_protobuf_Timestamp_set_seconds(google_protobuf_Timestamp* v, int64_t m);
+void google_protobuf_Timestamp_set_nanos(google_protobuf_Timestamp* v, int32_t m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
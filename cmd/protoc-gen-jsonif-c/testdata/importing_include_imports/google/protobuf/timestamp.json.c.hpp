@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_GOOGLE_PROTOBUF_TIMESTAMP_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_GOOGLE_PROTOBUF_TIMESTAMP_PROTO
+
+#include "google/protobuf/timestamp.json.h"
+#include "google/protobuf/timestamp.json.c.h"
+
+
+std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> google_protobuf_Timestamp_to_cpp(const google_protobuf_Timestamp* v);
+void google_protobuf_Timestamp_from_cpp(const std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>& u, google_protobuf_Timestamp* v);
+
+#endif
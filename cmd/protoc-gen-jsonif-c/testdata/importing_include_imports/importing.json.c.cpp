@@ -0,0 +1,107 @@
+#include "importing.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "importing.json.h"
+
+#include "google/protobuf/timestamp.json.c.hpp"
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "importing.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::importing::Test importing_Test_to_cpp(const importing_Test* v) {
+  ::importing::Test u;
+  u.t = google_protobuf_Timestamp_to_cpp(&v->t);
+  return u;
+}
+void importing_Test_from_cpp(const ::importing::Test& u, importing_Test* v) {
+  importing_Test_destroy(v);
+  memset(v, 0, sizeof(importing_Test));
+  google_protobuf_Timestamp_from_cpp(u.t, &v->t);
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_importing_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int importing_Test_size() {
+  return sizeof(importing_Test);
+}
+void importing_Test_init(importing_Test* v) {
+  memset(v, 0, sizeof(importing_Test));
+}
+void importing_Test_destroy(importing_Test* v) {
+  google_protobuf_Timestamp_destroy(&v->t);
+}
+void importing_Test_copy(const importing_Test* a, importing_Test* b) {
+  if (a == b) return;
+  ::importing::Test u = importing_Test_to_cpp(a);
+  importing_Test_from_cpp(u, b);
+}
+bool importing_Test_is_equal(const importing_Test* a, const importing_Test* b) {
+  if (a == b) return true;
+  ::importing::Test ua = importing_Test_to_cpp(a);
+  ::importing::Test ub = importing_Test_to_cpp(b);
+  return ua == ub;
+}
+int importing_Test_to_json_size(const importing_Test* v) {
+  ::importing::Test u = importing_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void importing_Test_to_json(const importing_Test* v, char* json) {
+  ::importing::Test u = importing_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int importing_Test_from_json(const char* json, importing_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return importing_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool importing_Test_try_from_json(const char* json, importing_Test* v, jsonif_error* error) {
+  try {
+    ::importing::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    importing_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void importing_Test_set_t(importing_Test* v, const google_protobuf_Timestamp* m) {
+  google_protobuf_Timestamp_copy(m, &v->t);
+}
+
+}
@@ -0,0 +1,422 @@
+#include "jsonvalue.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "jsonvalue.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "jsonvalue.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// C++ の JSON の値を jsonif_json に変換する
+static void jsonif_json_from_cpp(const ::jsonif::json_value& u, jsonif_json* v) {
+  v->type = JSONIF_JSON_NULL;
+  v->bool_value = false;
+  v->number_value = 0;
+  v->string_value = NULL;
+  v->values = NULL;
+  v->keys = NULL;
+  v->len = 0;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (u.is_boolean()) {
+    v->type = JSONIF_JSON_BOOL;
+    v->bool_value = u.get<bool>();
+  } else if (u.is_number()) {
+    v->type = JSONIF_JSON_NUMBER;
+    v->number_value = u.get<double>();
+  } else if (u.is_string()) {
+    v->type = JSONIF_JSON_STRING;
+    v->string_value = strdup(u.get_ref<const std::string&>().c_str());
+  } else if (u.is_array() || u.is_object()) {
+    v->type = u.is_array() ? JSONIF_JSON_ARRAY : JSONIF_JSON_OBJECT;
+    v->len = (int)u.size();
+    v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * u.size());
+    if (u.is_object()) v->keys = (char**)malloc(sizeof(char*) * u.size());
+    int i = 0;
+    for (auto it = u.begin(); it != u.end(); ++it, ++i) {
+      if (u.is_object()) v->keys[i] = strdup(it.key().c_str());
+      jsonif_json_from_cpp(*it, &v->values[i]);
+    }
+  }
+  #else
+  if (u.is_bool()) {
+    v->type = JSONIF_JSON_BOOL;
+    v->bool_value = u.get_bool();
+  } else if (u.is_number()) {
+    v->type = JSONIF_JSON_NUMBER;
+    v->number_value = u.to_number<double>();
+  } else if (u.is_string()) {
+    v->type = JSONIF_JSON_STRING;
+    v->string_value = strdup(std::string(u.get_string().data(), u.get_string().size()).c_str());
+  } else if (u.is_array()) {
+    const auto& a = u.get_array();
+    v->type = JSONIF_JSON_ARRAY;
+    v->len = (int)a.size();
+    v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * a.size());
+    for (int i = 0; i < v->len; i++) {
+      jsonif_json_from_cpp(a[i], &v->values[i]);
+    }
+  } else if (u.is_object()) {
+    const auto& o = u.get_object();
+    v->type = JSONIF_JSON_OBJECT;
+    v->len = (int)o.size();
+    v->values = (jsonif_json*)malloc(sizeof(jsonif_json) * o.size());
+    v->keys = (char**)malloc(sizeof(char*) * o.size());
+    int i = 0;
+    for (const auto& kv : o) {
+      v->keys[i] = strdup(std::string(kv.key().data(), kv.key().size()).c_str());
+      jsonif_json_from_cpp(kv.value(), &v->values[i]);
+      i++;
+    }
+  }
+  #endif
+}
+
+// jsonif_json を C++ の JSON の値に変換する
+static ::jsonif::json_value jsonif_json_to_cpp(const jsonif_json* v) {
+  if (v->type == JSONIF_JSON_BOOL) {
+    return ::jsonif::json_value(v->bool_value);
+  } else if (v->type == JSONIF_JSON_NUMBER) {
+    // 整数の値は小数点を付けずに出力する
+    double n = v->number_value;
+    if (n >= -9007199254740992.0 && n <= 9007199254740992.0 && (double)(int64_t)n == n) return ::jsonif::json_value((int64_t)n);
+    return ::jsonif::json_value(n);
+  } else if (v->type == JSONIF_JSON_STRING) {
+    return ::jsonif::json_value(v->string_value == NULL ? "" : v->string_value);
+  } else if (v->type == JSONIF_JSON_ARRAY) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    ::jsonif::json_value a = ::jsonif::json_value::array();
+    #else
+    boost::json::array a;
+    #endif
+    for (int i = 0; i < v->len; i++) {
+      a.push_back(jsonif_json_to_cpp(&v->values[i]));
+    }
+    return a;
+  } else if (v->type == JSONIF_JSON_OBJECT) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    ::jsonif::json_value o = ::jsonif::json_value::object();
+    #else
+    boost::json::object o;
+    #endif
+    for (int i = 0; i < v->len; i++) {
+      o[v->keys[i]] = jsonif_json_to_cpp(&v->values[i]);
+    }
+    return o;
+  }
+  return ::jsonif::json_value(nullptr);
+}
+
+// kind
+const jsonvalue_Test_KindCase jsonvalue_Test_KindCase_NOT_SET = 0;
+const jsonvalue_Test_KindCase jsonvalue_Test_KindCase_kOneofValue = 6;
+const jsonvalue_Test_KindCase jsonvalue_Test_KindCase_kOneofString = 7;
+
+::jsonvalue::Test jsonvalue_Test_to_cpp(const jsonvalue_Test* v) {
+  ::jsonvalue::Test u;
+  if (v->struct_value != nullptr) u.struct_value = jsonif::from_json<::jsonif::json_value>(std::string(v->struct_value, v->struct_value_len));
+  if (v->value != nullptr) u.value = jsonif::from_json<::jsonif::json_value>(std::string(v->value, v->value_len));
+  if (v->list_value != nullptr) u.list_value = jsonif::from_json<::jsonif::json_value>(std::string(v->list_value, v->list_value_len));
+  for (int i = 0; i < v->values_len; i++) {
+    if (v->values[i] != nullptr) {
+      u.values.push_back(jsonif::from_json<::jsonif::json_value>(std::string(v->values[i], v->values_lens[i])));
+    } else {
+      u.values.push_back(::jsonif::json_value());
+    }
+  }
+  for (int i = 0; i < v->structs_len; i++) {
+    decltype(u.structs)::key_type key{};
+    decltype(u.structs)::mapped_type value{};
+    if (v->structs[i].key_len != 0) key = std::string(v->structs[i].key, v->structs[i].key_len);
+    if (v->structs[i].value != nullptr) value = jsonif::from_json<::jsonif::json_value>(std::string(v->structs[i].value, v->structs[i].value_len));
+    u.structs.emplace(std::move(key), std::move(value));
+  }
+  if (v->oneof_value != nullptr) u.oneof_value = jsonif::from_json<::jsonif::json_value>(std::string(v->oneof_value, v->oneof_value_len));
+  if (v->oneof_string_len != 0) u.oneof_string = std::string(v->oneof_string, v->oneof_string_len);
+  u.kind_case = (::jsonvalue::Test::KindCase)v->kind_case;
+  return u;
+}
+void jsonvalue_Test_from_cpp(const ::jsonvalue::Test& u, jsonvalue_Test* v) {
+  jsonvalue_Test_destroy(v);
+  memset(v, 0, sizeof(jsonvalue_Test));
+  if (!u.struct_value.is_null()) {
+    std::string json = jsonif::to_json(u.struct_value);
+    v->struct_value = strdup(json.c_str());
+    v->struct_value_len = (int)json.size();
+  }
+  if (!u.value.is_null()) {
+    std::string json = jsonif::to_json(u.value);
+    v->value = strdup(json.c_str());
+    v->value_len = (int)json.size();
+  }
+  if (!u.list_value.is_null()) {
+    std::string json = jsonif::to_json(u.list_value);
+    v->list_value = strdup(json.c_str());
+    v->list_value_len = (int)json.size();
+  }
+  v->values_len = (int)u.values.size();
+  v->values = v->values_len == 0 ? nullptr : (decltype(v->values))malloc(sizeof(v->values[0]) * u.values.size());
+  v->values_lens = v->values_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.values.size());
+  for (int i = 0; i < (int)u.values.size(); i++) {
+    v->values[i] = nullptr;
+    v->values_lens[i] = 0;
+    if (!u.values[i].is_null()) {
+      std::string json = jsonif::to_json(u.values[i]);
+      v->values[i] = strdup(json.c_str());
+      v->values_lens[i] = (int)json.size();
+    }
+  }
+  v->structs_len = (int)u.structs.size();
+  v->structs = v->structs_len == 0 ? nullptr : (decltype(v->structs))malloc(sizeof(v->structs[0]) * u.structs.size());
+  int structs_index = 0;
+  for (const auto& kv : u.structs) {
+    jsonvalue_Test_StructsEntry_init(&v->structs[structs_index]);
+    if (!kv.first.empty()) v->structs[structs_index].key = strdup(kv.first.c_str());
+    v->structs[structs_index].key_len = (int)kv.first.size();
+    if (!kv.second.is_null()) {
+      std::string json = jsonif::to_json(kv.second);
+      v->structs[structs_index].value = strdup(json.c_str());
+      v->structs[structs_index].value_len = (int)json.size();
+    }
+    structs_index++;
+  }
+  if (!u.oneof_value.is_null()) {
+    std::string json = jsonif::to_json(u.oneof_value);
+    v->oneof_value = strdup(json.c_str());
+    v->oneof_value_len = (int)json.size();
+  }
+  if (!u.oneof_string.empty()) v->oneof_string = strdup(u.oneof_string.c_str());
+  v->oneof_string_len = (int)u.oneof_string.size();
+  v->kind_case = (int)u.kind_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_jsonvalue_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+bool jsonif_json_parse_jsonvalue_proto(const char* json, jsonif_json* v) {
+  try {
+    ::jsonif::json_value u;
+    if (json != nullptr) u = jsonif::from_json<::jsonif::json_value>(std::string(json));
+    jsonif_json_from_cpp(u, v);
+    return true;
+  } catch (...) {
+    jsonif_json_from_cpp(::jsonif::json_value(nullptr), v);
+    return false;
+  }
+}
+char* jsonif_json_to_string_jsonvalue_proto(const jsonif_json* v) {
+  if (v->type == JSONIF_JSON_NULL) return NULL;
+  std::string json = jsonif::to_json(jsonif_json_to_cpp(v));
+  return strdup(json.c_str());
+}
+
+int jsonvalue_Test_StructsEntry_size() {
+  return sizeof(jsonvalue_Test_StructsEntry);
+}
+void jsonvalue_Test_StructsEntry_init(jsonvalue_Test_StructsEntry* v) {
+  memset(v, 0, sizeof(jsonvalue_Test_StructsEntry));
+}
+void jsonvalue_Test_StructsEntry_destroy(jsonvalue_Test_StructsEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = 0;
+}
+void jsonvalue_Test_StructsEntry_set_key(jsonvalue_Test_StructsEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_StructsEntry_set_value(jsonvalue_Test_StructsEntry* v, const char* s) {
+  if (v->value) free(v->value);
+  v->value_len = s == nullptr ? 0 : strlen(s);
+  v->value = v->value_len == 0 ? nullptr : strdup(s);
+}
+int jsonvalue_Test_size() {
+  return sizeof(jsonvalue_Test);
+}
+void jsonvalue_Test_init(jsonvalue_Test* v) {
+  memset(v, 0, sizeof(jsonvalue_Test));
+}
+void jsonvalue_Test_destroy(jsonvalue_Test* v) {
+  if (v->struct_value) free(v->struct_value);
+  v->struct_value = nullptr;
+  v->struct_value_len = 0;
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = 0;
+  if (v->list_value) free(v->list_value);
+  v->list_value = nullptr;
+  v->list_value_len = 0;
+  for (int i = 0; i < v->values_len; i++) {
+    if (v->values[i]) free(v->values[i]);
+    v->values[i] = nullptr;
+    v->values_lens[i] = 0;
+  }
+  if (v->values_lens) free(v->values_lens);
+  v->values_lens = nullptr;
+  if (v->values) free(v->values);
+  v->values = nullptr;
+  v->values_len = 0;
+  for (int i = 0; i < v->structs_len; i++) {
+    jsonvalue_Test_StructsEntry_destroy(&v->structs[i]);
+  }
+  if (v->structs) free(v->structs);
+  v->structs = nullptr;
+  v->structs_len = 0;
+  if (v->oneof_value) free(v->oneof_value);
+  v->oneof_value = nullptr;
+  v->oneof_value_len = 0;
+  if (v->oneof_string) free(v->oneof_string);
+  v->oneof_string = nullptr;
+  v->oneof_string_len = 0;
+}
+void jsonvalue_Test_copy(const jsonvalue_Test* a, jsonvalue_Test* b) {
+  if (a == b) return;
+  ::jsonvalue::Test u = jsonvalue_Test_to_cpp(a);
+  jsonvalue_Test_from_cpp(u, b);
+}
+bool jsonvalue_Test_is_equal(const jsonvalue_Test* a, const jsonvalue_Test* b) {
+  if (a == b) return true;
+  ::jsonvalue::Test ua = jsonvalue_Test_to_cpp(a);
+  ::jsonvalue::Test ub = jsonvalue_Test_to_cpp(b);
+  return ua == ub;
+}
+int jsonvalue_Test_to_json_size(const jsonvalue_Test* v) {
+  ::jsonvalue::Test u = jsonvalue_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void jsonvalue_Test_to_json(const jsonvalue_Test* v, char* json) {
+  ::jsonvalue::Test u = jsonvalue_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int jsonvalue_Test_from_json(const char* json, jsonvalue_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return jsonvalue_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool jsonvalue_Test_try_from_json(const char* json, jsonvalue_Test* v, jsonif_error* error) {
+  try {
+    ::jsonvalue::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    jsonvalue_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void jsonvalue_Test_set_struct_value(jsonvalue_Test* v, const char* s) {
+  if (v->struct_value) free(v->struct_value);
+  v->struct_value_len = s == nullptr ? 0 : strlen(s);
+  v->struct_value = v->struct_value_len == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_set_value(jsonvalue_Test* v, const char* s) {
+  if (v->value) free(v->value);
+  v->value_len = s == nullptr ? 0 : strlen(s);
+  v->value = v->value_len == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_set_list_value(jsonvalue_Test* v, const char* s) {
+  if (v->list_value) free(v->list_value);
+  v->list_value_len = s == nullptr ? 0 : strlen(s);
+  v->list_value = v->list_value_len == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_alloc_values(jsonvalue_Test* v, int num) {
+  if (v->values) free(v->values);
+  v->values = nullptr;
+  v->values_len = 0;
+  if (num != 0) {
+    v->values = (decltype(v->values))malloc(sizeof(v->values[0]) * num);
+    memset(v->values, 0, sizeof(v->values[0]) * num);
+    v->values_len = num;
+    v->values_lens = (decltype(v->values_lens))malloc(sizeof(v->values_lens[0]) * num);
+    memset(v->values_lens, 0, sizeof(v->values_lens[0]) * num);
+  }
+}
+
+void jsonvalue_Test_set_values(jsonvalue_Test* v, int n, const char* s) {
+  if (v->values[n]) free(v->values[n]);
+  v->values_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->values[n] = v->values_lens[n] == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_alloc_structs(jsonvalue_Test* v, int num) {
+  if (v->structs) free(v->structs);
+  v->structs = nullptr;
+  v->structs_len = 0;
+  if (num != 0) {
+    v->structs = (decltype(v->structs))malloc(sizeof(v->structs[0]) * num);
+    memset(v->structs, 0, sizeof(v->structs[0]) * num);
+    v->structs_len = num;
+  }
+}
+
+void jsonvalue_Test_set_oneof_value(jsonvalue_Test* v, const char* s) {
+  jsonvalue_Test_clear_kind_case(v);
+  v->kind_case = jsonvalue_Test_KindCase_kOneofValue;
+  if (v->oneof_value) free(v->oneof_value);
+  v->oneof_value_len = s == nullptr ? 0 : strlen(s);
+  v->oneof_value = v->oneof_value_len == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_set_oneof_string(jsonvalue_Test* v, const char* s) {
+  jsonvalue_Test_clear_kind_case(v);
+  v->kind_case = jsonvalue_Test_KindCase_kOneofString;
+  if (v->oneof_string) free(v->oneof_string);
+  v->oneof_string_len = s == nullptr ? 0 : strlen(s);
+  v->oneof_string = v->oneof_string_len == 0 ? nullptr : strdup(s);
+}
+void jsonvalue_Test_clear_oneof_value(jsonvalue_Test* v) {
+  if (v->kind_case == jsonvalue_Test_KindCase_kOneofValue) {
+    jsonvalue_Test_clear_kind_case(v);
+  }
+}
+void jsonvalue_Test_clear_oneof_string(jsonvalue_Test* v) {
+  if (v->kind_case == jsonvalue_Test_KindCase_kOneofString) {
+    jsonvalue_Test_clear_kind_case(v);
+  }
+}
+void jsonvalue_Test_clear_kind_case(jsonvalue_Test* v) {
+  if (v->oneof_value) free(v->oneof_value);
+  v->oneof_value = nullptr;
+  v->oneof_value_len = 0;
+  if (v->oneof_string) free(v->oneof_string);
+  v->oneof_string = nullptr;
+  v->oneof_string_len = 0;
+  v->kind_case = jsonvalue_Test_KindCase_NOT_SET;
+}
+
+}
@@ -0,0 +1,209 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_JSONVALUE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_JSONVALUE_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifndef JSONIF_C_JSON_DEFINED
+#define JSONIF_C_JSON_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON の値の種類
+typedef enum {
+  JSONIF_JSON_NULL,
+  JSONIF_JSON_BOOL,
+  JSONIF_JSON_NUMBER,
+  JSONIF_JSON_STRING,
+  JSONIF_JSON_ARRAY,
+  JSONIF_JSON_OBJECT,
+} jsonif_json_type;
+
+// Struct, Value, ListValue などの JSON の文字列を読み込んだ値
+typedef struct jsonif_json {
+  jsonif_json_type type;
+  bool bool_value;
+  // 数値は全て double で保持する
+  double number_value;
+  char* string_value;
+  // 配列の要素、またはオブジェクトの値
+  struct jsonif_json* values;
+  // オブジェクトのキー。配列の場合は NULL
+  char** keys;
+  int len;
+} jsonif_json;
+
+// jsonif_json_parse で読み込んだ値を解放する
+static inline void jsonif_json_free(jsonif_json* v) {
+  for (int i = 0; i < v->len; i++) {
+    jsonif_json_free(&v->values[i]);
+    if (v->keys != NULL) free(v->keys[i]);
+  }
+  free(v->string_value);
+  free(v->values);
+  free(v->keys);
+  v->type = JSONIF_JSON_NULL;
+  v->string_value = NULL;
+  v->values = NULL;
+  v->keys = NULL;
+  v->len = 0;
+}
+
+// 配列の i 番目の要素。配列でないか範囲外の場合は NULL を返す
+static inline const jsonif_json* jsonif_json_at(const jsonif_json* v, int i) {
+  if (v->type != JSONIF_JSON_ARRAY || i < 0 || i >= v->len) return NULL;
+  return &v->values[i];
+}
+
+// オブジェクトのキー key の値。オブジェクトでないかキーが無い場合は NULL を返す
+static inline const jsonif_json* jsonif_json_get(const jsonif_json* v, const char* key) {
+  if (v->type != JSONIF_JSON_OBJECT) return NULL;
+  for (int i = 0; i < v->len; i++) {
+    if (strcmp(v->keys[i], key) == 0) return &v->values[i];
+  }
+  return NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_jsonvalue_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_jsonvalue_proto();
+}
+
+#endif
+
+bool jsonif_json_parse_jsonvalue_proto(const char* json, jsonif_json* v);
+char* jsonif_json_to_string_jsonvalue_proto(const jsonif_json* v);
+
+#ifndef JSONIF_C_JSON_FUNCS_DEFINED
+#define JSONIF_C_JSON_FUNCS_DEFINED
+
+// JSON の文字列を読み込む。NULL は JSONIF_JSON_NULL になる
+// 読み込めなかった場合は JSONIF_JSON_NULL にして false を返す。読み込んだ値は jsonif_json_free で解放する
+static inline bool jsonif_json_parse(const char* json, jsonif_json* v) {
+  return jsonif_json_parse_jsonvalue_proto(json, v);
+}
+
+// JSON の文字列にする。JSONIF_JSON_NULL の場合は NULL を返す。返した文字列は free で解放する
+static inline char* jsonif_json_to_string(const jsonif_json* v) {
+  return jsonif_json_to_string_jsonvalue_proto(v);
+}
+
+#endif
+
+// kind
+typedef int jsonvalue_Test_KindCase;
+extern const jsonvalue_Test_KindCase jsonvalue_Test_KindCase_NOT_SET;
+extern const jsonvalue_Test_KindCase jsonvalue_Test_KindCase_kOneofValue;
+extern const jsonvalue_Test_KindCase jsonvalue_Test_KindCase_kOneofString;
+
+// StructsEntry
+typedef struct {
+  char* key;
+  int key_len;
+  char* value;
+  int value_len;
+} jsonvalue_Test_StructsEntry;
+
+int jsonvalue_Test_StructsEntry_size();
+void jsonvalue_Test_StructsEntry_init(jsonvalue_Test_StructsEntry* v);
+void jsonvalue_Test_StructsEntry_destroy(jsonvalue_Test_StructsEntry*);
+void jsonvalue_Test_StructsEntry_set_key(jsonvalue_Test_StructsEntry* v, const char* s);
+void jsonvalue_Test_StructsEntry_set_value(jsonvalue_Test_StructsEntry* v, const char* s);
+
+// Test
+typedef struct {
+  char* struct_value;
+  int struct_value_len;
+  char* value;
+  int value_len;
+  char* list_value;
+  int list_value_len;
+  char** values;
+  int* values_lens;
+  int values_len;
+  jsonvalue_Test_StructsEntry* structs;
+  int structs_len;
+  char* oneof_value;
+  int oneof_value_len;
+  char* oneof_string;
+  int oneof_string_len;
+  jsonvalue_Test_KindCase kind_case;
+} jsonvalue_Test;
+
+int jsonvalue_Test_size();
+void jsonvalue_Test_init(jsonvalue_Test* v);
+void jsonvalue_Test_destroy(jsonvalue_Test*);
+void jsonvalue_Test_copy(const jsonvalue_Test* a, jsonvalue_Test* b);
+bool jsonvalue_Test_is_equal(const jsonvalue_Test* a, const jsonvalue_Test* b);
+int jsonvalue_Test_to_json_size(const jsonvalue_Test*);
+void jsonvalue_Test_to_json(const jsonvalue_Test*, char* json);
+int jsonvalue_Test_from_json(const char* json, jsonvalue_Test*);
+bool jsonvalue_Test_try_from_json(const char* json, jsonvalue_Test* v, jsonif_error* error);
+void jsonvalue_Test_set_struct_value(jsonvalue_Test* v, const char* s);
+void jsonvalue_Test_set_value(jsonvalue_Test* v, const char* s);
+void jsonvalue_Test_set_list_value(jsonvalue_Test* v, const char* s);
+void jsonvalue_Test_alloc_values(jsonvalue_Test* v, int num);
+void jsonvalue_Test_set_values(jsonvalue_Test* v, int n, const char* s);
+void jsonvalue_Test_alloc_structs(jsonvalue_Test* v, int num);
+void jsonvalue_Test_set_oneof_value(jsonvalue_Test* v, const char* s);
+void jsonvalue_Test_set_oneof_string(jsonvalue_Test* v, const char* s);
+
+void jsonvalue_Test_clear_oneof_value(jsonvalue_Test* v);
+void jsonvalue_Test_clear_oneof_string(jsonvalue_Test* v);
+void jsonvalue_Test_clear_kind_case(jsonvalue_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
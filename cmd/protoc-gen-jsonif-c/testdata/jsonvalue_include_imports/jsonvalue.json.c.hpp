@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_JSONVALUE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_JSONVALUE_PROTO
+
+#include "jsonvalue.json.h"
+#include "jsonvalue.json.c.h"
+
+
+::jsonvalue::Test jsonvalue_Test_to_cpp(const jsonvalue_Test* v);
+void jsonvalue_Test_from_cpp(const ::jsonvalue::Test& u, jsonvalue_Test* v);
+
+#endif
@@ -0,0 +1,285 @@
+#include "keywords.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "keywords.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "keywords.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Keyword
+const keywords_Keyword keywords_default = 0;
+const keywords_Keyword keywords_class = 1;
+
+// kind
+const keywords_Test_KindCase keywords_Test_KindCase_NOT_SET = 0;
+const keywords_Test_KindCase keywords_Test_KindCase_kNew = 10;
+const keywords_Test_KindCase keywords_Test_KindCase_kThis = 11;
+
+// _int
+const keywords_Test_IntCase keywords_Test_IntCase_NOT_SET = 0;
+const keywords_Test_IntCase keywords_Test_IntCase_kInt = 12;
+
+::keywords::Test keywords_Test_to_cpp(const keywords_Test* v) {
+  ::keywords::Test u;
+  u.class_ = v->class_;
+  if (v->default__len != 0) u.default_ = std::string(v->default_, v->default__len);
+  u.delete_ = v->delete_;
+  if (v->namespace__len != 0) u.namespace_ = std::string(v->namespace_, v->namespace__len);
+  u.operator_ = v->operator_;
+  if (v->object_len != 0) u.object = std::string(v->object, v->object_len);
+  if (v->string_len != 0) u.string = std::string(v->string, v->string_len);
+  for (int i = 0; i < v->restrict__len; i++) {
+    if (v->restrict__lens[i] != 0) {
+      u.restrict.push_back(std::string(v->restrict_[i], v->restrict__lens[i]));
+    } else {
+      u.restrict.push_back("");
+    }
+  }
+  u.constructor = (decltype(u.constructor))v->constructor;
+  u.new_ = v->new_;
+  if (v->this__len != 0) u.this_ = std::string(v->this_, v->this__len);
+  u.int_ = v->int_;
+  u.kind_case = (::keywords::Test::KindCase)v->kind_case;
+  u._int_case = (::keywords::Test::IntCase)v->_int_case;
+  return u;
+}
+void keywords_Test_from_cpp(const ::keywords::Test& u, keywords_Test* v) {
+  keywords_Test_destroy(v);
+  memset(v, 0, sizeof(keywords_Test));
+  v->class_ = u.class_;
+  if (!u.default_.empty()) v->default_ = strdup(u.default_.c_str());
+  v->default__len = (int)u.default_.size();
+  v->delete_ = u.delete_;
+  if (!u.namespace_.empty()) v->namespace_ = strdup(u.namespace_.c_str());
+  v->namespace__len = (int)u.namespace_.size();
+  v->operator_ = u.operator_;
+  if (!u.object.empty()) v->object = strdup(u.object.c_str());
+  v->object_len = (int)u.object.size();
+  if (!u.string.empty()) v->string = strdup(u.string.c_str());
+  v->string_len = (int)u.string.size();
+  v->restrict__len = (int)u.restrict.size();
+  v->restrict_ = v->restrict__len == 0 ? nullptr : (decltype(v->restrict_))malloc(sizeof(v->restrict_[0]) * u.restrict.size());
+  v->restrict__lens = v->restrict__len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.restrict.size());
+  for (int i = 0; i < (int)u.restrict.size(); i++) {
+    if (!u.restrict[i].empty()) v->restrict_[i] = strdup(u.restrict[i].c_str());
+    v->restrict__lens[i] = (int)u.restrict[i].size();
+  }
+  v->constructor = (int)u.constructor;
+  v->new_ = u.new_;
+  if (!u.this_.empty()) v->this_ = strdup(u.this_.c_str());
+  v->this__len = (int)u.this_.size();
+  v->int_ = u.int_;
+  v->kind_case = (int)u.kind_case;
+  v->_int_case = (int)u._int_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_keywords_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int keywords_Test_size() {
+  return sizeof(keywords_Test);
+}
+void keywords_Test_init(keywords_Test* v) {
+  memset(v, 0, sizeof(keywords_Test));
+}
+void keywords_Test_destroy(keywords_Test* v) {
+  memset(&v->class_, 0, sizeof(v->class_));
+  if (v->default_) free(v->default_);
+  v->default_ = nullptr;
+  v->default__len = 0;
+  memset(&v->delete_, 0, sizeof(v->delete_));
+  if (v->namespace_) free(v->namespace_);
+  v->namespace_ = nullptr;
+  v->namespace__len = 0;
+  memset(&v->operator_, 0, sizeof(v->operator_));
+  if (v->object) free(v->object);
+  v->object = nullptr;
+  v->object_len = 0;
+  if (v->string) free(v->string);
+  v->string = nullptr;
+  v->string_len = 0;
+  for (int i = 0; i < v->restrict__len; i++) {
+    if (v->restrict_[i]) free(v->restrict_[i]);
+    v->restrict_[i] = nullptr;
+    v->restrict__lens[i] = 0;
+  }
+  if (v->restrict__lens) free(v->restrict__lens);
+  v->restrict__lens = nullptr;
+  if (v->restrict_) free(v->restrict_);
+  v->restrict_ = nullptr;
+  v->restrict__len = 0;
+  memset(&v->constructor, 0, sizeof(v->constructor));
+  memset(&v->new_, 0, sizeof(v->new_));
+  if (v->this_) free(v->this_);
+  v->this_ = nullptr;
+  v->this__len = 0;
+  memset(&v->int_, 0, sizeof(v->int_));
+}
+void keywords_Test_copy(const keywords_Test* a, keywords_Test* b) {
+  if (a == b) return;
+  ::keywords::Test u = keywords_Test_to_cpp(a);
+  keywords_Test_from_cpp(u, b);
+}
+bool keywords_Test_is_equal(const keywords_Test* a, const keywords_Test* b) {
+  if (a == b) return true;
+  ::keywords::Test ua = keywords_Test_to_cpp(a);
+  ::keywords::Test ub = keywords_Test_to_cpp(b);
+  return ua == ub;
+}
+int keywords_Test_to_json_size(const keywords_Test* v) {
+  ::keywords::Test u = keywords_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void keywords_Test_to_json(const keywords_Test* v, char* json) {
+  ::keywords::Test u = keywords_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int keywords_Test_from_json(const char* json, keywords_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return keywords_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool keywords_Test_try_from_json(const char* json, keywords_Test* v, jsonif_error* error) {
+  try {
+    ::keywords::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    keywords_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void keywords_Test_set_class(keywords_Test* v, int32_t m) {
+  v->class_ = m;
+}
+void keywords_Test_set_default(keywords_Test* v, const char* s) {
+  if (v->default_) free(v->default_);
+  v->default__len = s == nullptr ? 0 : strlen(s);
+  v->default_ = v->default__len == 0 ? nullptr : strdup(s);
+}
+void keywords_Test_set_delete(keywords_Test* v, bool m) {
+  v->delete_ = m;
+}
+void keywords_Test_set_namespace(keywords_Test* v, const char* s) {
+  if (v->namespace_) free(v->namespace_);
+  v->namespace__len = s == nullptr ? 0 : strlen(s);
+  v->namespace_ = v->namespace__len == 0 ? nullptr : strdup(s);
+}
+void keywords_Test_set_operator(keywords_Test* v, int32_t m) {
+  v->operator_ = m;
+}
+void keywords_Test_set_object(keywords_Test* v, const char* s) {
+  if (v->object) free(v->object);
+  v->object_len = s == nullptr ? 0 : strlen(s);
+  v->object = v->object_len == 0 ? nullptr : strdup(s);
+}
+void keywords_Test_set_string(keywords_Test* v, const char* s) {
+  if (v->string) free(v->string);
+  v->string_len = s == nullptr ? 0 : strlen(s);
+  v->string = v->string_len == 0 ? nullptr : strdup(s);
+}
+void keywords_Test_alloc_restrict(keywords_Test* v, int num) {
+  if (v->restrict_) free(v->restrict_);
+  v->restrict_ = nullptr;
+  v->restrict__len = 0;
+  if (num != 0) {
+    v->restrict_ = (decltype(v->restrict_))malloc(sizeof(v->restrict_[0]) * num);
+    memset(v->restrict_, 0, sizeof(v->restrict_[0]) * num);
+    v->restrict__len = num;
+    v->restrict__lens = (decltype(v->restrict__lens))malloc(sizeof(v->restrict__lens[0]) * num);
+    memset(v->restrict__lens, 0, sizeof(v->restrict__lens[0]) * num);
+  }
+}
+
+void keywords_Test_set_restrict(keywords_Test* v, int n, const char* s) {
+  if (v->restrict_[n]) free(v->restrict_[n]);
+  v->restrict__lens[n] = s == nullptr ? 0 : strlen(s);
+  v->restrict_[n] = v->restrict__lens[n] == 0 ? nullptr : strdup(s);
+}
+void keywords_Test_set_constructor(keywords_Test* v, keywords_Keyword m) {
+  v->constructor = m;
+}
+void keywords_Test_set_new(keywords_Test* v, int32_t m) {
+  keywords_Test_clear_kind_case(v);
+  v->kind_case = keywords_Test_KindCase_kNew;
+  v->new_ = m;
+}
+void keywords_Test_set_this(keywords_Test* v, const char* s) {
+  keywords_Test_clear_kind_case(v);
+  v->kind_case = keywords_Test_KindCase_kThis;
+  if (v->this_) free(v->this_);
+  v->this__len = s == nullptr ? 0 : strlen(s);
+  v->this_ = v->this__len == 0 ? nullptr : strdup(s);
+}
+void keywords_Test_set_int(keywords_Test* v, int32_t m) {
+  keywords_Test_clear__int_case(v);
+  v->_int_case = keywords_Test_IntCase_kInt;
+  v->int_ = m;
+}
+void keywords_Test_clear_new(keywords_Test* v) {
+  if (v->kind_case == keywords_Test_KindCase_kNew) {
+    keywords_Test_clear_kind_case(v);
+  }
+}
+void keywords_Test_clear_this(keywords_Test* v) {
+  if (v->kind_case == keywords_Test_KindCase_kThis) {
+    keywords_Test_clear_kind_case(v);
+  }
+}
+bool keywords_Test_has_int(const keywords_Test* v) {
+  return v->_int_case == keywords_Test_IntCase_kInt;
+}
+void keywords_Test_clear_int(keywords_Test* v) {
+  if (v->_int_case == keywords_Test_IntCase_kInt) {
+    keywords_Test_clear__int_case(v);
+  }
+}
+void keywords_Test_clear_kind_case(keywords_Test* v) {
+  memset(&v->new_, 0, sizeof(v->new_));
+  if (v->this_) free(v->this_);
+  v->this_ = nullptr;
+  v->this__len = 0;
+  v->kind_case = keywords_Test_KindCase_NOT_SET;
+}
+void keywords_Test_clear__int_case(keywords_Test* v) {
+  memset(&v->int_, 0, sizeof(v->int_));
+  v->_int_case = keywords_Test_IntCase_NOT_SET;
+}
+
+}
@@ -0,0 +1,131 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_KEYWORDS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_KEYWORDS_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_keywords_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_keywords_proto();
+}
+
+#endif
+
+// Keyword
+/// 各言語の予約語と同じ名前を使っても、コンパイルできるコードが生成されるか確認する用
+typedef int keywords_Keyword;
+extern const keywords_Keyword keywords_default;
+extern const keywords_Keyword keywords_class;
+
+// kind
+typedef int keywords_Test_KindCase;
+extern const keywords_Test_KindCase keywords_Test_KindCase_NOT_SET;
+extern const keywords_Test_KindCase keywords_Test_KindCase_kNew;
+extern const keywords_Test_KindCase keywords_Test_KindCase_kThis;
+
+// _int
+typedef int keywords_Test_IntCase;
+extern const keywords_Test_IntCase keywords_Test_IntCase_NOT_SET;
+extern const keywords_Test_IntCase keywords_Test_IntCase_kInt;
+
+// Test
+typedef struct {
+  int32_t class_;
+  char* default_;
+  int default__len;
+  bool delete_;
+  char* namespace_;
+  int namespace__len;
+  int32_t operator_;
+  char* object;
+  int object_len;
+  char* string;
+  int string_len;
+  char** restrict_;
+  int* restrict__lens;
+  int restrict__len;
+  keywords_Keyword constructor;
+  int32_t new_;
+  char* this_;
+  int this__len;
+  int32_t int_;
+  keywords_Test_KindCase kind_case;
+  keywords_Test_IntCase _int_case;
+} keywords_Test;
+
+int keywords_Test_size();
+void keywords_Test_init(keywords_Test* v);
+void keywords_Test_destroy(keywords_Test*);
+void keywords_Test_copy(const keywords_Test* a, keywords_Test* b);
+bool keywords_Test_is_equal(const keywords_Test* a, const keywords_Test* b);
+int keywords_Test_to_json_size(const keywords_Test*);
+void keywords_Test_to_json(const keywords_Test*, char* json);
+int keywords_Test_from_json(const char* json, keywords_Test*);
+bool keywords_Test_try_from_json(const char* json, keywords_Test* v, jsonif_error* error);
+void keywords_Test_set_class(keywords_Test* v, int32_t m);
+void keywords_Test_set_default(keywords_Test* v, const char* s);
+void keywords_Test_set_delete(keywords_Test* v, bool m);
+void keywords_Test_set_namespace(keywords_Test* v, const char* s);
+void keywords_Test_set_operator(keywords_Test* v, int32_t m);
+void keywords_Test_set_object(keywords_Test* v, const char* s);
+void keywords_Test_set_string(keywords_Test* v, const char* s);
+void keywords_Test_alloc_restrict(keywords_Test* v, int num);
+void keywords_Test_set_restrict(keywords_Test* v, int n, const char* s);
+void keywords_Test_set_constructor(keywords_Test* v, keywords_Keyword m);
+void keywords_Test_set_new(keywords_Test* v, int32_t m);
+void keywords_Test_set_this(keywords_Test* v, const char* s);
+void keywords_Test_set_int(keywords_Test* v, int32_t m);
+
+void keywords_Test_clear_new(keywords_Test* v);
+void keywords_Test_clear_this(keywords_Test* v);
+bool keywords_Test_has_int(const keywords_Test* v);
+void keywords_Test_clear_int(keywords_Test* v);
+void keywords_Test_clear_kind_case(keywords_Test* v);
+void keywords_Test_clear__int_case(keywords_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_KEYWORDS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_KEYWORDS_PROTO
+
+#include "keywords.json.h"
+#include "keywords.json.c.h"
+
+
+::keywords::Test keywords_Test_to_cpp(const keywords_Test* v);
+void keywords_Test_from_cpp(const ::keywords::Test& u, keywords_Test* v);
+
+#endif
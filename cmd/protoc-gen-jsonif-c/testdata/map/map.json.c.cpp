@@ -0,0 +1,484 @@
+#include "map.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "map.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "map.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Enum
+const mappb_Enum mappb_FOO = 0;
+const mappb_Enum mappb_BAR = 1;
+
+::mappb::Message mappb_Message_to_cpp(const mappb_Message* v) {
+  ::mappb::Message u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  return u;
+}
+void mappb_Message_from_cpp(const ::mappb::Message& u, mappb_Message* v) {
+  mappb_Message_destroy(v);
+  memset(v, 0, sizeof(mappb_Message));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+}
+::mappb::Test mappb_Test_to_cpp(const mappb_Test* v) {
+  ::mappb::Test u;
+  for (int i = 0; i < v->a_len; i++) {
+    decltype(u.a)::key_type key{};
+    decltype(u.a)::mapped_type value{};
+    if (v->a[i].key_len != 0) key = std::string(v->a[i].key, v->a[i].key_len);
+    value = v->a[i].value;
+    u.a.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->b_len; i++) {
+    decltype(u.b)::key_type key{};
+    decltype(u.b)::mapped_type value{};
+    key = v->b[i].key;
+    if (v->b[i].value_len != 0) value = std::string(v->b[i].value, v->b[i].value_len);
+    u.b.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->c_len; i++) {
+    decltype(u.c)::key_type key{};
+    decltype(u.c)::mapped_type value{};
+    key = v->c[i].key;
+    value = (decltype(value))v->c[i].value;
+    u.c.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->d_len; i++) {
+    decltype(u.d)::key_type key{};
+    decltype(u.d)::mapped_type value{};
+    if (v->d[i].key_len != 0) key = std::string(v->d[i].key, v->d[i].key_len);
+    value = mappb_Message_to_cpp(&v->d[i].value);
+    u.d.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->e_len; i++) {
+    decltype(u.e)::key_type key{};
+    decltype(u.e)::mapped_type value{};
+    key = v->e[i].key;
+    value = v->e[i].value;
+    u.e.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->f_len; i++) {
+    decltype(u.f)::key_type key{};
+    decltype(u.f)::mapped_type value{};
+    key = v->f[i].key;
+    value = v->f[i].value;
+    u.f.emplace(std::move(key), std::move(value));
+  }
+  return u;
+}
+void mappb_Test_from_cpp(const ::mappb::Test& u, mappb_Test* v) {
+  mappb_Test_destroy(v);
+  memset(v, 0, sizeof(mappb_Test));
+  v->a_len = (int)u.a.size();
+  v->a = v->a_len == 0 ? nullptr : (decltype(v->a))malloc(sizeof(v->a[0]) * u.a.size());
+  int a_index = 0;
+  for (const auto& kv : u.a) {
+    mappb_Test_AEntry_init(&v->a[a_index]);
+    if (!kv.first.empty()) v->a[a_index].key = strdup(kv.first.c_str());
+    v->a[a_index].key_len = (int)kv.first.size();
+    v->a[a_index].value = kv.second;
+    a_index++;
+  }
+  v->b_len = (int)u.b.size();
+  v->b = v->b_len == 0 ? nullptr : (decltype(v->b))malloc(sizeof(v->b[0]) * u.b.size());
+  int b_index = 0;
+  for (const auto& kv : u.b) {
+    mappb_Test_BEntry_init(&v->b[b_index]);
+    v->b[b_index].key = kv.first;
+    if (!kv.second.empty()) v->b[b_index].value = strdup(kv.second.c_str());
+    v->b[b_index].value_len = (int)kv.second.size();
+    b_index++;
+  }
+  v->c_len = (int)u.c.size();
+  v->c = v->c_len == 0 ? nullptr : (decltype(v->c))malloc(sizeof(v->c[0]) * u.c.size());
+  int c_index = 0;
+  for (const auto& kv : u.c) {
+    mappb_Test_CEntry_init(&v->c[c_index]);
+    v->c[c_index].key = kv.first;
+    v->c[c_index].value = (int)kv.second;
+    c_index++;
+  }
+  v->d_len = (int)u.d.size();
+  v->d = v->d_len == 0 ? nullptr : (decltype(v->d))malloc(sizeof(v->d[0]) * u.d.size());
+  int d_index = 0;
+  for (const auto& kv : u.d) {
+    mappb_Test_DEntry_init(&v->d[d_index]);
+    if (!kv.first.empty()) v->d[d_index].key = strdup(kv.first.c_str());
+    v->d[d_index].key_len = (int)kv.first.size();
+    mappb_Message_from_cpp(kv.second, &v->d[d_index].value);
+    d_index++;
+  }
+  v->e_len = (int)u.e.size();
+  v->e = v->e_len == 0 ? nullptr : (decltype(v->e))malloc(sizeof(v->e[0]) * u.e.size());
+  int e_index = 0;
+  for (const auto& kv : u.e) {
+    mappb_Test_EEntry_init(&v->e[e_index]);
+    v->e[e_index].key = kv.first;
+    v->e[e_index].value = kv.second;
+    e_index++;
+  }
+  v->f_len = (int)u.f.size();
+  v->f = v->f_len == 0 ? nullptr : (decltype(v->f))malloc(sizeof(v->f[0]) * u.f.size());
+  int f_index = 0;
+  for (const auto& kv : u.f) {
+    mappb_Test_FEntry_init(&v->f[f_index]);
+    v->f[f_index].key = kv.first;
+    v->f[f_index].value = kv.second;
+    f_index++;
+  }
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_map_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int mappb_Message_size() {
+  return sizeof(mappb_Message);
+}
+void mappb_Message_init(mappb_Message* v) {
+  memset(v, 0, sizeof(mappb_Message));
+}
+void mappb_Message_destroy(mappb_Message* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+}
+void mappb_Message_copy(const mappb_Message* a, mappb_Message* b) {
+  if (a == b) return;
+  ::mappb::Message u = mappb_Message_to_cpp(a);
+  mappb_Message_from_cpp(u, b);
+}
+bool mappb_Message_is_equal(const mappb_Message* a, const mappb_Message* b) {
+  if (a == b) return true;
+  ::mappb::Message ua = mappb_Message_to_cpp(a);
+  ::mappb::Message ub = mappb_Message_to_cpp(b);
+  return ua == ub;
+}
+int mappb_Message_to_json_size(const mappb_Message* v) {
+  ::mappb::Message u = mappb_Message_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void mappb_Message_to_json(const mappb_Message* v, char* json) {
+  ::mappb::Message u = mappb_Message_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int mappb_Message_from_json(const char* json, mappb_Message* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return mappb_Message_try_from_json(json, v, error) ? 0 : -1;
+}
+bool mappb_Message_try_from_json(const char* json, mappb_Message* v, jsonif_error* error) {
+  try {
+    ::mappb::Message u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    mappb_Message_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void mappb_Message_set_name(mappb_Message* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+int mappb_Test_AEntry_size() {
+  return sizeof(mappb_Test_AEntry);
+}
+void mappb_Test_AEntry_init(mappb_Test_AEntry* v) {
+  memset(v, 0, sizeof(mappb_Test_AEntry));
+}
+void mappb_Test_AEntry_destroy(mappb_Test_AEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  memset(&v->value, 0, sizeof(v->value));
+}
+void mappb_Test_AEntry_set_key(mappb_Test_AEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void mappb_Test_AEntry_set_value(mappb_Test_AEntry* v, int32_t m) {
+  v->value = m;
+}
+int mappb_Test_BEntry_size() {
+  return sizeof(mappb_Test_BEntry);
+}
+void mappb_Test_BEntry_init(mappb_Test_BEntry* v) {
+  memset(v, 0, sizeof(mappb_Test_BEntry));
+}
+void mappb_Test_BEntry_destroy(mappb_Test_BEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = 0;
+}
+void mappb_Test_BEntry_set_key(mappb_Test_BEntry* v, int32_t m) {
+  v->key = m;
+}
+void mappb_Test_BEntry_set_value(mappb_Test_BEntry* v, const char* s) {
+  if (v->value) free(v->value);
+  v->value_len = s == nullptr ? 0 : strlen(s);
+  v->value = v->value_len == 0 ? nullptr : strdup(s);
+}
+int mappb_Test_CEntry_size() {
+  return sizeof(mappb_Test_CEntry);
+}
+void mappb_Test_CEntry_init(mappb_Test_CEntry* v) {
+  memset(v, 0, sizeof(mappb_Test_CEntry));
+}
+void mappb_Test_CEntry_destroy(mappb_Test_CEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  memset(&v->value, 0, sizeof(v->value));
+}
+void mappb_Test_CEntry_set_key(mappb_Test_CEntry* v, int64_t m) {
+  v->key = m;
+}
+void mappb_Test_CEntry_set_value(mappb_Test_CEntry* v, mappb_Enum m) {
+  v->value = m;
+}
+int mappb_Test_DEntry_size() {
+  return sizeof(mappb_Test_DEntry);
+}
+void mappb_Test_DEntry_init(mappb_Test_DEntry* v) {
+  memset(v, 0, sizeof(mappb_Test_DEntry));
+}
+void mappb_Test_DEntry_destroy(mappb_Test_DEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  mappb_Message_destroy(&v->value);
+}
+void mappb_Test_DEntry_set_key(mappb_Test_DEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void mappb_Test_DEntry_set_value(mappb_Test_DEntry* v, const mappb_Message* m) {
+  mappb_Message_copy(m, &v->value);
+}
+int mappb_Test_EEntry_size() {
+  return sizeof(mappb_Test_EEntry);
+}
+void mappb_Test_EEntry_init(mappb_Test_EEntry* v) {
+  memset(v, 0, sizeof(mappb_Test_EEntry));
+}
+void mappb_Test_EEntry_destroy(mappb_Test_EEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  memset(&v->value, 0, sizeof(v->value));
+}
+void mappb_Test_EEntry_set_key(mappb_Test_EEntry* v, bool m) {
+  v->key = m;
+}
+void mappb_Test_EEntry_set_value(mappb_Test_EEntry* v, double m) {
+  v->value = m;
+}
+int mappb_Test_FEntry_size() {
+  return sizeof(mappb_Test_FEntry);
+}
+void mappb_Test_FEntry_init(mappb_Test_FEntry* v) {
+  memset(v, 0, sizeof(mappb_Test_FEntry));
+}
+void mappb_Test_FEntry_destroy(mappb_Test_FEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  memset(&v->value, 0, sizeof(v->value));
+}
+void mappb_Test_FEntry_set_key(mappb_Test_FEntry* v, uint64_t m) {
+  v->key = m;
+}
+void mappb_Test_FEntry_set_value(mappb_Test_FEntry* v, bool m) {
+  v->value = m;
+}
+int mappb_Test_size() {
+  return sizeof(mappb_Test);
+}
+void mappb_Test_init(mappb_Test* v) {
+  memset(v, 0, sizeof(mappb_Test));
+}
+void mappb_Test_destroy(mappb_Test* v) {
+  for (int i = 0; i < v->a_len; i++) {
+    mappb_Test_AEntry_destroy(&v->a[i]);
+  }
+  if (v->a) free(v->a);
+  v->a = nullptr;
+  v->a_len = 0;
+  for (int i = 0; i < v->b_len; i++) {
+    mappb_Test_BEntry_destroy(&v->b[i]);
+  }
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  for (int i = 0; i < v->c_len; i++) {
+    mappb_Test_CEntry_destroy(&v->c[i]);
+  }
+  if (v->c) free(v->c);
+  v->c = nullptr;
+  v->c_len = 0;
+  for (int i = 0; i < v->d_len; i++) {
+    mappb_Test_DEntry_destroy(&v->d[i]);
+  }
+  if (v->d) free(v->d);
+  v->d = nullptr;
+  v->d_len = 0;
+  for (int i = 0; i < v->e_len; i++) {
+    mappb_Test_EEntry_destroy(&v->e[i]);
+  }
+  if (v->e) free(v->e);
+  v->e = nullptr;
+  v->e_len = 0;
+  for (int i = 0; i < v->f_len; i++) {
+    mappb_Test_FEntry_destroy(&v->f[i]);
+  }
+  if (v->f) free(v->f);
+  v->f = nullptr;
+  v->f_len = 0;
+}
+void mappb_Test_copy(const mappb_Test* a, mappb_Test* b) {
+  if (a == b) return;
+  ::mappb::Test u = mappb_Test_to_cpp(a);
+  mappb_Test_from_cpp(u, b);
+}
+bool mappb_Test_is_equal(const mappb_Test* a, const mappb_Test* b) {
+  if (a == b) return true;
+  ::mappb::Test ua = mappb_Test_to_cpp(a);
+  ::mappb::Test ub = mappb_Test_to_cpp(b);
+  return ua == ub;
+}
+int mappb_Test_to_json_size(const mappb_Test* v) {
+  ::mappb::Test u = mappb_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void mappb_Test_to_json(const mappb_Test* v, char* json) {
+  ::mappb::Test u = mappb_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int mappb_Test_from_json(const char* json, mappb_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return mappb_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool mappb_Test_try_from_json(const char* json, mappb_Test* v, jsonif_error* error) {
+  try {
+    ::mappb::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    mappb_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void mappb_Test_alloc_a(mappb_Test* v, int num) {
+  if (v->a) free(v->a);
+  v->a = nullptr;
+  v->a_len = 0;
+  if (num != 0) {
+    v->a = (decltype(v->a))malloc(sizeof(v->a[0]) * num);
+    memset(v->a, 0, sizeof(v->a[0]) * num);
+    v->a_len = num;
+  }
+}
+
+void mappb_Test_alloc_b(mappb_Test* v, int num) {
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  if (num != 0) {
+    v->b = (decltype(v->b))malloc(sizeof(v->b[0]) * num);
+    memset(v->b, 0, sizeof(v->b[0]) * num);
+    v->b_len = num;
+  }
+}
+
+void mappb_Test_alloc_c(mappb_Test* v, int num) {
+  if (v->c) free(v->c);
+  v->c = nullptr;
+  v->c_len = 0;
+  if (num != 0) {
+    v->c = (decltype(v->c))malloc(sizeof(v->c[0]) * num);
+    memset(v->c, 0, sizeof(v->c[0]) * num);
+    v->c_len = num;
+  }
+}
+
+void mappb_Test_alloc_d(mappb_Test* v, int num) {
+  if (v->d) free(v->d);
+  v->d = nullptr;
+  v->d_len = 0;
+  if (num != 0) {
+    v->d = (decltype(v->d))malloc(sizeof(v->d[0]) * num);
+    memset(v->d, 0, sizeof(v->d[0]) * num);
+    v->d_len = num;
+  }
+}
+
+void mappb_Test_alloc_e(mappb_Test* v, int num) {
+  if (v->e) free(v->e);
+  v->e = nullptr;
+  v->e_len = 0;
+  if (num != 0) {
+    v->e = (decltype(v->e))malloc(sizeof(v->e[0]) * num);
+    memset(v->e, 0, sizeof(v->e[0]) * num);
+    v->e_len = num;
+  }
+}
+
+void mappb_Test_alloc_f(mappb_Test* v, int num) {
+  if (v->f) free(v->f);
+  v->f = nullptr;
+  v->f_len = 0;
+  if (num != 0) {
+    v->f = (decltype(v->f))malloc(sizeof(v->f[0]) * num);
+    memset(v->f, 0, sizeof(v->f[0]) * num);
+    v->f_len = num;
+  }
+}
+
+
+}
@@ -0,0 +1,189 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_MAP_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_MAP_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_map_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_map_proto();
+}
+
+#endif
+
+// Enum
+typedef int mappb_Enum;
+extern const mappb_Enum mappb_FOO;
+extern const mappb_Enum mappb_BAR;
+
+// Message
+typedef struct {
+  char* name;
+  int name_len;
+} mappb_Message;
+
+int mappb_Message_size();
+void mappb_Message_init(mappb_Message* v);
+void mappb_Message_destroy(mappb_Message*);
+void mappb_Message_copy(const mappb_Message* a, mappb_Message* b);
+bool mappb_Message_is_equal(const mappb_Message* a, const mappb_Message* b);
+int mappb_Message_to_json_size(const mappb_Message*);
+void mappb_Message_to_json(const mappb_Message*, char* json);
+int mappb_Message_from_json(const char* json, mappb_Message*);
+bool mappb_Message_try_from_json(const char* json, mappb_Message* v, jsonif_error* error);
+void mappb_Message_set_name(mappb_Message* v, const char* s);
+
+// AEntry
+typedef struct {
+  char* key;
+  int key_len;
+  int32_t value;
+} mappb_Test_AEntry;
+
+int mappb_Test_AEntry_size();
+void mappb_Test_AEntry_init(mappb_Test_AEntry* v);
+void mappb_Test_AEntry_destroy(mappb_Test_AEntry*);
+void mappb_Test_AEntry_set_key(mappb_Test_AEntry* v, const char* s);
+void mappb_Test_AEntry_set_value(mappb_Test_AEntry* v, int32_t m);
+
+// BEntry
+typedef struct {
+  int32_t key;
+  char* value;
+  int value_len;
+} mappb_Test_BEntry;
+
+int mappb_Test_BEntry_size();
+void mappb_Test_BEntry_init(mappb_Test_BEntry* v);
+void mappb_Test_BEntry_destroy(mappb_Test_BEntry*);
+void mappb_Test_BEntry_set_key(mappb_Test_BEntry* v, int32_t m);
+void mappb_Test_BEntry_set_value(mappb_Test_BEntry* v, const char* s);
+
+// CEntry
+typedef struct {
+  int64_t key;
+  mappb_Enum value;
+} mappb_Test_CEntry;
+
+int mappb_Test_CEntry_size();
+void mappb_Test_CEntry_init(mappb_Test_CEntry* v);
+void mappb_Test_CEntry_destroy(mappb_Test_CEntry*);
+void mappb_Test_CEntry_set_key(mappb_Test_CEntry* v, int64_t m);
+void mappb_Test_CEntry_set_value(mappb_Test_CEntry* v, mappb_Enum m);
+
+// DEntry
+typedef struct {
+  char* key;
+  int key_len;
+  mappb_Message value;
+} mappb_Test_DEntry;
+
+int mappb_Test_DEntry_size();
+void mappb_Test_DEntry_init(mappb_Test_DEntry* v);
+void mappb_Test_DEntry_destroy(mappb_Test_DEntry*);
+void mappb_Test_DEntry_set_key(mappb_Test_DEntry* v, const char* s);
+void mappb_Test_DEntry_set_value(mappb_Test_DEntry* v, const mappb_Message* m);
+
+// EEntry
+typedef struct {
+  bool key;
+  double value;
+} mappb_Test_EEntry;
+
+int mappb_Test_EEntry_size();
+void mappb_Test_EEntry_init(mappb_Test_EEntry* v);
+void mappb_Test_EEntry_destroy(mappb_Test_EEntry*);
+void mappb_Test_EEntry_set_key(mappb_Test_EEntry* v, bool m);
+void mappb_Test_EEntry_set_value(mappb_Test_EEntry* v, double m);
+
+// FEntry
+typedef struct {
+  uint64_t key;
+  bool value;
+} mappb_Test_FEntry;
+
+int mappb_Test_FEntry_size();
+void mappb_Test_FEntry_init(mappb_Test_FEntry* v);
+void mappb_Test_FEntry_destroy(mappb_Test_FEntry*);
+void mappb_Test_FEntry_set_key(mappb_Test_FEntry* v, uint64_t m);
+void mappb_Test_FEntry_set_value(mappb_Test_FEntry* v, bool m);
+
+// Test
+typedef struct {
+  mappb_Test_AEntry* a;
+  int a_len;
+  mappb_Test_BEntry* b;
+  int b_len;
+  mappb_Test_CEntry* c;
+  int c_len;
+  mappb_Test_DEntry* d;
+  int d_len;
+  mappb_Test_EEntry* e;
+  int e_len;
+  mappb_Test_FEntry* f;
+  int f_len;
+} mappb_Test;
+
+int mappb_Test_size();
+void mappb_Test_init(mappb_Test* v);
+void mappb_Test_destroy(mappb_Test*);
+void mappb_Test_copy(const mappb_Test* a, mappb_Test* b);
+bool mappb_Test_is_equal(const mappb_Test* a, const mappb_Test* b);
+int mappb_Test_to_json_size(const mappb_Test*);
+void mappb_Test_to_json(const mappb_Test*, char* json);
+int mappb_Test_from_json(const char* json, mappb_Test*);
+bool mappb_Test_try_from_json(const char* json, mappb_Test* v, jsonif_error* error);
+void mappb_Test_alloc_a(mappb_Test* v, int num);
+void mappb_Test_alloc_b(mappb_Test* v, int num);
+void mappb_Test_alloc_c(mappb_Test* v, int num);
+void mappb_Test_alloc_d(mappb_Test* v, int num);
+void mappb_Test_alloc_e(mappb_Test* v, int num);
+void mappb_Test_alloc_f(mappb_Test* v, int num);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
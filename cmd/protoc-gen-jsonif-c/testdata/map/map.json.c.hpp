@@ -0,0 +1,13 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_MAP_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_MAP_PROTO
+
+#include "map.json.h"
+#include "map.json.c.h"
+
+
+::mappb::Message mappb_Message_to_cpp(const mappb_Message* v);
+void mappb_Message_from_cpp(const ::mappb::Message& u, mappb_Message* v);
+::mappb::Test mappb_Test_to_cpp(const mappb_Test* v);
+void mappb_Test_from_cpp(const ::mappb::Test& u, mappb_Test* v);
+
+#endif
@@ -0,0 +1,117 @@
+#include "message.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "message.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "message.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::message::Person message_Person_to_cpp(const message_Person* v) {
+  ::message::Person u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.flag = v->flag;
+  return u;
+}
+void message_Person_from_cpp(const ::message::Person& u, message_Person* v) {
+  message_Person_destroy(v);
+  memset(v, 0, sizeof(message_Person));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  v->flag = u.flag;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_message_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int message_Person_size() {
+  return sizeof(message_Person);
+}
+void message_Person_init(message_Person* v) {
+  memset(v, 0, sizeof(message_Person));
+}
+void message_Person_destroy(message_Person* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->flag, 0, sizeof(v->flag));
+}
+void message_Person_copy(const message_Person* a, message_Person* b) {
+  if (a == b) return;
+  ::message::Person u = message_Person_to_cpp(a);
+  message_Person_from_cpp(u, b);
+}
+bool message_Person_is_equal(const message_Person* a, const message_Person* b) {
+  if (a == b) return true;
+  ::message::Person ua = message_Person_to_cpp(a);
+  ::message::Person ub = message_Person_to_cpp(b);
+  return ua == ub;
+}
+int message_Person_to_json_size(const message_Person* v) {
+  ::message::Person u = message_Person_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void message_Person_to_json(const message_Person* v, char* json) {
+  ::message::Person u = message_Person_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int message_Person_from_json(const char* json, message_Person* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return message_Person_try_from_json(json, v, error) ? 0 : -1;
+}
+bool message_Person_try_from_json(const char* json, message_Person* v, jsonif_error* error) {
+  try {
+    ::message::Person u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    message_Person_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void message_Person_set_name(message_Person* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void message_Person_set_flag(message_Person* v, bool m) {
+  v->flag = m;
+}
+
+}
@@ -0,0 +1,79 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_MESSAGE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_MESSAGE_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_message_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_message_proto();
+}
+
+#endif
+
+// Person
+typedef struct {
+  char* name;
+  int name_len;
+  bool flag;
+} message_Person;
+
+int message_Person_size();
+void message_Person_init(message_Person* v);
+void message_Person_destroy(message_Person*);
+void message_Person_copy(const message_Person* a, message_Person* b);
+bool message_Person_is_equal(const message_Person* a, const message_Person* b);
+int message_Person_to_json_size(const message_Person*);
+void message_Person_to_json(const message_Person*, char* json);
+int message_Person_from_json(const char* json, message_Person*);
+bool message_Person_try_from_json(const char* json, message_Person* v, jsonif_error* error);
+void message_Person_set_name(message_Person* v, const char* s);
+void message_Person_set_flag(message_Person* v, bool m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
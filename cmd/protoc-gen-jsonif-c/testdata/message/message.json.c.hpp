@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_MESSAGE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_MESSAGE_PROTO
+
+#include "message.json.h"
+#include "message.json.c.h"
+
+
+::message::Person message_Person_to_cpp(const message_Person* v);
+void message_Person_from_cpp(const ::message::Person& u, message_Person* v);
+
+#endif
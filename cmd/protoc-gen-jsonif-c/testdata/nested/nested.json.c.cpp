@@ -0,0 +1,266 @@
+#include "nested.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "nested.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "nested.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// NestedEnum
+const nested_nested_Test_NestedEnum nested_nested_Test_FOO = 0;
+const nested_nested_Test_NestedEnum nested_nested_Test_BAR = 1;
+const nested_nested_Test_NestedEnum nested_nested_Test_HOGE = 2;
+
+::nested::nested::Test::NestedMessage nested_nested_Test_NestedMessage_to_cpp(const nested_nested_Test_NestedMessage* v) {
+  ::nested::nested::Test::NestedMessage u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  return u;
+}
+void nested_nested_Test_NestedMessage_from_cpp(const ::nested::nested::Test::NestedMessage& u, nested_nested_Test_NestedMessage* v) {
+  nested_nested_Test_NestedMessage_destroy(v);
+  memset(v, 0, sizeof(nested_nested_Test_NestedMessage));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+}
+::nested::nested::Test nested_nested_Test_to_cpp(const nested_nested_Test* v) {
+  ::nested::nested::Test u;
+  u.nested_message = nested_nested_Test_NestedMessage_to_cpp(&v->nested_message);
+  u.nested_enum = (decltype(u.nested_enum))v->nested_enum;
+  return u;
+}
+void nested_nested_Test_from_cpp(const ::nested::nested::Test& u, nested_nested_Test* v) {
+  nested_nested_Test_destroy(v);
+  memset(v, 0, sizeof(nested_nested_Test));
+  nested_nested_Test_NestedMessage_from_cpp(u.nested_message, &v->nested_message);
+  v->nested_enum = (int)u.nested_enum;
+}
+::nested::nested::Test2 nested_nested_Test2_to_cpp(const nested_nested_Test2* v) {
+  ::nested::nested::Test2 u;
+  u.test = nested_nested_Test_to_cpp(&v->test);
+  u.nested_message = nested_nested_Test_NestedMessage_to_cpp(&v->nested_message);
+  u.nested_enum = (decltype(u.nested_enum))v->nested_enum;
+  return u;
+}
+void nested_nested_Test2_from_cpp(const ::nested::nested::Test2& u, nested_nested_Test2* v) {
+  nested_nested_Test2_destroy(v);
+  memset(v, 0, sizeof(nested_nested_Test2));
+  nested_nested_Test_from_cpp(u.test, &v->test);
+  nested_nested_Test_NestedMessage_from_cpp(u.nested_message, &v->nested_message);
+  v->nested_enum = (int)u.nested_enum;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_nested_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int nested_nested_Test_NestedMessage_size() {
+  return sizeof(nested_nested_Test_NestedMessage);
+}
+void nested_nested_Test_NestedMessage_init(nested_nested_Test_NestedMessage* v) {
+  memset(v, 0, sizeof(nested_nested_Test_NestedMessage));
+}
+void nested_nested_Test_NestedMessage_destroy(nested_nested_Test_NestedMessage* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+}
+void nested_nested_Test_NestedMessage_copy(const nested_nested_Test_NestedMessage* a, nested_nested_Test_NestedMessage* b) {
+  if (a == b) return;
+  ::nested::nested::Test::NestedMessage u = nested_nested_Test_NestedMessage_to_cpp(a);
+  nested_nested_Test_NestedMessage_from_cpp(u, b);
+}
+bool nested_nested_Test_NestedMessage_is_equal(const nested_nested_Test_NestedMessage* a, const nested_nested_Test_NestedMessage* b) {
+  if (a == b) return true;
+  ::nested::nested::Test::NestedMessage ua = nested_nested_Test_NestedMessage_to_cpp(a);
+  ::nested::nested::Test::NestedMessage ub = nested_nested_Test_NestedMessage_to_cpp(b);
+  return ua == ub;
+}
+int nested_nested_Test_NestedMessage_to_json_size(const nested_nested_Test_NestedMessage* v) {
+  ::nested::nested::Test::NestedMessage u = nested_nested_Test_NestedMessage_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void nested_nested_Test_NestedMessage_to_json(const nested_nested_Test_NestedMessage* v, char* json) {
+  ::nested::nested::Test::NestedMessage u = nested_nested_Test_NestedMessage_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int nested_nested_Test_NestedMessage_from_json(const char* json, nested_nested_Test_NestedMessage* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return nested_nested_Test_NestedMessage_try_from_json(json, v, error) ? 0 : -1;
+}
+bool nested_nested_Test_NestedMessage_try_from_json(const char* json, nested_nested_Test_NestedMessage* v, jsonif_error* error) {
+  try {
+    ::nested::nested::Test::NestedMessage u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    nested_nested_Test_NestedMessage_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void nested_nested_Test_NestedMessage_set_name(nested_nested_Test_NestedMessage* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+int nested_nested_Test_size() {
+  return sizeof(nested_nested_Test);
+}
+void nested_nested_Test_init(nested_nested_Test* v) {
+  memset(v, 0, sizeof(nested_nested_Test));
+}
+void nested_nested_Test_destroy(nested_nested_Test* v) {
+  nested_nested_Test_NestedMessage_destroy(&v->nested_message);
+  memset(&v->nested_enum, 0, sizeof(v->nested_enum));
+}
+void nested_nested_Test_copy(const nested_nested_Test* a, nested_nested_Test* b) {
+  if (a == b) return;
+  ::nested::nested::Test u = nested_nested_Test_to_cpp(a);
+  nested_nested_Test_from_cpp(u, b);
+}
+bool nested_nested_Test_is_equal(const nested_nested_Test* a, const nested_nested_Test* b) {
+  if (a == b) return true;
+  ::nested::nested::Test ua = nested_nested_Test_to_cpp(a);
+  ::nested::nested::Test ub = nested_nested_Test_to_cpp(b);
+  return ua == ub;
+}
+int nested_nested_Test_to_json_size(const nested_nested_Test* v) {
+  ::nested::nested::Test u = nested_nested_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void nested_nested_Test_to_json(const nested_nested_Test* v, char* json) {
+  ::nested::nested::Test u = nested_nested_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int nested_nested_Test_from_json(const char* json, nested_nested_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return nested_nested_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool nested_nested_Test_try_from_json(const char* json, nested_nested_Test* v, jsonif_error* error) {
+  try {
+    ::nested::nested::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    nested_nested_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void nested_nested_Test_set_nested_message(nested_nested_Test* v, const nested_nested_Test_NestedMessage* m) {
+  nested_nested_Test_NestedMessage_copy(m, &v->nested_message);
+}
+void nested_nested_Test_set_nested_enum(nested_nested_Test* v, nested_nested_Test_NestedEnum m) {
+  v->nested_enum = m;
+}
+int nested_nested_Test2_size() {
+  return sizeof(nested_nested_Test2);
+}
+void nested_nested_Test2_init(nested_nested_Test2* v) {
+  memset(v, 0, sizeof(nested_nested_Test2));
+}
+void nested_nested_Test2_destroy(nested_nested_Test2* v) {
+  nested_nested_Test_destroy(&v->test);
+  nested_nested_Test_NestedMessage_destroy(&v->nested_message);
+  memset(&v->nested_enum, 0, sizeof(v->nested_enum));
+}
+void nested_nested_Test2_copy(const nested_nested_Test2* a, nested_nested_Test2* b) {
+  if (a == b) return;
+  ::nested::nested::Test2 u = nested_nested_Test2_to_cpp(a);
+  nested_nested_Test2_from_cpp(u, b);
+}
+bool nested_nested_Test2_is_equal(const nested_nested_Test2* a, const nested_nested_Test2* b) {
+  if (a == b) return true;
+  ::nested::nested::Test2 ua = nested_nested_Test2_to_cpp(a);
+  ::nested::nested::Test2 ub = nested_nested_Test2_to_cpp(b);
+  return ua == ub;
+}
+int nested_nested_Test2_to_json_size(const nested_nested_Test2* v) {
+  ::nested::nested::Test2 u = nested_nested_Test2_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void nested_nested_Test2_to_json(const nested_nested_Test2* v, char* json) {
+  ::nested::nested::Test2 u = nested_nested_Test2_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int nested_nested_Test2_from_json(const char* json, nested_nested_Test2* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return nested_nested_Test2_try_from_json(json, v, error) ? 0 : -1;
+}
+bool nested_nested_Test2_try_from_json(const char* json, nested_nested_Test2* v, jsonif_error* error) {
+  try {
+    ::nested::nested::Test2 u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    nested_nested_Test2_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void nested_nested_Test2_set_test(nested_nested_Test2* v, const nested_nested_Test* m) {
+  nested_nested_Test_copy(m, &v->test);
+}
+void nested_nested_Test2_set_nested_message(nested_nested_Test2* v, const nested_nested_Test_NestedMessage* m) {
+  nested_nested_Test_NestedMessage_copy(m, &v->nested_message);
+}
+void nested_nested_Test2_set_nested_enum(nested_nested_Test2* v, nested_nested_Test_NestedEnum m) {
+  v->nested_enum = m;
+}
+
+}
@@ -0,0 +1,121 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_NESTED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_NESTED_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_nested_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_nested_proto();
+}
+
+#endif
+
+// NestedEnum
+typedef int nested_nested_Test_NestedEnum;
+extern const nested_nested_Test_NestedEnum nested_nested_Test_FOO;
+extern const nested_nested_Test_NestedEnum nested_nested_Test_BAR;
+extern const nested_nested_Test_NestedEnum nested_nested_Test_HOGE;
+
+// NestedMessage
+typedef struct {
+  char* name;
+  int name_len;
+} nested_nested_Test_NestedMessage;
+
+int nested_nested_Test_NestedMessage_size();
+void nested_nested_Test_NestedMessage_init(nested_nested_Test_NestedMessage* v);
+void nested_nested_Test_NestedMessage_destroy(nested_nested_Test_NestedMessage*);
+void nested_nested_Test_NestedMessage_copy(const nested_nested_Test_NestedMessage* a, nested_nested_Test_NestedMessage* b);
+bool nested_nested_Test_NestedMessage_is_equal(const nested_nested_Test_NestedMessage* a, const nested_nested_Test_NestedMessage* b);
+int nested_nested_Test_NestedMessage_to_json_size(const nested_nested_Test_NestedMessage*);
+void nested_nested_Test_NestedMessage_to_json(const nested_nested_Test_NestedMessage*, char* json);
+int nested_nested_Test_NestedMessage_from_json(const char* json, nested_nested_Test_NestedMessage*);
+bool nested_nested_Test_NestedMessage_try_from_json(const char* json, nested_nested_Test_NestedMessage* v, jsonif_error* error);
+void nested_nested_Test_NestedMessage_set_name(nested_nested_Test_NestedMessage* v, const char* s);
+
+// Test
+typedef struct {
+  nested_nested_Test_NestedMessage nested_message;
+  nested_nested_Test_NestedEnum nested_enum;
+} nested_nested_Test;
+
+int nested_nested_Test_size();
+void nested_nested_Test_init(nested_nested_Test* v);
+void nested_nested_Test_destroy(nested_nested_Test*);
+void nested_nested_Test_copy(const nested_nested_Test* a, nested_nested_Test* b);
+bool nested_nested_Test_is_equal(const nested_nested_Test* a, const nested_nested_Test* b);
+int nested_nested_Test_to_json_size(const nested_nested_Test*);
+void nested_nested_Test_to_json(const nested_nested_Test*, char* json);
+int nested_nested_Test_from_json(const char* json, nested_nested_Test*);
+bool nested_nested_Test_try_from_json(const char* json, nested_nested_Test* v, jsonif_error* error);
+void nested_nested_Test_set_nested_message(nested_nested_Test* v, const nested_nested_Test_NestedMessage* m);
+void nested_nested_Test_set_nested_enum(nested_nested_Test* v, nested_nested_Test_NestedEnum m);
+
+// Test2
+typedef struct {
+  nested_nested_Test test;
+  nested_nested_Test_NestedMessage nested_message;
+  nested_nested_Test_NestedEnum nested_enum;
+} nested_nested_Test2;
+
+int nested_nested_Test2_size();
+void nested_nested_Test2_init(nested_nested_Test2* v);
+void nested_nested_Test2_destroy(nested_nested_Test2*);
+void nested_nested_Test2_copy(const nested_nested_Test2* a, nested_nested_Test2* b);
+bool nested_nested_Test2_is_equal(const nested_nested_Test2* a, const nested_nested_Test2* b);
+int nested_nested_Test2_to_json_size(const nested_nested_Test2*);
+void nested_nested_Test2_to_json(const nested_nested_Test2*, char* json);
+int nested_nested_Test2_from_json(const char* json, nested_nested_Test2*);
+bool nested_nested_Test2_try_from_json(const char* json, nested_nested_Test2* v, jsonif_error* error);
+void nested_nested_Test2_set_test(nested_nested_Test2* v, const nested_nested_Test* m);
+void nested_nested_Test2_set_nested_message(nested_nested_Test2* v, const nested_nested_Test_NestedMessage* m);
+void nested_nested_Test2_set_nested_enum(nested_nested_Test2* v, nested_nested_Test_NestedEnum m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,15 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_NESTED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_NESTED_PROTO
+
+#include "nested.json.h"
+#include "nested.json.c.h"
+
+
+::nested::nested::Test::NestedMessage nested_nested_Test_NestedMessage_to_cpp(const nested_nested_Test_NestedMessage* v);
+void nested_nested_Test_NestedMessage_from_cpp(const ::nested::nested::Test::NestedMessage& u, nested_nested_Test_NestedMessage* v);
+::nested::nested::Test nested_nested_Test_to_cpp(const nested_nested_Test* v);
+void nested_nested_Test_from_cpp(const ::nested::nested::Test& u, nested_nested_Test* v);
+::nested::nested::Test2 nested_nested_Test2_to_cpp(const nested_nested_Test2* v);
+void nested_nested_Test2_from_cpp(const ::nested::nested::Test2& u, nested_nested_Test2* v);
+
+#endif
@@ -0,0 +1,250 @@
+#include "oneof.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "oneof.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "oneof.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Enum
+const oneof_Enum oneof_FOO = 0;
+const oneof_Enum oneof_BAR = 1;
+
+::oneof::Message oneof_Message_to_cpp(const oneof_Message* v) {
+  ::oneof::Message u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  return u;
+}
+void oneof_Message_from_cpp(const ::oneof::Message& u, oneof_Message* v) {
+  oneof_Message_destroy(v);
+  memset(v, 0, sizeof(oneof_Message));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+}
+// test_oneof
+const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_NOT_SET = 0;
+const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kA = 1;
+const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kB = 2;
+const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kC = 3;
+const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kD = 4;
+
+::oneof::Test oneof_Test_to_cpp(const oneof_Test* v) {
+  ::oneof::Test u;
+  u.a = v->a;
+  if (v->b_len != 0) u.b = std::string(v->b, v->b_len);
+  u.c = (decltype(u.c))v->c;
+  u.d = oneof_Message_to_cpp(&v->d);
+  u.test_oneof_case = (::oneof::Test::TestOneofCase)v->test_oneof_case;
+  return u;
+}
+void oneof_Test_from_cpp(const ::oneof::Test& u, oneof_Test* v) {
+  oneof_Test_destroy(v);
+  memset(v, 0, sizeof(oneof_Test));
+  v->a = u.a;
+  if (!u.b.empty()) v->b = strdup(u.b.c_str());
+  v->b_len = (int)u.b.size();
+  v->c = (int)u.c;
+  oneof_Message_from_cpp(u.d, &v->d);
+  v->test_oneof_case = (int)u.test_oneof_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_oneof_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int oneof_Message_size() {
+  return sizeof(oneof_Message);
+}
+void oneof_Message_init(oneof_Message* v) {
+  memset(v, 0, sizeof(oneof_Message));
+}
+void oneof_Message_destroy(oneof_Message* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+}
+void oneof_Message_copy(const oneof_Message* a, oneof_Message* b) {
+  if (a == b) return;
+  ::oneof::Message u = oneof_Message_to_cpp(a);
+  oneof_Message_from_cpp(u, b);
+}
+bool oneof_Message_is_equal(const oneof_Message* a, const oneof_Message* b) {
+  if (a == b) return true;
+  ::oneof::Message ua = oneof_Message_to_cpp(a);
+  ::oneof::Message ub = oneof_Message_to_cpp(b);
+  return ua == ub;
+}
+int oneof_Message_to_json_size(const oneof_Message* v) {
+  ::oneof::Message u = oneof_Message_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void oneof_Message_to_json(const oneof_Message* v, char* json) {
+  ::oneof::Message u = oneof_Message_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int oneof_Message_from_json(const char* json, oneof_Message* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return oneof_Message_try_from_json(json, v, error) ? 0 : -1;
+}
+bool oneof_Message_try_from_json(const char* json, oneof_Message* v, jsonif_error* error) {
+  try {
+    ::oneof::Message u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    oneof_Message_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void oneof_Message_set_name(oneof_Message* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+int oneof_Test_size() {
+  return sizeof(oneof_Test);
+}
+void oneof_Test_init(oneof_Test* v) {
+  memset(v, 0, sizeof(oneof_Test));
+}
+void oneof_Test_destroy(oneof_Test* v) {
+  memset(&v->a, 0, sizeof(v->a));
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  memset(&v->c, 0, sizeof(v->c));
+  oneof_Message_destroy(&v->d);
+}
+void oneof_Test_copy(const oneof_Test* a, oneof_Test* b) {
+  if (a == b) return;
+  ::oneof::Test u = oneof_Test_to_cpp(a);
+  oneof_Test_from_cpp(u, b);
+}
+bool oneof_Test_is_equal(const oneof_Test* a, const oneof_Test* b) {
+  if (a == b) return true;
+  ::oneof::Test ua = oneof_Test_to_cpp(a);
+  ::oneof::Test ub = oneof_Test_to_cpp(b);
+  return ua == ub;
+}
+int oneof_Test_to_json_size(const oneof_Test* v) {
+  ::oneof::Test u = oneof_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void oneof_Test_to_json(const oneof_Test* v, char* json) {
+  ::oneof::Test u = oneof_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int oneof_Test_from_json(const char* json, oneof_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return oneof_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool oneof_Test_try_from_json(const char* json, oneof_Test* v, jsonif_error* error) {
+  try {
+    ::oneof::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    oneof_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void oneof_Test_set_a(oneof_Test* v, int32_t m) {
+  oneof_Test_clear_test_oneof_case(v);
+  v->test_oneof_case = oneof_Test_TestOneofCase_kA;
+  v->a = m;
+}
+void oneof_Test_set_b(oneof_Test* v, const char* s) {
+  oneof_Test_clear_test_oneof_case(v);
+  v->test_oneof_case = oneof_Test_TestOneofCase_kB;
+  if (v->b) free(v->b);
+  v->b_len = s == nullptr ? 0 : strlen(s);
+  v->b = v->b_len == 0 ? nullptr : strdup(s);
+}
+void oneof_Test_set_c(oneof_Test* v, oneof_Enum m) {
+  oneof_Test_clear_test_oneof_case(v);
+  v->test_oneof_case = oneof_Test_TestOneofCase_kC;
+  v->c = m;
+}
+void oneof_Test_set_d(oneof_Test* v, const oneof_Message* m) {
+  oneof_Test_clear_test_oneof_case(v);
+  v->test_oneof_case = oneof_Test_TestOneofCase_kD;
+  oneof_Message_copy(m, &v->d);
+}
+void oneof_Test_clear_a(oneof_Test* v) {
+  if (v->test_oneof_case == oneof_Test_TestOneofCase_kA) {
+    oneof_Test_clear_test_oneof_case(v);
+  }
+}
+void oneof_Test_clear_b(oneof_Test* v) {
+  if (v->test_oneof_case == oneof_Test_TestOneofCase_kB) {
+    oneof_Test_clear_test_oneof_case(v);
+  }
+}
+void oneof_Test_clear_c(oneof_Test* v) {
+  if (v->test_oneof_case == oneof_Test_TestOneofCase_kC) {
+    oneof_Test_clear_test_oneof_case(v);
+  }
+}
+void oneof_Test_clear_d(oneof_Test* v) {
+  if (v->test_oneof_case == oneof_Test_TestOneofCase_kD) {
+    oneof_Test_clear_test_oneof_case(v);
+  }
+}
+void oneof_Test_clear_test_oneof_case(oneof_Test* v) {
+  memset(&v->a, 0, sizeof(v->a));
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  memset(&v->c, 0, sizeof(v->c));
+  oneof_Message_destroy(&v->d);
+  v->test_oneof_case = oneof_Test_TestOneofCase_NOT_SET;
+}
+
+}
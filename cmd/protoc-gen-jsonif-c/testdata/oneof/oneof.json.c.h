@@ -0,0 +1,119 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ONEOF_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ONEOF_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_oneof_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_oneof_proto();
+}
+
+#endif
+
+// Enum
+typedef int oneof_Enum;
+extern const oneof_Enum oneof_FOO;
+extern const oneof_Enum oneof_BAR;
+
+// test_oneof
+typedef int oneof_Test_TestOneofCase;
+extern const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_NOT_SET;
+extern const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kA;
+extern const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kB;
+extern const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kC;
+extern const oneof_Test_TestOneofCase oneof_Test_TestOneofCase_kD;
+
+// Message
+typedef struct {
+  char* name;
+  int name_len;
+} oneof_Message;
+
+int oneof_Message_size();
+void oneof_Message_init(oneof_Message* v);
+void oneof_Message_destroy(oneof_Message*);
+void oneof_Message_copy(const oneof_Message* a, oneof_Message* b);
+bool oneof_Message_is_equal(const oneof_Message* a, const oneof_Message* b);
+int oneof_Message_to_json_size(const oneof_Message*);
+void oneof_Message_to_json(const oneof_Message*, char* json);
+int oneof_Message_from_json(const char* json, oneof_Message*);
+bool oneof_Message_try_from_json(const char* json, oneof_Message* v, jsonif_error* error);
+void oneof_Message_set_name(oneof_Message* v, const char* s);
+
+// Test
+typedef struct {
+  int32_t a;
+  char* b;
+  int b_len;
+  oneof_Enum c;
+  oneof_Message d;
+  oneof_Test_TestOneofCase test_oneof_case;
+} oneof_Test;
+
+int oneof_Test_size();
+void oneof_Test_init(oneof_Test* v);
+void oneof_Test_destroy(oneof_Test*);
+void oneof_Test_copy(const oneof_Test* a, oneof_Test* b);
+bool oneof_Test_is_equal(const oneof_Test* a, const oneof_Test* b);
+int oneof_Test_to_json_size(const oneof_Test*);
+void oneof_Test_to_json(const oneof_Test*, char* json);
+int oneof_Test_from_json(const char* json, oneof_Test*);
+bool oneof_Test_try_from_json(const char* json, oneof_Test* v, jsonif_error* error);
+void oneof_Test_set_a(oneof_Test* v, int32_t m);
+void oneof_Test_set_b(oneof_Test* v, const char* s);
+void oneof_Test_set_c(oneof_Test* v, oneof_Enum m);
+void oneof_Test_set_d(oneof_Test* v, const oneof_Message* m);
+
+void oneof_Test_clear_a(oneof_Test* v);
+void oneof_Test_clear_b(oneof_Test* v);
+void oneof_Test_clear_c(oneof_Test* v);
+void oneof_Test_clear_d(oneof_Test* v);
+void oneof_Test_clear_test_oneof_case(oneof_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,13 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ONEOF_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ONEOF_PROTO
+
+#include "oneof.json.h"
+#include "oneof.json.c.h"
+
+
+::oneof::Message oneof_Message_to_cpp(const oneof_Message* v);
+void oneof_Message_from_cpp(const ::oneof::Message& u, oneof_Message* v);
+::oneof::Test oneof_Test_to_cpp(const oneof_Test* v);
+void oneof_Test_from_cpp(const ::oneof::Test& u, oneof_Test* v);
+
+#endif
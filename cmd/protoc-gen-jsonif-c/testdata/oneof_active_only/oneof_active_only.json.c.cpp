@@ -0,0 +1,408 @@
+#include "oneof_active_only.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "oneof_active_only.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "oneof_active_only.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::oneof_active_only::Inner oneof_active_only_Inner_to_cpp(const oneof_active_only_Inner* v) {
+  ::oneof_active_only::Inner u;
+  u.value = v->value;
+  return u;
+}
+void oneof_active_only_Inner_from_cpp(const ::oneof_active_only::Inner& u, oneof_active_only_Inner* v) {
+  oneof_active_only_Inner_destroy(v);
+  memset(v, 0, sizeof(oneof_active_only_Inner));
+  v->value = u.value;
+}
+// kind
+const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_NOT_SET = 0;
+const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_kName = 1;
+const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_kNumber = 2;
+const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_kInner = 3;
+
+// legacy
+const oneof_active_only_Test_LegacyCase oneof_active_only_Test_LegacyCase_NOT_SET = 0;
+const oneof_active_only_Test_LegacyCase oneof_active_only_Test_LegacyCase_kLegacyName = 4;
+const oneof_active_only_Test_LegacyCase oneof_active_only_Test_LegacyCase_kLegacyNumber = 5;
+
+// _opt
+const oneof_active_only_Test_OptCase oneof_active_only_Test_OptCase_NOT_SET = 0;
+const oneof_active_only_Test_OptCase oneof_active_only_Test_OptCase_kOpt = 6;
+
+::oneof_active_only::Test oneof_active_only_Test_to_cpp(const oneof_active_only_Test* v) {
+  ::oneof_active_only::Test u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.number = v->number;
+  u.inner = oneof_active_only_Inner_to_cpp(&v->inner);
+  if (v->legacy_name_len != 0) u.legacy_name = std::string(v->legacy_name, v->legacy_name_len);
+  u.legacy_number = v->legacy_number;
+  u.opt = v->opt;
+  u.value = v->value;
+  u.kind_case = (::oneof_active_only::Test::KindCase)v->kind_case;
+  u.legacy_case = (::oneof_active_only::Test::LegacyCase)v->legacy_case;
+  u._opt_case = (::oneof_active_only::Test::OptCase)v->_opt_case;
+  return u;
+}
+void oneof_active_only_Test_from_cpp(const ::oneof_active_only::Test& u, oneof_active_only_Test* v) {
+  oneof_active_only_Test_destroy(v);
+  memset(v, 0, sizeof(oneof_active_only_Test));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  v->number = u.number;
+  oneof_active_only_Inner_from_cpp(u.inner, &v->inner);
+  if (!u.legacy_name.empty()) v->legacy_name = strdup(u.legacy_name.c_str());
+  v->legacy_name_len = (int)u.legacy_name.size();
+  v->legacy_number = u.legacy_number;
+  v->opt = u.opt;
+  v->value = u.value;
+  v->kind_case = (int)u.kind_case;
+  v->legacy_case = (int)u.legacy_case;
+  v->_opt_case = (int)u._opt_case;
+}
+// kind
+const oneof_active_only_Test2_KindCase oneof_active_only_Test2_KindCase_NOT_SET = 0;
+const oneof_active_only_Test2_KindCase oneof_active_only_Test2_KindCase_kName = 1;
+const oneof_active_only_Test2_KindCase oneof_active_only_Test2_KindCase_kNumber = 2;
+
+::oneof_active_only::Test2 oneof_active_only_Test2_to_cpp(const oneof_active_only_Test2* v) {
+  ::oneof_active_only::Test2 u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.number = v->number;
+  u.kind_case = (::oneof_active_only::Test2::KindCase)v->kind_case;
+  return u;
+}
+void oneof_active_only_Test2_from_cpp(const ::oneof_active_only::Test2& u, oneof_active_only_Test2* v) {
+  oneof_active_only_Test2_destroy(v);
+  memset(v, 0, sizeof(oneof_active_only_Test2));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  v->number = u.number;
+  v->kind_case = (int)u.kind_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_oneof_active_only_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int oneof_active_only_Inner_size() {
+  return sizeof(oneof_active_only_Inner);
+}
+void oneof_active_only_Inner_init(oneof_active_only_Inner* v) {
+  memset(v, 0, sizeof(oneof_active_only_Inner));
+}
+void oneof_active_only_Inner_destroy(oneof_active_only_Inner* v) {
+  memset(&v->value, 0, sizeof(v->value));
+}
+void oneof_active_only_Inner_copy(const oneof_active_only_Inner* a, oneof_active_only_Inner* b) {
+  if (a == b) return;
+  ::oneof_active_only::Inner u = oneof_active_only_Inner_to_cpp(a);
+  oneof_active_only_Inner_from_cpp(u, b);
+}
+bool oneof_active_only_Inner_is_equal(const oneof_active_only_Inner* a, const oneof_active_only_Inner* b) {
+  if (a == b) return true;
+  ::oneof_active_only::Inner ua = oneof_active_only_Inner_to_cpp(a);
+  ::oneof_active_only::Inner ub = oneof_active_only_Inner_to_cpp(b);
+  return ua == ub;
+}
+int oneof_active_only_Inner_to_json_size(const oneof_active_only_Inner* v) {
+  ::oneof_active_only::Inner u = oneof_active_only_Inner_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void oneof_active_only_Inner_to_json(const oneof_active_only_Inner* v, char* json) {
+  ::oneof_active_only::Inner u = oneof_active_only_Inner_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int oneof_active_only_Inner_from_json(const char* json, oneof_active_only_Inner* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return oneof_active_only_Inner_try_from_json(json, v, error) ? 0 : -1;
+}
+bool oneof_active_only_Inner_try_from_json(const char* json, oneof_active_only_Inner* v, jsonif_error* error) {
+  try {
+    ::oneof_active_only::Inner u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    oneof_active_only_Inner_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void oneof_active_only_Inner_set_value(oneof_active_only_Inner* v, int32_t m) {
+  v->value = m;
+}
+int oneof_active_only_Test_size() {
+  return sizeof(oneof_active_only_Test);
+}
+void oneof_active_only_Test_init(oneof_active_only_Test* v) {
+  memset(v, 0, sizeof(oneof_active_only_Test));
+}
+void oneof_active_only_Test_destroy(oneof_active_only_Test* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+  oneof_active_only_Inner_destroy(&v->inner);
+  if (v->legacy_name) free(v->legacy_name);
+  v->legacy_name = nullptr;
+  v->legacy_name_len = 0;
+  memset(&v->legacy_number, 0, sizeof(v->legacy_number));
+  memset(&v->opt, 0, sizeof(v->opt));
+  memset(&v->value, 0, sizeof(v->value));
+}
+void oneof_active_only_Test_copy(const oneof_active_only_Test* a, oneof_active_only_Test* b) {
+  if (a == b) return;
+  ::oneof_active_only::Test u = oneof_active_only_Test_to_cpp(a);
+  oneof_active_only_Test_from_cpp(u, b);
+}
+bool oneof_active_only_Test_is_equal(const oneof_active_only_Test* a, const oneof_active_only_Test* b) {
+  if (a == b) return true;
+  ::oneof_active_only::Test ua = oneof_active_only_Test_to_cpp(a);
+  ::oneof_active_only::Test ub = oneof_active_only_Test_to_cpp(b);
+  return ua == ub;
+}
+int oneof_active_only_Test_to_json_size(const oneof_active_only_Test* v) {
+  ::oneof_active_only::Test u = oneof_active_only_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void oneof_active_only_Test_to_json(const oneof_active_only_Test* v, char* json) {
+  ::oneof_active_only::Test u = oneof_active_only_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int oneof_active_only_Test_from_json(const char* json, oneof_active_only_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return oneof_active_only_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool oneof_active_only_Test_try_from_json(const char* json, oneof_active_only_Test* v, jsonif_error* error) {
+  try {
+    ::oneof_active_only::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    oneof_active_only_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void oneof_active_only_Test_set_name(oneof_active_only_Test* v, const char* s) {
+  oneof_active_only_Test_clear_kind_case(v);
+  v->kind_case = oneof_active_only_Test_KindCase_kName;
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void oneof_active_only_Test_set_number(oneof_active_only_Test* v, int32_t m) {
+  oneof_active_only_Test_clear_kind_case(v);
+  v->kind_case = oneof_active_only_Test_KindCase_kNumber;
+  v->number = m;
+}
+void oneof_active_only_Test_set_inner(oneof_active_only_Test* v, const oneof_active_only_Inner* m) {
+  oneof_active_only_Test_clear_kind_case(v);
+  v->kind_case = oneof_active_only_Test_KindCase_kInner;
+  oneof_active_only_Inner_copy(m, &v->inner);
+}
+void oneof_active_only_Test_set_legacy_name(oneof_active_only_Test* v, const char* s) {
+  oneof_active_only_Test_clear_legacy_case(v);
+  v->legacy_case = oneof_active_only_Test_LegacyCase_kLegacyName;
+  if (v->legacy_name) free(v->legacy_name);
+  v->legacy_name_len = s == nullptr ? 0 : strlen(s);
+  v->legacy_name = v->legacy_name_len == 0 ? nullptr : strdup(s);
+}
+void oneof_active_only_Test_set_legacy_number(oneof_active_only_Test* v, int32_t m) {
+  oneof_active_only_Test_clear_legacy_case(v);
+  v->legacy_case = oneof_active_only_Test_LegacyCase_kLegacyNumber;
+  v->legacy_number = m;
+}
+void oneof_active_only_Test_set_opt(oneof_active_only_Test* v, int32_t m) {
+  oneof_active_only_Test_clear__opt_case(v);
+  v->_opt_case = oneof_active_only_Test_OptCase_kOpt;
+  v->opt = m;
+}
+void oneof_active_only_Test_set_value(oneof_active_only_Test* v, int32_t m) {
+  v->value = m;
+}
+void oneof_active_only_Test_clear_name(oneof_active_only_Test* v) {
+  if (v->kind_case == oneof_active_only_Test_KindCase_kName) {
+    oneof_active_only_Test_clear_kind_case(v);
+  }
+}
+void oneof_active_only_Test_clear_number(oneof_active_only_Test* v) {
+  if (v->kind_case == oneof_active_only_Test_KindCase_kNumber) {
+    oneof_active_only_Test_clear_kind_case(v);
+  }
+}
+void oneof_active_only_Test_clear_inner(oneof_active_only_Test* v) {
+  if (v->kind_case == oneof_active_only_Test_KindCase_kInner) {
+    oneof_active_only_Test_clear_kind_case(v);
+  }
+}
+void oneof_active_only_Test_clear_legacy_name(oneof_active_only_Test* v) {
+  if (v->legacy_case == oneof_active_only_Test_LegacyCase_kLegacyName) {
+    oneof_active_only_Test_clear_legacy_case(v);
+  }
+}
+void oneof_active_only_Test_clear_legacy_number(oneof_active_only_Test* v) {
+  if (v->legacy_case == oneof_active_only_Test_LegacyCase_kLegacyNumber) {
+    oneof_active_only_Test_clear_legacy_case(v);
+  }
+}
+bool oneof_active_only_Test_has_opt(const oneof_active_only_Test* v) {
+  return v->_opt_case == oneof_active_only_Test_OptCase_kOpt;
+}
+void oneof_active_only_Test_clear_opt(oneof_active_only_Test* v) {
+  if (v->_opt_case == oneof_active_only_Test_OptCase_kOpt) {
+    oneof_active_only_Test_clear__opt_case(v);
+  }
+}
+void oneof_active_only_Test_clear_kind_case(oneof_active_only_Test* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+  oneof_active_only_Inner_destroy(&v->inner);
+  v->kind_case = oneof_active_only_Test_KindCase_NOT_SET;
+}
+void oneof_active_only_Test_clear_legacy_case(oneof_active_only_Test* v) {
+  if (v->legacy_name) free(v->legacy_name);
+  v->legacy_name = nullptr;
+  v->legacy_name_len = 0;
+  memset(&v->legacy_number, 0, sizeof(v->legacy_number));
+  v->legacy_case = oneof_active_only_Test_LegacyCase_NOT_SET;
+}
+void oneof_active_only_Test_clear__opt_case(oneof_active_only_Test* v) {
+  memset(&v->opt, 0, sizeof(v->opt));
+  v->_opt_case = oneof_active_only_Test_OptCase_NOT_SET;
+}
+int oneof_active_only_Test2_size() {
+  return sizeof(oneof_active_only_Test2);
+}
+void oneof_active_only_Test2_init(oneof_active_only_Test2* v) {
+  memset(v, 0, sizeof(oneof_active_only_Test2));
+}
+void oneof_active_only_Test2_destroy(oneof_active_only_Test2* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+}
+void oneof_active_only_Test2_copy(const oneof_active_only_Test2* a, oneof_active_only_Test2* b) {
+  if (a == b) return;
+  ::oneof_active_only::Test2 u = oneof_active_only_Test2_to_cpp(a);
+  oneof_active_only_Test2_from_cpp(u, b);
+}
+bool oneof_active_only_Test2_is_equal(const oneof_active_only_Test2* a, const oneof_active_only_Test2* b) {
+  if (a == b) return true;
+  ::oneof_active_only::Test2 ua = oneof_active_only_Test2_to_cpp(a);
+  ::oneof_active_only::Test2 ub = oneof_active_only_Test2_to_cpp(b);
+  return ua == ub;
+}
+int oneof_active_only_Test2_to_json_size(const oneof_active_only_Test2* v) {
+  ::oneof_active_only::Test2 u = oneof_active_only_Test2_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void oneof_active_only_Test2_to_json(const oneof_active_only_Test2* v, char* json) {
+  ::oneof_active_only::Test2 u = oneof_active_only_Test2_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int oneof_active_only_Test2_from_json(const char* json, oneof_active_only_Test2* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return oneof_active_only_Test2_try_from_json(json, v, error) ? 0 : -1;
+}
+bool oneof_active_only_Test2_try_from_json(const char* json, oneof_active_only_Test2* v, jsonif_error* error) {
+  try {
+    ::oneof_active_only::Test2 u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    oneof_active_only_Test2_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void oneof_active_only_Test2_set_name(oneof_active_only_Test2* v, const char* s) {
+  oneof_active_only_Test2_clear_kind_case(v);
+  v->kind_case = oneof_active_only_Test2_KindCase_kName;
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void oneof_active_only_Test2_set_number(oneof_active_only_Test2* v, int32_t m) {
+  oneof_active_only_Test2_clear_kind_case(v);
+  v->kind_case = oneof_active_only_Test2_KindCase_kNumber;
+  v->number = m;
+}
+void oneof_active_only_Test2_clear_name(oneof_active_only_Test2* v) {
+  if (v->kind_case == oneof_active_only_Test2_KindCase_kName) {
+    oneof_active_only_Test2_clear_kind_case(v);
+  }
+}
+void oneof_active_only_Test2_clear_number(oneof_active_only_Test2* v) {
+  if (v->kind_case == oneof_active_only_Test2_KindCase_kNumber) {
+    oneof_active_only_Test2_clear_kind_case(v);
+  }
+}
+void oneof_active_only_Test2_clear_kind_case(oneof_active_only_Test2* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+  v->kind_case = oneof_active_only_Test2_KindCase_NOT_SET;
+}
+
+}
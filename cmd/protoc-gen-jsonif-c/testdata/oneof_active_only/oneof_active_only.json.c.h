@@ -0,0 +1,167 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ONEOF_ACTIVE_ONLY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_ONEOF_ACTIVE_ONLY_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_oneof_active_only_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_oneof_active_only_proto();
+}
+
+#endif
+
+// kind
+typedef int oneof_active_only_Test_KindCase;
+extern const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_NOT_SET;
+extern const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_kName;
+extern const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_kNumber;
+extern const oneof_active_only_Test_KindCase oneof_active_only_Test_KindCase_kInner;
+
+// legacy
+/// メッセージの設定よりも oneof の設定が優先される
+typedef int oneof_active_only_Test_LegacyCase;
+extern const oneof_active_only_Test_LegacyCase oneof_active_only_Test_LegacyCase_NOT_SET;
+extern const oneof_active_only_Test_LegacyCase oneof_active_only_Test_LegacyCase_kLegacyName;
+extern const oneof_active_only_Test_LegacyCase oneof_active_only_Test_LegacyCase_kLegacyNumber;
+
+// _opt
+typedef int oneof_active_only_Test_OptCase;
+extern const oneof_active_only_Test_OptCase oneof_active_only_Test_OptCase_NOT_SET;
+extern const oneof_active_only_Test_OptCase oneof_active_only_Test_OptCase_kOpt;
+
+// kind
+typedef int oneof_active_only_Test2_KindCase;
+extern const oneof_active_only_Test2_KindCase oneof_active_only_Test2_KindCase_NOT_SET;
+extern const oneof_active_only_Test2_KindCase oneof_active_only_Test2_KindCase_kName;
+extern const oneof_active_only_Test2_KindCase oneof_active_only_Test2_KindCase_kNumber;
+
+// Inner
+typedef struct {
+  int32_t value;
+} oneof_active_only_Inner;
+
+int oneof_active_only_Inner_size();
+void oneof_active_only_Inner_init(oneof_active_only_Inner* v);
+void oneof_active_only_Inner_destroy(oneof_active_only_Inner*);
+void oneof_active_only_Inner_copy(const oneof_active_only_Inner* a, oneof_active_only_Inner* b);
+bool oneof_active_only_Inner_is_equal(const oneof_active_only_Inner* a, const oneof_active_only_Inner* b);
+int oneof_active_only_Inner_to_json_size(const oneof_active_only_Inner*);
+void oneof_active_only_Inner_to_json(const oneof_active_only_Inner*, char* json);
+int oneof_active_only_Inner_from_json(const char* json, oneof_active_only_Inner*);
+bool oneof_active_only_Inner_try_from_json(const char* json, oneof_active_only_Inner* v, jsonif_error* error);
+void oneof_active_only_Inner_set_value(oneof_active_only_Inner* v, int32_t m);
+
+// Test
+typedef struct {
+  char* name;
+  int name_len;
+  int32_t number;
+  oneof_active_only_Inner inner;
+  char* legacy_name;
+  int legacy_name_len;
+  int32_t legacy_number;
+  int32_t opt;
+  int32_t value;
+  oneof_active_only_Test_KindCase kind_case;
+  oneof_active_only_Test_LegacyCase legacy_case;
+  oneof_active_only_Test_OptCase _opt_case;
+} oneof_active_only_Test;
+
+int oneof_active_only_Test_size();
+void oneof_active_only_Test_init(oneof_active_only_Test* v);
+void oneof_active_only_Test_destroy(oneof_active_only_Test*);
+void oneof_active_only_Test_copy(const oneof_active_only_Test* a, oneof_active_only_Test* b);
+bool oneof_active_only_Test_is_equal(const oneof_active_only_Test* a, const oneof_active_only_Test* b);
+int oneof_active_only_Test_to_json_size(const oneof_active_only_Test*);
+void oneof_active_only_Test_to_json(const oneof_active_only_Test*, char* json);
+int oneof_active_only_Test_from_json(const char* json, oneof_active_only_Test*);
+bool oneof_active_only_Test_try_from_json(const char* json, oneof_active_only_Test* v, jsonif_error* error);
+void oneof_active_only_Test_set_name(oneof_active_only_Test* v, const char* s);
+void oneof_active_only_Test_set_number(oneof_active_only_Test* v, int32_t m);
+void oneof_active_only_Test_set_inner(oneof_active_only_Test* v, const oneof_active_only_Inner* m);
+void oneof_active_only_Test_set_legacy_name(oneof_active_only_Test* v, const char* s);
+void oneof_active_only_Test_set_legacy_number(oneof_active_only_Test* v, int32_t m);
+void oneof_active_only_Test_set_opt(oneof_active_only_Test* v, int32_t m);
+void oneof_active_only_Test_set_value(oneof_active_only_Test* v, int32_t m);
+
+void oneof_active_only_Test_clear_name(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_number(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_inner(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_legacy_name(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_legacy_number(oneof_active_only_Test* v);
+bool oneof_active_only_Test_has_opt(const oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_opt(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_kind_case(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear_legacy_case(oneof_active_only_Test* v);
+void oneof_active_only_Test_clear__opt_case(oneof_active_only_Test* v);
+// Test2
+typedef struct {
+  char* name;
+  int name_len;
+  int32_t number;
+  oneof_active_only_Test2_KindCase kind_case;
+} oneof_active_only_Test2;
+
+int oneof_active_only_Test2_size();
+void oneof_active_only_Test2_init(oneof_active_only_Test2* v);
+void oneof_active_only_Test2_destroy(oneof_active_only_Test2*);
+void oneof_active_only_Test2_copy(const oneof_active_only_Test2* a, oneof_active_only_Test2* b);
+bool oneof_active_only_Test2_is_equal(const oneof_active_only_Test2* a, const oneof_active_only_Test2* b);
+int oneof_active_only_Test2_to_json_size(const oneof_active_only_Test2*);
+void oneof_active_only_Test2_to_json(const oneof_active_only_Test2*, char* json);
+int oneof_active_only_Test2_from_json(const char* json, oneof_active_only_Test2*);
+bool oneof_active_only_Test2_try_from_json(const char* json, oneof_active_only_Test2* v, jsonif_error* error);
+void oneof_active_only_Test2_set_name(oneof_active_only_Test2* v, const char* s);
+void oneof_active_only_Test2_set_number(oneof_active_only_Test2* v, int32_t m);
+
+void oneof_active_only_Test2_clear_name(oneof_active_only_Test2* v);
+void oneof_active_only_Test2_clear_number(oneof_active_only_Test2* v);
+void oneof_active_only_Test2_clear_kind_case(oneof_active_only_Test2* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
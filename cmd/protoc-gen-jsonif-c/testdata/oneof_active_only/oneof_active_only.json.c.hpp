@@ -0,0 +1,15 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ONEOF_ACTIVE_ONLY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_ONEOF_ACTIVE_ONLY_PROTO
+
+#include "oneof_active_only.json.h"
+#include "oneof_active_only.json.c.h"
+
+
+::oneof_active_only::Inner oneof_active_only_Inner_to_cpp(const oneof_active_only_Inner* v);
+void oneof_active_only_Inner_from_cpp(const ::oneof_active_only::Inner& u, oneof_active_only_Inner* v);
+::oneof_active_only::Test oneof_active_only_Test_to_cpp(const oneof_active_only_Test* v);
+void oneof_active_only_Test_from_cpp(const ::oneof_active_only::Test& u, oneof_active_only_Test* v);
+::oneof_active_only::Test2 oneof_active_only_Test2_to_cpp(const oneof_active_only_Test2* v);
+void oneof_active_only_Test2_from_cpp(const ::oneof_active_only::Test2& u, oneof_active_only_Test2* v);
+
+#endif
@@ -0,0 +1,286 @@
+#include "optional.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "optional.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "optional.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Enum
+const optional_Enum optional_FOO = 0;
+const optional_Enum optional_BAR = 1;
+
+::optional::Message optional_Message_to_cpp(const optional_Message* v) {
+  ::optional::Message u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  return u;
+}
+void optional_Message_from_cpp(const ::optional::Message& u, optional_Message* v) {
+  optional_Message_destroy(v);
+  memset(v, 0, sizeof(optional_Message));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+}
+// _a
+const optional_Test_ACase optional_Test_ACase_NOT_SET = 0;
+const optional_Test_ACase optional_Test_ACase_kA = 1;
+
+// _b
+const optional_Test_BCase optional_Test_BCase_NOT_SET = 0;
+const optional_Test_BCase optional_Test_BCase_kB = 3;
+
+// _c
+const optional_Test_CCase optional_Test_CCase_NOT_SET = 0;
+const optional_Test_CCase optional_Test_CCase_kC = 4;
+
+// _d
+const optional_Test_DCase optional_Test_DCase_NOT_SET = 0;
+const optional_Test_DCase optional_Test_DCase_kD = 5;
+
+::optional::Test optional_Test_to_cpp(const optional_Test* v) {
+  ::optional::Test u;
+  u.a = v->a;
+  if (v->b_len != 0) u.b = std::string(v->b, v->b_len);
+  u.c = (decltype(u.c))v->c;
+  u.d = optional_Message_to_cpp(&v->d);
+  u._a_case = (::optional::Test::ACase)v->_a_case;
+  u._b_case = (::optional::Test::BCase)v->_b_case;
+  u._c_case = (::optional::Test::CCase)v->_c_case;
+  u._d_case = (::optional::Test::DCase)v->_d_case;
+  return u;
+}
+void optional_Test_from_cpp(const ::optional::Test& u, optional_Test* v) {
+  optional_Test_destroy(v);
+  memset(v, 0, sizeof(optional_Test));
+  v->a = u.a;
+  if (!u.b.empty()) v->b = strdup(u.b.c_str());
+  v->b_len = (int)u.b.size();
+  v->c = (int)u.c;
+  optional_Message_from_cpp(u.d, &v->d);
+  v->_a_case = (int)u._a_case;
+  v->_b_case = (int)u._b_case;
+  v->_c_case = (int)u._c_case;
+  v->_d_case = (int)u._d_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_optional_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int optional_Message_size() {
+  return sizeof(optional_Message);
+}
+void optional_Message_init(optional_Message* v) {
+  memset(v, 0, sizeof(optional_Message));
+}
+void optional_Message_destroy(optional_Message* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+}
+void optional_Message_copy(const optional_Message* a, optional_Message* b) {
+  if (a == b) return;
+  ::optional::Message u = optional_Message_to_cpp(a);
+  optional_Message_from_cpp(u, b);
+}
+bool optional_Message_is_equal(const optional_Message* a, const optional_Message* b) {
+  if (a == b) return true;
+  ::optional::Message ua = optional_Message_to_cpp(a);
+  ::optional::Message ub = optional_Message_to_cpp(b);
+  return ua == ub;
+}
+int optional_Message_to_json_size(const optional_Message* v) {
+  ::optional::Message u = optional_Message_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void optional_Message_to_json(const optional_Message* v, char* json) {
+  ::optional::Message u = optional_Message_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int optional_Message_from_json(const char* json, optional_Message* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return optional_Message_try_from_json(json, v, error) ? 0 : -1;
+}
+bool optional_Message_try_from_json(const char* json, optional_Message* v, jsonif_error* error) {
+  try {
+    ::optional::Message u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    optional_Message_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void optional_Message_set_name(optional_Message* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+int optional_Test_size() {
+  return sizeof(optional_Test);
+}
+void optional_Test_init(optional_Test* v) {
+  memset(v, 0, sizeof(optional_Test));
+}
+void optional_Test_destroy(optional_Test* v) {
+  memset(&v->a, 0, sizeof(v->a));
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  memset(&v->c, 0, sizeof(v->c));
+  optional_Message_destroy(&v->d);
+}
+void optional_Test_copy(const optional_Test* a, optional_Test* b) {
+  if (a == b) return;
+  ::optional::Test u = optional_Test_to_cpp(a);
+  optional_Test_from_cpp(u, b);
+}
+bool optional_Test_is_equal(const optional_Test* a, const optional_Test* b) {
+  if (a == b) return true;
+  ::optional::Test ua = optional_Test_to_cpp(a);
+  ::optional::Test ub = optional_Test_to_cpp(b);
+  return ua == ub;
+}
+int optional_Test_to_json_size(const optional_Test* v) {
+  ::optional::Test u = optional_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void optional_Test_to_json(const optional_Test* v, char* json) {
+  ::optional::Test u = optional_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int optional_Test_from_json(const char* json, optional_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return optional_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool optional_Test_try_from_json(const char* json, optional_Test* v, jsonif_error* error) {
+  try {
+    ::optional::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    optional_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void optional_Test_set_a(optional_Test* v, int64_t m) {
+  optional_Test_clear__a_case(v);
+  v->_a_case = optional_Test_ACase_kA;
+  v->a = m;
+}
+void optional_Test_set_b(optional_Test* v, const char* s) {
+  optional_Test_clear__b_case(v);
+  v->_b_case = optional_Test_BCase_kB;
+  if (v->b) free(v->b);
+  v->b_len = s == nullptr ? 0 : strlen(s);
+  v->b = v->b_len == 0 ? nullptr : strdup(s);
+}
+void optional_Test_set_c(optional_Test* v, optional_Enum m) {
+  optional_Test_clear__c_case(v);
+  v->_c_case = optional_Test_CCase_kC;
+  v->c = m;
+}
+void optional_Test_set_d(optional_Test* v, const optional_Message* m) {
+  optional_Test_clear__d_case(v);
+  v->_d_case = optional_Test_DCase_kD;
+  optional_Message_copy(m, &v->d);
+}
+bool optional_Test_has_a(const optional_Test* v) {
+  return v->_a_case == optional_Test_ACase_kA;
+}
+void optional_Test_clear_a(optional_Test* v) {
+  if (v->_a_case == optional_Test_ACase_kA) {
+    optional_Test_clear__a_case(v);
+  }
+}
+bool optional_Test_has_b(const optional_Test* v) {
+  return v->_b_case == optional_Test_BCase_kB;
+}
+void optional_Test_clear_b(optional_Test* v) {
+  if (v->_b_case == optional_Test_BCase_kB) {
+    optional_Test_clear__b_case(v);
+  }
+}
+bool optional_Test_has_c(const optional_Test* v) {
+  return v->_c_case == optional_Test_CCase_kC;
+}
+void optional_Test_clear_c(optional_Test* v) {
+  if (v->_c_case == optional_Test_CCase_kC) {
+    optional_Test_clear__c_case(v);
+  }
+}
+bool optional_Test_has_d(const optional_Test* v) {
+  return v->_d_case == optional_Test_DCase_kD;
+}
+void optional_Test_clear_d(optional_Test* v) {
+  if (v->_d_case == optional_Test_DCase_kD) {
+    optional_Test_clear__d_case(v);
+  }
+}
+void optional_Test_clear__a_case(optional_Test* v) {
+  memset(&v->a, 0, sizeof(v->a));
+  v->_a_case = optional_Test_ACase_NOT_SET;
+}
+void optional_Test_clear__b_case(optional_Test* v) {
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  v->_b_case = optional_Test_BCase_NOT_SET;
+}
+void optional_Test_clear__c_case(optional_Test* v) {
+  memset(&v->c, 0, sizeof(v->c));
+  v->_c_case = optional_Test_CCase_NOT_SET;
+}
+void optional_Test_clear__d_case(optional_Test* v) {
+  optional_Message_destroy(&v->d);
+  v->_d_case = optional_Test_DCase_NOT_SET;
+}
+
+}
@@ -0,0 +1,141 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_OPTIONAL_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_OPTIONAL_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_optional_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_optional_proto();
+}
+
+#endif
+
+// Enum
+typedef int optional_Enum;
+extern const optional_Enum optional_FOO;
+extern const optional_Enum optional_BAR;
+
+// _a
+typedef int optional_Test_ACase;
+extern const optional_Test_ACase optional_Test_ACase_NOT_SET;
+extern const optional_Test_ACase optional_Test_ACase_kA;
+
+// _b
+typedef int optional_Test_BCase;
+extern const optional_Test_BCase optional_Test_BCase_NOT_SET;
+extern const optional_Test_BCase optional_Test_BCase_kB;
+
+// _c
+typedef int optional_Test_CCase;
+extern const optional_Test_CCase optional_Test_CCase_NOT_SET;
+extern const optional_Test_CCase optional_Test_CCase_kC;
+
+// _d
+typedef int optional_Test_DCase;
+extern const optional_Test_DCase optional_Test_DCase_NOT_SET;
+extern const optional_Test_DCase optional_Test_DCase_kD;
+
+// Message
+typedef struct {
+  char* name;
+  int name_len;
+} optional_Message;
+
+int optional_Message_size();
+void optional_Message_init(optional_Message* v);
+void optional_Message_destroy(optional_Message*);
+void optional_Message_copy(const optional_Message* a, optional_Message* b);
+bool optional_Message_is_equal(const optional_Message* a, const optional_Message* b);
+int optional_Message_to_json_size(const optional_Message*);
+void optional_Message_to_json(const optional_Message*, char* json);
+int optional_Message_from_json(const char* json, optional_Message*);
+bool optional_Message_try_from_json(const char* json, optional_Message* v, jsonif_error* error);
+void optional_Message_set_name(optional_Message* v, const char* s);
+
+// Test
+typedef struct {
+  int64_t a;
+  char* b;
+  int b_len;
+  optional_Enum c;
+  optional_Message d;
+  optional_Test_ACase _a_case;
+  optional_Test_BCase _b_case;
+  optional_Test_CCase _c_case;
+  optional_Test_DCase _d_case;
+} optional_Test;
+
+int optional_Test_size();
+void optional_Test_init(optional_Test* v);
+void optional_Test_destroy(optional_Test*);
+void optional_Test_copy(const optional_Test* a, optional_Test* b);
+bool optional_Test_is_equal(const optional_Test* a, const optional_Test* b);
+int optional_Test_to_json_size(const optional_Test*);
+void optional_Test_to_json(const optional_Test*, char* json);
+int optional_Test_from_json(const char* json, optional_Test*);
+bool optional_Test_try_from_json(const char* json, optional_Test* v, jsonif_error* error);
+void optional_Test_set_a(optional_Test* v, int64_t m);
+void optional_Test_set_b(optional_Test* v, const char* s);
+void optional_Test_set_c(optional_Test* v, optional_Enum m);
+void optional_Test_set_d(optional_Test* v, const optional_Message* m);
+
+bool optional_Test_has_a(const optional_Test* v);
+void optional_Test_clear_a(optional_Test* v);
+bool optional_Test_has_b(const optional_Test* v);
+void optional_Test_clear_b(optional_Test* v);
+bool optional_Test_has_c(const optional_Test* v);
+void optional_Test_clear_c(optional_Test* v);
+bool optional_Test_has_d(const optional_Test* v);
+void optional_Test_clear_d(optional_Test* v);
+void optional_Test_clear__a_case(optional_Test* v);
+void optional_Test_clear__b_case(optional_Test* v);
+void optional_Test_clear__c_case(optional_Test* v);
+void optional_Test_clear__d_case(optional_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
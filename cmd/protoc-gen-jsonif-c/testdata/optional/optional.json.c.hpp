@@ -0,0 +1,13 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_OPTIONAL_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_OPTIONAL_PROTO
+
+#include "optional.json.h"
+#include "optional.json.c.h"
+
+
+::optional::Message optional_Message_to_cpp(const optional_Message* v);
+void optional_Message_from_cpp(const ::optional::Message& u, optional_Message* v);
+::optional::Test optional_Test_to_cpp(const optional_Test* v);
+void optional_Test_from_cpp(const ::optional::Test& u, optional_Test* v);
+
+#endif
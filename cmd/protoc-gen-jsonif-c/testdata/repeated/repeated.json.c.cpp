@@ -0,0 +1,296 @@
+#include "repeated.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "repeated.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "repeated.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Enum
+const repeated_Enum repeated_FOO = 0;
+const repeated_Enum repeated_BAR = 1;
+
+::repeated::Message repeated_Message_to_cpp(const repeated_Message* v) {
+  ::repeated::Message u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  return u;
+}
+void repeated_Message_from_cpp(const ::repeated::Message& u, repeated_Message* v) {
+  repeated_Message_destroy(v);
+  memset(v, 0, sizeof(repeated_Message));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+}
+::repeated::Test repeated_Test_to_cpp(const repeated_Test* v) {
+  ::repeated::Test u;
+  for (int i = 0; i < v->a_len; i++) {
+    u.a.push_back(v->a[i]);
+  }
+  for (int i = 0; i < v->b_len; i++) {
+    if (v->b_lens[i] != 0) {
+      u.b.push_back(std::string(v->b[i], v->b_lens[i]));
+    } else {
+      u.b.push_back("");
+    }
+  }
+  for (int i = 0; i < v->c_len; i++) {
+    u.c.push_back((decltype(u.c[0]))v->c[i]);
+  }
+  for (int i = 0; i < v->d_len; i++) {
+    u.d.push_back(repeated_Message_to_cpp(&v->d[i]));
+  }
+  return u;
+}
+void repeated_Test_from_cpp(const ::repeated::Test& u, repeated_Test* v) {
+  repeated_Test_destroy(v);
+  memset(v, 0, sizeof(repeated_Test));
+  v->a_len = (int)u.a.size();
+  v->a = v->a_len == 0 ? nullptr : (decltype(v->a))malloc(sizeof(v->a[0]) * u.a.size());
+  for (int i = 0; i < (int)u.a.size(); i++) {
+    v->a[i] = u.a[i];
+  }
+  v->b_len = (int)u.b.size();
+  v->b = v->b_len == 0 ? nullptr : (decltype(v->b))malloc(sizeof(v->b[0]) * u.b.size());
+  v->b_lens = v->b_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.b.size());
+  for (int i = 0; i < (int)u.b.size(); i++) {
+    if (!u.b[i].empty()) v->b[i] = strdup(u.b[i].c_str());
+    v->b_lens[i] = (int)u.b[i].size();
+  }
+  v->c_len = (int)u.c.size();
+  v->c = v->c_len == 0 ? nullptr : (decltype(v->c))malloc(sizeof(v->c[0]) * u.c.size());
+  for (int i = 0; i < (int)u.c.size(); i++) {
+    v->c[i] = (int)u.c[i];
+  }
+  v->d_len = (int)u.d.size();
+  v->d = v->d_len == 0 ? nullptr : (decltype(v->d))malloc(sizeof(v->d[0]) * u.d.size());
+  for (int i = 0; i < (int)u.d.size(); i++) {
+    repeated_Message_init(&v->d[i]);
+    repeated_Message_from_cpp(u.d[i], &v->d[i]);
+  }
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_repeated_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int repeated_Message_size() {
+  return sizeof(repeated_Message);
+}
+void repeated_Message_init(repeated_Message* v) {
+  memset(v, 0, sizeof(repeated_Message));
+}
+void repeated_Message_destroy(repeated_Message* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+}
+void repeated_Message_copy(const repeated_Message* a, repeated_Message* b) {
+  if (a == b) return;
+  ::repeated::Message u = repeated_Message_to_cpp(a);
+  repeated_Message_from_cpp(u, b);
+}
+bool repeated_Message_is_equal(const repeated_Message* a, const repeated_Message* b) {
+  if (a == b) return true;
+  ::repeated::Message ua = repeated_Message_to_cpp(a);
+  ::repeated::Message ub = repeated_Message_to_cpp(b);
+  return ua == ub;
+}
+int repeated_Message_to_json_size(const repeated_Message* v) {
+  ::repeated::Message u = repeated_Message_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void repeated_Message_to_json(const repeated_Message* v, char* json) {
+  ::repeated::Message u = repeated_Message_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int repeated_Message_from_json(const char* json, repeated_Message* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return repeated_Message_try_from_json(json, v, error) ? 0 : -1;
+}
+bool repeated_Message_try_from_json(const char* json, repeated_Message* v, jsonif_error* error) {
+  try {
+    ::repeated::Message u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    repeated_Message_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void repeated_Message_set_name(repeated_Message* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+int repeated_Test_size() {
+  return sizeof(repeated_Test);
+}
+void repeated_Test_init(repeated_Test* v) {
+  memset(v, 0, sizeof(repeated_Test));
+}
+void repeated_Test_destroy(repeated_Test* v) {
+  if (v->a) free(v->a);
+  v->a = nullptr;
+  v->a_len = 0;
+  for (int i = 0; i < v->b_len; i++) {
+    if (v->b[i]) free(v->b[i]);
+    v->b[i] = nullptr;
+    v->b_lens[i] = 0;
+  }
+  if (v->b_lens) free(v->b_lens);
+  v->b_lens = nullptr;
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  if (v->c) free(v->c);
+  v->c = nullptr;
+  v->c_len = 0;
+  for (int i = 0; i < v->d_len; i++) {
+    repeated_Message_destroy(&v->d[i]);
+  }
+  if (v->d) free(v->d);
+  v->d = nullptr;
+  v->d_len = 0;
+}
+void repeated_Test_copy(const repeated_Test* a, repeated_Test* b) {
+  if (a == b) return;
+  ::repeated::Test u = repeated_Test_to_cpp(a);
+  repeated_Test_from_cpp(u, b);
+}
+bool repeated_Test_is_equal(const repeated_Test* a, const repeated_Test* b) {
+  if (a == b) return true;
+  ::repeated::Test ua = repeated_Test_to_cpp(a);
+  ::repeated::Test ub = repeated_Test_to_cpp(b);
+  return ua == ub;
+}
+int repeated_Test_to_json_size(const repeated_Test* v) {
+  ::repeated::Test u = repeated_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void repeated_Test_to_json(const repeated_Test* v, char* json) {
+  ::repeated::Test u = repeated_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int repeated_Test_from_json(const char* json, repeated_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return repeated_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool repeated_Test_try_from_json(const char* json, repeated_Test* v, jsonif_error* error) {
+  try {
+    ::repeated::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    repeated_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void repeated_Test_alloc_a(repeated_Test* v, int num) {
+  if (v->a) free(v->a);
+  v->a = nullptr;
+  v->a_len = 0;
+  if (num != 0) {
+    v->a = (decltype(v->a))malloc(sizeof(v->a[0]) * num);
+    memset(v->a, 0, sizeof(v->a[0]) * num);
+    v->a_len = num;
+  }
+}
+
+void repeated_Test_set_a(repeated_Test* v, int n, int32_t m) {
+  v->a[n] = m;
+}
+void repeated_Test_alloc_b(repeated_Test* v, int num) {
+  if (v->b) free(v->b);
+  v->b = nullptr;
+  v->b_len = 0;
+  if (num != 0) {
+    v->b = (decltype(v->b))malloc(sizeof(v->b[0]) * num);
+    memset(v->b, 0, sizeof(v->b[0]) * num);
+    v->b_len = num;
+    v->b_lens = (decltype(v->b_lens))malloc(sizeof(v->b_lens[0]) * num);
+    memset(v->b_lens, 0, sizeof(v->b_lens[0]) * num);
+  }
+}
+
+void repeated_Test_set_b(repeated_Test* v, int n, const char* s) {
+  if (v->b[n]) free(v->b[n]);
+  v->b_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->b[n] = v->b_lens[n] == 0 ? nullptr : strdup(s);
+}
+void repeated_Test_alloc_c(repeated_Test* v, int num) {
+  if (v->c) free(v->c);
+  v->c = nullptr;
+  v->c_len = 0;
+  if (num != 0) {
+    v->c = (decltype(v->c))malloc(sizeof(v->c[0]) * num);
+    memset(v->c, 0, sizeof(v->c[0]) * num);
+    v->c_len = num;
+  }
+}
+
+void repeated_Test_set_c(repeated_Test* v, int n, repeated_Enum m) {
+  v->c[n] = m;
+}
+void repeated_Test_alloc_d(repeated_Test* v, int num) {
+  if (v->d) free(v->d);
+  v->d = nullptr;
+  v->d_len = 0;
+  if (num != 0) {
+    v->d = (decltype(v->d))malloc(sizeof(v->d[0]) * num);
+    memset(v->d, 0, sizeof(v->d[0]) * num);
+    v->d_len = num;
+  }
+}
+
+void repeated_Test_set_d(repeated_Test* v, int n, const repeated_Message* m) {
+  repeated_Message_copy(m, &v->d[n]);
+}
+
+}
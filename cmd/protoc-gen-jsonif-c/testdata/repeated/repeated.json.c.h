@@ -0,0 +1,113 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_REPEATED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_REPEATED_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_repeated_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_repeated_proto();
+}
+
+#endif
+
+// Enum
+typedef int repeated_Enum;
+extern const repeated_Enum repeated_FOO;
+extern const repeated_Enum repeated_BAR;
+
+// Message
+typedef struct {
+  char* name;
+  int name_len;
+} repeated_Message;
+
+int repeated_Message_size();
+void repeated_Message_init(repeated_Message* v);
+void repeated_Message_destroy(repeated_Message*);
+void repeated_Message_copy(const repeated_Message* a, repeated_Message* b);
+bool repeated_Message_is_equal(const repeated_Message* a, const repeated_Message* b);
+int repeated_Message_to_json_size(const repeated_Message*);
+void repeated_Message_to_json(const repeated_Message*, char* json);
+int repeated_Message_from_json(const char* json, repeated_Message*);
+bool repeated_Message_try_from_json(const char* json, repeated_Message* v, jsonif_error* error);
+void repeated_Message_set_name(repeated_Message* v, const char* s);
+
+// Test
+typedef struct {
+  int32_t* a;
+  int a_len;
+  char** b;
+  int* b_lens;
+  int b_len;
+  repeated_Enum* c;
+  int c_len;
+  repeated_Message* d;
+  int d_len;
+} repeated_Test;
+
+int repeated_Test_size();
+void repeated_Test_init(repeated_Test* v);
+void repeated_Test_destroy(repeated_Test*);
+void repeated_Test_copy(const repeated_Test* a, repeated_Test* b);
+bool repeated_Test_is_equal(const repeated_Test* a, const repeated_Test* b);
+int repeated_Test_to_json_size(const repeated_Test*);
+void repeated_Test_to_json(const repeated_Test*, char* json);
+int repeated_Test_from_json(const char* json, repeated_Test*);
+bool repeated_Test_try_from_json(const char* json, repeated_Test* v, jsonif_error* error);
+void repeated_Test_alloc_a(repeated_Test* v, int num);
+void repeated_Test_set_a(repeated_Test* v, int n, int32_t m);
+void repeated_Test_alloc_b(repeated_Test* v, int num);
+void repeated_Test_set_b(repeated_Test* v, int n, const char* s);
+void repeated_Test_alloc_c(repeated_Test* v, int num);
+void repeated_Test_set_c(repeated_Test* v, int n, repeated_Enum m);
+void repeated_Test_alloc_d(repeated_Test* v, int num);
+void repeated_Test_set_d(repeated_Test* v, int n, const repeated_Message* m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
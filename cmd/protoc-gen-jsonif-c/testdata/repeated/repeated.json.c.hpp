@@ -0,0 +1,13 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_REPEATED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_REPEATED_PROTO
+
+#include "repeated.json.h"
+#include "repeated.json.c.h"
+
+
+::repeated::Message repeated_Message_to_cpp(const repeated_Message* v);
+void repeated_Message_from_cpp(const ::repeated::Message& u, repeated_Message* v);
+::repeated::Test repeated_Test_to_cpp(const repeated_Test* v);
+void repeated_Test_from_cpp(const ::repeated::Test& u, repeated_Test* v);
+
+#endif
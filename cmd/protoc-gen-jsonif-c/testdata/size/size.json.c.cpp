@@ -0,0 +1,106 @@
+#include "size.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "size.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "size.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::size::Test size_Test_to_cpp(const size_Test* v) {
+  ::size::Test u;
+  u.v = v->v;
+  return u;
+}
+void size_Test_from_cpp(const ::size::Test& u, size_Test* v) {
+  size_Test_destroy(v);
+  memset(v, 0, sizeof(size_Test));
+  v->v = u.v;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_size_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int size_Test_size() {
+  return sizeof(size_Test);
+}
+void size_Test_init(size_Test* v) {
+  memset(v, 0, sizeof(size_Test));
+}
+void size_Test_destroy(size_Test* v) {
+  memset(&v->v, 0, sizeof(v->v));
+}
+void size_Test_copy(const size_Test* a, size_Test* b) {
+  if (a == b) return;
+  ::size::Test u = size_Test_to_cpp(a);
+  size_Test_from_cpp(u, b);
+}
+bool size_Test_is_equal(const size_Test* a, const size_Test* b) {
+  if (a == b) return true;
+  ::size::Test ua = size_Test_to_cpp(a);
+  ::size::Test ub = size_Test_to_cpp(b);
+  return ua == ub;
+}
+int size_Test_to_json_size(const size_Test* v) {
+  ::size::Test u = size_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void size_Test_to_json(const size_Test* v, char* json) {
+  ::size::Test u = size_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int size_Test_from_json(const char* json, size_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return size_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool size_Test_try_from_json(const char* json, size_Test* v, jsonif_error* error) {
+  try {
+    ::size::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    size_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void size_Test_set_v(size_Test* v, int64_t m) {
+  v->v = m;
+}
+
+}
@@ -0,0 +1,76 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_SIZE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_SIZE_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_size_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_size_proto();
+}
+
+#endif
+
+// Test
+typedef struct {
+  int64_t v;
+} size_Test;
+
+int size_Test_size();
+void size_Test_init(size_Test* v);
+void size_Test_destroy(size_Test*);
+void size_Test_copy(const size_Test* a, size_Test* b);
+bool size_Test_is_equal(const size_Test* a, const size_Test* b);
+int size_Test_to_json_size(const size_Test*);
+void size_Test_to_json(const size_Test*, char* json);
+int size_Test_from_json(const char* json, size_Test*);
+bool size_Test_try_from_json(const char* json, size_Test* v, jsonif_error* error);
+void size_Test_set_v(size_Test* v, int64_t m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,11 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_SIZE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_SIZE_PROTO
+
+#include "size.json.h"
+#include "size.json.c.h"
+
+
+::size::Test size_Test_to_cpp(const size_Test* v);
+void size_Test_from_cpp(const ::size::Test& u, size_Test* v);
+
+#endif
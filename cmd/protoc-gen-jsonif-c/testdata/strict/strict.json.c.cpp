@@ -0,0 +1,599 @@
+#include "strict.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "strict.json.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "strict.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Color
+const strict_Color strict_RED = 0;
+const strict_Color strict_GREEN = 1;
+
+::strict::Person strict_Person_to_cpp(const strict_Person* v) {
+  ::strict::Person u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.age = v->age;
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags_lens[i] != 0) {
+      u.tags.push_back(std::string(v->tags[i], v->tags_lens[i]));
+    } else {
+      u.tags.push_back("");
+    }
+  }
+  u.color = (decltype(u.color))v->color;
+  return u;
+}
+void strict_Person_from_cpp(const ::strict::Person& u, strict_Person* v) {
+  strict_Person_destroy(v);
+  memset(v, 0, sizeof(strict_Person));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  v->age = u.age;
+  v->tags_len = (int)u.tags.size();
+  v->tags = v->tags_len == 0 ? nullptr : (decltype(v->tags))malloc(sizeof(v->tags[0]) * u.tags.size());
+  v->tags_lens = v->tags_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.tags.size());
+  for (int i = 0; i < (int)u.tags.size(); i++) {
+    if (!u.tags[i].empty()) v->tags[i] = strdup(u.tags[i].c_str());
+    v->tags_lens[i] = (int)u.tags[i].size();
+  }
+  v->color = (int)u.color;
+}
+::strict::Address strict_Address_to_cpp(const strict_Address* v) {
+  ::strict::Address u;
+  if (v->city_len != 0) u.city = std::string(v->city, v->city_len);
+  return u;
+}
+void strict_Address_from_cpp(const ::strict::Address& u, strict_Address* v) {
+  strict_Address_destroy(v);
+  memset(v, 0, sizeof(strict_Address));
+  if (!u.city.empty()) v->city = strdup(u.city.c_str());
+  v->city_len = (int)u.city.size();
+}
+// kind
+const strict_Config_KindCase strict_Config_KindCase_NOT_SET = 0;
+const strict_Config_KindCase strict_Config_KindCase_kNumber = 5;
+const strict_Config_KindCase strict_Config_KindCase_kText = 6;
+
+// _opt
+const strict_Config_OptCase strict_Config_OptCase_NOT_SET = 0;
+const strict_Config_OptCase strict_Config_OptCase_kOpt = 7;
+
+::strict::Config strict_Config_to_cpp(const strict_Config* v) {
+  ::strict::Config u;
+  for (int i = 0; i < v->people_len; i++) {
+    u.people.push_back(strict_Person_to_cpp(&v->people[i]));
+  }
+  for (int i = 0; i < v->by_name_len; i++) {
+    decltype(u.by_name)::key_type key{};
+    decltype(u.by_name)::mapped_type value{};
+    if (v->by_name[i].key_len != 0) key = std::string(v->by_name[i].key, v->by_name[i].key_len);
+    value = strict_Person_to_cpp(&v->by_name[i].value);
+    u.by_name.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->labels_len; i++) {
+    decltype(u.labels)::key_type key{};
+    decltype(u.labels)::mapped_type value{};
+    key = v->labels[i].key;
+    if (v->labels[i].value_len != 0) value = std::string(v->labels[i].value, v->labels[i].value_len);
+    u.labels.emplace(std::move(key), std::move(value));
+  }
+  u.address = strict_Address_to_cpp(&v->address);
+  u.number = v->number;
+  if (v->text_len != 0) u.text = std::string(v->text, v->text_len);
+  u.opt = v->opt;
+  u.big = v->big;
+  u.limit = google_protobuf_Int32Value_to_cpp(&v->limit);
+  u.kind_case = (::strict::Config::KindCase)v->kind_case;
+  u._opt_case = (::strict::Config::OptCase)v->_opt_case;
+  return u;
+}
+void strict_Config_from_cpp(const ::strict::Config& u, strict_Config* v) {
+  strict_Config_destroy(v);
+  memset(v, 0, sizeof(strict_Config));
+  v->people_len = (int)u.people.size();
+  v->people = v->people_len == 0 ? nullptr : (decltype(v->people))malloc(sizeof(v->people[0]) * u.people.size());
+  for (int i = 0; i < (int)u.people.size(); i++) {
+    strict_Person_init(&v->people[i]);
+    strict_Person_from_cpp(u.people[i], &v->people[i]);
+  }
+  v->by_name_len = (int)u.by_name.size();
+  v->by_name = v->by_name_len == 0 ? nullptr : (decltype(v->by_name))malloc(sizeof(v->by_name[0]) * u.by_name.size());
+  int by_name_index = 0;
+  for (const auto& kv : u.by_name) {
+    strict_Config_ByNameEntry_init(&v->by_name[by_name_index]);
+    if (!kv.first.empty()) v->by_name[by_name_index].key = strdup(kv.first.c_str());
+    v->by_name[by_name_index].key_len = (int)kv.first.size();
+    strict_Person_from_cpp(kv.second, &v->by_name[by_name_index].value);
+    by_name_index++;
+  }
+  v->labels_len = (int)u.labels.size();
+  v->labels = v->labels_len == 0 ? nullptr : (decltype(v->labels))malloc(sizeof(v->labels[0]) * u.labels.size());
+  int labels_index = 0;
+  for (const auto& kv : u.labels) {
+    strict_Config_LabelsEntry_init(&v->labels[labels_index]);
+    v->labels[labels_index].key = kv.first;
+    if (!kv.second.empty()) v->labels[labels_index].value = strdup(kv.second.c_str());
+    v->labels[labels_index].value_len = (int)kv.second.size();
+    labels_index++;
+  }
+  strict_Address_from_cpp(u.address, &v->address);
+  v->number = u.number;
+  if (!u.text.empty()) v->text = strdup(u.text.c_str());
+  v->text_len = (int)u.text.size();
+  v->opt = u.opt;
+  v->big = u.big;
+  google_protobuf_Int32Value_from_cpp(u.limit, &v->limit);
+  v->kind_case = (int)u.kind_case;
+  v->_opt_case = (int)u._opt_case;
+}
+::strict::Loose strict_Loose_to_cpp(const strict_Loose* v) {
+  ::strict::Loose u;
+  u.id = v->id;
+  u.person = strict_Person_to_cpp(&v->person);
+  return u;
+}
+void strict_Loose_from_cpp(const ::strict::Loose& u, strict_Loose* v) {
+  strict_Loose_destroy(v);
+  memset(v, 0, sizeof(strict_Loose));
+  v->id = u.id;
+  strict_Person_from_cpp(u.person, &v->person);
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_strict_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int strict_Person_size() {
+  return sizeof(strict_Person);
+}
+void strict_Person_init(strict_Person* v) {
+  memset(v, 0, sizeof(strict_Person));
+}
+void strict_Person_destroy(strict_Person* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->age, 0, sizeof(v->age));
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags[i]) free(v->tags[i]);
+    v->tags[i] = nullptr;
+    v->tags_lens[i] = 0;
+  }
+  if (v->tags_lens) free(v->tags_lens);
+  v->tags_lens = nullptr;
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  memset(&v->color, 0, sizeof(v->color));
+}
+void strict_Person_copy(const strict_Person* a, strict_Person* b) {
+  if (a == b) return;
+  ::strict::Person u = strict_Person_to_cpp(a);
+  strict_Person_from_cpp(u, b);
+}
+bool strict_Person_is_equal(const strict_Person* a, const strict_Person* b) {
+  if (a == b) return true;
+  ::strict::Person ua = strict_Person_to_cpp(a);
+  ::strict::Person ub = strict_Person_to_cpp(b);
+  return ua == ub;
+}
+int strict_Person_to_json_size(const strict_Person* v) {
+  ::strict::Person u = strict_Person_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void strict_Person_to_json(const strict_Person* v, char* json) {
+  ::strict::Person u = strict_Person_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int strict_Person_from_json(const char* json, strict_Person* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return strict_Person_try_from_json(json, v, error) ? 0 : -1;
+}
+bool strict_Person_try_from_json(const char* json, strict_Person* v, jsonif_error* error) {
+  try {
+    ::strict::Person u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    strict_Person_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void strict_Person_set_name(strict_Person* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void strict_Person_set_age(strict_Person* v, int32_t m) {
+  v->age = m;
+}
+void strict_Person_alloc_tags(strict_Person* v, int num) {
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  if (num != 0) {
+    v->tags = (decltype(v->tags))malloc(sizeof(v->tags[0]) * num);
+    memset(v->tags, 0, sizeof(v->tags[0]) * num);
+    v->tags_len = num;
+    v->tags_lens = (decltype(v->tags_lens))malloc(sizeof(v->tags_lens[0]) * num);
+    memset(v->tags_lens, 0, sizeof(v->tags_lens[0]) * num);
+  }
+}
+
+void strict_Person_set_tags(strict_Person* v, int n, const char* s) {
+  if (v->tags[n]) free(v->tags[n]);
+  v->tags_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->tags[n] = v->tags_lens[n] == 0 ? nullptr : strdup(s);
+}
+void strict_Person_set_color(strict_Person* v, strict_Color m) {
+  v->color = m;
+}
+int strict_Address_size() {
+  return sizeof(strict_Address);
+}
+void strict_Address_init(strict_Address* v) {
+  memset(v, 0, sizeof(strict_Address));
+}
+void strict_Address_destroy(strict_Address* v) {
+  if (v->city) free(v->city);
+  v->city = nullptr;
+  v->city_len = 0;
+}
+void strict_Address_copy(const strict_Address* a, strict_Address* b) {
+  if (a == b) return;
+  ::strict::Address u = strict_Address_to_cpp(a);
+  strict_Address_from_cpp(u, b);
+}
+bool strict_Address_is_equal(const strict_Address* a, const strict_Address* b) {
+  if (a == b) return true;
+  ::strict::Address ua = strict_Address_to_cpp(a);
+  ::strict::Address ub = strict_Address_to_cpp(b);
+  return ua == ub;
+}
+int strict_Address_to_json_size(const strict_Address* v) {
+  ::strict::Address u = strict_Address_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void strict_Address_to_json(const strict_Address* v, char* json) {
+  ::strict::Address u = strict_Address_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int strict_Address_from_json(const char* json, strict_Address* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return strict_Address_try_from_json(json, v, error) ? 0 : -1;
+}
+bool strict_Address_try_from_json(const char* json, strict_Address* v, jsonif_error* error) {
+  try {
+    ::strict::Address u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    strict_Address_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void strict_Address_set_city(strict_Address* v, const char* s) {
+  if (v->city) free(v->city);
+  v->city_len = s == nullptr ? 0 : strlen(s);
+  v->city = v->city_len == 0 ? nullptr : strdup(s);
+}
+int strict_Config_ByNameEntry_size() {
+  return sizeof(strict_Config_ByNameEntry);
+}
+void strict_Config_ByNameEntry_init(strict_Config_ByNameEntry* v) {
+  memset(v, 0, sizeof(strict_Config_ByNameEntry));
+}
+void strict_Config_ByNameEntry_destroy(strict_Config_ByNameEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  strict_Person_destroy(&v->value);
+}
+void strict_Config_ByNameEntry_set_key(strict_Config_ByNameEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void strict_Config_ByNameEntry_set_value(strict_Config_ByNameEntry* v, const strict_Person* m) {
+  strict_Person_copy(m, &v->value);
+}
+int strict_Config_LabelsEntry_size() {
+  return sizeof(strict_Config_LabelsEntry);
+}
+void strict_Config_LabelsEntry_init(strict_Config_LabelsEntry* v) {
+  memset(v, 0, sizeof(strict_Config_LabelsEntry));
+}
+void strict_Config_LabelsEntry_destroy(strict_Config_LabelsEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  if (v->value) free(v->value);
+  v->value = nullptr;
+  v->value_len = 0;
+}
+void strict_Config_LabelsEntry_set_key(strict_Config_LabelsEntry* v, int32_t m) {
+  v->key = m;
+}
+void strict_Config_LabelsEntry_set_value(strict_Config_LabelsEntry* v, const char* s) {
+  if (v->value) free(v->value);
+  v->value_len = s == nullptr ? 0 : strlen(s);
+  v->value = v->value_len == 0 ? nullptr : strdup(s);
+}
+int strict_Config_size() {
+  return sizeof(strict_Config);
+}
+void strict_Config_init(strict_Config* v) {
+  memset(v, 0, sizeof(strict_Config));
+}
+void strict_Config_destroy(strict_Config* v) {
+  for (int i = 0; i < v->people_len; i++) {
+    strict_Person_destroy(&v->people[i]);
+  }
+  if (v->people) free(v->people);
+  v->people = nullptr;
+  v->people_len = 0;
+  for (int i = 0; i < v->by_name_len; i++) {
+    strict_Config_ByNameEntry_destroy(&v->by_name[i]);
+  }
+  if (v->by_name) free(v->by_name);
+  v->by_name = nullptr;
+  v->by_name_len = 0;
+  for (int i = 0; i < v->labels_len; i++) {
+    strict_Config_LabelsEntry_destroy(&v->labels[i]);
+  }
+  if (v->labels) free(v->labels);
+  v->labels = nullptr;
+  v->labels_len = 0;
+  strict_Address_destroy(&v->address);
+  memset(&v->number, 0, sizeof(v->number));
+  if (v->text) free(v->text);
+  v->text = nullptr;
+  v->text_len = 0;
+  memset(&v->opt, 0, sizeof(v->opt));
+  memset(&v->big, 0, sizeof(v->big));
+  google_protobuf_Int32Value_destroy(&v->limit);
+}
+void strict_Config_copy(const strict_Config* a, strict_Config* b) {
+  if (a == b) return;
+  ::strict::Config u = strict_Config_to_cpp(a);
+  strict_Config_from_cpp(u, b);
+}
+bool strict_Config_is_equal(const strict_Config* a, const strict_Config* b) {
+  if (a == b) return true;
+  ::strict::Config ua = strict_Config_to_cpp(a);
+  ::strict::Config ub = strict_Config_to_cpp(b);
+  return ua == ub;
+}
+int strict_Config_to_json_size(const strict_Config* v) {
+  ::strict::Config u = strict_Config_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void strict_Config_to_json(const strict_Config* v, char* json) {
+  ::strict::Config u = strict_Config_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int strict_Config_from_json(const char* json, strict_Config* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return strict_Config_try_from_json(json, v, error) ? 0 : -1;
+}
+bool strict_Config_try_from_json(const char* json, strict_Config* v, jsonif_error* error) {
+  try {
+    ::strict::Config u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    strict_Config_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void strict_Config_alloc_people(strict_Config* v, int num) {
+  if (v->people) free(v->people);
+  v->people = nullptr;
+  v->people_len = 0;
+  if (num != 0) {
+    v->people = (decltype(v->people))malloc(sizeof(v->people[0]) * num);
+    memset(v->people, 0, sizeof(v->people[0]) * num);
+    v->people_len = num;
+  }
+}
+
+void strict_Config_set_people(strict_Config* v, int n, const strict_Person* m) {
+  strict_Person_copy(m, &v->people[n]);
+}
+void strict_Config_alloc_by_name(strict_Config* v, int num) {
+  if (v->by_name) free(v->by_name);
+  v->by_name = nullptr;
+  v->by_name_len = 0;
+  if (num != 0) {
+    v->by_name = (decltype(v->by_name))malloc(sizeof(v->by_name[0]) * num);
+    memset(v->by_name, 0, sizeof(v->by_name[0]) * num);
+    v->by_name_len = num;
+  }
+}
+
+void strict_Config_alloc_labels(strict_Config* v, int num) {
+  if (v->labels) free(v->labels);
+  v->labels = nullptr;
+  v->labels_len = 0;
+  if (num != 0) {
+    v->labels = (decltype(v->labels))malloc(sizeof(v->labels[0]) * num);
+    memset(v->labels, 0, sizeof(v->labels[0]) * num);
+    v->labels_len = num;
+  }
+}
+
+void strict_Config_set_address(strict_Config* v, const strict_Address* m) {
+  strict_Address_copy(m, &v->address);
+}
+void strict_Config_set_number(strict_Config* v, int32_t m) {
+  strict_Config_clear_kind_case(v);
+  v->kind_case = strict_Config_KindCase_kNumber;
+  v->number = m;
+}
+void strict_Config_set_text(strict_Config* v, const char* s) {
+  strict_Config_clear_kind_case(v);
+  v->kind_case = strict_Config_KindCase_kText;
+  if (v->text) free(v->text);
+  v->text_len = s == nullptr ? 0 : strlen(s);
+  v->text = v->text_len == 0 ? nullptr : strdup(s);
+}
+void strict_Config_set_opt(strict_Config* v, uint32_t m) {
+  strict_Config_clear__opt_case(v);
+  v->_opt_case = strict_Config_OptCase_kOpt;
+  v->opt = m;
+}
+void strict_Config_set_big(strict_Config* v, int64_t m) {
+  v->big = m;
+}
+void strict_Config_set_limit(strict_Config* v, const google_protobuf_Int32Value* m) {
+  google_protobuf_Int32Value_copy(m, &v->limit);
+}
+void strict_Config_clear_number(strict_Config* v) {
+  if (v->kind_case == strict_Config_KindCase_kNumber) {
+    strict_Config_clear_kind_case(v);
+  }
+}
+void strict_Config_clear_text(strict_Config* v) {
+  if (v->kind_case == strict_Config_KindCase_kText) {
+    strict_Config_clear_kind_case(v);
+  }
+}
+bool strict_Config_has_opt(const strict_Config* v) {
+  return v->_opt_case == strict_Config_OptCase_kOpt;
+}
+void strict_Config_clear_opt(strict_Config* v) {
+  if (v->_opt_case == strict_Config_OptCase_kOpt) {
+    strict_Config_clear__opt_case(v);
+  }
+}
+void strict_Config_clear_kind_case(strict_Config* v) {
+  memset(&v->number, 0, sizeof(v->number));
+  if (v->text) free(v->text);
+  v->text = nullptr;
+  v->text_len = 0;
+  v->kind_case = strict_Config_KindCase_NOT_SET;
+}
+void strict_Config_clear__opt_case(strict_Config* v) {
+  memset(&v->opt, 0, sizeof(v->opt));
+  v->_opt_case = strict_Config_OptCase_NOT_SET;
+}
+int strict_Loose_size() {
+  return sizeof(strict_Loose);
+}
+void strict_Loose_init(strict_Loose* v) {
+  memset(v, 0, sizeof(strict_Loose));
+}
+void strict_Loose_destroy(strict_Loose* v) {
+  memset(&v->id, 0, sizeof(v->id));
+  strict_Person_destroy(&v->person);
+}
+void strict_Loose_copy(const strict_Loose* a, strict_Loose* b) {
+  if (a == b) return;
+  ::strict::Loose u = strict_Loose_to_cpp(a);
+  strict_Loose_from_cpp(u, b);
+}
+bool strict_Loose_is_equal(const strict_Loose* a, const strict_Loose* b) {
+  if (a == b) return true;
+  ::strict::Loose ua = strict_Loose_to_cpp(a);
+  ::strict::Loose ub = strict_Loose_to_cpp(b);
+  return ua == ub;
+}
+int strict_Loose_to_json_size(const strict_Loose* v) {
+  ::strict::Loose u = strict_Loose_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void strict_Loose_to_json(const strict_Loose* v, char* json) {
+  ::strict::Loose u = strict_Loose_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int strict_Loose_from_json(const char* json, strict_Loose* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return strict_Loose_try_from_json(json, v, error) ? 0 : -1;
+}
+bool strict_Loose_try_from_json(const char* json, strict_Loose* v, jsonif_error* error) {
+  try {
+    ::strict::Loose u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    strict_Loose_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void strict_Loose_set_id(strict_Loose* v, int32_t m) {
+  v->id = m;
+}
+void strict_Loose_set_person(strict_Loose* v, const strict_Person* m) {
+  strict_Person_copy(m, &v->person);
+}
+
+}
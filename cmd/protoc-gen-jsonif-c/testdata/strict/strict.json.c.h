@@ -0,0 +1,212 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_STRICT_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_STRICT_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#include "google/protobuf/wrappers.json.c.h"
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_strict_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_strict_proto();
+}
+
+#endif
+
+// Color
+typedef int strict_Color;
+extern const strict_Color strict_RED;
+extern const strict_Color strict_GREEN;
+
+// kind
+typedef int strict_Config_KindCase;
+extern const strict_Config_KindCase strict_Config_KindCase_NOT_SET;
+extern const strict_Config_KindCase strict_Config_KindCase_kNumber;
+extern const strict_Config_KindCase strict_Config_KindCase_kText;
+
+// _opt
+typedef int strict_Config_OptCase;
+extern const strict_Config_OptCase strict_Config_OptCase_NOT_SET;
+extern const strict_Config_OptCase strict_Config_OptCase_kOpt;
+
+// Person
+typedef struct {
+  char* name;
+  int name_len;
+  int32_t age;
+  char** tags;
+  int* tags_lens;
+  int tags_len;
+  strict_Color color;
+} strict_Person;
+
+int strict_Person_size();
+void strict_Person_init(strict_Person* v);
+void strict_Person_destroy(strict_Person*);
+void strict_Person_copy(const strict_Person* a, strict_Person* b);
+bool strict_Person_is_equal(const strict_Person* a, const strict_Person* b);
+int strict_Person_to_json_size(const strict_Person*);
+void strict_Person_to_json(const strict_Person*, char* json);
+int strict_Person_from_json(const char* json, strict_Person*);
+bool strict_Person_try_from_json(const char* json, strict_Person* v, jsonif_error* error);
+void strict_Person_set_name(strict_Person* v, const char* s);
+void strict_Person_set_age(strict_Person* v, int32_t m);
+void strict_Person_alloc_tags(strict_Person* v, int num);
+void strict_Person_set_tags(strict_Person* v, int n, const char* s);
+void strict_Person_set_color(strict_Person* v, strict_Color m);
+
+// Address
+/// strict ではないので知らないキーは無視するが、型は調べる
+typedef struct {
+  char* city;
+  int city_len;
+} strict_Address;
+
+int strict_Address_size();
+void strict_Address_init(strict_Address* v);
+void strict_Address_destroy(strict_Address*);
+void strict_Address_copy(const strict_Address* a, strict_Address* b);
+bool strict_Address_is_equal(const strict_Address* a, const strict_Address* b);
+int strict_Address_to_json_size(const strict_Address*);
+void strict_Address_to_json(const strict_Address*, char* json);
+int strict_Address_from_json(const char* json, strict_Address*);
+bool strict_Address_try_from_json(const char* json, strict_Address* v, jsonif_error* error);
+void strict_Address_set_city(strict_Address* v, const char* s);
+
+// ByNameEntry
+typedef struct {
+  char* key;
+  int key_len;
+  strict_Person value;
+} strict_Config_ByNameEntry;
+
+int strict_Config_ByNameEntry_size();
+void strict_Config_ByNameEntry_init(strict_Config_ByNameEntry* v);
+void strict_Config_ByNameEntry_destroy(strict_Config_ByNameEntry*);
+void strict_Config_ByNameEntry_set_key(strict_Config_ByNameEntry* v, const char* s);
+void strict_Config_ByNameEntry_set_value(strict_Config_ByNameEntry* v, const strict_Person* m);
+
+// LabelsEntry
+typedef struct {
+  int32_t key;
+  char* value;
+  int value_len;
+} strict_Config_LabelsEntry;
+
+int strict_Config_LabelsEntry_size();
+void strict_Config_LabelsEntry_init(strict_Config_LabelsEntry* v);
+void strict_Config_LabelsEntry_destroy(strict_Config_LabelsEntry*);
+void strict_Config_LabelsEntry_set_key(strict_Config_LabelsEntry* v, int32_t m);
+void strict_Config_LabelsEntry_set_value(strict_Config_LabelsEntry* v, const char* s);
+
+// Config
+/// 知らないキーや型の違う値をエラーにする
+typedef struct {
+  strict_Person* people;
+  int people_len;
+  strict_Config_ByNameEntry* by_name;
+  int by_name_len;
+  strict_Config_LabelsEntry* labels;
+  int labels_len;
+  strict_Address address;
+  int32_t number;
+  char* text;
+  int text_len;
+  uint32_t opt;
+  int64_t big;
+  google_protobuf_Int32Value limit;
+  strict_Config_KindCase kind_case;
+  strict_Config_OptCase _opt_case;
+} strict_Config;
+
+int strict_Config_size();
+void strict_Config_init(strict_Config* v);
+void strict_Config_destroy(strict_Config*);
+void strict_Config_copy(const strict_Config* a, strict_Config* b);
+bool strict_Config_is_equal(const strict_Config* a, const strict_Config* b);
+int strict_Config_to_json_size(const strict_Config*);
+void strict_Config_to_json(const strict_Config*, char* json);
+int strict_Config_from_json(const char* json, strict_Config*);
+bool strict_Config_try_from_json(const char* json, strict_Config* v, jsonif_error* error);
+void strict_Config_alloc_people(strict_Config* v, int num);
+void strict_Config_set_people(strict_Config* v, int n, const strict_Person* m);
+void strict_Config_alloc_by_name(strict_Config* v, int num);
+void strict_Config_alloc_labels(strict_Config* v, int num);
+void strict_Config_set_address(strict_Config* v, const strict_Address* m);
+void strict_Config_set_number(strict_Config* v, int32_t m);
+void strict_Config_set_text(strict_Config* v, const char* s);
+void strict_Config_set_opt(strict_Config* v, uint32_t m);
+void strict_Config_set_big(strict_Config* v, int64_t m);
+void strict_Config_set_limit(strict_Config* v, const google_protobuf_Int32Value* m);
+
+void strict_Config_clear_number(strict_Config* v);
+void strict_Config_clear_text(strict_Config* v);
+bool strict_Config_has_opt(const strict_Config* v);
+void strict_Config_clear_opt(strict_Config* v);
+void strict_Config_clear_kind_case(strict_Config* v);
+void strict_Config_clear__opt_case(strict_Config* v);
+// Loose
+/// strict ではないメッセージの中でも、strict なメッセージは調べる
+typedef struct {
+  int32_t id;
+  strict_Person person;
+} strict_Loose;
+
+int strict_Loose_size();
+void strict_Loose_init(strict_Loose* v);
+void strict_Loose_destroy(strict_Loose*);
+void strict_Loose_copy(const strict_Loose* a, strict_Loose* b);
+bool strict_Loose_is_equal(const strict_Loose* a, const strict_Loose* b);
+int strict_Loose_to_json_size(const strict_Loose*);
+void strict_Loose_to_json(const strict_Loose*, char* json);
+int strict_Loose_from_json(const char* json, strict_Loose*);
+bool strict_Loose_try_from_json(const char* json, strict_Loose* v, jsonif_error* error);
+void strict_Loose_set_id(strict_Loose* v, int32_t m);
+void strict_Loose_set_person(strict_Loose* v, const strict_Person* m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,18 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_STRICT_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_STRICT_PROTO
+
+#include "strict.json.h"
+#include "strict.json.c.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+::strict::Person strict_Person_to_cpp(const strict_Person* v);
+void strict_Person_from_cpp(const ::strict::Person& u, strict_Person* v);
+::strict::Address strict_Address_to_cpp(const strict_Address* v);
+void strict_Address_from_cpp(const ::strict::Address& u, strict_Address* v);
+::strict::Config strict_Config_to_cpp(const strict_Config* v);
+void strict_Config_from_cpp(const ::strict::Config& u, strict_Config* v);
+::strict::Loose strict_Loose_to_cpp(const strict_Loose* v);
+void strict_Loose_from_cpp(const ::strict::Loose& u, strict_Loose* v);
+
+#endif
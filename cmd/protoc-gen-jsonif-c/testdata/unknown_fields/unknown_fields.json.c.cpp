@@ -0,0 +1,475 @@
+#include "unknown_fields.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "unknown_fields.json.h"
+
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "unknown_fields.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+::unknown_fields::Inner unknown_fields_Inner_to_cpp(const unknown_fields_Inner* v) {
+  ::unknown_fields::Inner u;
+  u.value = v->value;
+  return u;
+}
+void unknown_fields_Inner_from_cpp(const ::unknown_fields::Inner& u, unknown_fields_Inner* v) {
+  unknown_fields_Inner_destroy(v);
+  memset(v, 0, sizeof(unknown_fields_Inner));
+  v->value = u.value;
+}
+// kind
+const unknown_fields_Test_KindCase unknown_fields_Test_KindCase_NOT_SET = 0;
+const unknown_fields_Test_KindCase unknown_fields_Test_KindCase_kNumber = 6;
+const unknown_fields_Test_KindCase unknown_fields_Test_KindCase_kText = 7;
+
+// _opt
+const unknown_fields_Test_OptCase unknown_fields_Test_OptCase_NOT_SET = 0;
+const unknown_fields_Test_OptCase unknown_fields_Test_OptCase_kOpt = 8;
+
+::unknown_fields::Test unknown_fields_Test_to_cpp(const unknown_fields_Test* v) {
+  ::unknown_fields::Test u;
+  u.id = v->id;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.inner = unknown_fields_Inner_to_cpp(&v->inner);
+  for (int i = 0; i < v->inners_len; i++) {
+    u.inners.push_back(unknown_fields_Inner_to_cpp(&v->inners[i]));
+  }
+  for (int i = 0; i < v->inner_map_len; i++) {
+    decltype(u.inner_map)::key_type key{};
+    decltype(u.inner_map)::mapped_type value{};
+    if (v->inner_map[i].key_len != 0) key = std::string(v->inner_map[i].key, v->inner_map[i].key_len);
+    value = unknown_fields_Inner_to_cpp(&v->inner_map[i].value);
+    u.inner_map.emplace(std::move(key), std::move(value));
+  }
+  u.number = v->number;
+  if (v->text_len != 0) u.text = std::string(v->text, v->text_len);
+  u.opt = v->opt;
+  u.kind_case = (::unknown_fields::Test::KindCase)v->kind_case;
+  u._opt_case = (::unknown_fields::Test::OptCase)v->_opt_case;
+  return u;
+}
+void unknown_fields_Test_from_cpp(const ::unknown_fields::Test& u, unknown_fields_Test* v) {
+  unknown_fields_Test_destroy(v);
+  memset(v, 0, sizeof(unknown_fields_Test));
+  v->id = u.id;
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  unknown_fields_Inner_from_cpp(u.inner, &v->inner);
+  v->inners_len = (int)u.inners.size();
+  v->inners = v->inners_len == 0 ? nullptr : (decltype(v->inners))malloc(sizeof(v->inners[0]) * u.inners.size());
+  for (int i = 0; i < (int)u.inners.size(); i++) {
+    unknown_fields_Inner_init(&v->inners[i]);
+    unknown_fields_Inner_from_cpp(u.inners[i], &v->inners[i]);
+  }
+  v->inner_map_len = (int)u.inner_map.size();
+  v->inner_map = v->inner_map_len == 0 ? nullptr : (decltype(v->inner_map))malloc(sizeof(v->inner_map[0]) * u.inner_map.size());
+  int inner_map_index = 0;
+  for (const auto& kv : u.inner_map) {
+    unknown_fields_Test_InnerMapEntry_init(&v->inner_map[inner_map_index]);
+    if (!kv.first.empty()) v->inner_map[inner_map_index].key = strdup(kv.first.c_str());
+    v->inner_map[inner_map_index].key_len = (int)kv.first.size();
+    unknown_fields_Inner_from_cpp(kv.second, &v->inner_map[inner_map_index].value);
+    inner_map_index++;
+  }
+  v->number = u.number;
+  if (!u.text.empty()) v->text = strdup(u.text.c_str());
+  v->text_len = (int)u.text.size();
+  v->opt = u.opt;
+  v->kind_case = (int)u.kind_case;
+  v->_opt_case = (int)u._opt_case;
+}
+::unknown_fields::Plain unknown_fields_Plain_to_cpp(const unknown_fields_Plain* v) {
+  ::unknown_fields::Plain u;
+  u.id = v->id;
+  u.test = unknown_fields_Test_to_cpp(&v->test);
+  return u;
+}
+void unknown_fields_Plain_from_cpp(const ::unknown_fields::Plain& u, unknown_fields_Plain* v) {
+  unknown_fields_Plain_destroy(v);
+  memset(v, 0, sizeof(unknown_fields_Plain));
+  v->id = u.id;
+  unknown_fields_Test_from_cpp(u.test, &v->test);
+}
+::unknown_fields::Empty unknown_fields_Empty_to_cpp(const unknown_fields_Empty* v) {
+  ::unknown_fields::Empty u;
+  return u;
+}
+void unknown_fields_Empty_from_cpp(const ::unknown_fields::Empty& u, unknown_fields_Empty* v) {
+  unknown_fields_Empty_destroy(v);
+  memset(v, 0, sizeof(unknown_fields_Empty));
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_unknown_fields_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int unknown_fields_Inner_size() {
+  return sizeof(unknown_fields_Inner);
+}
+void unknown_fields_Inner_init(unknown_fields_Inner* v) {
+  memset(v, 0, sizeof(unknown_fields_Inner));
+}
+void unknown_fields_Inner_destroy(unknown_fields_Inner* v) {
+  memset(&v->value, 0, sizeof(v->value));
+}
+void unknown_fields_Inner_copy(const unknown_fields_Inner* a, unknown_fields_Inner* b) {
+  if (a == b) return;
+  ::unknown_fields::Inner u = unknown_fields_Inner_to_cpp(a);
+  unknown_fields_Inner_from_cpp(u, b);
+}
+bool unknown_fields_Inner_is_equal(const unknown_fields_Inner* a, const unknown_fields_Inner* b) {
+  if (a == b) return true;
+  ::unknown_fields::Inner ua = unknown_fields_Inner_to_cpp(a);
+  ::unknown_fields::Inner ub = unknown_fields_Inner_to_cpp(b);
+  return ua == ub;
+}
+int unknown_fields_Inner_to_json_size(const unknown_fields_Inner* v) {
+  ::unknown_fields::Inner u = unknown_fields_Inner_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void unknown_fields_Inner_to_json(const unknown_fields_Inner* v, char* json) {
+  ::unknown_fields::Inner u = unknown_fields_Inner_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int unknown_fields_Inner_from_json(const char* json, unknown_fields_Inner* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return unknown_fields_Inner_try_from_json(json, v, error) ? 0 : -1;
+}
+bool unknown_fields_Inner_try_from_json(const char* json, unknown_fields_Inner* v, jsonif_error* error) {
+  try {
+    ::unknown_fields::Inner u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    unknown_fields_Inner_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void unknown_fields_Inner_set_value(unknown_fields_Inner* v, int32_t m) {
+  v->value = m;
+}
+int unknown_fields_Test_InnerMapEntry_size() {
+  return sizeof(unknown_fields_Test_InnerMapEntry);
+}
+void unknown_fields_Test_InnerMapEntry_init(unknown_fields_Test_InnerMapEntry* v) {
+  memset(v, 0, sizeof(unknown_fields_Test_InnerMapEntry));
+}
+void unknown_fields_Test_InnerMapEntry_destroy(unknown_fields_Test_InnerMapEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  unknown_fields_Inner_destroy(&v->value);
+}
+void unknown_fields_Test_InnerMapEntry_set_key(unknown_fields_Test_InnerMapEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void unknown_fields_Test_InnerMapEntry_set_value(unknown_fields_Test_InnerMapEntry* v, const unknown_fields_Inner* m) {
+  unknown_fields_Inner_copy(m, &v->value);
+}
+int unknown_fields_Test_size() {
+  return sizeof(unknown_fields_Test);
+}
+void unknown_fields_Test_init(unknown_fields_Test* v) {
+  memset(v, 0, sizeof(unknown_fields_Test));
+}
+void unknown_fields_Test_destroy(unknown_fields_Test* v) {
+  memset(&v->id, 0, sizeof(v->id));
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  unknown_fields_Inner_destroy(&v->inner);
+  for (int i = 0; i < v->inners_len; i++) {
+    unknown_fields_Inner_destroy(&v->inners[i]);
+  }
+  if (v->inners) free(v->inners);
+  v->inners = nullptr;
+  v->inners_len = 0;
+  for (int i = 0; i < v->inner_map_len; i++) {
+    unknown_fields_Test_InnerMapEntry_destroy(&v->inner_map[i]);
+  }
+  if (v->inner_map) free(v->inner_map);
+  v->inner_map = nullptr;
+  v->inner_map_len = 0;
+  memset(&v->number, 0, sizeof(v->number));
+  if (v->text) free(v->text);
+  v->text = nullptr;
+  v->text_len = 0;
+  memset(&v->opt, 0, sizeof(v->opt));
+}
+void unknown_fields_Test_copy(const unknown_fields_Test* a, unknown_fields_Test* b) {
+  if (a == b) return;
+  ::unknown_fields::Test u = unknown_fields_Test_to_cpp(a);
+  unknown_fields_Test_from_cpp(u, b);
+}
+bool unknown_fields_Test_is_equal(const unknown_fields_Test* a, const unknown_fields_Test* b) {
+  if (a == b) return true;
+  ::unknown_fields::Test ua = unknown_fields_Test_to_cpp(a);
+  ::unknown_fields::Test ub = unknown_fields_Test_to_cpp(b);
+  return ua == ub;
+}
+int unknown_fields_Test_to_json_size(const unknown_fields_Test* v) {
+  ::unknown_fields::Test u = unknown_fields_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void unknown_fields_Test_to_json(const unknown_fields_Test* v, char* json) {
+  ::unknown_fields::Test u = unknown_fields_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int unknown_fields_Test_from_json(const char* json, unknown_fields_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return unknown_fields_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool unknown_fields_Test_try_from_json(const char* json, unknown_fields_Test* v, jsonif_error* error) {
+  try {
+    ::unknown_fields::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    unknown_fields_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void unknown_fields_Test_set_id(unknown_fields_Test* v, int32_t m) {
+  v->id = m;
+}
+void unknown_fields_Test_set_name(unknown_fields_Test* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void unknown_fields_Test_set_inner(unknown_fields_Test* v, const unknown_fields_Inner* m) {
+  unknown_fields_Inner_copy(m, &v->inner);
+}
+void unknown_fields_Test_alloc_inners(unknown_fields_Test* v, int num) {
+  if (v->inners) free(v->inners);
+  v->inners = nullptr;
+  v->inners_len = 0;
+  if (num != 0) {
+    v->inners = (decltype(v->inners))malloc(sizeof(v->inners[0]) * num);
+    memset(v->inners, 0, sizeof(v->inners[0]) * num);
+    v->inners_len = num;
+  }
+}
+
+void unknown_fields_Test_set_inners(unknown_fields_Test* v, int n, const unknown_fields_Inner* m) {
+  unknown_fields_Inner_copy(m, &v->inners[n]);
+}
+void unknown_fields_Test_alloc_inner_map(unknown_fields_Test* v, int num) {
+  if (v->inner_map) free(v->inner_map);
+  v->inner_map = nullptr;
+  v->inner_map_len = 0;
+  if (num != 0) {
+    v->inner_map = (decltype(v->inner_map))malloc(sizeof(v->inner_map[0]) * num);
+    memset(v->inner_map, 0, sizeof(v->inner_map[0]) * num);
+    v->inner_map_len = num;
+  }
+}
+
+void unknown_fields_Test_set_number(unknown_fields_Test* v, int32_t m) {
+  unknown_fields_Test_clear_kind_case(v);
+  v->kind_case = unknown_fields_Test_KindCase_kNumber;
+  v->number = m;
+}
+void unknown_fields_Test_set_text(unknown_fields_Test* v, const char* s) {
+  unknown_fields_Test_clear_kind_case(v);
+  v->kind_case = unknown_fields_Test_KindCase_kText;
+  if (v->text) free(v->text);
+  v->text_len = s == nullptr ? 0 : strlen(s);
+  v->text = v->text_len == 0 ? nullptr : strdup(s);
+}
+void unknown_fields_Test_set_opt(unknown_fields_Test* v, int32_t m) {
+  unknown_fields_Test_clear__opt_case(v);
+  v->_opt_case = unknown_fields_Test_OptCase_kOpt;
+  v->opt = m;
+}
+void unknown_fields_Test_clear_number(unknown_fields_Test* v) {
+  if (v->kind_case == unknown_fields_Test_KindCase_kNumber) {
+    unknown_fields_Test_clear_kind_case(v);
+  }
+}
+void unknown_fields_Test_clear_text(unknown_fields_Test* v) {
+  if (v->kind_case == unknown_fields_Test_KindCase_kText) {
+    unknown_fields_Test_clear_kind_case(v);
+  }
+}
+bool unknown_fields_Test_has_opt(const unknown_fields_Test* v) {
+  return v->_opt_case == unknown_fields_Test_OptCase_kOpt;
+}
+void unknown_fields_Test_clear_opt(unknown_fields_Test* v) {
+  if (v->_opt_case == unknown_fields_Test_OptCase_kOpt) {
+    unknown_fields_Test_clear__opt_case(v);
+  }
+}
+void unknown_fields_Test_clear_kind_case(unknown_fields_Test* v) {
+  memset(&v->number, 0, sizeof(v->number));
+  if (v->text) free(v->text);
+  v->text = nullptr;
+  v->text_len = 0;
+  v->kind_case = unknown_fields_Test_KindCase_NOT_SET;
+}
+void unknown_fields_Test_clear__opt_case(unknown_fields_Test* v) {
+  memset(&v->opt, 0, sizeof(v->opt));
+  v->_opt_case = unknown_fields_Test_OptCase_NOT_SET;
+}
+int unknown_fields_Plain_size() {
+  return sizeof(unknown_fields_Plain);
+}
+void unknown_fields_Plain_init(unknown_fields_Plain* v) {
+  memset(v, 0, sizeof(unknown_fields_Plain));
+}
+void unknown_fields_Plain_destroy(unknown_fields_Plain* v) {
+  memset(&v->id, 0, sizeof(v->id));
+  unknown_fields_Test_destroy(&v->test);
+}
+void unknown_fields_Plain_copy(const unknown_fields_Plain* a, unknown_fields_Plain* b) {
+  if (a == b) return;
+  ::unknown_fields::Plain u = unknown_fields_Plain_to_cpp(a);
+  unknown_fields_Plain_from_cpp(u, b);
+}
+bool unknown_fields_Plain_is_equal(const unknown_fields_Plain* a, const unknown_fields_Plain* b) {
+  if (a == b) return true;
+  ::unknown_fields::Plain ua = unknown_fields_Plain_to_cpp(a);
+  ::unknown_fields::Plain ub = unknown_fields_Plain_to_cpp(b);
+  return ua == ub;
+}
+int unknown_fields_Plain_to_json_size(const unknown_fields_Plain* v) {
+  ::unknown_fields::Plain u = unknown_fields_Plain_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void unknown_fields_Plain_to_json(const unknown_fields_Plain* v, char* json) {
+  ::unknown_fields::Plain u = unknown_fields_Plain_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int unknown_fields_Plain_from_json(const char* json, unknown_fields_Plain* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return unknown_fields_Plain_try_from_json(json, v, error) ? 0 : -1;
+}
+bool unknown_fields_Plain_try_from_json(const char* json, unknown_fields_Plain* v, jsonif_error* error) {
+  try {
+    ::unknown_fields::Plain u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    unknown_fields_Plain_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void unknown_fields_Plain_set_id(unknown_fields_Plain* v, int32_t m) {
+  v->id = m;
+}
+void unknown_fields_Plain_set_test(unknown_fields_Plain* v, const unknown_fields_Test* m) {
+  unknown_fields_Test_copy(m, &v->test);
+}
+int unknown_fields_Empty_size() {
+  return sizeof(unknown_fields_Empty);
+}
+void unknown_fields_Empty_init(unknown_fields_Empty* v) {
+  memset(v, 0, sizeof(unknown_fields_Empty));
+}
+void unknown_fields_Empty_destroy(unknown_fields_Empty* v) {
+}
+void unknown_fields_Empty_copy(const unknown_fields_Empty* a, unknown_fields_Empty* b) {
+  if (a == b) return;
+  ::unknown_fields::Empty u = unknown_fields_Empty_to_cpp(a);
+  unknown_fields_Empty_from_cpp(u, b);
+}
+bool unknown_fields_Empty_is_equal(const unknown_fields_Empty* a, const unknown_fields_Empty* b) {
+  if (a == b) return true;
+  ::unknown_fields::Empty ua = unknown_fields_Empty_to_cpp(a);
+  ::unknown_fields::Empty ub = unknown_fields_Empty_to_cpp(b);
+  return ua == ub;
+}
+int unknown_fields_Empty_to_json_size(const unknown_fields_Empty* v) {
+  ::unknown_fields::Empty u = unknown_fields_Empty_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void unknown_fields_Empty_to_json(const unknown_fields_Empty* v, char* json) {
+  ::unknown_fields::Empty u = unknown_fields_Empty_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int unknown_fields_Empty_from_json(const char* json, unknown_fields_Empty* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return unknown_fields_Empty_try_from_json(json, v, error) ? 0 : -1;
+}
+bool unknown_fields_Empty_try_from_json(const char* json, unknown_fields_Empty* v, jsonif_error* error) {
+  try {
+    ::unknown_fields::Empty u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    unknown_fields_Empty_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+
+}
@@ -0,0 +1,178 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_UNKNOWN_FIELDS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_UNKNOWN_FIELDS_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_unknown_fields_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_unknown_fields_proto();
+}
+
+#endif
+
+// kind
+typedef int unknown_fields_Test_KindCase;
+extern const unknown_fields_Test_KindCase unknown_fields_Test_KindCase_NOT_SET;
+extern const unknown_fields_Test_KindCase unknown_fields_Test_KindCase_kNumber;
+extern const unknown_fields_Test_KindCase unknown_fields_Test_KindCase_kText;
+
+// _opt
+typedef int unknown_fields_Test_OptCase;
+extern const unknown_fields_Test_OptCase unknown_fields_Test_OptCase_NOT_SET;
+extern const unknown_fields_Test_OptCase unknown_fields_Test_OptCase_kOpt;
+
+// Inner
+typedef struct {
+  int32_t value;
+} unknown_fields_Inner;
+
+int unknown_fields_Inner_size();
+void unknown_fields_Inner_init(unknown_fields_Inner* v);
+void unknown_fields_Inner_destroy(unknown_fields_Inner*);
+void unknown_fields_Inner_copy(const unknown_fields_Inner* a, unknown_fields_Inner* b);
+bool unknown_fields_Inner_is_equal(const unknown_fields_Inner* a, const unknown_fields_Inner* b);
+int unknown_fields_Inner_to_json_size(const unknown_fields_Inner*);
+void unknown_fields_Inner_to_json(const unknown_fields_Inner*, char* json);
+int unknown_fields_Inner_from_json(const char* json, unknown_fields_Inner*);
+bool unknown_fields_Inner_try_from_json(const char* json, unknown_fields_Inner* v, jsonif_error* error);
+void unknown_fields_Inner_set_value(unknown_fields_Inner* v, int32_t m);
+
+// InnerMapEntry
+typedef struct {
+  char* key;
+  int key_len;
+  unknown_fields_Inner value;
+} unknown_fields_Test_InnerMapEntry;
+
+int unknown_fields_Test_InnerMapEntry_size();
+void unknown_fields_Test_InnerMapEntry_init(unknown_fields_Test_InnerMapEntry* v);
+void unknown_fields_Test_InnerMapEntry_destroy(unknown_fields_Test_InnerMapEntry*);
+void unknown_fields_Test_InnerMapEntry_set_key(unknown_fields_Test_InnerMapEntry* v, const char* s);
+void unknown_fields_Test_InnerMapEntry_set_value(unknown_fields_Test_InnerMapEntry* v, const unknown_fields_Inner* m);
+
+// Test
+/// 知らないキーを保持して、そのまま出力する
+typedef struct {
+  int32_t id;
+  char* name;
+  int name_len;
+  unknown_fields_Inner inner;
+  unknown_fields_Inner* inners;
+  int inners_len;
+  unknown_fields_Test_InnerMapEntry* inner_map;
+  int inner_map_len;
+  int32_t number;
+  char* text;
+  int text_len;
+  int32_t opt;
+  unknown_fields_Test_KindCase kind_case;
+  unknown_fields_Test_OptCase _opt_case;
+} unknown_fields_Test;
+
+int unknown_fields_Test_size();
+void unknown_fields_Test_init(unknown_fields_Test* v);
+void unknown_fields_Test_destroy(unknown_fields_Test*);
+void unknown_fields_Test_copy(const unknown_fields_Test* a, unknown_fields_Test* b);
+bool unknown_fields_Test_is_equal(const unknown_fields_Test* a, const unknown_fields_Test* b);
+int unknown_fields_Test_to_json_size(const unknown_fields_Test*);
+void unknown_fields_Test_to_json(const unknown_fields_Test*, char* json);
+int unknown_fields_Test_from_json(const char* json, unknown_fields_Test*);
+bool unknown_fields_Test_try_from_json(const char* json, unknown_fields_Test* v, jsonif_error* error);
+void unknown_fields_Test_set_id(unknown_fields_Test* v, int32_t m);
+void unknown_fields_Test_set_name(unknown_fields_Test* v, const char* s);
+void unknown_fields_Test_set_inner(unknown_fields_Test* v, const unknown_fields_Inner* m);
+void unknown_fields_Test_alloc_inners(unknown_fields_Test* v, int num);
+void unknown_fields_Test_set_inners(unknown_fields_Test* v, int n, const unknown_fields_Inner* m);
+void unknown_fields_Test_alloc_inner_map(unknown_fields_Test* v, int num);
+void unknown_fields_Test_set_number(unknown_fields_Test* v, int32_t m);
+void unknown_fields_Test_set_text(unknown_fields_Test* v, const char* s);
+void unknown_fields_Test_set_opt(unknown_fields_Test* v, int32_t m);
+
+void unknown_fields_Test_clear_number(unknown_fields_Test* v);
+void unknown_fields_Test_clear_text(unknown_fields_Test* v);
+bool unknown_fields_Test_has_opt(const unknown_fields_Test* v);
+void unknown_fields_Test_clear_opt(unknown_fields_Test* v);
+void unknown_fields_Test_clear_kind_case(unknown_fields_Test* v);
+void unknown_fields_Test_clear__opt_case(unknown_fields_Test* v);
+// Plain
+/// 知らないキーがあったら捨てる
+typedef struct {
+  int32_t id;
+  unknown_fields_Test test;
+} unknown_fields_Plain;
+
+int unknown_fields_Plain_size();
+void unknown_fields_Plain_init(unknown_fields_Plain* v);
+void unknown_fields_Plain_destroy(unknown_fields_Plain*);
+void unknown_fields_Plain_copy(const unknown_fields_Plain* a, unknown_fields_Plain* b);
+bool unknown_fields_Plain_is_equal(const unknown_fields_Plain* a, const unknown_fields_Plain* b);
+int unknown_fields_Plain_to_json_size(const unknown_fields_Plain*);
+void unknown_fields_Plain_to_json(const unknown_fields_Plain*, char* json);
+int unknown_fields_Plain_from_json(const char* json, unknown_fields_Plain*);
+bool unknown_fields_Plain_try_from_json(const char* json, unknown_fields_Plain* v, jsonif_error* error);
+void unknown_fields_Plain_set_id(unknown_fields_Plain* v, int32_t m);
+void unknown_fields_Plain_set_test(unknown_fields_Plain* v, const unknown_fields_Test* m);
+
+// Empty
+/// フィールドが無くても知らないキーは保持する
+typedef struct {
+} unknown_fields_Empty;
+
+int unknown_fields_Empty_size();
+void unknown_fields_Empty_init(unknown_fields_Empty* v);
+void unknown_fields_Empty_destroy(unknown_fields_Empty*);
+void unknown_fields_Empty_copy(const unknown_fields_Empty* a, unknown_fields_Empty* b);
+bool unknown_fields_Empty_is_equal(const unknown_fields_Empty* a, const unknown_fields_Empty* b);
+int unknown_fields_Empty_to_json_size(const unknown_fields_Empty*);
+void unknown_fields_Empty_to_json(const unknown_fields_Empty*, char* json);
+int unknown_fields_Empty_from_json(const char* json, unknown_fields_Empty*);
+bool unknown_fields_Empty_try_from_json(const char* json, unknown_fields_Empty* v, jsonif_error* error);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,17 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_UNKNOWN_FIELDS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_UNKNOWN_FIELDS_PROTO
+
+#include "unknown_fields.json.h"
+#include "unknown_fields.json.c.h"
+
+
+::unknown_fields::Inner unknown_fields_Inner_to_cpp(const unknown_fields_Inner* v);
+void unknown_fields_Inner_from_cpp(const ::unknown_fields::Inner& u, unknown_fields_Inner* v);
+::unknown_fields::Test unknown_fields_Test_to_cpp(const unknown_fields_Test* v);
+void unknown_fields_Test_from_cpp(const ::unknown_fields::Test& u, unknown_fields_Test* v);
+::unknown_fields::Plain unknown_fields_Plain_to_cpp(const unknown_fields_Plain* v);
+void unknown_fields_Plain_from_cpp(const ::unknown_fields::Plain& u, unknown_fields_Plain* v);
+::unknown_fields::Empty unknown_fields_Empty_to_cpp(const unknown_fields_Empty* v);
+void unknown_fields_Empty_from_cpp(const ::unknown_fields::Empty& u, unknown_fields_Empty* v);
+
+#endif
@@ -0,0 +1,755 @@
+#include "validation.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "validation.json.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "validation.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// Role
+const validation_Role validation_ROLE_UNSPECIFIED = 0;
+const validation_Role validation_ROLE_ADMIN = 1;
+const validation_Role validation_ROLE_MEMBER = 2;
+
+// _score
+const validation_Person_ScoreCase validation_Person_ScoreCase_NOT_SET = 0;
+const validation_Person_ScoreCase validation_Person_ScoreCase_kScore = 6;
+
+::validation::Person validation_Person_to_cpp(const validation_Person* v) {
+  ::validation::Person u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  u.age = v->age;
+  if (v->email_len != 0) u.email = std::string(v->email, v->email_len);
+  u.role = (decltype(u.role))v->role;
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags_lens[i] != 0) {
+      u.tags.push_back(std::string(v->tags[i], v->tags_lens[i]));
+    } else {
+      u.tags.push_back("");
+    }
+  }
+  u.score = v->score;
+  u.ratio = v->ratio;
+  u._score_case = (::validation::Person::ScoreCase)v->_score_case;
+  return u;
+}
+void validation_Person_from_cpp(const ::validation::Person& u, validation_Person* v) {
+  validation_Person_destroy(v);
+  memset(v, 0, sizeof(validation_Person));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+  v->age = u.age;
+  if (!u.email.empty()) v->email = strdup(u.email.c_str());
+  v->email_len = (int)u.email.size();
+  v->role = (int)u.role;
+  v->tags_len = (int)u.tags.size();
+  v->tags = v->tags_len == 0 ? nullptr : (decltype(v->tags))malloc(sizeof(v->tags[0]) * u.tags.size());
+  v->tags_lens = v->tags_len == 0 ? nullptr : (int*)malloc(sizeof(int) * u.tags.size());
+  for (int i = 0; i < (int)u.tags.size(); i++) {
+    if (!u.tags[i].empty()) v->tags[i] = strdup(u.tags[i].c_str());
+    v->tags_lens[i] = (int)u.tags[i].size();
+  }
+  v->score = u.score;
+  v->ratio = u.ratio;
+  v->_score_case = (int)u._score_case;
+}
+// contact
+const validation_Team_ContactCase validation_Team_ContactCase_NOT_SET = 0;
+const validation_Team_ContactCase validation_Team_ContactCase_kPhone = 6;
+const validation_Team_ContactCase validation_Team_ContactCase_kContactPerson = 7;
+
+::validation::Team validation_Team_to_cpp(const validation_Team* v) {
+  ::validation::Team u;
+  u.leader = validation_Person_to_cpp(&v->leader);
+  for (int i = 0; i < v->members_len; i++) {
+    u.members.push_back(validation_Person_to_cpp(&v->members[i]));
+  }
+  for (int i = 0; i < v->by_name_len; i++) {
+    decltype(u.by_name)::key_type key{};
+    decltype(u.by_name)::mapped_type value{};
+    if (v->by_name[i].key_len != 0) key = std::string(v->by_name[i].key, v->by_name[i].key_len);
+    value = validation_Person_to_cpp(&v->by_name[i].value);
+    u.by_name.emplace(std::move(key), std::move(value));
+  }
+  for (int i = 0; i < v->limits_len; i++) {
+    decltype(u.limits)::key_type key{};
+    decltype(u.limits)::mapped_type value{};
+    key = v->limits[i].key;
+    value = v->limits[i].value;
+    u.limits.emplace(std::move(key), std::move(value));
+  }
+  u.size = google_protobuf_Int32Value_to_cpp(&v->size);
+  if (v->phone_len != 0) u.phone = std::string(v->phone, v->phone_len);
+  u.contact_person = validation_Person_to_cpp(&v->contact_person);
+  u.contact_case = (::validation::Team::ContactCase)v->contact_case;
+  return u;
+}
+void validation_Team_from_cpp(const ::validation::Team& u, validation_Team* v) {
+  validation_Team_destroy(v);
+  memset(v, 0, sizeof(validation_Team));
+  validation_Person_from_cpp(u.leader, &v->leader);
+  v->members_len = (int)u.members.size();
+  v->members = v->members_len == 0 ? nullptr : (decltype(v->members))malloc(sizeof(v->members[0]) * u.members.size());
+  for (int i = 0; i < (int)u.members.size(); i++) {
+    validation_Person_init(&v->members[i]);
+    validation_Person_from_cpp(u.members[i], &v->members[i]);
+  }
+  v->by_name_len = (int)u.by_name.size();
+  v->by_name = v->by_name_len == 0 ? nullptr : (decltype(v->by_name))malloc(sizeof(v->by_name[0]) * u.by_name.size());
+  int by_name_index = 0;
+  for (const auto& kv : u.by_name) {
+    validation_Team_ByNameEntry_init(&v->by_name[by_name_index]);
+    if (!kv.first.empty()) v->by_name[by_name_index].key = strdup(kv.first.c_str());
+    v->by_name[by_name_index].key_len = (int)kv.first.size();
+    validation_Person_from_cpp(kv.second, &v->by_name[by_name_index].value);
+    by_name_index++;
+  }
+  v->limits_len = (int)u.limits.size();
+  v->limits = v->limits_len == 0 ? nullptr : (decltype(v->limits))malloc(sizeof(v->limits[0]) * u.limits.size());
+  int limits_index = 0;
+  for (const auto& kv : u.limits) {
+    validation_Team_LimitsEntry_init(&v->limits[limits_index]);
+    v->limits[limits_index].key = kv.first;
+    v->limits[limits_index].value = kv.second;
+    limits_index++;
+  }
+  google_protobuf_Int32Value_from_cpp(u.size, &v->size);
+  if (!u.phone.empty()) v->phone = strdup(u.phone.c_str());
+  v->phone_len = (int)u.phone.size();
+  validation_Person_from_cpp(u.contact_person, &v->contact_person);
+  v->contact_case = (int)u.contact_case;
+}
+::validation::Club validation_Club_to_cpp(const validation_Club* v) {
+  ::validation::Club u;
+  if (v->name_len != 0) u.name = std::string(v->name, v->name_len);
+  return u;
+}
+void validation_Club_from_cpp(const ::validation::Club& u, validation_Club* v) {
+  validation_Club_destroy(v);
+  memset(v, 0, sizeof(validation_Club));
+  if (!u.name.empty()) v->name = strdup(u.name.c_str());
+  v->name_len = (int)u.name.size();
+}
+::validation::League validation_League_to_cpp(const validation_League* v) {
+  ::validation::League u;
+  u.champion = validation_Club_to_cpp(&v->champion);
+  for (int i = 0; i < v->clubs_len; i++) {
+    u.clubs.push_back(validation_Club_to_cpp(&v->clubs[i]));
+  }
+  return u;
+}
+void validation_League_from_cpp(const ::validation::League& u, validation_League* v) {
+  validation_League_destroy(v);
+  memset(v, 0, sizeof(validation_League));
+  validation_Club_from_cpp(u.champion, &v->champion);
+  v->clubs_len = (int)u.clubs.size();
+  v->clubs = v->clubs_len == 0 ? nullptr : (decltype(v->clubs))malloc(sizeof(v->clubs[0]) * u.clubs.size());
+  for (int i = 0; i < (int)u.clubs.size(); i++) {
+    validation_Club_init(&v->clubs[i]);
+    validation_Club_from_cpp(u.clubs[i], &v->clubs[i]);
+  }
+}
+::validation::NoRules validation_NoRules_to_cpp(const validation_NoRules* v) {
+  ::validation::NoRules u;
+  u.value = v->value;
+  return u;
+}
+void validation_NoRules_from_cpp(const ::validation::NoRules& u, validation_NoRules* v) {
+  validation_NoRules_destroy(v);
+  memset(v, 0, sizeof(validation_NoRules));
+  v->value = u.value;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_validation_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int validation_Person_size() {
+  return sizeof(validation_Person);
+}
+void validation_Person_init(validation_Person* v) {
+  memset(v, 0, sizeof(validation_Person));
+}
+void validation_Person_destroy(validation_Person* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+  memset(&v->age, 0, sizeof(v->age));
+  if (v->email) free(v->email);
+  v->email = nullptr;
+  v->email_len = 0;
+  memset(&v->role, 0, sizeof(v->role));
+  for (int i = 0; i < v->tags_len; i++) {
+    if (v->tags[i]) free(v->tags[i]);
+    v->tags[i] = nullptr;
+    v->tags_lens[i] = 0;
+  }
+  if (v->tags_lens) free(v->tags_lens);
+  v->tags_lens = nullptr;
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  memset(&v->score, 0, sizeof(v->score));
+  memset(&v->ratio, 0, sizeof(v->ratio));
+}
+void validation_Person_copy(const validation_Person* a, validation_Person* b) {
+  if (a == b) return;
+  ::validation::Person u = validation_Person_to_cpp(a);
+  validation_Person_from_cpp(u, b);
+}
+bool validation_Person_is_equal(const validation_Person* a, const validation_Person* b) {
+  if (a == b) return true;
+  ::validation::Person ua = validation_Person_to_cpp(a);
+  ::validation::Person ub = validation_Person_to_cpp(b);
+  return ua == ub;
+}
+int validation_Person_to_json_size(const validation_Person* v) {
+  ::validation::Person u = validation_Person_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void validation_Person_to_json(const validation_Person* v, char* json) {
+  ::validation::Person u = validation_Person_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int validation_Person_from_json(const char* json, validation_Person* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return validation_Person_try_from_json(json, v, error) ? 0 : -1;
+}
+bool validation_Person_try_from_json(const char* json, validation_Person* v, jsonif_error* error) {
+  try {
+    ::validation::Person u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    validation_Person_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+int validation_Person_validate(const validation_Person* v, jsonif_violation** violations) {
+  ::validation::Person u = validation_Person_to_cpp(v);
+  std::vector<jsonif::violation> r = jsonif::validate(u);
+  if (violations != NULL) {
+    *violations = NULL;
+    if (!r.empty()) {
+      *violations = (jsonif_violation*)malloc(sizeof(jsonif_violation) * r.size());
+      for (size_t i = 0; i < r.size(); i++) {
+        (*violations)[i].path = strdup(r[i].path.c_str());
+        (*violations)[i].message = strdup(r[i].message.c_str());
+      }
+    }
+  }
+  return (int)r.size();
+}
+void validation_Person_set_name(validation_Person* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+void validation_Person_set_age(validation_Person* v, int32_t m) {
+  v->age = m;
+}
+void validation_Person_set_email(validation_Person* v, const char* s) {
+  if (v->email) free(v->email);
+  v->email_len = s == nullptr ? 0 : strlen(s);
+  v->email = v->email_len == 0 ? nullptr : strdup(s);
+}
+void validation_Person_set_role(validation_Person* v, validation_Role m) {
+  v->role = m;
+}
+void validation_Person_alloc_tags(validation_Person* v, int num) {
+  if (v->tags) free(v->tags);
+  v->tags = nullptr;
+  v->tags_len = 0;
+  if (num != 0) {
+    v->tags = (decltype(v->tags))malloc(sizeof(v->tags[0]) * num);
+    memset(v->tags, 0, sizeof(v->tags[0]) * num);
+    v->tags_len = num;
+    v->tags_lens = (decltype(v->tags_lens))malloc(sizeof(v->tags_lens[0]) * num);
+    memset(v->tags_lens, 0, sizeof(v->tags_lens[0]) * num);
+  }
+}
+
+void validation_Person_set_tags(validation_Person* v, int n, const char* s) {
+  if (v->tags[n]) free(v->tags[n]);
+  v->tags_lens[n] = s == nullptr ? 0 : strlen(s);
+  v->tags[n] = v->tags_lens[n] == 0 ? nullptr : strdup(s);
+}
+void validation_Person_set_score(validation_Person* v, double m) {
+  validation_Person_clear__score_case(v);
+  v->_score_case = validation_Person_ScoreCase_kScore;
+  v->score = m;
+}
+void validation_Person_set_ratio(validation_Person* v, float m) {
+  v->ratio = m;
+}
+bool validation_Person_has_score(const validation_Person* v) {
+  return v->_score_case == validation_Person_ScoreCase_kScore;
+}
+void validation_Person_clear_score(validation_Person* v) {
+  if (v->_score_case == validation_Person_ScoreCase_kScore) {
+    validation_Person_clear__score_case(v);
+  }
+}
+void validation_Person_clear__score_case(validation_Person* v) {
+  memset(&v->score, 0, sizeof(v->score));
+  v->_score_case = validation_Person_ScoreCase_NOT_SET;
+}
+int validation_Team_ByNameEntry_size() {
+  return sizeof(validation_Team_ByNameEntry);
+}
+void validation_Team_ByNameEntry_init(validation_Team_ByNameEntry* v) {
+  memset(v, 0, sizeof(validation_Team_ByNameEntry));
+}
+void validation_Team_ByNameEntry_destroy(validation_Team_ByNameEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  validation_Person_destroy(&v->value);
+}
+void validation_Team_ByNameEntry_set_key(validation_Team_ByNameEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void validation_Team_ByNameEntry_set_value(validation_Team_ByNameEntry* v, const validation_Person* m) {
+  validation_Person_copy(m, &v->value);
+}
+int validation_Team_LimitsEntry_size() {
+  return sizeof(validation_Team_LimitsEntry);
+}
+void validation_Team_LimitsEntry_init(validation_Team_LimitsEntry* v) {
+  memset(v, 0, sizeof(validation_Team_LimitsEntry));
+}
+void validation_Team_LimitsEntry_destroy(validation_Team_LimitsEntry* v) {
+  memset(&v->key, 0, sizeof(v->key));
+  memset(&v->value, 0, sizeof(v->value));
+}
+void validation_Team_LimitsEntry_set_key(validation_Team_LimitsEntry* v, int32_t m) {
+  v->key = m;
+}
+void validation_Team_LimitsEntry_set_value(validation_Team_LimitsEntry* v, int64_t m) {
+  v->value = m;
+}
+int validation_Team_size() {
+  return sizeof(validation_Team);
+}
+void validation_Team_init(validation_Team* v) {
+  memset(v, 0, sizeof(validation_Team));
+}
+void validation_Team_destroy(validation_Team* v) {
+  validation_Person_destroy(&v->leader);
+  for (int i = 0; i < v->members_len; i++) {
+    validation_Person_destroy(&v->members[i]);
+  }
+  if (v->members) free(v->members);
+  v->members = nullptr;
+  v->members_len = 0;
+  for (int i = 0; i < v->by_name_len; i++) {
+    validation_Team_ByNameEntry_destroy(&v->by_name[i]);
+  }
+  if (v->by_name) free(v->by_name);
+  v->by_name = nullptr;
+  v->by_name_len = 0;
+  for (int i = 0; i < v->limits_len; i++) {
+    validation_Team_LimitsEntry_destroy(&v->limits[i]);
+  }
+  if (v->limits) free(v->limits);
+  v->limits = nullptr;
+  v->limits_len = 0;
+  google_protobuf_Int32Value_destroy(&v->size);
+  if (v->phone) free(v->phone);
+  v->phone = nullptr;
+  v->phone_len = 0;
+  validation_Person_destroy(&v->contact_person);
+}
+void validation_Team_copy(const validation_Team* a, validation_Team* b) {
+  if (a == b) return;
+  ::validation::Team u = validation_Team_to_cpp(a);
+  validation_Team_from_cpp(u, b);
+}
+bool validation_Team_is_equal(const validation_Team* a, const validation_Team* b) {
+  if (a == b) return true;
+  ::validation::Team ua = validation_Team_to_cpp(a);
+  ::validation::Team ub = validation_Team_to_cpp(b);
+  return ua == ub;
+}
+int validation_Team_to_json_size(const validation_Team* v) {
+  ::validation::Team u = validation_Team_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void validation_Team_to_json(const validation_Team* v, char* json) {
+  ::validation::Team u = validation_Team_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int validation_Team_from_json(const char* json, validation_Team* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return validation_Team_try_from_json(json, v, error) ? 0 : -1;
+}
+bool validation_Team_try_from_json(const char* json, validation_Team* v, jsonif_error* error) {
+  try {
+    ::validation::Team u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    validation_Team_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+int validation_Team_validate(const validation_Team* v, jsonif_violation** violations) {
+  ::validation::Team u = validation_Team_to_cpp(v);
+  std::vector<jsonif::violation> r = jsonif::validate(u);
+  if (violations != NULL) {
+    *violations = NULL;
+    if (!r.empty()) {
+      *violations = (jsonif_violation*)malloc(sizeof(jsonif_violation) * r.size());
+      for (size_t i = 0; i < r.size(); i++) {
+        (*violations)[i].path = strdup(r[i].path.c_str());
+        (*violations)[i].message = strdup(r[i].message.c_str());
+      }
+    }
+  }
+  return (int)r.size();
+}
+void validation_Team_set_leader(validation_Team* v, const validation_Person* m) {
+  validation_Person_copy(m, &v->leader);
+}
+void validation_Team_alloc_members(validation_Team* v, int num) {
+  if (v->members) free(v->members);
+  v->members = nullptr;
+  v->members_len = 0;
+  if (num != 0) {
+    v->members = (decltype(v->members))malloc(sizeof(v->members[0]) * num);
+    memset(v->members, 0, sizeof(v->members[0]) * num);
+    v->members_len = num;
+  }
+}
+
+void validation_Team_set_members(validation_Team* v, int n, const validation_Person* m) {
+  validation_Person_copy(m, &v->members[n]);
+}
+void validation_Team_alloc_by_name(validation_Team* v, int num) {
+  if (v->by_name) free(v->by_name);
+  v->by_name = nullptr;
+  v->by_name_len = 0;
+  if (num != 0) {
+    v->by_name = (decltype(v->by_name))malloc(sizeof(v->by_name[0]) * num);
+    memset(v->by_name, 0, sizeof(v->by_name[0]) * num);
+    v->by_name_len = num;
+  }
+}
+
+void validation_Team_alloc_limits(validation_Team* v, int num) {
+  if (v->limits) free(v->limits);
+  v->limits = nullptr;
+  v->limits_len = 0;
+  if (num != 0) {
+    v->limits = (decltype(v->limits))malloc(sizeof(v->limits[0]) * num);
+    memset(v->limits, 0, sizeof(v->limits[0]) * num);
+    v->limits_len = num;
+  }
+}
+
+void validation_Team_set_size(validation_Team* v, const google_protobuf_Int32Value* m) {
+  google_protobuf_Int32Value_copy(m, &v->size);
+}
+void validation_Team_set_phone(validation_Team* v, const char* s) {
+  validation_Team_clear_contact_case(v);
+  v->contact_case = validation_Team_ContactCase_kPhone;
+  if (v->phone) free(v->phone);
+  v->phone_len = s == nullptr ? 0 : strlen(s);
+  v->phone = v->phone_len == 0 ? nullptr : strdup(s);
+}
+void validation_Team_set_contact_person(validation_Team* v, const validation_Person* m) {
+  validation_Team_clear_contact_case(v);
+  v->contact_case = validation_Team_ContactCase_kContactPerson;
+  validation_Person_copy(m, &v->contact_person);
+}
+void validation_Team_clear_phone(validation_Team* v) {
+  if (v->contact_case == validation_Team_ContactCase_kPhone) {
+    validation_Team_clear_contact_case(v);
+  }
+}
+void validation_Team_clear_contact_person(validation_Team* v) {
+  if (v->contact_case == validation_Team_ContactCase_kContactPerson) {
+    validation_Team_clear_contact_case(v);
+  }
+}
+void validation_Team_clear_contact_case(validation_Team* v) {
+  if (v->phone) free(v->phone);
+  v->phone = nullptr;
+  v->phone_len = 0;
+  validation_Person_destroy(&v->contact_person);
+  v->contact_case = validation_Team_ContactCase_NOT_SET;
+}
+int validation_Club_size() {
+  return sizeof(validation_Club);
+}
+void validation_Club_init(validation_Club* v) {
+  memset(v, 0, sizeof(validation_Club));
+}
+void validation_Club_destroy(validation_Club* v) {
+  if (v->name) free(v->name);
+  v->name = nullptr;
+  v->name_len = 0;
+}
+void validation_Club_copy(const validation_Club* a, validation_Club* b) {
+  if (a == b) return;
+  ::validation::Club u = validation_Club_to_cpp(a);
+  validation_Club_from_cpp(u, b);
+}
+bool validation_Club_is_equal(const validation_Club* a, const validation_Club* b) {
+  if (a == b) return true;
+  ::validation::Club ua = validation_Club_to_cpp(a);
+  ::validation::Club ub = validation_Club_to_cpp(b);
+  return ua == ub;
+}
+int validation_Club_to_json_size(const validation_Club* v) {
+  ::validation::Club u = validation_Club_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void validation_Club_to_json(const validation_Club* v, char* json) {
+  ::validation::Club u = validation_Club_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int validation_Club_from_json(const char* json, validation_Club* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return validation_Club_try_from_json(json, v, error) ? 0 : -1;
+}
+bool validation_Club_try_from_json(const char* json, validation_Club* v, jsonif_error* error) {
+  try {
+    ::validation::Club u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    validation_Club_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+int validation_Club_validate(const validation_Club* v, jsonif_violation** violations) {
+  ::validation::Club u = validation_Club_to_cpp(v);
+  std::vector<jsonif::violation> r = jsonif::validate(u);
+  if (violations != NULL) {
+    *violations = NULL;
+    if (!r.empty()) {
+      *violations = (jsonif_violation*)malloc(sizeof(jsonif_violation) * r.size());
+      for (size_t i = 0; i < r.size(); i++) {
+        (*violations)[i].path = strdup(r[i].path.c_str());
+        (*violations)[i].message = strdup(r[i].message.c_str());
+      }
+    }
+  }
+  return (int)r.size();
+}
+void validation_Club_set_name(validation_Club* v, const char* s) {
+  if (v->name) free(v->name);
+  v->name_len = s == nullptr ? 0 : strlen(s);
+  v->name = v->name_len == 0 ? nullptr : strdup(s);
+}
+int validation_League_size() {
+  return sizeof(validation_League);
+}
+void validation_League_init(validation_League* v) {
+  memset(v, 0, sizeof(validation_League));
+}
+void validation_League_destroy(validation_League* v) {
+  validation_Club_destroy(&v->champion);
+  for (int i = 0; i < v->clubs_len; i++) {
+    validation_Club_destroy(&v->clubs[i]);
+  }
+  if (v->clubs) free(v->clubs);
+  v->clubs = nullptr;
+  v->clubs_len = 0;
+}
+void validation_League_copy(const validation_League* a, validation_League* b) {
+  if (a == b) return;
+  ::validation::League u = validation_League_to_cpp(a);
+  validation_League_from_cpp(u, b);
+}
+bool validation_League_is_equal(const validation_League* a, const validation_League* b) {
+  if (a == b) return true;
+  ::validation::League ua = validation_League_to_cpp(a);
+  ::validation::League ub = validation_League_to_cpp(b);
+  return ua == ub;
+}
+int validation_League_to_json_size(const validation_League* v) {
+  ::validation::League u = validation_League_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void validation_League_to_json(const validation_League* v, char* json) {
+  ::validation::League u = validation_League_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int validation_League_from_json(const char* json, validation_League* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return validation_League_try_from_json(json, v, error) ? 0 : -1;
+}
+bool validation_League_try_from_json(const char* json, validation_League* v, jsonif_error* error) {
+  try {
+    ::validation::League u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    validation_League_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+int validation_League_validate(const validation_League* v, jsonif_violation** violations) {
+  ::validation::League u = validation_League_to_cpp(v);
+  std::vector<jsonif::violation> r = jsonif::validate(u);
+  if (violations != NULL) {
+    *violations = NULL;
+    if (!r.empty()) {
+      *violations = (jsonif_violation*)malloc(sizeof(jsonif_violation) * r.size());
+      for (size_t i = 0; i < r.size(); i++) {
+        (*violations)[i].path = strdup(r[i].path.c_str());
+        (*violations)[i].message = strdup(r[i].message.c_str());
+      }
+    }
+  }
+  return (int)r.size();
+}
+void validation_League_set_champion(validation_League* v, const validation_Club* m) {
+  validation_Club_copy(m, &v->champion);
+}
+void validation_League_alloc_clubs(validation_League* v, int num) {
+  if (v->clubs) free(v->clubs);
+  v->clubs = nullptr;
+  v->clubs_len = 0;
+  if (num != 0) {
+    v->clubs = (decltype(v->clubs))malloc(sizeof(v->clubs[0]) * num);
+    memset(v->clubs, 0, sizeof(v->clubs[0]) * num);
+    v->clubs_len = num;
+  }
+}
+
+void validation_League_set_clubs(validation_League* v, int n, const validation_Club* m) {
+  validation_Club_copy(m, &v->clubs[n]);
+}
+int validation_NoRules_size() {
+  return sizeof(validation_NoRules);
+}
+void validation_NoRules_init(validation_NoRules* v) {
+  memset(v, 0, sizeof(validation_NoRules));
+}
+void validation_NoRules_destroy(validation_NoRules* v) {
+  memset(&v->value, 0, sizeof(v->value));
+}
+void validation_NoRules_copy(const validation_NoRules* a, validation_NoRules* b) {
+  if (a == b) return;
+  ::validation::NoRules u = validation_NoRules_to_cpp(a);
+  validation_NoRules_from_cpp(u, b);
+}
+bool validation_NoRules_is_equal(const validation_NoRules* a, const validation_NoRules* b) {
+  if (a == b) return true;
+  ::validation::NoRules ua = validation_NoRules_to_cpp(a);
+  ::validation::NoRules ub = validation_NoRules_to_cpp(b);
+  return ua == ub;
+}
+int validation_NoRules_to_json_size(const validation_NoRules* v) {
+  ::validation::NoRules u = validation_NoRules_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void validation_NoRules_to_json(const validation_NoRules* v, char* json) {
+  ::validation::NoRules u = validation_NoRules_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int validation_NoRules_from_json(const char* json, validation_NoRules* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return validation_NoRules_try_from_json(json, v, error) ? 0 : -1;
+}
+bool validation_NoRules_try_from_json(const char* json, validation_NoRules* v, jsonif_error* error) {
+  try {
+    ::validation::NoRules u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    validation_NoRules_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void validation_NoRules_set_value(validation_NoRules* v, int32_t m) {
+  v->value = m;
+}
+
+}
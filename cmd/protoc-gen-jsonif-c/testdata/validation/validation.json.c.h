@@ -0,0 +1,267 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_VALIDATION_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_VALIDATION_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#include "google/protobuf/wrappers.json.c.h"
+
+#ifndef JSONIF_C_VIOLATION_DEFINED
+#define JSONIF_C_VIOLATION_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// 検証ルールの違反
+typedef struct {
+  // JSON でのフィールドの位置（people[3].name など）
+  char* path;
+  char* message;
+} jsonif_violation;
+
+// _validate で受け取った違反の配列を解放する
+static inline void jsonif_violations_free(jsonif_violation* violations, int len) {
+  for (int i = 0; i < len; i++) {
+    free(violations[i].path);
+    free(violations[i].message);
+  }
+  free(violations);
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_validation_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_validation_proto();
+}
+
+#endif
+
+// Role
+typedef int validation_Role;
+extern const validation_Role validation_ROLE_UNSPECIFIED;
+extern const validation_Role validation_ROLE_ADMIN;
+extern const validation_Role validation_ROLE_MEMBER;
+
+// _score
+typedef int validation_Person_ScoreCase;
+extern const validation_Person_ScoreCase validation_Person_ScoreCase_NOT_SET;
+extern const validation_Person_ScoreCase validation_Person_ScoreCase_kScore;
+
+// contact
+typedef int validation_Team_ContactCase;
+extern const validation_Team_ContactCase validation_Team_ContactCase_NOT_SET;
+extern const validation_Team_ContactCase validation_Team_ContactCase_kPhone;
+extern const validation_Team_ContactCase validation_Team_ContactCase_kContactPerson;
+
+// Person
+typedef struct {
+  char* name;
+  int name_len;
+  int32_t age;
+  char* email;
+  int email_len;
+  validation_Role role;
+  /// 各要素の長さと、要素数を検証する
+  char** tags;
+  int* tags_lens;
+  int tags_len;
+  double score;
+  float ratio;
+  validation_Person_ScoreCase _score_case;
+} validation_Person;
+
+int validation_Person_size();
+void validation_Person_init(validation_Person* v);
+void validation_Person_destroy(validation_Person*);
+void validation_Person_copy(const validation_Person* a, validation_Person* b);
+bool validation_Person_is_equal(const validation_Person* a, const validation_Person* b);
+int validation_Person_to_json_size(const validation_Person*);
+void validation_Person_to_json(const validation_Person*, char* json);
+int validation_Person_from_json(const char* json, validation_Person*);
+bool validation_Person_try_from_json(const char* json, validation_Person* v, jsonif_error* error);
+int validation_Person_validate(const validation_Person* v, jsonif_violation** violations);
+void validation_Person_set_name(validation_Person* v, const char* s);
+void validation_Person_set_age(validation_Person* v, int32_t m);
+void validation_Person_set_email(validation_Person* v, const char* s);
+void validation_Person_set_role(validation_Person* v, validation_Role m);
+void validation_Person_alloc_tags(validation_Person* v, int num);
+void validation_Person_set_tags(validation_Person* v, int n, const char* s);
+void validation_Person_set_score(validation_Person* v, double m);
+void validation_Person_set_ratio(validation_Person* v, float m);
+
+bool validation_Person_has_score(const validation_Person* v);
+void validation_Person_clear_score(validation_Person* v);
+void validation_Person_clear__score_case(validation_Person* v);
+// ByNameEntry
+typedef struct {
+  char* key;
+  int key_len;
+  validation_Person value;
+} validation_Team_ByNameEntry;
+
+int validation_Team_ByNameEntry_size();
+void validation_Team_ByNameEntry_init(validation_Team_ByNameEntry* v);
+void validation_Team_ByNameEntry_destroy(validation_Team_ByNameEntry*);
+void validation_Team_ByNameEntry_set_key(validation_Team_ByNameEntry* v, const char* s);
+void validation_Team_ByNameEntry_set_value(validation_Team_ByNameEntry* v, const validation_Person* m);
+
+// LimitsEntry
+typedef struct {
+  int32_t key;
+  int64_t value;
+} validation_Team_LimitsEntry;
+
+int validation_Team_LimitsEntry_size();
+void validation_Team_LimitsEntry_init(validation_Team_LimitsEntry* v);
+void validation_Team_LimitsEntry_destroy(validation_Team_LimitsEntry*);
+void validation_Team_LimitsEntry_set_key(validation_Team_LimitsEntry* v, int32_t m);
+void validation_Team_LimitsEntry_set_value(validation_Team_LimitsEntry* v, int64_t m);
+
+// Team
+/// 検証ルールのあるメッセージをフィールドに持つメッセージも検証する
+typedef struct {
+  validation_Person leader;
+  validation_Person* members;
+  int members_len;
+  validation_Team_ByNameEntry* by_name;
+  int by_name_len;
+  validation_Team_LimitsEntry* limits;
+  int limits_len;
+  google_protobuf_Int32Value size;
+  char* phone;
+  int phone_len;
+  validation_Person contact_person;
+  validation_Team_ContactCase contact_case;
+} validation_Team;
+
+int validation_Team_size();
+void validation_Team_init(validation_Team* v);
+void validation_Team_destroy(validation_Team*);
+void validation_Team_copy(const validation_Team* a, validation_Team* b);
+bool validation_Team_is_equal(const validation_Team* a, const validation_Team* b);
+int validation_Team_to_json_size(const validation_Team*);
+void validation_Team_to_json(const validation_Team*, char* json);
+int validation_Team_from_json(const char* json, validation_Team*);
+bool validation_Team_try_from_json(const char* json, validation_Team* v, jsonif_error* error);
+int validation_Team_validate(const validation_Team* v, jsonif_violation** violations);
+void validation_Team_set_leader(validation_Team* v, const validation_Person* m);
+void validation_Team_alloc_members(validation_Team* v, int num);
+void validation_Team_set_members(validation_Team* v, int n, const validation_Person* m);
+void validation_Team_alloc_by_name(validation_Team* v, int num);
+void validation_Team_alloc_limits(validation_Team* v, int num);
+void validation_Team_set_size(validation_Team* v, const google_protobuf_Int32Value* m);
+void validation_Team_set_phone(validation_Team* v, const char* s);
+void validation_Team_set_contact_person(validation_Team* v, const validation_Person* m);
+
+void validation_Team_clear_phone(validation_Team* v);
+void validation_Team_clear_contact_person(validation_Team* v);
+void validation_Team_clear_contact_case(validation_Team* v);
+// Club
+typedef struct {
+  char* name;
+  int name_len;
+} validation_Club;
+
+int validation_Club_size();
+void validation_Club_init(validation_Club* v);
+void validation_Club_destroy(validation_Club*);
+void validation_Club_copy(const validation_Club* a, validation_Club* b);
+bool validation_Club_is_equal(const validation_Club* a, const validation_Club* b);
+int validation_Club_to_json_size(const validation_Club*);
+void validation_Club_to_json(const validation_Club*, char* json);
+int validation_Club_from_json(const char* json, validation_Club*);
+bool validation_Club_try_from_json(const char* json, validation_Club* v, jsonif_error* error);
+int validation_Club_validate(const validation_Club* v, jsonif_violation** violations);
+void validation_Club_set_name(validation_Club* v, const char* s);
+
+// League
+/// 後で宣言されるメッセージをフィールドに持つメッセージも検証する
+typedef struct {
+  validation_Club champion;
+  validation_Club* clubs;
+  int clubs_len;
+} validation_League;
+
+int validation_League_size();
+void validation_League_init(validation_League* v);
+void validation_League_destroy(validation_League*);
+void validation_League_copy(const validation_League* a, validation_League* b);
+bool validation_League_is_equal(const validation_League* a, const validation_League* b);
+int validation_League_to_json_size(const validation_League*);
+void validation_League_to_json(const validation_League*, char* json);
+int validation_League_from_json(const char* json, validation_League*);
+bool validation_League_try_from_json(const char* json, validation_League* v, jsonif_error* error);
+int validation_League_validate(const validation_League* v, jsonif_violation** violations);
+void validation_League_set_champion(validation_League* v, const validation_Club* m);
+void validation_League_alloc_clubs(validation_League* v, int num);
+void validation_League_set_clubs(validation_League* v, int n, const validation_Club* m);
+
+// NoRules
+/// 検証ルールが無いメッセージは validate を生成しない
+typedef struct {
+  int32_t value;
+} validation_NoRules;
+
+int validation_NoRules_size();
+void validation_NoRules_init(validation_NoRules* v);
+void validation_NoRules_destroy(validation_NoRules*);
+void validation_NoRules_copy(const validation_NoRules* a, validation_NoRules* b);
+bool validation_NoRules_is_equal(const validation_NoRules* a, const validation_NoRules* b);
+int validation_NoRules_to_json_size(const validation_NoRules*);
+void validation_NoRules_to_json(const validation_NoRules*, char* json);
+int validation_NoRules_from_json(const char* json, validation_NoRules*);
+bool validation_NoRules_try_from_json(const char* json, validation_NoRules* v, jsonif_error* error);
+void validation_NoRules_set_value(validation_NoRules* v, int32_t m);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,20 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_VALIDATION_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_VALIDATION_PROTO
+
+#include "validation.json.h"
+#include "validation.json.c.h"
+
+#include "google/protobuf/wrappers.json.c.hpp"
+
+::validation::Person validation_Person_to_cpp(const validation_Person* v);
+void validation_Person_from_cpp(const ::validation::Person& u, validation_Person* v);
+::validation::Team validation_Team_to_cpp(const validation_Team* v);
+void validation_Team_from_cpp(const ::validation::Team& u, validation_Team* v);
+::validation::Club validation_Club_to_cpp(const validation_Club* v);
+void validation_Club_from_cpp(const ::validation::Club& u, validation_Club* v);
+::validation::League validation_League_to_cpp(const validation_League* v);
+void validation_League_from_cpp(const ::validation::League& u, validation_League* v);
+::validation::NoRules validation_NoRules_to_cpp(const validation_NoRules* v);
+void validation_NoRules_from_cpp(const ::validation::NoRules& u, validation_NoRules* v);
+
+#endif
@@ -0,0 +1,313 @@
+#include "wellknown.json.c.h"
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "wellknown.json.h"
+
+#include "google/protobuf/duration.json.c.hpp"
+#include "google/protobuf/timestamp.json.c.hpp"
+#include "google/protobuf/wrappers.json.c.hpp"
+
+#if !defined(JSONIF_TRY_FROM_JSON_DEFINED)
+#error "wellknown.proto requires C++ headers generated with the try_from_json parameter"
+#endif
+
+// error が NULL でなければ、path と message をコピーして設定する
+static void jsonif_set_error(jsonif_error* error, const char* path, const char* message) {
+  if (error == NULL) return;
+  // 前のエラーが残っていても漏れないように解放してから設定する
+  jsonif_error_free(error);
+  error->path = strdup(path);
+  error->message = strdup(message);
+}
+
+// _from_json で最後に失敗した時のエラー
+// どのファイルの関数からも同じ変数を使うように、inline 関数の static 変数にする
+struct jsonif_last_error_holder {
+  jsonif_error error = {NULL, NULL};
+  ~jsonif_last_error_holder() { jsonif_error_free(&error); }
+};
+inline jsonif_last_error_holder& jsonif_last_error_storage() {
+  thread_local jsonif_last_error_holder holder;
+  return holder;
+}
+
+// value
+const wellknown_Test_ValueCase wellknown_Test_ValueCase_NOT_SET = 0;
+const wellknown_Test_ValueCase wellknown_Test_ValueCase_kOneofDuration = 14;
+const wellknown_Test_ValueCase wellknown_Test_ValueCase_kOneofString = 15;
+
+::wellknown::Test wellknown_Test_to_cpp(const wellknown_Test* v) {
+  ::wellknown::Test u;
+  u.timestamp = google_protobuf_Timestamp_to_cpp(&v->timestamp);
+  u.duration = google_protobuf_Duration_to_cpp(&v->duration);
+  u.double_value = google_protobuf_DoubleValue_to_cpp(&v->double_value);
+  u.float_value = google_protobuf_FloatValue_to_cpp(&v->float_value);
+  u.int64_value = google_protobuf_Int64Value_to_cpp(&v->int64_value);
+  u.uint64_value = google_protobuf_UInt64Value_to_cpp(&v->uint64_value);
+  u.int32_value = google_protobuf_Int32Value_to_cpp(&v->int32_value);
+  u.uint32_value = google_protobuf_UInt32Value_to_cpp(&v->uint32_value);
+  u.bool_value = google_protobuf_BoolValue_to_cpp(&v->bool_value);
+  u.string_value = google_protobuf_StringValue_to_cpp(&v->string_value);
+  for (int i = 0; i < v->timestamps_len; i++) {
+    u.timestamps.push_back(google_protobuf_Timestamp_to_cpp(&v->timestamps[i]));
+  }
+  for (int i = 0; i < v->int32_values_len; i++) {
+    u.int32_values.push_back(google_protobuf_Int32Value_to_cpp(&v->int32_values[i]));
+  }
+  for (int i = 0; i < v->durations_len; i++) {
+    decltype(u.durations)::key_type key{};
+    decltype(u.durations)::mapped_type value{};
+    if (v->durations[i].key_len != 0) key = std::string(v->durations[i].key, v->durations[i].key_len);
+    value = google_protobuf_Duration_to_cpp(&v->durations[i].value);
+    u.durations.emplace(std::move(key), std::move(value));
+  }
+  u.oneof_duration = google_protobuf_Duration_to_cpp(&v->oneof_duration);
+  u.oneof_string = google_protobuf_StringValue_to_cpp(&v->oneof_string);
+  u.value_case = (::wellknown::Test::ValueCase)v->value_case;
+  return u;
+}
+void wellknown_Test_from_cpp(const ::wellknown::Test& u, wellknown_Test* v) {
+  wellknown_Test_destroy(v);
+  memset(v, 0, sizeof(wellknown_Test));
+  google_protobuf_Timestamp_from_cpp(u.timestamp, &v->timestamp);
+  google_protobuf_Duration_from_cpp(u.duration, &v->duration);
+  google_protobuf_DoubleValue_from_cpp(u.double_value, &v->double_value);
+  google_protobuf_FloatValue_from_cpp(u.float_value, &v->float_value);
+  google_protobuf_Int64Value_from_cpp(u.int64_value, &v->int64_value);
+  google_protobuf_UInt64Value_from_cpp(u.uint64_value, &v->uint64_value);
+  google_protobuf_Int32Value_from_cpp(u.int32_value, &v->int32_value);
+  google_protobuf_UInt32Value_from_cpp(u.uint32_value, &v->uint32_value);
+  google_protobuf_BoolValue_from_cpp(u.bool_value, &v->bool_value);
+  google_protobuf_StringValue_from_cpp(u.string_value, &v->string_value);
+  v->timestamps_len = (int)u.timestamps.size();
+  v->timestamps = v->timestamps_len == 0 ? nullptr : (decltype(v->timestamps))malloc(sizeof(v->timestamps[0]) * u.timestamps.size());
+  for (int i = 0; i < (int)u.timestamps.size(); i++) {
+    google_protobuf_Timestamp_init(&v->timestamps[i]);
+    google_protobuf_Timestamp_from_cpp(u.timestamps[i], &v->timestamps[i]);
+  }
+  v->int32_values_len = (int)u.int32_values.size();
+  v->int32_values = v->int32_values_len == 0 ? nullptr : (decltype(v->int32_values))malloc(sizeof(v->int32_values[0]) * u.int32_values.size());
+  for (int i = 0; i < (int)u.int32_values.size(); i++) {
+    google_protobuf_Int32Value_init(&v->int32_values[i]);
+    google_protobuf_Int32Value_from_cpp(u.int32_values[i], &v->int32_values[i]);
+  }
+  v->durations_len = (int)u.durations.size();
+  v->durations = v->durations_len == 0 ? nullptr : (decltype(v->durations))malloc(sizeof(v->durations[0]) * u.durations.size());
+  int durations_index = 0;
+  for (const auto& kv : u.durations) {
+    wellknown_Test_DurationsEntry_init(&v->durations[durations_index]);
+    if (!kv.first.empty()) v->durations[durations_index].key = strdup(kv.first.c_str());
+    v->durations[durations_index].key_len = (int)kv.first.size();
+    google_protobuf_Duration_from_cpp(kv.second, &v->durations[durations_index].value);
+    durations_index++;
+  }
+  google_protobuf_Duration_from_cpp(u.oneof_duration, &v->oneof_duration);
+  google_protobuf_StringValue_from_cpp(u.oneof_string, &v->oneof_string);
+  v->value_case = (int)u.value_case;
+}
+extern "C" {
+
+const jsonif_error* jsonif_last_error_wellknown_proto(void) {
+  return &jsonif_last_error_storage().error;
+}
+
+int wellknown_Test_DurationsEntry_size() {
+  return sizeof(wellknown_Test_DurationsEntry);
+}
+void wellknown_Test_DurationsEntry_init(wellknown_Test_DurationsEntry* v) {
+  memset(v, 0, sizeof(wellknown_Test_DurationsEntry));
+}
+void wellknown_Test_DurationsEntry_destroy(wellknown_Test_DurationsEntry* v) {
+  if (v->key) free(v->key);
+  v->key = nullptr;
+  v->key_len = 0;
+  google_protobuf_Duration_destroy(&v->value);
+}
+void wellknown_Test_DurationsEntry_set_key(wellknown_Test_DurationsEntry* v, const char* s) {
+  if (v->key) free(v->key);
+  v->key_len = s == nullptr ? 0 : strlen(s);
+  v->key = v->key_len == 0 ? nullptr : strdup(s);
+}
+void wellknown_Test_DurationsEntry_set_value(wellknown_Test_DurationsEntry* v, const google_protobuf_Duration* m) {
+  google_protobuf_Duration_copy(m, &v->value);
+}
+int wellknown_Test_size() {
+  return sizeof(wellknown_Test);
+}
+void wellknown_Test_init(wellknown_Test* v) {
+  memset(v, 0, sizeof(wellknown_Test));
+}
+void wellknown_Test_destroy(wellknown_Test* v) {
+  google_protobuf_Timestamp_destroy(&v->timestamp);
+  google_protobuf_Duration_destroy(&v->duration);
+  google_protobuf_DoubleValue_destroy(&v->double_value);
+  google_protobuf_FloatValue_destroy(&v->float_value);
+  google_protobuf_Int64Value_destroy(&v->int64_value);
+  google_protobuf_UInt64Value_destroy(&v->uint64_value);
+  google_protobuf_Int32Value_destroy(&v->int32_value);
+  google_protobuf_UInt32Value_destroy(&v->uint32_value);
+  google_protobuf_BoolValue_destroy(&v->bool_value);
+  google_protobuf_StringValue_destroy(&v->string_value);
+  for (int i = 0; i < v->timestamps_len; i++) {
+    google_protobuf_Timestamp_destroy(&v->timestamps[i]);
+  }
+  if (v->timestamps) free(v->timestamps);
+  v->timestamps = nullptr;
+  v->timestamps_len = 0;
+  for (int i = 0; i < v->int32_values_len; i++) {
+    google_protobuf_Int32Value_destroy(&v->int32_values[i]);
+  }
+  if (v->int32_values) free(v->int32_values);
+  v->int32_values = nullptr;
+  v->int32_values_len = 0;
+  for (int i = 0; i < v->durations_len; i++) {
+    wellknown_Test_DurationsEntry_destroy(&v->durations[i]);
+  }
+  if (v->durations) free(v->durations);
+  v->durations = nullptr;
+  v->durations_len = 0;
+  google_protobuf_Duration_destroy(&v->oneof_duration);
+  google_protobuf_StringValue_destroy(&v->oneof_string);
+}
+void wellknown_Test_copy(const wellknown_Test* a, wellknown_Test* b) {
+  if (a == b) return;
+  ::wellknown::Test u = wellknown_Test_to_cpp(a);
+  wellknown_Test_from_cpp(u, b);
+}
+bool wellknown_Test_is_equal(const wellknown_Test* a, const wellknown_Test* b) {
+  if (a == b) return true;
+  ::wellknown::Test ua = wellknown_Test_to_cpp(a);
+  ::wellknown::Test ub = wellknown_Test_to_cpp(b);
+  return ua == ub;
+}
+int wellknown_Test_to_json_size(const wellknown_Test* v) {
+  ::wellknown::Test u = wellknown_Test_to_cpp(v);
+  return jsonif::to_json(u).size() + 1;
+}
+void wellknown_Test_to_json(const wellknown_Test* v, char* json) {
+  ::wellknown::Test u = wellknown_Test_to_cpp(v);
+  std::string str = jsonif::to_json(u);
+  memcpy(json, str.c_str(), str.size() + 1);
+}
+int wellknown_Test_from_json(const char* json, wellknown_Test* v) {
+  jsonif_error* error = &jsonif_last_error_storage().error;
+  jsonif_error_free(error);
+  return wellknown_Test_try_from_json(json, v, error) ? 0 : -1;
+}
+bool wellknown_Test_try_from_json(const char* json, wellknown_Test* v, jsonif_error* error) {
+  try {
+    ::wellknown::Test u;
+    jsonif::error e;
+    if (!jsonif::try_from_json(json, u, e)) {
+      jsonif_set_error(error, e.path.c_str(), e.message.c_str());
+      return false;
+    }
+    wellknown_Test_from_cpp(u, v);
+    return true;
+  } catch (const jsonif::parse_error& e) {
+    jsonif_set_error(error, e.path().c_str(), e.message().c_str());
+  } catch (const std::exception& e) {
+    jsonif_set_error(error, "", e.what());
+  } catch (...) {
+    jsonif_set_error(error, "", "unknown error");
+  }
+  return false;
+}
+void wellknown_Test_set_timestamp(wellknown_Test* v, const google_protobuf_Timestamp* m) {
+  google_protobuf_Timestamp_copy(m, &v->timestamp);
+}
+void wellknown_Test_set_duration(wellknown_Test* v, const google_protobuf_Duration* m) {
+  google_protobuf_Duration_copy(m, &v->duration);
+}
+void wellknown_Test_set_double_value(wellknown_Test* v, const google_protobuf_DoubleValue* m) {
+  google_protobuf_DoubleValue_copy(m, &v->double_value);
+}
+void wellknown_Test_set_float_value(wellknown_Test* v, const google_protobuf_FloatValue* m) {
+  google_protobuf_FloatValue_copy(m, &v->float_value);
+}
+void wellknown_Test_set_int64_value(wellknown_Test* v, const google_protobuf_Int64Value* m) {
+  google_protobuf_Int64Value_copy(m, &v->int64_value);
+}
+void wellknown_Test_set_uint64_value(wellknown_Test* v, const google_protobuf_UInt64Value* m) {
+  google_protobuf_UInt64Value_copy(m, &v->uint64_value);
+}
+void wellknown_Test_set_int32_value(wellknown_Test* v, const google_protobuf_Int32Value* m) {
+  google_protobuf_Int32Value_copy(m, &v->int32_value);
+}
+void wellknown_Test_set_uint32_value(wellknown_Test* v, const google_protobuf_UInt32Value* m) {
+  google_protobuf_UInt32Value_copy(m, &v->uint32_value);
+}
+void wellknown_Test_set_bool_value(wellknown_Test* v, const google_protobuf_BoolValue* m) {
+  google_protobuf_BoolValue_copy(m, &v->bool_value);
+}
+void wellknown_Test_set_string_value(wellknown_Test* v, const google_protobuf_StringValue* m) {
+  google_protobuf_StringValue_copy(m, &v->string_value);
+}
+void wellknown_Test_alloc_timestamps(wellknown_Test* v, int num) {
+  if (v->timestamps) free(v->timestamps);
+  v->timestamps = nullptr;
+  v->timestamps_len = 0;
+  if (num != 0) {
+    v->timestamps = (decltype(v->timestamps))malloc(sizeof(v->timestamps[0]) * num);
+    memset(v->timestamps, 0, sizeof(v->timestamps[0]) * num);
+    v->timestamps_len = num;
+  }
+}
+
+void wellknown_Test_set_timestamps(wellknown_Test* v, int n, const google_protobuf_Timestamp* m) {
+  google_protobuf_Timestamp_copy(m, &v->timestamps[n]);
+}
+void wellknown_Test_alloc_int32_values(wellknown_Test* v, int num) {
+  if (v->int32_values) free(v->int32_values);
+  v->int32_values = nullptr;
+  v->int32_values_len = 0;
+  if (num != 0) {
+    v->int32_values = (decltype(v->int32_values))malloc(sizeof(v->int32_values[0]) * num);
+    memset(v->int32_values, 0, sizeof(v->int32_values[0]) * num);
+    v->int32_values_len = num;
+  }
+}
+
+void wellknown_Test_set_int32_values(wellknown_Test* v, int n, const google_protobuf_Int32Value* m) {
+  google_protobuf_Int32Value_copy(m, &v->int32_values[n]);
+}
+void wellknown_Test_alloc_durations(wellknown_Test* v, int num) {
+  if (v->durations) free(v->durations);
+  v->durations = nullptr;
+  v->durations_len = 0;
+  if (num != 0) {
+    v->durations = (decltype(v->durations))malloc(sizeof(v->durations[0]) * num);
+    memset(v->durations, 0, sizeof(v->durations[0]) * num);
+    v->durations_len = num;
+  }
+}
+
+void wellknown_Test_set_oneof_duration(wellknown_Test* v, const google_protobuf_Duration* m) {
+  wellknown_Test_clear_value_case(v);
+  v->value_case = wellknown_Test_ValueCase_kOneofDuration;
+  google_protobuf_Duration_copy(m, &v->oneof_duration);
+}
+void wellknown_Test_set_oneof_string(wellknown_Test* v, const google_protobuf_StringValue* m) {
+  wellknown_Test_clear_value_case(v);
+  v->value_case = wellknown_Test_ValueCase_kOneofString;
+  google_protobuf_StringValue_copy(m, &v->oneof_string);
+}
+void wellknown_Test_clear_oneof_duration(wellknown_Test* v) {
+  if (v->value_case == wellknown_Test_ValueCase_kOneofDuration) {
+    wellknown_Test_clear_value_case(v);
+  }
+}
+void wellknown_Test_clear_oneof_string(wellknown_Test* v) {
+  if (v->value_case == wellknown_Test_ValueCase_kOneofString) {
+    wellknown_Test_clear_value_case(v);
+  }
+}
+void wellknown_Test_clear_value_case(wellknown_Test* v) {
+  google_protobuf_Duration_destroy(&v->oneof_duration);
+  google_protobuf_StringValue_destroy(&v->oneof_string);
+  v->value_case = wellknown_Test_ValueCase_NOT_SET;
+}
+
+}
@@ -0,0 +1,135 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_C_WELLKNOWN_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_C_WELLKNOWN_PROTO
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#include "google/protobuf/duration.json.c.h"
+#include "google/protobuf/timestamp.json.c.h"
+#include "google/protobuf/wrappers.json.c.h"
+
+#ifndef JSONIF_C_ERROR_DEFINED
+#define JSONIF_C_ERROR_DEFINED
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// JSON を読み込めなかった理由
+typedef struct {
+  // JSON での位置（people[3].name など）。位置が分からない場合は空文字列
+  char* path;
+  char* message;
+} jsonif_error;
+
+// _try_from_json で受け取ったエラーを解放する
+static inline void jsonif_error_free(jsonif_error* error) {
+  free(error->path);
+  free(error->message);
+  error->path = NULL;
+  error->message = NULL;
+}
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+const jsonif_error* jsonif_last_error_wellknown_proto(void);
+
+#ifndef JSONIF_C_LAST_ERROR_DEFINED
+#define JSONIF_C_LAST_ERROR_DEFINED
+
+// 同じスレッドで最後に呼び出した _from_json のエラー
+// 成功した場合は path と message が NULL になる。次に _from_json を呼び出すまで有効
+static inline const jsonif_error* jsonif_last_error(void) {
+  return jsonif_last_error_wellknown_proto();
+}
+
+#endif
+
+// value
+typedef int wellknown_Test_ValueCase;
+extern const wellknown_Test_ValueCase wellknown_Test_ValueCase_NOT_SET;
+extern const wellknown_Test_ValueCase wellknown_Test_ValueCase_kOneofDuration;
+extern const wellknown_Test_ValueCase wellknown_Test_ValueCase_kOneofString;
+
+// DurationsEntry
+typedef struct {
+  char* key;
+  int key_len;
+  google_protobuf_Duration value;
+} wellknown_Test_DurationsEntry;
+
+int wellknown_Test_DurationsEntry_size();
+void wellknown_Test_DurationsEntry_init(wellknown_Test_DurationsEntry* v);
+void wellknown_Test_DurationsEntry_destroy(wellknown_Test_DurationsEntry*);
+void wellknown_Test_DurationsEntry_set_key(wellknown_Test_DurationsEntry* v, const char* s);
+void wellknown_Test_DurationsEntry_set_value(wellknown_Test_DurationsEntry* v, const google_protobuf_Duration* m);
+
+// Test
+typedef struct {
+  google_protobuf_Timestamp timestamp;
+  google_protobuf_Duration duration;
+  google_protobuf_DoubleValue double_value;
+  google_protobuf_FloatValue float_value;
+  google_protobuf_Int64Value int64_value;
+  google_protobuf_UInt64Value uint64_value;
+  google_protobuf_Int32Value int32_value;
+  google_protobuf_UInt32Value uint32_value;
+  google_protobuf_BoolValue bool_value;
+  google_protobuf_StringValue string_value;
+  google_protobuf_Timestamp* timestamps;
+  int timestamps_len;
+  google_protobuf_Int32Value* int32_values;
+  int int32_values_len;
+  wellknown_Test_DurationsEntry* durations;
+  int durations_len;
+  google_protobuf_Duration oneof_duration;
+  google_protobuf_StringValue oneof_string;
+  wellknown_Test_ValueCase value_case;
+} wellknown_Test;
+
+int wellknown_Test_size();
+void wellknown_Test_init(wellknown_Test* v);
+void wellknown_Test_destroy(wellknown_Test*);
+void wellknown_Test_copy(const wellknown_Test* a, wellknown_Test* b);
+bool wellknown_Test_is_equal(const wellknown_Test* a, const wellknown_Test* b);
+int wellknown_Test_to_json_size(const wellknown_Test*);
+void wellknown_Test_to_json(const wellknown_Test*, char* json);
+int wellknown_Test_from_json(const char* json, wellknown_Test*);
+bool wellknown_Test_try_from_json(const char* json, wellknown_Test* v, jsonif_error* error);
+void wellknown_Test_set_timestamp(wellknown_Test* v, const google_protobuf_Timestamp* m);
+void wellknown_Test_set_duration(wellknown_Test* v, const google_protobuf_Duration* m);
+void wellknown_Test_set_double_value(wellknown_Test* v, const google_protobuf_DoubleValue* m);
+void wellknown_Test_set_float_value(wellknown_Test* v, const google_protobuf_FloatValue* m);
+void wellknown_Test_set_int64_value(wellknown_Test* v, const google_protobuf_Int64Value* m);
+void wellknown_Test_set_uint64_value(wellknown_Test* v, const google_protobuf_UInt64Value* m);
+void wellknown_Test_set_int32_value(wellknown_Test* v, const google_protobuf_Int32Value* m);
+void wellknown_Test_set_uint32_value(wellknown_Test* v, const google_protobuf_UInt32Value* m);
+void wellknown_Test_set_bool_value(wellknown_Test* v, const google_protobuf_BoolValue* m);
+void wellknown_Test_set_string_value(wellknown_Test* v, const google_protobuf_StringValue* m);
+void wellknown_Test_alloc_timestamps(wellknown_Test* v, int num);
+void wellknown_Test_set_timestamps(wellknown_Test* v, int n, const google_protobuf_Timestamp* m);
+void wellknown_Test_alloc_int32_values(wellknown_Test* v, int num);
+void wellknown_Test_set_int32_values(wellknown_Test* v, int n, const google_protobuf_Int32Value* m);
+void wellknown_Test_alloc_durations(wellknown_Test* v, int num);
+void wellknown_Test_set_oneof_duration(wellknown_Test* v, const google_protobuf_Duration* m);
+void wellknown_Test_set_oneof_string(wellknown_Test* v, const google_protobuf_StringValue* m);
+
+void wellknown_Test_clear_oneof_duration(wellknown_Test* v);
+void wellknown_Test_clear_oneof_string(wellknown_Test* v);
+void wellknown_Test_clear_value_case(wellknown_Test* v);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
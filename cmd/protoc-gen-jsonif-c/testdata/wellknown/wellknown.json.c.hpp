@@ -0,0 +1,14 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_WELLKNOWN_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_HPP_WELLKNOWN_PROTO
+
+#include "wellknown.json.h"
+#include "wellknown.json.c.h"
+
+#include "google/protobuf/duration.json.c.hpp"
+#include "google/protobuf/timestamp.json.c.hpp"
+#include "google/protobuf/wrappers.json.c.hpp"
+
+::wellknown::Test wellknown_Test_to_cpp(const wellknown_Test* v);
+void wellknown_Test_from_cpp(const ::wellknown::Test& u, wellknown_Test* v);
+
+#endif
@@ -7,51 +7,93 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/melpon/protoc-gen-jsonif/cmd/generated"
 	"github.com/melpon/protoc-gen-jsonif/cmd/internal"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
+// プラグインパラメータ
+type options struct {
+	internal.CommonOptions
+	// 利用する JSON ライブラリ。空の場合は JSONIF_USE_NLOHMANN_JSON マクロで切り替える
+	Backend string
+	// map<K, V> に使うコンテナ。空の場合は std::map
+	MapType string
+	// deprecated なフィールドのキーが JSON にあった場合に jsonif::deprecated_field_handler() を呼ぶ
+	ReportDeprecated bool
+	// jsonif::try_from_json と、全てのメッセージの jsonif_check を生成する
+	TryFromJson bool
+}
+
+func newOptionSet(opts *options) *internal.OptionSet {
+	s := &internal.OptionSet{}
+	opts.CommonOptions.Register(s)
+	s.String("backend", &opts.Backend, "boost", "nlohmann")
+	s.String("map_type", &opts.MapType, "map", "unordered_map")
+	s.Bool("report_deprecated", &opts.ReportDeprecated)
+	s.Bool("try_from_json", &opts.TryFromJson)
+	return s
+}
+
 type cppFile struct {
 	Top        internal.Formatter
 	Bottom     internal.Formatter
 	Typedefs   internal.Formatter
 	TagInvokes internal.Formatter
+	// jsonif::type_name の特殊化（パッケージの名前空間の外に出力する）
+	TypeNames internal.Formatter
 }
 
 func (cpp *cppFile) String() string {
-	return cpp.Top.String() + cpp.Typedefs.String() + cpp.TagInvokes.String() + cpp.Bottom.String()
+	typeNames := cpp.TypeNames.String()
+	if len(typeNames) != 0 {
+		typeNames = "namespace jsonif {\n\n" + typeNames + "\n}\n\n"
+	}
+	return cpp.Top.String() + cpp.Typedefs.String() + cpp.TagInvokes.String() + typeNames + cpp.Bottom.String()
 }
 
-func toQualifiedName(name string, pkg *string, parents []*descriptorpb.DescriptorProto) (string, error) {
-	qualifiedName := ""
-	if pkg != nil {
-		qualifiedName += "::" + strings.ReplaceAll(*pkg, ".", "::")
+// 予約語と同じ名前の場合は後ろに _ を付ける
+func escapeName(name string) string {
+	if internal.CppKeywords[name] {
+		return name + "_"
 	}
-	for _, parent := range parents {
-		qualifiedName += "::" + *parent.Name
+	return name
+}
+
+// フィールドの変数名
+func toFieldName(field *internal.Field) string {
+	return escapeName(internal.ToSnakeCase(field.Name))
+}
+
+// pkg.Parent.Name を ::pkg::Parent::Name に変換する
+func toQualifiedName(fullName string) string {
+	xs := strings.Split(fullName, ".")
+	for i, x := range xs {
+		xs[i] = escapeName(x)
 	}
-	qualifiedName += "::" + name
-	return qualifiedName, nil
+	return "::" + strings.Join(xs, "::")
 }
-func toEnumQualifiedName(name string, pkg *string, parents []*descriptorpb.DescriptorProto) (string, error) {
-	qualifiedName := ""
-	if pkg != nil {
-		qualifiedName += "::" + strings.ReplaceAll(*pkg, ".", "::")
+
+// enum の値が定義されるスコープ
+func toEnumQualifiedName(enum *internal.Enum) string {
+	if enum.Parent != nil {
+		return toQualifiedName(enum.Parent.FullName)
 	}
-	for _, parent := range parents {
-		qualifiedName += "::" + *parent.Name
+	if len(enum.File.Package) != 0 {
+		return toQualifiedName(enum.File.Package)
 	}
-	return qualifiedName, nil
+	return ""
 }
 
-func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, string, error) {
-	isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+// google.protobuf.Timestamp の型
+// ナノ秒の精度を保つために、system_clock::time_point ではなく nanoseconds を使う
+const timestampType = "std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>"
+
+func toTypeName(field *internal.Field) (string, string, error) {
 	typeName := ""
 	defaultValue := ""
-	switch *field.Type {
+	switch field.Type {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
 		typeName = "double"
 		defaultValue = "0"
@@ -85,40 +127,223 @@ func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, string, error
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
 		typeName = "std::string"
 		defaultValue = ""
-	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
-		descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		typeName = toQualifiedName(field.Enum.FullName)
+		defaultValue = fmt.Sprintf("(%s)0", typeName)
+	case descriptorpb.FieldDescriptorProto_TYPE_GROUP,
 		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		typeName = strings.ReplaceAll(*field.TypeName, ".", "::")
-		if *field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
-			defaultValue = fmt.Sprintf("(%s)0", typeName)
+		switch field.WellKnownType() {
+		case internal.WellKnownTimestamp:
+			typeName = timestampType
+		case internal.WellKnownDuration:
+			typeName = "std::chrono::nanoseconds"
+			defaultValue = "std::chrono::nanoseconds::zero()"
+		case internal.WellKnownWrapper:
+			valueType, _, err := toTypeName(field.Message.WrapperValue())
+			if err != nil {
+				return "", "", err
+			}
+			typeName = fmt.Sprintf("std::optional<%s>", valueType)
+		case internal.WellKnownStruct, internal.WellKnownValue, internal.WellKnownListValue:
+			typeName = "::jsonif::json_value"
+		case internal.WellKnownAny:
+			typeName = "::jsonif::any"
+		default:
+			typeName = toQualifiedName(field.Message.FullName)
 		}
 	default:
 		return "", "", errors.New("invalid type")
 	}
 
-	if isRepeated {
+	if field.Repeated {
 		return fmt.Sprintf("std::vector<%s>", typeName), "", nil
+	} else if field.HasDefault && field.Enum != nil {
+		return typeName, fmt.Sprintf("(%s)%s", typeName, field.Default), nil
+	} else if field.HasDefault {
+		return typeName, internal.CLiteral(field, field.Default), nil
 	} else {
 		return typeName, defaultValue, nil
 	}
 }
 
-func genEnum(enum *descriptorpb.EnumDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cppFile) error {
-	cpp.Typedefs.PI("enum %s {", *enum.Name)
-	for _, v := range enum.Value {
-		cpp.Typedefs.P("%s = %d,", *v.Name, *v.Number)
+// map<K, V> の型名
+func toMapTypeName(field *internal.Field, opts *options) (string, error) {
+	keyType, _, err := toTypeName(field.MapKey)
+	if err != nil {
+		return "", err
 	}
-	cpp.Typedefs.PD("};")
-	cpp.Typedefs.P("")
-
-	qName, err := toQualifiedName(*enum.Name, pkg, parents)
+	valueType, _, err := toTypeName(field.MapValue)
 	if err != nil {
-		return err
+		return "", err
 	}
-	qEnumName, err := toEnumQualifiedName(*enum.Name, pkg, parents)
+	container := "std::map"
+	if opts.MapType == "unordered_map" {
+		container = "std::unordered_map"
+	}
+	return fmt.Sprintf("%s<%s, %s>", container, keyType, valueType), nil
+}
+
+// JSON のキーは文字列なので、map のキーを文字列に変換する式
+func toMapKeyString(field *internal.Field, expr string) string {
+	switch field.MapKey.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return expr
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return fmt.Sprintf("(%s ? \"true\" : \"false\")", expr)
+	default:
+		return fmt.Sprintf("std::to_string(%s)", expr)
+	}
+}
+
+// 文字列の expr を map のキーに変換する式
+func fromMapKeyString(field *internal.Field, expr string) (string, error) {
+	keyType, _, err := toTypeName(field.MapKey)
 	if err != nil {
-		return err
+		return "", err
+	}
+	switch field.MapKey.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return expr, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return fmt.Sprintf("%s == \"true\"", expr), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return fmt.Sprintf("(%s)std::stoull(%s)", keyType, expr), nil
+	default:
+		return fmt.Sprintf("(%s)std::stoll(%s)", keyType, expr), nil
+	}
+}
+
+// 値の変換に使う ::jsonif::detail の関数名の接頭辞
+// 特別な変換が不要な場合は空文字列を返す
+func toHelperName(field *internal.Field) string {
+	switch {
+	case field.Int64AsString:
+		return "int64"
+	case field.Base64URL:
+		return "base64url"
+	case field.Base64:
+		return "base64"
+	}
+	return ""
+}
+
+// ファイル内に pred を満たすフィールド（map の場合は値）があるかどうか
+func hasFieldFunc(msgs []*internal.Message, pred func(field *internal.Field) bool) bool {
+	for _, msg := range msgs {
+		for _, field := range msg.Fields {
+			if pred(field) || field.IsMap() && pred(field.MapValue) {
+				return true
+			}
+		}
+		if hasFieldFunc(msg.Messages, pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// ファイル内に pred を満たすメッセージ（ネストしたものを含む）があるかどうか
+func hasMessageFunc(msgs []*internal.Message, pred func(msg *internal.Message) bool) bool {
+	for _, msg := range msgs {
+		if pred(msg) || hasMessageFunc(msg.Messages, pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// フィールドを1つも出力しない場合があるかどうか
+func mayOmitAllFields(msg *internal.Message) bool {
+	for _, field := range msg.Fields {
+		if !field.DiscardIfDefault && (field.Oneof == nil || !field.Oneof.ActiveOnly) {
+			return false
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		if !oneof.ActiveOnly {
+			return false
+		}
+	}
+	return true
+}
+
+// ファイル内に map<K, V> のフィールドがあるかどうか
+func hasMapField(msgs []*internal.Message) bool {
+	for _, msg := range msgs {
+		for _, field := range msg.Fields {
+			if field.IsMap() {
+				return true
+			}
+		}
+		if hasMapField(msg.Messages) {
+			return true
+		}
+	}
+	return false
+}
+
+// フィールド（map の場合は値）が well-known type かどうか
+// well-known type は nlohmann::adl_serializer で変換するので、to_json, from_json を直接呼べない
+func hasWellKnownType(field *internal.Field) bool {
+	if field.IsMap() {
+		return field.MapValue.WellKnownType() != internal.NotWellKnown
+	}
+	return field.WellKnownType() != internal.NotWellKnown
+}
+
+// ファイル内に well-known type のフィールドがあるかどうか
+func hasWellKnownField(msgs []*internal.Message) bool {
+	for _, msg := range msgs {
+		for _, field := range msg.Fields {
+			if hasWellKnownType(field) {
+				return true
+			}
+		}
+		if hasWellKnownField(msg.Messages) {
+			return true
+		}
 	}
+	return false
+}
+
+// deprecated な要素に付ける属性
+func toDeprecatedAttr(deprecated bool) string {
+	if deprecated {
+		return "[[deprecated]] "
+	}
+	return ""
+}
+
+// proto のコメントを Doxygen 形式で出力する
+func genComment(f *internal.Formatter, comments []string) {
+	for _, line := range comments {
+		if len(line) == 0 {
+			f.P("///")
+		} else {
+			f.P("/// %s", line)
+		}
+	}
+}
+
+func genEnum(enum *internal.Enum, cpp *cppFile) error {
+	genComment(&cpp.Typedefs, enum.Comments)
+	cpp.Typedefs.PI("enum %s {", escapeName(enum.Name))
+	for _, v := range enum.Values {
+		genComment(&cpp.Typedefs, v.Comments)
+		if v.Deprecated {
+			cpp.Typedefs.P("%s [[deprecated]] = %d,", escapeName(v.Name), v.Number)
+		} else {
+			cpp.Typedefs.P("%s = %d,", escapeName(v.Name), v.Number)
+		}
+	}
+	cpp.Typedefs.PD("};")
+	cpp.Typedefs.P("")
+
+	qName := toQualifiedName(enum.FullName)
+	qEnumName := toEnumQualifiedName(enum)
 	cpp.TagInvokes.P("// %s", qName)
 	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 	cpp.TagInvokes.P("static void to_json(nlohmann::json& jv, const %s& v)", qName)
@@ -127,61 +352,106 @@ func genEnum(enum *descriptorpb.EnumDescriptorProto, pkg *string, parents []*des
 	cpp.TagInvokes.P("#endif")
 	cpp.TagInvokes.PI("{")
 	cpp.TagInvokes.PI("switch (v) {")
-	for _, v := range enum.Value {
-		cpp.TagInvokes.P("case %s::%s:", qEnumName, *v.Name)
+	if enum.AsName {
+		// 値の名前で出力する。未知の値は数値のまま出力する
+		for _, v := range enum.Values {
+			cpp.TagInvokes.P("case %s::%s:", qEnumName, escapeName(v.Name))
+			cpp.TagInvokes.Indent()
+			cpp.TagInvokes.P("jv = \"%s\";", v.Name)
+			cpp.TagInvokes.P("break;")
+			cpp.TagInvokes.Deindent()
+		}
+		cpp.TagInvokes.P("default:")
+		cpp.TagInvokes.Indent()
+		cpp.TagInvokes.P("jv = (int)v;")
+		cpp.TagInvokes.P("break;")
+		cpp.TagInvokes.Deindent()
+	} else {
+		for _, v := range enum.Values {
+			cpp.TagInvokes.P("case %s::%s:", qEnumName, escapeName(v.Name))
+		}
+		cpp.TagInvokes.Indent()
+		cpp.TagInvokes.P("jv = (int)v;")
+		cpp.TagInvokes.P("break;")
+		cpp.TagInvokes.Deindent()
+		cpp.TagInvokes.P("default:")
+		cpp.TagInvokes.Indent()
+		cpp.TagInvokes.P("jv = (int)(%s)0;", qName)
+		cpp.TagInvokes.P("break;")
+		cpp.TagInvokes.Deindent()
 	}
-	cpp.TagInvokes.Indent()
-	cpp.TagInvokes.P("jv = (int)v;")
-	cpp.TagInvokes.P("break;")
-	cpp.TagInvokes.Deindent()
-	cpp.TagInvokes.P("default:")
-	cpp.TagInvokes.Indent()
-	cpp.TagInvokes.P("jv = (int)(%s)0;", qName)
-	cpp.TagInvokes.P("break;")
-	cpp.TagInvokes.Deindent()
 	cpp.TagInvokes.PD("}")
 	cpp.TagInvokes.PD("}")
 	cpp.TagInvokes.P("")
-	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
-	cpp.TagInvokes.PI("static void from_json(const nlohmann::json& jv, %s& v) {", qName)
-	cpp.TagInvokes.P("v = (%s)jv.template get<int>();", qName)
-	cpp.TagInvokes.PD("}")
-	cpp.TagInvokes.P("#else")
-	cpp.TagInvokes.PI("static %s tag_invoke(const boost::json::value_to_tag<%s>&, const boost::json::value& jv) {", qName, qName)
-	cpp.TagInvokes.P("return (%s)boost::json::value_to<int>(jv);", qName)
-	cpp.TagInvokes.PD("}")
-	cpp.TagInvokes.P("#endif")
+	if enum.AsName {
+		// 名前と数値のどちらでも読み込める。未知の名前は 0 にする
+		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+		cpp.TagInvokes.PI("static void from_json(const nlohmann::json& jv, %s& v) {", qName)
+		cpp.TagInvokes.PI("if (!jv.is_string()) {")
+		cpp.TagInvokes.P("v = (%s)jv.template get<int>();", qName)
+		cpp.TagInvokes.P("return;")
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("std::string s = jv.template get<std::string>();")
+		for _, v := range enum.Values {
+			cpp.TagInvokes.PI("if (s == \"%s\") {", v.Name)
+			cpp.TagInvokes.P("v = %s::%s;", qEnumName, escapeName(v.Name))
+			cpp.TagInvokes.P("return;")
+			cpp.TagInvokes.PD("}")
+		}
+		cpp.TagInvokes.P("v = (%s)0;", qName)
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("#else")
+		cpp.TagInvokes.PI("static %s tag_invoke(const boost::json::value_to_tag<%s>&, const boost::json::value& jv) {", qName, qName)
+		cpp.TagInvokes.PI("if (!jv.is_string()) {")
+		cpp.TagInvokes.P("return (%s)boost::json::value_to<int>(jv);", qName)
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("std::string s(jv.as_string().data(), jv.as_string().size());")
+		for _, v := range enum.Values {
+			cpp.TagInvokes.P("if (s == \"%s\") return %s::%s;", v.Name, qEnumName, escapeName(v.Name))
+		}
+		cpp.TagInvokes.P("return (%s)0;", qName)
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("#endif")
+	} else {
+		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+		cpp.TagInvokes.PI("static void from_json(const nlohmann::json& jv, %s& v) {", qName)
+		cpp.TagInvokes.P("v = (%s)jv.template get<int>();", qName)
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("#else")
+		cpp.TagInvokes.PI("static %s tag_invoke(const boost::json::value_to_tag<%s>&, const boost::json::value& jv) {", qName, qName)
+		cpp.TagInvokes.P("return (%s)boost::json::value_to<int>(jv);", qName)
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("#endif")
+	}
 	cpp.TagInvokes.P("")
 
 	return nil
 }
 
-func genOneof(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.FieldDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cppFile) error {
-	typeName := internal.ToUpperCamel(*oneof.Name) + "Case"
-	fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+func genOneof(oneof *internal.Oneof, cpp *cppFile) error {
+	typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+	fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+	genComment(&cpp.Typedefs, oneof.Comments)
 	cpp.Typedefs.PI("enum class %s {", typeName)
 	cpp.Typedefs.P("NOT_SET = 0,")
-	for _, field := range fields {
-		cpp.Typedefs.P("k%s = %d,", internal.ToUpperCamel(*field.Name), *field.Number)
+	for _, field := range oneof.Fields {
+		cpp.Typedefs.P("k%s = %d,", internal.ToUpperCamel(field.Name), field.Number)
 	}
 	cpp.Typedefs.PD("};")
 	cpp.Typedefs.P("%s %s = %s::NOT_SET;", typeName, fieldName, typeName)
 	cpp.Typedefs.PI("void clear_%s() {", fieldName)
 	cpp.Typedefs.P("%s = %s::NOT_SET;", fieldName, typeName)
-	for _, field := range fields {
+	for _, field := range oneof.Fields {
 		fieldType, _, err := toTypeName(field)
 		if err != nil {
 			return err
 		}
-		cpp.Typedefs.P("%s = %s();", internal.ToSnakeCase(*field.Name), fieldType)
+		cpp.Typedefs.P("%s = %s();", toFieldName(field), fieldType)
 	}
 	cpp.Typedefs.PD("}")
 	cpp.Typedefs.P("")
 
-	qName, err := toQualifiedName(typeName, pkg, parents)
-	if err != nil {
-		return err
-	}
+	qName := toQualifiedName(oneof.Parent.FullName + "." + typeName)
 	cpp.TagInvokes.P("// %s", qName)
 	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 	cpp.TagInvokes.P("static void to_json(nlohmann::json& jv, const %s& v)", qName)
@@ -190,8 +460,8 @@ func genOneof(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.F
 	cpp.TagInvokes.P("#endif")
 	cpp.TagInvokes.PI("{")
 	cpp.TagInvokes.PI("switch (v) {")
-	for _, field := range fields {
-		cpp.TagInvokes.P("case %s::k%s:", qName, internal.ToUpperCamel(*field.Name))
+	for _, field := range oneof.Fields {
+		cpp.TagInvokes.P("case %s::k%s:", qName, internal.ToUpperCamel(field.Name))
 	}
 	cpp.TagInvokes.Indent()
 	cpp.TagInvokes.P("jv = (int)v;")
@@ -219,103 +489,114 @@ func genOneof(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.F
 	return nil
 }
 
-func genEquals(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cppFile) error {
-	cpp.Typedefs.PI("friend bool operator==(const %s& a, const %s& b) {", *desc.Name, *desc.Name)
+func genEquals(msg *internal.Message, cpp *cppFile) error {
+	cpp.Typedefs.PI("friend bool operator==(const %s& a, const %s& b) {", escapeName(msg.Name), escapeName(msg.Name))
 
 	// oneof 以外の比較
-	for _, field := range desc.Field {
-		if field.OneofIndex == nil {
-			fieldName := internal.ToSnakeCase(*field.Name)
+	for _, field := range msg.Fields {
+		if field.Oneof == nil {
+			fieldName := toFieldName(field)
 			cpp.Typedefs.P("if (a.%s != b.%s) return false;", fieldName, fieldName)
 		}
 	}
 	// oneof の比較
-	for i, oneof := range desc.OneofDecl {
-		oneofFieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		oneofTypeName := internal.ToUpperCamel(*oneof.Name) + "Case"
+	for _, oneof := range msg.Oneofs {
+		oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
 		cpp.Typedefs.P("if (a.%s != b.%s) return false;", oneofFieldName, oneofFieldName)
 
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fieldName := internal.ToSnakeCase(*field.Name)
-				enumFieldName := internal.ToUpperCamel(*field.Name)
-				cpp.Typedefs.P("if (a.%s == %s::k%s && a.%s != b.%s) return false;",
-					oneofFieldName, oneofTypeName, enumFieldName, fieldName, fieldName)
-			}
+		for _, field := range oneof.Fields {
+			fieldName := toFieldName(field)
+			enumFieldName := internal.ToUpperCamel(field.Name)
+			cpp.Typedefs.P("if (a.%s == %s::k%s && a.%s != b.%s) return false;",
+				oneofFieldName, oneofTypeName, enumFieldName, fieldName, fieldName)
 		}
 	}
+	if msg.KeepUnknownFields {
+		// 知らないキーは、キーと値の組の集合として比較する（キーの順序は無視する）
+		cpp.Typedefs.P("if (a.jsonif_unknown_fields != b.jsonif_unknown_fields) return false;")
+	}
 	cpp.Typedefs.P("return true;")
 	cpp.Typedefs.PD("}")
-	cpp.Typedefs.P("friend bool operator!=(const %s& a, const %s& b) { return !(a == b); }", *desc.Name, *desc.Name)
+	cpp.Typedefs.P("friend bool operator!=(const %s& a, const %s& b) { return !(a == b); }", escapeName(msg.Name), escapeName(msg.Name))
 
 	return nil
 }
 
-func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, cpp *cppFile) error {
-	descOptimistic := proto.HasExtension(desc.Options, generated.E_JsonifMessageOptimistic) && proto.GetExtension(desc.Options, generated.E_JsonifMessageOptimistic).(bool)
-	descDiscard := proto.HasExtension(desc.Options, generated.E_JsonifMessageDiscardIfDefault) && proto.GetExtension(desc.Options, generated.E_JsonifMessageDiscardIfDefault).(bool)
-	noSerializer := proto.HasExtension(desc.Options, generated.E_JsonifNoSerializer) && proto.GetExtension(desc.Options, generated.E_JsonifNoSerializer).(bool)
-	noDeserializer := proto.HasExtension(desc.Options, generated.E_JsonifNoDeserializer) && proto.GetExtension(desc.Options, generated.E_JsonifNoDeserializer).(bool)
+func genDescriptor(msg *internal.Message, cpp *cppFile, opts *options) error {
+	genComment(&cpp.Typedefs, msg.Comments)
+	cpp.Typedefs.PI("struct %s%s {", toDeprecatedAttr(msg.Deprecated), escapeName(msg.Name))
 
-	cpp.Typedefs.PI("struct %s {", *desc.Name)
-
-	for _, enum := range desc.EnumType {
-		if err := genEnum(enum, pkg, append(parents, desc), cpp); err != nil {
+	for _, enum := range msg.Enums {
+		if err := genEnum(enum, cpp); err != nil {
 			return err
 		}
 	}
 
-	for _, nested := range desc.NestedType {
-		if err := genDescriptor(nested, pkg, append(parents, desc), cpp); err != nil {
+	for _, nested := range msg.Messages {
+		// map<K, V> のエントリは構造体を生成しない
+		if nested.MapEntry {
+			continue
+		}
+		if err := genDescriptor(nested, cpp, opts); err != nil {
 			return err
 		}
 	}
 
-	for i, oneof := range desc.OneofDecl {
-		var fields []*descriptorpb.FieldDescriptorProto
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fields = append(fields, field)
-			}
-		}
-		if err := genOneof(oneof, fields, pkg, append(parents, desc), cpp); err != nil {
+	for _, oneof := range msg.Oneofs {
+		if err := genOneof(oneof, cpp); err != nil {
 			return err
 		}
 	}
 
-	for _, field := range desc.Field {
+	for _, field := range msg.Fields {
 		typeName, defaultValue, err := toTypeName(field)
 		if err != nil {
 			return err
 		}
-		fieldName := internal.ToSnakeCase(*field.Name)
+		if field.IsMap() {
+			typeName, err = toMapTypeName(field, opts)
+			if err != nil {
+				return err
+			}
+		}
+		fieldName := toFieldName(field)
 		if len(defaultValue) != 0 {
 			defaultValue = " = " + defaultValue
 		}
-		cpp.Typedefs.P("%s %s%s;", typeName, fieldName, defaultValue)
+		genComment(&cpp.Typedefs, field.Comments)
+		attr := toDeprecatedAttr(field.Deprecated)
+		cpp.Typedefs.P("%s%s %s%s;", attr, typeName, fieldName, defaultValue)
 
-		if oneof := field.OneofIndex; oneof != nil {
-			oneofTypeName := internal.ToUpperCamel(*desc.OneofDecl[*oneof].Name) + "Case"
-			oneofFieldName := internal.ToSnakeCase(*desc.OneofDecl[*oneof].Name) + "_case"
-			cpp.Typedefs.PI("void set_%s(%s %s) {", fieldName, typeName, fieldName)
+		if oneof := field.Oneof; oneof != nil {
+			oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			// メソッド名にはエスケープする前の名前を使う
+			name := internal.ToSnakeCase(field.Name)
+			cpp.Typedefs.PI("%svoid set_%s(%s %s) {", attr, name, typeName, fieldName)
 			cpp.Typedefs.P("clear_%s();", oneofFieldName)
-			cpp.Typedefs.P("%s = %s::k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(fieldName))
+			cpp.Typedefs.P("%s = %s::k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(name))
 			cpp.Typedefs.P("this->%s = %s;", fieldName, fieldName)
 			cpp.Typedefs.PD("}")
-			if field.Proto3Optional != nil && *field.Proto3Optional {
-				cpp.Typedefs.PI("bool has_%s() const {", fieldName)
-				cpp.Typedefs.P("return %s == %s::k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(fieldName))
+			if field.Optional {
+				cpp.Typedefs.PI("%sbool has_%s() const {", attr, name)
+				cpp.Typedefs.P("return %s == %s::k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(name))
 				cpp.Typedefs.PD("}")
 			}
-			cpp.Typedefs.PI("void clear_%s() {", fieldName)
-			cpp.Typedefs.PI("if (%s == %s::k%s) {", oneofFieldName, oneofTypeName, internal.ToUpperCamel(fieldName))
+			cpp.Typedefs.PI("%svoid clear_%s() {", attr, name)
+			cpp.Typedefs.PI("if (%s == %s::k%s) {", oneofFieldName, oneofTypeName, internal.ToUpperCamel(name))
 			cpp.Typedefs.P("clear_%s();", oneofFieldName)
 			cpp.Typedefs.PD("}")
 			cpp.Typedefs.PD("}")
 		}
 	}
 
-	err := genEquals(desc, pkg, append(parents, desc), cpp)
+	if msg.KeepUnknownFields {
+		cpp.Typedefs.P("// JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）")
+		cpp.Typedefs.P("::jsonif::unknown_fields jsonif_unknown_fields;")
+	}
+
+	err := genEquals(msg, cpp)
 	if err != nil {
 		return err
 	}
@@ -323,12 +604,16 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	cpp.Typedefs.PD("};")
 	cpp.Typedefs.P("")
 
-	qName, err := toQualifiedName(*desc.Name, pkg, parents)
-	if err != nil {
-		return err
-	}
+	qName := toQualifiedName(msg.FullName)
+	cpp.TypeNames.P("template<>")
+	cpp.TypeNames.PI("struct type_name<%s> {", qName)
+	cpp.TypeNames.P("static constexpr const char* value = \"%s\";", msg.FullName)
+	cpp.TypeNames.PD("};")
 	cpp.TagInvokes.P("// %s", qName)
-	if noSerializer {
+	if checkEnabled(msg.File, opts) {
+		genCheck(msg, cpp, opts)
+	}
+	if msg.NoSerializer {
 		cpp.TagInvokes.P("#if 0")
 	}
 	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
@@ -338,35 +623,92 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	cpp.TagInvokes.P("#endif")
 	cpp.TagInvokes.PI("{")
 	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
-	cpp.TagInvokes.P("nlohmann::json obj;")
+	if mayOmitAllFields(msg) || msg.KeepUnknownFields {
+		// 何も出力しなかった場合に null ではなく {} になるようにする
+		cpp.TagInvokes.P("nlohmann::json obj = nlohmann::json::object();")
+	} else {
+		cpp.TagInvokes.P("nlohmann::json obj;")
+	}
 	cpp.TagInvokes.P("#else")
 	cpp.TagInvokes.P("boost::json::object obj;")
 	cpp.TagInvokes.P("#endif")
-	for _, field := range desc.Field {
-		fieldName := internal.ToSnakeCase(*field.Name)
-		fieldKey := internal.GetJsonName(field, fieldName)
-		discard := descDiscard
-		if proto.HasExtension(field.Options, generated.E_JsonifDiscardIfDefault) {
-			discard = proto.GetExtension(field.Options, generated.E_JsonifDiscardIfDefault).(bool)
-		}
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		fieldKey := field.JsonKey
+		discard := field.DiscardIfDefault
+		activeOnly := field.Oneof != nil && field.Oneof.ActiveOnly
 
-		if discard {
+		if activeOnly {
+			// oneof は設定されているフィールドだけを出力する
+			oneofTypeName := internal.ToUpperCamel(field.Oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(field.Oneof.Name) + "_case"
+			cpp.TagInvokes.PI("if (v.%s == %s::%s::k%s) {", oneofFieldName, qName, oneofTypeName, internal.ToUpperCamel(field.Name))
+		} else if discard && field.HasDefault {
+			_, defaultValue, err := toTypeName(field)
+			if err != nil {
+				return err
+			}
+			cpp.TagInvokes.PI("if (v.%s != %s) {", fieldName, defaultValue)
+		} else if discard {
 			cpp.TagInvokes.PI("if (v.%s != decltype(v.%s)()) {", fieldName, fieldName)
 		}
-		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
-		cpp.TagInvokes.PI("{")
-		cpp.TagInvokes.P("using nlohmann::to_json;")
-		cpp.TagInvokes.P("to_json(obj[\"%s\"], v.%s);", fieldKey, fieldName)
-		cpp.TagInvokes.PD("}")
-		cpp.TagInvokes.P("#else")
-		cpp.TagInvokes.P("obj[\"%s\"] = boost::json::value_from(v.%s);", fieldKey, fieldName)
-		cpp.TagInvokes.P("#endif")
-		if discard {
+		if field.IsMap() {
+			// map は JSON のオブジェクトとして出力する
+			key := toMapKeyString(field, "kv.first")
+			helper := toHelperName(field.MapValue)
+			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+			cpp.TagInvokes.PI("{")
+			cpp.TagInvokes.P("using nlohmann::to_json;")
+			cpp.TagInvokes.P("nlohmann::json m = nlohmann::json::object();")
+			cpp.TagInvokes.PI("for (const auto& kv : v.%s) {", fieldName)
+			if len(helper) != 0 {
+				cpp.TagInvokes.P("::jsonif::detail::%s_to_json(m[%s], kv.second);", helper, key)
+			} else if hasWellKnownType(field) {
+				cpp.TagInvokes.P("m[%s] = kv.second;", key)
+			} else {
+				cpp.TagInvokes.P("to_json(m[%s], kv.second);", key)
+			}
+			cpp.TagInvokes.PD("}")
+			cpp.TagInvokes.P("obj[\"%s\"] = std::move(m);", fieldKey)
+			cpp.TagInvokes.PD("}")
+			cpp.TagInvokes.P("#else")
+			cpp.TagInvokes.PI("{")
+			cpp.TagInvokes.P("boost::json::object m;")
+			cpp.TagInvokes.PI("for (const auto& kv : v.%s) {", fieldName)
+			if len(helper) != 0 {
+				cpp.TagInvokes.P("::jsonif::detail::%s_to_json(m[%s], kv.second);", helper, key)
+			} else {
+				cpp.TagInvokes.P("m[%s] = boost::json::value_from(kv.second);", key)
+			}
+			cpp.TagInvokes.PD("}")
+			cpp.TagInvokes.P("obj[\"%s\"] = std::move(m);", fieldKey)
+			cpp.TagInvokes.PD("}")
+			cpp.TagInvokes.P("#endif")
+		} else if helper := toHelperName(field); len(helper) != 0 {
+			cpp.TagInvokes.P("::jsonif::detail::%s_to_json(obj[\"%s\"], v.%s);", helper, fieldKey, fieldName)
+		} else {
+			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+			if hasWellKnownType(field) {
+				cpp.TagInvokes.P("obj[\"%s\"] = v.%s;", fieldKey, fieldName)
+			} else {
+				cpp.TagInvokes.PI("{")
+				cpp.TagInvokes.P("using nlohmann::to_json;")
+				cpp.TagInvokes.P("to_json(obj[\"%s\"], v.%s);", fieldKey, fieldName)
+				cpp.TagInvokes.PD("}")
+			}
+			cpp.TagInvokes.P("#else")
+			cpp.TagInvokes.P("obj[\"%s\"] = boost::json::value_from(v.%s);", fieldKey, fieldName)
+			cpp.TagInvokes.P("#endif")
+		}
+		if activeOnly || discard {
 			cpp.TagInvokes.PD("}")
 		}
 	}
-	for _, oneof := range desc.OneofDecl {
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+	for _, oneof := range msg.Oneofs {
+		if oneof.ActiveOnly {
+			continue
+		}
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
 		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 		cpp.TagInvokes.PI("{")
 		cpp.TagInvokes.P("using nlohmann::to_json;")
@@ -376,13 +718,28 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 		cpp.TagInvokes.P("obj[\"%s\"] = boost::json::value_from(v.%s);", fieldName, fieldName)
 		cpp.TagInvokes.P("#endif")
 	}
+	if msg.KeepUnknownFields {
+		// 知っているキーと同じキーは出力しない
+		genKnownKeys(msg, &cpp.TagInvokes)
+		cpp.TagInvokes.PI("for (const auto& kv : v.jsonif_unknown_fields) {")
+		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+		cpp.TagInvokes.PI("if (!::jsonif::detail::is_known_key(known_keys, kv.first)) {")
+		cpp.TagInvokes.P("obj.emplace(kv.first, kv.second);")
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("#else")
+		cpp.TagInvokes.PI("if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {")
+		cpp.TagInvokes.P("obj.emplace(kv.key(), kv.value());")
+		cpp.TagInvokes.PD("}")
+		cpp.TagInvokes.P("#endif")
+		cpp.TagInvokes.PD("}")
+	}
 	cpp.TagInvokes.P("jv = std::move(obj);")
 	cpp.TagInvokes.PD("}")
-	if noSerializer {
+	if msg.NoSerializer {
 		cpp.TagInvokes.P("#endif")
 	}
 	cpp.TagInvokes.P("")
-	if noDeserializer {
+	if msg.NoDeserializer {
 		cpp.TagInvokes.P("#if 0")
 	}
 	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
@@ -395,18 +752,29 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	cpp.TagInvokes.P("#else")
 	cpp.TagInvokes.P("%s v;", qName)
 	cpp.TagInvokes.P("#endif")
-	for _, field := range desc.Field {
+	// strict ではなくても、中に strict なメッセージがあれば、エラーの位置をこのメッセージからの位置にするためにここで調べる
+	if msg.ReachesStrict() {
+		cpp.TagInvokes.P("::jsonif::detail::strict_scope strict(jv, static_cast<const %s*>(nullptr));", qName)
+	}
+	for _, field := range msg.Fields {
 		typeName, _, err := toTypeName(field)
 		if err != nil {
 			return err
 		}
-		fieldName := internal.ToSnakeCase(*field.Name)
-		fieldKey := internal.GetJsonName(field, fieldName)
-		optimistic := descOptimistic
-		if proto.HasExtension(field.Options, generated.E_JsonifOptimistic) {
-			optimistic = proto.GetExtension(field.Options, generated.E_JsonifOptimistic).(bool)
+		fieldName := toFieldName(field)
+		fieldKey := field.JsonKey
+		optimistic := field.Optimistic
+		if opts.ReportDeprecated && field.Deprecated {
+			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+			cpp.TagInvokes.P("if (jv.contains(\"%s\"))", fieldKey)
+			cpp.TagInvokes.P("#else")
+			cpp.TagInvokes.P("if (jv.as_object().find(\"%s\") != jv.as_object().end())", fieldKey)
+			cpp.TagInvokes.P("#endif")
+			cpp.TagInvokes.PI("{")
+			cpp.TagInvokes.P("::jsonif::detail::report_deprecated_field(\"%s\", \"%s\");", msg.FullName, fieldKey)
+			cpp.TagInvokes.PD("}")
 		}
-		if field.OneofIndex != nil || optimistic {
+		if field.Oneof != nil || optimistic {
 			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 			cpp.TagInvokes.P("if (jv.contains(\"%s\"))", fieldKey)
 			cpp.TagInvokes.P("#else")
@@ -414,24 +782,84 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 			cpp.TagInvokes.P("#endif")
 			cpp.TagInvokes.PI("{")
 		}
-		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
-		cpp.TagInvokes.PI("{")
-		cpp.TagInvokes.P("using nlohmann::from_json;")
-		cpp.TagInvokes.P("from_json(jv.at(\"%s\"), v.%s);", fieldKey, fieldName)
-		cpp.TagInvokes.PD("}")
-		cpp.TagInvokes.P("#else")
-		cpp.TagInvokes.P("v.%s = boost::json::value_to<%s>(jv.at(\"%s\"));", fieldName, typeName, fieldKey)
-		cpp.TagInvokes.P("#endif")
-		if field.OneofIndex != nil || optimistic {
+		if field.IsMap() {
+			valueType, _, err := toTypeName(field.MapValue)
+			if err != nil {
+				return err
+			}
+			key, err := fromMapKeyString(field, "key")
+			if err != nil {
+				return err
+			}
+			helper := toHelperName(field.MapValue)
+			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+			cpp.TagInvokes.PI("for (const auto& kv : jv.at(\"%s\").items()) {", fieldKey)
+			cpp.TagInvokes.P("using nlohmann::from_json;")
+			cpp.TagInvokes.P("std::string key = kv.key();")
+			cpp.TagInvokes.P("%s value{};", valueType)
+			if len(helper) != 0 {
+				cpp.TagInvokes.P("::jsonif::detail::%s_from_json(kv.value(), value);", helper)
+			} else if hasWellKnownType(field) {
+				cpp.TagInvokes.P("kv.value().get_to(value);")
+			} else {
+				cpp.TagInvokes.P("from_json(kv.value(), value);")
+			}
+			cpp.TagInvokes.P("v.%s.emplace(%s, std::move(value));", fieldName, key)
+			cpp.TagInvokes.PD("}")
+			cpp.TagInvokes.P("#else")
+			cpp.TagInvokes.PI("for (const auto& kv : jv.at(\"%s\").as_object()) {", fieldKey)
+			cpp.TagInvokes.P("std::string key(kv.key().data(), kv.key().size());")
+			if len(helper) != 0 {
+				cpp.TagInvokes.P("%s value{};", valueType)
+				cpp.TagInvokes.P("::jsonif::detail::%s_from_json(kv.value(), value);", helper)
+				cpp.TagInvokes.P("v.%s.emplace(%s, std::move(value));", fieldName, key)
+			} else {
+				cpp.TagInvokes.P("v.%s.emplace(%s, boost::json::value_to<%s>(kv.value()));", fieldName, key, valueType)
+			}
+			cpp.TagInvokes.PD("}")
+			cpp.TagInvokes.P("#endif")
+		} else if helper := toHelperName(field); len(helper) != 0 {
+			cpp.TagInvokes.P("::jsonif::detail::%s_from_json(jv.at(\"%s\"), v.%s);", helper, fieldKey, fieldName)
+		} else {
+			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+			if hasWellKnownType(field) {
+				cpp.TagInvokes.P("jv.at(\"%s\").get_to(v.%s);", fieldKey, fieldName)
+			} else {
+				cpp.TagInvokes.PI("{")
+				cpp.TagInvokes.P("using nlohmann::from_json;")
+				cpp.TagInvokes.P("from_json(jv.at(\"%s\"), v.%s);", fieldKey, fieldName)
+				cpp.TagInvokes.PD("}")
+			}
+			cpp.TagInvokes.P("#else")
+			cpp.TagInvokes.P("v.%s = boost::json::value_to<%s>(jv.at(\"%s\"));", fieldName, typeName, fieldKey)
+			cpp.TagInvokes.P("#endif")
+		}
+		if field.Oneof != nil && field.Oneof.ActiveOnly {
+			// キーがあるフィールドが設定されているフィールドになる
+			oneofTypeName := internal.ToUpperCamel(field.Oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(field.Oneof.Name) + "_case"
+			cpp.TagInvokes.P("v.%s = %s::%s::k%s;", oneofFieldName, qName, oneofTypeName, internal.ToUpperCamel(field.Name))
+		}
+		if field.Oneof != nil || optimistic {
 			cpp.TagInvokes.PD("}")
 		}
 	}
-	for _, oneof := range desc.OneofDecl {
-		typeName, err := toQualifiedName(internal.ToUpperCamel(*oneof.Name)+"Case", pkg, append(parents, desc))
-		if err != nil {
-			return err
+	for _, oneof := range msg.Oneofs {
+		// optional はキーの有無だけで判断する
+		if oneof.Synthetic {
+			continue
+		}
+		typeName := toQualifiedName(msg.FullName + "." + internal.ToUpperCamel(oneof.Name) + "Case")
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		if oneof.ActiveOnly {
+			// 互換性のため、_case があればキーから判断した値よりも優先する
+			cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+			cpp.TagInvokes.P("if (jv.contains(\"%s\"))", fieldName)
+			cpp.TagInvokes.P("#else")
+			cpp.TagInvokes.P("if (jv.as_object().find(\"%s\") != jv.as_object().end())", fieldName)
+			cpp.TagInvokes.P("#endif")
+			cpp.TagInvokes.PI("{")
 		}
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
 		cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 		cpp.TagInvokes.PI("{")
 		cpp.TagInvokes.P("using nlohmann::from_json;")
@@ -440,20 +868,876 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 		cpp.TagInvokes.P("#else")
 		cpp.TagInvokes.P("v.%s = boost::json::value_to<%s>(jv.at(\"%s\"));", fieldName, typeName, fieldName)
 		cpp.TagInvokes.P("#endif")
+		if oneof.ActiveOnly {
+			cpp.TagInvokes.PD("}")
+		}
+	}
+	if msg.KeepUnknownFields {
+		genReadUnknownFields(msg, cpp)
 	}
 	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 	cpp.TagInvokes.P("#else")
 	cpp.TagInvokes.P("return v;")
 	cpp.TagInvokes.P("#endif")
 	cpp.TagInvokes.PD("}")
-	if noDeserializer {
+	if msg.NoDeserializer {
 		cpp.TagInvokes.P("#endif")
 	}
 	cpp.TagInvokes.P("")
 
+	if msg.HasValidation() {
+		if err := genValidate(msg, cpp); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// 整数型の値の範囲（check_integer に渡す最小値と最大値）
+func toIntegerRange(field *internal.Field) (string, string, bool) {
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return "INT32_MIN", "INT32_MAX", true
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return "INT64_MIN", "INT64_MAX", true
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return "0", "UINT32_MAX", true
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return "0", "UINT64_MAX", true
+	}
+	return "", "", false
+}
+
+// JSON の値 expr の型を調べる式（repeated の場合は要素、map の場合は値に対して使う）
+// 何でも受け付ける場合は空文字列を返す
+func toCheckExpr(field *internal.Field, expr string, opts *options) string {
+	// expr は x か *p になる
+	isNull := expr + ".is_null()"
+	if strings.HasPrefix(expr, "*") {
+		isNull = expr[1:] + "->is_null()"
+	}
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		return fmt.Sprintf("::jsonif::detail::check_timestamp(%s, path, err)", expr)
+	case internal.WellKnownDuration:
+		return fmt.Sprintf("::jsonif::detail::check_duration(%s, path, err)", expr)
+	case internal.WellKnownWrapper:
+		if field.Base64 {
+			return fmt.Sprintf("(%s || ::jsonif::detail::check_base64(%s, path, err))", isNull, expr)
+		}
+		return fmt.Sprintf("(%s || %s)", isNull, toCheckExpr(field.Message.WrapperValue(), expr, opts))
+	case internal.WellKnownStruct:
+		// 値が無い場合は null になる
+		return fmt.Sprintf("(%s || ::jsonif::detail::check_object(%s, path, err))", isNull, expr)
+	case internal.WellKnownListValue:
+		return fmt.Sprintf("(%s || ::jsonif::detail::check_array(%s, path, err))", isNull, expr)
+	case internal.WellKnownValue:
+		return ""
+	case internal.WellKnownAny:
+		return fmt.Sprintf("::jsonif::detail::check_any(%s, path, err)", expr)
+	}
+	if field.Base64 {
+		return fmt.Sprintf("::jsonif::detail::check_base64(%s, path, err)", expr)
+	}
+	if field.IsMessage() {
+		// 参照先のファイルに jsonif_check が無い場合は、オブジェクトかどうかだけを調べる
+		if !checkEnabled(field.Message.File, opts) {
+			return fmt.Sprintf("::jsonif::detail::check_object(%s, path, err)", expr)
+		}
+		return fmt.Sprintf("::jsonif::detail::check<%s>(%s, path, err)", toQualifiedName(field.Message.FullName), expr)
+	}
+	if field.Enum != nil {
+		return fmt.Sprintf("::jsonif::detail::check_enum(%s, %t, path, err)", expr, field.Enum.AsName)
+	}
+	if min, max, ok := toIntegerRange(field); ok {
+		if field.Int64AsString {
+			return fmt.Sprintf("::jsonif::detail::check_integer_string(%s, %s, %s, path, err)", expr, min, max)
+		}
+		return fmt.Sprintf("::jsonif::detail::check_integer(%s, %s, %s, path, err)", expr, min, max)
+	}
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return fmt.Sprintf("::jsonif::detail::check_bool(%s, path, err)", expr)
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return fmt.Sprintf("::jsonif::detail::check_number(%s, path, err)", expr)
+	default:
+		return fmt.Sprintf("::jsonif::detail::check_string(%s, path, err)", expr)
+	}
+}
+
+// JSON のキーの文字列を map のキーとして読み込めるかを調べるラムダ式
+func toMapKeyCheck(field *internal.Field) string {
+	if min, max, ok := toIntegerRange(field.MapKey); ok {
+		return fmt.Sprintf("[](const std::string& k) { return ::jsonif::detail::is_integer_string(k, %s, %s); }", min, max)
+	}
+	if field.MapKey.Type == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+		return "[](const std::string& k) { return k == \"true\" || k == \"false\"; }"
+	}
+	return "[](const std::string&) { return true; }"
+}
+
+// フィールドの JSON の値 expr を調べる式
+func toFieldCheckExpr(field *internal.Field, expr string, opts *options) string {
+	if field.IsMap() {
+		check := toCheckExpr(field.MapValue, "x", opts)
+		if len(check) == 0 {
+			check = "true"
+		}
+		return fmt.Sprintf("::jsonif::detail::check_map(%s, path, err, %s, [&](const ::jsonif::detail::check_json& x) { return %s; })", expr, toMapKeyCheck(field), check)
+	}
+	if field.Repeated {
+		check := toCheckExpr(field, "x", opts)
+		if len(check) == 0 {
+			return fmt.Sprintf("::jsonif::detail::check_array(%s, path, err)", expr)
+		}
+		return fmt.Sprintf("::jsonif::detail::check_each(%s, path, err, [&](const ::jsonif::detail::check_json& x) { return %s; })", expr, check)
+	}
+	return toCheckExpr(field, expr, opts)
+}
+
+// jsonif_check などの検査する関数を生成するかどうか
+// ヘッダを小さくするために、try_from_json パラメータを指定したか、strict なメッセージを読み込むメッセージがある場合だけ生成する
+func checkEnabled(file *internal.File, opts *options) bool {
+	return opts.TryFromJson && len(file.Messages) != 0 || file.UsesStrict()
+}
+
+// JSON を読み込めるかどうかを調べて、問題があれば err に位置と理由を設定して false を返す関数
+// from_json と同じく、oneof と optimistic 以外のフィールドのキーは必須になる
+// strict なメッセージの場合は知らないキーもエラーにする
+func genCheck(msg *internal.Message, cpp *cppFile, opts *options) {
+	f := &cpp.TagInvokes
+	qName := toQualifiedName(msg.FullName)
+	if msg.NoDeserializer {
+		// from_json を自分で定義するので、どんな JSON を受け付けるかは分からない
+		f.PI("static bool jsonif_check(const ::jsonif::detail::check_json&, const %s*, std::string&, ::jsonif::error&) {", qName)
+		f.P("return true;")
+		f.PD("}")
+		f.P("")
+		return
+	}
+	f.PI("static bool jsonif_check(const ::jsonif::detail::check_json& jv, const %s*, std::string& path, ::jsonif::error& err) {", qName)
+	f.P("if (!::jsonif::detail::check_object(jv, path, err)) return false;")
+	if msg.Strict && !msg.KeepUnknownFields {
+		genKnownKeys(msg, f)
+		f.P("if (!::jsonif::detail::check_unknown_keys(jv, known_keys, path, err)) return false;")
+	}
+	check := func(key string, expr string, required bool) {
+		f.PI("if (const auto* p = ::jsonif::detail::find_key(jv, \"%s\")) {", key)
+		if len(expr) != 0 {
+			f.P("size_t n = ::jsonif::detail::push_key(path, \"%s\");", key)
+			f.P("if (!%s) return false;", expr)
+			f.P("path.resize(n);")
+		} else {
+			f.P("(void)p;")
+		}
+		if required {
+			f.PDI("} else {")
+			f.P("return ::jsonif::detail::missing_key(path, \"%s\", err);", key)
+		}
+		f.PD("}")
+	}
+	for _, field := range msg.Fields {
+		check(field.JsonKey, toFieldCheckExpr(field, "*p", opts), field.Oneof == nil && !field.Optimistic)
+	}
+	for _, oneof := range msg.Oneofs {
+		if oneof.Synthetic {
+			continue
+		}
+		check(internal.ToSnakeCase(oneof.Name)+"_case", "::jsonif::detail::check_integer(*p, INT32_MIN, INT32_MAX, path, err)", !oneof.ActiveOnly)
+	}
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+}
+
+// 読み込むキーの配列（nullptr で終わる）
+func genKnownKeys(msg *internal.Message, f *internal.Formatter) {
+	var keys []string
+	for _, field := range msg.Fields {
+		keys = append(keys, fmt.Sprintf("\"%s\"", field.JsonKey))
+	}
+	for _, oneof := range msg.Oneofs {
+		if oneof.Synthetic {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("\"%s_case\"", internal.ToSnakeCase(oneof.Name)))
+	}
+	keys = append(keys, "nullptr")
+	f.P("static const char* const known_keys[] = {%s};", strings.Join(keys, ", "))
+}
+
+// 読み込むキー以外のキーを jsonif_unknown_fields に保持する
+func genReadUnknownFields(msg *internal.Message, cpp *cppFile) {
+	genKnownKeys(msg, &cpp.TagInvokes)
+	cpp.TagInvokes.P("v.jsonif_unknown_fields.clear();")
+	cpp.TagInvokes.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	cpp.TagInvokes.PI("for (const auto& kv : jv.items()) {")
+	cpp.TagInvokes.PI("if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {")
+	cpp.TagInvokes.P("v.jsonif_unknown_fields.emplace(kv.key(), kv.value());")
+	cpp.TagInvokes.PD("}")
+	cpp.TagInvokes.PD("}")
+	cpp.TagInvokes.P("#else")
+	cpp.TagInvokes.PI("for (const auto& kv : jv.as_object()) {")
+	cpp.TagInvokes.PI("if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {")
+	cpp.TagInvokes.P("v.jsonif_unknown_fields.emplace(kv.key(), kv.value());")
+	cpp.TagInvokes.PD("}")
+	cpp.TagInvokes.PD("}")
+	cpp.TagInvokes.P("#endif")
+}
+
+// 値が enum で定義されているかどうかの条件式
+func toEnumDefinedCondition(enum *internal.Enum, expr string) string {
+	qEnumName := toEnumQualifiedName(enum)
+	var conds []string
+	for _, v := range enum.Values {
+		conds = append(conds, fmt.Sprintf("%s == %s::%s", expr, qEnumName, escapeName(v.Name)))
+	}
+	return strings.Join(conds, " || ")
+}
+
+// 値 expr の検証（repeated の場合は要素、map の場合は値ごとに呼ぶ）
+func genValueValidate(f *internal.Formatter, field *internal.Field, expr string, path string) {
+	value := field.ValueField()
+	if v := field.Validation; v != nil {
+		if len(v.Min) != 0 {
+			f.P("if (%s < %s) r.push_back({%s, \"%s\"});", expr, internal.CLiteral(value, v.Min), path, v.MinMessage())
+		}
+		if len(v.Max) != 0 {
+			f.P("if (%s > %s) r.push_back({%s, \"%s\"});", expr, internal.CLiteral(value, v.Max), path, v.MaxMessage())
+		}
+		length := fmt.Sprintf("::jsonif::detail::utf8_length(%s)", expr)
+		if value.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			length = expr + ".size()"
+		}
+		if v.MinLen >= 0 {
+			f.P("if (%s < %d) r.push_back({%s, \"%s\"});", length, v.MinLen, path, v.MinLenMessage())
+		}
+		if v.MaxLen >= 0 {
+			f.P("if (%s > %d) r.push_back({%s, \"%s\"});", length, v.MaxLen, path, v.MaxLenMessage())
+		}
+		if len(v.Pattern) != 0 {
+			f.P("static const std::regex %s_pattern(%s);", toFieldName(field), internal.CStringLiteral(v.Pattern))
+			f.P("if (!std::regex_search(%s, %s_pattern)) r.push_back({%s, %s});", expr, toFieldName(field), path, internal.CStringLiteral(v.PatternMessage()))
+		}
+		if v.EnumDefinedOnly {
+			f.P("if (!(%s)) r.push_back({%s, \"%s\"});", toEnumDefinedCondition(value.Enum, expr), path, internal.EnumDefinedOnlyMessage)
+		}
+	}
+	if value.IsMessage() && value.WellKnownType() == internal.NotWellKnown && value.Message.HasValidation() {
+		f.P("::jsonif::detail::validate_message(%s, %s, r);", expr, path)
+	}
+}
+
+// 検証ルールを満たしているかを調べて、違反を r に追加する関数
+// 検証ルールのあるメッセージをフィールドに持つ場合は、そのメッセージの関数も呼び出す
+func genValidate(msg *internal.Message, cpp *cppFile) error {
+	f := &cpp.TagInvokes
+	qName := toQualifiedName(msg.FullName)
+	f.PI("static void jsonif_validate(const %s& v, const std::string& path, std::vector<::jsonif::violation>& r) {", qName)
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		path := fmt.Sprintf("::jsonif::detail::join_path(path, %s)", internal.CStringLiteral(field.JsonKey))
+		// oneof（optional を含む）のフィールドは、設定されている場合だけ検証する
+		cond := ""
+		if oneof := field.Oneof; oneof != nil {
+			cond = fmt.Sprintf("v.%s_case == %s::%sCase::k%s", internal.ToSnakeCase(oneof.Name), qName, internal.ToUpperCamel(oneof.Name), internal.ToUpperCamel(field.Name))
+		}
+		v := field.Validation
+		if v != nil && v.Required {
+			if len(cond) != 0 {
+				f.P("if (!(%s)) r.push_back({%s, \"%s\"});", cond, path, internal.RequiredMessage)
+			} else {
+				f.P("if (v.%s == decltype(v.%s)()) r.push_back({%s, \"%s\"});", fieldName, fieldName, path, internal.RequiredMessage)
+			}
+		}
+		if v != nil && v.MinItems >= 0 {
+			f.P("if (v.%s.size() < %d) r.push_back({%s, \"%s\"});", fieldName, v.MinItems, path, v.MinItemsMessage())
+		}
+		if v != nil && v.MaxItems >= 0 {
+			f.P("if (v.%s.size() > %d) r.push_back({%s, \"%s\"});", fieldName, v.MaxItems, path, v.MaxItemsMessage())
+		}
+		value := field.ValueField()
+		if !(v != nil && v.HasValueRules()) && !(value.IsMessage() && value.WellKnownType() == internal.NotWellKnown && value.Message.HasValidation()) {
+			continue
+		}
+		if len(cond) != 0 {
+			f.PI("if (%s) {", cond)
+		}
+		if field.IsMap() {
+			f.PI("for (const auto& kv : v.%s) {", fieldName)
+			genValueValidate(f, field, "kv.second", fmt.Sprintf("%s + \"[\\\"\" + %s + \"\\\"]\"", path, toMapKeyString(field, "kv.first")))
+			f.PD("}")
+		} else if field.Repeated {
+			f.PI("for (size_t i = 0; i < v.%s.size(); i++) {", fieldName)
+			genValueValidate(f, field, fmt.Sprintf("v.%s[i]", fieldName), path+" + \"[\" + std::to_string(i) + \"]\"")
+			f.PD("}")
+		} else {
+			genValueValidate(f, field, "v."+fieldName, path)
+		}
+		if len(cond) != 0 {
+			f.PD("}")
+		}
+	}
+	f.PD("}")
+	f.P("")
+	return nil
+}
+
+// validate で使う型と関数
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genValidateHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_VALIDATE_DEFINED")
+	f.P("#define JSONIF_VALIDATE_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("")
+	f.P("// 検証ルールの違反")
+	f.PI("struct violation {")
+	f.P("// JSON でのフィールドの位置（people[3].name など）")
+	f.P("std::string path;")
+	f.P("std::string message;")
+	f.PD("};")
+	f.P("")
+	f.P("namespace detail {")
+	f.P("")
+	f.P("// UTF-8 の文字列のコードポイント数")
+	f.PI("inline size_t utf8_length(const std::string& s) {")
+	f.P("size_t n = 0;")
+	f.PI("for (unsigned char c : s) {")
+	f.PI("if ((c & 0xc0) != 0x80) {")
+	f.P("n++;")
+	f.PD("}")
+	f.PD("}")
+	f.P("return n;")
+	f.PD("}")
+	f.P("")
+	f.PI("inline std::string join_path(const std::string& path, const char* key) {")
+	f.P("return path.empty() ? std::string(key) : path + \".\" + key;")
+	f.PD("}")
+	f.P("")
+	f.P("// メッセージごとに生成した jsonif_validate を ADL で呼び出す")
+	f.P("// インスタンス化する時に探すので、後で定義されるメッセージの jsonif_validate も呼び出せる")
+	f.P("template<class T>")
+	f.PI("inline void validate_message(const T& v, const std::string& path, std::vector<violation>& r) {")
+	f.P("jsonif_validate(v, path, r);")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("// 検証ルールを満たしていなければ、その違反を全て返す")
+	f.P("// 検証ルールのあるメッセージ（検証ルールのあるメッセージをフィールドに持つものを含む）でだけ使える")
+	f.P("template<class T>")
+	f.PI("inline std::vector<violation> validate(const T& v) {")
+	f.P("std::vector<violation> r;")
+	f.P("jsonif_validate(v, \"\", r);")
+	f.P("return r;")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// report_deprecated で使う関数
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genDeprecatedFieldHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_DEPRECATED_FIELD_DEFINED")
+	f.P("#define JSONIF_DEPRECATED_FIELD_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("")
+	f.P("// deprecated なフィールドのキーが JSON にあった時に呼ばれる関数")
+	f.P("// message はメッセージの完全修飾名、key は JSON のキー")
+	f.PI("inline std::function<void(const char* message, const char* key)>& deprecated_field_handler() {")
+	f.P("static std::function<void(const char* message, const char* key)> handler;")
+	f.P("return handler;")
+	f.PD("}")
+	f.P("")
+	f.P("// deprecated なフィールドのキーが JSON にあった回数")
+	f.PI("inline std::atomic<uint64_t>& deprecated_field_count() {")
+	f.P("static std::atomic<uint64_t> count{0};")
+	f.P("return count;")
+	f.PD("}")
+	f.P("")
+	f.P("namespace detail {")
+	f.P("")
+	f.PI("inline void report_deprecated_field(const char* message, const char* key) {")
+	f.P("++deprecated_field_count();")
+	f.P("const auto& handler = deprecated_field_handler();")
+	f.PI("if (handler) {")
+	f.P("handler(message, key);")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// jsonif_message_keep_unknown_fields で使う型と関数
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genUnknownFieldsHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_UNKNOWN_FIELDS_DEFINED")
+	f.P("#define JSONIF_UNKNOWN_FIELDS_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("")
+	f.P("// JSON から読み込んだ時の知らないキーとその値")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("typedef nlohmann::json::object_t unknown_fields;")
+	f.P("#else")
+	f.P("typedef boost::json::object unknown_fields;")
+	f.P("#endif")
+	f.P("")
+	f.P("namespace detail {")
+	f.P("")
+	f.P("// keys は nullptr で終わる配列")
+	f.P("template<class Key>")
+	f.PI("inline bool is_known_key(const char* const* keys, const Key& key) {")
+	f.PI("for (; *keys != nullptr; ++keys) {")
+	f.PI("if (key.compare(*keys) == 0) {")
+	f.P("return true;")
+	f.PD("}")
+	f.PD("}")
+	f.P("return false;")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// JSON を読み込む前に型やキーを調べる関数と、読み込めなかった時のエラー
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genCheckHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_CHECK_DEFINED")
+	f.P("#define JSONIF_CHECK_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("")
+	f.P("// JSON を読み込めなかった理由")
+	f.PI("struct error {")
+	f.P("// JSON での位置（people[3].name など）。JSON 全体の場合は空文字列")
+	f.P("std::string path;")
+	f.P("std::string message;")
+	f.PD("};")
+	f.P("")
+	f.P("// strict なメッセージを読み込めなかった時に投げる例外")
+	f.P("// what() は \"people[3].name: expected string, got number\" のような文字列になる")
+	f.PI("class parse_error : public std::runtime_error {")
+	f.Deindent()
+	f.P(" public:")
+	f.Indent()
+	f.P("explicit parse_error(error e)")
+	f.P("    : std::runtime_error(e.path.empty() ? e.message : e.path + \": \" + e.message), error_(std::move(e)) {}")
+	f.P("const std::string& path() const { return error_.path; }")
+	f.P("const std::string& message() const { return error_.message; }")
+	f.P("")
+	f.Deindent()
+	f.P(" private:")
+	f.Indent()
+	f.P("error error_;")
+	f.PD("};")
+	f.P("")
+	f.P("namespace detail {")
+	f.P("")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("typedef nlohmann::json check_json;")
+	f.P("#else")
+	f.P("typedef boost::json::value check_json;")
+	f.P("#endif")
+	f.P("")
+	f.PI("inline const char* json_kind(const check_json& jv) {")
+	f.P("if (jv.is_null()) return \"null\";")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("if (jv.is_boolean()) return \"boolean\";")
+	f.P("#else")
+	f.P("if (jv.is_bool()) return \"boolean\";")
+	f.P("#endif")
+	f.P("if (jv.is_number()) return \"number\";")
+	f.P("if (jv.is_string()) return \"string\";")
+	f.P("if (jv.is_array()) return \"array\";")
+	f.P("return \"object\";")
+	f.PD("}")
+	f.P("")
+	f.P("// err を設定して false を返す")
+	f.PI("inline bool fail(const std::string& path, std::string message, error& err) {")
+	f.P("err.path = path;")
+	f.P("err.message = std::move(message);")
+	f.P("return false;")
+	f.PD("}")
+	f.PI("inline bool expected(const check_json& jv, const char* kind, const std::string& path, error& err) {")
+	f.P("return fail(path, std::string(\"expected \") + kind + \", got \" + json_kind(jv), err);")
+	f.PD("}")
+	f.P("")
+	f.P("// path にキーや添字を追加して、追加する前の長さを返す")
+	f.PI("inline size_t push_key(std::string& path, const char* key) {")
+	f.P("size_t n = path.size();")
+	f.P("if (n != 0) path += '.';")
+	f.P("path += key;")
+	f.P("return n;")
+	f.PD("}")
+	f.PI("inline size_t push_index(std::string& path, size_t i) {")
+	f.P("size_t n = path.size();")
+	f.P("path += \"[\" + std::to_string(i) + \"]\";")
+	f.P("return n;")
+	f.PD("}")
+	f.PI("inline size_t push_map_key(std::string& path, const std::string& key) {")
+	f.P("size_t n = path.size();")
+	f.P("path += \"[\\\"\" + key + \"\\\"]\";")
+	f.P("return n;")
+	f.PD("}")
+	f.P("")
+	f.PI("inline const check_json* find_key(const check_json& jv, const char* key) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("auto it = jv.find(key);")
+	f.P("return it == jv.end() ? nullptr : &*it;")
+	f.P("#else")
+	f.P("return jv.as_object().if_contains(key);")
+	f.P("#endif")
+	f.PD("}")
+	f.PI("inline bool missing_key(std::string& path, const char* key, error& err) {")
+	f.P("push_key(path, key);")
+	f.P("return fail(path, \"missing key\", err);")
+	f.PD("}")
+	f.P("// keys は nullptr で終わる配列")
+	f.P("// \"@type\" は google.protobuf.Any に詰めた時に付くので、常に受け付ける")
+	f.PI("inline bool check_unknown_keys(const check_json& jv, const char* const* keys, std::string& path, error& err) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("for (const auto& kv : jv.items()) {")
+	f.P("const std::string& key = kv.key();")
+	f.P("#else")
+	f.P("for (const auto& kv : jv.as_object()) {")
+	f.P("std::string key(kv.key().data(), kv.key().size());")
+	f.P("#endif")
+	f.P("bool known = key == \"@type\";")
+	f.PI("for (const char* const* p = keys; !known && *p != nullptr; ++p) {")
+	f.P("known = key == *p;")
+	f.PD("}")
+	f.PI("if (!known) {")
+	f.P("push_key(path, key.c_str());")
+	f.P("return fail(path, \"unknown key\", err);")
+	f.PD("}")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.PI("inline bool check_object(const check_json& jv, const std::string& path, error& err) {")
+	f.P("return jv.is_object() || expected(jv, \"object\", path, err);")
+	f.PD("}")
+	f.PI("inline bool check_array(const check_json& jv, const std::string& path, error& err) {")
+	f.P("return jv.is_array() || expected(jv, \"array\", path, err);")
+	f.PD("}")
+	f.PI("inline bool check_string(const check_json& jv, const std::string& path, error& err) {")
+	f.P("return jv.is_string() || expected(jv, \"string\", path, err);")
+	f.PD("}")
+	f.PI("inline bool check_bool(const check_json& jv, const std::string& path, error& err) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("return jv.is_boolean() || expected(jv, \"boolean\", path, err);")
+	f.P("#else")
+	f.P("return jv.is_bool() || expected(jv, \"boolean\", path, err);")
+	f.P("#endif")
+	f.PD("}")
+	f.PI("inline bool check_number(const check_json& jv, const std::string& path, error& err) {")
+	f.P("return jv.is_number() || expected(jv, \"number\", path, err);")
+	f.PD("}")
+	f.P("// Boost.JSON の value_to と同じく、小数の数値は 1.0 のように整数として表せる場合だけ受け付ける")
+	f.PI("inline bool check_integer(const check_json& jv, int64_t min, uint64_t max, const std::string& path, error& err) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("if (jv.is_number_unsigned()) {")
+	f.P("return jv.get<uint64_t>() <= max || fail(path, \"out of range\", err);")
+	f.PD("}")
+	f.PI("if (jv.is_number_integer()) {")
+	f.P("int64_t v = jv.get<int64_t>();")
+	f.P("return (v < 0 ? v >= min : (uint64_t)v <= max) || fail(path, \"out of range\", err);")
+	f.PD("}")
+	f.P("if (!jv.is_number_float()) return expected(jv, \"integer\", path, err);")
+	f.P("double d = jv.get<double>();")
+	f.P("#else")
+	f.PI("if (jv.is_uint64()) {")
+	f.P("return jv.get_uint64() <= max || fail(path, \"out of range\", err);")
+	f.PD("}")
+	f.PI("if (jv.is_int64()) {")
+	f.P("int64_t v = jv.get_int64();")
+	f.P("return (v < 0 ? v >= min : (uint64_t)v <= max) || fail(path, \"out of range\", err);")
+	f.PD("}")
+	f.P("if (!jv.is_double()) return expected(jv, \"integer\", path, err);")
+	f.P("double d = jv.get_double();")
+	f.P("#endif")
+	f.P("// 整数に変換できる範囲かどうかを先に調べて、変換して戻した値が同じなら整数として表せる")
+	f.PI("if (d < 0) {")
+	f.P("if (d < -9223372036854775808.0) return fail(path, \"out of range\", err);")
+	f.P("int64_t v = (int64_t)d;")
+	f.P("if ((double)v != d) return expected(jv, \"integer\", path, err);")
+	f.P("return v >= min || fail(path, \"out of range\", err);")
+	f.PD("}")
+	f.P("if (!(d < 18446744073709551616.0)) return fail(path, \"out of range\", err);")
+	f.P("uint64_t v = (uint64_t)d;")
+	f.P("if ((double)v != d) return expected(jv, \"integer\", path, err);")
+	f.P("return v <= max || fail(path, \"out of range\", err);")
+	f.PD("}")
+	f.P("// 10 進数の整数の文字列で、範囲内かどうか")
+	f.PI("inline bool is_integer_string(const std::string& s, int64_t min, uint64_t max) {")
+	f.P("bool neg = !s.empty() && s[0] == '-';")
+	f.P("size_t i = neg ? 1 : 0;")
+	f.P("if (i == s.size()) return false;")
+	f.P("uint64_t n = 0;")
+	f.PI("for (; i < s.size(); i++) {")
+	f.P("if (s[i] < '0' || '9' < s[i]) return false;")
+	f.P("uint64_t d = (uint64_t)(s[i] - '0');")
+	f.P("if (n > (UINT64_MAX - d) / 10) return false;")
+	f.P("n = n * 10 + d;")
+	f.PD("}")
+	f.P("return neg ? n <= 0 - (uint64_t)min : n <= max;")
+	f.PD("}")
+	f.P("// 文字列の int64 も数値の int64 も読み込めるようにする")
+	f.PI("inline bool check_integer_string(const check_json& jv, int64_t min, uint64_t max, const std::string& path, error& err) {")
+	f.PI("if (!jv.is_string()) {")
+	f.P("return check_integer(jv, min, max, path, err);")
+	f.PD("}")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("const std::string& s = jv.get_ref<const std::string&>();")
+	f.P("#else")
+	f.P("std::string s(jv.as_string().data(), jv.as_string().size());")
+	f.P("#endif")
+	f.P("return is_integer_string(s, min, max) || fail(path, \"invalid integer\", err);")
+	f.PD("}")
+	f.P("// jsonif_enum_as_name の enum は名前の文字列も数値も読み込める")
+	f.PI("inline bool check_enum(const check_json& jv, bool as_name, const std::string& path, error& err) {")
+	f.P("if (as_name && jv.is_string()) return true;")
+	f.P("if (as_name && !jv.is_number()) return expected(jv, \"string or integer\", path, err);")
+	f.P("return check_integer(jv, INT32_MIN, INT32_MAX, path, err);")
+	f.PD("}")
+	f.P("")
+	f.P("// 配列の各要素を f で調べる")
+	f.P("template<class F>")
+	f.PI("inline bool check_each(const check_json& jv, std::string& path, error& err, F f) {")
+	f.P("if (!check_array(jv, path, err)) return false;")
+	f.P("size_t i = 0;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("for (const auto& x : jv) {")
+	f.P("#else")
+	f.P("for (const auto& x : jv.as_array()) {")
+	f.P("#endif")
+	f.P("size_t n = push_index(path, i++);")
+	f.P("if (!f(x)) return false;")
+	f.P("path.resize(n);")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("// map のキーを is_key で、値を f で調べる")
+	f.P("template<class K, class F>")
+	f.PI("inline bool check_map(const check_json& jv, std::string& path, error& err, K is_key, F f) {")
+	f.P("if (!check_object(jv, path, err)) return false;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("for (const auto& kv : jv.items()) {")
+	f.P("std::string key = kv.key();")
+	f.P("#else")
+	f.P("for (const auto& kv : jv.as_object()) {")
+	f.P("std::string key(kv.key().data(), kv.key().size());")
+	f.P("#endif")
+	f.P("size_t n = push_map_key(path, key);")
+	f.P("if (!is_key(key)) return fail(path, \"invalid map key\", err);")
+	f.P("if (!f(kv.value())) return false;")
+	f.P("path.resize(n);")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("// メッセージごとに生成した jsonif_check を ADL で呼び出す")
+	f.P("template<class T>")
+	f.PI("inline bool check(const check_json& jv, std::string& path, error& err) {")
+	f.P("return jsonif_check(jv, static_cast<const T*>(nullptr), path, err);")
+	f.PD("}")
+	f.P("")
+	f.P("// strict なメッセージ（とそれを含むメッセージ）を読み込む前に、JSON 全体を調べて、問題があれば parse_error を投げる")
+	f.P("// ネストしたメッセージで何度も調べないように、一番外側のメッセージでだけ調べる")
+	f.PI("class strict_scope {")
+	f.Deindent()
+	f.P(" public:")
+	f.Indent()
+	f.P("// 既に JSON 全体を調べてある場合は、中のメッセージで調べないようにする")
+	f.PI("strict_scope() : outermost_(!active()) {")
+	f.P("active() = true;")
+	f.PD("}")
+	f.P("template<class T>")
+	f.PI("strict_scope(const check_json& jv, const T*) : outermost_(!active()) {")
+	f.P("if (!outermost_) return;")
+	f.P("std::string path;")
+	f.P("error err;")
+	f.PI("if (!check<T>(jv, path, err)) {")
+	f.P("JSONIF_THROW(parse_error(std::move(err)));")
+	f.PD("}")
+	f.P("active() = true;")
+	f.PD("}")
+	f.PI("~strict_scope() {")
+	f.P("if (outermost_) active() = false;")
+	f.PD("}")
+	f.P("strict_scope(const strict_scope&) = delete;")
+	f.P("strict_scope& operator=(const strict_scope&) = delete;")
+	f.P("")
+	f.Deindent()
+	f.P(" private:")
+	f.Indent()
+	f.PI("static bool& active() {")
+	f.P("thread_local bool v = false;")
+	f.P("return v;")
+	f.PD("}")
+	f.P("bool outermost_;")
+	f.PD("};")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// 例外を投げずに JSON を読み込む関数
+// std::string_view を使うので C++17 が必要になる。try_from_json パラメータを指定した場合だけ出力する
+func genTryFromJsonHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_TRY_FROM_JSON_DEFINED")
+	f.P("#define JSONIF_TRY_FROM_JSON_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("")
+	f.P("// 例外を投げずに JSON を読み込む")
+	f.P("// 読み込めなかった場合は err に位置と理由を設定して false を返す")
+	f.P("// 先に JSON 全体を調べるので、-fno-exceptions でも使える")
+	f.P("template<class T>")
+	f.PI("inline bool try_from_json(std::string_view s, T& v, error& err) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("nlohmann::json jv = nlohmann::json::parse(s.begin(), s.end(), nullptr, false);")
+	f.PI("if (jv.is_discarded()) {")
+	f.P("return detail::fail(\"\", \"invalid JSON\", err);")
+	f.PD("}")
+	f.P("#else")
+	f.P("boost::json::error_code ec;")
+	f.P("boost::json::value jv = boost::json::parse(boost::json::string_view(s.data(), s.size()), ec);")
+	f.PI("if (ec) {")
+	f.P("return detail::fail(\"\", \"invalid JSON: \" + ec.message(), err);")
+	f.PD("}")
+	f.P("#endif")
+	f.P("std::string path;")
+	f.PI("if (!detail::check<T>(jv, path, err)) {")
+	f.P("return false;")
+	f.PD("}")
+	f.P("detail::strict_scope checked;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("v = jv.get<T>();")
+	f.P("#else")
+	f.P("v = boost::json::value_to<T>(jv);")
+	f.P("#endif")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// well-known type の文字列の形式を調べる関数
+// jsonif_check から使うので、genCheckHelper と genWellKnownHelper の両方を出力した後に出力する
+func genCheckWellKnownHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_CHECK_WELL_KNOWN_TYPES_DEFINED")
+	f.P("#define JSONIF_CHECK_WELL_KNOWN_TYPES_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("namespace detail {")
+	f.P("")
+	f.PI("inline bool check_timestamp(const check_json& jv, const std::string& path, error& err) {")
+	f.P("if (!check_string(jv, path, err)) return false;")
+	f.P("timestamp v;")
+	f.P("bool out_of_range;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("if (parse_timestamp(jv.get_ref<const std::string&>(), v, out_of_range)) return true;")
+	f.P("#else")
+	f.P("if (parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()), v, out_of_range)) return true;")
+	f.P("#endif")
+	f.P("return fail(path, out_of_range ? \"out of range\" : \"invalid timestamp\", err);")
+	f.PD("}")
+	f.PI("inline bool check_duration(const check_json& jv, const std::string& path, error& err) {")
+	f.P("if (!check_string(jv, path, err)) return false;")
+	f.P("std::chrono::nanoseconds v;")
+	f.P("bool out_of_range;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("if (parse_duration(jv.get_ref<const std::string&>(), v, out_of_range)) return true;")
+	f.P("#else")
+	f.P("if (parse_duration(std::string(jv.as_string().data(), jv.as_string().size()), v, out_of_range)) return true;")
+	f.P("#endif")
+	f.P("return fail(path, out_of_range ? \"out of range\" : \"invalid duration\", err);")
+	f.PD("}")
+	f.P("// make_any と同じく、null 以外は \"@type\" に型の URL が必要")
+	f.PI("inline bool check_any(const check_json& jv, std::string& path, error& err) {")
+	f.P("if (jv.is_null()) return true;")
+	f.P("if (!check_object(jv, path, err)) return false;")
+	f.P("const check_json* p = find_key(jv, \"@type\");")
+	f.P("if (p == nullptr) return missing_key(path, \"@type\", err);")
+	f.P("size_t n = push_key(path, \"@type\");")
+	f.P("if (!check_string(*p, path, err)) return false;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("const std::string& url = p->get_ref<const std::string&>();")
+	f.P("#else")
+	f.P("std::string url(p->as_string().data(), p->as_string().size());")
+	f.P("#endif")
+	f.P("// any::type_name() と同じく、最後の / より後ろをメッセージの名前にする")
+	f.PI("if (url.empty() || url.back() == '/') {")
+	f.P("return fail(path, \"invalid type URL\", err);")
+	f.PD("}")
+	f.P("path.resize(n);")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// base64 の文字列を調べる関数
+// jsonif_check から使うので、genCheckHelper と genBase64Helper の両方を出力した後に出力する
+func genCheckBase64Helper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_CHECK_BASE64_DEFINED")
+	f.P("#define JSONIF_CHECK_BASE64_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("namespace detail {")
+	f.P("")
+	f.PI("inline bool check_base64(const check_json& jv, const std::string& path, error& err) {")
+	f.P("if (!check_string(jv, path, err)) return false;")
+	f.P("std::string v;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("if (decode_base64(jv.get_ref<const std::string&>(), v)) return true;")
+	f.P("#else")
+	f.P("if (decode_base64(std::string(jv.as_string().data(), jv.as_string().size()), v)) return true;")
+	f.P("#endif")
+	f.P("return fail(path, \"invalid base64\", err);")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
 // 大文字と数字はそのまま、小文字は大文字に、それ以外は _ にする
 // test/foo.proto → TEST_FOO_PROTO
 func toPreprocessorName(name string) string {
@@ -473,56 +1757,795 @@ func toPreprocessorName(name string) string {
 	return r
 }
 
-func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileDescriptorProto) (*pluginpb.CodeGeneratorResponse_File, error) {
+// 生成したコードで例外を投げる時に使うマクロ
+// -fno-exceptions でもコンパイルできるように、例外が使えない場合は abort する
+// 事前に JSONIF_THROW を定義しておけば、その定義を使う
+func genThrowHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_THROW")
+	f.P("#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)")
+	f.P("#define JSONIF_THROW(e) throw e")
+	f.P("#else")
+	f.P("#define JSONIF_THROW(e) abort()")
+	f.P("#endif")
+	f.P("#endif")
+	f.P("")
+}
+
+// well-known type の変換に使う関数
+// 複数のヘッダで定義しないように、最初にインクルードしたヘッダでだけ定義する
+func genWellKnownHelper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_WELL_KNOWN_TYPES_DEFINED")
+	f.P("#define JSONIF_WELL_KNOWN_TYPES_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("")
+	f.P("// google.protobuf.Struct, Value, ListValue は JSON の値をそのまま保持する")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("typedef nlohmann::json json_value;")
+	f.P("#else")
+	f.P("typedef boost::json::value json_value;")
+	f.P("#endif")
+	f.P("")
+	f.P("// google.protobuf.Any は \"@type\" を含む JSON のオブジェクトをそのまま保持する")
+	f.P("// 値が無い場合は null になる")
+	f.PI("struct any {")
+	f.P("json_value value;")
+	f.P("")
+	f.P("// \"@type\" の型の URL から取り出したメッセージの完全修飾名")
+	f.PI("std::string type_name() const {")
+	f.P("if (!value.is_object()) return \"\";")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("auto it = value.find(\"@type\");")
+	f.P("if (it == value.end() || !it->is_string()) return \"\";")
+	f.P("std::string url = it->get<std::string>();")
+	f.P("#else")
+	f.P("auto p = value.as_object().if_contains(\"@type\");")
+	f.P("if (p == nullptr || !p->is_string()) return \"\";")
+	f.P("std::string url(p->as_string().data(), p->as_string().size());")
+	f.P("#endif")
+	f.P("return url.substr(url.rfind('/') + 1);")
+	f.PD("}")
+	f.P("")
+	f.P("friend bool operator==(const any& a, const any& b) { return a.value == b.value; }")
+	f.P("friend bool operator!=(const any& a, const any& b) { return !(a == b); }")
+	f.PD("};")
+	f.P("")
+	f.P("// メッセージを google.protobuf.Any に詰める")
+	f.P("template<class T>")
+	f.PI("inline any pack(const T& v) {")
+	f.P("std::string url = std::string(\"type.googleapis.com/\") + ::jsonif::type_name<T>::value;")
+	f.P("any a;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("a.value = v;")
+	f.P("a.value[\"@type\"] = url;")
+	f.P("#else")
+	f.P("// \"@type\" を先頭に出力する")
+	f.P("boost::json::value fields = boost::json::value_from(v);")
+	f.P("boost::json::object obj;")
+	f.P("obj[\"@type\"] = url;")
+	f.PI("for (const auto& kv : fields.as_object()) {")
+	f.P("obj[kv.key()] = kv.value();")
+	f.PD("}")
+	f.P("a.value = std::move(obj);")
+	f.P("#endif")
+	f.P("return a;")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Any に入っているのが T かどうか")
+	f.P("template<class T>")
+	f.PI("inline bool is(const any& a) {")
+	f.P("return a.type_name() == ::jsonif::type_name<T>::value;")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Any から T を取り出す。T ではない場合は std::nullopt を返す")
+	f.P("template<class T>")
+	f.PI("inline std::optional<T> unpack(const any& a) {")
+	f.P("if (!is<T>(a)) return std::nullopt;")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("return a.value.get<T>();")
+	f.P("#else")
+	f.P("return boost::json::value_to<T>(a.value);")
+	f.P("#endif")
+	f.PD("}")
+	f.P("")
+	f.P("namespace detail {")
+	f.P("")
+	f.P("// \"@type\" の無いオブジェクトは google.protobuf.Any として読み込めない")
+	f.PI("inline any make_any(json_value jv) {")
+	f.P("any a;")
+	f.P("a.value = std::move(jv);")
+	f.PI("if (!a.value.is_null() && a.type_name().empty()) {")
+	f.P("JSONIF_THROW(std::invalid_argument(\"invalid google.protobuf.Any: @type is required\"));")
+	f.PD("}")
+	f.P("return a;")
+	f.PD("}")
+	f.P("")
+	f.P("typedef %s timestamp;", timestampType)
+	f.P("")
+	f.P("// 1970-01-01 からの日数と年月日を変換する")
+	f.P("// http://howardhinnant.github.io/date_algorithms.html")
+	f.PI("inline int64_t days_from_civil(int64_t y, int64_t m, int64_t d) {")
+	f.P("y -= m <= 2;")
+	f.P("const int64_t era = (y >= 0 ? y : y - 399) / 400;")
+	f.P("const int64_t yoe = y - era * 400;")
+	f.P("const int64_t doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;")
+	f.P("const int64_t doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;")
+	f.P("return era * 146097 + doe - 719468;")
+	f.PD("}")
+	f.PI("inline void civil_from_days(int64_t z, int64_t& y, int64_t& m, int64_t& d) {")
+	f.P("z += 719468;")
+	f.P("const int64_t era = (z >= 0 ? z : z - 146096) / 146097;")
+	f.P("const int64_t doe = z - era * 146097;")
+	f.P("const int64_t yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;")
+	f.P("const int64_t doy = doe - (365 * yoe + yoe / 4 - yoe / 100);")
+	f.P("const int64_t mp = (5 * doy + 2) / 153;")
+	f.P("d = doy - (153 * mp + 2) / 5 + 1;")
+	f.P("m = mp < 10 ? mp + 3 : mp - 9;")
+	f.P("y = yoe + era * 400 + (m <= 2);")
+	f.PD("}")
+	f.P("")
+	f.P("// 小数部は 0, 3, 6, 9 桁のいずれかで出力する")
+	f.PI("inline std::string format_nanos(int64_t nanos) {")
+	f.P("char buf[16];")
+	f.PI("if (nanos == 0) {")
+	f.P("return \"\";")
+	f.PDI("} else if (nanos %% 1000000 == 0) {")
+	f.P("snprintf(buf, sizeof(buf), \".%%03d\", (int)(nanos / 1000000));")
+	f.PDI("} else if (nanos %% 1000 == 0) {")
+	f.P("snprintf(buf, sizeof(buf), \".%%06d\", (int)(nanos / 1000));")
+	f.PDI("} else {")
+	f.P("snprintf(buf, sizeof(buf), \".%%09d\", (int)nanos);")
+	f.PD("}")
+	f.P("return buf;")
+	f.PD("}")
+	f.PI("inline bool parse_digits(const std::string& s, size_t& i, size_t n, int64_t& r) {")
+	f.P("r = 0;")
+	f.PI("for (size_t end = i + n; i < end; i++) {")
+	f.P("if (i >= s.size() || s[i] < '0' || '9' < s[i]) return false;")
+	f.P("r = r * 10 + (s[i] - '0');")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.PI("inline bool parse_char(const std::string& s, size_t& i, const char* cs) {")
+	f.P("if (i >= s.size() || strchr(cs, s[i]) == nullptr) return false;")
+	f.P("i++;")
+	f.P("return true;")
+	f.PD("}")
+	f.PI("inline bool parse_nanos(const std::string& s, size_t& i, int64_t& nanos) {")
+	f.P("nanos = 0;")
+	f.P("int digits = 0;")
+	f.PI("if (i < s.size() && s[i] == '.') {")
+	f.P("i++;")
+	f.PI("for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++, digits++) {")
+	f.P("if (digits == 9) return false;")
+	f.P("nanos = nanos * 10 + (s[i] - '0');")
+	f.PD("}")
+	f.P("if (digits == 0) return false;")
+	f.PD("}")
+	f.PI("for (; digits < 9; digits++) {")
+	f.P("nanos *= 10;")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Timestamp は RFC 3339 形式の文字列にする（例: 1972-01-01T10:00:20.021Z）")
+	f.PI("inline std::string format_timestamp(const timestamp& v) {")
+	f.P("int64_t ns = v.time_since_epoch().count();")
+	f.P("int64_t secs = ns / 1000000000;")
+	f.P("int64_t nanos = ns %% 1000000000;")
+	f.PI("if (nanos < 0) {")
+	f.P("secs -= 1;")
+	f.P("nanos += 1000000000;")
+	f.PD("}")
+	f.P("int64_t days = secs / 86400;")
+	f.P("int64_t rem = secs %% 86400;")
+	f.PI("if (rem < 0) {")
+	f.P("days -= 1;")
+	f.P("rem += 86400;")
+	f.PD("}")
+	f.P("int64_t y, m, d;")
+	f.P("civil_from_days(days, y, m, d);")
+	f.P("char buf[32];")
+	f.P("snprintf(buf, sizeof(buf), \"%%04d-%%02d-%%02dT%%02d:%%02d:%%02d\", (int)y, (int)m, (int)d,")
+	f.P("         (int)(rem / 3600), (int)(rem / 60 %% 60), (int)(rem %% 60));")
+	f.P("return buf + format_nanos(nanos) + \"Z\";")
+	f.PD("}")
+	f.P("// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする")
+	f.PI("inline bool parse_timestamp(const std::string& s, timestamp& v, bool& out_of_range) {")
+	f.P("out_of_range = false;")
+	f.P("size_t i = 0;")
+	f.P("int64_t y = 0, m = 0, d = 0, hh = 0, mm = 0, ss = 0, nanos = 0;")
+	f.P("int64_t offset = 0;")
+	f.P("bool ok = parse_digits(s, i, 4, y) && parse_char(s, i, \"-\") && parse_digits(s, i, 2, m) &&")
+	f.P("          parse_char(s, i, \"-\") && parse_digits(s, i, 2, d) && parse_char(s, i, \"Tt\") &&")
+	f.P("          parse_digits(s, i, 2, hh) && parse_char(s, i, \":\") && parse_digits(s, i, 2, mm) &&")
+	f.P("          parse_char(s, i, \":\") && parse_digits(s, i, 2, ss) && parse_nanos(s, i, nanos);")
+	f.PI("if (ok && !parse_char(s, i, \"Zz\")) {")
+	f.P("// UTC 以外の場合は +09:00 のようなオフセットが付いている")
+	f.P("int64_t sign = i < s.size() && s[i] == '-' ? -1 : 1;")
+	f.P("int64_t oh = 0, om = 0;")
+	f.P("ok = parse_char(s, i, \"+-\") && parse_digits(s, i, 2, oh) && parse_char(s, i, \":\") && parse_digits(s, i, 2, om);")
+	f.P("offset = sign * (oh * 3600 + om * 60);")
+	f.PD("}")
+	f.PI("if (!ok || i != s.size() || m < 1 || 12 < m || d < 1 || 31 < d || 23 < hh || 59 < mm || 59 < ss) {")
+	f.P("return false;")
+	f.PD("}")
+	f.P("int64_t secs = days_from_civil(y, m, d) * 86400 + hh * 3600 + mm * 60 + ss - offset;")
+	f.P("// ナノ秒で表現できる範囲（1677 年から 2262 年まで）を超える場合はエラーにする")
+	f.PI("if (secs < -9223372035 || 9223372035 < secs) {")
+	f.P("out_of_range = true;")
+	f.P("return false;")
+	f.PD("}")
+	f.P("v = timestamp(std::chrono::nanoseconds(secs * 1000000000 + nanos));")
+	f.P("return true;")
+	f.PD("}")
+	f.PI("inline timestamp parse_timestamp(const std::string& s) {")
+	f.P("timestamp v;")
+	f.P("bool out_of_range;")
+	f.PI("if (!parse_timestamp(s, v, out_of_range)) {")
+	f.PI("if (out_of_range) {")
+	f.P("JSONIF_THROW(std::out_of_range(\"google.protobuf.Timestamp out of range: \" + s));")
+	f.PD("}")
+	f.P("JSONIF_THROW(std::invalid_argument(\"invalid google.protobuf.Timestamp: \" + s));")
+	f.PD("}")
+	f.P("return v;")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Duration は秒数の後ろに s を付けた文字列にする（例: 1.5s）")
+	f.PI("inline std::string format_duration(std::chrono::nanoseconds v) {")
+	f.P("int64_t ns = v.count();")
+	f.P("// 符号を反転した時に溢れないように、符号無しで計算する")
+	f.P("uint64_t abs = ns < 0 ? 0 - (uint64_t)ns : (uint64_t)ns;")
+	f.P("return (ns < 0 ? \"-\" : \"\") + std::to_string(abs / 1000000000) + format_nanos((int64_t)(abs %% 1000000000)) + \"s\";")
+	f.PD("}")
+	f.P("// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする")
+	f.PI("inline bool parse_duration(const std::string& s, std::chrono::nanoseconds& v, bool& out_of_range) {")
+	f.P("out_of_range = false;")
+	f.P("size_t i = 0;")
+	f.P("bool neg = i < s.size() && s[i] == '-';")
+	f.P("if (neg) i++;")
+	f.P("size_t start = i;")
+	f.P("int64_t secs = 0;")
+	f.PI("for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++) {")
+	f.PI("if (secs > 922337203) {")
+	f.P("out_of_range = true;")
+	f.P("return false;")
+	f.PD("}")
+	f.P("secs = secs * 10 + (s[i] - '0');")
+	f.PD("}")
+	f.P("int64_t nanos = 0;")
+	f.PI("if (i == start || !parse_nanos(s, i, nanos) || i + 1 != s.size() || s[i] != 's') {")
+	f.P("return false;")
+	f.PD("}")
+	f.PI("if (secs > 9223372035) {")
+	f.P("out_of_range = true;")
+	f.P("return false;")
+	f.PD("}")
+	f.P("int64_t ns = secs * 1000000000 + nanos;")
+	f.P("v = std::chrono::nanoseconds(neg ? -ns : ns);")
+	f.P("return true;")
+	f.PD("}")
+	f.PI("inline std::chrono::nanoseconds parse_duration(const std::string& s) {")
+	f.P("std::chrono::nanoseconds v;")
+	f.P("bool out_of_range;")
+	f.PI("if (!parse_duration(s, v, out_of_range)) {")
+	f.PI("if (out_of_range) {")
+	f.P("JSONIF_THROW(std::out_of_range(\"google.protobuf.Duration out of range: \" + s));")
+	f.PD("}")
+	f.P("JSONIF_THROW(std::invalid_argument(\"invalid google.protobuf.Duration: \" + s));")
+	f.PD("}")
+	f.P("return v;")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("}")
+	f.P("")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("")
+	f.P("namespace nlohmann {")
+	f.P("")
+	f.P("template<>")
+	f.PI("struct adl_serializer<::jsonif::detail::timestamp> {")
+	f.PI("static void to_json(nlohmann::json& jv, const ::jsonif::detail::timestamp& v) {")
+	f.P("jv = ::jsonif::detail::format_timestamp(v);")
+	f.PD("}")
+	f.PI("static void from_json(const nlohmann::json& jv, ::jsonif::detail::timestamp& v) {")
+	f.P("v = ::jsonif::detail::parse_timestamp(jv.get<std::string>());")
+	f.PD("}")
+	f.PD("};")
+	f.P("")
+	f.P("template<>")
+	f.PI("struct adl_serializer<std::chrono::nanoseconds> {")
+	f.PI("static void to_json(nlohmann::json& jv, const std::chrono::nanoseconds& v) {")
+	f.P("jv = ::jsonif::detail::format_duration(v);")
+	f.PD("}")
+	f.PI("static void from_json(const nlohmann::json& jv, std::chrono::nanoseconds& v) {")
+	f.P("v = ::jsonif::detail::parse_duration(jv.get<std::string>());")
+	f.PD("}")
+	f.PD("};")
+	f.P("")
+	f.P("// ラッパー型は値が無い場合に null になる")
+	f.P("template<class T>")
+	f.PI("struct adl_serializer<std::optional<T>> {")
+	f.PI("static void to_json(nlohmann::json& jv, const std::optional<T>& v) {")
+	f.PI("if (v) {")
+	f.P("jv = *v;")
+	f.PDI("} else {")
+	f.P("jv = nullptr;")
+	f.PD("}")
+	f.PD("}")
+	f.PI("static void from_json(const nlohmann::json& jv, std::optional<T>& v) {")
+	f.PI("if (jv.is_null()) {")
+	f.P("v = std::nullopt;")
+	f.PDI("} else {")
+	f.P("v = jv.template get<T>();")
+	f.PD("}")
+	f.PD("}")
+	f.PD("};")
+	f.P("")
+	f.P("template<>")
+	f.PI("struct adl_serializer<::jsonif::any> {")
+	f.PI("static void to_json(nlohmann::json& jv, const ::jsonif::any& v) {")
+	f.P("jv = v.value;")
+	f.PD("}")
+	f.PI("static void from_json(const nlohmann::json& jv, ::jsonif::any& v) {")
+	f.P("v = ::jsonif::detail::make_any(jv);")
+	f.PD("}")
+	f.PD("};")
+	f.P("")
+	f.P("}")
+	f.P("")
+	f.P("#else")
+	f.P("")
+	f.P("// std::optional は Boost.JSON が null として扱ってくれる")
+	f.P("// std::chrono の型は、ADL で見つかるように boost::json 名前空間に定義する")
+	f.P("namespace boost {")
+	f.P("namespace json {")
+	f.P("")
+	f.PI("inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::detail::timestamp& v) {")
+	f.P("jv = ::jsonif::detail::format_timestamp(v);")
+	f.PD("}")
+	f.PI("inline ::jsonif::detail::timestamp tag_invoke(const value_to_tag<::jsonif::detail::timestamp>&, const value& jv) {")
+	f.P("return ::jsonif::detail::parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()));")
+	f.PD("}")
+	f.PI("inline void tag_invoke(const value_from_tag&, value& jv, const std::chrono::nanoseconds& v) {")
+	f.P("jv = ::jsonif::detail::format_duration(v);")
+	f.PD("}")
+	f.PI("inline std::chrono::nanoseconds tag_invoke(const value_to_tag<std::chrono::nanoseconds>&, const value& jv) {")
+	f.P("return ::jsonif::detail::parse_duration(std::string(jv.as_string().data(), jv.as_string().size()));")
+	f.PD("}")
+	f.PI("inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::any& v) {")
+	f.P("jv = v.value;")
+	f.PD("}")
+	f.PI("inline ::jsonif::any tag_invoke(const value_to_tag<::jsonif::any>&, const value& jv) {")
+	f.P("return ::jsonif::detail::make_any(jv);")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+	f.P("#endif")
+}
+
+// int64, uint64 を文字列で読み書きする関数（canonical で使う）
+// ラッパー型の std::optional と repeated の std::vector も扱う
+func genInt64Helper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_INT64_STRING_DEFINED")
+	f.P("#define JSONIF_INT64_STRING_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("namespace detail {")
+	f.P("")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("typedef nlohmann::json int64_json;")
+	f.P("#else")
+	f.P("typedef boost::json::value int64_json;")
+	f.P("#endif")
+	f.P("")
+	f.P("// 精度が落ちないように文字列で出力する")
+	f.P("template<class T>")
+	f.PI("inline void int64_to_json(int64_json& jv, const T& v) {")
+	f.P("jv = std::to_string(v);")
+	f.PD("}")
+	f.P("template<class T>")
+	f.PI("inline void int64_to_json(int64_json& jv, const std::optional<T>& v) {")
+	f.PI("if (v) {")
+	f.P("int64_to_json(jv, *v);")
+	f.PDI("} else {")
+	f.P("jv = nullptr;")
+	f.PD("}")
+	f.PD("}")
+	f.P("template<class T>")
+	f.PI("inline void int64_to_json(int64_json& jv, const std::vector<T>& v) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("jv = nlohmann::json::array();")
+	f.PI("for (const auto& x : v) {")
+	f.P("jv.push_back(std::to_string(x));")
+	f.PD("}")
+	f.P("#else")
+	f.P("boost::json::array arr;")
+	f.PI("for (const auto& x : v) {")
+	f.P("arr.emplace_back(std::to_string(x));")
+	f.PD("}")
+	f.P("jv = std::move(arr);")
+	f.P("#endif")
+	f.PD("}")
+	f.P("")
+	f.P("// 文字列と数値のどちらでも読み込める")
+	f.P("template<class T>")
+	f.PI("inline void int64_from_json(const int64_json& jv, T& v) {")
+	f.PI("if (jv.is_string()) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("std::string s = jv.template get<std::string>();")
+	f.P("#else")
+	f.P("std::string s(jv.as_string().data(), jv.as_string().size());")
+	f.P("#endif")
+	f.PI("if constexpr (std::is_signed<T>::value) {")
+	f.P("v = (T)std::stoll(s);")
+	f.PDI("} else {")
+	f.P("v = (T)std::stoull(s);")
+	f.PD("}")
+	f.PDI("} else {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("v = jv.template get<T>();")
+	f.P("#else")
+	f.P("v = boost::json::value_to<T>(jv);")
+	f.P("#endif")
+	f.PD("}")
+	f.PD("}")
+	f.P("template<class T>")
+	f.PI("inline void int64_from_json(const int64_json& jv, std::optional<T>& v) {")
+	f.PI("if (jv.is_null()) {")
+	f.P("v = std::nullopt;")
+	f.PDI("} else {")
+	f.P("T x{};")
+	f.P("int64_from_json(jv, x);")
+	f.P("v = x;")
+	f.PD("}")
+	f.PD("}")
+	f.P("template<class T>")
+	f.PI("inline void int64_from_json(const int64_json& jv, std::vector<T>& v) {")
+	f.P("v.clear();")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("for (const auto& x : jv) {")
+	f.P("#else")
+	f.P("for (const auto& x : jv.as_array()) {")
+	f.P("#endif")
+	f.P("T y{};")
+	f.P("int64_from_json(x, y);")
+	f.P("v.push_back(y);")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+// bytes を base64 の文字列で読み書きする関数
+// ラッパー型の std::optional と repeated の std::vector も扱う
+func genBase64Helper(f *internal.Formatter) {
+	f.P("#ifndef JSONIF_BASE64_DEFINED")
+	f.P("#define JSONIF_BASE64_DEFINED")
+	f.P("")
+	f.P("namespace jsonif {")
+	f.P("namespace detail {")
+	f.P("")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("typedef nlohmann::json base64_json;")
+	f.P("#else")
+	f.P("typedef boost::json::value base64_json;")
+	f.P("#endif")
+	f.P("")
+	f.P("// パディング付きの標準の base64 にする")
+	f.P("// url が true の場合はパディング無しの URL セーフな base64 にする")
+	f.PI("inline std::string encode_base64(const std::string& s, bool url = false) {")
+	f.P("const char* table = url ? \"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_\"")
+	f.P("                        : \"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/\";")
+	f.P("std::string r;")
+	f.P("r.reserve((s.size() + 2) / 3 * 4);")
+	f.PI("for (size_t i = 0; i < s.size(); i += 3) {")
+	f.P("uint32_t n = (uint32_t)(unsigned char)s[i] << 16;")
+	f.P("if (i + 1 < s.size()) n |= (uint32_t)(unsigned char)s[i + 1] << 8;")
+	f.P("if (i + 2 < s.size()) n |= (uint32_t)(unsigned char)s[i + 2];")
+	f.P("r += table[(n >> 18) & 0x3f];")
+	f.P("r += table[(n >> 12) & 0x3f];")
+	f.PI("if (i + 1 < s.size()) {")
+	f.P("r += table[(n >> 6) & 0x3f];")
+	f.PDI("} else if (!url) {")
+	f.P("r += '=';")
+	f.PD("}")
+	f.PI("if (i + 2 < s.size()) {")
+	f.P("r += table[n & 0x3f];")
+	f.PDI("} else if (!url) {")
+	f.P("r += '=';")
+	f.PD("}")
+	f.PD("}")
+	f.P("return r;")
+	f.PD("}")
+	f.P("// 標準と URL セーフのどちらの base64 でも読み込める。パディングは省略してもいい")
+	f.P("// 読み込めなかった場合は false を返す")
+	f.PI("inline bool decode_base64(const std::string& s, std::string& r) {")
+	f.P("r.clear();")
+	f.P("uint32_t n = 0;")
+	f.P("int bits = 0;")
+	f.P("size_t i = 0;")
+	f.PI("for (; i < s.size() && s[i] != '='; i++) {")
+	f.P("char c = s[i];")
+	f.P("int d;")
+	f.PI("if ('A' <= c && c <= 'Z') {")
+	f.P("d = c - 'A';")
+	f.PDI("} else if ('a' <= c && c <= 'z') {")
+	f.P("d = c - 'a' + 26;")
+	f.PDI("} else if ('0' <= c && c <= '9') {")
+	f.P("d = c - '0' + 52;")
+	f.PDI("} else if (c == '+' || c == '-') {")
+	f.P("d = 62;")
+	f.PDI("} else if (c == '/' || c == '_') {")
+	f.P("d = 63;")
+	f.PDI("} else {")
+	f.P("return false;")
+	f.PD("}")
+	f.P("n = (n << 6) | (uint32_t)d;")
+	f.P("bits += 6;")
+	f.PI("if (bits >= 8) {")
+	f.P("bits -= 8;")
+	f.P("r += (char)((n >> bits) & 0xff);")
+	f.PD("}")
+	f.PD("}")
+	f.P("size_t pad = s.size() - i;")
+	f.P("return pad <= 2 && s.find_first_not_of('=', i) == std::string::npos && i %% 4 != 1;")
+	f.PD("}")
+	f.PI("inline std::string decode_base64(const std::string& s) {")
+	f.P("std::string r;")
+	f.PI("if (!decode_base64(s, r)) {")
+	f.P("JSONIF_THROW(std::invalid_argument(\"invalid base64: \" + s));")
+	f.PD("}")
+	f.P("return r;")
+	f.PD("}")
+	f.P("")
+	f.PI("inline void base64_to_json(base64_json& jv, const std::string& v, bool url = false) {")
+	f.P("jv = encode_base64(v, url);")
+	f.PD("}")
+	f.PI("inline void base64_to_json(base64_json& jv, const std::optional<std::string>& v, bool url = false) {")
+	f.PI("if (v) {")
+	f.P("jv = encode_base64(*v, url);")
+	f.PDI("} else {")
+	f.P("jv = nullptr;")
+	f.PD("}")
+	f.PD("}")
+	f.PI("inline void base64_to_json(base64_json& jv, const std::vector<std::string>& v, bool url = false) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("jv = nlohmann::json::array();")
+	f.PI("for (const auto& x : v) {")
+	f.P("jv.push_back(encode_base64(x, url));")
+	f.PD("}")
+	f.P("#else")
+	f.P("boost::json::array arr;")
+	f.PI("for (const auto& x : v) {")
+	f.P("arr.emplace_back(encode_base64(x, url));")
+	f.PD("}")
+	f.P("jv = std::move(arr);")
+	f.P("#endif")
+	f.PD("}")
+	f.P("")
+	f.PI("inline void base64_from_json(const base64_json& jv, std::string& v) {")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.P("v = decode_base64(jv.get<std::string>());")
+	f.P("#else")
+	f.P("v = decode_base64(std::string(jv.as_string().data(), jv.as_string().size()));")
+	f.P("#endif")
+	f.PD("}")
+	f.PI("inline void base64_from_json(const base64_json& jv, std::optional<std::string>& v) {")
+	f.PI("if (jv.is_null()) {")
+	f.P("v = std::nullopt;")
+	f.PDI("} else {")
+	f.P("std::string x;")
+	f.P("base64_from_json(jv, x);")
+	f.P("v = std::move(x);")
+	f.PD("}")
+	f.PD("}")
+	f.PI("inline void base64_from_json(const base64_json& jv, std::vector<std::string>& v) {")
+	f.P("v.clear();")
+	f.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+	f.PI("for (const auto& x : jv) {")
+	f.P("#else")
+	f.P("for (const auto& x : jv.as_array()) {")
+	f.P("#endif")
+	f.P("std::string y;")
+	f.P("base64_from_json(x, y);")
+	f.P("v.push_back(std::move(y));")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// base64url パラメータを指定した場合に使う。読み込みは base64 と同じ")
+	f.P("template<class T>")
+	f.PI("inline void base64url_to_json(base64_json& jv, const T& v) {")
+	f.P("base64_to_json(jv, v, true);")
+	f.PD("}")
+	f.P("template<class T>")
+	f.PI("inline void base64url_from_json(const base64_json& jv, T& v) {")
+	f.P("base64_from_json(jv, v);")
+	f.PD("}")
+	f.P("")
+	f.P("}")
+	f.P("}")
+	f.P("")
+	f.P("#endif")
+	f.P("")
+}
+
+func genFile(file *internal.File, opts *options) (*pluginpb.CodeGeneratorResponse_File, error) {
 	var pkgs []string
-	if file.Package != nil {
-		pkgs = strings.Split(*file.Package, ".")
+	if len(file.Package) != 0 {
+		for _, pkg := range strings.Split(file.Package, ".") {
+			pkgs = append(pkgs, escapeName(pkg))
+		}
 	}
 
+	// timestamp.proto のように well-known type だけを定義しているファイルは、
+	// 型を定義する代わりに変換処理だけを出力する（C 向けのコードから利用する）
+	wellKnownFile := file.IsWellKnown()
+	useWellKnown := wellKnownFile || hasWellKnownField(file.Messages)
+	useInt64 := !wellKnownFile && hasFieldFunc(file.Messages, func(field *internal.Field) bool { return field.Int64AsString })
+	useBase64 := !wellKnownFile && hasFieldFunc(file.Messages, func(field *internal.Field) bool { return field.Base64 })
+	useValidate := !wellKnownFile && hasMessageFunc(file.Messages, func(msg *internal.Message) bool { return msg.HasValidation() })
+	useReportDeprecated := !wellKnownFile && opts.ReportDeprecated
+	useDeprecated := !wellKnownFile && file.UsesDeprecated()
+	useUnknownFields := !wellKnownFile && hasMessageFunc(file.Messages, func(msg *internal.Message) bool { return msg.KeepUnknownFields })
+	// well-known type のファイルも、C 向けのコードから parse_error を使うので、メッセージを生成しなくても定義する
+	useCheck := checkEnabled(file, opts)
+	useTryFromJson := useCheck && opts.TryFromJson
+	useRegex := useValidate && hasFieldFunc(file.Messages, func(field *internal.Field) bool {
+		return field.Validation != nil && len(field.Validation.Pattern) != 0
+	})
+
 	cpp := cppFile{}
-	cpp.Top.P("#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_%s", toPreprocessorName(*file.Name))
-	cpp.Top.P("#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_%s", toPreprocessorName(*file.Name))
+	cpp.Top.P("#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_%s", toPreprocessorName(file.Name))
+	cpp.Top.P("#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_%s", toPreprocessorName(file.Name))
 	cpp.Top.P("")
 	cpp.Top.P("#include <string>")
 	cpp.Top.P("#include <vector>")
+	if hasMapField(file.Messages) {
+		if opts.MapType == "unordered_map" {
+			cpp.Top.P("#include <unordered_map>")
+		} else {
+			cpp.Top.P("#include <map>")
+		}
+	}
+	if useWellKnown {
+		cpp.Top.P("#include <chrono>")
+	}
+	if useWellKnown || useInt64 || useBase64 {
+		cpp.Top.P("#include <optional>")
+	}
+	if useWellKnown || useInt64 || useBase64 || useCheck {
+		cpp.Top.P("#include <stdexcept>")
+	}
+	if useTryFromJson {
+		cpp.Top.P("#include <string_view>")
+	}
+	if useInt64 {
+		cpp.Top.P("#include <type_traits>")
+	}
+	if useRegex {
+		cpp.Top.P("#include <regex>")
+	}
+	if useReportDeprecated {
+		cpp.Top.P("#include <atomic>")
+		cpp.Top.P("#include <functional>")
+	}
 	cpp.Top.P("#include <stddef.h>")
+	if useWellKnown || useBase64 || useReportDeprecated || useCheck {
+		cpp.Top.P("#include <stdint.h>")
+	}
+	if useWellKnown {
+		cpp.Top.P("#include <stdio.h>")
+	}
+	if useWellKnown || useBase64 || useCheck {
+		cpp.Top.P("#include <stdlib.h>")
+	}
+	if useWellKnown {
+		cpp.Top.P("#include <string.h>")
+	}
 	cpp.Top.P("")
+	switch opts.Backend {
+	case "nlohmann":
+		cpp.Top.P("#if !defined(JSONIF_USE_NLOHMANN_JSON)")
+		cpp.Top.P("#define JSONIF_USE_NLOHMANN_JSON")
+		cpp.Top.P("#endif")
+		cpp.Top.P("")
+	case "boost":
+		cpp.Top.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
+		cpp.Top.P("#error \"%s was generated with backend=boost\"", file.Name)
+		cpp.Top.P("#endif")
+		cpp.Top.P("")
+	}
 	cpp.Top.P("#if defined(JSONIF_USE_NLOHMANN_JSON)")
 	cpp.Top.P("#include <nlohmann/json.hpp>")
 	cpp.Top.P("#else")
 	cpp.Top.P("#include <boost/json.hpp>")
 	cpp.Top.P("#endif")
 	cpp.Top.P("")
-	for _, dep := range file.Dependency {
-		// ファイルが存在してない可能性もあるのでチェックする
-		exists := false
-		for _, f := range files {
-			if dep == *f.Name {
-				exists = true
-				break
-			}
-		}
-		if !exists {
+	cpp.Top.P("#ifndef JSONIF_TYPE_NAME_DEFINED")
+	cpp.Top.P("#define JSONIF_TYPE_NAME_DEFINED")
+	cpp.Top.P("")
+	cpp.Top.P("namespace jsonif {")
+	cpp.Top.P("")
+	cpp.Top.P("// メッセージの完全修飾名（google.protobuf.Any の \"@type\" に使う）")
+	cpp.Top.P("// 生成したメッセージごとに特殊化する")
+	cpp.Top.P("template<class T>")
+	cpp.Top.P("struct type_name;")
+	cpp.Top.P("")
+	cpp.Top.P("}")
+	cpp.Top.P("")
+	cpp.Top.P("#endif")
+	cpp.Top.P("")
+	if useWellKnown || useBase64 || useCheck {
+		genThrowHelper(&cpp.Top)
+	}
+	if useWellKnown {
+		genWellKnownHelper(&cpp.Top)
+	}
+	if useInt64 {
+		genInt64Helper(&cpp.Top)
+	}
+	if useBase64 {
+		genBase64Helper(&cpp.Top)
+	}
+	if useValidate {
+		genValidateHelper(&cpp.Top)
+	}
+	if useReportDeprecated {
+		genDeprecatedFieldHelper(&cpp.Top)
+	}
+	if useUnknownFields {
+		genUnknownFieldsHelper(&cpp.Top)
+	}
+	if useCheck {
+		genCheckHelper(&cpp.Top)
+	}
+	if useTryFromJson {
+		genTryFromJsonHelper(&cpp.Top)
+	}
+	if useCheck && useWellKnown {
+		genCheckWellKnownHelper(&cpp.Top)
+	}
+	if useCheck && useBase64 {
+		genCheckBase64Helper(&cpp.Top)
+	}
+	for _, dep := range file.Dependencies {
+		// well-known type は std::chrono などに変換するので、生成したヘッダは不要
+		if dep.IsWellKnown() {
 			continue
 		}
-
 		// 拡張子を取り除いて .json.h を付ける
-		fileName := dep
+		fileName := dep.Name
 		fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
 		fileName = fileName + ".json.h"
 		cpp.Top.P("#include \"%s\"", fileName)
 	}
 	cpp.Top.P("")
-	for _, pkg := range pkgs {
-		cpp.Top.P("namespace %s {", pkg)
+	if useDeprecated {
+		// 生成したコード自身が deprecated な要素を参照した時の警告は出さない
+		internal.DisableDeprecatedWarnings(&cpp.Top)
+		cpp.Top.P("")
 	}
-	cpp.Top.P("")
+	if !wellKnownFile {
+		for _, pkg := range pkgs {
+			cpp.Top.P("namespace %s {", pkg)
+		}
+		cpp.Top.P("")
 
-	cpp.Bottom.P("")
-	for range pkgs {
-		cpp.Bottom.P("}")
 	}
-	cpp.Bottom.P("")
+	if useDeprecated {
+		internal.RestoreDeprecatedWarnings(&cpp.Bottom)
+		cpp.Bottom.P("")
+	}
 	cpp.Bottom.P("#ifndef JSONIF_HELPER_DEFINED")
 	cpp.Bottom.P("#define JSONIF_HELPER_DEFINED")
 	cpp.Bottom.P("")
@@ -531,9 +2554,7 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 	cpp.Bottom.P("template<class T>")
 	cpp.Bottom.PI("inline T from_json(const std::string& s) {")
 	cpp.Bottom.PI("#if defined(JSONIF_USE_NLOHMANN_JSON)")
-	cpp.Bottom.P("T t;")
-	cpp.Bottom.P("from_json(nlohmann::json::parse(s), t);")
-	cpp.Bottom.P("return t;")
+	cpp.Bottom.P("return nlohmann::json::parse(s).get<T>();")
 	cpp.Bottom.PDI("#else")
 	cpp.Bottom.P("return boost::json::value_to<T>(boost::json::parse(s));")
 	cpp.Bottom.PD("#endif")
@@ -542,9 +2563,7 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 	cpp.Bottom.P("template<class T>")
 	cpp.Bottom.PI("inline std::string to_json(const T& v) {")
 	cpp.Bottom.PI("#if defined(JSONIF_USE_NLOHMANN_JSON)")
-	cpp.Bottom.P("using nlohmann::to_json;")
-	cpp.Bottom.P("nlohmann::json j;")
-	cpp.Bottom.P("to_json(j, v);")
+	cpp.Bottom.P("nlohmann::json j = v;")
 	cpp.Bottom.P("return j.dump();")
 	cpp.Bottom.PDI("#else")
 	cpp.Bottom.P("return boost::json::serialize(boost::json::value_from(v));")
@@ -557,20 +2576,28 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 	cpp.Bottom.P("")
 	cpp.Bottom.P("#endif")
 
-	for _, enum := range file.EnumType {
-		if err := genEnum(enum, file.Package, nil, &cpp); err != nil {
-			return nil, err
+	if !wellKnownFile {
+		for _, enum := range file.Enums {
+			if err := genEnum(enum, &cpp); err != nil {
+				return nil, err
+			}
 		}
-	}
 
-	for _, desc := range file.MessageType {
-		if err := genDescriptor(desc, file.Package, nil, &cpp); err != nil {
-			return nil, err
+		for _, msg := range file.MessagesInDefinitionOrder() {
+			if err := genDescriptor(msg, &cpp, opts); err != nil {
+				return nil, err
+			}
 		}
+
+		cpp.TagInvokes.P("")
+		for range pkgs {
+			cpp.TagInvokes.P("}")
+		}
+		cpp.TagInvokes.P("")
 	}
 
 	// 拡張子を取り除いて .json.h を付ける
-	fileName := *file.Name
+	fileName := file.Name
 	fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
 	fileName = fileName + ".json.h"
 
@@ -582,11 +2609,124 @@ func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileD
 	return resp, nil
 }
 
-func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+func checkEnumNames(enum *internal.Enum, scope *internal.NameScope) error {
+	if err := scope.Add(enum.Name, enum); err != nil {
+		return err
+	}
+	// enum class ではないので、値は enum と同じスコープに定義される
+	for _, v := range enum.Values {
+		if err := scope.Add(v.Name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkMessageNames(msg *internal.Message, scope *internal.NameScope) error {
+	if err := scope.Add(escapeName(msg.Name), msg); err != nil {
+		return err
+	}
+
+	// 構造体のメンバ（構造体と同じ名前のメンバは定義できない）
+	members := &internal.NameScope{}
+	if err := members.Add(escapeName(msg.Name), msg); err != nil {
+		return err
+	}
+	for _, enum := range msg.Enums {
+		if err := checkEnumNames(enum, members); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if nested.MapEntry {
+			continue
+		}
+		if err := checkMessageNames(nested, members); err != nil {
+			return err
+		}
+	}
+	for _, field := range msg.Fields {
+		name := internal.ToSnakeCase(field.Name)
+		names := []string{toFieldName(field)}
+		if field.Oneof != nil {
+			names = append(names, "set_"+name, "clear_"+name)
+			if field.Optional {
+				names = append(names, "has_"+name)
+			}
+		}
+		for _, name := range names {
+			if err := members.Add(name, field); err != nil {
+				return err
+			}
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		names := []string{internal.ToUpperCamel(oneof.Name) + "Case", fieldName, "clear_" + fieldName}
+		for _, name := range names {
+			if err := members.Add(name, oneof); err != nil {
+				return err
+			}
+		}
+	}
+	if msg.KeepUnknownFields {
+		if err := members.Add("jsonif_unknown_fields", msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 生成する識別子が衝突していないか調べる
+func checkNames(schema *internal.Schema) error {
+	// 名前空間ごとのスコープ
+	scopes := map[string]*internal.NameScope{}
+	scope := func(ns string) *internal.NameScope {
+		if _, ok := scopes[ns]; !ok {
+			scopes[ns] = &internal.NameScope{}
+		}
+		return scopes[ns]
+	}
+	for _, file := range schema.Files {
+		var xs []string
+		if len(file.Package) != 0 {
+			for _, pkg := range strings.Split(file.Package, ".") {
+				xs = append(xs, escapeName(pkg))
+			}
+		}
+		for i := range xs {
+			ns := internal.Namespace(strings.Join(xs[:i+1], "::"))
+			if err := scope(strings.Join(xs[:i], "::")).Add(xs[i], ns); err != nil {
+				return err
+			}
+		}
+		ns := strings.Join(xs, "::")
+		for _, enum := range file.Enums {
+			if err := checkEnumNames(enum, scope(ns)); err != nil {
+				return err
+			}
+		}
+		for _, msg := range file.Messages {
+			if err := checkMessageNames(msg, scope(ns)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func gen(req *pluginpb.CodeGeneratorRequest, opts *options) (*pluginpb.CodeGeneratorResponse, error) {
 	resp := &pluginpb.CodeGeneratorResponse{}
 	resp.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
-	for _, file := range req.ProtoFile {
-		respFile, err := genFile(file, req.ProtoFile)
+	schema, err := internal.NewSchema(req.ProtoFile, &opts.CommonOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNames(schema); err != nil {
+		return nil, err
+	}
+	for _, file := range schema.FilesToGenerate(req, &opts.CommonOptions) {
+		respFile, err := genFile(file, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -596,7 +2736,10 @@ func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, e
 }
 
 func main() {
-	err := internal.RunPlugin(gen)
+	opts := &options{}
+	err := internal.RunPlugin(newOptionSet(opts), func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return gen(req, opts)
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
 		os.Exit(1)
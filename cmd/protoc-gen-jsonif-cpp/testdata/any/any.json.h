@@ -0,0 +1,795 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ANY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ANY_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <chrono>
+#include <optional>
+#include <stdexcept>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_WELL_KNOWN_TYPES_DEFINED
+#define JSONIF_WELL_KNOWN_TYPES_DEFINED
+
+namespace jsonif {
+
+// google.protobuf.Struct, Value, ListValue は JSON の値をそのまま保持する
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json json_value;
+#else
+typedef boost::json::value json_value;
+#endif
+
+// google.protobuf.Any は "@type" を含む JSON のオブジェクトをそのまま保持する
+// 値が無い場合は null になる
+struct any {
+  json_value value;
+  
+  // "@type" の型の URL から取り出したメッセージの完全修飾名
+  std::string type_name() const {
+    if (!value.is_object()) return "";
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    auto it = value.find("@type");
+    if (it == value.end() || !it->is_string()) return "";
+    std::string url = it->get<std::string>();
+    #else
+    auto p = value.as_object().if_contains("@type");
+    if (p == nullptr || !p->is_string()) return "";
+    std::string url(p->as_string().data(), p->as_string().size());
+    #endif
+    return url.substr(url.rfind('/') + 1);
+  }
+  
+  friend bool operator==(const any& a, const any& b) { return a.value == b.value; }
+  friend bool operator!=(const any& a, const any& b) { return !(a == b); }
+};
+
+// メッセージを google.protobuf.Any に詰める
+template<class T>
+inline any pack(const T& v) {
+  std::string url = std::string("type.googleapis.com/") + ::jsonif::type_name<T>::value;
+  any a;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  a.value = v;
+  a.value["@type"] = url;
+  #else
+  // "@type" を先頭に出力する
+  boost::json::value fields = boost::json::value_from(v);
+  boost::json::object obj;
+  obj["@type"] = url;
+  for (const auto& kv : fields.as_object()) {
+    obj[kv.key()] = kv.value();
+  }
+  a.value = std::move(obj);
+  #endif
+  return a;
+}
+
+// google.protobuf.Any に入っているのが T かどうか
+template<class T>
+inline bool is(const any& a) {
+  return a.type_name() == ::jsonif::type_name<T>::value;
+}
+
+// google.protobuf.Any から T を取り出す。T ではない場合は std::nullopt を返す
+template<class T>
+inline std::optional<T> unpack(const any& a) {
+  if (!is<T>(a)) return std::nullopt;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return a.value.get<T>();
+  #else
+  return boost::json::value_to<T>(a.value);
+  #endif
+}
+
+namespace detail {
+
+// "@type" の無いオブジェクトは google.protobuf.Any として読み込めない
+inline any make_any(json_value jv) {
+  any a;
+  a.value = std::move(jv);
+  if (!a.value.is_null() && a.type_name().empty()) {
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Any: @type is required"));
+  }
+  return a;
+}
+
+typedef std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> timestamp;
+
+// 1970-01-01 からの日数と年月日を変換する
+// http://howardhinnant.github.io/date_algorithms.html
+inline int64_t days_from_civil(int64_t y, int64_t m, int64_t d) {
+  y -= m <= 2;
+  const int64_t era = (y >= 0 ? y : y - 399) / 400;
+  const int64_t yoe = y - era * 400;
+  const int64_t doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;
+  const int64_t doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+  return era * 146097 + doe - 719468;
+}
+inline void civil_from_days(int64_t z, int64_t& y, int64_t& m, int64_t& d) {
+  z += 719468;
+  const int64_t era = (z >= 0 ? z : z - 146096) / 146097;
+  const int64_t doe = z - era * 146097;
+  const int64_t yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+  const int64_t doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+  const int64_t mp = (5 * doy + 2) / 153;
+  d = doy - (153 * mp + 2) / 5 + 1;
+  m = mp < 10 ? mp + 3 : mp - 9;
+  y = yoe + era * 400 + (m <= 2);
+}
+
+// 小数部は 0, 3, 6, 9 桁のいずれかで出力する
+inline std::string format_nanos(int64_t nanos) {
+  char buf[16];
+  if (nanos == 0) {
+    return "";
+  } else if (nanos % 1000000 == 0) {
+    snprintf(buf, sizeof(buf), ".%03d", (int)(nanos / 1000000));
+  } else if (nanos % 1000 == 0) {
+    snprintf(buf, sizeof(buf), ".%06d", (int)(nanos / 1000));
+  } else {
+    snprintf(buf, sizeof(buf), ".%09d", (int)nanos);
+  }
+  return buf;
+}
+inline bool parse_digits(const std::string& s, size_t& i, size_t n, int64_t& r) {
+  r = 0;
+  for (size_t end = i + n; i < end; i++) {
+    if (i >= s.size() || s[i] < '0' || '9' < s[i]) return false;
+    r = r * 10 + (s[i] - '0');
+  }
+  return true;
+}
+inline bool parse_char(const std::string& s, size_t& i, const char* cs) {
+  if (i >= s.size() || strchr(cs, s[i]) == nullptr) return false;
+  i++;
+  return true;
+}
+inline bool parse_nanos(const std::string& s, size_t& i, int64_t& nanos) {
+  nanos = 0;
+  int digits = 0;
+  if (i < s.size() && s[i] == '.') {
+    i++;
+    for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++, digits++) {
+      if (digits == 9) return false;
+      nanos = nanos * 10 + (s[i] - '0');
+    }
+    if (digits == 0) return false;
+  }
+  for (; digits < 9; digits++) {
+    nanos *= 10;
+  }
+  return true;
+}
+
+// google.protobuf.Timestamp は RFC 3339 形式の文字列にする（例: 1972-01-01T10:00:20.021Z）
+inline std::string format_timestamp(const timestamp& v) {
+  int64_t ns = v.time_since_epoch().count();
+  int64_t secs = ns / 1000000000;
+  int64_t nanos = ns % 1000000000;
+  if (nanos < 0) {
+    secs -= 1;
+    nanos += 1000000000;
+  }
+  int64_t days = secs / 86400;
+  int64_t rem = secs % 86400;
+  if (rem < 0) {
+    days -= 1;
+    rem += 86400;
+  }
+  int64_t y, m, d;
+  civil_from_days(days, y, m, d);
+  char buf[32];
+  snprintf(buf, sizeof(buf), "%04d-%02d-%02dT%02d:%02d:%02d", (int)y, (int)m, (int)d,
+           (int)(rem / 3600), (int)(rem / 60 % 60), (int)(rem % 60));
+  return buf + format_nanos(nanos) + "Z";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_timestamp(const std::string& s, timestamp& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  int64_t y = 0, m = 0, d = 0, hh = 0, mm = 0, ss = 0, nanos = 0;
+  int64_t offset = 0;
+  bool ok = parse_digits(s, i, 4, y) && parse_char(s, i, "-") && parse_digits(s, i, 2, m) &&
+            parse_char(s, i, "-") && parse_digits(s, i, 2, d) && parse_char(s, i, "Tt") &&
+            parse_digits(s, i, 2, hh) && parse_char(s, i, ":") && parse_digits(s, i, 2, mm) &&
+            parse_char(s, i, ":") && parse_digits(s, i, 2, ss) && parse_nanos(s, i, nanos);
+  if (ok && !parse_char(s, i, "Zz")) {
+    // UTC 以外の場合は +09:00 のようなオフセットが付いている
+    int64_t sign = i < s.size() && s[i] == '-' ? -1 : 1;
+    int64_t oh = 0, om = 0;
+    ok = parse_char(s, i, "+-") && parse_digits(s, i, 2, oh) && parse_char(s, i, ":") && parse_digits(s, i, 2, om);
+    offset = sign * (oh * 3600 + om * 60);
+  }
+  if (!ok || i != s.size() || m < 1 || 12 < m || d < 1 || 31 < d || 23 < hh || 59 < mm || 59 < ss) {
+    return false;
+  }
+  int64_t secs = days_from_civil(y, m, d) * 86400 + hh * 3600 + mm * 60 + ss - offset;
+  // ナノ秒で表現できる範囲（1677 年から 2262 年まで）を超える場合はエラーにする
+  if (secs < -9223372035 || 9223372035 < secs) {
+    out_of_range = true;
+    return false;
+  }
+  v = timestamp(std::chrono::nanoseconds(secs * 1000000000 + nanos));
+  return true;
+}
+inline timestamp parse_timestamp(const std::string& s) {
+  timestamp v;
+  bool out_of_range;
+  if (!parse_timestamp(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Timestamp out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Timestamp: " + s));
+  }
+  return v;
+}
+
+// google.protobuf.Duration は秒数の後ろに s を付けた文字列にする（例: 1.5s）
+inline std::string format_duration(std::chrono::nanoseconds v) {
+  int64_t ns = v.count();
+  // 符号を反転した時に溢れないように、符号無しで計算する
+  uint64_t abs = ns < 0 ? 0 - (uint64_t)ns : (uint64_t)ns;
+  return (ns < 0 ? "-" : "") + std::to_string(abs / 1000000000) + format_nanos((int64_t)(abs % 1000000000)) + "s";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_duration(const std::string& s, std::chrono::nanoseconds& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  bool neg = i < s.size() && s[i] == '-';
+  if (neg) i++;
+  size_t start = i;
+  int64_t secs = 0;
+  for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++) {
+    if (secs > 922337203) {
+      out_of_range = true;
+      return false;
+    }
+    secs = secs * 10 + (s[i] - '0');
+  }
+  int64_t nanos = 0;
+  if (i == start || !parse_nanos(s, i, nanos) || i + 1 != s.size() || s[i] != 's') {
+    return false;
+  }
+  if (secs > 9223372035) {
+    out_of_range = true;
+    return false;
+  }
+  int64_t ns = secs * 1000000000 + nanos;
+  v = std::chrono::nanoseconds(neg ? -ns : ns);
+  return true;
+}
+inline std::chrono::nanoseconds parse_duration(const std::string& s) {
+  std::chrono::nanoseconds v;
+  bool out_of_range;
+  if (!parse_duration(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Duration out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Duration: " + s));
+  }
+  return v;
+}
+
+}
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+
+namespace nlohmann {
+
+template<>
+struct adl_serializer<::jsonif::detail::timestamp> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::detail::timestamp& v) {
+    jv = ::jsonif::detail::format_timestamp(v);
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::detail::timestamp& v) {
+    v = ::jsonif::detail::parse_timestamp(jv.get<std::string>());
+  }
+};
+
+template<>
+struct adl_serializer<std::chrono::nanoseconds> {
+  static void to_json(nlohmann::json& jv, const std::chrono::nanoseconds& v) {
+    jv = ::jsonif::detail::format_duration(v);
+  }
+  static void from_json(const nlohmann::json& jv, std::chrono::nanoseconds& v) {
+    v = ::jsonif::detail::parse_duration(jv.get<std::string>());
+  }
+};
+
+// ラッパー型は値が無い場合に null になる
+template<class T>
+struct adl_serializer<std::optional<T>> {
+  static void to_json(nlohmann::json& jv, const std::optional<T>& v) {
+    if (v) {
+      jv = *v;
+    } else {
+      jv = nullptr;
+    }
+  }
+  static void from_json(const nlohmann::json& jv, std::optional<T>& v) {
+    if (jv.is_null()) {
+      v = std::nullopt;
+    } else {
+      v = jv.template get<T>();
+    }
+  }
+};
+
+template<>
+struct adl_serializer<::jsonif::any> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::any& v) {
+    jv = v.value;
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::any& v) {
+    v = ::jsonif::detail::make_any(jv);
+  }
+};
+
+}
+
+#else
+
+// std::optional は Boost.JSON が null として扱ってくれる
+// std::chrono の型は、ADL で見つかるように boost::json 名前空間に定義する
+namespace boost {
+namespace json {
+
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::detail::timestamp& v) {
+  jv = ::jsonif::detail::format_timestamp(v);
+}
+inline ::jsonif::detail::timestamp tag_invoke(const value_to_tag<::jsonif::detail::timestamp>&, const value& jv) {
+  return ::jsonif::detail::parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const std::chrono::nanoseconds& v) {
+  jv = ::jsonif::detail::format_duration(v);
+}
+inline std::chrono::nanoseconds tag_invoke(const value_to_tag<std::chrono::nanoseconds>&, const value& jv) {
+  return ::jsonif::detail::parse_duration(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::any& v) {
+  jv = v.value;
+}
+inline ::jsonif::any tag_invoke(const value_to_tag<::jsonif::any>&, const value& jv) {
+  return ::jsonif::detail::make_any(jv);
+}
+
+}
+}
+
+#endif
+
+#endif
+
+namespace anypb {
+
+struct Payload {
+  std::string name;
+  int32_t count = 0;
+  friend bool operator==(const Payload& a, const Payload& b) {
+    if (a.name != b.name) return false;
+    if (a.count != b.count) return false;
+    return true;
+  }
+  friend bool operator!=(const Payload& a, const Payload& b) { return !(a == b); }
+};
+
+struct Test {
+  struct Nested {
+    std::vector<std::string> tags;
+    friend bool operator==(const Nested& a, const Nested& b) {
+      if (a.tags != b.tags) return false;
+      return true;
+    }
+    friend bool operator!=(const Nested& a, const Nested& b) { return !(a == b); }
+  };
+  
+  enum class KindCase {
+    NOT_SET = 0,
+    kOneofAny = 4,
+    kOneofString = 5,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    oneof_any = ::jsonif::any();
+    oneof_string = std::string();
+  }
+  
+  ::jsonif::any any;
+  std::vector<::jsonif::any> anys;
+  std::map<std::string, ::jsonif::any> any_map;
+  ::jsonif::any oneof_any;
+  void set_oneof_any(::jsonif::any oneof_any) {
+    clear_kind_case();
+    kind_case = KindCase::kOneofAny;
+    this->oneof_any = oneof_any;
+  }
+  void clear_oneof_any() {
+    if (kind_case == KindCase::kOneofAny) {
+      clear_kind_case();
+    }
+  }
+  std::string oneof_string;
+  void set_oneof_string(std::string oneof_string) {
+    clear_kind_case();
+    kind_case = KindCase::kOneofString;
+    this->oneof_string = oneof_string;
+  }
+  void clear_oneof_string() {
+    if (kind_case == KindCase::kOneofString) {
+      clear_kind_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.any != b.any) return false;
+    if (a.anys != b.anys) return false;
+    if (a.any_map != b.any_map) return false;
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kOneofAny && a.oneof_any != b.oneof_any) return false;
+    if (a.kind_case == KindCase::kOneofString && a.oneof_string != b.oneof_string) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::anypb::Payload
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::anypb::Payload& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::anypb::Payload& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["count"], v.count);
+  }
+  #else
+  obj["count"] = boost::json::value_from(v.count);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::anypb::Payload& v)
+#else
+static ::anypb::Payload tag_invoke(const boost::json::value_to_tag<::anypb::Payload>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::anypb::Payload v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("count"), v.count);
+  }
+  #else
+  v.count = boost::json::value_to<int32_t>(jv.at("count"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::anypb::Test::Nested
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::anypb::Test::Nested& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::anypb::Test::Nested& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["tags"], v.tags);
+  }
+  #else
+  obj["tags"] = boost::json::value_from(v.tags);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::anypb::Test::Nested& v)
+#else
+static ::anypb::Test::Nested tag_invoke(const boost::json::value_to_tag<::anypb::Test::Nested>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::anypb::Test::Nested v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("tags"), v.tags);
+  }
+  #else
+  v.tags = boost::json::value_to<std::vector<std::string>>(jv.at("tags"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::anypb::Test::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::anypb::Test::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::anypb::Test::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::anypb::Test::KindCase::kOneofAny:
+    case ::anypb::Test::KindCase::kOneofString:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::anypb::Test::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::anypb::Test::KindCase& v) {
+  v = (::anypb::Test::KindCase)jv.template get<int>();
+}
+#else
+static ::anypb::Test::KindCase tag_invoke(const boost::json::value_to_tag<::anypb::Test::KindCase>&, const boost::json::value& jv) {
+  return (::anypb::Test::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::anypb::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::anypb::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::anypb::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  obj["any"] = v.any;
+  #else
+  obj["any"] = boost::json::value_from(v.any);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  obj["anys"] = v.anys;
+  #else
+  obj["anys"] = boost::json::value_from(v.anys);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.any_map) {
+      m[kv.first] = kv.second;
+    }
+    obj["any_map"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.any_map) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["any_map"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  obj["oneof_any"] = v.oneof_any;
+  #else
+  obj["oneof_any"] = boost::json::value_from(v.oneof_any);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["oneof_string"], v.oneof_string);
+  }
+  #else
+  obj["oneof_string"] = boost::json::value_from(v.oneof_string);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["kind_case"], v.kind_case);
+  }
+  #else
+  obj["kind_case"] = boost::json::value_from(v.kind_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::anypb::Test& v)
+#else
+static ::anypb::Test tag_invoke(const boost::json::value_to_tag<::anypb::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::anypb::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv.at("any").get_to(v.any);
+  #else
+  v.any = boost::json::value_to<::jsonif::any>(jv.at("any"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv.at("anys").get_to(v.anys);
+  #else
+  v.anys = boost::json::value_to<std::vector<::jsonif::any>>(jv.at("anys"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("any_map").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::jsonif::any value{};
+    kv.value().get_to(value);
+    v.any_map.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("any_map").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.any_map.emplace(key, boost::json::value_to<::jsonif::any>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("oneof_any"))
+  #else
+  if (jv.as_object().find("oneof_any") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    jv.at("oneof_any").get_to(v.oneof_any);
+    #else
+    v.oneof_any = boost::json::value_to<::jsonif::any>(jv.at("oneof_any"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("oneof_string"))
+  #else
+  if (jv.as_object().find("oneof_string") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("oneof_string"), v.oneof_string);
+    }
+    #else
+    v.oneof_string = boost::json::value_to<std::string>(jv.at("oneof_string"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("kind_case"), v.kind_case);
+  }
+  #else
+  v.kind_case = boost::json::value_to<::anypb::Test::KindCase>(jv.at("kind_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::anypb::Payload> {
+  static constexpr const char* value = "anypb.Payload";
+};
+template<>
+struct type_name<::anypb::Test::Nested> {
+  static constexpr const char* value = "anypb.Test.Nested";
+};
+template<>
+struct type_name<::anypb::Test> {
+  static constexpr const char* value = "anypb.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
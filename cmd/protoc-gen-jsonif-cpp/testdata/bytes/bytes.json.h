@@ -0,0 +1,277 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_BYTES_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_BYTES_PROTO
+
+#include <string>
+#include <vector>
+#include <optional>
+#include <stdexcept>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_BASE64_DEFINED
+#define JSONIF_BASE64_DEFINED
+
+namespace jsonif {
+namespace detail {
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json base64_json;
+#else
+typedef boost::json::value base64_json;
+#endif
+
+// パディング付きの標準の base64 にする
+// url が true の場合はパディング無しの URL セーフな base64 にする
+inline std::string encode_base64(const std::string& s, bool url = false) {
+  const char* table = url ? "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+                          : "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/";
+  std::string r;
+  r.reserve((s.size() + 2) / 3 * 4);
+  for (size_t i = 0; i < s.size(); i += 3) {
+    uint32_t n = (uint32_t)(unsigned char)s[i] << 16;
+    if (i + 1 < s.size()) n |= (uint32_t)(unsigned char)s[i + 1] << 8;
+    if (i + 2 < s.size()) n |= (uint32_t)(unsigned char)s[i + 2];
+    r += table[(n >> 18) & 0x3f];
+    r += table[(n >> 12) & 0x3f];
+    if (i + 1 < s.size()) {
+      r += table[(n >> 6) & 0x3f];
+    } else if (!url) {
+      r += '=';
+    }
+    if (i + 2 < s.size()) {
+      r += table[n & 0x3f];
+    } else if (!url) {
+      r += '=';
+    }
+  }
+  return r;
+}
+// 標準と URL セーフのどちらの base64 でも読み込める。パディングは省略してもいい
+// 読み込めなかった場合は false を返す
+inline bool decode_base64(const std::string& s, std::string& r) {
+  r.clear();
+  uint32_t n = 0;
+  int bits = 0;
+  size_t i = 0;
+  for (; i < s.size() && s[i] != '='; i++) {
+    char c = s[i];
+    int d;
+    if ('A' <= c && c <= 'Z') {
+      d = c - 'A';
+    } else if ('a' <= c && c <= 'z') {
+      d = c - 'a' + 26;
+    } else if ('0' <= c && c <= '9') {
+      d = c - '0' + 52;
+    } else if (c == '+' || c == '-') {
+      d = 62;
+    } else if (c == '/' || c == '_') {
+      d = 63;
+    } else {
+      return false;
+    }
+    n = (n << 6) | (uint32_t)d;
+    bits += 6;
+    if (bits >= 8) {
+      bits -= 8;
+      r += (char)((n >> bits) & 0xff);
+    }
+  }
+  size_t pad = s.size() - i;
+  return pad <= 2 && s.find_first_not_of('=', i) == std::string::npos && i % 4 != 1;
+}
+inline std::string decode_base64(const std::string& s) {
+  std::string r;
+  if (!decode_base64(s, r)) {
+    JSONIF_THROW(std::invalid_argument("invalid base64: " + s));
+  }
+  return r;
+}
+
+inline void base64_to_json(base64_json& jv, const std::string& v, bool url = false) {
+  jv = encode_base64(v, url);
+}
+inline void base64_to_json(base64_json& jv, const std::optional<std::string>& v, bool url = false) {
+  if (v) {
+    jv = encode_base64(*v, url);
+  } else {
+    jv = nullptr;
+  }
+}
+inline void base64_to_json(base64_json& jv, const std::vector<std::string>& v, bool url = false) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv = nlohmann::json::array();
+  for (const auto& x : v) {
+    jv.push_back(encode_base64(x, url));
+  }
+  #else
+  boost::json::array arr;
+  for (const auto& x : v) {
+    arr.emplace_back(encode_base64(x, url));
+  }
+  jv = std::move(arr);
+  #endif
+}
+
+inline void base64_from_json(const base64_json& jv, std::string& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  v = decode_base64(jv.get<std::string>());
+  #else
+  v = decode_base64(std::string(jv.as_string().data(), jv.as_string().size()));
+  #endif
+}
+inline void base64_from_json(const base64_json& jv, std::optional<std::string>& v) {
+  if (jv.is_null()) {
+    v = std::nullopt;
+  } else {
+    std::string x;
+    base64_from_json(jv, x);
+    v = std::move(x);
+  }
+}
+inline void base64_from_json(const base64_json& jv, std::vector<std::string>& v) {
+  v.clear();
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& x : jv) {
+    #else
+    for (const auto& x : jv.as_array()) {
+    #endif
+    std::string y;
+    base64_from_json(x, y);
+    v.push_back(std::move(y));
+  }
+}
+
+// base64url パラメータを指定した場合に使う。読み込みは base64 と同じ
+template<class T>
+inline void base64url_to_json(base64_json& jv, const T& v) {
+  base64_to_json(jv, v, true);
+}
+template<class T>
+inline void base64url_from_json(const base64_json& jv, T& v) {
+  base64_from_json(jv, v);
+}
+
+}
+}
+
+#endif
+
+
+namespace bytes {
+
+struct Test {
+  std::string data;
+  std::vector<std::string> rp_data;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.data != b.data) return false;
+    if (a.rp_data != b.rp_data) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::bytes::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::bytes::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::bytes::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  ::jsonif::detail::base64_to_json(obj["data"], v.data);
+  ::jsonif::detail::base64_to_json(obj["rp_data"], v.rp_data);
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::bytes::Test& v)
+#else
+static ::bytes::Test tag_invoke(const boost::json::value_to_tag<::bytes::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::bytes::Test v;
+  #endif
+  ::jsonif::detail::base64_from_json(jv.at("data"), v.data);
+  ::jsonif::detail::base64_from_json(jv.at("rp_data"), v.rp_data);
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::bytes::Test> {
+  static constexpr const char* value = "bytes.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,1216 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_CANONICAL_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_CANONICAL_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <chrono>
+#include <optional>
+#include <stdexcept>
+#include <type_traits>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_WELL_KNOWN_TYPES_DEFINED
+#define JSONIF_WELL_KNOWN_TYPES_DEFINED
+
+namespace jsonif {
+
+// google.protobuf.Struct, Value, ListValue は JSON の値をそのまま保持する
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json json_value;
+#else
+typedef boost::json::value json_value;
+#endif
+
+// google.protobuf.Any は "@type" を含む JSON のオブジェクトをそのまま保持する
+// 値が無い場合は null になる
+struct any {
+  json_value value;
+  
+  // "@type" の型の URL から取り出したメッセージの完全修飾名
+  std::string type_name() const {
+    if (!value.is_object()) return "";
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    auto it = value.find("@type");
+    if (it == value.end() || !it->is_string()) return "";
+    std::string url = it->get<std::string>();
+    #else
+    auto p = value.as_object().if_contains("@type");
+    if (p == nullptr || !p->is_string()) return "";
+    std::string url(p->as_string().data(), p->as_string().size());
+    #endif
+    return url.substr(url.rfind('/') + 1);
+  }
+  
+  friend bool operator==(const any& a, const any& b) { return a.value == b.value; }
+  friend bool operator!=(const any& a, const any& b) { return !(a == b); }
+};
+
+// メッセージを google.protobuf.Any に詰める
+template<class T>
+inline any pack(const T& v) {
+  std::string url = std::string("type.googleapis.com/") + ::jsonif::type_name<T>::value;
+  any a;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  a.value = v;
+  a.value["@type"] = url;
+  #else
+  // "@type" を先頭に出力する
+  boost::json::value fields = boost::json::value_from(v);
+  boost::json::object obj;
+  obj["@type"] = url;
+  for (const auto& kv : fields.as_object()) {
+    obj[kv.key()] = kv.value();
+  }
+  a.value = std::move(obj);
+  #endif
+  return a;
+}
+
+// google.protobuf.Any に入っているのが T かどうか
+template<class T>
+inline bool is(const any& a) {
+  return a.type_name() == ::jsonif::type_name<T>::value;
+}
+
+// google.protobuf.Any から T を取り出す。T ではない場合は std::nullopt を返す
+template<class T>
+inline std::optional<T> unpack(const any& a) {
+  if (!is<T>(a)) return std::nullopt;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return a.value.get<T>();
+  #else
+  return boost::json::value_to<T>(a.value);
+  #endif
+}
+
+namespace detail {
+
+// "@type" の無いオブジェクトは google.protobuf.Any として読み込めない
+inline any make_any(json_value jv) {
+  any a;
+  a.value = std::move(jv);
+  if (!a.value.is_null() && a.type_name().empty()) {
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Any: @type is required"));
+  }
+  return a;
+}
+
+typedef std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> timestamp;
+
+// 1970-01-01 からの日数と年月日を変換する
+// http://howardhinnant.github.io/date_algorithms.html
+inline int64_t days_from_civil(int64_t y, int64_t m, int64_t d) {
+  y -= m <= 2;
+  const int64_t era = (y >= 0 ? y : y - 399) / 400;
+  const int64_t yoe = y - era * 400;
+  const int64_t doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;
+  const int64_t doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+  return era * 146097 + doe - 719468;
+}
+inline void civil_from_days(int64_t z, int64_t& y, int64_t& m, int64_t& d) {
+  z += 719468;
+  const int64_t era = (z >= 0 ? z : z - 146096) / 146097;
+  const int64_t doe = z - era * 146097;
+  const int64_t yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+  const int64_t doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+  const int64_t mp = (5 * doy + 2) / 153;
+  d = doy - (153 * mp + 2) / 5 + 1;
+  m = mp < 10 ? mp + 3 : mp - 9;
+  y = yoe + era * 400 + (m <= 2);
+}
+
+// 小数部は 0, 3, 6, 9 桁のいずれかで出力する
+inline std::string format_nanos(int64_t nanos) {
+  char buf[16];
+  if (nanos == 0) {
+    return "";
+  } else if (nanos % 1000000 == 0) {
+    snprintf(buf, sizeof(buf), ".%03d", (int)(nanos / 1000000));
+  } else if (nanos % 1000 == 0) {
+    snprintf(buf, sizeof(buf), ".%06d", (int)(nanos / 1000));
+  } else {
+    snprintf(buf, sizeof(buf), ".%09d", (int)nanos);
+  }
+  return buf;
+}
+inline bool parse_digits(const std::string& s, size_t& i, size_t n, int64_t& r) {
+  r = 0;
+  for (size_t end = i + n; i < end; i++) {
+    if (i >= s.size() || s[i] < '0' || '9' < s[i]) return false;
+    r = r * 10 + (s[i] - '0');
+  }
+  return true;
+}
+inline bool parse_char(const std::string& s, size_t& i, const char* cs) {
+  if (i >= s.size() || strchr(cs, s[i]) == nullptr) return false;
+  i++;
+  return true;
+}
+inline bool parse_nanos(const std::string& s, size_t& i, int64_t& nanos) {
+  nanos = 0;
+  int digits = 0;
+  if (i < s.size() && s[i] == '.') {
+    i++;
+    for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++, digits++) {
+      if (digits == 9) return false;
+      nanos = nanos * 10 + (s[i] - '0');
+    }
+    if (digits == 0) return false;
+  }
+  for (; digits < 9; digits++) {
+    nanos *= 10;
+  }
+  return true;
+}
+
+// google.protobuf.Timestamp は RFC 3339 形式の文字列にする（例: 1972-01-01T10:00:20.021Z）
+inline std::string format_timestamp(const timestamp& v) {
+  int64_t ns = v.time_since_epoch().count();
+  int64_t secs = ns / 1000000000;
+  int64_t nanos = ns % 1000000000;
+  if (nanos < 0) {
+    secs -= 1;
+    nanos += 1000000000;
+  }
+  int64_t days = secs / 86400;
+  int64_t rem = secs % 86400;
+  if (rem < 0) {
+    days -= 1;
+    rem += 86400;
+  }
+  int64_t y, m, d;
+  civil_from_days(days, y, m, d);
+  char buf[32];
+  snprintf(buf, sizeof(buf), "%04d-%02d-%02dT%02d:%02d:%02d", (int)y, (int)m, (int)d,
+           (int)(rem / 3600), (int)(rem / 60 % 60), (int)(rem % 60));
+  return buf + format_nanos(nanos) + "Z";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_timestamp(const std::string& s, timestamp& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  int64_t y = 0, m = 0, d = 0, hh = 0, mm = 0, ss = 0, nanos = 0;
+  int64_t offset = 0;
+  bool ok = parse_digits(s, i, 4, y) && parse_char(s, i, "-") && parse_digits(s, i, 2, m) &&
+            parse_char(s, i, "-") && parse_digits(s, i, 2, d) && parse_char(s, i, "Tt") &&
+            parse_digits(s, i, 2, hh) && parse_char(s, i, ":") && parse_digits(s, i, 2, mm) &&
+            parse_char(s, i, ":") && parse_digits(s, i, 2, ss) && parse_nanos(s, i, nanos);
+  if (ok && !parse_char(s, i, "Zz")) {
+    // UTC 以外の場合は +09:00 のようなオフセットが付いている
+    int64_t sign = i < s.size() && s[i] == '-' ? -1 : 1;
+    int64_t oh = 0, om = 0;
+    ok = parse_char(s, i, "+-") && parse_digits(s, i, 2, oh) && parse_char(s, i, ":") && parse_digits(s, i, 2, om);
+    offset = sign * (oh * 3600 + om * 60);
+  }
+  if (!ok || i != s.size() || m < 1 || 12 < m || d < 1 || 31 < d || 23 < hh || 59 < mm || 59 < ss) {
+    return false;
+  }
+  int64_t secs = days_from_civil(y, m, d) * 86400 + hh * 3600 + mm * 60 + ss - offset;
+  // ナノ秒で表現できる範囲（1677 年から 2262 年まで）を超える場合はエラーにする
+  if (secs < -9223372035 || 9223372035 < secs) {
+    out_of_range = true;
+    return false;
+  }
+  v = timestamp(std::chrono::nanoseconds(secs * 1000000000 + nanos));
+  return true;
+}
+inline timestamp parse_timestamp(const std::string& s) {
+  timestamp v;
+  bool out_of_range;
+  if (!parse_timestamp(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Timestamp out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Timestamp: " + s));
+  }
+  return v;
+}
+
+// google.protobuf.Duration は秒数の後ろに s を付けた文字列にする（例: 1.5s）
+inline std::string format_duration(std::chrono::nanoseconds v) {
+  int64_t ns = v.count();
+  // 符号を反転した時に溢れないように、符号無しで計算する
+  uint64_t abs = ns < 0 ? 0 - (uint64_t)ns : (uint64_t)ns;
+  return (ns < 0 ? "-" : "") + std::to_string(abs / 1000000000) + format_nanos((int64_t)(abs % 1000000000)) + "s";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_duration(const std::string& s, std::chrono::nanoseconds& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  bool neg = i < s.size() && s[i] == '-';
+  if (neg) i++;
+  size_t start = i;
+  int64_t secs = 0;
+  for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++) {
+    if (secs > 922337203) {
+      out_of_range = true;
+      return false;
+    }
+    secs = secs * 10 + (s[i] - '0');
+  }
+  int64_t nanos = 0;
+  if (i == start || !parse_nanos(s, i, nanos) || i + 1 != s.size() || s[i] != 's') {
+    return false;
+  }
+  if (secs > 9223372035) {
+    out_of_range = true;
+    return false;
+  }
+  int64_t ns = secs * 1000000000 + nanos;
+  v = std::chrono::nanoseconds(neg ? -ns : ns);
+  return true;
+}
+inline std::chrono::nanoseconds parse_duration(const std::string& s) {
+  std::chrono::nanoseconds v;
+  bool out_of_range;
+  if (!parse_duration(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Duration out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Duration: " + s));
+  }
+  return v;
+}
+
+}
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+
+namespace nlohmann {
+
+template<>
+struct adl_serializer<::jsonif::detail::timestamp> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::detail::timestamp& v) {
+    jv = ::jsonif::detail::format_timestamp(v);
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::detail::timestamp& v) {
+    v = ::jsonif::detail::parse_timestamp(jv.get<std::string>());
+  }
+};
+
+template<>
+struct adl_serializer<std::chrono::nanoseconds> {
+  static void to_json(nlohmann::json& jv, const std::chrono::nanoseconds& v) {
+    jv = ::jsonif::detail::format_duration(v);
+  }
+  static void from_json(const nlohmann::json& jv, std::chrono::nanoseconds& v) {
+    v = ::jsonif::detail::parse_duration(jv.get<std::string>());
+  }
+};
+
+// ラッパー型は値が無い場合に null になる
+template<class T>
+struct adl_serializer<std::optional<T>> {
+  static void to_json(nlohmann::json& jv, const std::optional<T>& v) {
+    if (v) {
+      jv = *v;
+    } else {
+      jv = nullptr;
+    }
+  }
+  static void from_json(const nlohmann::json& jv, std::optional<T>& v) {
+    if (jv.is_null()) {
+      v = std::nullopt;
+    } else {
+      v = jv.template get<T>();
+    }
+  }
+};
+
+template<>
+struct adl_serializer<::jsonif::any> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::any& v) {
+    jv = v.value;
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::any& v) {
+    v = ::jsonif::detail::make_any(jv);
+  }
+};
+
+}
+
+#else
+
+// std::optional は Boost.JSON が null として扱ってくれる
+// std::chrono の型は、ADL で見つかるように boost::json 名前空間に定義する
+namespace boost {
+namespace json {
+
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::detail::timestamp& v) {
+  jv = ::jsonif::detail::format_timestamp(v);
+}
+inline ::jsonif::detail::timestamp tag_invoke(const value_to_tag<::jsonif::detail::timestamp>&, const value& jv) {
+  return ::jsonif::detail::parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const std::chrono::nanoseconds& v) {
+  jv = ::jsonif::detail::format_duration(v);
+}
+inline std::chrono::nanoseconds tag_invoke(const value_to_tag<std::chrono::nanoseconds>&, const value& jv) {
+  return ::jsonif::detail::parse_duration(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::any& v) {
+  jv = v.value;
+}
+inline ::jsonif::any tag_invoke(const value_to_tag<::jsonif::any>&, const value& jv) {
+  return ::jsonif::detail::make_any(jv);
+}
+
+}
+}
+
+#endif
+
+#endif
+#ifndef JSONIF_INT64_STRING_DEFINED
+#define JSONIF_INT64_STRING_DEFINED
+
+namespace jsonif {
+namespace detail {
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json int64_json;
+#else
+typedef boost::json::value int64_json;
+#endif
+
+// 精度が落ちないように文字列で出力する
+template<class T>
+inline void int64_to_json(int64_json& jv, const T& v) {
+  jv = std::to_string(v);
+}
+template<class T>
+inline void int64_to_json(int64_json& jv, const std::optional<T>& v) {
+  if (v) {
+    int64_to_json(jv, *v);
+  } else {
+    jv = nullptr;
+  }
+}
+template<class T>
+inline void int64_to_json(int64_json& jv, const std::vector<T>& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv = nlohmann::json::array();
+  for (const auto& x : v) {
+    jv.push_back(std::to_string(x));
+  }
+  #else
+  boost::json::array arr;
+  for (const auto& x : v) {
+    arr.emplace_back(std::to_string(x));
+  }
+  jv = std::move(arr);
+  #endif
+}
+
+// 文字列と数値のどちらでも読み込める
+template<class T>
+inline void int64_from_json(const int64_json& jv, T& v) {
+  if (jv.is_string()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    std::string s = jv.template get<std::string>();
+    #else
+    std::string s(jv.as_string().data(), jv.as_string().size());
+    #endif
+    if constexpr (std::is_signed<T>::value) {
+      v = (T)std::stoll(s);
+    } else {
+      v = (T)std::stoull(s);
+    }
+  } else {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    v = jv.template get<T>();
+    #else
+    v = boost::json::value_to<T>(jv);
+    #endif
+  }
+}
+template<class T>
+inline void int64_from_json(const int64_json& jv, std::optional<T>& v) {
+  if (jv.is_null()) {
+    v = std::nullopt;
+  } else {
+    T x{};
+    int64_from_json(jv, x);
+    v = x;
+  }
+}
+template<class T>
+inline void int64_from_json(const int64_json& jv, std::vector<T>& v) {
+  v.clear();
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& x : jv) {
+    #else
+    for (const auto& x : jv.as_array()) {
+    #endif
+    T y{};
+    int64_from_json(x, y);
+    v.push_back(y);
+  }
+}
+
+}
+}
+
+#endif
+
+
+namespace canonical {
+
+enum Color {
+  COLOR_UNSPECIFIED = 0,
+  COLOR_RED = 1,
+  COLOR_BLUE = 2,
+};
+
+struct Inner {
+  int32_t value = 0;
+  friend bool operator==(const Inner& a, const Inner& b) {
+    if (a.value != b.value) return false;
+    return true;
+  }
+  friend bool operator!=(const Inner& a, const Inner& b) { return !(a == b); }
+};
+
+struct Test {
+  enum class KindCase {
+    NOT_SET = 0,
+    kKindName = 13,
+    kKindNumber = 14,
+    kKindInner = 15,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    kind_name = std::string();
+    kind_number = int64_t();
+    kind_inner = ::canonical::Inner();
+  }
+  
+  enum class OptValueCase {
+    NOT_SET = 0,
+    kOptValue = 12,
+  };
+  OptValueCase _opt_value_case = OptValueCase::NOT_SET;
+  void clear__opt_value_case() {
+    _opt_value_case = OptValueCase::NOT_SET;
+    opt_value = int32_t();
+  }
+  
+  int32_t int_value = 0;
+  int64_t int64_value = 0;
+  uint64_t uint64_value = 0;
+  std::vector<int64_t> int64_values;
+  std::map<std::string, int64_t> int64_map;
+  std::string renamed;
+  ::canonical::Color color = (::canonical::Color)0;
+  std::vector<::canonical::Color> colors;
+  std::map<int32_t, ::canonical::Color> color_map;
+  ::canonical::Inner inner;
+  std::optional<int64_t> wrapped_int64;
+  int32_t opt_value = 0;
+  void set_opt_value(int32_t opt_value) {
+    clear__opt_value_case();
+    _opt_value_case = OptValueCase::kOptValue;
+    this->opt_value = opt_value;
+  }
+  bool has_opt_value() const {
+    return _opt_value_case == OptValueCase::kOptValue;
+  }
+  void clear_opt_value() {
+    if (_opt_value_case == OptValueCase::kOptValue) {
+      clear__opt_value_case();
+    }
+  }
+  std::string kind_name;
+  void set_kind_name(std::string kind_name) {
+    clear_kind_case();
+    kind_case = KindCase::kKindName;
+    this->kind_name = kind_name;
+  }
+  void clear_kind_name() {
+    if (kind_case == KindCase::kKindName) {
+      clear_kind_case();
+    }
+  }
+  int64_t kind_number = 0;
+  void set_kind_number(int64_t kind_number) {
+    clear_kind_case();
+    kind_case = KindCase::kKindNumber;
+    this->kind_number = kind_number;
+  }
+  void clear_kind_number() {
+    if (kind_case == KindCase::kKindNumber) {
+      clear_kind_case();
+    }
+  }
+  ::canonical::Inner kind_inner;
+  void set_kind_inner(::canonical::Inner kind_inner) {
+    clear_kind_case();
+    kind_case = KindCase::kKindInner;
+    this->kind_inner = kind_inner;
+  }
+  void clear_kind_inner() {
+    if (kind_case == KindCase::kKindInner) {
+      clear_kind_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.int_value != b.int_value) return false;
+    if (a.int64_value != b.int64_value) return false;
+    if (a.uint64_value != b.uint64_value) return false;
+    if (a.int64_values != b.int64_values) return false;
+    if (a.int64_map != b.int64_map) return false;
+    if (a.renamed != b.renamed) return false;
+    if (a.color != b.color) return false;
+    if (a.colors != b.colors) return false;
+    if (a.color_map != b.color_map) return false;
+    if (a.inner != b.inner) return false;
+    if (a.wrapped_int64 != b.wrapped_int64) return false;
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kKindName && a.kind_name != b.kind_name) return false;
+    if (a.kind_case == KindCase::kKindNumber && a.kind_number != b.kind_number) return false;
+    if (a.kind_case == KindCase::kKindInner && a.kind_inner != b.kind_inner) return false;
+    if (a._opt_value_case != b._opt_value_case) return false;
+    if (a._opt_value_case == OptValueCase::kOptValue && a.opt_value != b.opt_value) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::canonical::Color
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::canonical::Color& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::canonical::Color& v)
+#endif
+{
+  switch (v) {
+    case ::canonical::COLOR_UNSPECIFIED:
+      jv = "COLOR_UNSPECIFIED";
+      break;
+    case ::canonical::COLOR_RED:
+      jv = "COLOR_RED";
+      break;
+    case ::canonical::COLOR_BLUE:
+      jv = "COLOR_BLUE";
+      break;
+    default:
+      jv = (int)v;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::canonical::Color& v) {
+  if (!jv.is_string()) {
+    v = (::canonical::Color)jv.template get<int>();
+    return;
+  }
+  std::string s = jv.template get<std::string>();
+  if (s == "COLOR_UNSPECIFIED") {
+    v = ::canonical::COLOR_UNSPECIFIED;
+    return;
+  }
+  if (s == "COLOR_RED") {
+    v = ::canonical::COLOR_RED;
+    return;
+  }
+  if (s == "COLOR_BLUE") {
+    v = ::canonical::COLOR_BLUE;
+    return;
+  }
+  v = (::canonical::Color)0;
+}
+#else
+static ::canonical::Color tag_invoke(const boost::json::value_to_tag<::canonical::Color>&, const boost::json::value& jv) {
+  if (!jv.is_string()) {
+    return (::canonical::Color)boost::json::value_to<int>(jv);
+  }
+  std::string s(jv.as_string().data(), jv.as_string().size());
+  if (s == "COLOR_UNSPECIFIED") return ::canonical::COLOR_UNSPECIFIED;
+  if (s == "COLOR_RED") return ::canonical::COLOR_RED;
+  if (s == "COLOR_BLUE") return ::canonical::COLOR_BLUE;
+  return (::canonical::Color)0;
+}
+#endif
+
+// ::canonical::Inner
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::canonical::Inner& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::canonical::Inner& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  if (v.value != decltype(v.value)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["value"], v.value);
+    }
+    #else
+    obj["value"] = boost::json::value_from(v.value);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::canonical::Inner& v)
+#else
+static ::canonical::Inner tag_invoke(const boost::json::value_to_tag<::canonical::Inner>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::canonical::Inner v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("value"))
+  #else
+  if (jv.as_object().find("value") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("value"), v.value);
+    }
+    #else
+    v.value = boost::json::value_to<int32_t>(jv.at("value"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::canonical::Test::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::canonical::Test::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::canonical::Test::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::canonical::Test::KindCase::kKindName:
+    case ::canonical::Test::KindCase::kKindNumber:
+    case ::canonical::Test::KindCase::kKindInner:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::canonical::Test::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::canonical::Test::KindCase& v) {
+  v = (::canonical::Test::KindCase)jv.template get<int>();
+}
+#else
+static ::canonical::Test::KindCase tag_invoke(const boost::json::value_to_tag<::canonical::Test::KindCase>&, const boost::json::value& jv) {
+  return (::canonical::Test::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::canonical::Test::OptValueCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::canonical::Test::OptValueCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::canonical::Test::OptValueCase& v)
+#endif
+{
+  switch (v) {
+    case ::canonical::Test::OptValueCase::kOptValue:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::canonical::Test::OptValueCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::canonical::Test::OptValueCase& v) {
+  v = (::canonical::Test::OptValueCase)jv.template get<int>();
+}
+#else
+static ::canonical::Test::OptValueCase tag_invoke(const boost::json::value_to_tag<::canonical::Test::OptValueCase>&, const boost::json::value& jv) {
+  return (::canonical::Test::OptValueCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::canonical::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::canonical::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::canonical::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  if (v.int_value != decltype(v.int_value)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["intValue"], v.int_value);
+    }
+    #else
+    obj["intValue"] = boost::json::value_from(v.int_value);
+    #endif
+  }
+  if (v.int64_value != decltype(v.int64_value)()) {
+    ::jsonif::detail::int64_to_json(obj["int64Value"], v.int64_value);
+  }
+  if (v.uint64_value != decltype(v.uint64_value)()) {
+    ::jsonif::detail::int64_to_json(obj["uint64Value"], v.uint64_value);
+  }
+  if (v.int64_values != decltype(v.int64_values)()) {
+    ::jsonif::detail::int64_to_json(obj["int64Values"], v.int64_values);
+  }
+  if (v.int64_map != decltype(v.int64_map)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      nlohmann::json m = nlohmann::json::object();
+      for (const auto& kv : v.int64_map) {
+        ::jsonif::detail::int64_to_json(m[kv.first], kv.second);
+      }
+      obj["int64Map"] = std::move(m);
+    }
+    #else
+    {
+      boost::json::object m;
+      for (const auto& kv : v.int64_map) {
+        ::jsonif::detail::int64_to_json(m[kv.first], kv.second);
+      }
+      obj["int64Map"] = std::move(m);
+    }
+    #endif
+  }
+  if (v.renamed != decltype(v.renamed)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["customName"], v.renamed);
+    }
+    #else
+    obj["customName"] = boost::json::value_from(v.renamed);
+    #endif
+  }
+  if (v.color != decltype(v.color)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["color"], v.color);
+    }
+    #else
+    obj["color"] = boost::json::value_from(v.color);
+    #endif
+  }
+  if (v.colors != decltype(v.colors)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["colors"], v.colors);
+    }
+    #else
+    obj["colors"] = boost::json::value_from(v.colors);
+    #endif
+  }
+  if (v.color_map != decltype(v.color_map)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      nlohmann::json m = nlohmann::json::object();
+      for (const auto& kv : v.color_map) {
+        to_json(m[std::to_string(kv.first)], kv.second);
+      }
+      obj["colorMap"] = std::move(m);
+    }
+    #else
+    {
+      boost::json::object m;
+      for (const auto& kv : v.color_map) {
+        m[std::to_string(kv.first)] = boost::json::value_from(kv.second);
+      }
+      obj["colorMap"] = std::move(m);
+    }
+    #endif
+  }
+  if (v.inner != decltype(v.inner)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["inner"], v.inner);
+    }
+    #else
+    obj["inner"] = boost::json::value_from(v.inner);
+    #endif
+  }
+  if (v.wrapped_int64 != decltype(v.wrapped_int64)()) {
+    ::jsonif::detail::int64_to_json(obj["wrappedInt64"], v.wrapped_int64);
+  }
+  if (v._opt_value_case == ::canonical::Test::OptValueCase::kOptValue) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["optValue"], v.opt_value);
+    }
+    #else
+    obj["optValue"] = boost::json::value_from(v.opt_value);
+    #endif
+  }
+  if (v.kind_case == ::canonical::Test::KindCase::kKindName) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["kindName"], v.kind_name);
+    }
+    #else
+    obj["kindName"] = boost::json::value_from(v.kind_name);
+    #endif
+  }
+  if (v.kind_case == ::canonical::Test::KindCase::kKindNumber) {
+    ::jsonif::detail::int64_to_json(obj["kindNumber"], v.kind_number);
+  }
+  if (v.kind_case == ::canonical::Test::KindCase::kKindInner) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["kindInner"], v.kind_inner);
+    }
+    #else
+    obj["kindInner"] = boost::json::value_from(v.kind_inner);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::canonical::Test& v)
+#else
+static ::canonical::Test tag_invoke(const boost::json::value_to_tag<::canonical::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::canonical::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("intValue"))
+  #else
+  if (jv.as_object().find("intValue") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("intValue"), v.int_value);
+    }
+    #else
+    v.int_value = boost::json::value_to<int32_t>(jv.at("intValue"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("int64Value"))
+  #else
+  if (jv.as_object().find("int64Value") != jv.as_object().end())
+  #endif
+  {
+    ::jsonif::detail::int64_from_json(jv.at("int64Value"), v.int64_value);
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("uint64Value"))
+  #else
+  if (jv.as_object().find("uint64Value") != jv.as_object().end())
+  #endif
+  {
+    ::jsonif::detail::int64_from_json(jv.at("uint64Value"), v.uint64_value);
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("int64Values"))
+  #else
+  if (jv.as_object().find("int64Values") != jv.as_object().end())
+  #endif
+  {
+    ::jsonif::detail::int64_from_json(jv.at("int64Values"), v.int64_values);
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("int64Map"))
+  #else
+  if (jv.as_object().find("int64Map") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    for (const auto& kv : jv.at("int64Map").items()) {
+      using nlohmann::from_json;
+      std::string key = kv.key();
+      int64_t value{};
+      ::jsonif::detail::int64_from_json(kv.value(), value);
+      v.int64_map.emplace(key, std::move(value));
+    }
+    #else
+    for (const auto& kv : jv.at("int64Map").as_object()) {
+      std::string key(kv.key().data(), kv.key().size());
+      int64_t value{};
+      ::jsonif::detail::int64_from_json(kv.value(), value);
+      v.int64_map.emplace(key, std::move(value));
+    }
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("customName"))
+  #else
+  if (jv.as_object().find("customName") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("customName"), v.renamed);
+    }
+    #else
+    v.renamed = boost::json::value_to<std::string>(jv.at("customName"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("color"))
+  #else
+  if (jv.as_object().find("color") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("color"), v.color);
+    }
+    #else
+    v.color = boost::json::value_to<::canonical::Color>(jv.at("color"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("colors"))
+  #else
+  if (jv.as_object().find("colors") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("colors"), v.colors);
+    }
+    #else
+    v.colors = boost::json::value_to<std::vector<::canonical::Color>>(jv.at("colors"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("colorMap"))
+  #else
+  if (jv.as_object().find("colorMap") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    for (const auto& kv : jv.at("colorMap").items()) {
+      using nlohmann::from_json;
+      std::string key = kv.key();
+      ::canonical::Color value{};
+      from_json(kv.value(), value);
+      v.color_map.emplace((int32_t)std::stoll(key), std::move(value));
+    }
+    #else
+    for (const auto& kv : jv.at("colorMap").as_object()) {
+      std::string key(kv.key().data(), kv.key().size());
+      v.color_map.emplace((int32_t)std::stoll(key), boost::json::value_to<::canonical::Color>(kv.value()));
+    }
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("inner"))
+  #else
+  if (jv.as_object().find("inner") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("inner"), v.inner);
+    }
+    #else
+    v.inner = boost::json::value_to<::canonical::Inner>(jv.at("inner"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("wrappedInt64"))
+  #else
+  if (jv.as_object().find("wrappedInt64") != jv.as_object().end())
+  #endif
+  {
+    ::jsonif::detail::int64_from_json(jv.at("wrappedInt64"), v.wrapped_int64);
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("optValue"))
+  #else
+  if (jv.as_object().find("optValue") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("optValue"), v.opt_value);
+    }
+    #else
+    v.opt_value = boost::json::value_to<int32_t>(jv.at("optValue"));
+    #endif
+    v._opt_value_case = ::canonical::Test::OptValueCase::kOptValue;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("kindName"))
+  #else
+  if (jv.as_object().find("kindName") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("kindName"), v.kind_name);
+    }
+    #else
+    v.kind_name = boost::json::value_to<std::string>(jv.at("kindName"));
+    #endif
+    v.kind_case = ::canonical::Test::KindCase::kKindName;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("kindNumber"))
+  #else
+  if (jv.as_object().find("kindNumber") != jv.as_object().end())
+  #endif
+  {
+    ::jsonif::detail::int64_from_json(jv.at("kindNumber"), v.kind_number);
+    v.kind_case = ::canonical::Test::KindCase::kKindNumber;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("kindInner"))
+  #else
+  if (jv.as_object().find("kindInner") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("kindInner"), v.kind_inner);
+    }
+    #else
+    v.kind_inner = boost::json::value_to<::canonical::Inner>(jv.at("kindInner"));
+    #endif
+    v.kind_case = ::canonical::Test::KindCase::kKindInner;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("kind_case"))
+  #else
+  if (jv.as_object().find("kind_case") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("kind_case"), v.kind_case);
+    }
+    #else
+    v.kind_case = boost::json::value_to<::canonical::Test::KindCase>(jv.at("kind_case"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::canonical::Inner> {
+  static constexpr const char* value = "canonical.Inner";
+};
+template<>
+struct type_name<::canonical::Test> {
+  static constexpr const char* value = "canonical.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,455 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_COMMENTS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_COMMENTS_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace comments {
+
+/// 列挙型のコメント
+enum Kind {
+  /// 値のコメント
+  KIND_UNKNOWN = 0,
+  /// 後置コメント
+  KIND_FOO = 1,
+};
+
+/// proto のコメントがドキュメントコメントとして出力されるか確認する用
+///
+/// 複数行のコメントや <記号> & */ も扱えること
+struct Test {
+  /// ネストしたメッセージ
+  struct Nested {
+    /// ネストしたメッセージのフィールド
+    bool flag = false;
+    friend bool operator==(const Nested& a, const Nested& b) {
+      if (a.flag != b.flag) return false;
+      return true;
+    }
+    friend bool operator!=(const Nested& a, const Nested& b) { return !(a == b); }
+  };
+  
+  /// oneof のコメント
+  enum class ValueCase {
+    NOT_SET = 0,
+    kA = 4,
+    kB = 5,
+  };
+  ValueCase value_case = ValueCase::NOT_SET;
+  void clear_value_case() {
+    value_case = ValueCase::NOT_SET;
+    a = int32_t();
+    b = std::string();
+  }
+  
+  /// 前置コメント
+  int32_t leading = 0;
+  /// 後置コメント
+  std::string trailing;
+  std::vector<int32_t> none;
+  /// oneof フィールドのコメント
+  int32_t a = 0;
+  void set_a(int32_t a) {
+    clear_value_case();
+    value_case = ValueCase::kA;
+    this->a = a;
+  }
+  void clear_a() {
+    if (value_case == ValueCase::kA) {
+      clear_value_case();
+    }
+  }
+  std::string b;
+  void set_b(std::string b) {
+    clear_value_case();
+    value_case = ValueCase::kB;
+    this->b = b;
+  }
+  void clear_b() {
+    if (value_case == ValueCase::kB) {
+      clear_value_case();
+    }
+  }
+  ::comments::Test::Nested nested;
+  /// 離れたコメント
+  ///
+  /// 離れたコメントの後の前置コメント
+  int32_t detached = 0;
+  /// 前置コメントと
+  ///
+  /// 後置コメントの両方
+  int32_t both = 0;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.leading != b.leading) return false;
+    if (a.trailing != b.trailing) return false;
+    if (a.none != b.none) return false;
+    if (a.nested != b.nested) return false;
+    if (a.detached != b.detached) return false;
+    if (a.both != b.both) return false;
+    if (a.value_case != b.value_case) return false;
+    if (a.value_case == ValueCase::kA && a.a != b.a) return false;
+    if (a.value_case == ValueCase::kB && a.b != b.b) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::comments::Kind
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::comments::Kind& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::comments::Kind& v)
+#endif
+{
+  switch (v) {
+    case ::comments::KIND_UNKNOWN:
+    case ::comments::KIND_FOO:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::comments::Kind)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::comments::Kind& v) {
+  v = (::comments::Kind)jv.template get<int>();
+}
+#else
+static ::comments::Kind tag_invoke(const boost::json::value_to_tag<::comments::Kind>&, const boost::json::value& jv) {
+  return (::comments::Kind)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::comments::Test::Nested
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::comments::Test::Nested& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::comments::Test::Nested& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["flag"], v.flag);
+  }
+  #else
+  obj["flag"] = boost::json::value_from(v.flag);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::comments::Test::Nested& v)
+#else
+static ::comments::Test::Nested tag_invoke(const boost::json::value_to_tag<::comments::Test::Nested>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::comments::Test::Nested v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("flag"), v.flag);
+  }
+  #else
+  v.flag = boost::json::value_to<bool>(jv.at("flag"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::comments::Test::ValueCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::comments::Test::ValueCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::comments::Test::ValueCase& v)
+#endif
+{
+  switch (v) {
+    case ::comments::Test::ValueCase::kA:
+    case ::comments::Test::ValueCase::kB:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::comments::Test::ValueCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::comments::Test::ValueCase& v) {
+  v = (::comments::Test::ValueCase)jv.template get<int>();
+}
+#else
+static ::comments::Test::ValueCase tag_invoke(const boost::json::value_to_tag<::comments::Test::ValueCase>&, const boost::json::value& jv) {
+  return (::comments::Test::ValueCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::comments::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::comments::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::comments::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["leading"], v.leading);
+  }
+  #else
+  obj["leading"] = boost::json::value_from(v.leading);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["trailing"], v.trailing);
+  }
+  #else
+  obj["trailing"] = boost::json::value_from(v.trailing);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["none"], v.none);
+  }
+  #else
+  obj["none"] = boost::json::value_from(v.none);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["a"], v.a);
+  }
+  #else
+  obj["a"] = boost::json::value_from(v.a);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["b"], v.b);
+  }
+  #else
+  obj["b"] = boost::json::value_from(v.b);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["nested"], v.nested);
+  }
+  #else
+  obj["nested"] = boost::json::value_from(v.nested);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["detached"], v.detached);
+  }
+  #else
+  obj["detached"] = boost::json::value_from(v.detached);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["both"], v.both);
+  }
+  #else
+  obj["both"] = boost::json::value_from(v.both);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value_case"], v.value_case);
+  }
+  #else
+  obj["value_case"] = boost::json::value_from(v.value_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::comments::Test& v)
+#else
+static ::comments::Test tag_invoke(const boost::json::value_to_tag<::comments::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::comments::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("leading"), v.leading);
+  }
+  #else
+  v.leading = boost::json::value_to<int32_t>(jv.at("leading"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("trailing"), v.trailing);
+  }
+  #else
+  v.trailing = boost::json::value_to<std::string>(jv.at("trailing"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("none"), v.none);
+  }
+  #else
+  v.none = boost::json::value_to<std::vector<int32_t>>(jv.at("none"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("a"))
+  #else
+  if (jv.as_object().find("a") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("a"), v.a);
+    }
+    #else
+    v.a = boost::json::value_to<int32_t>(jv.at("a"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("b"))
+  #else
+  if (jv.as_object().find("b") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("b"), v.b);
+    }
+    #else
+    v.b = boost::json::value_to<std::string>(jv.at("b"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("nested"), v.nested);
+  }
+  #else
+  v.nested = boost::json::value_to<::comments::Test::Nested>(jv.at("nested"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("detached"), v.detached);
+  }
+  #else
+  v.detached = boost::json::value_to<int32_t>(jv.at("detached"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("both"), v.both);
+  }
+  #else
+  v.both = boost::json::value_to<int32_t>(jv.at("both"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value_case"), v.value_case);
+  }
+  #else
+  v.value_case = boost::json::value_to<::comments::Test::ValueCase>(jv.at("value_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::comments::Test::Nested> {
+  static constexpr const char* value = "comments.Test.Nested";
+};
+template<>
+struct type_name<::comments::Test> {
+  static constexpr const char* value = "comments.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
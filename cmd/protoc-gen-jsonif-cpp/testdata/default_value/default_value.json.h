@@ -0,0 +1,593 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_DEFAULT_VALUE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_DEFAULT_VALUE_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace default_value {
+
+enum Color {
+  COLOR_UNSPECIFIED = 0,
+  COLOR_RED = 1,
+  COLOR_BLUE = 2,
+};
+
+struct Inner {
+  int32_t value = 10;
+  friend bool operator==(const Inner& a, const Inner& b) {
+    if (a.value != b.value) return false;
+    return true;
+  }
+  friend bool operator!=(const Inner& a, const Inner& b) { return !(a == b); }
+};
+
+/// キーが無い場合は jsonif_default の値になる
+/// デフォルト値と同じ値のフィールドは出力しない
+struct Test {
+  double d = 1.5;
+  float f = 3.0f;
+  double big = 1e+30;
+  int32_t i32 = -2147483648;
+  int64_t i64 = (-9223372036854775807LL - 1);
+  uint32_t u32 = 4294967295U;
+  uint64_t u64 = 12345678901234ULL;
+  bool b = true;
+  std::string s = "hello \"jsonif\"\n";
+  std::string empty = "";
+  /// enum は値の名前でも数値でも指定できる
+  ::default_value::Color color = (::default_value::Color)1;
+  ::default_value::Color color_num = (::default_value::Color)2;
+  int32_t no_default = 0;
+  /// メッセージのフィールドも、そのメッセージの jsonif_default の値で初期化される
+  ::default_value::Inner inner;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.d != b.d) return false;
+    if (a.f != b.f) return false;
+    if (a.big != b.big) return false;
+    if (a.i32 != b.i32) return false;
+    if (a.i64 != b.i64) return false;
+    if (a.u32 != b.u32) return false;
+    if (a.u64 != b.u64) return false;
+    if (a.b != b.b) return false;
+    if (a.s != b.s) return false;
+    if (a.empty != b.empty) return false;
+    if (a.color != b.color) return false;
+    if (a.color_num != b.color_num) return false;
+    if (a.no_default != b.no_default) return false;
+    if (a.inner != b.inner) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::default_value::Color
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::default_value::Color& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::default_value::Color& v)
+#endif
+{
+  switch (v) {
+    case ::default_value::COLOR_UNSPECIFIED:
+    case ::default_value::COLOR_RED:
+    case ::default_value::COLOR_BLUE:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::default_value::Color)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::default_value::Color& v) {
+  v = (::default_value::Color)jv.template get<int>();
+}
+#else
+static ::default_value::Color tag_invoke(const boost::json::value_to_tag<::default_value::Color>&, const boost::json::value& jv) {
+  return (::default_value::Color)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::default_value::Inner
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::default_value::Inner& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::default_value::Inner& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value"], v.value);
+  }
+  #else
+  obj["value"] = boost::json::value_from(v.value);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::default_value::Inner& v)
+#else
+static ::default_value::Inner tag_invoke(const boost::json::value_to_tag<::default_value::Inner>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::default_value::Inner v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("value"))
+  #else
+  if (jv.as_object().find("value") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("value"), v.value);
+    }
+    #else
+    v.value = boost::json::value_to<int32_t>(jv.at("value"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::default_value::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::default_value::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::default_value::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  if (v.d != 1.5) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["d"], v.d);
+    }
+    #else
+    obj["d"] = boost::json::value_from(v.d);
+    #endif
+  }
+  if (v.f != 3.0f) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["f"], v.f);
+    }
+    #else
+    obj["f"] = boost::json::value_from(v.f);
+    #endif
+  }
+  if (v.big != 1e+30) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["big"], v.big);
+    }
+    #else
+    obj["big"] = boost::json::value_from(v.big);
+    #endif
+  }
+  if (v.i32 != -2147483648) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["i32"], v.i32);
+    }
+    #else
+    obj["i32"] = boost::json::value_from(v.i32);
+    #endif
+  }
+  if (v.i64 != (-9223372036854775807LL - 1)) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["i64"], v.i64);
+    }
+    #else
+    obj["i64"] = boost::json::value_from(v.i64);
+    #endif
+  }
+  if (v.u32 != 4294967295U) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["u32"], v.u32);
+    }
+    #else
+    obj["u32"] = boost::json::value_from(v.u32);
+    #endif
+  }
+  if (v.u64 != 12345678901234ULL) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["u64"], v.u64);
+    }
+    #else
+    obj["u64"] = boost::json::value_from(v.u64);
+    #endif
+  }
+  if (v.b != true) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["b"], v.b);
+    }
+    #else
+    obj["b"] = boost::json::value_from(v.b);
+    #endif
+  }
+  if (v.s != "hello \"jsonif\"\n") {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["s"], v.s);
+    }
+    #else
+    obj["s"] = boost::json::value_from(v.s);
+    #endif
+  }
+  if (v.empty != "") {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["empty"], v.empty);
+    }
+    #else
+    obj["empty"] = boost::json::value_from(v.empty);
+    #endif
+  }
+  if (v.color != (::default_value::Color)1) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["color"], v.color);
+    }
+    #else
+    obj["color"] = boost::json::value_from(v.color);
+    #endif
+  }
+  if (v.color_num != (::default_value::Color)2) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["color_num"], v.color_num);
+    }
+    #else
+    obj["color_num"] = boost::json::value_from(v.color_num);
+    #endif
+  }
+  if (v.no_default != decltype(v.no_default)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["no_default"], v.no_default);
+    }
+    #else
+    obj["no_default"] = boost::json::value_from(v.no_default);
+    #endif
+  }
+  if (v.inner != decltype(v.inner)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["inner"], v.inner);
+    }
+    #else
+    obj["inner"] = boost::json::value_from(v.inner);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::default_value::Test& v)
+#else
+static ::default_value::Test tag_invoke(const boost::json::value_to_tag<::default_value::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::default_value::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("d"))
+  #else
+  if (jv.as_object().find("d") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("d"), v.d);
+    }
+    #else
+    v.d = boost::json::value_to<double>(jv.at("d"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("f"))
+  #else
+  if (jv.as_object().find("f") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("f"), v.f);
+    }
+    #else
+    v.f = boost::json::value_to<float>(jv.at("f"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("big"))
+  #else
+  if (jv.as_object().find("big") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("big"), v.big);
+    }
+    #else
+    v.big = boost::json::value_to<double>(jv.at("big"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("i32"))
+  #else
+  if (jv.as_object().find("i32") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("i32"), v.i32);
+    }
+    #else
+    v.i32 = boost::json::value_to<int32_t>(jv.at("i32"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("i64"))
+  #else
+  if (jv.as_object().find("i64") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("i64"), v.i64);
+    }
+    #else
+    v.i64 = boost::json::value_to<int64_t>(jv.at("i64"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("u32"))
+  #else
+  if (jv.as_object().find("u32") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("u32"), v.u32);
+    }
+    #else
+    v.u32 = boost::json::value_to<uint32_t>(jv.at("u32"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("u64"))
+  #else
+  if (jv.as_object().find("u64") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("u64"), v.u64);
+    }
+    #else
+    v.u64 = boost::json::value_to<uint64_t>(jv.at("u64"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("b"))
+  #else
+  if (jv.as_object().find("b") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("b"), v.b);
+    }
+    #else
+    v.b = boost::json::value_to<bool>(jv.at("b"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("s"))
+  #else
+  if (jv.as_object().find("s") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("s"), v.s);
+    }
+    #else
+    v.s = boost::json::value_to<std::string>(jv.at("s"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("empty"))
+  #else
+  if (jv.as_object().find("empty") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("empty"), v.empty);
+    }
+    #else
+    v.empty = boost::json::value_to<std::string>(jv.at("empty"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("color"))
+  #else
+  if (jv.as_object().find("color") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("color"), v.color);
+    }
+    #else
+    v.color = boost::json::value_to<::default_value::Color>(jv.at("color"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("color_num"))
+  #else
+  if (jv.as_object().find("color_num") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("color_num"), v.color_num);
+    }
+    #else
+    v.color_num = boost::json::value_to<::default_value::Color>(jv.at("color_num"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("no_default"))
+  #else
+  if (jv.as_object().find("no_default") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("no_default"), v.no_default);
+    }
+    #else
+    v.no_default = boost::json::value_to<int32_t>(jv.at("no_default"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("inner"))
+  #else
+  if (jv.as_object().find("inner") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("inner"), v.inner);
+    }
+    #else
+    v.inner = boost::json::value_to<::default_value::Inner>(jv.at("inner"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::default_value::Inner> {
+  static constexpr const char* value = "default_value.Inner";
+};
+template<>
+struct type_name<::default_value::Test> {
+  static constexpr const char* value = "default_value.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
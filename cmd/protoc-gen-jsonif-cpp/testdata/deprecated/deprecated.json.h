@@ -0,0 +1,613 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_DEPRECATED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_DEPRECATED_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic push
+#pragma GCC diagnostic ignored "-Wdeprecated-declarations"
+#elif defined(_MSC_VER)
+#pragma warning(push)
+#pragma warning(disable: 4996)
+#endif
+
+namespace deprecated {
+
+enum Status {
+  STATUS_UNKNOWN = 0,
+  STATUS_ACTIVE = 1,
+  /// STATUS_ACTIVE を使うこと
+  STATUS_ENABLED [[deprecated]] = 2,
+};
+
+/// Test に置き換えられた古いメッセージ
+struct [[deprecated]] Legacy {
+  int32_t value = 0;
+  friend bool operator==(const Legacy& a, const Legacy& b) {
+    if (a.value != b.value) return false;
+    return true;
+  }
+  friend bool operator!=(const Legacy& a, const Legacy& b) { return !(a == b); }
+};
+
+struct Test {
+  enum class ChoiceCase {
+    NOT_SET = 0,
+    kNumber = 7,
+    kText = 8,
+  };
+  ChoiceCase choice_case = ChoiceCase::NOT_SET;
+  void clear_choice_case() {
+    choice_case = ChoiceCase::NOT_SET;
+    number = int32_t();
+    text = std::string();
+  }
+  
+  enum class NoteCase {
+    NOT_SET = 0,
+    kNote = 6,
+  };
+  NoteCase _note_case = NoteCase::NOT_SET;
+  void clear__note_case() {
+    _note_case = NoteCase::NOT_SET;
+    note = std::string();
+  }
+  
+  int32_t id = 0;
+  /// id を使うこと
+  [[deprecated]] int32_t old_id = 0;
+  ::deprecated::Status status = (::deprecated::Status)0;
+  [[deprecated]] ::deprecated::Legacy legacy;
+  [[deprecated]] std::vector<std::string> tags;
+  [[deprecated]] std::string note;
+  [[deprecated]] void set_note(std::string note) {
+    clear__note_case();
+    _note_case = NoteCase::kNote;
+    this->note = note;
+  }
+  [[deprecated]] bool has_note() const {
+    return _note_case == NoteCase::kNote;
+  }
+  [[deprecated]] void clear_note() {
+    if (_note_case == NoteCase::kNote) {
+      clear__note_case();
+    }
+  }
+  int32_t number = 0;
+  void set_number(int32_t number) {
+    clear_choice_case();
+    choice_case = ChoiceCase::kNumber;
+    this->number = number;
+  }
+  void clear_number() {
+    if (choice_case == ChoiceCase::kNumber) {
+      clear_choice_case();
+    }
+  }
+  [[deprecated]] std::string text;
+  [[deprecated]] void set_text(std::string text) {
+    clear_choice_case();
+    choice_case = ChoiceCase::kText;
+    this->text = text;
+  }
+  [[deprecated]] void clear_text() {
+    if (choice_case == ChoiceCase::kText) {
+      clear_choice_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.id != b.id) return false;
+    if (a.old_id != b.old_id) return false;
+    if (a.status != b.status) return false;
+    if (a.legacy != b.legacy) return false;
+    if (a.tags != b.tags) return false;
+    if (a.choice_case != b.choice_case) return false;
+    if (a.choice_case == ChoiceCase::kNumber && a.number != b.number) return false;
+    if (a.choice_case == ChoiceCase::kText && a.text != b.text) return false;
+    if (a._note_case != b._note_case) return false;
+    if (a._note_case == NoteCase::kNote && a.note != b.note) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+/// deprecated なメッセージや enum の値を参照するだけのメッセージ
+struct Holder {
+  ::deprecated::Status status = (::deprecated::Status)0;
+  std::map<std::string, ::deprecated::Status> statuses;
+  friend bool operator==(const Holder& a, const Holder& b) {
+    if (a.status != b.status) return false;
+    if (a.statuses != b.statuses) return false;
+    return true;
+  }
+  friend bool operator!=(const Holder& a, const Holder& b) { return !(a == b); }
+};
+
+// ::deprecated::Status
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::deprecated::Status& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::deprecated::Status& v)
+#endif
+{
+  switch (v) {
+    case ::deprecated::STATUS_UNKNOWN:
+    case ::deprecated::STATUS_ACTIVE:
+    case ::deprecated::STATUS_ENABLED:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::deprecated::Status)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::deprecated::Status& v) {
+  v = (::deprecated::Status)jv.template get<int>();
+}
+#else
+static ::deprecated::Status tag_invoke(const boost::json::value_to_tag<::deprecated::Status>&, const boost::json::value& jv) {
+  return (::deprecated::Status)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::deprecated::Legacy
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::deprecated::Legacy& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::deprecated::Legacy& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value"], v.value);
+  }
+  #else
+  obj["value"] = boost::json::value_from(v.value);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::deprecated::Legacy& v)
+#else
+static ::deprecated::Legacy tag_invoke(const boost::json::value_to_tag<::deprecated::Legacy>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::deprecated::Legacy v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value"), v.value);
+  }
+  #else
+  v.value = boost::json::value_to<int32_t>(jv.at("value"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::deprecated::Test::ChoiceCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::deprecated::Test::ChoiceCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::deprecated::Test::ChoiceCase& v)
+#endif
+{
+  switch (v) {
+    case ::deprecated::Test::ChoiceCase::kNumber:
+    case ::deprecated::Test::ChoiceCase::kText:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::deprecated::Test::ChoiceCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::deprecated::Test::ChoiceCase& v) {
+  v = (::deprecated::Test::ChoiceCase)jv.template get<int>();
+}
+#else
+static ::deprecated::Test::ChoiceCase tag_invoke(const boost::json::value_to_tag<::deprecated::Test::ChoiceCase>&, const boost::json::value& jv) {
+  return (::deprecated::Test::ChoiceCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::deprecated::Test::NoteCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::deprecated::Test::NoteCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::deprecated::Test::NoteCase& v)
+#endif
+{
+  switch (v) {
+    case ::deprecated::Test::NoteCase::kNote:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::deprecated::Test::NoteCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::deprecated::Test::NoteCase& v) {
+  v = (::deprecated::Test::NoteCase)jv.template get<int>();
+}
+#else
+static ::deprecated::Test::NoteCase tag_invoke(const boost::json::value_to_tag<::deprecated::Test::NoteCase>&, const boost::json::value& jv) {
+  return (::deprecated::Test::NoteCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::deprecated::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::deprecated::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::deprecated::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["id"], v.id);
+  }
+  #else
+  obj["id"] = boost::json::value_from(v.id);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["old_id"], v.old_id);
+  }
+  #else
+  obj["old_id"] = boost::json::value_from(v.old_id);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["status"], v.status);
+  }
+  #else
+  obj["status"] = boost::json::value_from(v.status);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["legacy"], v.legacy);
+  }
+  #else
+  obj["legacy"] = boost::json::value_from(v.legacy);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["tags"], v.tags);
+  }
+  #else
+  obj["tags"] = boost::json::value_from(v.tags);
+  #endif
+  if (v._note_case == ::deprecated::Test::NoteCase::kNote) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["note"], v.note);
+    }
+    #else
+    obj["note"] = boost::json::value_from(v.note);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["number"], v.number);
+  }
+  #else
+  obj["number"] = boost::json::value_from(v.number);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["text"], v.text);
+  }
+  #else
+  obj["text"] = boost::json::value_from(v.text);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["choice_case"], v.choice_case);
+  }
+  #else
+  obj["choice_case"] = boost::json::value_from(v.choice_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::deprecated::Test& v)
+#else
+static ::deprecated::Test tag_invoke(const boost::json::value_to_tag<::deprecated::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::deprecated::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("id"), v.id);
+  }
+  #else
+  v.id = boost::json::value_to<int32_t>(jv.at("id"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("old_id"), v.old_id);
+  }
+  #else
+  v.old_id = boost::json::value_to<int32_t>(jv.at("old_id"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("status"), v.status);
+  }
+  #else
+  v.status = boost::json::value_to<::deprecated::Status>(jv.at("status"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("legacy"), v.legacy);
+  }
+  #else
+  v.legacy = boost::json::value_to<::deprecated::Legacy>(jv.at("legacy"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("tags"), v.tags);
+  }
+  #else
+  v.tags = boost::json::value_to<std::vector<std::string>>(jv.at("tags"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("note"))
+  #else
+  if (jv.as_object().find("note") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("note"), v.note);
+    }
+    #else
+    v.note = boost::json::value_to<std::string>(jv.at("note"));
+    #endif
+    v._note_case = ::deprecated::Test::NoteCase::kNote;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("number"))
+  #else
+  if (jv.as_object().find("number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("number"), v.number);
+    }
+    #else
+    v.number = boost::json::value_to<int32_t>(jv.at("number"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("text"))
+  #else
+  if (jv.as_object().find("text") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("text"), v.text);
+    }
+    #else
+    v.text = boost::json::value_to<std::string>(jv.at("text"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("choice_case"), v.choice_case);
+  }
+  #else
+  v.choice_case = boost::json::value_to<::deprecated::Test::ChoiceCase>(jv.at("choice_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::deprecated::Holder
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::deprecated::Holder& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::deprecated::Holder& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["status"], v.status);
+  }
+  #else
+  obj["status"] = boost::json::value_from(v.status);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.statuses) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["statuses"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.statuses) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["statuses"] = std::move(m);
+  }
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::deprecated::Holder& v)
+#else
+static ::deprecated::Holder tag_invoke(const boost::json::value_to_tag<::deprecated::Holder>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::deprecated::Holder v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("status"), v.status);
+  }
+  #else
+  v.status = boost::json::value_to<::deprecated::Status>(jv.at("status"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("statuses").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::deprecated::Status value{};
+    from_json(kv.value(), value);
+    v.statuses.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("statuses").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.statuses.emplace(key, boost::json::value_to<::deprecated::Status>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::deprecated::Legacy> {
+  static constexpr const char* value = "deprecated.Legacy";
+};
+template<>
+struct type_name<::deprecated::Test> {
+  static constexpr const char* value = "deprecated.Test";
+};
+template<>
+struct type_name<::deprecated::Holder> {
+  static constexpr const char* value = "deprecated.Holder";
+};
+
+}
+
+#if defined(__GNUC__)
+#pragma GCC diagnostic pop
+#elif defined(_MSC_VER)
+#pragma warning(pop)
+#endif
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,227 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_DISCARD_IF_DEFAULT_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_DISCARD_IF_DEFAULT_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace discard_if_default {
+
+struct Test2 {
+  int32_t a = 0;
+  friend bool operator==(const Test2& a, const Test2& b) {
+    if (a.a != b.a) return false;
+    return true;
+  }
+  friend bool operator!=(const Test2& a, const Test2& b) { return !(a == b); }
+};
+
+struct Test {
+  std::string a;
+  std::string b;
+  ::discard_if_default::Test2 c;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.a != b.a) return false;
+    if (a.b != b.b) return false;
+    if (a.c != b.c) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::discard_if_default::Test2
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::discard_if_default::Test2& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::discard_if_default::Test2& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["a"], v.a);
+  }
+  #else
+  obj["a"] = boost::json::value_from(v.a);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::discard_if_default::Test2& v)
+#else
+static ::discard_if_default::Test2 tag_invoke(const boost::json::value_to_tag<::discard_if_default::Test2>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::discard_if_default::Test2 v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("a"), v.a);
+  }
+  #else
+  v.a = boost::json::value_to<int32_t>(jv.at("a"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::discard_if_default::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::discard_if_default::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::discard_if_default::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["a"], v.a);
+  }
+  #else
+  obj["a"] = boost::json::value_from(v.a);
+  #endif
+  if (v.b != decltype(v.b)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["b"], v.b);
+    }
+    #else
+    obj["b"] = boost::json::value_from(v.b);
+    #endif
+  }
+  if (v.c != decltype(v.c)()) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["c"], v.c);
+    }
+    #else
+    obj["c"] = boost::json::value_from(v.c);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::discard_if_default::Test& v)
+#else
+static ::discard_if_default::Test tag_invoke(const boost::json::value_to_tag<::discard_if_default::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::discard_if_default::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("a"), v.a);
+  }
+  #else
+  v.a = boost::json::value_to<std::string>(jv.at("a"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("b"), v.b);
+  }
+  #else
+  v.b = boost::json::value_to<std::string>(jv.at("b"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("c"), v.c);
+  }
+  #else
+  v.c = boost::json::value_to<::discard_if_default::Test2>(jv.at("c"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::discard_if_default::Test2> {
+  static constexpr const char* value = "discard_if_default.Test2";
+};
+template<>
+struct type_name<::discard_if_default::Test> {
+  static constexpr const char* value = "discard_if_default.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
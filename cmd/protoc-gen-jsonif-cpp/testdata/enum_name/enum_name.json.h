@@ -0,0 +1,608 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ENUM_NAME_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ENUM_NAME_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace enum_name {
+
+enum Color {
+  COLOR_UNSPECIFIED = 0,
+  COLOR_RED = 1,
+  COLOR_BLUE = 2,
+};
+
+/// enum ごとの設定がファイルの設定より優先される
+enum Number {
+  NUMBER_ZERO = 0,
+  NUMBER_ONE = 1,
+};
+
+struct Test {
+  enum Nested {
+    NESTED_FOO = 0,
+    NESTED_BAR = 1,
+  };
+  
+  enum class ValueCase {
+    NOT_SET = 0,
+    kOneofColor = 7,
+    kOneofInt = 8,
+  };
+  ValueCase value_case = ValueCase::NOT_SET;
+  void clear_value_case() {
+    value_case = ValueCase::NOT_SET;
+    oneof_color = ::enum_name::Color();
+    oneof_int = int32_t();
+  }
+  
+  enum class OptColorCase {
+    NOT_SET = 0,
+    kOptColor = 6,
+  };
+  OptColorCase _opt_color_case = OptColorCase::NOT_SET;
+  void clear__opt_color_case() {
+    _opt_color_case = OptColorCase::NOT_SET;
+    opt_color = ::enum_name::Color();
+  }
+  
+  ::enum_name::Color color = (::enum_name::Color)0;
+  std::vector<::enum_name::Color> colors;
+  std::map<std::string, ::enum_name::Color> color_map;
+  ::enum_name::Number number = (::enum_name::Number)0;
+  ::enum_name::Test::Nested nested = (::enum_name::Test::Nested)0;
+  ::enum_name::Color opt_color = (::enum_name::Color)0;
+  void set_opt_color(::enum_name::Color opt_color) {
+    clear__opt_color_case();
+    _opt_color_case = OptColorCase::kOptColor;
+    this->opt_color = opt_color;
+  }
+  bool has_opt_color() const {
+    return _opt_color_case == OptColorCase::kOptColor;
+  }
+  void clear_opt_color() {
+    if (_opt_color_case == OptColorCase::kOptColor) {
+      clear__opt_color_case();
+    }
+  }
+  ::enum_name::Color oneof_color = (::enum_name::Color)0;
+  void set_oneof_color(::enum_name::Color oneof_color) {
+    clear_value_case();
+    value_case = ValueCase::kOneofColor;
+    this->oneof_color = oneof_color;
+  }
+  void clear_oneof_color() {
+    if (value_case == ValueCase::kOneofColor) {
+      clear_value_case();
+    }
+  }
+  int32_t oneof_int = 0;
+  void set_oneof_int(int32_t oneof_int) {
+    clear_value_case();
+    value_case = ValueCase::kOneofInt;
+    this->oneof_int = oneof_int;
+  }
+  void clear_oneof_int() {
+    if (value_case == ValueCase::kOneofInt) {
+      clear_value_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.color != b.color) return false;
+    if (a.colors != b.colors) return false;
+    if (a.color_map != b.color_map) return false;
+    if (a.number != b.number) return false;
+    if (a.nested != b.nested) return false;
+    if (a.value_case != b.value_case) return false;
+    if (a.value_case == ValueCase::kOneofColor && a.oneof_color != b.oneof_color) return false;
+    if (a.value_case == ValueCase::kOneofInt && a.oneof_int != b.oneof_int) return false;
+    if (a._opt_color_case != b._opt_color_case) return false;
+    if (a._opt_color_case == OptColorCase::kOptColor && a.opt_color != b.opt_color) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::enum_name::Color
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enum_name::Color& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enum_name::Color& v)
+#endif
+{
+  switch (v) {
+    case ::enum_name::COLOR_UNSPECIFIED:
+      jv = "COLOR_UNSPECIFIED";
+      break;
+    case ::enum_name::COLOR_RED:
+      jv = "COLOR_RED";
+      break;
+    case ::enum_name::COLOR_BLUE:
+      jv = "COLOR_BLUE";
+      break;
+    default:
+      jv = (int)v;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enum_name::Color& v) {
+  if (!jv.is_string()) {
+    v = (::enum_name::Color)jv.template get<int>();
+    return;
+  }
+  std::string s = jv.template get<std::string>();
+  if (s == "COLOR_UNSPECIFIED") {
+    v = ::enum_name::COLOR_UNSPECIFIED;
+    return;
+  }
+  if (s == "COLOR_RED") {
+    v = ::enum_name::COLOR_RED;
+    return;
+  }
+  if (s == "COLOR_BLUE") {
+    v = ::enum_name::COLOR_BLUE;
+    return;
+  }
+  v = (::enum_name::Color)0;
+}
+#else
+static ::enum_name::Color tag_invoke(const boost::json::value_to_tag<::enum_name::Color>&, const boost::json::value& jv) {
+  if (!jv.is_string()) {
+    return (::enum_name::Color)boost::json::value_to<int>(jv);
+  }
+  std::string s(jv.as_string().data(), jv.as_string().size());
+  if (s == "COLOR_UNSPECIFIED") return ::enum_name::COLOR_UNSPECIFIED;
+  if (s == "COLOR_RED") return ::enum_name::COLOR_RED;
+  if (s == "COLOR_BLUE") return ::enum_name::COLOR_BLUE;
+  return (::enum_name::Color)0;
+}
+#endif
+
+// ::enum_name::Number
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enum_name::Number& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enum_name::Number& v)
+#endif
+{
+  switch (v) {
+    case ::enum_name::NUMBER_ZERO:
+    case ::enum_name::NUMBER_ONE:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::enum_name::Number)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enum_name::Number& v) {
+  v = (::enum_name::Number)jv.template get<int>();
+}
+#else
+static ::enum_name::Number tag_invoke(const boost::json::value_to_tag<::enum_name::Number>&, const boost::json::value& jv) {
+  return (::enum_name::Number)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::enum_name::Test::Nested
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enum_name::Test::Nested& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enum_name::Test::Nested& v)
+#endif
+{
+  switch (v) {
+    case ::enum_name::Test::NESTED_FOO:
+      jv = "NESTED_FOO";
+      break;
+    case ::enum_name::Test::NESTED_BAR:
+      jv = "NESTED_BAR";
+      break;
+    default:
+      jv = (int)v;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enum_name::Test::Nested& v) {
+  if (!jv.is_string()) {
+    v = (::enum_name::Test::Nested)jv.template get<int>();
+    return;
+  }
+  std::string s = jv.template get<std::string>();
+  if (s == "NESTED_FOO") {
+    v = ::enum_name::Test::NESTED_FOO;
+    return;
+  }
+  if (s == "NESTED_BAR") {
+    v = ::enum_name::Test::NESTED_BAR;
+    return;
+  }
+  v = (::enum_name::Test::Nested)0;
+}
+#else
+static ::enum_name::Test::Nested tag_invoke(const boost::json::value_to_tag<::enum_name::Test::Nested>&, const boost::json::value& jv) {
+  if (!jv.is_string()) {
+    return (::enum_name::Test::Nested)boost::json::value_to<int>(jv);
+  }
+  std::string s(jv.as_string().data(), jv.as_string().size());
+  if (s == "NESTED_FOO") return ::enum_name::Test::NESTED_FOO;
+  if (s == "NESTED_BAR") return ::enum_name::Test::NESTED_BAR;
+  return (::enum_name::Test::Nested)0;
+}
+#endif
+
+// ::enum_name::Test::ValueCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enum_name::Test::ValueCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enum_name::Test::ValueCase& v)
+#endif
+{
+  switch (v) {
+    case ::enum_name::Test::ValueCase::kOneofColor:
+    case ::enum_name::Test::ValueCase::kOneofInt:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::enum_name::Test::ValueCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enum_name::Test::ValueCase& v) {
+  v = (::enum_name::Test::ValueCase)jv.template get<int>();
+}
+#else
+static ::enum_name::Test::ValueCase tag_invoke(const boost::json::value_to_tag<::enum_name::Test::ValueCase>&, const boost::json::value& jv) {
+  return (::enum_name::Test::ValueCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::enum_name::Test::OptColorCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enum_name::Test::OptColorCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enum_name::Test::OptColorCase& v)
+#endif
+{
+  switch (v) {
+    case ::enum_name::Test::OptColorCase::kOptColor:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::enum_name::Test::OptColorCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enum_name::Test::OptColorCase& v) {
+  v = (::enum_name::Test::OptColorCase)jv.template get<int>();
+}
+#else
+static ::enum_name::Test::OptColorCase tag_invoke(const boost::json::value_to_tag<::enum_name::Test::OptColorCase>&, const boost::json::value& jv) {
+  return (::enum_name::Test::OptColorCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::enum_name::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enum_name::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enum_name::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["color"], v.color);
+  }
+  #else
+  obj["color"] = boost::json::value_from(v.color);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["colors"], v.colors);
+  }
+  #else
+  obj["colors"] = boost::json::value_from(v.colors);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.color_map) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["color_map"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.color_map) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["color_map"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["number"], v.number);
+  }
+  #else
+  obj["number"] = boost::json::value_from(v.number);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["nested"], v.nested);
+  }
+  #else
+  obj["nested"] = boost::json::value_from(v.nested);
+  #endif
+  if (v._opt_color_case == ::enum_name::Test::OptColorCase::kOptColor) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["opt_color"], v.opt_color);
+    }
+    #else
+    obj["opt_color"] = boost::json::value_from(v.opt_color);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["oneof_color"], v.oneof_color);
+  }
+  #else
+  obj["oneof_color"] = boost::json::value_from(v.oneof_color);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["oneof_int"], v.oneof_int);
+  }
+  #else
+  obj["oneof_int"] = boost::json::value_from(v.oneof_int);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value_case"], v.value_case);
+  }
+  #else
+  obj["value_case"] = boost::json::value_from(v.value_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enum_name::Test& v)
+#else
+static ::enum_name::Test tag_invoke(const boost::json::value_to_tag<::enum_name::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::enum_name::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("color"))
+  #else
+  if (jv.as_object().find("color") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("color"), v.color);
+    }
+    #else
+    v.color = boost::json::value_to<::enum_name::Color>(jv.at("color"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("colors"))
+  #else
+  if (jv.as_object().find("colors") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("colors"), v.colors);
+    }
+    #else
+    v.colors = boost::json::value_to<std::vector<::enum_name::Color>>(jv.at("colors"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("color_map"))
+  #else
+  if (jv.as_object().find("color_map") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    for (const auto& kv : jv.at("color_map").items()) {
+      using nlohmann::from_json;
+      std::string key = kv.key();
+      ::enum_name::Color value{};
+      from_json(kv.value(), value);
+      v.color_map.emplace(key, std::move(value));
+    }
+    #else
+    for (const auto& kv : jv.at("color_map").as_object()) {
+      std::string key(kv.key().data(), kv.key().size());
+      v.color_map.emplace(key, boost::json::value_to<::enum_name::Color>(kv.value()));
+    }
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("number"))
+  #else
+  if (jv.as_object().find("number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("number"), v.number);
+    }
+    #else
+    v.number = boost::json::value_to<::enum_name::Number>(jv.at("number"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("nested"))
+  #else
+  if (jv.as_object().find("nested") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("nested"), v.nested);
+    }
+    #else
+    v.nested = boost::json::value_to<::enum_name::Test::Nested>(jv.at("nested"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("opt_color"))
+  #else
+  if (jv.as_object().find("opt_color") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("opt_color"), v.opt_color);
+    }
+    #else
+    v.opt_color = boost::json::value_to<::enum_name::Color>(jv.at("opt_color"));
+    #endif
+    v._opt_color_case = ::enum_name::Test::OptColorCase::kOptColor;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("oneof_color"))
+  #else
+  if (jv.as_object().find("oneof_color") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("oneof_color"), v.oneof_color);
+    }
+    #else
+    v.oneof_color = boost::json::value_to<::enum_name::Color>(jv.at("oneof_color"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("oneof_int"))
+  #else
+  if (jv.as_object().find("oneof_int") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("oneof_int"), v.oneof_int);
+    }
+    #else
+    v.oneof_int = boost::json::value_to<int32_t>(jv.at("oneof_int"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value_case"), v.value_case);
+  }
+  #else
+  v.value_case = boost::json::value_to<::enum_name::Test::ValueCase>(jv.at("value_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::enum_name::Test> {
+  static constexpr const char* value = "enum_name.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
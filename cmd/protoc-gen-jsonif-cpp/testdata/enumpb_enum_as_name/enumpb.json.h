@@ -0,0 +1,116 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ENUMPB_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ENUMPB_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace enumpb {
+
+enum Data {
+  FOO = 0,
+  BAR = 1,
+};
+
+// ::enumpb::Data
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::enumpb::Data& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::enumpb::Data& v)
+#endif
+{
+  switch (v) {
+    case ::enumpb::FOO:
+      jv = "FOO";
+      break;
+    case ::enumpb::BAR:
+      jv = "BAR";
+      break;
+    default:
+      jv = (int)v;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::enumpb::Data& v) {
+  if (!jv.is_string()) {
+    v = (::enumpb::Data)jv.template get<int>();
+    return;
+  }
+  std::string s = jv.template get<std::string>();
+  if (s == "FOO") {
+    v = ::enumpb::FOO;
+    return;
+  }
+  if (s == "BAR") {
+    v = ::enumpb::BAR;
+    return;
+  }
+  v = (::enumpb::Data)0;
+}
+#else
+static ::enumpb::Data tag_invoke(const boost::json::value_to_tag<::enumpb::Data>&, const boost::json::value& jv) {
+  if (!jv.is_string()) {
+    return (::enumpb::Data)boost::json::value_to<int>(jv);
+  }
+  std::string s(jv.as_string().data(), jv.as_string().size());
+  if (s == "FOO") return ::enumpb::FOO;
+  if (s == "BAR") return ::enumpb::BAR;
+  return (::enumpb::Data)0;
+}
+#endif
+
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,489 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_IMPORTING_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_IMPORTING_PROTO
+
+#include <string>
+#include <vector>
+#include <chrono>
+#include <optional>
+#include <stdexcept>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_WELL_KNOWN_TYPES_DEFINED
+#define JSONIF_WELL_KNOWN_TYPES_DEFINED
+
+namespace jsonif {
+
+// google.protobuf.Struct, Value, ListValue は JSON の値をそのまま保持する
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json json_value;
+#else
+typedef boost::json::value json_value;
+#endif
+
+// google.protobuf.Any は "@type" を含む JSON のオブジェクトをそのまま保持する
+// 値が無い場合は null になる
+struct any {
+  json_value value;
+  
+  // "@type" の型の URL から取り出したメッセージの完全修飾名
+  std::string type_name() const {
+    if (!value.is_object()) return "";
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    auto it = value.find("@type");
+    if (it == value.end() || !it->is_string()) return "";
+    std::string url = it->get<std::string>();
+    #else
+    auto p = value.as_object().if_contains("@type");
+    if (p == nullptr || !p->is_string()) return "";
+    std::string url(p->as_string().data(), p->as_string().size());
+    #endif
+    return url.substr(url.rfind('/') + 1);
+  }
+  
+  friend bool operator==(const any& a, const any& b) { return a.value == b.value; }
+  friend bool operator!=(const any& a, const any& b) { return !(a == b); }
+};
+
+// メッセージを google.protobuf.Any に詰める
+template<class T>
+inline any pack(const T& v) {
+  std::string url = std::string("type.googleapis.com/") + ::jsonif::type_name<T>::value;
+  any a;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  a.value = v;
+  a.value["@type"] = url;
+  #else
+  // "@type" を先頭に出力する
+  boost::json::value fields = boost::json::value_from(v);
+  boost::json::object obj;
+  obj["@type"] = url;
+  for (const auto& kv : fields.as_object()) {
+    obj[kv.key()] = kv.value();
+  }
+  a.value = std::move(obj);
+  #endif
+  return a;
+}
+
+// google.protobuf.Any に入っているのが T かどうか
+template<class T>
+inline bool is(const any& a) {
+  return a.type_name() == ::jsonif::type_name<T>::value;
+}
+
+// google.protobuf.Any から T を取り出す。T ではない場合は std::nullopt を返す
+template<class T>
+inline std::optional<T> unpack(const any& a) {
+  if (!is<T>(a)) return std::nullopt;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return a.value.get<T>();
+  #else
+  return boost::json::value_to<T>(a.value);
+  #endif
+}
+
+namespace detail {
+
+// "@type" の無いオブジェクトは google.protobuf.Any として読み込めない
+inline any make_any(json_value jv) {
+  any a;
+  a.value = std::move(jv);
+  if (!a.value.is_null() && a.type_name().empty()) {
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Any: @type is required"));
+  }
+  return a;
+}
+
+typedef std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> timestamp;
+
+// 1970-01-01 からの日数と年月日を変換する
+// http://howardhinnant.github.io/date_algorithms.html
+inline int64_t days_from_civil(int64_t y, int64_t m, int64_t d) {
+  y -= m <= 2;
+  const int64_t era = (y >= 0 ? y : y - 399) / 400;
+  const int64_t yoe = y - era * 400;
+  const int64_t doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;
+  const int64_t doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+  return era * 146097 + doe - 719468;
+}
+inline void civil_from_days(int64_t z, int64_t& y, int64_t& m, int64_t& d) {
+  z += 719468;
+  const int64_t era = (z >= 0 ? z : z - 146096) / 146097;
+  const int64_t doe = z - era * 146097;
+  const int64_t yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+  const int64_t doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+  const int64_t mp = (5 * doy + 2) / 153;
+  d = doy - (153 * mp + 2) / 5 + 1;
+  m = mp < 10 ? mp + 3 : mp - 9;
+  y = yoe + era * 400 + (m <= 2);
+}
+
+// 小数部は 0, 3, 6, 9 桁のいずれかで出力する
+inline std::string format_nanos(int64_t nanos) {
+  char buf[16];
+  if (nanos == 0) {
+    return "";
+  } else if (nanos % 1000000 == 0) {
+    snprintf(buf, sizeof(buf), ".%03d", (int)(nanos / 1000000));
+  } else if (nanos % 1000 == 0) {
+    snprintf(buf, sizeof(buf), ".%06d", (int)(nanos / 1000));
+  } else {
+    snprintf(buf, sizeof(buf), ".%09d", (int)nanos);
+  }
+  return buf;
+}
+inline bool parse_digits(const std::string& s, size_t& i, size_t n, int64_t& r) {
+  r = 0;
+  for (size_t end = i + n; i < end; i++) {
+    if (i >= s.size() || s[i] < '0' || '9' < s[i]) return false;
+    r = r * 10 + (s[i] - '0');
+  }
+  return true;
+}
+inline bool parse_char(const std::string& s, size_t& i, const char* cs) {
+  if (i >= s.size() || strchr(cs, s[i]) == nullptr) return false;
+  i++;
+  return true;
+}
+inline bool parse_nanos(const std::string& s, size_t& i, int64_t& nanos) {
+  nanos = 0;
+  int digits = 0;
+  if (i < s.size() && s[i] == '.') {
+    i++;
+    for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++, digits++) {
+      if (digits == 9) return false;
+      nanos = nanos * 10 + (s[i] - '0');
+    }
+    if (digits == 0) return false;
+  }
+  for (; digits < 9; digits++) {
+    nanos *= 10;
+  }
+  return true;
+}
+
+// google.protobuf.Timestamp は RFC 3339 形式の文字列にする（例: 1972-01-01T10:00:20.021Z）
+inline std::string format_timestamp(const timestamp& v) {
+  int64_t ns = v.time_since_epoch().count();
+  int64_t secs = ns / 1000000000;
+  int64_t nanos = ns % 1000000000;
+  if (nanos < 0) {
+    secs -= 1;
+    nanos += 1000000000;
+  }
+  int64_t days = secs / 86400;
+  int64_t rem = secs % 86400;
+  if (rem < 0) {
+    days -= 1;
+    rem += 86400;
+  }
+  int64_t y, m, d;
+  civil_from_days(days, y, m, d);
+  char buf[32];
+  snprintf(buf, sizeof(buf), "%04d-%02d-%02dT%02d:%02d:%02d", (int)y, (int)m, (int)d,
+           (int)(rem / 3600), (int)(rem / 60 % 60), (int)(rem % 60));
+  return buf + format_nanos(nanos) + "Z";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_timestamp(const std::string& s, timestamp& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  int64_t y = 0, m = 0, d = 0, hh = 0, mm = 0, ss = 0, nanos = 0;
+  int64_t offset = 0;
+  bool ok = parse_digits(s, i, 4, y) && parse_char(s, i, "-") && parse_digits(s, i, 2, m) &&
+            parse_char(s, i, "-") && parse_digits(s, i, 2, d) && parse_char(s, i, "Tt") &&
+            parse_digits(s, i, 2, hh) && parse_char(s, i, ":") && parse_digits(s, i, 2, mm) &&
+            parse_char(s, i, ":") && parse_digits(s, i, 2, ss) && parse_nanos(s, i, nanos);
+  if (ok && !parse_char(s, i, "Zz")) {
+    // UTC 以外の場合は +09:00 のようなオフセットが付いている
+    int64_t sign = i < s.size() && s[i] == '-' ? -1 : 1;
+    int64_t oh = 0, om = 0;
+    ok = parse_char(s, i, "+-") && parse_digits(s, i, 2, oh) && parse_char(s, i, ":") && parse_digits(s, i, 2, om);
+    offset = sign * (oh * 3600 + om * 60);
+  }
+  if (!ok || i != s.size() || m < 1 || 12 < m || d < 1 || 31 < d || 23 < hh || 59 < mm || 59 < ss) {
+    return false;
+  }
+  int64_t secs = days_from_civil(y, m, d) * 86400 + hh * 3600 + mm * 60 + ss - offset;
+  // ナノ秒で表現できる範囲（1677 年から 2262 年まで）を超える場合はエラーにする
+  if (secs < -9223372035 || 9223372035 < secs) {
+    out_of_range = true;
+    return false;
+  }
+  v = timestamp(std::chrono::nanoseconds(secs * 1000000000 + nanos));
+  return true;
+}
+inline timestamp parse_timestamp(const std::string& s) {
+  timestamp v;
+  bool out_of_range;
+  if (!parse_timestamp(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Timestamp out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Timestamp: " + s));
+  }
+  return v;
+}
+
+// google.protobuf.Duration は秒数の後ろに s を付けた文字列にする（例: 1.5s）
+inline std::string format_duration(std::chrono::nanoseconds v) {
+  int64_t ns = v.count();
+  // 符号を反転した時に溢れないように、符号無しで計算する
+  uint64_t abs = ns < 0 ? 0 - (uint64_t)ns : (uint64_t)ns;
+  return (ns < 0 ? "-" : "") + std::to_string(abs / 1000000000) + format_nanos((int64_t)(abs % 1000000000)) + "s";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_duration(const std::string& s, std::chrono::nanoseconds& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  bool neg = i < s.size() && s[i] == '-';
+  if (neg) i++;
+  size_t start = i;
+  int64_t secs = 0;
+  for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++) {
+    if (secs > 922337203) {
+      out_of_range = true;
+      return false;
+    }
+    secs = secs * 10 + (s[i] - '0');
+  }
+  int64_t nanos = 0;
+  if (i == start || !parse_nanos(s, i, nanos) || i + 1 != s.size() || s[i] != 's') {
+    return false;
+  }
+  if (secs > 9223372035) {
+    out_of_range = true;
+    return false;
+  }
+  int64_t ns = secs * 1000000000 + nanos;
+  v = std::chrono::nanoseconds(neg ? -ns : ns);
+  return true;
+}
+inline std::chrono::nanoseconds parse_duration(const std::string& s) {
+  std::chrono::nanoseconds v;
+  bool out_of_range;
+  if (!parse_duration(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Duration out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Duration: " + s));
+  }
+  return v;
+}
+
+}
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+
+namespace nlohmann {
+
+template<>
+struct adl_serializer<::jsonif::detail::timestamp> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::detail::timestamp& v) {
+    jv = ::jsonif::detail::format_timestamp(v);
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::detail::timestamp& v) {
+    v = ::jsonif::detail::parse_timestamp(jv.get<std::string>());
+  }
+};
+
+template<>
+struct adl_serializer<std::chrono::nanoseconds> {
+  static void to_json(nlohmann::json& jv, const std::chrono::nanoseconds& v) {
+    jv = ::jsonif::detail::format_duration(v);
+  }
+  static void from_json(const nlohmann::json& jv, std::chrono::nanoseconds& v) {
+    v = ::jsonif::detail::parse_duration(jv.get<std::string>());
+  }
+};
+
+// ラッパー型は値が無い場合に null になる
+template<class T>
+struct adl_serializer<std::optional<T>> {
+  static void to_json(nlohmann::json& jv, const std::optional<T>& v) {
+    if (v) {
+      jv = *v;
+    } else {
+      jv = nullptr;
+    }
+  }
+  static void from_json(const nlohmann::json& jv, std::optional<T>& v) {
+    if (jv.is_null()) {
+      v = std::nullopt;
+    } else {
+      v = jv.template get<T>();
+    }
+  }
+};
+
+template<>
+struct adl_serializer<::jsonif::any> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::any& v) {
+    jv = v.value;
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::any& v) {
+    v = ::jsonif::detail::make_any(jv);
+  }
+};
+
+}
+
+#else
+
+// std::optional は Boost.JSON が null として扱ってくれる
+// std::chrono の型は、ADL で見つかるように boost::json 名前空間に定義する
+namespace boost {
+namespace json {
+
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::detail::timestamp& v) {
+  jv = ::jsonif::detail::format_timestamp(v);
+}
+inline ::jsonif::detail::timestamp tag_invoke(const value_to_tag<::jsonif::detail::timestamp>&, const value& jv) {
+  return ::jsonif::detail::parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const std::chrono::nanoseconds& v) {
+  jv = ::jsonif::detail::format_duration(v);
+}
+inline std::chrono::nanoseconds tag_invoke(const value_to_tag<std::chrono::nanoseconds>&, const value& jv) {
+  return ::jsonif::detail::parse_duration(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::any& v) {
+  jv = v.value;
+}
+inline ::jsonif::any tag_invoke(const value_to_tag<::jsonif::any>&, const value& jv) {
+  return ::jsonif::detail::make_any(jv);
+}
+
+}
+}
+
+#endif
+
+#endif
+
+namespace importing {
+
+struct Test {
+  std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> t;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.t != b.t) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::importing::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::importing::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::importing::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  obj["t"] = v.t;
+  #else
+  obj["t"] = boost::json::value_from(v.t);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::importing::Test& v)
+#else
+static ::importing::Test tag_invoke(const boost::json::value_to_tag<::importing::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::importing::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv.at("t").get_to(v.t);
+  #else
+  v.t = boost::json::value_to<std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds>>(jv.at("t"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::importing::Test> {
+  static constexpr const char* value = "importing.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
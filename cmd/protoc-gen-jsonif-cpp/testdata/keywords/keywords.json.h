@@ -0,0 +1,511 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_KEYWORDS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_KEYWORDS_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace keywords {
+
+/// 各言語の予約語と同じ名前を使っても、コンパイルできるコードが生成されるか確認する用
+enum Keyword {
+  default_ = 0,
+  class_ = 1,
+};
+
+struct Test {
+  enum class KindCase {
+    NOT_SET = 0,
+    kNew = 10,
+    kThis = 11,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    new_ = int32_t();
+    this_ = std::string();
+  }
+  
+  enum class IntCase {
+    NOT_SET = 0,
+    kInt = 12,
+  };
+  IntCase _int_case = IntCase::NOT_SET;
+  void clear__int_case() {
+    _int_case = IntCase::NOT_SET;
+    int_ = int32_t();
+  }
+  
+  int32_t class_ = 0;
+  std::string default_;
+  bool delete_ = false;
+  std::string namespace_;
+  int32_t operator_ = 0;
+  std::string object;
+  std::string string;
+  std::vector<std::string> restrict;
+  ::keywords::Keyword constructor = (::keywords::Keyword)0;
+  int32_t new_ = 0;
+  void set_new(int32_t new_) {
+    clear_kind_case();
+    kind_case = KindCase::kNew;
+    this->new_ = new_;
+  }
+  void clear_new() {
+    if (kind_case == KindCase::kNew) {
+      clear_kind_case();
+    }
+  }
+  std::string this_;
+  void set_this(std::string this_) {
+    clear_kind_case();
+    kind_case = KindCase::kThis;
+    this->this_ = this_;
+  }
+  void clear_this() {
+    if (kind_case == KindCase::kThis) {
+      clear_kind_case();
+    }
+  }
+  int32_t int_ = 0;
+  void set_int(int32_t int_) {
+    clear__int_case();
+    _int_case = IntCase::kInt;
+    this->int_ = int_;
+  }
+  bool has_int() const {
+    return _int_case == IntCase::kInt;
+  }
+  void clear_int() {
+    if (_int_case == IntCase::kInt) {
+      clear__int_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.class_ != b.class_) return false;
+    if (a.default_ != b.default_) return false;
+    if (a.delete_ != b.delete_) return false;
+    if (a.namespace_ != b.namespace_) return false;
+    if (a.operator_ != b.operator_) return false;
+    if (a.object != b.object) return false;
+    if (a.string != b.string) return false;
+    if (a.restrict != b.restrict) return false;
+    if (a.constructor != b.constructor) return false;
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kNew && a.new_ != b.new_) return false;
+    if (a.kind_case == KindCase::kThis && a.this_ != b.this_) return false;
+    if (a._int_case != b._int_case) return false;
+    if (a._int_case == IntCase::kInt && a.int_ != b.int_) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::keywords::Keyword
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::keywords::Keyword& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::keywords::Keyword& v)
+#endif
+{
+  switch (v) {
+    case ::keywords::default_:
+    case ::keywords::class_:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::keywords::Keyword)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::keywords::Keyword& v) {
+  v = (::keywords::Keyword)jv.template get<int>();
+}
+#else
+static ::keywords::Keyword tag_invoke(const boost::json::value_to_tag<::keywords::Keyword>&, const boost::json::value& jv) {
+  return (::keywords::Keyword)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::keywords::Test::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::keywords::Test::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::keywords::Test::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::keywords::Test::KindCase::kNew:
+    case ::keywords::Test::KindCase::kThis:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::keywords::Test::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::keywords::Test::KindCase& v) {
+  v = (::keywords::Test::KindCase)jv.template get<int>();
+}
+#else
+static ::keywords::Test::KindCase tag_invoke(const boost::json::value_to_tag<::keywords::Test::KindCase>&, const boost::json::value& jv) {
+  return (::keywords::Test::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::keywords::Test::IntCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::keywords::Test::IntCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::keywords::Test::IntCase& v)
+#endif
+{
+  switch (v) {
+    case ::keywords::Test::IntCase::kInt:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::keywords::Test::IntCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::keywords::Test::IntCase& v) {
+  v = (::keywords::Test::IntCase)jv.template get<int>();
+}
+#else
+static ::keywords::Test::IntCase tag_invoke(const boost::json::value_to_tag<::keywords::Test::IntCase>&, const boost::json::value& jv) {
+  return (::keywords::Test::IntCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::keywords::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::keywords::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::keywords::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["class"], v.class_);
+  }
+  #else
+  obj["class"] = boost::json::value_from(v.class_);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["default"], v.default_);
+  }
+  #else
+  obj["default"] = boost::json::value_from(v.default_);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["delete"], v.delete_);
+  }
+  #else
+  obj["delete"] = boost::json::value_from(v.delete_);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["namespace"], v.namespace_);
+  }
+  #else
+  obj["namespace"] = boost::json::value_from(v.namespace_);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["operator"], v.operator_);
+  }
+  #else
+  obj["operator"] = boost::json::value_from(v.operator_);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["object"], v.object);
+  }
+  #else
+  obj["object"] = boost::json::value_from(v.object);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["string"], v.string);
+  }
+  #else
+  obj["string"] = boost::json::value_from(v.string);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["restrict"], v.restrict);
+  }
+  #else
+  obj["restrict"] = boost::json::value_from(v.restrict);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["constructor"], v.constructor);
+  }
+  #else
+  obj["constructor"] = boost::json::value_from(v.constructor);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["new"], v.new_);
+  }
+  #else
+  obj["new"] = boost::json::value_from(v.new_);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["this"], v.this_);
+  }
+  #else
+  obj["this"] = boost::json::value_from(v.this_);
+  #endif
+  if (v._int_case == ::keywords::Test::IntCase::kInt) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["int"], v.int_);
+    }
+    #else
+    obj["int"] = boost::json::value_from(v.int_);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["kind_case"], v.kind_case);
+  }
+  #else
+  obj["kind_case"] = boost::json::value_from(v.kind_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::keywords::Test& v)
+#else
+static ::keywords::Test tag_invoke(const boost::json::value_to_tag<::keywords::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::keywords::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("class"), v.class_);
+  }
+  #else
+  v.class_ = boost::json::value_to<int32_t>(jv.at("class"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("default"), v.default_);
+  }
+  #else
+  v.default_ = boost::json::value_to<std::string>(jv.at("default"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("delete"), v.delete_);
+  }
+  #else
+  v.delete_ = boost::json::value_to<bool>(jv.at("delete"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("namespace"), v.namespace_);
+  }
+  #else
+  v.namespace_ = boost::json::value_to<std::string>(jv.at("namespace"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("operator"), v.operator_);
+  }
+  #else
+  v.operator_ = boost::json::value_to<int32_t>(jv.at("operator"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("object"), v.object);
+  }
+  #else
+  v.object = boost::json::value_to<std::string>(jv.at("object"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("string"), v.string);
+  }
+  #else
+  v.string = boost::json::value_to<std::string>(jv.at("string"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("restrict"), v.restrict);
+  }
+  #else
+  v.restrict = boost::json::value_to<std::vector<std::string>>(jv.at("restrict"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("constructor"), v.constructor);
+  }
+  #else
+  v.constructor = boost::json::value_to<::keywords::Keyword>(jv.at("constructor"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("new"))
+  #else
+  if (jv.as_object().find("new") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("new"), v.new_);
+    }
+    #else
+    v.new_ = boost::json::value_to<int32_t>(jv.at("new"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("this"))
+  #else
+  if (jv.as_object().find("this") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("this"), v.this_);
+    }
+    #else
+    v.this_ = boost::json::value_to<std::string>(jv.at("this"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("int"))
+  #else
+  if (jv.as_object().find("int") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("int"), v.int_);
+    }
+    #else
+    v.int_ = boost::json::value_to<int32_t>(jv.at("int"));
+    #endif
+    v._int_case = ::keywords::Test::IntCase::kInt;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("kind_case"), v.kind_case);
+  }
+  #else
+  v.kind_case = boost::json::value_to<::keywords::Test::KindCase>(jv.at("kind_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::keywords::Test> {
+  static constexpr const char* value = "keywords.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,407 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_MAP_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_MAP_PROTO
+
+#include <string>
+#include <vector>
+#include <unordered_map>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace mappb {
+
+enum Enum {
+  FOO = 0,
+  BAR = 1,
+};
+
+struct Message {
+  std::string name;
+  friend bool operator==(const Message& a, const Message& b) {
+    if (a.name != b.name) return false;
+    return true;
+  }
+  friend bool operator!=(const Message& a, const Message& b) { return !(a == b); }
+};
+
+struct Test {
+  std::unordered_map<std::string, int32_t> a;
+  std::unordered_map<int32_t, std::string> b;
+  std::unordered_map<int64_t, ::mappb::Enum> c;
+  std::unordered_map<std::string, ::mappb::Message> d;
+  std::unordered_map<bool, double> e;
+  std::unordered_map<uint64_t, bool> f;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.a != b.a) return false;
+    if (a.b != b.b) return false;
+    if (a.c != b.c) return false;
+    if (a.d != b.d) return false;
+    if (a.e != b.e) return false;
+    if (a.f != b.f) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::mappb::Enum
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::mappb::Enum& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::mappb::Enum& v)
+#endif
+{
+  switch (v) {
+    case ::mappb::FOO:
+    case ::mappb::BAR:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::mappb::Enum)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::mappb::Enum& v) {
+  v = (::mappb::Enum)jv.template get<int>();
+}
+#else
+static ::mappb::Enum tag_invoke(const boost::json::value_to_tag<::mappb::Enum>&, const boost::json::value& jv) {
+  return (::mappb::Enum)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::mappb::Message
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::mappb::Message& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::mappb::Message& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::mappb::Message& v)
+#else
+static ::mappb::Message tag_invoke(const boost::json::value_to_tag<::mappb::Message>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::mappb::Message v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::mappb::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::mappb::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::mappb::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.a) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["a"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.a) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["a"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.b) {
+      to_json(m[std::to_string(kv.first)], kv.second);
+    }
+    obj["b"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.b) {
+      m[std::to_string(kv.first)] = boost::json::value_from(kv.second);
+    }
+    obj["b"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.c) {
+      to_json(m[std::to_string(kv.first)], kv.second);
+    }
+    obj["c"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.c) {
+      m[std::to_string(kv.first)] = boost::json::value_from(kv.second);
+    }
+    obj["c"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.d) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["d"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.d) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["d"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.e) {
+      to_json(m[(kv.first ? "true" : "false")], kv.second);
+    }
+    obj["e"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.e) {
+      m[(kv.first ? "true" : "false")] = boost::json::value_from(kv.second);
+    }
+    obj["e"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.f) {
+      to_json(m[std::to_string(kv.first)], kv.second);
+    }
+    obj["f"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.f) {
+      m[std::to_string(kv.first)] = boost::json::value_from(kv.second);
+    }
+    obj["f"] = std::move(m);
+  }
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::mappb::Test& v)
+#else
+static ::mappb::Test tag_invoke(const boost::json::value_to_tag<::mappb::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::mappb::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("a").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    int32_t value{};
+    from_json(kv.value(), value);
+    v.a.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("a").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.a.emplace(key, boost::json::value_to<int32_t>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("b").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    std::string value{};
+    from_json(kv.value(), value);
+    v.b.emplace((int32_t)std::stoll(key), std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("b").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.b.emplace((int32_t)std::stoll(key), boost::json::value_to<std::string>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("c").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::mappb::Enum value{};
+    from_json(kv.value(), value);
+    v.c.emplace((int64_t)std::stoll(key), std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("c").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.c.emplace((int64_t)std::stoll(key), boost::json::value_to<::mappb::Enum>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("d").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::mappb::Message value{};
+    from_json(kv.value(), value);
+    v.d.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("d").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.d.emplace(key, boost::json::value_to<::mappb::Message>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("e").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    double value{};
+    from_json(kv.value(), value);
+    v.e.emplace(key == "true", std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("e").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.e.emplace(key == "true", boost::json::value_to<double>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("f").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    bool value{};
+    from_json(kv.value(), value);
+    v.f.emplace((uint64_t)std::stoull(key), std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("f").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.f.emplace((uint64_t)std::stoull(key), boost::json::value_to<bool>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::mappb::Message> {
+  static constexpr const char* value = "mappb.Message";
+};
+template<>
+struct type_name<::mappb::Test> {
+  static constexpr const char* value = "mappb.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,489 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_MESSAGE_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_MESSAGE_PROTO
+
+#include <string>
+#include <vector>
+#include <stdexcept>
+#include <string_view>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_CHECK_DEFINED
+#define JSONIF_CHECK_DEFINED
+
+namespace jsonif {
+
+// JSON を読み込めなかった理由
+struct error {
+  // JSON での位置（people[3].name など）。JSON 全体の場合は空文字列
+  std::string path;
+  std::string message;
+};
+
+// strict なメッセージを読み込めなかった時に投げる例外
+// what() は "people[3].name: expected string, got number" のような文字列になる
+class parse_error : public std::runtime_error {
+ public:
+  explicit parse_error(error e)
+      : std::runtime_error(e.path.empty() ? e.message : e.path + ": " + e.message), error_(std::move(e)) {}
+  const std::string& path() const { return error_.path; }
+  const std::string& message() const { return error_.message; }
+  
+ private:
+  error error_;
+};
+
+namespace detail {
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json check_json;
+#else
+typedef boost::json::value check_json;
+#endif
+
+inline const char* json_kind(const check_json& jv) {
+  if (jv.is_null()) return "null";
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.is_boolean()) return "boolean";
+  #else
+  if (jv.is_bool()) return "boolean";
+  #endif
+  if (jv.is_number()) return "number";
+  if (jv.is_string()) return "string";
+  if (jv.is_array()) return "array";
+  return "object";
+}
+
+// err を設定して false を返す
+inline bool fail(const std::string& path, std::string message, error& err) {
+  err.path = path;
+  err.message = std::move(message);
+  return false;
+}
+inline bool expected(const check_json& jv, const char* kind, const std::string& path, error& err) {
+  return fail(path, std::string("expected ") + kind + ", got " + json_kind(jv), err);
+}
+
+// path にキーや添字を追加して、追加する前の長さを返す
+inline size_t push_key(std::string& path, const char* key) {
+  size_t n = path.size();
+  if (n != 0) path += '.';
+  path += key;
+  return n;
+}
+inline size_t push_index(std::string& path, size_t i) {
+  size_t n = path.size();
+  path += "[" + std::to_string(i) + "]";
+  return n;
+}
+inline size_t push_map_key(std::string& path, const std::string& key) {
+  size_t n = path.size();
+  path += "[\"" + key + "\"]";
+  return n;
+}
+
+inline const check_json* find_key(const check_json& jv, const char* key) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  auto it = jv.find(key);
+  return it == jv.end() ? nullptr : &*it;
+  #else
+  return jv.as_object().if_contains(key);
+  #endif
+}
+inline bool missing_key(std::string& path, const char* key, error& err) {
+  push_key(path, key);
+  return fail(path, "missing key", err);
+}
+// keys は nullptr で終わる配列
+// "@type" は google.protobuf.Any に詰めた時に付くので、常に受け付ける
+inline bool check_unknown_keys(const check_json& jv, const char* const* keys, std::string& path, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    const std::string& key = kv.key();
+    #else
+    for (const auto& kv : jv.as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    #endif
+    bool known = key == "@type";
+    for (const char* const* p = keys; !known && *p != nullptr; ++p) {
+      known = key == *p;
+    }
+    if (!known) {
+      push_key(path, key.c_str());
+      return fail(path, "unknown key", err);
+    }
+  }
+  return true;
+}
+
+inline bool check_object(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_object() || expected(jv, "object", path, err);
+}
+inline bool check_array(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_array() || expected(jv, "array", path, err);
+}
+inline bool check_string(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_string() || expected(jv, "string", path, err);
+}
+inline bool check_bool(const check_json& jv, const std::string& path, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return jv.is_boolean() || expected(jv, "boolean", path, err);
+  #else
+  return jv.is_bool() || expected(jv, "boolean", path, err);
+  #endif
+}
+inline bool check_number(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_number() || expected(jv, "number", path, err);
+}
+// Boost.JSON の value_to と同じく、小数の数値は 1.0 のように整数として表せる場合だけ受け付ける
+inline bool check_integer(const check_json& jv, int64_t min, uint64_t max, const std::string& path, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.is_number_unsigned()) {
+    return jv.get<uint64_t>() <= max || fail(path, "out of range", err);
+  }
+  if (jv.is_number_integer()) {
+    int64_t v = jv.get<int64_t>();
+    return (v < 0 ? v >= min : (uint64_t)v <= max) || fail(path, "out of range", err);
+  }
+  if (!jv.is_number_float()) return expected(jv, "integer", path, err);
+  double d = jv.get<double>();
+  #else
+  if (jv.is_uint64()) {
+    return jv.get_uint64() <= max || fail(path, "out of range", err);
+  }
+  if (jv.is_int64()) {
+    int64_t v = jv.get_int64();
+    return (v < 0 ? v >= min : (uint64_t)v <= max) || fail(path, "out of range", err);
+  }
+  if (!jv.is_double()) return expected(jv, "integer", path, err);
+  double d = jv.get_double();
+  #endif
+  // 整数に変換できる範囲かどうかを先に調べて、変換して戻した値が同じなら整数として表せる
+  if (d < 0) {
+    if (d < -9223372036854775808.0) return fail(path, "out of range", err);
+    int64_t v = (int64_t)d;
+    if ((double)v != d) return expected(jv, "integer", path, err);
+    return v >= min || fail(path, "out of range", err);
+  }
+  if (!(d < 18446744073709551616.0)) return fail(path, "out of range", err);
+  uint64_t v = (uint64_t)d;
+  if ((double)v != d) return expected(jv, "integer", path, err);
+  return v <= max || fail(path, "out of range", err);
+}
+// 10 進数の整数の文字列で、範囲内かどうか
+inline bool is_integer_string(const std::string& s, int64_t min, uint64_t max) {
+  bool neg = !s.empty() && s[0] == '-';
+  size_t i = neg ? 1 : 0;
+  if (i == s.size()) return false;
+  uint64_t n = 0;
+  for (; i < s.size(); i++) {
+    if (s[i] < '0' || '9' < s[i]) return false;
+    uint64_t d = (uint64_t)(s[i] - '0');
+    if (n > (UINT64_MAX - d) / 10) return false;
+    n = n * 10 + d;
+  }
+  return neg ? n <= 0 - (uint64_t)min : n <= max;
+}
+// 文字列の int64 も数値の int64 も読み込めるようにする
+inline bool check_integer_string(const check_json& jv, int64_t min, uint64_t max, const std::string& path, error& err) {
+  if (!jv.is_string()) {
+    return check_integer(jv, min, max, path, err);
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  const std::string& s = jv.get_ref<const std::string&>();
+  #else
+  std::string s(jv.as_string().data(), jv.as_string().size());
+  #endif
+  return is_integer_string(s, min, max) || fail(path, "invalid integer", err);
+}
+// jsonif_enum_as_name の enum は名前の文字列も数値も読み込める
+inline bool check_enum(const check_json& jv, bool as_name, const std::string& path, error& err) {
+  if (as_name && jv.is_string()) return true;
+  if (as_name && !jv.is_number()) return expected(jv, "string or integer", path, err);
+  return check_integer(jv, INT32_MIN, INT32_MAX, path, err);
+}
+
+// 配列の各要素を f で調べる
+template<class F>
+inline bool check_each(const check_json& jv, std::string& path, error& err, F f) {
+  if (!check_array(jv, path, err)) return false;
+  size_t i = 0;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& x : jv) {
+    #else
+    for (const auto& x : jv.as_array()) {
+    #endif
+    size_t n = push_index(path, i++);
+    if (!f(x)) return false;
+    path.resize(n);
+  }
+  return true;
+}
+// map のキーを is_key で、値を f で調べる
+template<class K, class F>
+inline bool check_map(const check_json& jv, std::string& path, error& err, K is_key, F f) {
+  if (!check_object(jv, path, err)) return false;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    std::string key = kv.key();
+    #else
+    for (const auto& kv : jv.as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    #endif
+    size_t n = push_map_key(path, key);
+    if (!is_key(key)) return fail(path, "invalid map key", err);
+    if (!f(kv.value())) return false;
+    path.resize(n);
+  }
+  return true;
+}
+
+// メッセージごとに生成した jsonif_check を ADL で呼び出す
+template<class T>
+inline bool check(const check_json& jv, std::string& path, error& err) {
+  return jsonif_check(jv, static_cast<const T*>(nullptr), path, err);
+}
+
+// strict なメッセージ（とそれを含むメッセージ）を読み込む前に、JSON 全体を調べて、問題があれば parse_error を投げる
+// ネストしたメッセージで何度も調べないように、一番外側のメッセージでだけ調べる
+class strict_scope {
+ public:
+  // 既に JSON 全体を調べてある場合は、中のメッセージで調べないようにする
+  strict_scope() : outermost_(!active()) {
+    active() = true;
+  }
+  template<class T>
+  strict_scope(const check_json& jv, const T*) : outermost_(!active()) {
+    if (!outermost_) return;
+    std::string path;
+    error err;
+    if (!check<T>(jv, path, err)) {
+      JSONIF_THROW(parse_error(std::move(err)));
+    }
+    active() = true;
+  }
+  ~strict_scope() {
+    if (outermost_) active() = false;
+  }
+  strict_scope(const strict_scope&) = delete;
+  strict_scope& operator=(const strict_scope&) = delete;
+  
+ private:
+  static bool& active() {
+    thread_local bool v = false;
+    return v;
+  }
+  bool outermost_;
+};
+
+}
+
+}
+
+#endif
+
+#ifndef JSONIF_TRY_FROM_JSON_DEFINED
+#define JSONIF_TRY_FROM_JSON_DEFINED
+
+namespace jsonif {
+
+// 例外を投げずに JSON を読み込む
+// 読み込めなかった場合は err に位置と理由を設定して false を返す
+// 先に JSON 全体を調べるので、-fno-exceptions でも使える
+template<class T>
+inline bool try_from_json(std::string_view s, T& v, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json jv = nlohmann::json::parse(s.begin(), s.end(), nullptr, false);
+  if (jv.is_discarded()) {
+    return detail::fail("", "invalid JSON", err);
+  }
+  #else
+  boost::json::error_code ec;
+  boost::json::value jv = boost::json::parse(boost::json::string_view(s.data(), s.size()), ec);
+  if (ec) {
+    return detail::fail("", "invalid JSON: " + ec.message(), err);
+  }
+  #endif
+  std::string path;
+  if (!detail::check<T>(jv, path, err)) {
+    return false;
+  }
+  detail::strict_scope checked;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  v = jv.get<T>();
+  #else
+  v = boost::json::value_to<T>(jv);
+  #endif
+  return true;
+}
+
+}
+
+#endif
+
+
+namespace message {
+
+struct Person {
+  std::string name;
+  bool flag = false;
+  friend bool operator==(const Person& a, const Person& b) {
+    if (a.name != b.name) return false;
+    if (a.flag != b.flag) return false;
+    return true;
+  }
+  friend bool operator!=(const Person& a, const Person& b) { return !(a == b); }
+};
+
+// ::message::Person
+static bool jsonif_check(const ::jsonif::detail::check_json& jv, const ::message::Person*, std::string& path, ::jsonif::error& err) {
+  if (!::jsonif::detail::check_object(jv, path, err)) return false;
+  if (const auto* p = ::jsonif::detail::find_key(jv, "name")) {
+    size_t n = ::jsonif::detail::push_key(path, "name");
+    if (!::jsonif::detail::check_string(*p, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "name", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "flag")) {
+    size_t n = ::jsonif::detail::push_key(path, "flag");
+    if (!::jsonif::detail::check_bool(*p, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "flag", err);
+  }
+  return true;
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::message::Person& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::message::Person& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["flag"], v.flag);
+  }
+  #else
+  obj["flag"] = boost::json::value_from(v.flag);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::message::Person& v)
+#else
+static ::message::Person tag_invoke(const boost::json::value_to_tag<::message::Person>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::message::Person v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("flag"), v.flag);
+  }
+  #else
+  v.flag = boost::json::value_to<bool>(jv.at("flag"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::message::Person> {
+  static constexpr const char* value = "message.Person";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,338 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_NESTED_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_NESTED_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace nested {
+namespace nested {
+
+struct Test {
+  enum NestedEnum {
+    FOO = 0,
+    BAR = 1,
+    HOGE = 2,
+  };
+  
+  struct NestedMessage {
+    std::string name;
+    friend bool operator==(const NestedMessage& a, const NestedMessage& b) {
+      if (a.name != b.name) return false;
+      return true;
+    }
+    friend bool operator!=(const NestedMessage& a, const NestedMessage& b) { return !(a == b); }
+  };
+  
+  ::nested::nested::Test::NestedMessage nested_message;
+  ::nested::nested::Test::NestedEnum nested_enum = (::nested::nested::Test::NestedEnum)0;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.nested_message != b.nested_message) return false;
+    if (a.nested_enum != b.nested_enum) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+struct Test2 {
+  ::nested::nested::Test test;
+  ::nested::nested::Test::NestedMessage nested_message;
+  ::nested::nested::Test::NestedEnum nested_enum = (::nested::nested::Test::NestedEnum)0;
+  friend bool operator==(const Test2& a, const Test2& b) {
+    if (a.test != b.test) return false;
+    if (a.nested_message != b.nested_message) return false;
+    if (a.nested_enum != b.nested_enum) return false;
+    return true;
+  }
+  friend bool operator!=(const Test2& a, const Test2& b) { return !(a == b); }
+};
+
+// ::nested::nested::Test::NestedEnum
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::nested::nested::Test::NestedEnum& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::nested::nested::Test::NestedEnum& v)
+#endif
+{
+  switch (v) {
+    case ::nested::nested::Test::FOO:
+    case ::nested::nested::Test::BAR:
+    case ::nested::nested::Test::HOGE:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::nested::nested::Test::NestedEnum)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::nested::nested::Test::NestedEnum& v) {
+  v = (::nested::nested::Test::NestedEnum)jv.template get<int>();
+}
+#else
+static ::nested::nested::Test::NestedEnum tag_invoke(const boost::json::value_to_tag<::nested::nested::Test::NestedEnum>&, const boost::json::value& jv) {
+  return (::nested::nested::Test::NestedEnum)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::nested::nested::Test::NestedMessage
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::nested::nested::Test::NestedMessage& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::nested::nested::Test::NestedMessage& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::nested::nested::Test::NestedMessage& v)
+#else
+static ::nested::nested::Test::NestedMessage tag_invoke(const boost::json::value_to_tag<::nested::nested::Test::NestedMessage>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::nested::nested::Test::NestedMessage v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::nested::nested::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::nested::nested::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::nested::nested::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["nested_message"], v.nested_message);
+  }
+  #else
+  obj["nested_message"] = boost::json::value_from(v.nested_message);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["nested_enum"], v.nested_enum);
+  }
+  #else
+  obj["nested_enum"] = boost::json::value_from(v.nested_enum);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::nested::nested::Test& v)
+#else
+static ::nested::nested::Test tag_invoke(const boost::json::value_to_tag<::nested::nested::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::nested::nested::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("nested_message"), v.nested_message);
+  }
+  #else
+  v.nested_message = boost::json::value_to<::nested::nested::Test::NestedMessage>(jv.at("nested_message"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("nested_enum"), v.nested_enum);
+  }
+  #else
+  v.nested_enum = boost::json::value_to<::nested::nested::Test::NestedEnum>(jv.at("nested_enum"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::nested::nested::Test2
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::nested::nested::Test2& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::nested::nested::Test2& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["test"], v.test);
+  }
+  #else
+  obj["test"] = boost::json::value_from(v.test);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["nested_message"], v.nested_message);
+  }
+  #else
+  obj["nested_message"] = boost::json::value_from(v.nested_message);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["nested_enum"], v.nested_enum);
+  }
+  #else
+  obj["nested_enum"] = boost::json::value_from(v.nested_enum);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::nested::nested::Test2& v)
+#else
+static ::nested::nested::Test2 tag_invoke(const boost::json::value_to_tag<::nested::nested::Test2>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::nested::nested::Test2 v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("test"), v.test);
+  }
+  #else
+  v.test = boost::json::value_to<::nested::nested::Test>(jv.at("test"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("nested_message"), v.nested_message);
+  }
+  #else
+  v.nested_message = boost::json::value_to<::nested::nested::Test::NestedMessage>(jv.at("nested_message"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("nested_enum"), v.nested_enum);
+  }
+  #else
+  v.nested_enum = boost::json::value_to<::nested::nested::Test::NestedEnum>(jv.at("nested_enum"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::nested::nested::Test::NestedMessage> {
+  static constexpr const char* value = "nested.nested.Test.NestedMessage";
+};
+template<>
+struct type_name<::nested::nested::Test> {
+  static constexpr const char* value = "nested.nested.Test";
+};
+template<>
+struct type_name<::nested::nested::Test2> {
+  static constexpr const char* value = "nested.nested.Test2";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,741 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ONEOF_ACTIVE_ONLY_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ONEOF_ACTIVE_ONLY_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace oneof_active_only {
+
+struct Inner {
+  int32_t value = 0;
+  friend bool operator==(const Inner& a, const Inner& b) {
+    if (a.value != b.value) return false;
+    return true;
+  }
+  friend bool operator!=(const Inner& a, const Inner& b) { return !(a == b); }
+};
+
+struct Test {
+  enum class KindCase {
+    NOT_SET = 0,
+    kName = 1,
+    kNumber = 2,
+    kInner = 3,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    name = std::string();
+    number = int32_t();
+    inner = ::oneof_active_only::Inner();
+  }
+  
+  /// メッセージの設定よりも oneof の設定が優先される
+  enum class LegacyCase {
+    NOT_SET = 0,
+    kLegacyName = 4,
+    kLegacyNumber = 5,
+  };
+  LegacyCase legacy_case = LegacyCase::NOT_SET;
+  void clear_legacy_case() {
+    legacy_case = LegacyCase::NOT_SET;
+    legacy_name = std::string();
+    legacy_number = int32_t();
+  }
+  
+  enum class OptCase {
+    NOT_SET = 0,
+    kOpt = 6,
+  };
+  OptCase _opt_case = OptCase::NOT_SET;
+  void clear__opt_case() {
+    _opt_case = OptCase::NOT_SET;
+    opt = int32_t();
+  }
+  
+  std::string name;
+  void set_name(std::string name) {
+    clear_kind_case();
+    kind_case = KindCase::kName;
+    this->name = name;
+  }
+  void clear_name() {
+    if (kind_case == KindCase::kName) {
+      clear_kind_case();
+    }
+  }
+  int32_t number = 0;
+  void set_number(int32_t number) {
+    clear_kind_case();
+    kind_case = KindCase::kNumber;
+    this->number = number;
+  }
+  void clear_number() {
+    if (kind_case == KindCase::kNumber) {
+      clear_kind_case();
+    }
+  }
+  ::oneof_active_only::Inner inner;
+  void set_inner(::oneof_active_only::Inner inner) {
+    clear_kind_case();
+    kind_case = KindCase::kInner;
+    this->inner = inner;
+  }
+  void clear_inner() {
+    if (kind_case == KindCase::kInner) {
+      clear_kind_case();
+    }
+  }
+  std::string legacy_name;
+  void set_legacy_name(std::string legacy_name) {
+    clear_legacy_case();
+    legacy_case = LegacyCase::kLegacyName;
+    this->legacy_name = legacy_name;
+  }
+  void clear_legacy_name() {
+    if (legacy_case == LegacyCase::kLegacyName) {
+      clear_legacy_case();
+    }
+  }
+  int32_t legacy_number = 0;
+  void set_legacy_number(int32_t legacy_number) {
+    clear_legacy_case();
+    legacy_case = LegacyCase::kLegacyNumber;
+    this->legacy_number = legacy_number;
+  }
+  void clear_legacy_number() {
+    if (legacy_case == LegacyCase::kLegacyNumber) {
+      clear_legacy_case();
+    }
+  }
+  int32_t opt = 0;
+  void set_opt(int32_t opt) {
+    clear__opt_case();
+    _opt_case = OptCase::kOpt;
+    this->opt = opt;
+  }
+  bool has_opt() const {
+    return _opt_case == OptCase::kOpt;
+  }
+  void clear_opt() {
+    if (_opt_case == OptCase::kOpt) {
+      clear__opt_case();
+    }
+  }
+  int32_t value = 0;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.value != b.value) return false;
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kName && a.name != b.name) return false;
+    if (a.kind_case == KindCase::kNumber && a.number != b.number) return false;
+    if (a.kind_case == KindCase::kInner && a.inner != b.inner) return false;
+    if (a.legacy_case != b.legacy_case) return false;
+    if (a.legacy_case == LegacyCase::kLegacyName && a.legacy_name != b.legacy_name) return false;
+    if (a.legacy_case == LegacyCase::kLegacyNumber && a.legacy_number != b.legacy_number) return false;
+    if (a._opt_case != b._opt_case) return false;
+    if (a._opt_case == OptCase::kOpt && a.opt != b.opt) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+struct Test2 {
+  enum class KindCase {
+    NOT_SET = 0,
+    kName = 1,
+    kNumber = 2,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    name = std::string();
+    number = int32_t();
+  }
+  
+  std::string name;
+  void set_name(std::string name) {
+    clear_kind_case();
+    kind_case = KindCase::kName;
+    this->name = name;
+  }
+  void clear_name() {
+    if (kind_case == KindCase::kName) {
+      clear_kind_case();
+    }
+  }
+  int32_t number = 0;
+  void set_number(int32_t number) {
+    clear_kind_case();
+    kind_case = KindCase::kNumber;
+    this->number = number;
+  }
+  void clear_number() {
+    if (kind_case == KindCase::kNumber) {
+      clear_kind_case();
+    }
+  }
+  friend bool operator==(const Test2& a, const Test2& b) {
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kName && a.name != b.name) return false;
+    if (a.kind_case == KindCase::kNumber && a.number != b.number) return false;
+    return true;
+  }
+  friend bool operator!=(const Test2& a, const Test2& b) { return !(a == b); }
+};
+
+// ::oneof_active_only::Inner
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Inner& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Inner& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value"], v.value);
+  }
+  #else
+  obj["value"] = boost::json::value_from(v.value);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Inner& v)
+#else
+static ::oneof_active_only::Inner tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Inner>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::oneof_active_only::Inner v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value"), v.value);
+  }
+  #else
+  v.value = boost::json::value_to<int32_t>(jv.at("value"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::oneof_active_only::Test::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Test::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Test::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::oneof_active_only::Test::KindCase::kName:
+    case ::oneof_active_only::Test::KindCase::kNumber:
+    case ::oneof_active_only::Test::KindCase::kInner:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::oneof_active_only::Test::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Test::KindCase& v) {
+  v = (::oneof_active_only::Test::KindCase)jv.template get<int>();
+}
+#else
+static ::oneof_active_only::Test::KindCase tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Test::KindCase>&, const boost::json::value& jv) {
+  return (::oneof_active_only::Test::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::oneof_active_only::Test::LegacyCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Test::LegacyCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Test::LegacyCase& v)
+#endif
+{
+  switch (v) {
+    case ::oneof_active_only::Test::LegacyCase::kLegacyName:
+    case ::oneof_active_only::Test::LegacyCase::kLegacyNumber:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::oneof_active_only::Test::LegacyCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Test::LegacyCase& v) {
+  v = (::oneof_active_only::Test::LegacyCase)jv.template get<int>();
+}
+#else
+static ::oneof_active_only::Test::LegacyCase tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Test::LegacyCase>&, const boost::json::value& jv) {
+  return (::oneof_active_only::Test::LegacyCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::oneof_active_only::Test::OptCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Test::OptCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Test::OptCase& v)
+#endif
+{
+  switch (v) {
+    case ::oneof_active_only::Test::OptCase::kOpt:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::oneof_active_only::Test::OptCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Test::OptCase& v) {
+  v = (::oneof_active_only::Test::OptCase)jv.template get<int>();
+}
+#else
+static ::oneof_active_only::Test::OptCase tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Test::OptCase>&, const boost::json::value& jv) {
+  return (::oneof_active_only::Test::OptCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::oneof_active_only::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  if (v.kind_case == ::oneof_active_only::Test::KindCase::kName) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["name"], v.name);
+    }
+    #else
+    obj["name"] = boost::json::value_from(v.name);
+    #endif
+  }
+  if (v.kind_case == ::oneof_active_only::Test::KindCase::kNumber) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["number"], v.number);
+    }
+    #else
+    obj["number"] = boost::json::value_from(v.number);
+    #endif
+  }
+  if (v.kind_case == ::oneof_active_only::Test::KindCase::kInner) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["inner"], v.inner);
+    }
+    #else
+    obj["inner"] = boost::json::value_from(v.inner);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["legacy_name"], v.legacy_name);
+  }
+  #else
+  obj["legacy_name"] = boost::json::value_from(v.legacy_name);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["legacy_number"], v.legacy_number);
+  }
+  #else
+  obj["legacy_number"] = boost::json::value_from(v.legacy_number);
+  #endif
+  if (v._opt_case == ::oneof_active_only::Test::OptCase::kOpt) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["opt"], v.opt);
+    }
+    #else
+    obj["opt"] = boost::json::value_from(v.opt);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value"], v.value);
+  }
+  #else
+  obj["value"] = boost::json::value_from(v.value);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["legacy_case"], v.legacy_case);
+  }
+  #else
+  obj["legacy_case"] = boost::json::value_from(v.legacy_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Test& v)
+#else
+static ::oneof_active_only::Test tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::oneof_active_only::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("name"))
+  #else
+  if (jv.as_object().find("name") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("name"), v.name);
+    }
+    #else
+    v.name = boost::json::value_to<std::string>(jv.at("name"));
+    #endif
+    v.kind_case = ::oneof_active_only::Test::KindCase::kName;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("number"))
+  #else
+  if (jv.as_object().find("number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("number"), v.number);
+    }
+    #else
+    v.number = boost::json::value_to<int32_t>(jv.at("number"));
+    #endif
+    v.kind_case = ::oneof_active_only::Test::KindCase::kNumber;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("inner"))
+  #else
+  if (jv.as_object().find("inner") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("inner"), v.inner);
+    }
+    #else
+    v.inner = boost::json::value_to<::oneof_active_only::Inner>(jv.at("inner"));
+    #endif
+    v.kind_case = ::oneof_active_only::Test::KindCase::kInner;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("legacy_name"))
+  #else
+  if (jv.as_object().find("legacy_name") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("legacy_name"), v.legacy_name);
+    }
+    #else
+    v.legacy_name = boost::json::value_to<std::string>(jv.at("legacy_name"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("legacy_number"))
+  #else
+  if (jv.as_object().find("legacy_number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("legacy_number"), v.legacy_number);
+    }
+    #else
+    v.legacy_number = boost::json::value_to<int32_t>(jv.at("legacy_number"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("opt"))
+  #else
+  if (jv.as_object().find("opt") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("opt"), v.opt);
+    }
+    #else
+    v.opt = boost::json::value_to<int32_t>(jv.at("opt"));
+    #endif
+    v._opt_case = ::oneof_active_only::Test::OptCase::kOpt;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value"), v.value);
+  }
+  #else
+  v.value = boost::json::value_to<int32_t>(jv.at("value"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("kind_case"))
+  #else
+  if (jv.as_object().find("kind_case") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("kind_case"), v.kind_case);
+    }
+    #else
+    v.kind_case = boost::json::value_to<::oneof_active_only::Test::KindCase>(jv.at("kind_case"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("legacy_case"), v.legacy_case);
+  }
+  #else
+  v.legacy_case = boost::json::value_to<::oneof_active_only::Test::LegacyCase>(jv.at("legacy_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::oneof_active_only::Test2::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Test2::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Test2::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::oneof_active_only::Test2::KindCase::kName:
+    case ::oneof_active_only::Test2::KindCase::kNumber:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::oneof_active_only::Test2::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Test2::KindCase& v) {
+  v = (::oneof_active_only::Test2::KindCase)jv.template get<int>();
+}
+#else
+static ::oneof_active_only::Test2::KindCase tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Test2::KindCase>&, const boost::json::value& jv) {
+  return (::oneof_active_only::Test2::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::oneof_active_only::Test2
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof_active_only::Test2& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof_active_only::Test2& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  if (v.kind_case == ::oneof_active_only::Test2::KindCase::kName) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["name"], v.name);
+    }
+    #else
+    obj["name"] = boost::json::value_from(v.name);
+    #endif
+  }
+  if (v.kind_case == ::oneof_active_only::Test2::KindCase::kNumber) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["number"], v.number);
+    }
+    #else
+    obj["number"] = boost::json::value_from(v.number);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof_active_only::Test2& v)
+#else
+static ::oneof_active_only::Test2 tag_invoke(const boost::json::value_to_tag<::oneof_active_only::Test2>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::oneof_active_only::Test2 v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("name"))
+  #else
+  if (jv.as_object().find("name") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("name"), v.name);
+    }
+    #else
+    v.name = boost::json::value_to<std::string>(jv.at("name"));
+    #endif
+    v.kind_case = ::oneof_active_only::Test2::KindCase::kName;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("number"))
+  #else
+  if (jv.as_object().find("number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("number"), v.number);
+    }
+    #else
+    v.number = boost::json::value_to<int32_t>(jv.at("number"));
+    #endif
+    v.kind_case = ::oneof_active_only::Test2::KindCase::kNumber;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("kind_case"))
+  #else
+  if (jv.as_object().find("kind_case") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("kind_case"), v.kind_case);
+    }
+    #else
+    v.kind_case = boost::json::value_to<::oneof_active_only::Test2::KindCase>(jv.at("kind_case"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::oneof_active_only::Inner> {
+  static constexpr const char* value = "oneof_active_only.Inner";
+};
+template<>
+struct type_name<::oneof_active_only::Test> {
+  static constexpr const char* value = "oneof_active_only.Test";
+};
+template<>
+struct type_name<::oneof_active_only::Test2> {
+  static constexpr const char* value = "oneof_active_only.Test2";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
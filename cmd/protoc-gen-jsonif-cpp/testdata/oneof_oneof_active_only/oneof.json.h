@@ -0,0 +1,416 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ONEOF_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_ONEOF_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace oneof {
+
+enum Enum {
+  FOO = 0,
+  BAR = 1,
+};
+
+struct Message {
+  std::string name;
+  friend bool operator==(const Message& a, const Message& b) {
+    if (a.name != b.name) return false;
+    return true;
+  }
+  friend bool operator!=(const Message& a, const Message& b) { return !(a == b); }
+};
+
+struct Test {
+  enum class TestOneofCase {
+    NOT_SET = 0,
+    kA = 1,
+    kB = 2,
+    kC = 3,
+    kD = 4,
+  };
+  TestOneofCase test_oneof_case = TestOneofCase::NOT_SET;
+  void clear_test_oneof_case() {
+    test_oneof_case = TestOneofCase::NOT_SET;
+    a = int32_t();
+    b = std::string();
+    c = ::oneof::Enum();
+    d = ::oneof::Message();
+  }
+  
+  int32_t a = 0;
+  void set_a(int32_t a) {
+    clear_test_oneof_case();
+    test_oneof_case = TestOneofCase::kA;
+    this->a = a;
+  }
+  void clear_a() {
+    if (test_oneof_case == TestOneofCase::kA) {
+      clear_test_oneof_case();
+    }
+  }
+  std::string b;
+  void set_b(std::string b) {
+    clear_test_oneof_case();
+    test_oneof_case = TestOneofCase::kB;
+    this->b = b;
+  }
+  void clear_b() {
+    if (test_oneof_case == TestOneofCase::kB) {
+      clear_test_oneof_case();
+    }
+  }
+  ::oneof::Enum c = (::oneof::Enum)0;
+  void set_c(::oneof::Enum c) {
+    clear_test_oneof_case();
+    test_oneof_case = TestOneofCase::kC;
+    this->c = c;
+  }
+  void clear_c() {
+    if (test_oneof_case == TestOneofCase::kC) {
+      clear_test_oneof_case();
+    }
+  }
+  ::oneof::Message d;
+  void set_d(::oneof::Message d) {
+    clear_test_oneof_case();
+    test_oneof_case = TestOneofCase::kD;
+    this->d = d;
+  }
+  void clear_d() {
+    if (test_oneof_case == TestOneofCase::kD) {
+      clear_test_oneof_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.test_oneof_case != b.test_oneof_case) return false;
+    if (a.test_oneof_case == TestOneofCase::kA && a.a != b.a) return false;
+    if (a.test_oneof_case == TestOneofCase::kB && a.b != b.b) return false;
+    if (a.test_oneof_case == TestOneofCase::kC && a.c != b.c) return false;
+    if (a.test_oneof_case == TestOneofCase::kD && a.d != b.d) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::oneof::Enum
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof::Enum& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof::Enum& v)
+#endif
+{
+  switch (v) {
+    case ::oneof::FOO:
+    case ::oneof::BAR:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::oneof::Enum)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof::Enum& v) {
+  v = (::oneof::Enum)jv.template get<int>();
+}
+#else
+static ::oneof::Enum tag_invoke(const boost::json::value_to_tag<::oneof::Enum>&, const boost::json::value& jv) {
+  return (::oneof::Enum)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::oneof::Message
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof::Message& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof::Message& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof::Message& v)
+#else
+static ::oneof::Message tag_invoke(const boost::json::value_to_tag<::oneof::Message>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::oneof::Message v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::oneof::Test::TestOneofCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof::Test::TestOneofCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof::Test::TestOneofCase& v)
+#endif
+{
+  switch (v) {
+    case ::oneof::Test::TestOneofCase::kA:
+    case ::oneof::Test::TestOneofCase::kB:
+    case ::oneof::Test::TestOneofCase::kC:
+    case ::oneof::Test::TestOneofCase::kD:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::oneof::Test::TestOneofCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof::Test::TestOneofCase& v) {
+  v = (::oneof::Test::TestOneofCase)jv.template get<int>();
+}
+#else
+static ::oneof::Test::TestOneofCase tag_invoke(const boost::json::value_to_tag<::oneof::Test::TestOneofCase>&, const boost::json::value& jv) {
+  return (::oneof::Test::TestOneofCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::oneof::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::oneof::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::oneof::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  if (v.test_oneof_case == ::oneof::Test::TestOneofCase::kA) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["a"], v.a);
+    }
+    #else
+    obj["a"] = boost::json::value_from(v.a);
+    #endif
+  }
+  if (v.test_oneof_case == ::oneof::Test::TestOneofCase::kB) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["b"], v.b);
+    }
+    #else
+    obj["b"] = boost::json::value_from(v.b);
+    #endif
+  }
+  if (v.test_oneof_case == ::oneof::Test::TestOneofCase::kC) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["c"], v.c);
+    }
+    #else
+    obj["c"] = boost::json::value_from(v.c);
+    #endif
+  }
+  if (v.test_oneof_case == ::oneof::Test::TestOneofCase::kD) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["d"], v.d);
+    }
+    #else
+    obj["d"] = boost::json::value_from(v.d);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::oneof::Test& v)
+#else
+static ::oneof::Test tag_invoke(const boost::json::value_to_tag<::oneof::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::oneof::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("a"))
+  #else
+  if (jv.as_object().find("a") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("a"), v.a);
+    }
+    #else
+    v.a = boost::json::value_to<int32_t>(jv.at("a"));
+    #endif
+    v.test_oneof_case = ::oneof::Test::TestOneofCase::kA;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("b"))
+  #else
+  if (jv.as_object().find("b") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("b"), v.b);
+    }
+    #else
+    v.b = boost::json::value_to<std::string>(jv.at("b"));
+    #endif
+    v.test_oneof_case = ::oneof::Test::TestOneofCase::kB;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("c"))
+  #else
+  if (jv.as_object().find("c") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("c"), v.c);
+    }
+    #else
+    v.c = boost::json::value_to<::oneof::Enum>(jv.at("c"));
+    #endif
+    v.test_oneof_case = ::oneof::Test::TestOneofCase::kC;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("d"))
+  #else
+  if (jv.as_object().find("d") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("d"), v.d);
+    }
+    #else
+    v.d = boost::json::value_to<::oneof::Message>(jv.at("d"));
+    #endif
+    v.test_oneof_case = ::oneof::Test::TestOneofCase::kD;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("test_oneof_case"))
+  #else
+  if (jv.as_object().find("test_oneof_case") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("test_oneof_case"), v.test_oneof_case);
+    }
+    #else
+    v.test_oneof_case = boost::json::value_to<::oneof::Test::TestOneofCase>(jv.at("test_oneof_case"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::oneof::Message> {
+  static constexpr const char* value = "oneof.Message";
+};
+template<>
+struct type_name<::oneof::Test> {
+  static constexpr const char* value = "oneof.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
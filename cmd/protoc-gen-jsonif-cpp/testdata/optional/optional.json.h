@@ -0,0 +1,518 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_OPTIONAL_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_OPTIONAL_PROTO
+
+#include <string>
+#include <vector>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+
+namespace optional {
+
+enum Enum {
+  FOO = 0,
+  BAR = 1,
+};
+
+struct Message {
+  std::string name;
+  friend bool operator==(const Message& a, const Message& b) {
+    if (a.name != b.name) return false;
+    return true;
+  }
+  friend bool operator!=(const Message& a, const Message& b) { return !(a == b); }
+};
+
+struct Test {
+  enum class ACase {
+    NOT_SET = 0,
+    kA = 1,
+  };
+  ACase _a_case = ACase::NOT_SET;
+  void clear__a_case() {
+    _a_case = ACase::NOT_SET;
+    a = int64_t();
+  }
+  
+  enum class BCase {
+    NOT_SET = 0,
+    kB = 3,
+  };
+  BCase _b_case = BCase::NOT_SET;
+  void clear__b_case() {
+    _b_case = BCase::NOT_SET;
+    b = std::string();
+  }
+  
+  enum class CCase {
+    NOT_SET = 0,
+    kC = 4,
+  };
+  CCase _c_case = CCase::NOT_SET;
+  void clear__c_case() {
+    _c_case = CCase::NOT_SET;
+    c = ::optional::Enum();
+  }
+  
+  enum class DCase {
+    NOT_SET = 0,
+    kD = 5,
+  };
+  DCase _d_case = DCase::NOT_SET;
+  void clear__d_case() {
+    _d_case = DCase::NOT_SET;
+    d = ::optional::Message();
+  }
+  
+  int64_t a = 0;
+  void set_a(int64_t a) {
+    clear__a_case();
+    _a_case = ACase::kA;
+    this->a = a;
+  }
+  bool has_a() const {
+    return _a_case == ACase::kA;
+  }
+  void clear_a() {
+    if (_a_case == ACase::kA) {
+      clear__a_case();
+    }
+  }
+  std::string b;
+  void set_b(std::string b) {
+    clear__b_case();
+    _b_case = BCase::kB;
+    this->b = b;
+  }
+  bool has_b() const {
+    return _b_case == BCase::kB;
+  }
+  void clear_b() {
+    if (_b_case == BCase::kB) {
+      clear__b_case();
+    }
+  }
+  ::optional::Enum c = (::optional::Enum)0;
+  void set_c(::optional::Enum c) {
+    clear__c_case();
+    _c_case = CCase::kC;
+    this->c = c;
+  }
+  bool has_c() const {
+    return _c_case == CCase::kC;
+  }
+  void clear_c() {
+    if (_c_case == CCase::kC) {
+      clear__c_case();
+    }
+  }
+  ::optional::Message d;
+  void set_d(::optional::Message d) {
+    clear__d_case();
+    _d_case = DCase::kD;
+    this->d = d;
+  }
+  bool has_d() const {
+    return _d_case == DCase::kD;
+  }
+  void clear_d() {
+    if (_d_case == DCase::kD) {
+      clear__d_case();
+    }
+  }
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a._a_case != b._a_case) return false;
+    if (a._a_case == ACase::kA && a.a != b.a) return false;
+    if (a._b_case != b._b_case) return false;
+    if (a._b_case == BCase::kB && a.b != b.b) return false;
+    if (a._c_case != b._c_case) return false;
+    if (a._c_case == CCase::kC && a.c != b.c) return false;
+    if (a._d_case != b._d_case) return false;
+    if (a._d_case == DCase::kD && a.d != b.d) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+// ::optional::Enum
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Enum& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Enum& v)
+#endif
+{
+  switch (v) {
+    case ::optional::FOO:
+    case ::optional::BAR:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::optional::Enum)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Enum& v) {
+  v = (::optional::Enum)jv.template get<int>();
+}
+#else
+static ::optional::Enum tag_invoke(const boost::json::value_to_tag<::optional::Enum>&, const boost::json::value& jv) {
+  return (::optional::Enum)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::optional::Message
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Message& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Message& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Message& v)
+#else
+static ::optional::Message tag_invoke(const boost::json::value_to_tag<::optional::Message>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::optional::Message v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::optional::Test::ACase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Test::ACase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Test::ACase& v)
+#endif
+{
+  switch (v) {
+    case ::optional::Test::ACase::kA:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::optional::Test::ACase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Test::ACase& v) {
+  v = (::optional::Test::ACase)jv.template get<int>();
+}
+#else
+static ::optional::Test::ACase tag_invoke(const boost::json::value_to_tag<::optional::Test::ACase>&, const boost::json::value& jv) {
+  return (::optional::Test::ACase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::optional::Test::BCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Test::BCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Test::BCase& v)
+#endif
+{
+  switch (v) {
+    case ::optional::Test::BCase::kB:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::optional::Test::BCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Test::BCase& v) {
+  v = (::optional::Test::BCase)jv.template get<int>();
+}
+#else
+static ::optional::Test::BCase tag_invoke(const boost::json::value_to_tag<::optional::Test::BCase>&, const boost::json::value& jv) {
+  return (::optional::Test::BCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::optional::Test::CCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Test::CCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Test::CCase& v)
+#endif
+{
+  switch (v) {
+    case ::optional::Test::CCase::kC:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::optional::Test::CCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Test::CCase& v) {
+  v = (::optional::Test::CCase)jv.template get<int>();
+}
+#else
+static ::optional::Test::CCase tag_invoke(const boost::json::value_to_tag<::optional::Test::CCase>&, const boost::json::value& jv) {
+  return (::optional::Test::CCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::optional::Test::DCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Test::DCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Test::DCase& v)
+#endif
+{
+  switch (v) {
+    case ::optional::Test::DCase::kD:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::optional::Test::DCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Test::DCase& v) {
+  v = (::optional::Test::DCase)jv.template get<int>();
+}
+#else
+static ::optional::Test::DCase tag_invoke(const boost::json::value_to_tag<::optional::Test::DCase>&, const boost::json::value& jv) {
+  return (::optional::Test::DCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::optional::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::optional::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::optional::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  if (v._a_case == ::optional::Test::ACase::kA) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["a"], v.a);
+    }
+    #else
+    obj["a"] = boost::json::value_from(v.a);
+    #endif
+  }
+  if (v._b_case == ::optional::Test::BCase::kB) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["b"], v.b);
+    }
+    #else
+    obj["b"] = boost::json::value_from(v.b);
+    #endif
+  }
+  if (v._c_case == ::optional::Test::CCase::kC) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["c"], v.c);
+    }
+    #else
+    obj["c"] = boost::json::value_from(v.c);
+    #endif
+  }
+  if (v._d_case == ::optional::Test::DCase::kD) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["d"], v.d);
+    }
+    #else
+    obj["d"] = boost::json::value_from(v.d);
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::optional::Test& v)
+#else
+static ::optional::Test tag_invoke(const boost::json::value_to_tag<::optional::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::optional::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("a"))
+  #else
+  if (jv.as_object().find("a") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("a"), v.a);
+    }
+    #else
+    v.a = boost::json::value_to<int64_t>(jv.at("a"));
+    #endif
+    v._a_case = ::optional::Test::ACase::kA;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("b"))
+  #else
+  if (jv.as_object().find("b") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("b"), v.b);
+    }
+    #else
+    v.b = boost::json::value_to<std::string>(jv.at("b"));
+    #endif
+    v._b_case = ::optional::Test::BCase::kB;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("c"))
+  #else
+  if (jv.as_object().find("c") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("c"), v.c);
+    }
+    #else
+    v.c = boost::json::value_to<::optional::Enum>(jv.at("c"));
+    #endif
+    v._c_case = ::optional::Test::CCase::kC;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("d"))
+  #else
+  if (jv.as_object().find("d") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("d"), v.d);
+    }
+    #else
+    v.d = boost::json::value_to<::optional::Message>(jv.at("d"));
+    #endif
+    v._d_case = ::optional::Test::DCase::kD;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::optional::Message> {
+  static constexpr const char* value = "optional.Message";
+};
+template<>
+struct type_name<::optional::Test> {
+  static constexpr const char* value = "optional.Test";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,1574 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_STRICT_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_STRICT_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <chrono>
+#include <optional>
+#include <stdexcept>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_WELL_KNOWN_TYPES_DEFINED
+#define JSONIF_WELL_KNOWN_TYPES_DEFINED
+
+namespace jsonif {
+
+// google.protobuf.Struct, Value, ListValue は JSON の値をそのまま保持する
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json json_value;
+#else
+typedef boost::json::value json_value;
+#endif
+
+// google.protobuf.Any は "@type" を含む JSON のオブジェクトをそのまま保持する
+// 値が無い場合は null になる
+struct any {
+  json_value value;
+  
+  // "@type" の型の URL から取り出したメッセージの完全修飾名
+  std::string type_name() const {
+    if (!value.is_object()) return "";
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    auto it = value.find("@type");
+    if (it == value.end() || !it->is_string()) return "";
+    std::string url = it->get<std::string>();
+    #else
+    auto p = value.as_object().if_contains("@type");
+    if (p == nullptr || !p->is_string()) return "";
+    std::string url(p->as_string().data(), p->as_string().size());
+    #endif
+    return url.substr(url.rfind('/') + 1);
+  }
+  
+  friend bool operator==(const any& a, const any& b) { return a.value == b.value; }
+  friend bool operator!=(const any& a, const any& b) { return !(a == b); }
+};
+
+// メッセージを google.protobuf.Any に詰める
+template<class T>
+inline any pack(const T& v) {
+  std::string url = std::string("type.googleapis.com/") + ::jsonif::type_name<T>::value;
+  any a;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  a.value = v;
+  a.value["@type"] = url;
+  #else
+  // "@type" を先頭に出力する
+  boost::json::value fields = boost::json::value_from(v);
+  boost::json::object obj;
+  obj["@type"] = url;
+  for (const auto& kv : fields.as_object()) {
+    obj[kv.key()] = kv.value();
+  }
+  a.value = std::move(obj);
+  #endif
+  return a;
+}
+
+// google.protobuf.Any に入っているのが T かどうか
+template<class T>
+inline bool is(const any& a) {
+  return a.type_name() == ::jsonif::type_name<T>::value;
+}
+
+// google.protobuf.Any から T を取り出す。T ではない場合は std::nullopt を返す
+template<class T>
+inline std::optional<T> unpack(const any& a) {
+  if (!is<T>(a)) return std::nullopt;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return a.value.get<T>();
+  #else
+  return boost::json::value_to<T>(a.value);
+  #endif
+}
+
+namespace detail {
+
+// "@type" の無いオブジェクトは google.protobuf.Any として読み込めない
+inline any make_any(json_value jv) {
+  any a;
+  a.value = std::move(jv);
+  if (!a.value.is_null() && a.type_name().empty()) {
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Any: @type is required"));
+  }
+  return a;
+}
+
+typedef std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> timestamp;
+
+// 1970-01-01 からの日数と年月日を変換する
+// http://howardhinnant.github.io/date_algorithms.html
+inline int64_t days_from_civil(int64_t y, int64_t m, int64_t d) {
+  y -= m <= 2;
+  const int64_t era = (y >= 0 ? y : y - 399) / 400;
+  const int64_t yoe = y - era * 400;
+  const int64_t doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;
+  const int64_t doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+  return era * 146097 + doe - 719468;
+}
+inline void civil_from_days(int64_t z, int64_t& y, int64_t& m, int64_t& d) {
+  z += 719468;
+  const int64_t era = (z >= 0 ? z : z - 146096) / 146097;
+  const int64_t doe = z - era * 146097;
+  const int64_t yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+  const int64_t doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+  const int64_t mp = (5 * doy + 2) / 153;
+  d = doy - (153 * mp + 2) / 5 + 1;
+  m = mp < 10 ? mp + 3 : mp - 9;
+  y = yoe + era * 400 + (m <= 2);
+}
+
+// 小数部は 0, 3, 6, 9 桁のいずれかで出力する
+inline std::string format_nanos(int64_t nanos) {
+  char buf[16];
+  if (nanos == 0) {
+    return "";
+  } else if (nanos % 1000000 == 0) {
+    snprintf(buf, sizeof(buf), ".%03d", (int)(nanos / 1000000));
+  } else if (nanos % 1000 == 0) {
+    snprintf(buf, sizeof(buf), ".%06d", (int)(nanos / 1000));
+  } else {
+    snprintf(buf, sizeof(buf), ".%09d", (int)nanos);
+  }
+  return buf;
+}
+inline bool parse_digits(const std::string& s, size_t& i, size_t n, int64_t& r) {
+  r = 0;
+  for (size_t end = i + n; i < end; i++) {
+    if (i >= s.size() || s[i] < '0' || '9' < s[i]) return false;
+    r = r * 10 + (s[i] - '0');
+  }
+  return true;
+}
+inline bool parse_char(const std::string& s, size_t& i, const char* cs) {
+  if (i >= s.size() || strchr(cs, s[i]) == nullptr) return false;
+  i++;
+  return true;
+}
+inline bool parse_nanos(const std::string& s, size_t& i, int64_t& nanos) {
+  nanos = 0;
+  int digits = 0;
+  if (i < s.size() && s[i] == '.') {
+    i++;
+    for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++, digits++) {
+      if (digits == 9) return false;
+      nanos = nanos * 10 + (s[i] - '0');
+    }
+    if (digits == 0) return false;
+  }
+  for (; digits < 9; digits++) {
+    nanos *= 10;
+  }
+  return true;
+}
+
+// google.protobuf.Timestamp は RFC 3339 形式の文字列にする（例: 1972-01-01T10:00:20.021Z）
+inline std::string format_timestamp(const timestamp& v) {
+  int64_t ns = v.time_since_epoch().count();
+  int64_t secs = ns / 1000000000;
+  int64_t nanos = ns % 1000000000;
+  if (nanos < 0) {
+    secs -= 1;
+    nanos += 1000000000;
+  }
+  int64_t days = secs / 86400;
+  int64_t rem = secs % 86400;
+  if (rem < 0) {
+    days -= 1;
+    rem += 86400;
+  }
+  int64_t y, m, d;
+  civil_from_days(days, y, m, d);
+  char buf[32];
+  snprintf(buf, sizeof(buf), "%04d-%02d-%02dT%02d:%02d:%02d", (int)y, (int)m, (int)d,
+           (int)(rem / 3600), (int)(rem / 60 % 60), (int)(rem % 60));
+  return buf + format_nanos(nanos) + "Z";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_timestamp(const std::string& s, timestamp& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  int64_t y = 0, m = 0, d = 0, hh = 0, mm = 0, ss = 0, nanos = 0;
+  int64_t offset = 0;
+  bool ok = parse_digits(s, i, 4, y) && parse_char(s, i, "-") && parse_digits(s, i, 2, m) &&
+            parse_char(s, i, "-") && parse_digits(s, i, 2, d) && parse_char(s, i, "Tt") &&
+            parse_digits(s, i, 2, hh) && parse_char(s, i, ":") && parse_digits(s, i, 2, mm) &&
+            parse_char(s, i, ":") && parse_digits(s, i, 2, ss) && parse_nanos(s, i, nanos);
+  if (ok && !parse_char(s, i, "Zz")) {
+    // UTC 以外の場合は +09:00 のようなオフセットが付いている
+    int64_t sign = i < s.size() && s[i] == '-' ? -1 : 1;
+    int64_t oh = 0, om = 0;
+    ok = parse_char(s, i, "+-") && parse_digits(s, i, 2, oh) && parse_char(s, i, ":") && parse_digits(s, i, 2, om);
+    offset = sign * (oh * 3600 + om * 60);
+  }
+  if (!ok || i != s.size() || m < 1 || 12 < m || d < 1 || 31 < d || 23 < hh || 59 < mm || 59 < ss) {
+    return false;
+  }
+  int64_t secs = days_from_civil(y, m, d) * 86400 + hh * 3600 + mm * 60 + ss - offset;
+  // ナノ秒で表現できる範囲（1677 年から 2262 年まで）を超える場合はエラーにする
+  if (secs < -9223372035 || 9223372035 < secs) {
+    out_of_range = true;
+    return false;
+  }
+  v = timestamp(std::chrono::nanoseconds(secs * 1000000000 + nanos));
+  return true;
+}
+inline timestamp parse_timestamp(const std::string& s) {
+  timestamp v;
+  bool out_of_range;
+  if (!parse_timestamp(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Timestamp out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Timestamp: " + s));
+  }
+  return v;
+}
+
+// google.protobuf.Duration は秒数の後ろに s を付けた文字列にする（例: 1.5s）
+inline std::string format_duration(std::chrono::nanoseconds v) {
+  int64_t ns = v.count();
+  // 符号を反転した時に溢れないように、符号無しで計算する
+  uint64_t abs = ns < 0 ? 0 - (uint64_t)ns : (uint64_t)ns;
+  return (ns < 0 ? "-" : "") + std::to_string(abs / 1000000000) + format_nanos((int64_t)(abs % 1000000000)) + "s";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_duration(const std::string& s, std::chrono::nanoseconds& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  bool neg = i < s.size() && s[i] == '-';
+  if (neg) i++;
+  size_t start = i;
+  int64_t secs = 0;
+  for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++) {
+    if (secs > 922337203) {
+      out_of_range = true;
+      return false;
+    }
+    secs = secs * 10 + (s[i] - '0');
+  }
+  int64_t nanos = 0;
+  if (i == start || !parse_nanos(s, i, nanos) || i + 1 != s.size() || s[i] != 's') {
+    return false;
+  }
+  if (secs > 9223372035) {
+    out_of_range = true;
+    return false;
+  }
+  int64_t ns = secs * 1000000000 + nanos;
+  v = std::chrono::nanoseconds(neg ? -ns : ns);
+  return true;
+}
+inline std::chrono::nanoseconds parse_duration(const std::string& s) {
+  std::chrono::nanoseconds v;
+  bool out_of_range;
+  if (!parse_duration(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Duration out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Duration: " + s));
+  }
+  return v;
+}
+
+}
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+
+namespace nlohmann {
+
+template<>
+struct adl_serializer<::jsonif::detail::timestamp> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::detail::timestamp& v) {
+    jv = ::jsonif::detail::format_timestamp(v);
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::detail::timestamp& v) {
+    v = ::jsonif::detail::parse_timestamp(jv.get<std::string>());
+  }
+};
+
+template<>
+struct adl_serializer<std::chrono::nanoseconds> {
+  static void to_json(nlohmann::json& jv, const std::chrono::nanoseconds& v) {
+    jv = ::jsonif::detail::format_duration(v);
+  }
+  static void from_json(const nlohmann::json& jv, std::chrono::nanoseconds& v) {
+    v = ::jsonif::detail::parse_duration(jv.get<std::string>());
+  }
+};
+
+// ラッパー型は値が無い場合に null になる
+template<class T>
+struct adl_serializer<std::optional<T>> {
+  static void to_json(nlohmann::json& jv, const std::optional<T>& v) {
+    if (v) {
+      jv = *v;
+    } else {
+      jv = nullptr;
+    }
+  }
+  static void from_json(const nlohmann::json& jv, std::optional<T>& v) {
+    if (jv.is_null()) {
+      v = std::nullopt;
+    } else {
+      v = jv.template get<T>();
+    }
+  }
+};
+
+template<>
+struct adl_serializer<::jsonif::any> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::any& v) {
+    jv = v.value;
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::any& v) {
+    v = ::jsonif::detail::make_any(jv);
+  }
+};
+
+}
+
+#else
+
+// std::optional は Boost.JSON が null として扱ってくれる
+// std::chrono の型は、ADL で見つかるように boost::json 名前空間に定義する
+namespace boost {
+namespace json {
+
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::detail::timestamp& v) {
+  jv = ::jsonif::detail::format_timestamp(v);
+}
+inline ::jsonif::detail::timestamp tag_invoke(const value_to_tag<::jsonif::detail::timestamp>&, const value& jv) {
+  return ::jsonif::detail::parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const std::chrono::nanoseconds& v) {
+  jv = ::jsonif::detail::format_duration(v);
+}
+inline std::chrono::nanoseconds tag_invoke(const value_to_tag<std::chrono::nanoseconds>&, const value& jv) {
+  return ::jsonif::detail::parse_duration(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::any& v) {
+  jv = v.value;
+}
+inline ::jsonif::any tag_invoke(const value_to_tag<::jsonif::any>&, const value& jv) {
+  return ::jsonif::detail::make_any(jv);
+}
+
+}
+}
+
+#endif
+
+#endif
+#ifndef JSONIF_CHECK_DEFINED
+#define JSONIF_CHECK_DEFINED
+
+namespace jsonif {
+
+// JSON を読み込めなかった理由
+struct error {
+  // JSON での位置（people[3].name など）。JSON 全体の場合は空文字列
+  std::string path;
+  std::string message;
+};
+
+// strict なメッセージを読み込めなかった時に投げる例外
+// what() は "people[3].name: expected string, got number" のような文字列になる
+class parse_error : public std::runtime_error {
+ public:
+  explicit parse_error(error e)
+      : std::runtime_error(e.path.empty() ? e.message : e.path + ": " + e.message), error_(std::move(e)) {}
+  const std::string& path() const { return error_.path; }
+  const std::string& message() const { return error_.message; }
+  
+ private:
+  error error_;
+};
+
+namespace detail {
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json check_json;
+#else
+typedef boost::json::value check_json;
+#endif
+
+inline const char* json_kind(const check_json& jv) {
+  if (jv.is_null()) return "null";
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.is_boolean()) return "boolean";
+  #else
+  if (jv.is_bool()) return "boolean";
+  #endif
+  if (jv.is_number()) return "number";
+  if (jv.is_string()) return "string";
+  if (jv.is_array()) return "array";
+  return "object";
+}
+
+// err を設定して false を返す
+inline bool fail(const std::string& path, std::string message, error& err) {
+  err.path = path;
+  err.message = std::move(message);
+  return false;
+}
+inline bool expected(const check_json& jv, const char* kind, const std::string& path, error& err) {
+  return fail(path, std::string("expected ") + kind + ", got " + json_kind(jv), err);
+}
+
+// path にキーや添字を追加して、追加する前の長さを返す
+inline size_t push_key(std::string& path, const char* key) {
+  size_t n = path.size();
+  if (n != 0) path += '.';
+  path += key;
+  return n;
+}
+inline size_t push_index(std::string& path, size_t i) {
+  size_t n = path.size();
+  path += "[" + std::to_string(i) + "]";
+  return n;
+}
+inline size_t push_map_key(std::string& path, const std::string& key) {
+  size_t n = path.size();
+  path += "[\"" + key + "\"]";
+  return n;
+}
+
+inline const check_json* find_key(const check_json& jv, const char* key) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  auto it = jv.find(key);
+  return it == jv.end() ? nullptr : &*it;
+  #else
+  return jv.as_object().if_contains(key);
+  #endif
+}
+inline bool missing_key(std::string& path, const char* key, error& err) {
+  push_key(path, key);
+  return fail(path, "missing key", err);
+}
+// keys は nullptr で終わる配列
+// "@type" は google.protobuf.Any に詰めた時に付くので、常に受け付ける
+inline bool check_unknown_keys(const check_json& jv, const char* const* keys, std::string& path, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    const std::string& key = kv.key();
+    #else
+    for (const auto& kv : jv.as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    #endif
+    bool known = key == "@type";
+    for (const char* const* p = keys; !known && *p != nullptr; ++p) {
+      known = key == *p;
+    }
+    if (!known) {
+      push_key(path, key.c_str());
+      return fail(path, "unknown key", err);
+    }
+  }
+  return true;
+}
+
+inline bool check_object(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_object() || expected(jv, "object", path, err);
+}
+inline bool check_array(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_array() || expected(jv, "array", path, err);
+}
+inline bool check_string(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_string() || expected(jv, "string", path, err);
+}
+inline bool check_bool(const check_json& jv, const std::string& path, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return jv.is_boolean() || expected(jv, "boolean", path, err);
+  #else
+  return jv.is_bool() || expected(jv, "boolean", path, err);
+  #endif
+}
+inline bool check_number(const check_json& jv, const std::string& path, error& err) {
+  return jv.is_number() || expected(jv, "number", path, err);
+}
+// Boost.JSON の value_to と同じく、小数の数値は 1.0 のように整数として表せる場合だけ受け付ける
+inline bool check_integer(const check_json& jv, int64_t min, uint64_t max, const std::string& path, error& err) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.is_number_unsigned()) {
+    return jv.get<uint64_t>() <= max || fail(path, "out of range", err);
+  }
+  if (jv.is_number_integer()) {
+    int64_t v = jv.get<int64_t>();
+    return (v < 0 ? v >= min : (uint64_t)v <= max) || fail(path, "out of range", err);
+  }
+  if (!jv.is_number_float()) return expected(jv, "integer", path, err);
+  double d = jv.get<double>();
+  #else
+  if (jv.is_uint64()) {
+    return jv.get_uint64() <= max || fail(path, "out of range", err);
+  }
+  if (jv.is_int64()) {
+    int64_t v = jv.get_int64();
+    return (v < 0 ? v >= min : (uint64_t)v <= max) || fail(path, "out of range", err);
+  }
+  if (!jv.is_double()) return expected(jv, "integer", path, err);
+  double d = jv.get_double();
+  #endif
+  // 整数に変換できる範囲かどうかを先に調べて、変換して戻した値が同じなら整数として表せる
+  if (d < 0) {
+    if (d < -9223372036854775808.0) return fail(path, "out of range", err);
+    int64_t v = (int64_t)d;
+    if ((double)v != d) return expected(jv, "integer", path, err);
+    return v >= min || fail(path, "out of range", err);
+  }
+  if (!(d < 18446744073709551616.0)) return fail(path, "out of range", err);
+  uint64_t v = (uint64_t)d;
+  if ((double)v != d) return expected(jv, "integer", path, err);
+  return v <= max || fail(path, "out of range", err);
+}
+// 10 進数の整数の文字列で、範囲内かどうか
+inline bool is_integer_string(const std::string& s, int64_t min, uint64_t max) {
+  bool neg = !s.empty() && s[0] == '-';
+  size_t i = neg ? 1 : 0;
+  if (i == s.size()) return false;
+  uint64_t n = 0;
+  for (; i < s.size(); i++) {
+    if (s[i] < '0' || '9' < s[i]) return false;
+    uint64_t d = (uint64_t)(s[i] - '0');
+    if (n > (UINT64_MAX - d) / 10) return false;
+    n = n * 10 + d;
+  }
+  return neg ? n <= 0 - (uint64_t)min : n <= max;
+}
+// 文字列の int64 も数値の int64 も読み込めるようにする
+inline bool check_integer_string(const check_json& jv, int64_t min, uint64_t max, const std::string& path, error& err) {
+  if (!jv.is_string()) {
+    return check_integer(jv, min, max, path, err);
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  const std::string& s = jv.get_ref<const std::string&>();
+  #else
+  std::string s(jv.as_string().data(), jv.as_string().size());
+  #endif
+  return is_integer_string(s, min, max) || fail(path, "invalid integer", err);
+}
+// jsonif_enum_as_name の enum は名前の文字列も数値も読み込める
+inline bool check_enum(const check_json& jv, bool as_name, const std::string& path, error& err) {
+  if (as_name && jv.is_string()) return true;
+  if (as_name && !jv.is_number()) return expected(jv, "string or integer", path, err);
+  return check_integer(jv, INT32_MIN, INT32_MAX, path, err);
+}
+
+// 配列の各要素を f で調べる
+template<class F>
+inline bool check_each(const check_json& jv, std::string& path, error& err, F f) {
+  if (!check_array(jv, path, err)) return false;
+  size_t i = 0;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& x : jv) {
+    #else
+    for (const auto& x : jv.as_array()) {
+    #endif
+    size_t n = push_index(path, i++);
+    if (!f(x)) return false;
+    path.resize(n);
+  }
+  return true;
+}
+// map のキーを is_key で、値を f で調べる
+template<class K, class F>
+inline bool check_map(const check_json& jv, std::string& path, error& err, K is_key, F f) {
+  if (!check_object(jv, path, err)) return false;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    std::string key = kv.key();
+    #else
+    for (const auto& kv : jv.as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    #endif
+    size_t n = push_map_key(path, key);
+    if (!is_key(key)) return fail(path, "invalid map key", err);
+    if (!f(kv.value())) return false;
+    path.resize(n);
+  }
+  return true;
+}
+
+// メッセージごとに生成した jsonif_check を ADL で呼び出す
+template<class T>
+inline bool check(const check_json& jv, std::string& path, error& err) {
+  return jsonif_check(jv, static_cast<const T*>(nullptr), path, err);
+}
+
+// strict なメッセージ（とそれを含むメッセージ）を読み込む前に、JSON 全体を調べて、問題があれば parse_error を投げる
+// ネストしたメッセージで何度も調べないように、一番外側のメッセージでだけ調べる
+class strict_scope {
+ public:
+  // 既に JSON 全体を調べてある場合は、中のメッセージで調べないようにする
+  strict_scope() : outermost_(!active()) {
+    active() = true;
+  }
+  template<class T>
+  strict_scope(const check_json& jv, const T*) : outermost_(!active()) {
+    if (!outermost_) return;
+    std::string path;
+    error err;
+    if (!check<T>(jv, path, err)) {
+      JSONIF_THROW(parse_error(std::move(err)));
+    }
+    active() = true;
+  }
+  ~strict_scope() {
+    if (outermost_) active() = false;
+  }
+  strict_scope(const strict_scope&) = delete;
+  strict_scope& operator=(const strict_scope&) = delete;
+  
+ private:
+  static bool& active() {
+    thread_local bool v = false;
+    return v;
+  }
+  bool outermost_;
+};
+
+}
+
+}
+
+#endif
+
+#ifndef JSONIF_CHECK_WELL_KNOWN_TYPES_DEFINED
+#define JSONIF_CHECK_WELL_KNOWN_TYPES_DEFINED
+
+namespace jsonif {
+namespace detail {
+
+inline bool check_timestamp(const check_json& jv, const std::string& path, error& err) {
+  if (!check_string(jv, path, err)) return false;
+  timestamp v;
+  bool out_of_range;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (parse_timestamp(jv.get_ref<const std::string&>(), v, out_of_range)) return true;
+  #else
+  if (parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()), v, out_of_range)) return true;
+  #endif
+  return fail(path, out_of_range ? "out of range" : "invalid timestamp", err);
+}
+inline bool check_duration(const check_json& jv, const std::string& path, error& err) {
+  if (!check_string(jv, path, err)) return false;
+  std::chrono::nanoseconds v;
+  bool out_of_range;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (parse_duration(jv.get_ref<const std::string&>(), v, out_of_range)) return true;
+  #else
+  if (parse_duration(std::string(jv.as_string().data(), jv.as_string().size()), v, out_of_range)) return true;
+  #endif
+  return fail(path, out_of_range ? "out of range" : "invalid duration", err);
+}
+// make_any と同じく、null 以外は "@type" に型の URL が必要
+inline bool check_any(const check_json& jv, std::string& path, error& err) {
+  if (jv.is_null()) return true;
+  if (!check_object(jv, path, err)) return false;
+  const check_json* p = find_key(jv, "@type");
+  if (p == nullptr) return missing_key(path, "@type", err);
+  size_t n = push_key(path, "@type");
+  if (!check_string(*p, path, err)) return false;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  const std::string& url = p->get_ref<const std::string&>();
+  #else
+  std::string url(p->as_string().data(), p->as_string().size());
+  #endif
+  // any::type_name() と同じく、最後の / より後ろをメッセージの名前にする
+  if (url.empty() || url.back() == '/') {
+    return fail(path, "invalid type URL", err);
+  }
+  path.resize(n);
+  return true;
+}
+
+}
+}
+
+#endif
+
+
+namespace strict {
+
+enum Color {
+  RED = 0,
+  GREEN = 1,
+};
+
+struct Person {
+  std::string name;
+  int32_t age = 0;
+  std::vector<std::string> tags;
+  ::strict::Color color = (::strict::Color)0;
+  friend bool operator==(const Person& a, const Person& b) {
+    if (a.name != b.name) return false;
+    if (a.age != b.age) return false;
+    if (a.tags != b.tags) return false;
+    if (a.color != b.color) return false;
+    return true;
+  }
+  friend bool operator!=(const Person& a, const Person& b) { return !(a == b); }
+};
+
+/// strict ではないので知らないキーは無視するが、型は調べる
+struct Address {
+  std::string city;
+  friend bool operator==(const Address& a, const Address& b) {
+    if (a.city != b.city) return false;
+    return true;
+  }
+  friend bool operator!=(const Address& a, const Address& b) { return !(a == b); }
+};
+
+/// 知らないキーや型の違う値をエラーにする
+struct Config {
+  enum class KindCase {
+    NOT_SET = 0,
+    kNumber = 5,
+    kText = 6,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    number = int32_t();
+    text = std::string();
+  }
+  
+  enum class OptCase {
+    NOT_SET = 0,
+    kOpt = 7,
+  };
+  OptCase _opt_case = OptCase::NOT_SET;
+  void clear__opt_case() {
+    _opt_case = OptCase::NOT_SET;
+    opt = uint32_t();
+  }
+  
+  std::vector<::strict::Person> people;
+  std::map<std::string, ::strict::Person> by_name;
+  std::map<int32_t, std::string> labels;
+  ::strict::Address address;
+  int32_t number = 0;
+  void set_number(int32_t number) {
+    clear_kind_case();
+    kind_case = KindCase::kNumber;
+    this->number = number;
+  }
+  void clear_number() {
+    if (kind_case == KindCase::kNumber) {
+      clear_kind_case();
+    }
+  }
+  std::string text;
+  void set_text(std::string text) {
+    clear_kind_case();
+    kind_case = KindCase::kText;
+    this->text = text;
+  }
+  void clear_text() {
+    if (kind_case == KindCase::kText) {
+      clear_kind_case();
+    }
+  }
+  uint32_t opt = 0;
+  void set_opt(uint32_t opt) {
+    clear__opt_case();
+    _opt_case = OptCase::kOpt;
+    this->opt = opt;
+  }
+  bool has_opt() const {
+    return _opt_case == OptCase::kOpt;
+  }
+  void clear_opt() {
+    if (_opt_case == OptCase::kOpt) {
+      clear__opt_case();
+    }
+  }
+  int64_t big = 0;
+  std::optional<int32_t> limit;
+  friend bool operator==(const Config& a, const Config& b) {
+    if (a.people != b.people) return false;
+    if (a.by_name != b.by_name) return false;
+    if (a.labels != b.labels) return false;
+    if (a.address != b.address) return false;
+    if (a.big != b.big) return false;
+    if (a.limit != b.limit) return false;
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kNumber && a.number != b.number) return false;
+    if (a.kind_case == KindCase::kText && a.text != b.text) return false;
+    if (a._opt_case != b._opt_case) return false;
+    if (a._opt_case == OptCase::kOpt && a.opt != b.opt) return false;
+    return true;
+  }
+  friend bool operator!=(const Config& a, const Config& b) { return !(a == b); }
+};
+
+/// strict ではないメッセージの中でも、strict なメッセージは調べる
+struct Loose {
+  int32_t id = 0;
+  ::strict::Person person;
+  friend bool operator==(const Loose& a, const Loose& b) {
+    if (a.id != b.id) return false;
+    if (a.person != b.person) return false;
+    return true;
+  }
+  friend bool operator!=(const Loose& a, const Loose& b) { return !(a == b); }
+};
+
+// ::strict::Color
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Color& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Color& v)
+#endif
+{
+  switch (v) {
+    case ::strict::RED:
+    case ::strict::GREEN:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::strict::Color)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Color& v) {
+  v = (::strict::Color)jv.template get<int>();
+}
+#else
+static ::strict::Color tag_invoke(const boost::json::value_to_tag<::strict::Color>&, const boost::json::value& jv) {
+  return (::strict::Color)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::strict::Person
+static bool jsonif_check(const ::jsonif::detail::check_json& jv, const ::strict::Person*, std::string& path, ::jsonif::error& err) {
+  if (!::jsonif::detail::check_object(jv, path, err)) return false;
+  static const char* const known_keys[] = {"name", "age", "tags", "color", nullptr};
+  if (!::jsonif::detail::check_unknown_keys(jv, known_keys, path, err)) return false;
+  if (const auto* p = ::jsonif::detail::find_key(jv, "name")) {
+    size_t n = ::jsonif::detail::push_key(path, "name");
+    if (!::jsonif::detail::check_string(*p, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "name", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "age")) {
+    size_t n = ::jsonif::detail::push_key(path, "age");
+    if (!::jsonif::detail::check_integer(*p, INT32_MIN, INT32_MAX, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "age", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "tags")) {
+    size_t n = ::jsonif::detail::push_key(path, "tags");
+    if (!::jsonif::detail::check_each(*p, path, err, [&](const ::jsonif::detail::check_json& x) { return ::jsonif::detail::check_string(x, path, err); })) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "tags", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "color")) {
+    size_t n = ::jsonif::detail::push_key(path, "color");
+    if (!::jsonif::detail::check_enum(*p, false, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "color", err);
+  }
+  return true;
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Person& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Person& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["age"], v.age);
+  }
+  #else
+  obj["age"] = boost::json::value_from(v.age);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["tags"], v.tags);
+  }
+  #else
+  obj["tags"] = boost::json::value_from(v.tags);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["color"], v.color);
+  }
+  #else
+  obj["color"] = boost::json::value_from(v.color);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Person& v)
+#else
+static ::strict::Person tag_invoke(const boost::json::value_to_tag<::strict::Person>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::strict::Person v;
+  #endif
+  ::jsonif::detail::strict_scope strict(jv, static_cast<const ::strict::Person*>(nullptr));
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("age"), v.age);
+  }
+  #else
+  v.age = boost::json::value_to<int32_t>(jv.at("age"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("tags"), v.tags);
+  }
+  #else
+  v.tags = boost::json::value_to<std::vector<std::string>>(jv.at("tags"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("color"), v.color);
+  }
+  #else
+  v.color = boost::json::value_to<::strict::Color>(jv.at("color"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::strict::Address
+static bool jsonif_check(const ::jsonif::detail::check_json& jv, const ::strict::Address*, std::string& path, ::jsonif::error& err) {
+  if (!::jsonif::detail::check_object(jv, path, err)) return false;
+  if (const auto* p = ::jsonif::detail::find_key(jv, "city")) {
+    size_t n = ::jsonif::detail::push_key(path, "city");
+    if (!::jsonif::detail::check_string(*p, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "city", err);
+  }
+  return true;
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Address& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Address& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["city"], v.city);
+  }
+  #else
+  obj["city"] = boost::json::value_from(v.city);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Address& v)
+#else
+static ::strict::Address tag_invoke(const boost::json::value_to_tag<::strict::Address>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::strict::Address v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("city"), v.city);
+  }
+  #else
+  v.city = boost::json::value_to<std::string>(jv.at("city"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::strict::Config::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Config::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Config::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::strict::Config::KindCase::kNumber:
+    case ::strict::Config::KindCase::kText:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::strict::Config::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Config::KindCase& v) {
+  v = (::strict::Config::KindCase)jv.template get<int>();
+}
+#else
+static ::strict::Config::KindCase tag_invoke(const boost::json::value_to_tag<::strict::Config::KindCase>&, const boost::json::value& jv) {
+  return (::strict::Config::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::strict::Config::OptCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Config::OptCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Config::OptCase& v)
+#endif
+{
+  switch (v) {
+    case ::strict::Config::OptCase::kOpt:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::strict::Config::OptCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Config::OptCase& v) {
+  v = (::strict::Config::OptCase)jv.template get<int>();
+}
+#else
+static ::strict::Config::OptCase tag_invoke(const boost::json::value_to_tag<::strict::Config::OptCase>&, const boost::json::value& jv) {
+  return (::strict::Config::OptCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::strict::Config
+static bool jsonif_check(const ::jsonif::detail::check_json& jv, const ::strict::Config*, std::string& path, ::jsonif::error& err) {
+  if (!::jsonif::detail::check_object(jv, path, err)) return false;
+  static const char* const known_keys[] = {"people", "by_name", "labels", "address", "number", "text", "opt", "big", "limit", "kind_case", nullptr};
+  if (!::jsonif::detail::check_unknown_keys(jv, known_keys, path, err)) return false;
+  if (const auto* p = ::jsonif::detail::find_key(jv, "people")) {
+    size_t n = ::jsonif::detail::push_key(path, "people");
+    if (!::jsonif::detail::check_each(*p, path, err, [&](const ::jsonif::detail::check_json& x) { return ::jsonif::detail::check<::strict::Person>(x, path, err); })) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "people", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "by_name")) {
+    size_t n = ::jsonif::detail::push_key(path, "by_name");
+    if (!::jsonif::detail::check_map(*p, path, err, [](const std::string&) { return true; }, [&](const ::jsonif::detail::check_json& x) { return ::jsonif::detail::check<::strict::Person>(x, path, err); })) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "by_name", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "labels")) {
+    size_t n = ::jsonif::detail::push_key(path, "labels");
+    if (!::jsonif::detail::check_map(*p, path, err, [](const std::string& k) { return ::jsonif::detail::is_integer_string(k, INT32_MIN, INT32_MAX); }, [&](const ::jsonif::detail::check_json& x) { return ::jsonif::detail::check_string(x, path, err); })) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "labels", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "address")) {
+    size_t n = ::jsonif::detail::push_key(path, "address");
+    if (!::jsonif::detail::check<::strict::Address>(*p, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "address", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "number")) {
+    size_t n = ::jsonif::detail::push_key(path, "number");
+    if (!::jsonif::detail::check_integer(*p, INT32_MIN, INT32_MAX, path, err)) return false;
+    path.resize(n);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "text")) {
+    size_t n = ::jsonif::detail::push_key(path, "text");
+    if (!::jsonif::detail::check_string(*p, path, err)) return false;
+    path.resize(n);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "opt")) {
+    size_t n = ::jsonif::detail::push_key(path, "opt");
+    if (!::jsonif::detail::check_integer(*p, 0, UINT32_MAX, path, err)) return false;
+    path.resize(n);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "big")) {
+    size_t n = ::jsonif::detail::push_key(path, "big");
+    if (!::jsonif::detail::check_integer(*p, INT64_MIN, INT64_MAX, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "big", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "limit")) {
+    size_t n = ::jsonif::detail::push_key(path, "limit");
+    if (!(p->is_null() || ::jsonif::detail::check_integer(*p, INT32_MIN, INT32_MAX, path, err))) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "limit", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "kind_case")) {
+    size_t n = ::jsonif::detail::push_key(path, "kind_case");
+    if (!::jsonif::detail::check_integer(*p, INT32_MIN, INT32_MAX, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "kind_case", err);
+  }
+  return true;
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Config& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Config& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["people"], v.people);
+  }
+  #else
+  obj["people"] = boost::json::value_from(v.people);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.by_name) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["by_name"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.by_name) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["by_name"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.labels) {
+      to_json(m[std::to_string(kv.first)], kv.second);
+    }
+    obj["labels"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.labels) {
+      m[std::to_string(kv.first)] = boost::json::value_from(kv.second);
+    }
+    obj["labels"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["address"], v.address);
+  }
+  #else
+  obj["address"] = boost::json::value_from(v.address);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["number"], v.number);
+  }
+  #else
+  obj["number"] = boost::json::value_from(v.number);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["text"], v.text);
+  }
+  #else
+  obj["text"] = boost::json::value_from(v.text);
+  #endif
+  if (v._opt_case == ::strict::Config::OptCase::kOpt) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["opt"], v.opt);
+    }
+    #else
+    obj["opt"] = boost::json::value_from(v.opt);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["big"], v.big);
+  }
+  #else
+  obj["big"] = boost::json::value_from(v.big);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  obj["limit"] = v.limit;
+  #else
+  obj["limit"] = boost::json::value_from(v.limit);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["kind_case"], v.kind_case);
+  }
+  #else
+  obj["kind_case"] = boost::json::value_from(v.kind_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Config& v)
+#else
+static ::strict::Config tag_invoke(const boost::json::value_to_tag<::strict::Config>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::strict::Config v;
+  #endif
+  ::jsonif::detail::strict_scope strict(jv, static_cast<const ::strict::Config*>(nullptr));
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("people"), v.people);
+  }
+  #else
+  v.people = boost::json::value_to<std::vector<::strict::Person>>(jv.at("people"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("by_name").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::strict::Person value{};
+    from_json(kv.value(), value);
+    v.by_name.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("by_name").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.by_name.emplace(key, boost::json::value_to<::strict::Person>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("labels").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    std::string value{};
+    from_json(kv.value(), value);
+    v.labels.emplace((int32_t)std::stoll(key), std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("labels").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.labels.emplace((int32_t)std::stoll(key), boost::json::value_to<std::string>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("address"), v.address);
+  }
+  #else
+  v.address = boost::json::value_to<::strict::Address>(jv.at("address"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("number"))
+  #else
+  if (jv.as_object().find("number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("number"), v.number);
+    }
+    #else
+    v.number = boost::json::value_to<int32_t>(jv.at("number"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("text"))
+  #else
+  if (jv.as_object().find("text") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("text"), v.text);
+    }
+    #else
+    v.text = boost::json::value_to<std::string>(jv.at("text"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("opt"))
+  #else
+  if (jv.as_object().find("opt") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("opt"), v.opt);
+    }
+    #else
+    v.opt = boost::json::value_to<uint32_t>(jv.at("opt"));
+    #endif
+    v._opt_case = ::strict::Config::OptCase::kOpt;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("big"), v.big);
+  }
+  #else
+  v.big = boost::json::value_to<int64_t>(jv.at("big"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv.at("limit").get_to(v.limit);
+  #else
+  v.limit = boost::json::value_to<std::optional<int32_t>>(jv.at("limit"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("kind_case"), v.kind_case);
+  }
+  #else
+  v.kind_case = boost::json::value_to<::strict::Config::KindCase>(jv.at("kind_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::strict::Loose
+static bool jsonif_check(const ::jsonif::detail::check_json& jv, const ::strict::Loose*, std::string& path, ::jsonif::error& err) {
+  if (!::jsonif::detail::check_object(jv, path, err)) return false;
+  if (const auto* p = ::jsonif::detail::find_key(jv, "id")) {
+    size_t n = ::jsonif::detail::push_key(path, "id");
+    if (!::jsonif::detail::check_integer(*p, INT32_MIN, INT32_MAX, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "id", err);
+  }
+  if (const auto* p = ::jsonif::detail::find_key(jv, "person")) {
+    size_t n = ::jsonif::detail::push_key(path, "person");
+    if (!::jsonif::detail::check<::strict::Person>(*p, path, err)) return false;
+    path.resize(n);
+  } else {
+    return ::jsonif::detail::missing_key(path, "person", err);
+  }
+  return true;
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::strict::Loose& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::strict::Loose& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["id"], v.id);
+  }
+  #else
+  obj["id"] = boost::json::value_from(v.id);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["person"], v.person);
+  }
+  #else
+  obj["person"] = boost::json::value_from(v.person);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::strict::Loose& v)
+#else
+static ::strict::Loose tag_invoke(const boost::json::value_to_tag<::strict::Loose>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::strict::Loose v;
+  #endif
+  ::jsonif::detail::strict_scope strict(jv, static_cast<const ::strict::Loose*>(nullptr));
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("id"), v.id);
+  }
+  #else
+  v.id = boost::json::value_to<int32_t>(jv.at("id"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("person"), v.person);
+  }
+  #else
+  v.person = boost::json::value_to<::strict::Person>(jv.at("person"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::strict::Person> {
+  static constexpr const char* value = "strict.Person";
+};
+template<>
+struct type_name<::strict::Address> {
+  static constexpr const char* value = "strict.Address";
+};
+template<>
+struct type_name<::strict::Config> {
+  static constexpr const char* value = "strict.Config";
+};
+template<>
+struct type_name<::strict::Loose> {
+  static constexpr const char* value = "strict.Loose";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
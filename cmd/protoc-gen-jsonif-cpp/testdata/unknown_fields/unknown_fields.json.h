@@ -0,0 +1,726 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_UNKNOWN_FIELDS_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_UNKNOWN_FIELDS_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <stddef.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_UNKNOWN_FIELDS_DEFINED
+#define JSONIF_UNKNOWN_FIELDS_DEFINED
+
+namespace jsonif {
+
+// JSON から読み込んだ時の知らないキーとその値
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json::object_t unknown_fields;
+#else
+typedef boost::json::object unknown_fields;
+#endif
+
+namespace detail {
+
+// keys は nullptr で終わる配列
+template<class Key>
+inline bool is_known_key(const char* const* keys, const Key& key) {
+  for (; *keys != nullptr; ++keys) {
+    if (key.compare(*keys) == 0) {
+      return true;
+    }
+  }
+  return false;
+}
+
+}
+
+}
+
+#endif
+
+
+namespace unknown_fields {
+
+struct Inner {
+  int32_t value = 0;
+  // JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+  ::jsonif::unknown_fields jsonif_unknown_fields;
+  friend bool operator==(const Inner& a, const Inner& b) {
+    if (a.value != b.value) return false;
+    if (a.jsonif_unknown_fields != b.jsonif_unknown_fields) return false;
+    return true;
+  }
+  friend bool operator!=(const Inner& a, const Inner& b) { return !(a == b); }
+};
+
+/// 知らないキーを保持して、そのまま出力する
+struct Test {
+  enum class KindCase {
+    NOT_SET = 0,
+    kNumber = 6,
+    kText = 7,
+  };
+  KindCase kind_case = KindCase::NOT_SET;
+  void clear_kind_case() {
+    kind_case = KindCase::NOT_SET;
+    number = int32_t();
+    text = std::string();
+  }
+  
+  enum class OptCase {
+    NOT_SET = 0,
+    kOpt = 8,
+  };
+  OptCase _opt_case = OptCase::NOT_SET;
+  void clear__opt_case() {
+    _opt_case = OptCase::NOT_SET;
+    opt = int32_t();
+  }
+  
+  int32_t id = 0;
+  std::string name;
+  ::unknown_fields::Inner inner;
+  std::vector<::unknown_fields::Inner> inners;
+  std::map<std::string, ::unknown_fields::Inner> inner_map;
+  int32_t number = 0;
+  void set_number(int32_t number) {
+    clear_kind_case();
+    kind_case = KindCase::kNumber;
+    this->number = number;
+  }
+  void clear_number() {
+    if (kind_case == KindCase::kNumber) {
+      clear_kind_case();
+    }
+  }
+  std::string text;
+  void set_text(std::string text) {
+    clear_kind_case();
+    kind_case = KindCase::kText;
+    this->text = text;
+  }
+  void clear_text() {
+    if (kind_case == KindCase::kText) {
+      clear_kind_case();
+    }
+  }
+  int32_t opt = 0;
+  void set_opt(int32_t opt) {
+    clear__opt_case();
+    _opt_case = OptCase::kOpt;
+    this->opt = opt;
+  }
+  bool has_opt() const {
+    return _opt_case == OptCase::kOpt;
+  }
+  void clear_opt() {
+    if (_opt_case == OptCase::kOpt) {
+      clear__opt_case();
+    }
+  }
+  // JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+  ::jsonif::unknown_fields jsonif_unknown_fields;
+  friend bool operator==(const Test& a, const Test& b) {
+    if (a.id != b.id) return false;
+    if (a.name != b.name) return false;
+    if (a.inner != b.inner) return false;
+    if (a.inners != b.inners) return false;
+    if (a.inner_map != b.inner_map) return false;
+    if (a.kind_case != b.kind_case) return false;
+    if (a.kind_case == KindCase::kNumber && a.number != b.number) return false;
+    if (a.kind_case == KindCase::kText && a.text != b.text) return false;
+    if (a._opt_case != b._opt_case) return false;
+    if (a._opt_case == OptCase::kOpt && a.opt != b.opt) return false;
+    if (a.jsonif_unknown_fields != b.jsonif_unknown_fields) return false;
+    return true;
+  }
+  friend bool operator!=(const Test& a, const Test& b) { return !(a == b); }
+};
+
+/// 知らないキーがあったら捨てる
+struct Plain {
+  int32_t id = 0;
+  ::unknown_fields::Test test;
+  friend bool operator==(const Plain& a, const Plain& b) {
+    if (a.id != b.id) return false;
+    if (a.test != b.test) return false;
+    return true;
+  }
+  friend bool operator!=(const Plain& a, const Plain& b) { return !(a == b); }
+};
+
+/// フィールドが無くても知らないキーは保持する
+struct Empty {
+  // JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+  ::jsonif::unknown_fields jsonif_unknown_fields;
+  friend bool operator==(const Empty& a, const Empty& b) {
+    if (a.jsonif_unknown_fields != b.jsonif_unknown_fields) return false;
+    return true;
+  }
+  friend bool operator!=(const Empty& a, const Empty& b) { return !(a == b); }
+};
+
+// ::unknown_fields::Inner
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::unknown_fields::Inner& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::unknown_fields::Inner& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value"], v.value);
+  }
+  #else
+  obj["value"] = boost::json::value_from(v.value);
+  #endif
+  static const char* const known_keys[] = {"value", nullptr};
+  for (const auto& kv : v.jsonif_unknown_fields) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    if (!::jsonif::detail::is_known_key(known_keys, kv.first)) {
+      obj.emplace(kv.first, kv.second);
+    }
+    #else
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      obj.emplace(kv.key(), kv.value());
+    }
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::unknown_fields::Inner& v)
+#else
+static ::unknown_fields::Inner tag_invoke(const boost::json::value_to_tag<::unknown_fields::Inner>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::unknown_fields::Inner v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value"), v.value);
+  }
+  #else
+  v.value = boost::json::value_to<int32_t>(jv.at("value"));
+  #endif
+  static const char* const known_keys[] = {"value", nullptr};
+  v.jsonif_unknown_fields.clear();
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      v.jsonif_unknown_fields.emplace(kv.key(), kv.value());
+    }
+  }
+  #else
+  for (const auto& kv : jv.as_object()) {
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      v.jsonif_unknown_fields.emplace(kv.key(), kv.value());
+    }
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::unknown_fields::Test::KindCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::unknown_fields::Test::KindCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::unknown_fields::Test::KindCase& v)
+#endif
+{
+  switch (v) {
+    case ::unknown_fields::Test::KindCase::kNumber:
+    case ::unknown_fields::Test::KindCase::kText:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::unknown_fields::Test::KindCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::unknown_fields::Test::KindCase& v) {
+  v = (::unknown_fields::Test::KindCase)jv.template get<int>();
+}
+#else
+static ::unknown_fields::Test::KindCase tag_invoke(const boost::json::value_to_tag<::unknown_fields::Test::KindCase>&, const boost::json::value& jv) {
+  return (::unknown_fields::Test::KindCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::unknown_fields::Test::OptCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::unknown_fields::Test::OptCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::unknown_fields::Test::OptCase& v)
+#endif
+{
+  switch (v) {
+    case ::unknown_fields::Test::OptCase::kOpt:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::unknown_fields::Test::OptCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::unknown_fields::Test::OptCase& v) {
+  v = (::unknown_fields::Test::OptCase)jv.template get<int>();
+}
+#else
+static ::unknown_fields::Test::OptCase tag_invoke(const boost::json::value_to_tag<::unknown_fields::Test::OptCase>&, const boost::json::value& jv) {
+  return (::unknown_fields::Test::OptCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::unknown_fields::Test
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::unknown_fields::Test& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::unknown_fields::Test& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["id"], v.id);
+  }
+  #else
+  obj["id"] = boost::json::value_from(v.id);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["inner"], v.inner);
+  }
+  #else
+  obj["inner"] = boost::json::value_from(v.inner);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["inners"], v.inners);
+  }
+  #else
+  obj["inners"] = boost::json::value_from(v.inners);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.inner_map) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["inner_map"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.inner_map) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["inner_map"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["number"], v.number);
+  }
+  #else
+  obj["number"] = boost::json::value_from(v.number);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["text"], v.text);
+  }
+  #else
+  obj["text"] = boost::json::value_from(v.text);
+  #endif
+  if (v._opt_case == ::unknown_fields::Test::OptCase::kOpt) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["opt"], v.opt);
+    }
+    #else
+    obj["opt"] = boost::json::value_from(v.opt);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["kind_case"], v.kind_case);
+  }
+  #else
+  obj["kind_case"] = boost::json::value_from(v.kind_case);
+  #endif
+  static const char* const known_keys[] = {"id", "name", "inner", "inners", "inner_map", "number", "text", "opt", "kind_case", nullptr};
+  for (const auto& kv : v.jsonif_unknown_fields) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    if (!::jsonif::detail::is_known_key(known_keys, kv.first)) {
+      obj.emplace(kv.first, kv.second);
+    }
+    #else
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      obj.emplace(kv.key(), kv.value());
+    }
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::unknown_fields::Test& v)
+#else
+static ::unknown_fields::Test tag_invoke(const boost::json::value_to_tag<::unknown_fields::Test>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::unknown_fields::Test v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("id"), v.id);
+  }
+  #else
+  v.id = boost::json::value_to<int32_t>(jv.at("id"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("inner"), v.inner);
+  }
+  #else
+  v.inner = boost::json::value_to<::unknown_fields::Inner>(jv.at("inner"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("inners"), v.inners);
+  }
+  #else
+  v.inners = boost::json::value_to<std::vector<::unknown_fields::Inner>>(jv.at("inners"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("inner_map").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::unknown_fields::Inner value{};
+    from_json(kv.value(), value);
+    v.inner_map.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("inner_map").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.inner_map.emplace(key, boost::json::value_to<::unknown_fields::Inner>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("number"))
+  #else
+  if (jv.as_object().find("number") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("number"), v.number);
+    }
+    #else
+    v.number = boost::json::value_to<int32_t>(jv.at("number"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("text"))
+  #else
+  if (jv.as_object().find("text") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("text"), v.text);
+    }
+    #else
+    v.text = boost::json::value_to<std::string>(jv.at("text"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("opt"))
+  #else
+  if (jv.as_object().find("opt") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("opt"), v.opt);
+    }
+    #else
+    v.opt = boost::json::value_to<int32_t>(jv.at("opt"));
+    #endif
+    v._opt_case = ::unknown_fields::Test::OptCase::kOpt;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("kind_case"), v.kind_case);
+  }
+  #else
+  v.kind_case = boost::json::value_to<::unknown_fields::Test::KindCase>(jv.at("kind_case"));
+  #endif
+  static const char* const known_keys[] = {"id", "name", "inner", "inners", "inner_map", "number", "text", "opt", "kind_case", nullptr};
+  v.jsonif_unknown_fields.clear();
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      v.jsonif_unknown_fields.emplace(kv.key(), kv.value());
+    }
+  }
+  #else
+  for (const auto& kv : jv.as_object()) {
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      v.jsonif_unknown_fields.emplace(kv.key(), kv.value());
+    }
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::unknown_fields::Plain
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::unknown_fields::Plain& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::unknown_fields::Plain& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["id"], v.id);
+  }
+  #else
+  obj["id"] = boost::json::value_from(v.id);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["test"], v.test);
+  }
+  #else
+  obj["test"] = boost::json::value_from(v.test);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::unknown_fields::Plain& v)
+#else
+static ::unknown_fields::Plain tag_invoke(const boost::json::value_to_tag<::unknown_fields::Plain>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::unknown_fields::Plain v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("id"), v.id);
+  }
+  #else
+  v.id = boost::json::value_to<int32_t>(jv.at("id"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("test"), v.test);
+  }
+  #else
+  v.test = boost::json::value_to<::unknown_fields::Test>(jv.at("test"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+// ::unknown_fields::Empty
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::unknown_fields::Empty& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::unknown_fields::Empty& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj = nlohmann::json::object();
+  #else
+  boost::json::object obj;
+  #endif
+  static const char* const known_keys[] = {nullptr};
+  for (const auto& kv : v.jsonif_unknown_fields) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    if (!::jsonif::detail::is_known_key(known_keys, kv.first)) {
+      obj.emplace(kv.first, kv.second);
+    }
+    #else
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      obj.emplace(kv.key(), kv.value());
+    }
+    #endif
+  }
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::unknown_fields::Empty& v)
+#else
+static ::unknown_fields::Empty tag_invoke(const boost::json::value_to_tag<::unknown_fields::Empty>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::unknown_fields::Empty v;
+  #endif
+  static const char* const known_keys[] = {nullptr};
+  v.jsonif_unknown_fields.clear();
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.items()) {
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      v.jsonif_unknown_fields.emplace(kv.key(), kv.value());
+    }
+  }
+  #else
+  for (const auto& kv : jv.as_object()) {
+    if (!::jsonif::detail::is_known_key(known_keys, kv.key())) {
+      v.jsonif_unknown_fields.emplace(kv.key(), kv.value());
+    }
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::unknown_fields::Inner> {
+  static constexpr const char* value = "unknown_fields.Inner";
+};
+template<>
+struct type_name<::unknown_fields::Test> {
+  static constexpr const char* value = "unknown_fields.Test";
+};
+template<>
+struct type_name<::unknown_fields::Plain> {
+  static constexpr const char* value = "unknown_fields.Plain";
+};
+template<>
+struct type_name<::unknown_fields::Empty> {
+  static constexpr const char* value = "unknown_fields.Empty";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,1294 @@
+#ifndef AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_VALIDATION_PROTO
+#define AUTO_GENERATED_PROTOC_GEN_JSONIF_CPP_VALIDATION_PROTO
+
+#include <string>
+#include <vector>
+#include <map>
+#include <chrono>
+#include <optional>
+#include <stdexcept>
+#include <regex>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#include <nlohmann/json.hpp>
+#else
+#include <boost/json.hpp>
+#endif
+
+#ifndef JSONIF_TYPE_NAME_DEFINED
+#define JSONIF_TYPE_NAME_DEFINED
+
+namespace jsonif {
+
+// メッセージの完全修飾名（google.protobuf.Any の "@type" に使う）
+// 生成したメッセージごとに特殊化する
+template<class T>
+struct type_name;
+
+}
+
+#endif
+
+#ifndef JSONIF_THROW
+#if defined(__cpp_exceptions) || defined(__EXCEPTIONS) || defined(_CPPUNWIND)
+#define JSONIF_THROW(e) throw e
+#else
+#define JSONIF_THROW(e) abort()
+#endif
+#endif
+
+#ifndef JSONIF_WELL_KNOWN_TYPES_DEFINED
+#define JSONIF_WELL_KNOWN_TYPES_DEFINED
+
+namespace jsonif {
+
+// google.protobuf.Struct, Value, ListValue は JSON の値をそのまま保持する
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+typedef nlohmann::json json_value;
+#else
+typedef boost::json::value json_value;
+#endif
+
+// google.protobuf.Any は "@type" を含む JSON のオブジェクトをそのまま保持する
+// 値が無い場合は null になる
+struct any {
+  json_value value;
+  
+  // "@type" の型の URL から取り出したメッセージの完全修飾名
+  std::string type_name() const {
+    if (!value.is_object()) return "";
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    auto it = value.find("@type");
+    if (it == value.end() || !it->is_string()) return "";
+    std::string url = it->get<std::string>();
+    #else
+    auto p = value.as_object().if_contains("@type");
+    if (p == nullptr || !p->is_string()) return "";
+    std::string url(p->as_string().data(), p->as_string().size());
+    #endif
+    return url.substr(url.rfind('/') + 1);
+  }
+  
+  friend bool operator==(const any& a, const any& b) { return a.value == b.value; }
+  friend bool operator!=(const any& a, const any& b) { return !(a == b); }
+};
+
+// メッセージを google.protobuf.Any に詰める
+template<class T>
+inline any pack(const T& v) {
+  std::string url = std::string("type.googleapis.com/") + ::jsonif::type_name<T>::value;
+  any a;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  a.value = v;
+  a.value["@type"] = url;
+  #else
+  // "@type" を先頭に出力する
+  boost::json::value fields = boost::json::value_from(v);
+  boost::json::object obj;
+  obj["@type"] = url;
+  for (const auto& kv : fields.as_object()) {
+    obj[kv.key()] = kv.value();
+  }
+  a.value = std::move(obj);
+  #endif
+  return a;
+}
+
+// google.protobuf.Any に入っているのが T かどうか
+template<class T>
+inline bool is(const any& a) {
+  return a.type_name() == ::jsonif::type_name<T>::value;
+}
+
+// google.protobuf.Any から T を取り出す。T ではない場合は std::nullopt を返す
+template<class T>
+inline std::optional<T> unpack(const any& a) {
+  if (!is<T>(a)) return std::nullopt;
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  return a.value.get<T>();
+  #else
+  return boost::json::value_to<T>(a.value);
+  #endif
+}
+
+namespace detail {
+
+// "@type" の無いオブジェクトは google.protobuf.Any として読み込めない
+inline any make_any(json_value jv) {
+  any a;
+  a.value = std::move(jv);
+  if (!a.value.is_null() && a.type_name().empty()) {
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Any: @type is required"));
+  }
+  return a;
+}
+
+typedef std::chrono::time_point<std::chrono::system_clock, std::chrono::nanoseconds> timestamp;
+
+// 1970-01-01 からの日数と年月日を変換する
+// http://howardhinnant.github.io/date_algorithms.html
+inline int64_t days_from_civil(int64_t y, int64_t m, int64_t d) {
+  y -= m <= 2;
+  const int64_t era = (y >= 0 ? y : y - 399) / 400;
+  const int64_t yoe = y - era * 400;
+  const int64_t doy = (153 * (m > 2 ? m - 3 : m + 9) + 2) / 5 + d - 1;
+  const int64_t doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+  return era * 146097 + doe - 719468;
+}
+inline void civil_from_days(int64_t z, int64_t& y, int64_t& m, int64_t& d) {
+  z += 719468;
+  const int64_t era = (z >= 0 ? z : z - 146096) / 146097;
+  const int64_t doe = z - era * 146097;
+  const int64_t yoe = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+  const int64_t doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+  const int64_t mp = (5 * doy + 2) / 153;
+  d = doy - (153 * mp + 2) / 5 + 1;
+  m = mp < 10 ? mp + 3 : mp - 9;
+  y = yoe + era * 400 + (m <= 2);
+}
+
+// 小数部は 0, 3, 6, 9 桁のいずれかで出力する
+inline std::string format_nanos(int64_t nanos) {
+  char buf[16];
+  if (nanos == 0) {
+    return "";
+  } else if (nanos % 1000000 == 0) {
+    snprintf(buf, sizeof(buf), ".%03d", (int)(nanos / 1000000));
+  } else if (nanos % 1000 == 0) {
+    snprintf(buf, sizeof(buf), ".%06d", (int)(nanos / 1000));
+  } else {
+    snprintf(buf, sizeof(buf), ".%09d", (int)nanos);
+  }
+  return buf;
+}
+inline bool parse_digits(const std::string& s, size_t& i, size_t n, int64_t& r) {
+  r = 0;
+  for (size_t end = i + n; i < end; i++) {
+    if (i >= s.size() || s[i] < '0' || '9' < s[i]) return false;
+    r = r * 10 + (s[i] - '0');
+  }
+  return true;
+}
+inline bool parse_char(const std::string& s, size_t& i, const char* cs) {
+  if (i >= s.size() || strchr(cs, s[i]) == nullptr) return false;
+  i++;
+  return true;
+}
+inline bool parse_nanos(const std::string& s, size_t& i, int64_t& nanos) {
+  nanos = 0;
+  int digits = 0;
+  if (i < s.size() && s[i] == '.') {
+    i++;
+    for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++, digits++) {
+      if (digits == 9) return false;
+      nanos = nanos * 10 + (s[i] - '0');
+    }
+    if (digits == 0) return false;
+  }
+  for (; digits < 9; digits++) {
+    nanos *= 10;
+  }
+  return true;
+}
+
+// google.protobuf.Timestamp は RFC 3339 形式の文字列にする（例: 1972-01-01T10:00:20.021Z）
+inline std::string format_timestamp(const timestamp& v) {
+  int64_t ns = v.time_since_epoch().count();
+  int64_t secs = ns / 1000000000;
+  int64_t nanos = ns % 1000000000;
+  if (nanos < 0) {
+    secs -= 1;
+    nanos += 1000000000;
+  }
+  int64_t days = secs / 86400;
+  int64_t rem = secs % 86400;
+  if (rem < 0) {
+    days -= 1;
+    rem += 86400;
+  }
+  int64_t y, m, d;
+  civil_from_days(days, y, m, d);
+  char buf[32];
+  snprintf(buf, sizeof(buf), "%04d-%02d-%02dT%02d:%02d:%02d", (int)y, (int)m, (int)d,
+           (int)(rem / 3600), (int)(rem / 60 % 60), (int)(rem % 60));
+  return buf + format_nanos(nanos) + "Z";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_timestamp(const std::string& s, timestamp& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  int64_t y = 0, m = 0, d = 0, hh = 0, mm = 0, ss = 0, nanos = 0;
+  int64_t offset = 0;
+  bool ok = parse_digits(s, i, 4, y) && parse_char(s, i, "-") && parse_digits(s, i, 2, m) &&
+            parse_char(s, i, "-") && parse_digits(s, i, 2, d) && parse_char(s, i, "Tt") &&
+            parse_digits(s, i, 2, hh) && parse_char(s, i, ":") && parse_digits(s, i, 2, mm) &&
+            parse_char(s, i, ":") && parse_digits(s, i, 2, ss) && parse_nanos(s, i, nanos);
+  if (ok && !parse_char(s, i, "Zz")) {
+    // UTC 以外の場合は +09:00 のようなオフセットが付いている
+    int64_t sign = i < s.size() && s[i] == '-' ? -1 : 1;
+    int64_t oh = 0, om = 0;
+    ok = parse_char(s, i, "+-") && parse_digits(s, i, 2, oh) && parse_char(s, i, ":") && parse_digits(s, i, 2, om);
+    offset = sign * (oh * 3600 + om * 60);
+  }
+  if (!ok || i != s.size() || m < 1 || 12 < m || d < 1 || 31 < d || 23 < hh || 59 < mm || 59 < ss) {
+    return false;
+  }
+  int64_t secs = days_from_civil(y, m, d) * 86400 + hh * 3600 + mm * 60 + ss - offset;
+  // ナノ秒で表現できる範囲（1677 年から 2262 年まで）を超える場合はエラーにする
+  if (secs < -9223372035 || 9223372035 < secs) {
+    out_of_range = true;
+    return false;
+  }
+  v = timestamp(std::chrono::nanoseconds(secs * 1000000000 + nanos));
+  return true;
+}
+inline timestamp parse_timestamp(const std::string& s) {
+  timestamp v;
+  bool out_of_range;
+  if (!parse_timestamp(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Timestamp out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Timestamp: " + s));
+  }
+  return v;
+}
+
+// google.protobuf.Duration は秒数の後ろに s を付けた文字列にする（例: 1.5s）
+inline std::string format_duration(std::chrono::nanoseconds v) {
+  int64_t ns = v.count();
+  // 符号を反転した時に溢れないように、符号無しで計算する
+  uint64_t abs = ns < 0 ? 0 - (uint64_t)ns : (uint64_t)ns;
+  return (ns < 0 ? "-" : "") + std::to_string(abs / 1000000000) + format_nanos((int64_t)(abs % 1000000000)) + "s";
+}
+// 読み込めなかった場合は false を返す。範囲外の場合は out_of_range を true にする
+inline bool parse_duration(const std::string& s, std::chrono::nanoseconds& v, bool& out_of_range) {
+  out_of_range = false;
+  size_t i = 0;
+  bool neg = i < s.size() && s[i] == '-';
+  if (neg) i++;
+  size_t start = i;
+  int64_t secs = 0;
+  for (; i < s.size() && '0' <= s[i] && s[i] <= '9'; i++) {
+    if (secs > 922337203) {
+      out_of_range = true;
+      return false;
+    }
+    secs = secs * 10 + (s[i] - '0');
+  }
+  int64_t nanos = 0;
+  if (i == start || !parse_nanos(s, i, nanos) || i + 1 != s.size() || s[i] != 's') {
+    return false;
+  }
+  if (secs > 9223372035) {
+    out_of_range = true;
+    return false;
+  }
+  int64_t ns = secs * 1000000000 + nanos;
+  v = std::chrono::nanoseconds(neg ? -ns : ns);
+  return true;
+}
+inline std::chrono::nanoseconds parse_duration(const std::string& s) {
+  std::chrono::nanoseconds v;
+  bool out_of_range;
+  if (!parse_duration(s, v, out_of_range)) {
+    if (out_of_range) {
+      JSONIF_THROW(std::out_of_range("google.protobuf.Duration out of range: " + s));
+    }
+    JSONIF_THROW(std::invalid_argument("invalid google.protobuf.Duration: " + s));
+  }
+  return v;
+}
+
+}
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+
+namespace nlohmann {
+
+template<>
+struct adl_serializer<::jsonif::detail::timestamp> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::detail::timestamp& v) {
+    jv = ::jsonif::detail::format_timestamp(v);
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::detail::timestamp& v) {
+    v = ::jsonif::detail::parse_timestamp(jv.get<std::string>());
+  }
+};
+
+template<>
+struct adl_serializer<std::chrono::nanoseconds> {
+  static void to_json(nlohmann::json& jv, const std::chrono::nanoseconds& v) {
+    jv = ::jsonif::detail::format_duration(v);
+  }
+  static void from_json(const nlohmann::json& jv, std::chrono::nanoseconds& v) {
+    v = ::jsonif::detail::parse_duration(jv.get<std::string>());
+  }
+};
+
+// ラッパー型は値が無い場合に null になる
+template<class T>
+struct adl_serializer<std::optional<T>> {
+  static void to_json(nlohmann::json& jv, const std::optional<T>& v) {
+    if (v) {
+      jv = *v;
+    } else {
+      jv = nullptr;
+    }
+  }
+  static void from_json(const nlohmann::json& jv, std::optional<T>& v) {
+    if (jv.is_null()) {
+      v = std::nullopt;
+    } else {
+      v = jv.template get<T>();
+    }
+  }
+};
+
+template<>
+struct adl_serializer<::jsonif::any> {
+  static void to_json(nlohmann::json& jv, const ::jsonif::any& v) {
+    jv = v.value;
+  }
+  static void from_json(const nlohmann::json& jv, ::jsonif::any& v) {
+    v = ::jsonif::detail::make_any(jv);
+  }
+};
+
+}
+
+#else
+
+// std::optional は Boost.JSON が null として扱ってくれる
+// std::chrono の型は、ADL で見つかるように boost::json 名前空間に定義する
+namespace boost {
+namespace json {
+
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::detail::timestamp& v) {
+  jv = ::jsonif::detail::format_timestamp(v);
+}
+inline ::jsonif::detail::timestamp tag_invoke(const value_to_tag<::jsonif::detail::timestamp>&, const value& jv) {
+  return ::jsonif::detail::parse_timestamp(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const std::chrono::nanoseconds& v) {
+  jv = ::jsonif::detail::format_duration(v);
+}
+inline std::chrono::nanoseconds tag_invoke(const value_to_tag<std::chrono::nanoseconds>&, const value& jv) {
+  return ::jsonif::detail::parse_duration(std::string(jv.as_string().data(), jv.as_string().size()));
+}
+inline void tag_invoke(const value_from_tag&, value& jv, const ::jsonif::any& v) {
+  jv = v.value;
+}
+inline ::jsonif::any tag_invoke(const value_to_tag<::jsonif::any>&, const value& jv) {
+  return ::jsonif::detail::make_any(jv);
+}
+
+}
+}
+
+#endif
+
+#endif
+#ifndef JSONIF_VALIDATE_DEFINED
+#define JSONIF_VALIDATE_DEFINED
+
+namespace jsonif {
+
+// 検証ルールの違反
+struct violation {
+  // JSON でのフィールドの位置（people[3].name など）
+  std::string path;
+  std::string message;
+};
+
+namespace detail {
+
+// UTF-8 の文字列のコードポイント数
+inline size_t utf8_length(const std::string& s) {
+  size_t n = 0;
+  for (unsigned char c : s) {
+    if ((c & 0xc0) != 0x80) {
+      n++;
+    }
+  }
+  return n;
+}
+
+inline std::string join_path(const std::string& path, const char* key) {
+  return path.empty() ? std::string(key) : path + "." + key;
+}
+
+// メッセージごとに生成した jsonif_validate を ADL で呼び出す
+// インスタンス化する時に探すので、後で定義されるメッセージの jsonif_validate も呼び出せる
+template<class T>
+inline void validate_message(const T& v, const std::string& path, std::vector<violation>& r) {
+  jsonif_validate(v, path, r);
+}
+
+}
+
+// 検証ルールを満たしていなければ、その違反を全て返す
+// 検証ルールのあるメッセージ（検証ルールのあるメッセージをフィールドに持つものを含む）でだけ使える
+template<class T>
+inline std::vector<violation> validate(const T& v) {
+  std::vector<violation> r;
+  jsonif_validate(v, "", r);
+  return r;
+}
+
+}
+
+#endif
+
+
+namespace validation {
+
+enum Role {
+  ROLE_UNSPECIFIED = 0,
+  ROLE_ADMIN = 1,
+  ROLE_MEMBER = 2,
+};
+
+struct Person {
+  enum class ScoreCase {
+    NOT_SET = 0,
+    kScore = 6,
+  };
+  ScoreCase _score_case = ScoreCase::NOT_SET;
+  void clear__score_case() {
+    _score_case = ScoreCase::NOT_SET;
+    score = double();
+  }
+  
+  std::string name;
+  int32_t age = 0;
+  std::string email;
+  ::validation::Role role = (::validation::Role)0;
+  /// 各要素の長さと、要素数を検証する
+  std::vector<std::string> tags;
+  double score = 0;
+  void set_score(double score) {
+    clear__score_case();
+    _score_case = ScoreCase::kScore;
+    this->score = score;
+  }
+  bool has_score() const {
+    return _score_case == ScoreCase::kScore;
+  }
+  void clear_score() {
+    if (_score_case == ScoreCase::kScore) {
+      clear__score_case();
+    }
+  }
+  float ratio = 0;
+  friend bool operator==(const Person& a, const Person& b) {
+    if (a.name != b.name) return false;
+    if (a.age != b.age) return false;
+    if (a.email != b.email) return false;
+    if (a.role != b.role) return false;
+    if (a.tags != b.tags) return false;
+    if (a.ratio != b.ratio) return false;
+    if (a._score_case != b._score_case) return false;
+    if (a._score_case == ScoreCase::kScore && a.score != b.score) return false;
+    return true;
+  }
+  friend bool operator!=(const Person& a, const Person& b) { return !(a == b); }
+};
+
+/// 検証ルールのあるメッセージをフィールドに持つメッセージも検証する
+struct Team {
+  enum class ContactCase {
+    NOT_SET = 0,
+    kPhone = 6,
+    kContactPerson = 7,
+  };
+  ContactCase contact_case = ContactCase::NOT_SET;
+  void clear_contact_case() {
+    contact_case = ContactCase::NOT_SET;
+    phone = std::string();
+    contact_person = ::validation::Person();
+  }
+  
+  ::validation::Person leader;
+  std::vector<::validation::Person> members;
+  std::map<std::string, ::validation::Person> by_name;
+  std::map<int32_t, int64_t> limits;
+  std::optional<int32_t> size;
+  std::string phone;
+  void set_phone(std::string phone) {
+    clear_contact_case();
+    contact_case = ContactCase::kPhone;
+    this->phone = phone;
+  }
+  void clear_phone() {
+    if (contact_case == ContactCase::kPhone) {
+      clear_contact_case();
+    }
+  }
+  ::validation::Person contact_person;
+  void set_contact_person(::validation::Person contact_person) {
+    clear_contact_case();
+    contact_case = ContactCase::kContactPerson;
+    this->contact_person = contact_person;
+  }
+  void clear_contact_person() {
+    if (contact_case == ContactCase::kContactPerson) {
+      clear_contact_case();
+    }
+  }
+  friend bool operator==(const Team& a, const Team& b) {
+    if (a.leader != b.leader) return false;
+    if (a.members != b.members) return false;
+    if (a.by_name != b.by_name) return false;
+    if (a.limits != b.limits) return false;
+    if (a.size != b.size) return false;
+    if (a.contact_case != b.contact_case) return false;
+    if (a.contact_case == ContactCase::kPhone && a.phone != b.phone) return false;
+    if (a.contact_case == ContactCase::kContactPerson && a.contact_person != b.contact_person) return false;
+    return true;
+  }
+  friend bool operator!=(const Team& a, const Team& b) { return !(a == b); }
+};
+
+struct Club {
+  std::string name;
+  friend bool operator==(const Club& a, const Club& b) {
+    if (a.name != b.name) return false;
+    return true;
+  }
+  friend bool operator!=(const Club& a, const Club& b) { return !(a == b); }
+};
+
+/// 後で宣言されるメッセージをフィールドに持つメッセージも検証する
+struct League {
+  ::validation::Club champion;
+  std::vector<::validation::Club> clubs;
+  friend bool operator==(const League& a, const League& b) {
+    if (a.champion != b.champion) return false;
+    if (a.clubs != b.clubs) return false;
+    return true;
+  }
+  friend bool operator!=(const League& a, const League& b) { return !(a == b); }
+};
+
+/// 検証ルールが無いメッセージは validate を生成しない
+struct NoRules {
+  int32_t value = 0;
+  friend bool operator==(const NoRules& a, const NoRules& b) {
+    if (a.value != b.value) return false;
+    return true;
+  }
+  friend bool operator!=(const NoRules& a, const NoRules& b) { return !(a == b); }
+};
+
+// ::validation::Role
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::Role& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::Role& v)
+#endif
+{
+  switch (v) {
+    case ::validation::ROLE_UNSPECIFIED:
+    case ::validation::ROLE_ADMIN:
+    case ::validation::ROLE_MEMBER:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)(::validation::Role)0;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::Role& v) {
+  v = (::validation::Role)jv.template get<int>();
+}
+#else
+static ::validation::Role tag_invoke(const boost::json::value_to_tag<::validation::Role>&, const boost::json::value& jv) {
+  return (::validation::Role)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::validation::Person::ScoreCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::Person::ScoreCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::Person::ScoreCase& v)
+#endif
+{
+  switch (v) {
+    case ::validation::Person::ScoreCase::kScore:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::validation::Person::ScoreCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::Person::ScoreCase& v) {
+  v = (::validation::Person::ScoreCase)jv.template get<int>();
+}
+#else
+static ::validation::Person::ScoreCase tag_invoke(const boost::json::value_to_tag<::validation::Person::ScoreCase>&, const boost::json::value& jv) {
+  return (::validation::Person::ScoreCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::validation::Person
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::Person& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::Person& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["age"], v.age);
+  }
+  #else
+  obj["age"] = boost::json::value_from(v.age);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["email"], v.email);
+  }
+  #else
+  obj["email"] = boost::json::value_from(v.email);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["role"], v.role);
+  }
+  #else
+  obj["role"] = boost::json::value_from(v.role);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["tags"], v.tags);
+  }
+  #else
+  obj["tags"] = boost::json::value_from(v.tags);
+  #endif
+  if (v._score_case == ::validation::Person::ScoreCase::kScore) {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::to_json;
+      to_json(obj["score"], v.score);
+    }
+    #else
+    obj["score"] = boost::json::value_from(v.score);
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["ratio"], v.ratio);
+  }
+  #else
+  obj["ratio"] = boost::json::value_from(v.ratio);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::Person& v)
+#else
+static ::validation::Person tag_invoke(const boost::json::value_to_tag<::validation::Person>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::validation::Person v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("age"), v.age);
+  }
+  #else
+  v.age = boost::json::value_to<int32_t>(jv.at("age"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("email"), v.email);
+  }
+  #else
+  v.email = boost::json::value_to<std::string>(jv.at("email"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("role"), v.role);
+  }
+  #else
+  v.role = boost::json::value_to<::validation::Role>(jv.at("role"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("tags"), v.tags);
+  }
+  #else
+  v.tags = boost::json::value_to<std::vector<std::string>>(jv.at("tags"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("score"))
+  #else
+  if (jv.as_object().find("score") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("score"), v.score);
+    }
+    #else
+    v.score = boost::json::value_to<double>(jv.at("score"));
+    #endif
+    v._score_case = ::validation::Person::ScoreCase::kScore;
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("ratio"), v.ratio);
+  }
+  #else
+  v.ratio = boost::json::value_to<float>(jv.at("ratio"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+static void jsonif_validate(const ::validation::Person& v, const std::string& path, std::vector<::jsonif::violation>& r) {
+  if (::jsonif::detail::utf8_length(v.name) < 1) r.push_back({::jsonif::detail::join_path(path, "name"), "length must be at least 1"});
+  if (::jsonif::detail::utf8_length(v.name) > 8) r.push_back({::jsonif::detail::join_path(path, "name"), "length must be at most 8"});
+  if (v.age < 0) r.push_back({::jsonif::detail::join_path(path, "age"), "must be greater than or equal to 0"});
+  if (v.age > 150) r.push_back({::jsonif::detail::join_path(path, "age"), "must be less than or equal to 150"});
+  static const std::regex email_pattern("^[^@]+@[^@]+$");
+  if (!std::regex_search(v.email, email_pattern)) r.push_back({::jsonif::detail::join_path(path, "email"), "must match pattern \"^[^@]+@[^@]+$\""});
+  if (!(v.role == ::validation::ROLE_UNSPECIFIED || v.role == ::validation::ROLE_ADMIN || v.role == ::validation::ROLE_MEMBER)) r.push_back({::jsonif::detail::join_path(path, "role"), "must be a defined enum value"});
+  if (v.tags.size() > 3) r.push_back({::jsonif::detail::join_path(path, "tags"), "must have at most 3 items"});
+  for (size_t i = 0; i < v.tags.size(); i++) {
+    if (::jsonif::detail::utf8_length(v.tags[i]) < 1) r.push_back({::jsonif::detail::join_path(path, "tags") + "[" + std::to_string(i) + "]", "length must be at least 1"});
+  }
+  if (!(v._score_case == ::validation::Person::ScoreCase::kScore)) r.push_back({::jsonif::detail::join_path(path, "score"), "is required"});
+  if (v._score_case == ::validation::Person::ScoreCase::kScore) {
+    if (v.score < 0.5) r.push_back({::jsonif::detail::join_path(path, "score"), "must be greater than or equal to 0.5"});
+  }
+  if (v.ratio > 1.0f) r.push_back({::jsonif::detail::join_path(path, "ratio"), "must be less than or equal to 1"});
+}
+
+// ::validation::Team::ContactCase
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::Team::ContactCase& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::Team::ContactCase& v)
+#endif
+{
+  switch (v) {
+    case ::validation::Team::ContactCase::kPhone:
+    case ::validation::Team::ContactCase::kContactPerson:
+      jv = (int)v;
+      break;
+    default:
+      jv = (int)::validation::Team::ContactCase::NOT_SET;
+      break;
+  }
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::Team::ContactCase& v) {
+  v = (::validation::Team::ContactCase)jv.template get<int>();
+}
+#else
+static ::validation::Team::ContactCase tag_invoke(const boost::json::value_to_tag<::validation::Team::ContactCase>&, const boost::json::value& jv) {
+  return (::validation::Team::ContactCase)boost::json::value_to<int>(jv);
+}
+#endif
+
+// ::validation::Team
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::Team& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::Team& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["leader"], v.leader);
+  }
+  #else
+  obj["leader"] = boost::json::value_from(v.leader);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["members"], v.members);
+  }
+  #else
+  obj["members"] = boost::json::value_from(v.members);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.by_name) {
+      to_json(m[kv.first], kv.second);
+    }
+    obj["by_name"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.by_name) {
+      m[kv.first] = boost::json::value_from(kv.second);
+    }
+    obj["by_name"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    nlohmann::json m = nlohmann::json::object();
+    for (const auto& kv : v.limits) {
+      to_json(m[std::to_string(kv.first)], kv.second);
+    }
+    obj["limits"] = std::move(m);
+  }
+  #else
+  {
+    boost::json::object m;
+    for (const auto& kv : v.limits) {
+      m[std::to_string(kv.first)] = boost::json::value_from(kv.second);
+    }
+    obj["limits"] = std::move(m);
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  obj["size"] = v.size;
+  #else
+  obj["size"] = boost::json::value_from(v.size);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["phone"], v.phone);
+  }
+  #else
+  obj["phone"] = boost::json::value_from(v.phone);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["contact_person"], v.contact_person);
+  }
+  #else
+  obj["contact_person"] = boost::json::value_from(v.contact_person);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["contact_case"], v.contact_case);
+  }
+  #else
+  obj["contact_case"] = boost::json::value_from(v.contact_case);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::Team& v)
+#else
+static ::validation::Team tag_invoke(const boost::json::value_to_tag<::validation::Team>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::validation::Team v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("leader"), v.leader);
+  }
+  #else
+  v.leader = boost::json::value_to<::validation::Person>(jv.at("leader"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("members"), v.members);
+  }
+  #else
+  v.members = boost::json::value_to<std::vector<::validation::Person>>(jv.at("members"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("by_name").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    ::validation::Person value{};
+    from_json(kv.value(), value);
+    v.by_name.emplace(key, std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("by_name").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.by_name.emplace(key, boost::json::value_to<::validation::Person>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  for (const auto& kv : jv.at("limits").items()) {
+    using nlohmann::from_json;
+    std::string key = kv.key();
+    int64_t value{};
+    from_json(kv.value(), value);
+    v.limits.emplace((int32_t)std::stoll(key), std::move(value));
+  }
+  #else
+  for (const auto& kv : jv.at("limits").as_object()) {
+    std::string key(kv.key().data(), kv.key().size());
+    v.limits.emplace((int32_t)std::stoll(key), boost::json::value_to<int64_t>(kv.value()));
+  }
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  jv.at("size").get_to(v.size);
+  #else
+  v.size = boost::json::value_to<std::optional<int32_t>>(jv.at("size"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("phone"))
+  #else
+  if (jv.as_object().find("phone") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("phone"), v.phone);
+    }
+    #else
+    v.phone = boost::json::value_to<std::string>(jv.at("phone"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  if (jv.contains("contact_person"))
+  #else
+  if (jv.as_object().find("contact_person") != jv.as_object().end())
+  #endif
+  {
+    #if defined(JSONIF_USE_NLOHMANN_JSON)
+    {
+      using nlohmann::from_json;
+      from_json(jv.at("contact_person"), v.contact_person);
+    }
+    #else
+    v.contact_person = boost::json::value_to<::validation::Person>(jv.at("contact_person"));
+    #endif
+  }
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("contact_case"), v.contact_case);
+  }
+  #else
+  v.contact_case = boost::json::value_to<::validation::Team::ContactCase>(jv.at("contact_case"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+static void jsonif_validate(const ::validation::Team& v, const std::string& path, std::vector<::jsonif::violation>& r) {
+  if (v.leader == decltype(v.leader)()) r.push_back({::jsonif::detail::join_path(path, "leader"), "is required"});
+  ::jsonif::detail::validate_message(v.leader, ::jsonif::detail::join_path(path, "leader"), r);
+  if (v.members.size() < 1) r.push_back({::jsonif::detail::join_path(path, "members"), "must have at least 1 items"});
+  for (size_t i = 0; i < v.members.size(); i++) {
+    ::jsonif::detail::validate_message(v.members[i], ::jsonif::detail::join_path(path, "members") + "[" + std::to_string(i) + "]", r);
+  }
+  for (const auto& kv : v.by_name) {
+    ::jsonif::detail::validate_message(kv.second, ::jsonif::detail::join_path(path, "by_name") + "[\"" + kv.first + "\"]", r);
+  }
+  for (const auto& kv : v.limits) {
+    if (kv.second < -10LL) r.push_back({::jsonif::detail::join_path(path, "limits") + "[\"" + std::to_string(kv.first) + "\"]", "must be greater than or equal to -10"});
+    if (kv.second > 10LL) r.push_back({::jsonif::detail::join_path(path, "limits") + "[\"" + std::to_string(kv.first) + "\"]", "must be less than or equal to 10"});
+  }
+  if (v.size == decltype(v.size)()) r.push_back({::jsonif::detail::join_path(path, "size"), "is required"});
+  if (v.contact_case == ::validation::Team::ContactCase::kPhone) {
+    static const std::regex phone_pattern("^[0-9]+$");
+    if (!std::regex_search(v.phone, phone_pattern)) r.push_back({::jsonif::detail::join_path(path, "phone"), "must match pattern \"^[0-9]+$\""});
+  }
+  if (v.contact_case == ::validation::Team::ContactCase::kContactPerson) {
+    ::jsonif::detail::validate_message(v.contact_person, ::jsonif::detail::join_path(path, "contact_person"), r);
+  }
+}
+
+// ::validation::Club
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::Club& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::Club& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["name"], v.name);
+  }
+  #else
+  obj["name"] = boost::json::value_from(v.name);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::Club& v)
+#else
+static ::validation::Club tag_invoke(const boost::json::value_to_tag<::validation::Club>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::validation::Club v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("name"), v.name);
+  }
+  #else
+  v.name = boost::json::value_to<std::string>(jv.at("name"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+static void jsonif_validate(const ::validation::Club& v, const std::string& path, std::vector<::jsonif::violation>& r) {
+  if (::jsonif::detail::utf8_length(v.name) < 1) r.push_back({::jsonif::detail::join_path(path, "name"), "length must be at least 1"});
+}
+
+// ::validation::League
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::League& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::League& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["champion"], v.champion);
+  }
+  #else
+  obj["champion"] = boost::json::value_from(v.champion);
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["clubs"], v.clubs);
+  }
+  #else
+  obj["clubs"] = boost::json::value_from(v.clubs);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::League& v)
+#else
+static ::validation::League tag_invoke(const boost::json::value_to_tag<::validation::League>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::validation::League v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("champion"), v.champion);
+  }
+  #else
+  v.champion = boost::json::value_to<::validation::Club>(jv.at("champion"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("clubs"), v.clubs);
+  }
+  #else
+  v.clubs = boost::json::value_to<std::vector<::validation::Club>>(jv.at("clubs"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+static void jsonif_validate(const ::validation::League& v, const std::string& path, std::vector<::jsonif::violation>& r) {
+  if (v.champion == decltype(v.champion)()) r.push_back({::jsonif::detail::join_path(path, "champion"), "is required"});
+  ::jsonif::detail::validate_message(v.champion, ::jsonif::detail::join_path(path, "champion"), r);
+  for (size_t i = 0; i < v.clubs.size(); i++) {
+    ::jsonif::detail::validate_message(v.clubs[i], ::jsonif::detail::join_path(path, "clubs") + "[" + std::to_string(i) + "]", r);
+  }
+}
+
+// ::validation::NoRules
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void to_json(nlohmann::json& jv, const ::validation::NoRules& v)
+#else
+static void tag_invoke(const boost::json::value_from_tag&, boost::json::value& jv, const ::validation::NoRules& v)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  nlohmann::json obj;
+  #else
+  boost::json::object obj;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::to_json;
+    to_json(obj["value"], v.value);
+  }
+  #else
+  obj["value"] = boost::json::value_from(v.value);
+  #endif
+  jv = std::move(obj);
+}
+
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+static void from_json(const nlohmann::json& jv, ::validation::NoRules& v)
+#else
+static ::validation::NoRules tag_invoke(const boost::json::value_to_tag<::validation::NoRules>&, const boost::json::value& jv)
+#endif
+{
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  ::validation::NoRules v;
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  {
+    using nlohmann::from_json;
+    from_json(jv.at("value"), v.value);
+  }
+  #else
+  v.value = boost::json::value_to<int32_t>(jv.at("value"));
+  #endif
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+  #else
+  return v;
+  #endif
+}
+
+
+}
+
+namespace jsonif {
+
+template<>
+struct type_name<::validation::Person> {
+  static constexpr const char* value = "validation.Person";
+};
+template<>
+struct type_name<::validation::Team> {
+  static constexpr const char* value = "validation.Team";
+};
+template<>
+struct type_name<::validation::Club> {
+  static constexpr const char* value = "validation.Club";
+};
+template<>
+struct type_name<::validation::League> {
+  static constexpr const char* value = "validation.League";
+};
+template<>
+struct type_name<::validation::NoRules> {
+  static constexpr const char* value = "validation.NoRules";
+};
+
+}
+
+#ifndef JSONIF_HELPER_DEFINED
+#define JSONIF_HELPER_DEFINED
+
+namespace jsonif {
+
+template<class T>
+inline T from_json(const std::string& s) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    return nlohmann::json::parse(s).get<T>();
+  #else
+    return boost::json::value_to<T>(boost::json::parse(s));
+  #endif
+}
+
+template<class T>
+inline std::string to_json(const T& v) {
+  #if defined(JSONIF_USE_NLOHMANN_JSON)
+    nlohmann::json j = v;
+    return j.dump();
+  #else
+    return boost::json::serialize(boost::json::value_from(v));
+  #endif
+}
+
+}
+
+#endif
+
+#endif
@@ -0,0 +1,533 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// プラグインパラメータ
+type options struct {
+	internal.CommonOptions
+}
+
+func newOptionSet(opts *options) *internal.OptionSet {
+	s := &internal.OptionSet{}
+	opts.CommonOptions.Register(s)
+	return s
+}
+
+const schemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// キーの順序を保ったまま出力する JSON オブジェクト
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	Key   string
+	Value interface{}
+}
+
+// 既にあるキーの場合は、順序を変えずに値を置き換える
+func (o *jsonObject) Set(key string, value interface{}) {
+	for i := range *o {
+		if (*o)[i].Key == key {
+			(*o)[i].Value = value
+			return
+		}
+	}
+	*o = append(*o, jsonMember{key, value})
+}
+
+// コメントに含まれる < や & をそのまま出力するために HTML のエスケープはしない
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, m := range o {
+		if i != 0 {
+			buf.WriteString(",")
+		}
+		key, err := marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// メッセージごとに出力するファイル名
+// 他のメッセージからは $ref でこのファイル名を参照する
+func toSchemaFileName(msg *internal.Message) string {
+	return msg.FullName + ".schema.json"
+}
+
+func toDescription(comments []string) string {
+	return strings.TrimSpace(strings.Join(comments, "\n"))
+}
+
+func toEnumSchema(enum *internal.Enum) jsonObject {
+	s := jsonObject{}
+	s.Set("title", enum.FullName)
+	if desc := toDescription(enum.Comments); len(desc) != 0 {
+		s.Set("description", desc)
+	}
+	var values []interface{}
+	for _, v := range enum.Values {
+		values = append(values, v.Number)
+	}
+	if enum.AsName {
+		// 値の名前で出力するが、数値も読み込める
+		s.Set("type", []string{"integer", "string"})
+		for _, v := range enum.Values {
+			values = append(values, v.Name)
+		}
+	} else {
+		s.Set("type", "integer")
+	}
+	s.Set("enum", values)
+	return s
+}
+
+// フィールドの値のスキーマ（repeated の場合は要素のスキーマ）を返す
+func toValueSchema(field *internal.Field, defs map[string]*internal.Enum) (jsonObject, error) {
+	s := jsonObject{}
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		s.Set("type", "number")
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		s.Set("type", "integer")
+		s.Set("minimum", json.Number("-2147483648"))
+		s.Set("maximum", json.Number("2147483647"))
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		s.Set("type", "integer")
+		s.Set("minimum", json.Number("0"))
+		s.Set("maximum", json.Number("4294967295"))
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		s.Set("type", toInt64Type(field))
+		s.Set("minimum", json.Number("-9223372036854775808"))
+		s.Set("maximum", json.Number("9223372036854775807"))
+		if field.Int64AsString {
+			s.Set("pattern", "^(0|-?[1-9][0-9]*)$")
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		s.Set("type", toInt64Type(field))
+		s.Set("minimum", json.Number("0"))
+		s.Set("maximum", json.Number("18446744073709551615"))
+		if field.Int64AsString {
+			s.Set("pattern", "^(0|[1-9][0-9]*)$")
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		s.Set("type", "boolean")
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		s.Set("type", "string")
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		s.Set("type", "string")
+		if field.Base64URL {
+			s.Set("contentEncoding", "base64url")
+		} else {
+			s.Set("contentEncoding", "base64")
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		defs[field.Enum.FullName] = field.Enum
+		s.Set("$ref", "#/$defs/"+field.Enum.FullName)
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		if field.WellKnownType() != internal.NotWellKnown {
+			return toWellKnownSchema(field.Message, defs)
+		}
+		s.Set("$ref", toSchemaFileName(field.Message))
+	case descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		s.Set("$ref", toSchemaFileName(field.Message))
+	default:
+		return nil, errors.New("invalid type")
+	}
+	return s, nil
+}
+
+// 64bit 整数は文字列で出力する場合があるが、数値も読み込める
+// minimum と maximum は数値、pattern は文字列にだけ適用される
+func toInt64Type(field *internal.Field) interface{} {
+	if field.Int64AsString {
+		return []string{"integer", "string"}
+	}
+	return "integer"
+}
+
+// well-known type は $ref で参照せず、JSON での表現をそのままスキーマにする
+func toWellKnownSchema(msg *internal.Message, defs map[string]*internal.Enum) (jsonObject, error) {
+	s := jsonObject{}
+	switch msg.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		s.Set("type", "string")
+		s.Set("format", "date-time")
+	case internal.WellKnownDuration:
+		s.Set("type", "string")
+		s.Set("pattern", `^-?[0-9]+(\.[0-9]{1,9})?s$`)
+	case internal.WellKnownWrapper:
+		value, err := toValueSchema(msg.WrapperValue(), defs)
+		if err != nil {
+			return nil, err
+		}
+		s.Set("anyOf", []interface{}{value, jsonObject{{"type", "null"}}})
+	case internal.WellKnownStruct:
+		s.Set("type", []string{"object", "null"})
+	case internal.WellKnownListValue:
+		s.Set("type", []string{"array", "null"})
+	case internal.WellKnownValue:
+		// 任意の JSON の値を受け付ける
+	case internal.WellKnownAny:
+		// "@type" 以外のフィールドは型によって異なるので調べない
+		s.Set("type", []string{"object", "null"})
+		s.Set("properties", jsonObject{{"@type", jsonObject{{"type", "string"}}}})
+		s.Set("required", []string{"@type"})
+	default:
+		return nil, errors.New("not well-known type")
+	}
+	return s, nil
+}
+
+// JSON のキーは文字列なので、整数や bool のキーはパターンで制限する
+func toMapKeySchema(key *internal.Field) jsonObject {
+	s := jsonObject{}
+	switch key.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		s.Set("enum", []string{"true", "false"})
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		s.Set("pattern", "^(0|[1-9][0-9]*)$")
+	default:
+		s.Set("pattern", "^(0|-?[1-9][0-9]*)$")
+	}
+	return s
+}
+
+// 検証ルール（jsonif_min など）を値のスキーマに追加する
+// enum で定義されている値であることは、元から enum のスキーマで制限している
+func setValueValidation(s jsonObject, field *internal.Field) jsonObject {
+	v := field.Validation
+	if v == nil {
+		return s
+	}
+	if len(v.Min) != 0 {
+		s.Set("minimum", json.Number(v.Min))
+	}
+	if len(v.Max) != 0 {
+		s.Set("maximum", json.Number(v.Max))
+	}
+	// bytes は base64 の文字列になるので、長さはスキーマでは制限しない
+	if field.ValueField().Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		if v.MinLen >= 0 {
+			s.Set("minLength", v.MinLen)
+		}
+		if v.MaxLen >= 0 {
+			s.Set("maxLength", v.MaxLen)
+		}
+		if len(v.Pattern) != 0 {
+			s.Set("pattern", v.Pattern)
+		}
+	}
+	return s
+}
+
+func toFieldSchema(field *internal.Field, defs map[string]*internal.Enum) (jsonObject, error) {
+	if field.IsMap() {
+		value, err := toValueSchema(field.MapValue, defs)
+		if err != nil {
+			return nil, err
+		}
+		value = setValueValidation(value, field)
+		s := jsonObject{}
+		if desc := toDescription(field.Comments); len(desc) != 0 {
+			s.Set("description", desc)
+		}
+		if field.Deprecated {
+			s.Set("deprecated", true)
+		}
+		s.Set("type", "object")
+		if key := toMapKeySchema(field.MapKey); key != nil {
+			s.Set("propertyNames", key)
+		}
+		s.Set("additionalProperties", value)
+		if v := field.Validation; v != nil && v.MinItems >= 0 {
+			s.Set("minProperties", v.MinItems)
+		}
+		if v := field.Validation; v != nil && v.MaxItems >= 0 {
+			s.Set("maxProperties", v.MaxItems)
+		}
+		return s, nil
+	}
+
+	value, err := toValueSchema(field, defs)
+	if err != nil {
+		return nil, err
+	}
+	value = setValueValidation(value, field)
+	s := jsonObject{}
+	if desc := toDescription(field.Comments); len(desc) != 0 {
+		s.Set("description", desc)
+	}
+	if field.Deprecated {
+		s.Set("deprecated", true)
+	}
+	if field.Repeated {
+		s.Set("type", "array")
+		s.Set("items", value)
+		if v := field.Validation; v != nil && v.MinItems >= 0 {
+			s.Set("minItems", v.MinItems)
+		}
+		if v := field.Validation; v != nil && v.MaxItems >= 0 {
+			s.Set("maxItems", v.MaxItems)
+		}
+	} else if field.Optional && field.Validation != nil && field.Validation.Required {
+		// 値が必須の optional フィールドは null にならない
+		s = append(s, value...)
+	} else if field.Optional {
+		// TypeScript は値が設定されていない optional フィールドを null として出力する
+		s.Set("anyOf", []interface{}{value, jsonObject{{"type", "null"}}})
+	} else {
+		s = append(s, value...)
+	}
+	if field.HasDefault {
+		s.Set("default", toDefaultValue(field))
+	}
+	return s, nil
+}
+
+// jsonif_default で指定された値を、JSON に出力される時と同じ形で返す
+func toDefaultValue(field *internal.Field) interface{} {
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return field.Default == "true"
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return field.Default
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		if field.Enum.AsName {
+			return field.DefaultEnum.Name
+		}
+	}
+	if field.Int64AsString {
+		return field.Default
+	}
+	return json.Number(field.Default)
+}
+
+func toOneofSchema(oneof *internal.Oneof) jsonObject {
+	s := jsonObject{}
+	if desc := toDescription(oneof.Comments); len(desc) != 0 {
+		s.Set("description", desc)
+	}
+	s.Set("type", "integer")
+	// NOT_SET と各フィールドの番号
+	values := []int32{0}
+	for _, field := range oneof.Fields {
+		values = append(values, field.Number)
+	}
+	s.Set("enum", values)
+	return s
+}
+
+func genMessage(msg *internal.Message) (*pluginpb.CodeGeneratorResponse_File, error) {
+	defs := map[string]*internal.Enum{}
+	properties := jsonObject{}
+	var required []string
+	for _, field := range msg.Fields {
+		s, err := toFieldSchema(field, defs)
+		if err != nil {
+			return nil, err
+		}
+		properties.Set(field.JsonKey, s)
+		// oneof に属しているフィールドと optimistic なフィールドは、無くてもデシリアライズできる
+		if field.Oneof == nil && !field.Optimistic {
+			required = append(required, field.JsonKey)
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		// optional はキーの有無で設定されているかを表すので _case は無い
+		if oneof.Synthetic {
+			continue
+		}
+		key := internal.ToSnakeCase(oneof.Name) + "_case"
+		properties.Set(key, toOneofSchema(oneof))
+		// 設定されているフィールドだけを出力する oneof は _case を出力しないが、互換性のため読み込める
+		if !oneof.ActiveOnly {
+			required = append(required, key)
+		}
+	}
+
+	s := jsonObject{}
+	s.Set("$schema", schemaVersion)
+	s.Set("$id", toSchemaFileName(msg))
+	s.Set("title", msg.FullName)
+	if desc := toDescription(msg.Comments); len(desc) != 0 {
+		s.Set("description", desc)
+	}
+	if msg.Deprecated {
+		s.Set("deprecated", true)
+	}
+	s.Set("type", "object")
+	s.Set("properties", properties)
+	if len(required) != 0 {
+		s.Set("required", required)
+	}
+	// strict なメッセージは知らないキーを受け付けない（"@type" は google.protobuf.Any に詰めた時に付く）
+	if msg.Strict && !msg.KeepUnknownFields {
+		s.Set("patternProperties", jsonObject{{"^@type$", jsonObject{{"type", "string"}}}})
+		s.Set("additionalProperties", false)
+	}
+	if len(defs) != 0 {
+		// 出力が毎回同じになるように名前順に並べる
+		var names []string
+		for name := range defs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		d := jsonObject{}
+		for _, name := range names {
+			d.Set(name, toEnumSchema(defs[name]))
+		}
+		s.Set("$defs", d)
+	}
+
+	b, err := marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+
+	fileName := toSchemaFileName(msg)
+	content := buf.String()
+	return &pluginpb.CodeGeneratorResponse_File{
+		Name:    &fileName,
+		Content: &content,
+	}, nil
+}
+
+func genMessages(msg *internal.Message, resp *pluginpb.CodeGeneratorResponse) error {
+	respFile, err := genMessage(msg)
+	if err != nil {
+		return err
+	}
+	resp.File = append(resp.File, respFile)
+	for _, nested := range msg.Messages {
+		// map のエントリは map フィールドの中に展開するので、スキーマを出力しない
+		if nested.MapEntry {
+			continue
+		}
+		if err := genMessages(nested, resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkMessageNames(msg *internal.Message) error {
+	// JSON のキー
+	keys := &internal.NameScope{}
+	for _, field := range msg.Fields {
+		if err := keys.Add(field.JsonKey, field); err != nil {
+			return err
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		if oneof.Synthetic {
+			continue
+		}
+		if err := keys.Add(internal.ToSnakeCase(oneof.Name)+"_case", oneof); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if err := checkMessageNames(nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 出力する JSON のキーが衝突していないか調べる
+func checkNames(schema *internal.Schema) error {
+	for _, file := range schema.Files {
+		for _, msg := range file.Messages {
+			if err := checkMessageNames(msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func gen(req *pluginpb.CodeGeneratorRequest, opts *options) (*pluginpb.CodeGeneratorResponse, error) {
+	resp := &pluginpb.CodeGeneratorResponse{}
+	resp.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
+	schema, err := internal.NewSchema(req.ProtoFile, &opts.CommonOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNames(schema); err != nil {
+		return nil, err
+	}
+	for _, file := range schema.FilesToGenerate(req, &opts.CommonOptions) {
+		// well-known type は組み込み型に変換するので、定義ファイルのコードは出力しない
+		if file.IsWellKnown() {
+			continue
+		}
+		for _, msg := range file.Messages {
+			if err := genMessages(msg, resp); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
+
+func main() {
+	opts := &options{}
+	err := internal.RunPlugin(newOptionSet(opts), func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return gen(req, opts)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
+		os.Exit(1)
+	}
+}
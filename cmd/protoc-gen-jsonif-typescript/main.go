@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -13,124 +14,186 @@ import (
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
+// プラグインパラメータ
+type options struct {
+	internal.CommonOptions
+	// deprecated なフィールドのキーが JSON にあった場合に jsonif.reportDeprecatedField を呼ぶ
+	ReportDeprecated bool
+}
+
+func newOptionSet(opts *options) *internal.OptionSet {
+	s := &internal.OptionSet{}
+	opts.CommonOptions.Register(s)
+	s.Bool("report_deprecated", &opts.ReportDeprecated)
+	return s
+}
+
 type typescriptFile struct {
 	Top    internal.Formatter
 	Bottom internal.Formatter
 	Body   internal.Formatter
+	// jsonif.registerType で登録するクラス
+	Messages []string
 }
 
 func (u *typescriptFile) String() string {
 	return u.Top.String() + u.Body.String() + u.Bottom.String()
 }
 
-type pkgInfo struct {
-	fullnameToType    map[string]string
-	fullnameToPackage map[string]string
-	packageToAlias    map[string]string
-	filenameToAlias   map[string]string
+// 予約語や組み込みの型名と同じ名前の場合は後ろに _ を付ける
+func escapeName(name string) string {
+	if internal.TypeScriptKeywords[name] {
+		return name + "_"
+	}
+	return name
 }
 
-func newPkgInfo() *pkgInfo {
-	return &pkgInfo{
-		fullnameToType:    make(map[string]string),
-		fullnameToPackage: make(map[string]string),
-		packageToAlias:    make(map[string]string),
-		filenameToAlias:   make(map[string]string),
+// クラスのプロパティ名
+// プロパティ名には予約語も使えるので、エスケープが必要なのは constructor だけ。
+// JSON のキー（XxxObject のキー）には元のフィールド名を使う
+func toPropertyName(field *internal.Field) string {
+	if field.Name == "constructor" {
+		return field.Name + "_"
 	}
+	return field.Name
 }
 
-func (p *pkgInfo) enumEnum(enum *descriptorpb.EnumDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto) {
-	fullname := "." + *pkg + "." + toClassName(parents, *enum.Name)
-	p.fullnameToType[fullname] = toLocalClassName(parents, *enum.Name)
-	p.fullnameToPackage[fullname] = *pkg
+// インポート時のエイリアス（foo.bar を foo_bar に変換する）
+func packageToAlias(pkg string) string {
+	return escapeName(strings.ReplaceAll(pkg, ".", "_"))
 }
-func (p *pkgInfo) enumDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto) {
-	fullname := "." + *pkg + "." + toClassName(parents, *desc.Name)
-	p.fullnameToType[fullname] = toLocalClassName(parents, *desc.Name)
-	p.fullnameToPackage[fullname] = *pkg
-	for _, enum := range desc.EnumType {
-		p.enumEnum(enum, pkg, append(parents, desc))
-	}
-	for _, nested := range desc.NestedType {
-		p.enumDescriptor(nested, pkg, append(parents, desc))
+
+// 処理中のパッケージから見た型名を返す
+func toTypeRef(pkg string, file *internal.File, parents []*internal.Message, name string) string {
+	name = toLocalClassName(parents, name)
+	// 処理中のパッケージと、このクラスのパッケージ名が一致してたら name をそのまま返す
+	if pkg == file.Package {
+		return name
 	}
+	// 処理中のパッケージと、このクラスのパッケージ名が一致してない場合、インポート時のエイリアスを付け加える
+	return packageToAlias(file.Package) + "." + name
 }
 
-func (p *pkgInfo) addFile(file *descriptorpb.FileDescriptorProto) {
-	alias := strings.ReplaceAll(*file.Package, ".", "_")
-	p.packageToAlias[*file.Package] = alias
-	p.filenameToAlias[*file.Name] = alias
-	for _, enum := range file.EnumType {
-		p.enumEnum(enum, file.Package, nil)
+// toLocalClassName([Foo, Bar], Baz) を Foo_Bar_Baz に変換する
+func toLocalClassName(parents []*internal.Message, name string) string {
+	var xs []string
+	for _, parent := range parents {
+		xs = append(xs, parent.Name)
 	}
-	for _, desc := range file.MessageType {
-		p.enumDescriptor(desc, file.Package, nil)
+	xs = append(xs, name)
+	return escapeName(strings.Join(xs, "_"))
+}
+
+// optional のための oneof はフィールドを生成しないので、それ以外の oneof を返す
+func getOneofs(msg *internal.Message) []*internal.Oneof {
+	var r []*internal.Oneof
+	for _, oneof := range msg.Oneofs {
+		if !oneof.Synthetic {
+			r = append(r, oneof)
+		}
 	}
+	return r
 }
 
-func (p *pkgInfo) findTypeName(pkg string, typeName string) (string, error) {
-	var name string
-	var targetPkg string
-	var ok bool
-	if name, ok = p.fullnameToType[typeName]; !ok {
-		return "", errors.New("not found")
+// メッセージのクラスになるフィールドかどうか
+// well-known type は Date などの組み込み型になるので、メッセージとしては扱わない
+func isMessageField(field *internal.Field) bool {
+	return field.IsMessage() && field.WellKnownType() == internal.NotWellKnown
+}
+
+// JSON の値とクラスのプロパティの値が異なる場合に、値を変換する式を返す
+// Timestamp と Duration は文字列、bytes は base64 になる。canonical の場合は enum の名前、int64 の文字列も変換する
+// 変換しないフィールドは nil を返す
+func toConverter(pkg string, field *internal.Field, toObject bool) func(expr string) string {
+	name := ""
+	switch {
+	case field.WellKnownType() == internal.WellKnownTimestamp:
+		name = "timestamp"
+	case field.WellKnownType() == internal.WellKnownDuration:
+		name = "duration"
+	case field.Enum != nil && field.Enum.AsName:
+		enumType := toTypeRef(pkg, field.Enum.File, field.Enum.Parents(), field.Enum.Name)
+		if toObject {
+			return func(expr string) string { return fmt.Sprintf("jsonif.enumToJson(%s, %s)", enumType, expr) }
+		}
+		return func(expr string) string { return fmt.Sprintf("jsonif.enumFromJson(%s, %s)", enumType, expr) }
+	case field.Int64AsString:
+		name = "int64"
+	case field.Base64:
+		name = "bytes"
+		// 読み込みはどちらの base64 も受け付けるので、出力だけ変える
+		if toObject && field.Base64URL {
+			name = "bytesUrl"
+		}
+	default:
+		return nil
 	}
-	if targetPkg, ok = p.fullnameToPackage[typeName]; !ok {
-		return "", errors.New("not found")
+	if toObject {
+		name += "ToJson"
+	} else {
+		name += "FromJson"
 	}
-	// 処理中のパッケージと、このクラスのパッケージ名が一致してたら name をそのまま返す
-	if pkg == targetPkg {
-		return name, nil
+	// ラッパー型は null の場合があるので、null はそのままにする
+	if field.WellKnownType() == internal.WellKnownWrapper {
+		return func(expr string) string { return fmt.Sprintf("%s === null ? null : jsonif.%s(%s)", expr, name, expr) }
 	}
-	// 処理中のパッケージと、このクラスのパッケージ名が一致してない場合、インポート時のエイリアスを付け加える
-	var alias string
-	if alias, ok = p.packageToAlias[targetPkg]; !ok {
-		return "", errors.New("not found")
+	return func(expr string) string { return fmt.Sprintf("jsonif.%s(%s)", name, expr) }
+}
+
+// XxxObject のキー
+// canonical の場合は JSON のキー名（lowerCamelCase か json_name）、それ以外は元のフィールド名を使う
+func toObjectKey(field *internal.Field, opts *options) string {
+	if opts.Canonical {
+		return field.JsonKey
 	}
-	return alias + "." + name, nil
+	return field.Name
 }
 
-// toLocalClassName([Foo, Bar], Baz) を Foo_Bar_Baz に変換する
-func toLocalClassName(parents []*descriptorpb.DescriptorProto, name string) string {
-	var xs []string
-	for _, parent := range parents {
-		xs = append(xs, *parent.Name)
+// 識別子として使えないキーは文字列にする
+func quoteKey(key string) string {
+	for i, c := range key {
+		if !(c == '_' || c == '$' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i != 0 && '0' <= c && c <= '9') {
+			return fmt.Sprintf("%q", key)
+		}
 	}
-	xs = append(xs, name)
-	return strings.Join(xs, "_")
+	return key
 }
 
-// toClassName([Foo, Bar], Baz) を Foo.Bar.Baz に変換する
-func toClassName(parents []*descriptorpb.DescriptorProto, name string) string {
-	var xs []string
-	for _, parent := range parents {
-		xs = append(xs, *parent.Name)
+// obj のキー key にアクセスする式
+func accessKey(obj string, key string) string {
+	if quoteKey(key) != key {
+		return fmt.Sprintf("%s[%q]", obj, key)
 	}
-	xs = append(xs, name)
-	return strings.Join(xs, ".")
+	return obj + "." + key
 }
 
-func getOneofFields(fields []*descriptorpb.FieldDescriptorProto, i int) []*descriptorpb.FieldDescriptorProto {
-	var r []*descriptorpb.FieldDescriptorProto
-	for _, field := range fields {
-		if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-			if field.Proto3Optional != nil && *field.Proto3Optional {
-				// optional だった場合はフィールドを生成しない
-			} else {
-				r = append(r, field)
-			}
+// jsonif.ts を import する必要があるかどうか
+// メッセージは Any で使えるように jsonif.registerType で登録するので、メッセージがあれば常に使う
+// （Timestamp, Duration, Struct などのフィールドはメッセージにしか無い）
+func usesJsonifFile(file *internal.File) bool {
+	return len(file.Messages) != 0
+}
+
+func toTypeName(pkg string, field *internal.Field, forObject bool) (string, string, bool, error) {
+	// map は JSON ではオブジェクトになるので、キーは常に文字列になる
+	if field.IsMap() {
+		keyTypeName, _, _, err := toTypeName(pkg, field.MapKey, forObject)
+		if err != nil {
+			return "", "", false, err
+		}
+		valueTypeName, _, _, err := toTypeName(pkg, field.MapValue, forObject)
+		if err != nil {
+			return "", "", false, err
 		}
+		if forObject {
+			return fmt.Sprintf("{ [key: string]: %s }", valueTypeName), "{}", false, nil
+		}
+		return fmt.Sprintf("Map<%s, %s>", keyTypeName, valueTypeName), "new Map()", false, nil
 	}
-	return r
-}
 
-func toTypeName(pkg *string, pkgInfo *pkgInfo, field *descriptorpb.FieldDescriptorProto, forObject bool) (string, string, bool, error) {
-	isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
-	isOptional := field.Proto3Optional != nil && *field.Proto3Optional
 	typeName := ""
 	defaultValue := ""
-	var err error
-	switch *field.Type {
+	switch field.Type {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
 		descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
 		descriptorpb.FieldDescriptorProto_TYPE_INT32,
@@ -145,6 +208,10 @@ func toTypeName(pkg *string, pkgInfo *pkgInfo, field *descriptorpb.FieldDescript
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		typeName = "number"
 		defaultValue = "0"
+		// 文字列の int64 も数値の int64 も読み込めるようにする
+		if forObject && field.Int64AsString {
+			typeName = "string | number"
+		}
 	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
 		typeName = "boolean"
 		defaultValue = "false"
@@ -154,18 +221,29 @@ func toTypeName(pkg *string, pkgInfo *pkgInfo, field *descriptorpb.FieldDescript
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
 		typeName = "Uint8Array"
 		defaultValue = "new Uint8Array(0)"
-	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
-		typeName, err = pkgInfo.findTypeName(*pkg, *field.TypeName)
-		if err != nil {
-			return "", "", false, fmt.Errorf("type not found: %s", *field.TypeName)
+		// JSON では base64 の文字列になる
+		if forObject {
+			typeName = "string"
 		}
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		typeName = toTypeRef(pkg, field.Enum.File, field.Enum.Parents(), field.Enum.Name)
 		defaultValue = "0"
-	case descriptorpb.FieldDescriptorProto_TYPE_GROUP,
-		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		typeName, err = pkgInfo.findTypeName(*pkg, *field.TypeName)
-		if err != nil {
-			return "", "", false, fmt.Errorf("type not found: %s", *field.TypeName)
+		// 名前の文字列も数値も読み込めるようにする
+		if forObject && field.Enum.AsName {
+			typeName = "string | number"
 		}
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		if field.WellKnownType() != internal.NotWellKnown {
+			var err error
+			typeName, defaultValue, err = toWellKnownTypeName(pkg, field, forObject)
+			if err != nil {
+				return "", "", false, err
+			}
+			break
+		}
+		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		typeName = toTypeRef(pkg, field.Message.File, field.Message.Parents(), field.Message.Name)
 		defaultValue = fmt.Sprintf("new %s()", typeName)
 		if forObject {
 			typeName += "Object"
@@ -174,94 +252,268 @@ func toTypeName(pkg *string, pkgInfo *pkgInfo, field *descriptorpb.FieldDescript
 		return "", "", false, errors.New("invalid type")
 	}
 
-	if isRepeated {
+	if field.HasDefault {
+		defaultValue = toDefaultLiteral(field)
+	}
+	if field.Repeated {
+		if strings.Contains(typeName, "|") {
+			typeName = "(" + typeName + ")"
+		}
 		typeName = typeName + "[]"
 		defaultValue = "[]"
 	}
-	if isOptional {
+	if field.Optional {
 		defaultValue = "null"
 	}
 
-	return typeName, defaultValue, isOptional, nil
+	return typeName, defaultValue, field.Optional, nil
+}
+
+// jsonif_default で指定された値のリテラル
+// enum は値の番号になる
+func toDefaultLiteral(field *internal.Field) string {
+	if field.Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		b, _ := json.Marshal(field.Default)
+		return string(b)
+	}
+	return field.Default
+}
+
+// well-known type の型名とデフォルト値を返す
+// Timestamp は Date、Duration はミリ秒の number、ラッパー型は値の型か null になる
+// Struct, ListValue, Value は JSON の値をそのまま保持する
+func toWellKnownTypeName(pkg string, field *internal.Field, forObject bool) (string, string, error) {
+	switch field.WellKnownType() {
+	case internal.WellKnownStruct:
+		return "{ [key: string]: jsonif.JsonValue } | null", "null", nil
+	case internal.WellKnownListValue:
+		return "jsonif.JsonValue[] | null", "null", nil
+	case internal.WellKnownValue:
+		return "jsonif.JsonValue", "null", nil
+	case internal.WellKnownAny:
+		return "jsonif.Any | null", "null", nil
+	case internal.WellKnownTimestamp:
+		if forObject {
+			return "string", "", nil
+		}
+		return "Date", "new Date(0)", nil
+	case internal.WellKnownDuration:
+		if forObject {
+			return "string", "", nil
+		}
+		return "number", "0", nil
+	case internal.WellKnownWrapper:
+		typeName, _, _, err := toTypeName(pkg, field.Message.WrapperValue(), forObject)
+		if err != nil {
+			return "", "", err
+		}
+		return typeName + " | null", "null", nil
+	}
+	return "", "", errors.New("not well-known type")
 }
 
-func genEnum(enum *descriptorpb.EnumDescriptorProto, parents []*descriptorpb.DescriptorProto, u *typescriptFile) error {
-	u.Body.PI("export enum %s {", toLocalClassName(parents, *enum.Name))
-	for _, v := range enum.Value {
-		u.Body.P("%s = %d,", *v.Name, *v.Number)
+// proto のコメントを TSDoc として出力する
+func genComment(f *internal.Formatter, comments []string) {
+	if len(comments) == 0 {
+		return
+	}
+	f.P("/**")
+	for _, line := range comments {
+		if len(line) == 0 {
+			f.P(" *")
+		} else {
+			// コメントが途中で閉じられないようにする
+			f.P(" * %s", strings.ReplaceAll(line, "*/", "*\\/"))
+		}
+	}
+	f.P(" */")
+}
+
+// deprecated な要素は TSDoc の @deprecated タグを付ける
+func withDeprecated(comments []string, deprecated bool) []string {
+	if !deprecated {
+		return comments
+	}
+	if len(comments) == 0 {
+		return []string{"@deprecated"}
+	}
+	return append(append(comments[:len(comments):len(comments)], ""), "@deprecated")
+}
+
+// JSON のキー（文字列）k を map のキーに変換する式
+func toMapKey(field *internal.Field) string {
+	switch field.MapKey.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return "k"
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "k === \"true\""
+	default:
+		return "Number(k)"
+	}
+}
+
+func genEnum(enum *internal.Enum, u *typescriptFile) error {
+	genComment(&u.Body, enum.Comments)
+	u.Body.PI("export enum %s {", toLocalClassName(enum.Parents(), enum.Name))
+	for _, v := range enum.Values {
+		genComment(&u.Body, withDeprecated(v.Comments, v.Deprecated))
+		u.Body.P("%s = %d,", v.Name, v.Number)
 	}
 	u.Body.PD("}")
 	u.Body.P("")
 	return nil
 }
 
-func genOneofEnum(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.FieldDescriptorProto, parents []*descriptorpb.DescriptorProto, u *typescriptFile) error {
-	typeName := toLocalClassName(parents, internal.ToUpperCamel(*oneof.Name)) + "Case"
+func genOneofEnum(oneof *internal.Oneof, u *typescriptFile) error {
+	typeName := toLocalClassName(append(oneof.Parent.Parents(), oneof.Parent), internal.ToUpperCamel(oneof.Name)) + "Case"
+	genComment(&u.Body, oneof.Comments)
 	u.Body.PI("export enum %s {", typeName)
 	u.Body.P("NOT_SET = 0,")
-	for _, field := range fields {
-		u.Body.P("k%s = %d,", internal.ToUpperCamel(*field.Name), *field.Number)
+	for _, field := range oneof.Fields {
+		u.Body.P("k%s = %d,", internal.ToUpperCamel(field.Name), field.Number)
 	}
 	u.Body.PD("}")
 	u.Body.P("")
 	return nil
 }
 
-func genOneof(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.FieldDescriptorProto, pkg *string, pkgInfo *pkgInfo, parents []*descriptorpb.DescriptorProto, u *typescriptFile) error {
-	typeName := toLocalClassName(parents, internal.ToUpperCamel(*oneof.Name)) + "Case"
-	fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+func genOneof(oneof *internal.Oneof, pkg string, u *typescriptFile) error {
+	typeName := toLocalClassName(append(oneof.Parent.Parents(), oneof.Parent), internal.ToUpperCamel(oneof.Name)) + "Case"
+	fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
 	u.Body.P("%s: %s = %s.NOT_SET;", fieldName, typeName, typeName)
-	u.Body.PI("clear%s() {", internal.ToUpperCamel(*oneof.Name))
+	u.Body.PI("clear%s() {", internal.ToUpperCamel(oneof.Name))
 	u.Body.P("this.%s = %s.NOT_SET;", fieldName, typeName)
-	for _, field := range fields {
-		_, defaultValue, _, err := toTypeName(pkg, pkgInfo, field, false)
+	for _, field := range oneof.Fields {
+		_, defaultValue, _, err := toTypeName(pkg, field, false)
 		if err != nil {
 			return err
 		}
-		u.Body.P("this.%s = %s;", *field.Name, defaultValue)
+		u.Body.P("this.%s = %s;", toPropertyName(field), defaultValue)
 	}
 	u.Body.PD("}")
-	for _, field := range fields {
-		fieldTypeName, _, _, err := toTypeName(pkg, pkgInfo, field, false)
+	for _, field := range oneof.Fields {
+		fieldTypeName, _, _, err := toTypeName(pkg, field, false)
 		if err != nil {
 			return err
 		}
-		u.Body.PI("set%s(value: %s) {", internal.ToUpperCamel(*field.Name), fieldTypeName)
-		u.Body.P("this.%s = %s.k%s;", fieldName, typeName, internal.ToUpperCamel(*field.Name))
-		u.Body.P("this.%s = value;", *field.Name)
+		genComment(&u.Body, withDeprecated(nil, field.Deprecated))
+		u.Body.PI("set%s(value: %s) {", internal.ToUpperCamel(field.Name), fieldTypeName)
+		u.Body.P("this.%s = %s.k%s;", fieldName, typeName, internal.ToUpperCamel(field.Name))
+		u.Body.P("this.%s = value;", toPropertyName(field))
 		u.Body.PD("}")
-		u.Body.PI("clear%s() {", internal.ToUpperCamel(*field.Name))
-		u.Body.PI("if (this.%s === %s.k%s) {", fieldName, typeName, internal.ToUpperCamel(*field.Name))
-		u.Body.P("this.clear%s();", internal.ToUpperCamel(*oneof.Name))
+		genComment(&u.Body, withDeprecated(nil, field.Deprecated))
+		u.Body.PI("clear%s() {", internal.ToUpperCamel(field.Name))
+		u.Body.PI("if (this.%s === %s.k%s) {", fieldName, typeName, internal.ToUpperCamel(field.Name))
+		u.Body.P("this.clear%s();", internal.ToUpperCamel(oneof.Name))
 		u.Body.PD("}")
 		u.Body.PD("}")
 	}
 	return nil
 }
 
-func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, pkgInfo *pkgInfo, parents []*descriptorpb.DescriptorProto, u *typescriptFile) error {
-	for _, nested := range desc.NestedType {
-		if err := genDescriptor(nested, pkg, pkgInfo, append(parents, desc), u); err != nil {
+// toObject で出力する値の式
+func toObjectValue(pkg string, field *internal.Field) string {
+	if field.IsMap() {
+		return escapeName(field.Name)
+	}
+	isRepeated := field.Repeated
+	isMessage := isMessageField(field)
+	converter := toConverter(pkg, field, true)
+	value := "this." + toPropertyName(field)
+	expr := value
+	if isRepeated && isMessage {
+		expr = fmt.Sprintf("%s.map((x) => x.toObject())", value)
+	} else if !isRepeated && isMessage {
+		expr = fmt.Sprintf("%s.toObject()", value)
+	} else if isRepeated && converter != nil {
+		expr = fmt.Sprintf("%s.map((x) => %s)", value, converter("x"))
+	} else if !isRepeated && converter != nil {
+		expr = converter(value)
+	}
+	if field.Optional && expr != value {
+		expr = fmt.Sprintf("%s === null ? null : %s", value, expr)
+	}
+	return expr
+}
+
+// active only の oneof があるかどうか
+func hasActiveOnlyOneof(msg *internal.Message) bool {
+	for _, oneof := range msg.Oneofs {
+		if oneof.ActiveOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// toObject で、フィールドがデフォルト値ではない、または oneof で設定されている（出力する）条件
+func toNonDefaultCondition(msg *internal.Message, field *internal.Field) string {
+	value := "this." + toPropertyName(field)
+	if oneof := field.Oneof; oneof != nil && !oneof.Synthetic && oneof.ActiveOnly {
+		oneofTypeName := toLocalClassName(append(msg.Parents(), msg), internal.ToUpperCamel(oneof.Name)) + "Case"
+		oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		return fmt.Sprintf("this.%s === %s.k%s", oneofFieldName, oneofTypeName, internal.ToUpperCamel(field.Name))
+	}
+	if field.HasDefault {
+		return value + " !== " + toDefaultLiteral(field)
+	}
+	switch {
+	case field.IsMap():
+		return value + ".size !== 0"
+	case field.Repeated:
+		return value + ".length !== 0"
+	case field.Optional:
+		return value + " !== null"
+	}
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		return value + ".getTime() !== 0"
+	case internal.WellKnownDuration:
+		return value + " !== 0"
+	case internal.NotWellKnown:
+	default:
+		return value + " !== null"
+	}
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return value
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return value + " !== \"\""
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return value + ".length !== 0"
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		// デフォルト値のフィールドは出力しないので、空のオブジェクトになればデフォルト値
+		return fmt.Sprintf("Object.keys(%s.toObject()).length !== 0", value)
+	}
+	return value + " !== 0"
+}
+
+func genDescriptor(msg *internal.Message, pkg string, u *typescriptFile, opts *options) error {
+	for _, nested := range msg.Messages {
+		// map のエントリは Map で表現するのでクラスを生成しない
+		if nested.MapEntry {
+			continue
+		}
+		if err := genDescriptor(nested, pkg, u, opts); err != nil {
 			return err
 		}
 	}
-	for i, oneof := range desc.OneofDecl {
-		fields := getOneofFields(desc.Field, i)
-		if len(fields) != 0 {
-			if err := genOneofEnum(oneof, fields, append(parents, desc), u); err != nil {
-				return err
-			}
+	for _, oneof := range getOneofs(msg) {
+		if err := genOneofEnum(oneof, u); err != nil {
+			return err
 		}
 	}
 
-	localClassName := toLocalClassName(parents, *desc.Name)
+	localClassName := toLocalClassName(msg.Parents(), msg.Name)
+	genComment(&u.Body, withDeprecated(msg.Comments, msg.Deprecated))
 	u.Body.PI("export type %sObject = {", localClassName)
-	for _, field := range desc.Field {
-		typeName, _, isOptional, err := toTypeName(pkg, pkgInfo, field, true)
+	for _, field := range msg.Fields {
+		typeName, _, isOptional, err := toTypeName(pkg, field, true)
 		if err != nil {
 			return err
 		}
-		fieldName := *field.Name
+		fieldName := quoteKey(toObjectKey(field, opts))
+		genComment(&u.Body, withDeprecated(field.Comments, field.Deprecated))
 		if isOptional {
 			u.Body.P("%s?: %s | null;", fieldName, typeName)
 		} else {
@@ -274,93 +526,126 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, pkgInfo *pkg
 		//	u.Body.P("%s?: %s;", oneofFieldName, oneofTypeName)
 		//}
 	}
-	for i, oneof := range desc.OneofDecl {
-		// optional の oneof はフィールドを生成しない
-		isOptional := false
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				if field.Proto3Optional != nil && *field.Proto3Optional {
-					isOptional = true
-				}
-			}
-		}
-		if isOptional {
-			continue
-		}
-
-		typeName := toLocalClassName(append(parents, desc), internal.ToUpperCamel(*oneof.Name)) + "Case"
-		fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+	for _, oneof := range getOneofs(msg) {
+		// active only の oneof でも、互換性のため _case を受け付ける
+		typeName := toLocalClassName(append(msg.Parents(), msg), internal.ToUpperCamel(oneof.Name)) + "Case"
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
 		u.Body.P("%s?: %s;", fieldName, typeName)
 	}
 	u.Body.PD("}")
 	u.Body.P("")
 
-	for _, enum := range desc.EnumType {
-		if err := genEnum(enum, append(parents, desc), u); err != nil {
+	for _, enum := range msg.Enums {
+		if err := genEnum(enum, u); err != nil {
 			return err
 		}
 	}
 
+	genComment(&u.Body, withDeprecated(msg.Comments, msg.Deprecated))
 	u.Body.PI("export class %s {", localClassName)
-	for _, field := range desc.Field {
-		typeName, defaultValue, isOptional, err := toTypeName(pkg, pkgInfo, field, false)
+	for _, field := range msg.Fields {
+		typeName, defaultValue, isOptional, err := toTypeName(pkg, field, false)
 		if err != nil {
 			return err
 		}
+		genComment(&u.Body, withDeprecated(field.Comments, field.Deprecated))
 		if isOptional {
-			u.Body.P("%s: %s | null = %s;", *field.Name, typeName, defaultValue)
+			u.Body.P("%s: %s | null = %s;", toPropertyName(field), typeName, defaultValue)
 		} else {
-			u.Body.P("%s: %s = %s;", *field.Name, typeName, defaultValue)
+			u.Body.P("%s: %s = %s;", toPropertyName(field), typeName, defaultValue)
 		}
 	}
-	for i, oneof := range desc.OneofDecl {
-		fields := getOneofFields(desc.Field, i)
-		if len(fields) != 0 {
-			if err := genOneof(oneof, fields, pkg, pkgInfo, append(parents, desc), u); err != nil {
-				return err
-			}
+	for _, oneof := range getOneofs(msg) {
+		if err := genOneof(oneof, pkg, u); err != nil {
+			return err
 		}
 	}
+	if msg.KeepUnknownFields {
+		genComment(&u.Body, []string{"JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）"})
+		u.Body.P("extra: Record<string, unknown> = {};")
+	}
+	if msg.KeepUnknownFields || msg.Strict {
+		u.Body.P("static readonly knownKeys: ReadonlySet<string> = new Set<string>([%s]);", strings.Join(toKnownKeys(msg, opts), ", "))
+	}
 
 	// constructor
 	u.Body.PI("constructor(obj: %sObject = {}) {", localClassName)
-	for _, field := range desc.Field {
-		u.Body.PI("if (obj.%s !== undefined) {", *field.Name)
+	for _, field := range msg.Fields {
+		objField := accessKey("obj", toObjectKey(field, opts))
+		u.Body.PI("if (%s !== undefined) {", objField)
+		if opts.ReportDeprecated && field.Deprecated {
+			u.Body.P("jsonif.reportDeprecatedField(\"%s\", \"%s\");", msg.FullName, toObjectKey(field, opts))
+		}
 
-		typeName, _, isOptional, err := toTypeName(pkg, pkgInfo, field, false)
+		typeName, _, isOptional, err := toTypeName(pkg, field, false)
 		if err != nil {
 			return err
 		}
 		if isOptional {
-			u.Body.PI("if (obj.%s !== null) {", *field.Name)
+			u.Body.PI("if (%s !== null) {", objField)
 		}
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
-		isMessage := *field.Type == descriptorpb.FieldDescriptorProto_TYPE_GROUP || *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
-		if isRepeated && isMessage {
+		isRepeated := field.Repeated
+		isMessage := isMessageField(field)
+		converter := toConverter(pkg, field, false)
+		if field.IsMap() {
+			u.Body.P("this.%s = new Map();", toPropertyName(field))
+			u.Body.PI("for (const k of Object.keys(%s)) {", objField)
+			if isMessageField(field.MapValue) {
+				valueTypeName, _, _, err := toTypeName(pkg, field.MapValue, false)
+				if err != nil {
+					return err
+				}
+				u.Body.P("this.%s.set(%s, %s.fromObject(%s[k]));", toPropertyName(field), toMapKey(field), valueTypeName, objField)
+			} else if converter := toConverter(pkg, field.MapValue, false); converter != nil {
+				u.Body.P("this.%s.set(%s, %s);", toPropertyName(field), toMapKey(field), converter(objField+"[k]"))
+			} else {
+				u.Body.P("this.%s.set(%s, %s[k]);", toPropertyName(field), toMapKey(field), objField)
+			}
+			u.Body.PD("}")
+		} else if isRepeated && isMessage {
 			// isRepeated なので typeName の後ろ２文字は確実に [] となるはず
 			elementType := typeName[:len(typeName)-2]
-			u.Body.P("this.%s = obj.%s.map((x) => %s.fromObject(x));", *field.Name, *field.Name, elementType)
+			u.Body.P("this.%s = %s.map((x) => %s.fromObject(x));", toPropertyName(field), objField, elementType)
 		} else if !isRepeated && isMessage {
-			u.Body.P("this.%s = %s.fromObject(obj.%s);", *field.Name, typeName, *field.Name)
+			u.Body.P("this.%s = %s.fromObject(%s);", toPropertyName(field), typeName, objField)
+		} else if isRepeated && converter != nil {
+			u.Body.P("this.%s = %s.map((x) => %s);", toPropertyName(field), objField, converter("x"))
+		} else if !isRepeated && converter != nil {
+			u.Body.P("this.%s = %s;", toPropertyName(field), converter(objField))
 		} else {
-			u.Body.P("this.%s = obj.%s;", *field.Name, *field.Name)
+			u.Body.P("this.%s = %s;", toPropertyName(field), objField)
+		}
+		if oneof := field.Oneof; oneof != nil && !oneof.Synthetic && oneof.ActiveOnly {
+			// キーがあるフィールドが設定されているフィールドになる
+			oneofTypeName := toLocalClassName(append(msg.Parents(), msg), internal.ToUpperCamel(oneof.Name)) + "Case"
+			oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			u.Body.P("this.%s = %s.k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(field.Name))
 		}
 		if isOptional {
 			u.Body.PD("}")
 		}
 		u.Body.PD("}")
 	}
-	for i, oneof := range desc.OneofDecl {
-		fields := getOneofFields(desc.Field, i)
-		if len(fields) != 0 {
-			fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-			u.Body.PI("if (obj.%s !== undefined) {", fieldName)
-			u.Body.P("this.%s = obj.%s;", fieldName, fieldName)
-			u.Body.PD("}")
-		}
+	for _, oneof := range getOneofs(msg) {
+		// active only の oneof の場合、キーから判断した値よりも _case を優先する
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		u.Body.PI("if (obj.%s !== undefined) {", fieldName)
+		u.Body.P("this.%s = obj.%s;", fieldName, fieldName)
+		u.Body.PD("}")
+	}
+	if msg.KeepUnknownFields {
+		u.Body.PI("for (const k of Object.keys(obj)) {")
+		u.Body.PI("if (!%s.knownKeys.has(k)) {", localClassName)
+		u.Body.P("this.extra[k] = (obj as Record<string, unknown>)[k];")
+		u.Body.PD("}")
+		u.Body.PD("}")
 	}
 	u.Body.PD("}")
 
+	// Any の "@type" に使う完全修飾名
+	u.Body.P("static readonly typeName: string = \"%s\";", msg.FullName)
+	u.Messages = append(u.Messages, localClassName)
+
 	// getType
 	u.Body.PI("getType(): typeof %s {", localClassName)
 	u.Body.P("return %s;", localClassName)
@@ -376,46 +661,100 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, pkgInfo *pkg
 	u.Body.P("return JSON.stringify(this.toObject());")
 	u.Body.PD("}")
 
+	// checkJson
+	genCheck(msg, pkg, localClassName, u, opts)
+
 	// fromObject
 	u.Body.PI("static fromObject(obj: %sObject): %s {", localClassName, localClassName)
-	u.Body.P("return new %s(obj);", localClassName)
+	// strict ではなくても、中に strict なメッセージがあれば、エラーの位置をこのメッセージからの位置にするためにここで調べる
+	if msg.ReachesStrict() {
+		u.Body.P("return jsonif.readStrict(obj, %s.checkJson, () => new %s(obj));", localClassName, localClassName)
+	} else {
+		u.Body.P("return new %s(obj);", localClassName)
+	}
 	u.Body.PD("}")
 
 	// toObject
 	u.Body.PI("toObject(): %sObject {", localClassName)
-	u.Body.PI("return {")
-	for _, field := range desc.Field {
-		isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
-		isMessage := *field.Type == descriptorpb.FieldDescriptorProto_TYPE_GROUP || *field.Type == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
-		isOptional := field.Proto3Optional != nil && *field.Proto3Optional
-		if isOptional {
-			if isRepeated && isMessage {
-				u.Body.P("%s: this.%s === null ? null : this.%s.map((x) => x.toObject()),", *field.Name, *field.Name, *field.Name)
-			} else if !isRepeated && isMessage {
-				u.Body.P("%s: this.%s === null ? null : this.%s.toObject(),", *field.Name, *field.Name, *field.Name)
-			} else {
-				u.Body.P("%s: this.%s,", *field.Name, *field.Name)
-			}
+	// map は先にオブジェクトに変換しておく
+	for _, field := range msg.Fields {
+		if !field.IsMap() {
+			continue
+		}
+		typeName, _, _, err := toTypeName(pkg, field, true)
+		if err != nil {
+			return err
+		}
+		u.Body.P("const %s: %s = {};", escapeName(field.Name), typeName)
+		u.Body.PI("this.%s.forEach((v, k) => {", toPropertyName(field))
+		if isMessageField(field.MapValue) {
+			u.Body.P("%s[String(k)] = v.toObject();", escapeName(field.Name))
+		} else if converter := toConverter(pkg, field.MapValue, true); converter != nil {
+			u.Body.P("%s[String(k)] = %s;", escapeName(field.Name), converter("v"))
 		} else {
-			if isRepeated && isMessage {
-				u.Body.P("%s: this.%s.map((x) => x.toObject()),", *field.Name, *field.Name)
-			} else if !isRepeated && isMessage {
-				u.Body.P("%s: this.%s.toObject(),", *field.Name, *field.Name)
+			u.Body.P("%s[String(k)] = v;", escapeName(field.Name))
+		}
+		u.Body.PD("});")
+	}
+	if opts.Canonical || hasActiveOnlyOneof(msg) {
+		// デフォルト値のフィールド（canonical の場合）と設定されていない oneof のフィールドは出力しない
+		u.Body.P("const obj: %sObject = {};", localClassName)
+		for _, field := range msg.Fields {
+			key := accessKey("obj", toObjectKey(field, opts))
+			if opts.Canonical || field.Oneof != nil && field.Oneof.ActiveOnly {
+				u.Body.PI("if (%s) {", toNonDefaultCondition(msg, field))
+				u.Body.P("%s = %s;", key, toObjectValue(pkg, field))
+				u.Body.PD("}")
 			} else {
-				u.Body.P("%s: this.%s,", *field.Name, *field.Name)
+				u.Body.P("%s = %s;", key, toObjectValue(pkg, field))
 			}
 		}
-	}
-	for i, oneof := range desc.OneofDecl {
-		fields := getOneofFields(desc.Field, i)
-		if len(fields) != 0 {
-			fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+		for _, oneof := range getOneofs(msg) {
+			if oneof.ActiveOnly {
+				continue
+			}
+			fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			u.Body.P("obj.%s = this.%s;", fieldName, fieldName)
+		}
+		genWriteUnknownFields(msg, localClassName, u)
+		u.Body.P("return obj;")
+	} else if msg.KeepUnknownFields {
+		u.Body.PI("const obj: %sObject = {", localClassName)
+		for _, field := range msg.Fields {
+			u.Body.P("%s: %s,", quoteKey(toObjectKey(field, opts)), toObjectValue(pkg, field))
+		}
+		for _, oneof := range getOneofs(msg) {
+			if oneof.ActiveOnly {
+				continue
+			}
+			fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
 			u.Body.P("%s: this.%s,", fieldName, fieldName)
 		}
+		u.Body.PD("};")
+		genWriteUnknownFields(msg, localClassName, u)
+		u.Body.P("return obj;")
+	} else {
+		u.Body.PI("return {")
+		for _, field := range msg.Fields {
+			u.Body.P("%s: %s,", quoteKey(toObjectKey(field, opts)), toObjectValue(pkg, field))
+		}
+		for _, oneof := range getOneofs(msg) {
+			if oneof.ActiveOnly {
+				continue
+			}
+			fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			u.Body.P("%s: this.%s,", fieldName, fieldName)
+		}
+		u.Body.PD("};")
 	}
-	u.Body.PD("};")
 	u.Body.PD("}")
 
+	if msg.HasValidation() {
+		if err := genValidate(msg, pkg, u); err != nil {
+			return err
+		}
+	}
+
 	// 	if oneof := field.OneofIndex; oneof != nil {
 	// 		oneofTypeName := internal.ToUpperCamel(*desc.OneofDecl[*oneof].Name) + "Case"
 	// 		oneofFieldName := internal.ToSnakeCase(*desc.OneofDecl[*oneof].Name) + "_case"
@@ -445,49 +784,318 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, pkgInfo *pkg
 	return nil
 }
 
-func genFile(file *descriptorpb.FileDescriptorProto, files []*descriptorpb.FileDescriptorProto, pkgInfo *pkgInfo) (*pluginpb.CodeGeneratorResponse_File, error) {
+// 整数型の値の範囲
+func toIntegerRange(field *internal.Field) (string, string, bool) {
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return "-2147483648", "2147483647", true
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return "-9223372036854775808", "9223372036854775807", true
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return "0", "4294967295", true
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return "0", "18446744073709551615", true
+	}
+	return "", "", false
+}
+
+// JSON の値を調べる jsonif.CheckFunc の式（repeated の場合は要素、map の場合は値に対して使う）
+func toCheckFunc(pkg string, field *internal.Field) string {
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp, internal.WellKnownDuration:
+		return "jsonif.checkString"
+	case internal.WellKnownWrapper:
+		return fmt.Sprintf("jsonif.nullable(%s)", toCheckFunc(pkg, field.Message.WrapperValue()))
+	case internal.WellKnownStruct, internal.WellKnownAny:
+		return "jsonif.nullable(jsonif.checkObject)"
+	case internal.WellKnownListValue:
+		return "jsonif.nullable(jsonif.checkArray)"
+	case internal.WellKnownValue:
+		return "jsonif.checkJsonValue"
+	}
+	if field.IsMessage() {
+		return toTypeRef(pkg, field.Message.File, field.Message.Parents(), field.Message.Name) + ".checkJson"
+	}
+	if field.Enum != nil {
+		return fmt.Sprintf("jsonif.enumChecker(%t)", field.Enum.AsName)
+	}
+	if min, max, ok := toIntegerRange(field); ok {
+		if field.Int64AsString {
+			return fmt.Sprintf("jsonif.integerStringChecker(%s, %s)", min, max)
+		}
+		return fmt.Sprintf("jsonif.integerChecker(%s, %s)", min, max)
+	}
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "jsonif.checkBoolean"
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return "jsonif.checkNumber"
+	}
+	return "jsonif.checkString"
+}
+
+// フィールドの値を調べる jsonif.CheckFunc の式
+func toFieldCheckFunc(pkg string, field *internal.Field) string {
+	if field.IsMap() {
+		isKey := "jsonif.stringKey"
+		if min, max, ok := toIntegerRange(field.MapKey); ok {
+			isKey = fmt.Sprintf("jsonif.integerKey(%s, %s)", min, max)
+		} else if field.MapKey.Type == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+			isKey = "jsonif.booleanKey"
+		}
+		return fmt.Sprintf("jsonif.mapOf(%s, %s)", isKey, toCheckFunc(pkg, field.MapValue))
+	}
+	check := toCheckFunc(pkg, field)
+	if field.Repeated {
+		check = fmt.Sprintf("jsonif.arrayOf(%s)", check)
+	}
+	// proto3 optional は値が無い場合に null を出力する
+	if field.Optional {
+		check = fmt.Sprintf("jsonif.nullable(%s)", check)
+	}
+	return check
+}
+
+// JSON を読み込めるかどうかを調べる関数
+// oneof と optimistic 以外のフィールドのキーは必須になる
+// strict なメッセージの場合は知らないキーもエラーにする
+func genCheck(msg *internal.Message, pkg string, localClassName string, u *typescriptFile, opts *options) {
+	genComment(&u.Body, []string{"JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる"})
+	u.Body.PI("static checkJson(obj: unknown, path: string): void {")
+	u.Body.P("jsonif.checkObject(obj, path);")
+	u.Body.P("const o = obj as Record<string, unknown>;")
+	if msg.Strict && !msg.KeepUnknownFields {
+		u.Body.P("jsonif.checkUnknownKeys(o, %s.knownKeys, path);", localClassName)
+	}
+	check := func(key string, checkFunc string, required bool) {
+		objField := accessKey("o", key)
+		u.Body.PI("if (%s !== undefined) {", objField)
+		u.Body.P("%s(%s, jsonif.joinPath(path, \"%s\"));", checkFunc, objField, key)
+		if required {
+			u.Body.PDI("} else {")
+			u.Body.P("jsonif.missingKey(path, \"%s\");", key)
+		}
+		u.Body.PD("}")
+	}
+	for _, field := range msg.Fields {
+		check(toObjectKey(field, opts), toFieldCheckFunc(pkg, field), field.Oneof == nil && !field.Optimistic)
+	}
+	for _, oneof := range getOneofs(msg) {
+		check(internal.ToSnakeCase(oneof.Name)+"_case", "jsonif.integerChecker(-2147483648, 2147483647)", !oneof.ActiveOnly)
+	}
+	u.Body.PD("}")
+}
+
+// constructor で読み込むキーの文字列リテラル
+func toKnownKeys(msg *internal.Message, opts *options) []string {
+	var keys []string
+	for _, field := range msg.Fields {
+		keys = append(keys, fmt.Sprintf("\"%s\"", toObjectKey(field, opts)))
+	}
+	for _, oneof := range getOneofs(msg) {
+		keys = append(keys, fmt.Sprintf("\"%s_case\"", internal.ToSnakeCase(oneof.Name)))
+	}
+	return keys
+}
+
+// 知らないキーを obj に追加する（知っているキーと同じキーは出力しない）
+func genWriteUnknownFields(msg *internal.Message, localClassName string, u *typescriptFile) {
+	if !msg.KeepUnknownFields {
+		return
+	}
+	u.Body.PI("for (const k of Object.keys(this.extra)) {")
+	u.Body.PI("if (!%s.knownKeys.has(k)) {", localClassName)
+	u.Body.P("(obj as Record<string, unknown>)[k] = this.extra[k];")
+	u.Body.PD("}")
+	u.Body.PD("}")
+}
+
+// 値が enum で定義されているかどうかの条件式
+func toEnumDefinedCondition(pkg string, enum *internal.Enum, expr string) string {
+	enumType := toTypeRef(pkg, enum.File, enum.Parents(), enum.Name)
+	var conds []string
+	for _, v := range enum.Values {
+		conds = append(conds, fmt.Sprintf("%s === %s.%s", expr, enumType, v.Name))
+	}
+	return strings.Join(conds, " || ")
+}
+
+// 値 expr の検証（repeated の場合は要素、map の場合は値ごとに呼ぶ）
+func genValueValidate(f *internal.Formatter, pkg string, field *internal.Field, expr string, path string) {
+	value := field.ValueField()
+	if v := field.Validation; v != nil {
+		push := func(cond string, message string) {
+			b, _ := json.Marshal(message)
+			f.PI("if (%s) {", cond)
+			f.P("violations.push({ path: %s, message: %s });", path, string(b))
+			f.PD("}")
+		}
+		if len(v.Min) != 0 {
+			push(fmt.Sprintf("%s < %s", expr, v.Min), v.MinMessage())
+		}
+		if len(v.Max) != 0 {
+			push(fmt.Sprintf("%s > %s", expr, v.Max), v.MaxMessage())
+		}
+		length := fmt.Sprintf("jsonif.stringLength(%s)", expr)
+		if value.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			length = expr + ".length"
+		}
+		if v.MinLen >= 0 {
+			push(fmt.Sprintf("%s < %d", length, v.MinLen), v.MinLenMessage())
+		}
+		if v.MaxLen >= 0 {
+			push(fmt.Sprintf("%s > %d", length, v.MaxLen), v.MaxLenMessage())
+		}
+		if len(v.Pattern) != 0 {
+			b, _ := json.Marshal(v.Pattern)
+			push(fmt.Sprintf("!new RegExp(%s).test(%s)", string(b), expr), v.PatternMessage())
+		}
+		if v.EnumDefinedOnly {
+			push(fmt.Sprintf("!(%s)", toEnumDefinedCondition(pkg, value.Enum, expr)), internal.EnumDefinedOnlyMessage)
+		}
+	}
+	if isMessageField(value) && value.Message.HasValidation() {
+		f.P("%s.validate(%s, violations);", expr, path)
+	}
+}
+
+// jsonif_required のフィールドが設定されていない条件
+// optional は null、メッセージ型はデフォルト値の場合に設定されていないとする
+func toMissingCondition(pkg string, field *internal.Field) (string, error) {
+	value := "this." + toPropertyName(field)
+	if field.Optional {
+		return value + " === null", nil
+	}
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		return value + ".getTime() === 0", nil
+	case internal.WellKnownDuration:
+		return value + " === 0", nil
+	case internal.NotWellKnown:
+	default:
+		return value + " === null", nil
+	}
+	// デフォルト値のメッセージと同じ JSON になればデフォルト値
+	typeName, _, _, err := toTypeName(pkg, field, false)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.toJson() === new %s().toJson()", value, typeName), nil
+}
+
+// 検証ルールを満たしているかを調べて、違反を violations に追加して返す
+// 検証ルールのあるメッセージをフィールドに持つ場合は、そのメッセージの validate も呼び出す
+func genValidate(msg *internal.Message, pkg string, u *typescriptFile) error {
+	f := &u.Body
+	f.PI("validate(path: string = \"\", violations: jsonif.Violation[] = []): jsonif.Violation[] {")
+	for _, field := range msg.Fields {
+		value := "this." + toPropertyName(field)
+		b, _ := json.Marshal(field.JsonKey)
+		path := fmt.Sprintf("jsonif.joinPath(path, %s)", string(b))
+		// oneof（optional を含む）のフィールドは、設定されている場合だけ検証する
+		cond := ""
+		if oneof := field.Oneof; oneof != nil && oneof.Synthetic {
+			cond = value + " !== null"
+		} else if oneof != nil {
+			oneofTypeName := toLocalClassName(append(msg.Parents(), msg), internal.ToUpperCamel(oneof.Name)) + "Case"
+			cond = fmt.Sprintf("this.%s_case === %s.k%s", internal.ToSnakeCase(oneof.Name), oneofTypeName, internal.ToUpperCamel(field.Name))
+		}
+		v := field.Validation
+		push := func(cond string, message string) {
+			f.PI("if (%s) {", cond)
+			f.P("violations.push({ path: %s, message: \"%s\" });", path, message)
+			f.PD("}")
+		}
+		if v != nil && v.Required {
+			missing, err := toMissingCondition(pkg, field)
+			if err != nil {
+				return err
+			}
+			push(missing, internal.RequiredMessage)
+		}
+		length := value + ".length"
+		if field.IsMap() {
+			length = value + ".size"
+		}
+		if v != nil && v.MinItems >= 0 {
+			push(fmt.Sprintf("%s < %d", length, v.MinItems), v.MinItemsMessage())
+		}
+		if v != nil && v.MaxItems >= 0 {
+			push(fmt.Sprintf("%s > %d", length, v.MaxItems), v.MaxItemsMessage())
+		}
+		valueField := field.ValueField()
+		if !(v != nil && v.HasValueRules()) && !(isMessageField(valueField) && valueField.Message.HasValidation()) {
+			continue
+		}
+		if len(cond) != 0 {
+			f.PI("if (%s) {", cond)
+		}
+		if field.IsMap() {
+			f.PI("%s.forEach((v, k) => {", value)
+			genValueValidate(f, pkg, field, "v", path+" + \"[\\\"\" + String(k) + \"\\\"]\"")
+			f.PD("});")
+		} else if field.Repeated {
+			f.PI("for (let i = 0; i < %s.length; i++) {", value)
+			genValueValidate(f, pkg, field, value+"[i]", path+" + \"[\" + i + \"]\"")
+			f.PD("}")
+		} else {
+			genValueValidate(f, pkg, field, value, path)
+		}
+		if len(cond) != 0 {
+			f.PD("}")
+		}
+	}
+	f.P("return violations;")
+	f.PD("}")
+	return nil
+}
+
+func genFile(file *internal.File, opts *options) (*pluginpb.CodeGeneratorResponse_File, error) {
 	u := typescriptFile{}
 	u.Top.SetIndentUnit(4)
 	u.Bottom.SetIndentUnit(4)
 	u.Body.SetIndentUnit(4)
 
-	for _, dep := range file.Dependency {
-		// ファイルが存在してない可能性もあるのでチェックする
-		exists := false
-		for _, f := range files {
-			if dep == *f.Name {
-				exists = true
-				break
-			}
-		}
-		if !exists {
+	// メッセージの登録や、Timestamp と Duration の変換、JSON の値の型は jsonif.ts のものを使う
+	if usesJsonifFile(file) {
+		u.Top.P("import * as jsonif from \"./jsonif\";")
+	}
+	for _, dep := range file.Dependencies {
+		// well-known type は組み込み型に変換するので、インポートは不要
+		if dep.IsWellKnown() {
 			continue
 		}
-
 		// 拡張子を取り除く
-		fileName := dep
+		fileName := dep.Name
 		fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
-		alias, ok := pkgInfo.filenameToAlias[dep]
-		if !ok {
-			return nil, errors.New("filename not found")
-		}
-		u.Top.P("import * as %s from \"./%s\";", alias, fileName)
+		u.Top.P("import * as %s from \"./%s\";", packageToAlias(dep.Package), fileName)
 	}
 	u.Top.P("")
 
-	for _, enum := range file.EnumType {
-		if err := genEnum(enum, nil, &u); err != nil {
+	for _, enum := range file.Enums {
+		if err := genEnum(enum, &u); err != nil {
 			return nil, err
 		}
 	}
-	for _, desc := range file.MessageType {
-		if err := genDescriptor(desc, file.Package, pkgInfo, nil, &u); err != nil {
+	for _, msg := range file.Messages {
+		if err := genDescriptor(msg, file.Package, &u, opts); err != nil {
 			return nil, err
 		}
 	}
 
+	// Any から取り出せるように、読み込んだ時にメッセージを登録する
+	for _, name := range u.Messages {
+		u.Bottom.P("jsonif.registerType(%s);", name)
+	}
+
 	// 拡張子を取り除いて .ts を付ける
-	fileName := *file.Name
+	fileName := file.Name
 	fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
 	fileName = fileName + ".ts"
 
@@ -503,6 +1111,55 @@ func genJsonif() (*pluginpb.CodeGeneratorResponse_File, error) {
 	f := internal.Formatter{}
 	f.SetIndentUnit(4)
 
+	f.P("// google.protobuf.Struct, ListValue, Value の値")
+	f.P("export type JsonValue = null | boolean | number | string | JsonValue[] | { [key: string]: JsonValue };")
+	f.P("")
+	f.P("// google.protobuf.Any の値（\"@type\" に型の URL が入る）")
+	f.P("export type Any = { \"@type\": string; [key: string]: JsonValue };")
+	f.P("")
+	f.P("// 生成したメッセージのクラス")
+	f.PI("export interface MessageType<T> {")
+	f.P("typeName: string;")
+	f.P("fromObject(obj: any): T;")
+	f.PD("}")
+	f.P("")
+	f.P("// メッセージの完全修飾名とクラスの対応表")
+	f.P("// 生成したファイルを読み込むと、そのファイルのメッセージが登録される")
+	f.P("const types = new Map<string, MessageType<unknown>>();")
+	f.P("")
+	f.PI("export function registerType(type: MessageType<unknown>): void {")
+	f.P("types.set(type.typeName, type);")
+	f.PD("}")
+	f.P("")
+	f.P("// 型の URL から取り出したメッセージの完全修飾名")
+	f.PI("function anyTypeName(a: Any): string {")
+	f.P("const url = a[\"@type\"];")
+	f.P("return url.slice(url.lastIndexOf(\"/\") + 1);")
+	f.PD("}")
+	f.P("")
+	f.P("// メッセージを google.protobuf.Any に詰める")
+	f.PI("export function pack(v: { getType(): { typeName: string }; toObject(): object }): Any {")
+	f.P("return { \"@type\": \"type.googleapis.com/\" + v.getType().typeName, ...v.toObject() } as Any;")
+	f.PD("}")
+	f.P("")
+	f.PI("export function is<T>(a: Any, type: MessageType<T>): boolean {")
+	f.P("return anyTypeName(a) === type.typeName;")
+	f.PD("}")
+	f.P("")
+	f.P("// type を省略した場合は registerType で登録されたクラスから探す")
+	f.P("export function unpack<T>(a: Any, type: MessageType<T>): T;")
+	f.P("export function unpack(a: Any): unknown;")
+	f.PI("export function unpack(a: Any, type?: MessageType<unknown>): unknown {")
+	f.P("const t = type !== undefined ? type : types.get(anyTypeName(a));")
+	f.PI("if (t === undefined) {")
+	f.P("throw new Error(\"unknown type: \" + a[\"@type\"]);")
+	f.PD("}")
+	f.PI("if (anyTypeName(a) !== t.typeName) {")
+	f.P("throw new Error(\"type mismatch: \" + a[\"@type\"]);")
+	f.PD("}")
+	f.P("return t.fromObject(a);")
+	f.PD("}")
+	f.P("")
 	f.PI("export interface Jsonif<T> {")
 	f.P("getType: () => { fromJson(json: string): T };")
 	f.P("toJson: () => string;")
@@ -531,6 +1188,326 @@ func genJsonif() (*pluginpb.CodeGeneratorResponse_File, error) {
 	f.P("return v.toJson();")
 	f.PD("}")
 	f.PD("}")
+	f.P("")
+	f.P("// 小数部は 0, 3, 6, 9 桁のいずれかにする")
+	f.PI("function formatNanos(nanos: number): string {")
+	f.PI("if (nanos === 0) {")
+	f.P("return \"\";")
+	f.PD("}")
+	f.P("const s = String(1000000000 + nanos).slice(1);")
+	f.PI("if (nanos %% 1000000 === 0) {")
+	f.P("return \".\" + s.slice(0, 3);")
+	f.PD("}")
+	f.PI("if (nanos %% 1000 === 0) {")
+	f.P("return \".\" + s.slice(0, 6);")
+	f.PD("}")
+	f.P("return \".\" + s;")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Timestamp は RFC 3339 形式の文字列になる")
+	f.PI("export function timestampToJson(v: Date): string {")
+	f.P("const s = v.toISOString();")
+	f.P("const ms = v.getTime() - Math.floor(v.getTime() / 1000) * 1000;")
+	f.P("return s.slice(0, s.indexOf(\".\")) + formatNanos(ms * 1000000) + \"Z\";")
+	f.PD("}")
+	f.P("")
+	f.PI("export function timestampFromJson(v: string): Date {")
+	f.P("const d = new Date(v);")
+	f.PI("if (isNaN(d.getTime())) {")
+	f.P("throw new Error(\"invalid google.protobuf.Timestamp: \" + v);")
+	f.PD("}")
+	f.P("return d;")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Duration はミリ秒の number で扱い、JSON では \"1.5s\" のような文字列になる")
+	f.PI("export function durationToJson(v: number): string {")
+	f.P("const ms = Math.abs(v);")
+	f.P("let seconds = Math.floor(ms / 1000);")
+	f.P("let nanos = Math.round((ms - seconds * 1000) * 1000000);")
+	f.PI("if (nanos >= 1000000000) {")
+	f.P("seconds += 1;")
+	f.P("nanos -= 1000000000;")
+	f.PD("}")
+	f.P("return (v < 0 ? \"-\" : \"\") + String(seconds) + formatNanos(nanos) + \"s\";")
+	f.PD("}")
+	f.P("")
+	f.PI("export function durationFromJson(v: string): number {")
+	f.P("const m = /^(-?)([0-9]+)(?:\\.([0-9]{1,9}))?s$/.exec(v);")
+	f.PI("if (m === null) {")
+	f.P("throw new Error(\"invalid google.protobuf.Duration: \" + v);")
+	f.PD("}")
+	f.P("const nanos = m[3] === undefined ? 0 : Number((m[3] + \"00000000\").slice(0, 9));")
+	f.P("const ms = Number(m[2]) * 1000 + nanos / 1000000;")
+	f.P("return m[1] === \"-\" ? -ms : ms;")
+	f.PD("}")
+	f.P("")
+	f.P("// canonical の場合、enum は値の名前の文字列になる（未知の値は数値のまま）")
+	f.PI("export function enumToJson(type: any, v: number): string | number {")
+	f.P("const name = type[v];")
+	f.P("return typeof name === \"string\" ? name : v;")
+	f.PD("}")
+	f.P("")
+	f.P("// 名前と数値のどちらでも読み込める。未知の名前は 0 になる")
+	f.PI("export function enumFromJson(type: any, v: string | number): number {")
+	f.PI("if (typeof v === \"number\") {")
+	f.P("return v;")
+	f.PD("}")
+	f.P("const value = type[v];")
+	f.P("return typeof value === \"number\" ? value : 0;")
+	f.PD("}")
+	f.P("")
+	f.P("// canonical の場合、int64 や uint64 は文字列になる（読み込む時は数値も受け付ける）")
+	f.P("// number で扱うので、2^53 を超える値は精度が落ちる")
+	f.PI("export function int64ToJson(v: number): string {")
+	f.P("return String(v);")
+	f.PD("}")
+	f.P("")
+	f.PI("export function int64FromJson(v: string | number): number {")
+	f.P("const n = Number(v);")
+	f.PI("if (typeof v === \"string\" && (v.trim() === \"\" || isNaN(n))) {")
+	f.P("throw new Error(\"invalid int64: \" + v);")
+	f.PD("}")
+	f.P("return n;")
+	f.PD("}")
+	f.P("")
+	f.P("// bytes はパディング付きの base64 の文字列になる")
+	f.PI("export function bytesToJson(v: Uint8Array): string {")
+	f.P("let s = \"\";")
+	f.PI("for (let i = 0; i < v.length; i++) {")
+	f.P("s += String.fromCharCode(v[i]);")
+	f.PD("}")
+	f.P("return btoa(s);")
+	f.PD("}")
+	f.P("")
+	f.P("// base64url パラメータを指定した場合は、パディング無しの URL セーフな base64 になる")
+	f.PI("export function bytesUrlToJson(v: Uint8Array): string {")
+	f.P("return bytesToJson(v).replace(/\\+/g, \"-\").replace(/\\//g, \"_\").replace(/=+$/, \"\");")
+	f.PD("}")
+	f.P("")
+	f.P("// URL セーフな base64 やパディングの無い base64 も読み込める")
+	f.PI("export function bytesFromJson(v: string): Uint8Array {")
+	f.P("const b = v.replace(/-/g, \"+\").replace(/_/g, \"/\");")
+	f.P("const s = atob(b.padEnd(Math.ceil(b.length / 4) * 4, \"=\"));")
+	f.P("const r = new Uint8Array(s.length);")
+	f.PI("for (let i = 0; i < s.length; i++) {")
+	f.P("r[i] = s.charCodeAt(i);")
+	f.PD("}")
+	f.P("return r;")
+	f.PD("}")
+	f.P("")
+	f.P("// 検証ルールの違反")
+	f.PI("export type Violation = {")
+	f.P("// JSON でのフィールドの位置（people[3].name など）")
+	f.P("path: string;")
+	f.P("message: string;")
+	f.PD("}")
+	f.P("")
+	f.PI("export function joinPath(path: string, key: string): string {")
+	f.P("return path === \"\" ? key : path + \".\" + key;")
+	f.PD("}")
+	f.P("")
+	f.P("// 文字列のコードポイント数（サロゲートペアは 1 文字として数える）")
+	f.PI("export function stringLength(s: string): number {")
+	f.P("let n = 0;")
+	f.PI("for (let i = 0; i < s.length; i++) {")
+	f.P("const c = s.charCodeAt(i);")
+	f.PI("if (c < 0xdc00 || c > 0xdfff) {")
+	f.P("n++;")
+	f.PD("}")
+	f.PD("}")
+	f.P("return n;")
+	f.PD("}")
+	f.P("")
+	f.P("// report_deprecated を指定して生成したクラスで、deprecated なフィールドのキーが JSON にあった時に呼ばれる関数")
+	f.P("// typeName はメッセージの完全修飾名、key は JSON のキー")
+	f.P("export type DeprecatedFieldHandler = (typeName: string, key: string) => void;")
+	f.P("")
+	f.P("let deprecatedFieldHandler: DeprecatedFieldHandler | null = null;")
+	f.P("let deprecatedFieldCount = 0;")
+	f.P("")
+	f.PI("export function setDeprecatedFieldHandler(handler: DeprecatedFieldHandler | null): void {")
+	f.P("deprecatedFieldHandler = handler;")
+	f.PD("}")
+	f.P("")
+	f.P("// deprecated なフィールドのキーが JSON にあった回数")
+	f.PI("export function getDeprecatedFieldCount(): number {")
+	f.P("return deprecatedFieldCount;")
+	f.PD("}")
+	f.P("")
+	f.PI("export function resetDeprecatedFieldCount(): void {")
+	f.P("deprecatedFieldCount = 0;")
+	f.PD("}")
+	f.P("")
+	f.PI("export function reportDeprecatedField(typeName: string, key: string): void {")
+	f.P("deprecatedFieldCount++;")
+	f.PI("if (deprecatedFieldHandler !== null) {")
+	f.P("deprecatedFieldHandler(typeName, key);")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// JSON を読み込めなかった時のエラー")
+	f.P("// message は \"people[3].name: expected string, got number\" のような文字列になる")
+	f.PI("export class ParseError extends Error {")
+	f.P("// JSON での位置（people[3].name など）。JSON 全体の場合は空文字列")
+	f.P("readonly path: string;")
+	f.P("readonly reason: string;")
+	f.PI("constructor(path: string, reason: string) {")
+	f.P("super(path === \"\" ? reason : path + \": \" + reason);")
+	f.P("// ES5 に変換した場合でも instanceof で判定できるようにする")
+	f.P("Object.setPrototypeOf(this, ParseError.prototype);")
+	f.P("this.name = \"ParseError\";")
+	f.P("this.path = path;")
+	f.P("this.reason = reason;")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// JSON の値を調べて、問題があれば ParseError を投げる関数")
+	f.P("export type CheckFunc = (v: unknown, path: string) => void;")
+	f.P("export type KeyFunc = (key: string) => boolean;")
+	f.P("export type ReadFunc<T> = () => T;")
+	f.P("")
+	f.PI("export function jsonKind(v: unknown): string {")
+	f.P("if (v === null) return \"null\";")
+	f.P("if (Array.isArray(v)) return \"array\";")
+	f.P("return typeof v === \"object\" ? \"object\" : typeof v;")
+	f.PD("}")
+	f.P("")
+	f.PI("function expected(kind: string, v: unknown, path: string): never {")
+	f.P("throw new ParseError(path, \"expected \" + kind + \", got \" + jsonKind(v));")
+	f.PD("}")
+	f.P("")
+	f.PI("export function missingKey(path: string, key: string): never {")
+	f.P("throw new ParseError(joinPath(path, key), \"missing key\");")
+	f.PD("}")
+	f.P("")
+	f.P("// \"@type\" は google.protobuf.Any に詰めた時に付くので、常に受け付ける")
+	f.PI("export function checkUnknownKeys(obj: Record<string, unknown>, knownKeys: ReadonlySet<string>, path: string): void {")
+	f.PI("for (const k of Object.keys(obj)) {")
+	f.PI("if (k !== \"@type\" && !knownKeys.has(k)) {")
+	f.P("throw new ParseError(joinPath(path, k), \"unknown key\");")
+	f.PD("}")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.PI("export function checkObject(v: unknown, path: string): void {")
+	f.PI("if (v === null || typeof v !== \"object\" || Array.isArray(v)) {")
+	f.P("expected(\"object\", v, path);")
+	f.PD("}")
+	f.PD("}")
+	f.PI("export function checkArray(v: unknown, path: string): void {")
+	f.PI("if (!Array.isArray(v)) {")
+	f.P("expected(\"array\", v, path);")
+	f.PD("}")
+	f.PD("}")
+	f.PI("export function checkString(v: unknown, path: string): void {")
+	f.PI("if (typeof v !== \"string\") {")
+	f.P("expected(\"string\", v, path);")
+	f.PD("}")
+	f.PD("}")
+	f.PI("export function checkBoolean(v: unknown, path: string): void {")
+	f.PI("if (typeof v !== \"boolean\") {")
+	f.P("expected(\"boolean\", v, path);")
+	f.PD("}")
+	f.PD("}")
+	f.PI("export function checkNumber(v: unknown, path: string): void {")
+	f.PI("if (typeof v !== \"number\") {")
+	f.P("expected(\"number\", v, path);")
+	f.PD("}")
+	f.PD("}")
+	f.P("// google.protobuf.Value のように JSON の値なら何でもいい場合")
+	f.PI("export function checkJsonValue(): void {")
+	f.PD("}")
+	f.P("")
+	f.P("// 小数の数値は整数として受け付けない")
+	f.PI("export function integerChecker(min: number, max: number): CheckFunc {")
+	f.PI("return (v, path) => {")
+	f.PI("if (typeof v !== \"number\" || !Number.isInteger(v)) {")
+	f.P("expected(\"integer\", v, path);")
+	f.PD("}")
+	f.PI("if ((v as number) < min || (v as number) > max) {")
+	f.P("throw new ParseError(path, \"out of range\");")
+	f.PD("}")
+	f.PD("};")
+	f.PD("}")
+	f.P("// 10 進数の整数の文字列で、範囲内かどうか")
+	f.PI("export function isIntegerString(s: string, min: number, max: number): boolean {")
+	f.P("return /^-?[0-9]+$/.test(s) && Number(s) >= min && Number(s) <= max;")
+	f.PD("}")
+	f.P("// 文字列の int64 も数値の int64 も読み込めるようにする")
+	f.PI("export function integerStringChecker(min: number, max: number): CheckFunc {")
+	f.PI("return (v, path) => {")
+	f.PI("if (typeof v !== \"string\") {")
+	f.P("integerChecker(min, max)(v, path);")
+	f.PDI("} else if (!isIntegerString(v, min, max)) {")
+	f.P("throw new ParseError(path, \"invalid integer\");")
+	f.PD("}")
+	f.PD("};")
+	f.PD("}")
+	f.P("// jsonif_enum_as_name の enum は名前の文字列も数値も読み込める")
+	f.PI("export function enumChecker(asName: boolean): CheckFunc {")
+	f.PI("return (v, path) => {")
+	f.PI("if (asName && typeof v === \"string\") {")
+	f.P("return;")
+	f.PD("}")
+	f.PI("if (asName && typeof v !== \"number\") {")
+	f.P("expected(\"string or integer\", v, path);")
+	f.PD("}")
+	f.P("integerChecker(-2147483648, 2147483647)(v, path);")
+	f.PD("};")
+	f.PD("}")
+	f.PI("export function nullable(f: CheckFunc): CheckFunc {")
+	f.PI("return (v, path) => {")
+	f.PI("if (v !== null) {")
+	f.P("f(v, path);")
+	f.PD("}")
+	f.PD("};")
+	f.PD("}")
+	f.P("// 配列の各要素を f で調べる")
+	f.PI("export function arrayOf(f: CheckFunc): CheckFunc {")
+	f.PI("return (v, path) => {")
+	f.P("checkArray(v, path);")
+	f.P("(v as unknown[]).forEach((x, i) => f(x, path + \"[\" + i + \"]\"));")
+	f.PD("};")
+	f.PD("}")
+	f.P("// map のキーを isKey で、値を f で調べる")
+	f.PI("export function mapOf(isKey: KeyFunc, f: CheckFunc): CheckFunc {")
+	f.PI("return (v, path) => {")
+	f.P("checkObject(v, path);")
+	f.P("const obj = v as Record<string, unknown>;")
+	f.PI("for (const k of Object.keys(obj)) {")
+	f.P("const p = path + \"[\\\"\" + k + \"\\\"]\";")
+	f.PI("if (!isKey(k)) {")
+	f.P("throw new ParseError(p, \"invalid map key\");")
+	f.PD("}")
+	f.P("f(obj[k], p);")
+	f.PD("}")
+	f.PD("};")
+	f.PD("}")
+	f.PI("export function integerKey(min: number, max: number): KeyFunc {")
+	f.P("return (k) => isIntegerString(k, min, max);")
+	f.PD("}")
+	f.PI("export function booleanKey(k: string): boolean {")
+	f.P("return k === \"true\" || k === \"false\";")
+	f.PD("}")
+	f.PI("export function stringKey(): boolean {")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("let strictDepth = 0;")
+	f.P("")
+	f.P("// strict なメッセージ（とそれを含むメッセージ）を読み込む前に、オブジェクト全体を check で調べる")
+	f.P("// ネストしたメッセージで何度も調べないように、一番外側のメッセージでだけ調べる")
+	f.PI("export function readStrict<T>(obj: unknown, check: CheckFunc, read: ReadFunc<T>): T {")
+	f.PI("if (strictDepth === 0) {")
+	f.P("check(obj, \"\");")
+	f.PD("}")
+	f.P("strictDepth++;")
+	f.PI("try {")
+	f.P("return read();")
+	f.PDI("} finally {")
+	f.P("strictDepth--;")
+	f.PD("}")
+	f.PD("}")
 
 	fileName := "jsonif.ts"
 
@@ -542,16 +1519,123 @@ func genJsonif() (*pluginpb.CodeGeneratorResponse_File, error) {
 	return resp, nil
 }
 
-func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+func checkMessageNames(msg *internal.Message, scope *internal.NameScope) error {
+	localClassName := toLocalClassName(msg.Parents(), msg.Name)
+	for _, name := range []string{localClassName, localClassName + "Object"} {
+		if err := scope.Add(name, msg); err != nil {
+			return err
+		}
+	}
+	for _, enum := range msg.Enums {
+		if err := scope.Add(toLocalClassName(enum.Parents(), enum.Name), enum); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if nested.MapEntry {
+			continue
+		}
+		if err := checkMessageNames(nested, scope); err != nil {
+			return err
+		}
+	}
+
+	// クラスのメンバ
+	members := &internal.NameScope{}
+	for _, name := range []string{"constructor", "getType", "fromJson", "toJson", "checkJson", "fromObject", "toObject"} {
+		if err := members.Add(name, msg); err != nil {
+			return err
+		}
+	}
+	if msg.HasValidation() {
+		if err := members.Add("validate", msg); err != nil {
+			return err
+		}
+	}
+	if msg.KeepUnknownFields {
+		if err := members.Add("extra", msg); err != nil {
+			return err
+		}
+	}
+	if msg.KeepUnknownFields || msg.Strict {
+		if err := members.Add("knownKeys", msg); err != nil {
+			return err
+		}
+	}
+	for _, field := range msg.Fields {
+		names := []string{toPropertyName(field)}
+		if field.Oneof != nil && !field.Oneof.Synthetic {
+			names = append(names, "set"+internal.ToUpperCamel(field.Name), "clear"+internal.ToUpperCamel(field.Name))
+		}
+		for _, name := range names {
+			if err := members.Add(name, field); err != nil {
+				return err
+			}
+		}
+	}
+	for _, oneof := range getOneofs(msg) {
+		typeName := toLocalClassName(append(msg.Parents(), msg), internal.ToUpperCamel(oneof.Name)) + "Case"
+		if err := scope.Add(typeName, oneof); err != nil {
+			return err
+		}
+		names := []string{internal.ToSnakeCase(oneof.Name) + "_case", "clear" + internal.ToUpperCamel(oneof.Name)}
+		for _, name := range names {
+			if err := members.Add(name, oneof); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 生成する識別子が衝突していないか調べる
+// TypeScript ではファイルごとにモジュールになるので、ファイルごとに調べる
+func checkNames(schema *internal.Schema) error {
+	for _, file := range schema.Files {
+		scope := &internal.NameScope{}
+		if usesJsonifFile(file) {
+			if err := scope.Add("jsonif", internal.Namespace("jsonif")); err != nil {
+				return err
+			}
+		}
+		for _, dep := range file.Dependencies {
+			if dep.IsWellKnown() {
+				continue
+			}
+			if err := scope.Add(packageToAlias(dep.Package), internal.Namespace(dep.Package)); err != nil {
+				return err
+			}
+		}
+		for _, enum := range file.Enums {
+			if err := scope.Add(enum.Name, enum); err != nil {
+				return err
+			}
+		}
+		for _, msg := range file.Messages {
+			if err := checkMessageNames(msg, scope); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func gen(req *pluginpb.CodeGeneratorRequest, opts *options) (*pluginpb.CodeGeneratorResponse, error) {
 	resp := &pluginpb.CodeGeneratorResponse{}
 	resp.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
-	pkgInfo := newPkgInfo()
-	for _, file := range req.ProtoFile {
-		pkgInfo.addFile(file)
+	schema, err := internal.NewSchema(req.ProtoFile, &opts.CommonOptions)
+	if err != nil {
+		return nil, err
 	}
-
-	for _, file := range req.ProtoFile {
-		respFile, err := genFile(file, req.ProtoFile, pkgInfo)
+	if err := checkNames(schema); err != nil {
+		return nil, err
+	}
+	for _, file := range schema.FilesToGenerate(req, &opts.CommonOptions) {
+		// well-known type は組み込み型に変換するので、定義ファイルのコードは出力しない
+		if file.IsWellKnown() {
+			continue
+		}
+		respFile, err := genFile(file, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -569,7 +1653,10 @@ func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, e
 }
 
 func main() {
-	err := internal.RunPlugin(gen)
+	opts := &options{}
+	err := internal.RunPlugin(newOptionSet(opts), func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return gen(req, opts)
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
 		os.Exit(1)
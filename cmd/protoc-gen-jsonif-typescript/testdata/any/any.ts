@@ -0,0 +1,232 @@
+import * as jsonif from "./jsonif";
+
+export type PayloadObject = {
+    name?: string;
+    count?: number;
+}
+
+export class Payload {
+    name: string = "";
+    count: number = 0;
+    constructor(obj: PayloadObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+        if (obj.count !== undefined) {
+            this.count = obj.count;
+        }
+    }
+    static readonly typeName: string = "anypb.Payload";
+    getType(): typeof Payload {
+        return Payload;
+    }
+    static fromJson(json: string): Payload {
+        return Payload.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+        if (o.count !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.count, jsonif.joinPath(path, "count"));
+        } else {
+            jsonif.missingKey(path, "count");
+        }
+    }
+    static fromObject(obj: PayloadObject): Payload {
+        return new Payload(obj);
+    }
+    toObject(): PayloadObject {
+        return {
+            name: this.name,
+            count: this.count,
+        };
+    }
+}
+
+export type Test_NestedObject = {
+    tags?: string[];
+}
+
+export class Test_Nested {
+    tags: string[] = [];
+    constructor(obj: Test_NestedObject = {}) {
+        if (obj.tags !== undefined) {
+            this.tags = obj.tags;
+        }
+    }
+    static readonly typeName: string = "anypb.Test.Nested";
+    getType(): typeof Test_Nested {
+        return Test_Nested;
+    }
+    static fromJson(json: string): Test_Nested {
+        return Test_Nested.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.tags !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.tags, jsonif.joinPath(path, "tags"));
+        } else {
+            jsonif.missingKey(path, "tags");
+        }
+    }
+    static fromObject(obj: Test_NestedObject): Test_Nested {
+        return new Test_Nested(obj);
+    }
+    toObject(): Test_NestedObject {
+        return {
+            tags: this.tags,
+        };
+    }
+}
+
+export enum Test_KindCase {
+    NOT_SET = 0,
+    kOneofAny = 4,
+    kOneofString = 5,
+}
+
+export type TestObject = {
+    any?: jsonif.Any | null;
+    anys?: (jsonif.Any | null)[];
+    any_map?: { [key: string]: jsonif.Any | null };
+    oneof_any?: jsonif.Any | null;
+    oneof_string?: string;
+    kind_case?: Test_KindCase;
+}
+
+export class Test {
+    any: jsonif.Any | null = null;
+    anys: (jsonif.Any | null)[] = [];
+    any_map: Map<string, jsonif.Any | null> = new Map();
+    oneof_any: jsonif.Any | null = null;
+    oneof_string: string = "";
+    kind_case: Test_KindCase = Test_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test_KindCase.NOT_SET;
+        this.oneof_any = null;
+        this.oneof_string = "";
+    }
+    setOneofAny(value: jsonif.Any | null) {
+        this.kind_case = Test_KindCase.kOneofAny;
+        this.oneof_any = value;
+    }
+    clearOneofAny() {
+        if (this.kind_case === Test_KindCase.kOneofAny) {
+            this.clearKind();
+        }
+    }
+    setOneofString(value: string) {
+        this.kind_case = Test_KindCase.kOneofString;
+        this.oneof_string = value;
+    }
+    clearOneofString() {
+        if (this.kind_case === Test_KindCase.kOneofString) {
+            this.clearKind();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.any !== undefined) {
+            this.any = obj.any;
+        }
+        if (obj.anys !== undefined) {
+            this.anys = obj.anys;
+        }
+        if (obj.any_map !== undefined) {
+            this.any_map = new Map();
+            for (const k of Object.keys(obj.any_map)) {
+                this.any_map.set(k, obj.any_map[k]);
+            }
+        }
+        if (obj.oneof_any !== undefined) {
+            this.oneof_any = obj.oneof_any;
+        }
+        if (obj.oneof_string !== undefined) {
+            this.oneof_string = obj.oneof_string;
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+    }
+    static readonly typeName: string = "anypb.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.any !== undefined) {
+            jsonif.nullable(jsonif.checkObject)(o.any, jsonif.joinPath(path, "any"));
+        } else {
+            jsonif.missingKey(path, "any");
+        }
+        if (o.anys !== undefined) {
+            jsonif.arrayOf(jsonif.nullable(jsonif.checkObject))(o.anys, jsonif.joinPath(path, "anys"));
+        } else {
+            jsonif.missingKey(path, "anys");
+        }
+        if (o.any_map !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.nullable(jsonif.checkObject))(o.any_map, jsonif.joinPath(path, "any_map"));
+        } else {
+            jsonif.missingKey(path, "any_map");
+        }
+        if (o.oneof_any !== undefined) {
+            jsonif.nullable(jsonif.checkObject)(o.oneof_any, jsonif.joinPath(path, "oneof_any"));
+        }
+        if (o.oneof_string !== undefined) {
+            jsonif.checkString(o.oneof_string, jsonif.joinPath(path, "oneof_string"));
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        } else {
+            jsonif.missingKey(path, "kind_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const any_map: { [key: string]: jsonif.Any | null } = {};
+        this.any_map.forEach((v, k) => {
+            any_map[String(k)] = v;
+        });
+        return {
+            any: this.any,
+            anys: this.anys,
+            any_map: any_map,
+            oneof_any: this.oneof_any,
+            oneof_string: this.oneof_string,
+            kind_case: this.kind_case,
+        };
+    }
+}
+
+jsonif.registerType(Payload);
+jsonif.registerType(Test_Nested);
+jsonif.registerType(Test);
@@ -0,0 +1,397 @@
+// google.protobuf.Struct, ListValue, Value の値
+export type JsonValue = null | boolean | number | string | JsonValue[] | { [key: string]: JsonValue };
+
+// google.protobuf.Any の値（"@type" に型の URL が入る）
+export type Any = { "@type": string; [key: string]: JsonValue };
+
+// 生成したメッセージのクラス
+export interface MessageType<T> {
+    typeName: string;
+    fromObject(obj: any): T;
+}
+
+// メッセージの完全修飾名とクラスの対応表
+// 生成したファイルを読み込むと、そのファイルのメッセージが登録される
+const types = new Map<string, MessageType<unknown>>();
+
+export function registerType(type: MessageType<unknown>): void {
+    types.set(type.typeName, type);
+}
+
+// 型の URL から取り出したメッセージの完全修飾名
+function anyTypeName(a: Any): string {
+    const url = a["@type"];
+    return url.slice(url.lastIndexOf("/") + 1);
+}
+
+// メッセージを google.protobuf.Any に詰める
+export function pack(v: { getType(): { typeName: string }; toObject(): object }): Any {
+    return { "@type": "type.googleapis.com/" + v.getType().typeName, ...v.toObject() } as Any;
+}
+
+export function is<T>(a: Any, type: MessageType<T>): boolean {
+    return anyTypeName(a) === type.typeName;
+}
+
+// type を省略した場合は registerType で登録されたクラスから探す
+export function unpack<T>(a: Any, type: MessageType<T>): T;
+export function unpack(a: Any): unknown;
+export function unpack(a: Any, type?: MessageType<unknown>): unknown {
+    const t = type !== undefined ? type : types.get(anyTypeName(a));
+    if (t === undefined) {
+        throw new Error("unknown type: " + a["@type"]);
+    }
+    if (anyTypeName(a) !== t.typeName) {
+        throw new Error("type mismatch: " + a["@type"]);
+    }
+    return t.fromObject(a);
+}
+
+export interface Jsonif<T> {
+    getType: () => { fromJson(json: string): T };
+    toJson: () => string;
+}
+
+export function getType<T extends number | string | boolean | Jsonif<T>>(v: T): any {
+    if ((v as any).getType !== undefined) {
+        return (v as any).getType();
+    } else {
+        return v.constructor as any;
+    }
+}
+
+export function fromJson<T>(v: string, type: any): T {
+    if (type.fromJson !== undefined) {
+        return type.fromJson(v) as T;
+    } else {
+        return JSON.parse(v) as T;
+    }
+}
+
+export function toJson<T extends number | string | boolean | Jsonif<T>>(v: T): string {
+    if (typeof v === 'number' || typeof v === 'string' || typeof v === 'boolean') {
+        return JSON.stringify(v);
+    } else {
+        return v.toJson();
+    }
+}
+
+// 小数部は 0, 3, 6, 9 桁のいずれかにする
+function formatNanos(nanos: number): string {
+    if (nanos === 0) {
+        return "";
+    }
+    const s = String(1000000000 + nanos).slice(1);
+    if (nanos % 1000000 === 0) {
+        return "." + s.slice(0, 3);
+    }
+    if (nanos % 1000 === 0) {
+        return "." + s.slice(0, 6);
+    }
+    return "." + s;
+}
+
+// google.protobuf.Timestamp は RFC 3339 形式の文字列になる
+export function timestampToJson(v: Date): string {
+    const s = v.toISOString();
+    const ms = v.getTime() - Math.floor(v.getTime() / 1000) * 1000;
+    return s.slice(0, s.indexOf(".")) + formatNanos(ms * 1000000) + "Z";
+}
+
+export function timestampFromJson(v: string): Date {
+    const d = new Date(v);
+    if (isNaN(d.getTime())) {
+        throw new Error("invalid google.protobuf.Timestamp: " + v);
+    }
+    return d;
+}
+
+// google.protobuf.Duration はミリ秒の number で扱い、JSON では "1.5s" のような文字列になる
+export function durationToJson(v: number): string {
+    const ms = Math.abs(v);
+    let seconds = Math.floor(ms / 1000);
+    let nanos = Math.round((ms - seconds * 1000) * 1000000);
+    if (nanos >= 1000000000) {
+        seconds += 1;
+        nanos -= 1000000000;
+    }
+    return (v < 0 ? "-" : "") + String(seconds) + formatNanos(nanos) + "s";
+}
+
+export function durationFromJson(v: string): number {
+    const m = /^(-?)([0-9]+)(?:\.([0-9]{1,9}))?s$/.exec(v);
+    if (m === null) {
+        throw new Error("invalid google.protobuf.Duration: " + v);
+    }
+    const nanos = m[3] === undefined ? 0 : Number((m[3] + "00000000").slice(0, 9));
+    const ms = Number(m[2]) * 1000 + nanos / 1000000;
+    return m[1] === "-" ? -ms : ms;
+}
+
+// canonical の場合、enum は値の名前の文字列になる（未知の値は数値のまま）
+export function enumToJson(type: any, v: number): string | number {
+    const name = type[v];
+    return typeof name === "string" ? name : v;
+}
+
+// 名前と数値のどちらでも読み込める。未知の名前は 0 になる
+export function enumFromJson(type: any, v: string | number): number {
+    if (typeof v === "number") {
+        return v;
+    }
+    const value = type[v];
+    return typeof value === "number" ? value : 0;
+}
+
+// canonical の場合、int64 や uint64 は文字列になる（読み込む時は数値も受け付ける）
+// number で扱うので、2^53 を超える値は精度が落ちる
+export function int64ToJson(v: number): string {
+    return String(v);
+}
+
+export function int64FromJson(v: string | number): number {
+    const n = Number(v);
+    if (typeof v === "string" && (v.trim() === "" || isNaN(n))) {
+        throw new Error("invalid int64: " + v);
+    }
+    return n;
+}
+
+// bytes はパディング付きの base64 の文字列になる
+export function bytesToJson(v: Uint8Array): string {
+    let s = "";
+    for (let i = 0; i < v.length; i++) {
+        s += String.fromCharCode(v[i]);
+    }
+    return btoa(s);
+}
+
+// base64url パラメータを指定した場合は、パディング無しの URL セーフな base64 になる
+export function bytesUrlToJson(v: Uint8Array): string {
+    return bytesToJson(v).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+// URL セーフな base64 やパディングの無い base64 も読み込める
+export function bytesFromJson(v: string): Uint8Array {
+    const b = v.replace(/-/g, "+").replace(/_/g, "/");
+    const s = atob(b.padEnd(Math.ceil(b.length / 4) * 4, "="));
+    const r = new Uint8Array(s.length);
+    for (let i = 0; i < s.length; i++) {
+        r[i] = s.charCodeAt(i);
+    }
+    return r;
+}
+
+// 検証ルールの違反
+export type Violation = {
+    // JSON でのフィールドの位置（people[3].name など）
+    path: string;
+    message: string;
+}
+
+export function joinPath(path: string, key: string): string {
+    return path === "" ? key : path + "." + key;
+}
+
+// 文字列のコードポイント数（サロゲートペアは 1 文字として数える）
+export function stringLength(s: string): number {
+    let n = 0;
+    for (let i = 0; i < s.length; i++) {
+        const c = s.charCodeAt(i);
+        if (c < 0xdc00 || c > 0xdfff) {
+            n++;
+        }
+    }
+    return n;
+}
+
+// report_deprecated を指定して生成したクラスで、deprecated なフィールドのキーが JSON にあった時に呼ばれる関数
+// typeName はメッセージの完全修飾名、key は JSON のキー
+export type DeprecatedFieldHandler = (typeName: string, key: string) => void;
+
+let deprecatedFieldHandler: DeprecatedFieldHandler | null = null;
+let deprecatedFieldCount = 0;
+
+export function setDeprecatedFieldHandler(handler: DeprecatedFieldHandler | null): void {
+    deprecatedFieldHandler = handler;
+}
+
+// deprecated なフィールドのキーが JSON にあった回数
+export function getDeprecatedFieldCount(): number {
+    return deprecatedFieldCount;
+}
+
+export function resetDeprecatedFieldCount(): void {
+    deprecatedFieldCount = 0;
+}
+
+export function reportDeprecatedField(typeName: string, key: string): void {
+    deprecatedFieldCount++;
+    if (deprecatedFieldHandler !== null) {
+        deprecatedFieldHandler(typeName, key);
+    }
+}
+
+// JSON を読み込めなかった時のエラー
+// message は "people[3].name: expected string, got number" のような文字列になる
+export class ParseError extends Error {
+    // JSON での位置（people[3].name など）。JSON 全体の場合は空文字列
+    readonly path: string;
+    readonly reason: string;
+    constructor(path: string, reason: string) {
+        super(path === "" ? reason : path + ": " + reason);
+        // ES5 に変換した場合でも instanceof で判定できるようにする
+        Object.setPrototypeOf(this, ParseError.prototype);
+        this.name = "ParseError";
+        this.path = path;
+        this.reason = reason;
+    }
+}
+
+// JSON の値を調べて、問題があれば ParseError を投げる関数
+export type CheckFunc = (v: unknown, path: string) => void;
+export type KeyFunc = (key: string) => boolean;
+export type ReadFunc<T> = () => T;
+
+export function jsonKind(v: unknown): string {
+    if (v === null) return "null";
+    if (Array.isArray(v)) return "array";
+    return typeof v === "object" ? "object" : typeof v;
+}
+
+function expected(kind: string, v: unknown, path: string): never {
+    throw new ParseError(path, "expected " + kind + ", got " + jsonKind(v));
+}
+
+export function missingKey(path: string, key: string): never {
+    throw new ParseError(joinPath(path, key), "missing key");
+}
+
+// "@type" は google.protobuf.Any に詰めた時に付くので、常に受け付ける
+export function checkUnknownKeys(obj: Record<string, unknown>, knownKeys: ReadonlySet<string>, path: string): void {
+    for (const k of Object.keys(obj)) {
+        if (k !== "@type" && !knownKeys.has(k)) {
+            throw new ParseError(joinPath(path, k), "unknown key");
+        }
+    }
+}
+
+export function checkObject(v: unknown, path: string): void {
+    if (v === null || typeof v !== "object" || Array.isArray(v)) {
+        expected("object", v, path);
+    }
+}
+export function checkArray(v: unknown, path: string): void {
+    if (!Array.isArray(v)) {
+        expected("array", v, path);
+    }
+}
+export function checkString(v: unknown, path: string): void {
+    if (typeof v !== "string") {
+        expected("string", v, path);
+    }
+}
+export function checkBoolean(v: unknown, path: string): void {
+    if (typeof v !== "boolean") {
+        expected("boolean", v, path);
+    }
+}
+export function checkNumber(v: unknown, path: string): void {
+    if (typeof v !== "number") {
+        expected("number", v, path);
+    }
+}
+// google.protobuf.Value のように JSON の値なら何でもいい場合
+export function checkJsonValue(): void {
+}
+
+// 小数の数値は整数として受け付けない
+export function integerChecker(min: number, max: number): CheckFunc {
+    return (v, path) => {
+        if (typeof v !== "number" || !Number.isInteger(v)) {
+            expected("integer", v, path);
+        }
+        if ((v as number) < min || (v as number) > max) {
+            throw new ParseError(path, "out of range");
+        }
+    };
+}
+// 10 進数の整数の文字列で、範囲内かどうか
+export function isIntegerString(s: string, min: number, max: number): boolean {
+    return /^-?[0-9]+$/.test(s) && Number(s) >= min && Number(s) <= max;
+}
+// 文字列の int64 も数値の int64 も読み込めるようにする
+export function integerStringChecker(min: number, max: number): CheckFunc {
+    return (v, path) => {
+        if (typeof v !== "string") {
+            integerChecker(min, max)(v, path);
+        } else if (!isIntegerString(v, min, max)) {
+            throw new ParseError(path, "invalid integer");
+        }
+    };
+}
+// jsonif_enum_as_name の enum は名前の文字列も数値も読み込める
+export function enumChecker(asName: boolean): CheckFunc {
+    return (v, path) => {
+        if (asName && typeof v === "string") {
+            return;
+        }
+        if (asName && typeof v !== "number") {
+            expected("string or integer", v, path);
+        }
+        integerChecker(-2147483648, 2147483647)(v, path);
+    };
+}
+export function nullable(f: CheckFunc): CheckFunc {
+    return (v, path) => {
+        if (v !== null) {
+            f(v, path);
+        }
+    };
+}
+// 配列の各要素を f で調べる
+export function arrayOf(f: CheckFunc): CheckFunc {
+    return (v, path) => {
+        checkArray(v, path);
+        (v as unknown[]).forEach((x, i) => f(x, path + "[" + i + "]"));
+    };
+}
+// map のキーを isKey で、値を f で調べる
+export function mapOf(isKey: KeyFunc, f: CheckFunc): CheckFunc {
+    return (v, path) => {
+        checkObject(v, path);
+        const obj = v as Record<string, unknown>;
+        for (const k of Object.keys(obj)) {
+            const p = path + "[\"" + k + "\"]";
+            if (!isKey(k)) {
+                throw new ParseError(p, "invalid map key");
+            }
+            f(obj[k], p);
+        }
+    };
+}
+export function integerKey(min: number, max: number): KeyFunc {
+    return (k) => isIntegerString(k, min, max);
+}
+export function booleanKey(k: string): boolean {
+    return k === "true" || k === "false";
+}
+export function stringKey(): boolean {
+    return true;
+}
+
+let strictDepth = 0;
+
+// strict なメッセージ（とそれを含むメッセージ）を読み込む前に、オブジェクト全体を check で調べる
+// ネストしたメッセージで何度も調べないように、一番外側のメッセージでだけ調べる
+export function readStrict<T>(obj: unknown, check: CheckFunc, read: ReadFunc<T>): T {
+    if (strictDepth === 0) {
+        check(obj, "");
+    }
+    strictDepth++;
+    try {
+        return read();
+    } finally {
+        strictDepth--;
+    }
+}
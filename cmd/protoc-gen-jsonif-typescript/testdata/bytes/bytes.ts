@@ -0,0 +1,57 @@
+import * as jsonif from "./jsonif";
+
+export type TestObject = {
+    data?: string;
+    rp_data?: string[];
+}
+
+export class Test {
+    data: Uint8Array = new Uint8Array(0);
+    rp_data: Uint8Array[] = [];
+    constructor(obj: TestObject = {}) {
+        if (obj.data !== undefined) {
+            this.data = jsonif.bytesFromJson(obj.data);
+        }
+        if (obj.rp_data !== undefined) {
+            this.rp_data = obj.rp_data.map((x) => jsonif.bytesFromJson(x));
+        }
+    }
+    static readonly typeName: string = "bytes.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.data !== undefined) {
+            jsonif.checkString(o.data, jsonif.joinPath(path, "data"));
+        } else {
+            jsonif.missingKey(path, "data");
+        }
+        if (o.rp_data !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.rp_data, jsonif.joinPath(path, "rp_data"));
+        } else {
+            jsonif.missingKey(path, "rp_data");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+            data: jsonif.bytesToJson(this.data),
+            rp_data: this.rp_data.map((x) => jsonif.bytesToJson(x)),
+        };
+    }
+}
+
+jsonif.registerType(Test);
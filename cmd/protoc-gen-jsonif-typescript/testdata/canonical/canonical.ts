@@ -0,0 +1,317 @@
+import * as jsonif from "./jsonif";
+
+export enum Color {
+    COLOR_UNSPECIFIED = 0,
+    COLOR_RED = 1,
+    COLOR_BLUE = 2,
+}
+
+export type InnerObject = {
+    value?: number;
+}
+
+export class Inner {
+    value: number = 0;
+    constructor(obj: InnerObject = {}) {
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+    }
+    static readonly typeName: string = "canonical.Inner";
+    getType(): typeof Inner {
+        return Inner;
+    }
+    static fromJson(json: string): Inner {
+        return Inner.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        }
+    }
+    static fromObject(obj: InnerObject): Inner {
+        return new Inner(obj);
+    }
+    toObject(): InnerObject {
+        const obj: InnerObject = {};
+        if (this.value !== 0) {
+            obj.value = this.value;
+        }
+        return obj;
+    }
+}
+
+export enum Test_KindCase {
+    NOT_SET = 0,
+    kKindName = 13,
+    kKindNumber = 14,
+    kKindInner = 15,
+}
+
+export type TestObject = {
+    intValue?: number;
+    int64Value?: string | number;
+    uint64Value?: string | number;
+    int64Values?: (string | number)[];
+    int64Map?: { [key: string]: string | number };
+    customName?: string;
+    color?: string | number;
+    colors?: (string | number)[];
+    colorMap?: { [key: string]: string | number };
+    inner?: InnerObject;
+    wrappedInt64?: string | number | null;
+    optValue?: number | null;
+    kindName?: string;
+    kindNumber?: string | number;
+    kindInner?: InnerObject;
+    kind_case?: Test_KindCase;
+}
+
+export class Test {
+    int_value: number = 0;
+    int64_value: number = 0;
+    uint64_value: number = 0;
+    int64_values: number[] = [];
+    int64_map: Map<string, number> = new Map();
+    renamed: string = "";
+    color: Color = 0;
+    colors: Color[] = [];
+    color_map: Map<number, Color> = new Map();
+    inner: Inner = new Inner();
+    wrapped_int64: number | null = null;
+    opt_value: number | null = null;
+    kind_name: string = "";
+    kind_number: number = 0;
+    kind_inner: Inner = new Inner();
+    kind_case: Test_KindCase = Test_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test_KindCase.NOT_SET;
+        this.kind_name = "";
+        this.kind_number = 0;
+        this.kind_inner = new Inner();
+    }
+    setKindName(value: string) {
+        this.kind_case = Test_KindCase.kKindName;
+        this.kind_name = value;
+    }
+    clearKindName() {
+        if (this.kind_case === Test_KindCase.kKindName) {
+            this.clearKind();
+        }
+    }
+    setKindNumber(value: number) {
+        this.kind_case = Test_KindCase.kKindNumber;
+        this.kind_number = value;
+    }
+    clearKindNumber() {
+        if (this.kind_case === Test_KindCase.kKindNumber) {
+            this.clearKind();
+        }
+    }
+    setKindInner(value: Inner) {
+        this.kind_case = Test_KindCase.kKindInner;
+        this.kind_inner = value;
+    }
+    clearKindInner() {
+        if (this.kind_case === Test_KindCase.kKindInner) {
+            this.clearKind();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.intValue !== undefined) {
+            this.int_value = obj.intValue;
+        }
+        if (obj.int64Value !== undefined) {
+            this.int64_value = jsonif.int64FromJson(obj.int64Value);
+        }
+        if (obj.uint64Value !== undefined) {
+            this.uint64_value = jsonif.int64FromJson(obj.uint64Value);
+        }
+        if (obj.int64Values !== undefined) {
+            this.int64_values = obj.int64Values.map((x) => jsonif.int64FromJson(x));
+        }
+        if (obj.int64Map !== undefined) {
+            this.int64_map = new Map();
+            for (const k of Object.keys(obj.int64Map)) {
+                this.int64_map.set(k, jsonif.int64FromJson(obj.int64Map[k]));
+            }
+        }
+        if (obj.customName !== undefined) {
+            this.renamed = obj.customName;
+        }
+        if (obj.color !== undefined) {
+            this.color = jsonif.enumFromJson(Color, obj.color);
+        }
+        if (obj.colors !== undefined) {
+            this.colors = obj.colors.map((x) => jsonif.enumFromJson(Color, x));
+        }
+        if (obj.colorMap !== undefined) {
+            this.color_map = new Map();
+            for (const k of Object.keys(obj.colorMap)) {
+                this.color_map.set(Number(k), jsonif.enumFromJson(Color, obj.colorMap[k]));
+            }
+        }
+        if (obj.inner !== undefined) {
+            this.inner = Inner.fromObject(obj.inner);
+        }
+        if (obj.wrappedInt64 !== undefined) {
+            this.wrapped_int64 = obj.wrappedInt64 === null ? null : jsonif.int64FromJson(obj.wrappedInt64);
+        }
+        if (obj.optValue !== undefined) {
+            if (obj.optValue !== null) {
+                this.opt_value = obj.optValue;
+            }
+        }
+        if (obj.kindName !== undefined) {
+            this.kind_name = obj.kindName;
+            this.kind_case = Test_KindCase.kKindName;
+        }
+        if (obj.kindNumber !== undefined) {
+            this.kind_number = jsonif.int64FromJson(obj.kindNumber);
+            this.kind_case = Test_KindCase.kKindNumber;
+        }
+        if (obj.kindInner !== undefined) {
+            this.kind_inner = Inner.fromObject(obj.kindInner);
+            this.kind_case = Test_KindCase.kKindInner;
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+    }
+    static readonly typeName: string = "canonical.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.intValue !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.intValue, jsonif.joinPath(path, "intValue"));
+        }
+        if (o.int64Value !== undefined) {
+            jsonif.integerStringChecker(-9223372036854775808, 9223372036854775807)(o.int64Value, jsonif.joinPath(path, "int64Value"));
+        }
+        if (o.uint64Value !== undefined) {
+            jsonif.integerStringChecker(0, 18446744073709551615)(o.uint64Value, jsonif.joinPath(path, "uint64Value"));
+        }
+        if (o.int64Values !== undefined) {
+            jsonif.arrayOf(jsonif.integerStringChecker(-9223372036854775808, 9223372036854775807))(o.int64Values, jsonif.joinPath(path, "int64Values"));
+        }
+        if (o.int64Map !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.integerStringChecker(-9223372036854775808, 9223372036854775807))(o.int64Map, jsonif.joinPath(path, "int64Map"));
+        }
+        if (o.customName !== undefined) {
+            jsonif.checkString(o.customName, jsonif.joinPath(path, "customName"));
+        }
+        if (o.color !== undefined) {
+            jsonif.enumChecker(true)(o.color, jsonif.joinPath(path, "color"));
+        }
+        if (o.colors !== undefined) {
+            jsonif.arrayOf(jsonif.enumChecker(true))(o.colors, jsonif.joinPath(path, "colors"));
+        }
+        if (o.colorMap !== undefined) {
+            jsonif.mapOf(jsonif.integerKey(-2147483648, 2147483647), jsonif.enumChecker(true))(o.colorMap, jsonif.joinPath(path, "colorMap"));
+        }
+        if (o.inner !== undefined) {
+            Inner.checkJson(o.inner, jsonif.joinPath(path, "inner"));
+        }
+        if (o.wrappedInt64 !== undefined) {
+            jsonif.nullable(jsonif.integerStringChecker(-9223372036854775808, 9223372036854775807))(o.wrappedInt64, jsonif.joinPath(path, "wrappedInt64"));
+        }
+        if (o.optValue !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.optValue, jsonif.joinPath(path, "optValue"));
+        }
+        if (o.kindName !== undefined) {
+            jsonif.checkString(o.kindName, jsonif.joinPath(path, "kindName"));
+        }
+        if (o.kindNumber !== undefined) {
+            jsonif.integerStringChecker(-9223372036854775808, 9223372036854775807)(o.kindNumber, jsonif.joinPath(path, "kindNumber"));
+        }
+        if (o.kindInner !== undefined) {
+            Inner.checkJson(o.kindInner, jsonif.joinPath(path, "kindInner"));
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const int64_map: { [key: string]: string | number } = {};
+        this.int64_map.forEach((v, k) => {
+            int64_map[String(k)] = jsonif.int64ToJson(v);
+        });
+        const color_map: { [key: string]: string | number } = {};
+        this.color_map.forEach((v, k) => {
+            color_map[String(k)] = jsonif.enumToJson(Color, v);
+        });
+        const obj: TestObject = {};
+        if (this.int_value !== 0) {
+            obj.intValue = this.int_value;
+        }
+        if (this.int64_value !== 0) {
+            obj.int64Value = jsonif.int64ToJson(this.int64_value);
+        }
+        if (this.uint64_value !== 0) {
+            obj.uint64Value = jsonif.int64ToJson(this.uint64_value);
+        }
+        if (this.int64_values.length !== 0) {
+            obj.int64Values = this.int64_values.map((x) => jsonif.int64ToJson(x));
+        }
+        if (this.int64_map.size !== 0) {
+            obj.int64Map = int64_map;
+        }
+        if (this.renamed !== "") {
+            obj.customName = this.renamed;
+        }
+        if (this.color !== 0) {
+            obj.color = jsonif.enumToJson(Color, this.color);
+        }
+        if (this.colors.length !== 0) {
+            obj.colors = this.colors.map((x) => jsonif.enumToJson(Color, x));
+        }
+        if (this.color_map.size !== 0) {
+            obj.colorMap = color_map;
+        }
+        if (Object.keys(this.inner.toObject()).length !== 0) {
+            obj.inner = this.inner.toObject();
+        }
+        if (this.wrapped_int64 !== null) {
+            obj.wrappedInt64 = this.wrapped_int64 === null ? null : jsonif.int64ToJson(this.wrapped_int64);
+        }
+        if (this.opt_value !== null) {
+            obj.optValue = this.opt_value;
+        }
+        if (this.kind_case === Test_KindCase.kKindName) {
+            obj.kindName = this.kind_name;
+        }
+        if (this.kind_case === Test_KindCase.kKindNumber) {
+            obj.kindNumber = jsonif.int64ToJson(this.kind_number);
+        }
+        if (this.kind_case === Test_KindCase.kKindInner) {
+            obj.kindInner = this.kind_inner.toObject();
+        }
+        return obj;
+    }
+}
+
+jsonif.registerType(Inner);
+jsonif.registerType(Test);
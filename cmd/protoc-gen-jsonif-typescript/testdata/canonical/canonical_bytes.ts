@@ -0,0 +1,86 @@
+import * as jsonif from "./jsonif";
+
+export type BytesTestObject = {
+    data?: string;
+    datas?: string[];
+    dataMap?: { [key: string]: string };
+    wrappedData?: string | null;
+}
+
+export class BytesTest {
+    data: Uint8Array = new Uint8Array(0);
+    datas: Uint8Array[] = [];
+    data_map: Map<string, Uint8Array> = new Map();
+    wrapped_data: Uint8Array | null = null;
+    constructor(obj: BytesTestObject = {}) {
+        if (obj.data !== undefined) {
+            this.data = jsonif.bytesFromJson(obj.data);
+        }
+        if (obj.datas !== undefined) {
+            this.datas = obj.datas.map((x) => jsonif.bytesFromJson(x));
+        }
+        if (obj.dataMap !== undefined) {
+            this.data_map = new Map();
+            for (const k of Object.keys(obj.dataMap)) {
+                this.data_map.set(k, jsonif.bytesFromJson(obj.dataMap[k]));
+            }
+        }
+        if (obj.wrappedData !== undefined) {
+            this.wrapped_data = obj.wrappedData === null ? null : jsonif.bytesFromJson(obj.wrappedData);
+        }
+    }
+    static readonly typeName: string = "canonical.BytesTest";
+    getType(): typeof BytesTest {
+        return BytesTest;
+    }
+    static fromJson(json: string): BytesTest {
+        return BytesTest.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.data !== undefined) {
+            jsonif.checkString(o.data, jsonif.joinPath(path, "data"));
+        }
+        if (o.datas !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.datas, jsonif.joinPath(path, "datas"));
+        }
+        if (o.dataMap !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.checkString)(o.dataMap, jsonif.joinPath(path, "dataMap"));
+        }
+        if (o.wrappedData !== undefined) {
+            jsonif.nullable(jsonif.checkString)(o.wrappedData, jsonif.joinPath(path, "wrappedData"));
+        }
+    }
+    static fromObject(obj: BytesTestObject): BytesTest {
+        return new BytesTest(obj);
+    }
+    toObject(): BytesTestObject {
+        const data_map: { [key: string]: string } = {};
+        this.data_map.forEach((v, k) => {
+            data_map[String(k)] = jsonif.bytesToJson(v);
+        });
+        const obj: BytesTestObject = {};
+        if (this.data.length !== 0) {
+            obj.data = jsonif.bytesToJson(this.data);
+        }
+        if (this.datas.length !== 0) {
+            obj.datas = this.datas.map((x) => jsonif.bytesToJson(x));
+        }
+        if (this.data_map.size !== 0) {
+            obj.dataMap = data_map;
+        }
+        if (this.wrapped_data !== null) {
+            obj.wrappedData = this.wrapped_data === null ? null : jsonif.bytesToJson(this.wrapped_data);
+        }
+        return obj;
+    }
+}
+
+jsonif.registerType(BytesTest);
@@ -0,0 +1,280 @@
+import * as jsonif from "./jsonif";
+
+/**
+ * 列挙型のコメント
+ */
+export enum Kind {
+    /**
+     * 値のコメント
+     */
+    KIND_UNKNOWN = 0,
+    /**
+     * 後置コメント
+     */
+    KIND_FOO = 1,
+}
+
+/**
+ * ネストしたメッセージ
+ */
+export type Test_NestedObject = {
+    /**
+     * ネストしたメッセージのフィールド
+     */
+    flag?: boolean;
+}
+
+/**
+ * ネストしたメッセージ
+ */
+export class Test_Nested {
+    /**
+     * ネストしたメッセージのフィールド
+     */
+    flag: boolean = false;
+    constructor(obj: Test_NestedObject = {}) {
+        if (obj.flag !== undefined) {
+            this.flag = obj.flag;
+        }
+    }
+    static readonly typeName: string = "comments.Test.Nested";
+    getType(): typeof Test_Nested {
+        return Test_Nested;
+    }
+    static fromJson(json: string): Test_Nested {
+        return Test_Nested.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.flag !== undefined) {
+            jsonif.checkBoolean(o.flag, jsonif.joinPath(path, "flag"));
+        } else {
+            jsonif.missingKey(path, "flag");
+        }
+    }
+    static fromObject(obj: Test_NestedObject): Test_Nested {
+        return new Test_Nested(obj);
+    }
+    toObject(): Test_NestedObject {
+        return {
+            flag: this.flag,
+        };
+    }
+}
+
+/**
+ * oneof のコメント
+ */
+export enum Test_ValueCase {
+    NOT_SET = 0,
+    kA = 4,
+    kB = 5,
+}
+
+/**
+ * proto のコメントがドキュメントコメントとして出力されるか確認する用
+ *
+ * 複数行のコメントや <記号> & *\/ も扱えること
+ */
+export type TestObject = {
+    /**
+     * 前置コメント
+     */
+    leading?: number;
+    /**
+     * 後置コメント
+     */
+    trailing?: string;
+    none?: number[];
+    /**
+     * oneof フィールドのコメント
+     */
+    a?: number;
+    b?: string;
+    nested?: Test_NestedObject;
+    /**
+     * 離れたコメント
+     *
+     * 離れたコメントの後の前置コメント
+     */
+    detached?: number;
+    /**
+     * 前置コメントと
+     *
+     * 後置コメントの両方
+     */
+    both?: number;
+    value_case?: Test_ValueCase;
+}
+
+/**
+ * proto のコメントがドキュメントコメントとして出力されるか確認する用
+ *
+ * 複数行のコメントや <記号> & *\/ も扱えること
+ */
+export class Test {
+    /**
+     * 前置コメント
+     */
+    leading: number = 0;
+    /**
+     * 後置コメント
+     */
+    trailing: string = "";
+    none: number[] = [];
+    /**
+     * oneof フィールドのコメント
+     */
+    a: number = 0;
+    b: string = "";
+    nested: Test_Nested = new Test_Nested();
+    /**
+     * 離れたコメント
+     *
+     * 離れたコメントの後の前置コメント
+     */
+    detached: number = 0;
+    /**
+     * 前置コメントと
+     *
+     * 後置コメントの両方
+     */
+    both: number = 0;
+    value_case: Test_ValueCase = Test_ValueCase.NOT_SET;
+    clearValue() {
+        this.value_case = Test_ValueCase.NOT_SET;
+        this.a = 0;
+        this.b = "";
+    }
+    setA(value: number) {
+        this.value_case = Test_ValueCase.kA;
+        this.a = value;
+    }
+    clearA() {
+        if (this.value_case === Test_ValueCase.kA) {
+            this.clearValue();
+        }
+    }
+    setB(value: string) {
+        this.value_case = Test_ValueCase.kB;
+        this.b = value;
+    }
+    clearB() {
+        if (this.value_case === Test_ValueCase.kB) {
+            this.clearValue();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.leading !== undefined) {
+            this.leading = obj.leading;
+        }
+        if (obj.trailing !== undefined) {
+            this.trailing = obj.trailing;
+        }
+        if (obj.none !== undefined) {
+            this.none = obj.none;
+        }
+        if (obj.a !== undefined) {
+            this.a = obj.a;
+        }
+        if (obj.b !== undefined) {
+            this.b = obj.b;
+        }
+        if (obj.nested !== undefined) {
+            this.nested = Test_Nested.fromObject(obj.nested);
+        }
+        if (obj.detached !== undefined) {
+            this.detached = obj.detached;
+        }
+        if (obj.both !== undefined) {
+            this.both = obj.both;
+        }
+        if (obj.value_case !== undefined) {
+            this.value_case = obj.value_case;
+        }
+    }
+    static readonly typeName: string = "comments.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.leading !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.leading, jsonif.joinPath(path, "leading"));
+        } else {
+            jsonif.missingKey(path, "leading");
+        }
+        if (o.trailing !== undefined) {
+            jsonif.checkString(o.trailing, jsonif.joinPath(path, "trailing"));
+        } else {
+            jsonif.missingKey(path, "trailing");
+        }
+        if (o.none !== undefined) {
+            jsonif.arrayOf(jsonif.integerChecker(-2147483648, 2147483647))(o.none, jsonif.joinPath(path, "none"));
+        } else {
+            jsonif.missingKey(path, "none");
+        }
+        if (o.a !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.a, jsonif.joinPath(path, "a"));
+        }
+        if (o.b !== undefined) {
+            jsonif.checkString(o.b, jsonif.joinPath(path, "b"));
+        }
+        if (o.nested !== undefined) {
+            Test_Nested.checkJson(o.nested, jsonif.joinPath(path, "nested"));
+        } else {
+            jsonif.missingKey(path, "nested");
+        }
+        if (o.detached !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.detached, jsonif.joinPath(path, "detached"));
+        } else {
+            jsonif.missingKey(path, "detached");
+        }
+        if (o.both !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.both, jsonif.joinPath(path, "both"));
+        } else {
+            jsonif.missingKey(path, "both");
+        }
+        if (o.value_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value_case, jsonif.joinPath(path, "value_case"));
+        } else {
+            jsonif.missingKey(path, "value_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+            leading: this.leading,
+            trailing: this.trailing,
+            none: this.none,
+            a: this.a,
+            b: this.b,
+            nested: this.nested.toObject(),
+            detached: this.detached,
+            both: this.both,
+            value_case: this.value_case,
+        };
+    }
+}
+
+jsonif.registerType(Test_Nested);
+jsonif.registerType(Test);
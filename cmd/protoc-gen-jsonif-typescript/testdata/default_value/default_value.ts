@@ -0,0 +1,229 @@
+import * as jsonif from "./jsonif";
+
+export enum Color {
+    COLOR_UNSPECIFIED = 0,
+    COLOR_RED = 1,
+    COLOR_BLUE = 2,
+}
+
+export type InnerObject = {
+    value?: number;
+}
+
+export class Inner {
+    value: number = 10;
+    constructor(obj: InnerObject = {}) {
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+    }
+    static readonly typeName: string = "default_value.Inner";
+    getType(): typeof Inner {
+        return Inner;
+    }
+    static fromJson(json: string): Inner {
+        return Inner.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        }
+    }
+    static fromObject(obj: InnerObject): Inner {
+        return new Inner(obj);
+    }
+    toObject(): InnerObject {
+        return {
+            value: this.value,
+        };
+    }
+}
+
+/**
+ * キーが無い場合は jsonif_default の値になる
+ * デフォルト値と同じ値のフィールドは出力しない
+ */
+export type TestObject = {
+    d?: number;
+    f?: number;
+    big?: number;
+    i32?: number;
+    i64?: number;
+    u32?: number;
+    u64?: number;
+    b?: boolean;
+    s?: string;
+    empty?: string;
+    /**
+     * enum は値の名前でも数値でも指定できる
+     */
+    color?: Color;
+    color_num?: Color;
+    no_default?: number;
+    /**
+     * メッセージのフィールドも、そのメッセージの jsonif_default の値で初期化される
+     */
+    inner?: InnerObject;
+}
+
+/**
+ * キーが無い場合は jsonif_default の値になる
+ * デフォルト値と同じ値のフィールドは出力しない
+ */
+export class Test {
+    d: number = 1.5;
+    f: number = 3;
+    big: number = 1e+30;
+    i32: number = -2147483648;
+    i64: number = -9223372036854775808;
+    u32: number = 4294967295;
+    u64: number = 12345678901234;
+    b: boolean = true;
+    s: string = "hello \"jsonif\"\n";
+    empty: string = "";
+    /**
+     * enum は値の名前でも数値でも指定できる
+     */
+    color: Color = 1;
+    color_num: Color = 2;
+    no_default: number = 0;
+    /**
+     * メッセージのフィールドも、そのメッセージの jsonif_default の値で初期化される
+     */
+    inner: Inner = new Inner();
+    constructor(obj: TestObject = {}) {
+        if (obj.d !== undefined) {
+            this.d = obj.d;
+        }
+        if (obj.f !== undefined) {
+            this.f = obj.f;
+        }
+        if (obj.big !== undefined) {
+            this.big = obj.big;
+        }
+        if (obj.i32 !== undefined) {
+            this.i32 = obj.i32;
+        }
+        if (obj.i64 !== undefined) {
+            this.i64 = obj.i64;
+        }
+        if (obj.u32 !== undefined) {
+            this.u32 = obj.u32;
+        }
+        if (obj.u64 !== undefined) {
+            this.u64 = obj.u64;
+        }
+        if (obj.b !== undefined) {
+            this.b = obj.b;
+        }
+        if (obj.s !== undefined) {
+            this.s = obj.s;
+        }
+        if (obj.empty !== undefined) {
+            this.empty = obj.empty;
+        }
+        if (obj.color !== undefined) {
+            this.color = obj.color;
+        }
+        if (obj.color_num !== undefined) {
+            this.color_num = obj.color_num;
+        }
+        if (obj.no_default !== undefined) {
+            this.no_default = obj.no_default;
+        }
+        if (obj.inner !== undefined) {
+            this.inner = Inner.fromObject(obj.inner);
+        }
+    }
+    static readonly typeName: string = "default_value.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.d !== undefined) {
+            jsonif.checkNumber(o.d, jsonif.joinPath(path, "d"));
+        }
+        if (o.f !== undefined) {
+            jsonif.checkNumber(o.f, jsonif.joinPath(path, "f"));
+        }
+        if (o.big !== undefined) {
+            jsonif.checkNumber(o.big, jsonif.joinPath(path, "big"));
+        }
+        if (o.i32 !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.i32, jsonif.joinPath(path, "i32"));
+        }
+        if (o.i64 !== undefined) {
+            jsonif.integerChecker(-9223372036854775808, 9223372036854775807)(o.i64, jsonif.joinPath(path, "i64"));
+        }
+        if (o.u32 !== undefined) {
+            jsonif.integerChecker(0, 4294967295)(o.u32, jsonif.joinPath(path, "u32"));
+        }
+        if (o.u64 !== undefined) {
+            jsonif.integerChecker(0, 18446744073709551615)(o.u64, jsonif.joinPath(path, "u64"));
+        }
+        if (o.b !== undefined) {
+            jsonif.checkBoolean(o.b, jsonif.joinPath(path, "b"));
+        }
+        if (o.s !== undefined) {
+            jsonif.checkString(o.s, jsonif.joinPath(path, "s"));
+        }
+        if (o.empty !== undefined) {
+            jsonif.checkString(o.empty, jsonif.joinPath(path, "empty"));
+        }
+        if (o.color !== undefined) {
+            jsonif.enumChecker(false)(o.color, jsonif.joinPath(path, "color"));
+        }
+        if (o.color_num !== undefined) {
+            jsonif.enumChecker(false)(o.color_num, jsonif.joinPath(path, "color_num"));
+        }
+        if (o.no_default !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.no_default, jsonif.joinPath(path, "no_default"));
+        }
+        if (o.inner !== undefined) {
+            Inner.checkJson(o.inner, jsonif.joinPath(path, "inner"));
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+            d: this.d,
+            f: this.f,
+            big: this.big,
+            i32: this.i32,
+            i64: this.i64,
+            u32: this.u32,
+            u64: this.u64,
+            b: this.b,
+            s: this.s,
+            empty: this.empty,
+            color: this.color,
+            color_num: this.color_num,
+            no_default: this.no_default,
+            inner: this.inner.toObject(),
+        };
+    }
+}
+
+jsonif.registerType(Inner);
+jsonif.registerType(Test);
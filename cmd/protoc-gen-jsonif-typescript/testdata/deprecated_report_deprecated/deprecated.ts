@@ -0,0 +1,339 @@
+import * as jsonif from "./jsonif";
+
+export enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+    /**
+     * STATUS_ACTIVE を使うこと
+     *
+     * @deprecated
+     */
+    STATUS_ENABLED = 2,
+}
+
+/**
+ * Test に置き換えられた古いメッセージ
+ *
+ * @deprecated
+ */
+export type LegacyObject = {
+    value?: number;
+}
+
+/**
+ * Test に置き換えられた古いメッセージ
+ *
+ * @deprecated
+ */
+export class Legacy {
+    value: number = 0;
+    constructor(obj: LegacyObject = {}) {
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+    }
+    static readonly typeName: string = "deprecated.Legacy";
+    getType(): typeof Legacy {
+        return Legacy;
+    }
+    static fromJson(json: string): Legacy {
+        return Legacy.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        } else {
+            jsonif.missingKey(path, "value");
+        }
+    }
+    static fromObject(obj: LegacyObject): Legacy {
+        return new Legacy(obj);
+    }
+    toObject(): LegacyObject {
+        return {
+            value: this.value,
+        };
+    }
+}
+
+export enum Test_ChoiceCase {
+    NOT_SET = 0,
+    kNumber = 7,
+    kText = 8,
+}
+
+export type TestObject = {
+    id?: number;
+    /**
+     * id を使うこと
+     *
+     * @deprecated
+     */
+    old_id?: number;
+    status?: Status;
+    /**
+     * @deprecated
+     */
+    legacy?: LegacyObject;
+    /**
+     * @deprecated
+     */
+    tags?: string[];
+    /**
+     * @deprecated
+     */
+    note?: string | null;
+    number?: number;
+    /**
+     * @deprecated
+     */
+    text?: string;
+    choice_case?: Test_ChoiceCase;
+}
+
+export class Test {
+    id: number = 0;
+    /**
+     * id を使うこと
+     *
+     * @deprecated
+     */
+    old_id: number = 0;
+    status: Status = 0;
+    /**
+     * @deprecated
+     */
+    legacy: Legacy = new Legacy();
+    /**
+     * @deprecated
+     */
+    tags: string[] = [];
+    /**
+     * @deprecated
+     */
+    note: string | null = null;
+    number: number = 0;
+    /**
+     * @deprecated
+     */
+    text: string = "";
+    choice_case: Test_ChoiceCase = Test_ChoiceCase.NOT_SET;
+    clearChoice() {
+        this.choice_case = Test_ChoiceCase.NOT_SET;
+        this.number = 0;
+        this.text = "";
+    }
+    setNumber(value: number) {
+        this.choice_case = Test_ChoiceCase.kNumber;
+        this.number = value;
+    }
+    clearNumber() {
+        if (this.choice_case === Test_ChoiceCase.kNumber) {
+            this.clearChoice();
+        }
+    }
+    /**
+     * @deprecated
+     */
+    setText(value: string) {
+        this.choice_case = Test_ChoiceCase.kText;
+        this.text = value;
+    }
+    /**
+     * @deprecated
+     */
+    clearText() {
+        if (this.choice_case === Test_ChoiceCase.kText) {
+            this.clearChoice();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.id !== undefined) {
+            this.id = obj.id;
+        }
+        if (obj.old_id !== undefined) {
+            jsonif.reportDeprecatedField("deprecated.Test", "old_id");
+            this.old_id = obj.old_id;
+        }
+        if (obj.status !== undefined) {
+            this.status = obj.status;
+        }
+        if (obj.legacy !== undefined) {
+            jsonif.reportDeprecatedField("deprecated.Test", "legacy");
+            this.legacy = Legacy.fromObject(obj.legacy);
+        }
+        if (obj.tags !== undefined) {
+            jsonif.reportDeprecatedField("deprecated.Test", "tags");
+            this.tags = obj.tags;
+        }
+        if (obj.note !== undefined) {
+            jsonif.reportDeprecatedField("deprecated.Test", "note");
+            if (obj.note !== null) {
+                this.note = obj.note;
+            }
+        }
+        if (obj.number !== undefined) {
+            this.number = obj.number;
+        }
+        if (obj.text !== undefined) {
+            jsonif.reportDeprecatedField("deprecated.Test", "text");
+            this.text = obj.text;
+        }
+        if (obj.choice_case !== undefined) {
+            this.choice_case = obj.choice_case;
+        }
+    }
+    static readonly typeName: string = "deprecated.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.id !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.id, jsonif.joinPath(path, "id"));
+        } else {
+            jsonif.missingKey(path, "id");
+        }
+        if (o.old_id !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.old_id, jsonif.joinPath(path, "old_id"));
+        } else {
+            jsonif.missingKey(path, "old_id");
+        }
+        if (o.status !== undefined) {
+            jsonif.enumChecker(false)(o.status, jsonif.joinPath(path, "status"));
+        } else {
+            jsonif.missingKey(path, "status");
+        }
+        if (o.legacy !== undefined) {
+            Legacy.checkJson(o.legacy, jsonif.joinPath(path, "legacy"));
+        } else {
+            jsonif.missingKey(path, "legacy");
+        }
+        if (o.tags !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.tags, jsonif.joinPath(path, "tags"));
+        } else {
+            jsonif.missingKey(path, "tags");
+        }
+        if (o.note !== undefined) {
+            jsonif.nullable(jsonif.checkString)(o.note, jsonif.joinPath(path, "note"));
+        }
+        if (o.number !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.number, jsonif.joinPath(path, "number"));
+        }
+        if (o.text !== undefined) {
+            jsonif.checkString(o.text, jsonif.joinPath(path, "text"));
+        }
+        if (o.choice_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.choice_case, jsonif.joinPath(path, "choice_case"));
+        } else {
+            jsonif.missingKey(path, "choice_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const obj: TestObject = {};
+        obj.id = this.id;
+        obj.old_id = this.old_id;
+        obj.status = this.status;
+        obj.legacy = this.legacy.toObject();
+        obj.tags = this.tags;
+        if (this.note !== null) {
+            obj.note = this.note;
+        }
+        obj.number = this.number;
+        obj.text = this.text;
+        obj.choice_case = this.choice_case;
+        return obj;
+    }
+}
+
+/**
+ * deprecated なメッセージや enum の値を参照するだけのメッセージ
+ */
+export type HolderObject = {
+    status?: Status;
+    statuses?: { [key: string]: Status };
+}
+
+/**
+ * deprecated なメッセージや enum の値を参照するだけのメッセージ
+ */
+export class Holder {
+    status: Status = 0;
+    statuses: Map<string, Status> = new Map();
+    constructor(obj: HolderObject = {}) {
+        if (obj.status !== undefined) {
+            this.status = obj.status;
+        }
+        if (obj.statuses !== undefined) {
+            this.statuses = new Map();
+            for (const k of Object.keys(obj.statuses)) {
+                this.statuses.set(k, obj.statuses[k]);
+            }
+        }
+    }
+    static readonly typeName: string = "deprecated.Holder";
+    getType(): typeof Holder {
+        return Holder;
+    }
+    static fromJson(json: string): Holder {
+        return Holder.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.status !== undefined) {
+            jsonif.enumChecker(false)(o.status, jsonif.joinPath(path, "status"));
+        } else {
+            jsonif.missingKey(path, "status");
+        }
+        if (o.statuses !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.enumChecker(false))(o.statuses, jsonif.joinPath(path, "statuses"));
+        } else {
+            jsonif.missingKey(path, "statuses");
+        }
+    }
+    static fromObject(obj: HolderObject): Holder {
+        return new Holder(obj);
+    }
+    toObject(): HolderObject {
+        const statuses: { [key: string]: Status } = {};
+        this.statuses.forEach((v, k) => {
+            statuses[String(k)] = v;
+        });
+        return {
+            status: this.status,
+            statuses: statuses,
+        };
+    }
+}
+
+jsonif.registerType(Legacy);
+jsonif.registerType(Test);
+jsonif.registerType(Holder);
@@ -0,0 +1,35 @@
+import * as jsonif from "./jsonif";
+
+export type TestObject = {
+}
+
+export class Test {
+    constructor(obj: TestObject = {}) {
+    }
+    static readonly typeName: string = "empty.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+        };
+    }
+}
+
+jsonif.registerType(Test);
@@ -0,0 +1,177 @@
+import * as jsonif from "./jsonif";
+
+export enum Color {
+    COLOR_UNSPECIFIED = 0,
+    COLOR_RED = 1,
+    COLOR_BLUE = 2,
+}
+
+/**
+ * enum ごとの設定がファイルの設定より優先される
+ */
+export enum Number {
+    NUMBER_ZERO = 0,
+    NUMBER_ONE = 1,
+}
+
+export enum Test_ValueCase {
+    NOT_SET = 0,
+    kOneofColor = 7,
+    kOneofInt = 8,
+}
+
+export type TestObject = {
+    color?: string | number;
+    colors?: (string | number)[];
+    color_map?: { [key: string]: string | number };
+    number?: Number;
+    nested?: string | number;
+    opt_color?: string | number | null;
+    oneof_color?: string | number;
+    oneof_int?: number;
+    value_case?: Test_ValueCase;
+}
+
+export enum Test_Nested {
+    NESTED_FOO = 0,
+    NESTED_BAR = 1,
+}
+
+export class Test {
+    color: Color = 0;
+    colors: Color[] = [];
+    color_map: Map<string, Color> = new Map();
+    number: Number = 0;
+    nested: Test_Nested = 0;
+    opt_color: Color | null = null;
+    oneof_color: Color = 0;
+    oneof_int: number = 0;
+    value_case: Test_ValueCase = Test_ValueCase.NOT_SET;
+    clearValue() {
+        this.value_case = Test_ValueCase.NOT_SET;
+        this.oneof_color = 0;
+        this.oneof_int = 0;
+    }
+    setOneofColor(value: Color) {
+        this.value_case = Test_ValueCase.kOneofColor;
+        this.oneof_color = value;
+    }
+    clearOneofColor() {
+        if (this.value_case === Test_ValueCase.kOneofColor) {
+            this.clearValue();
+        }
+    }
+    setOneofInt(value: number) {
+        this.value_case = Test_ValueCase.kOneofInt;
+        this.oneof_int = value;
+    }
+    clearOneofInt() {
+        if (this.value_case === Test_ValueCase.kOneofInt) {
+            this.clearValue();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.color !== undefined) {
+            this.color = jsonif.enumFromJson(Color, obj.color);
+        }
+        if (obj.colors !== undefined) {
+            this.colors = obj.colors.map((x) => jsonif.enumFromJson(Color, x));
+        }
+        if (obj.color_map !== undefined) {
+            this.color_map = new Map();
+            for (const k of Object.keys(obj.color_map)) {
+                this.color_map.set(k, jsonif.enumFromJson(Color, obj.color_map[k]));
+            }
+        }
+        if (obj.number !== undefined) {
+            this.number = obj.number;
+        }
+        if (obj.nested !== undefined) {
+            this.nested = jsonif.enumFromJson(Test_Nested, obj.nested);
+        }
+        if (obj.opt_color !== undefined) {
+            if (obj.opt_color !== null) {
+                this.opt_color = jsonif.enumFromJson(Color, obj.opt_color);
+            }
+        }
+        if (obj.oneof_color !== undefined) {
+            this.oneof_color = jsonif.enumFromJson(Color, obj.oneof_color);
+        }
+        if (obj.oneof_int !== undefined) {
+            this.oneof_int = obj.oneof_int;
+        }
+        if (obj.value_case !== undefined) {
+            this.value_case = obj.value_case;
+        }
+    }
+    static readonly typeName: string = "enum_name.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.color !== undefined) {
+            jsonif.enumChecker(true)(o.color, jsonif.joinPath(path, "color"));
+        }
+        if (o.colors !== undefined) {
+            jsonif.arrayOf(jsonif.enumChecker(true))(o.colors, jsonif.joinPath(path, "colors"));
+        }
+        if (o.color_map !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.enumChecker(true))(o.color_map, jsonif.joinPath(path, "color_map"));
+        }
+        if (o.number !== undefined) {
+            jsonif.enumChecker(false)(o.number, jsonif.joinPath(path, "number"));
+        }
+        if (o.nested !== undefined) {
+            jsonif.enumChecker(true)(o.nested, jsonif.joinPath(path, "nested"));
+        }
+        if (o.opt_color !== undefined) {
+            jsonif.nullable(jsonif.enumChecker(true))(o.opt_color, jsonif.joinPath(path, "opt_color"));
+        }
+        if (o.oneof_color !== undefined) {
+            jsonif.enumChecker(true)(o.oneof_color, jsonif.joinPath(path, "oneof_color"));
+        }
+        if (o.oneof_int !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.oneof_int, jsonif.joinPath(path, "oneof_int"));
+        }
+        if (o.value_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value_case, jsonif.joinPath(path, "value_case"));
+        } else {
+            jsonif.missingKey(path, "value_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const color_map: { [key: string]: string | number } = {};
+        this.color_map.forEach((v, k) => {
+            color_map[String(k)] = jsonif.enumToJson(Color, v);
+        });
+        const obj: TestObject = {};
+        obj.color = jsonif.enumToJson(Color, this.color);
+        obj.colors = this.colors.map((x) => jsonif.enumToJson(Color, x));
+        obj.color_map = color_map;
+        obj.number = this.number;
+        obj.nested = jsonif.enumToJson(Test_Nested, this.nested);
+        if (this.opt_color !== null) {
+            obj.opt_color = this.opt_color === null ? null : jsonif.enumToJson(Color, this.opt_color);
+        }
+        obj.oneof_color = jsonif.enumToJson(Color, this.oneof_color);
+        obj.oneof_int = this.oneof_int;
+        obj.value_case = this.value_case;
+        return obj;
+    }
+}
+
+jsonif.registerType(Test);
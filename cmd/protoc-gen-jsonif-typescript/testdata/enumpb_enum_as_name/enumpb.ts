@@ -0,0 +1,6 @@
+
+export enum Data {
+    FOO = 0,
+    BAR = 1,
+}
+
@@ -0,0 +1,46 @@
+import * as jsonif from "./jsonif";
+
+export type TestObject = {
+    t?: string;
+}
+
+export class Test {
+    t: Date = new Date(0);
+    constructor(obj: TestObject = {}) {
+        if (obj.t !== undefined) {
+            this.t = jsonif.timestampFromJson(obj.t);
+        }
+    }
+    static readonly typeName: string = "importing.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.t !== undefined) {
+            jsonif.checkString(o.t, jsonif.joinPath(path, "t"));
+        } else {
+            jsonif.missingKey(path, "t");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+            t: jsonif.timestampToJson(this.t),
+        };
+    }
+}
+
+jsonif.registerType(Test);
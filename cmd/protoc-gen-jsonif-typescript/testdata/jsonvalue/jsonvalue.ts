@@ -0,0 +1,155 @@
+import * as jsonif from "./jsonif";
+
+export enum Test_KindCase {
+    NOT_SET = 0,
+    kOneofValue = 6,
+    kOneofString = 7,
+}
+
+export type TestObject = {
+    struct_value?: { [key: string]: jsonif.JsonValue } | null;
+    value?: jsonif.JsonValue;
+    list_value?: jsonif.JsonValue[] | null;
+    values?: jsonif.JsonValue[];
+    structs?: { [key: string]: { [key: string]: jsonif.JsonValue } | null };
+    oneof_value?: jsonif.JsonValue;
+    oneof_string?: string;
+    kind_case?: Test_KindCase;
+}
+
+export class Test {
+    struct_value: { [key: string]: jsonif.JsonValue } | null = null;
+    value: jsonif.JsonValue = null;
+    list_value: jsonif.JsonValue[] | null = null;
+    values: jsonif.JsonValue[] = [];
+    structs: Map<string, { [key: string]: jsonif.JsonValue } | null> = new Map();
+    oneof_value: jsonif.JsonValue = null;
+    oneof_string: string = "";
+    kind_case: Test_KindCase = Test_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test_KindCase.NOT_SET;
+        this.oneof_value = null;
+        this.oneof_string = "";
+    }
+    setOneofValue(value: jsonif.JsonValue) {
+        this.kind_case = Test_KindCase.kOneofValue;
+        this.oneof_value = value;
+    }
+    clearOneofValue() {
+        if (this.kind_case === Test_KindCase.kOneofValue) {
+            this.clearKind();
+        }
+    }
+    setOneofString(value: string) {
+        this.kind_case = Test_KindCase.kOneofString;
+        this.oneof_string = value;
+    }
+    clearOneofString() {
+        if (this.kind_case === Test_KindCase.kOneofString) {
+            this.clearKind();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.struct_value !== undefined) {
+            this.struct_value = obj.struct_value;
+        }
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+        if (obj.list_value !== undefined) {
+            this.list_value = obj.list_value;
+        }
+        if (obj.values !== undefined) {
+            this.values = obj.values;
+        }
+        if (obj.structs !== undefined) {
+            this.structs = new Map();
+            for (const k of Object.keys(obj.structs)) {
+                this.structs.set(k, obj.structs[k]);
+            }
+        }
+        if (obj.oneof_value !== undefined) {
+            this.oneof_value = obj.oneof_value;
+        }
+        if (obj.oneof_string !== undefined) {
+            this.oneof_string = obj.oneof_string;
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+    }
+    static readonly typeName: string = "jsonvalue.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.struct_value !== undefined) {
+            jsonif.nullable(jsonif.checkObject)(o.struct_value, jsonif.joinPath(path, "struct_value"));
+        } else {
+            jsonif.missingKey(path, "struct_value");
+        }
+        if (o.value !== undefined) {
+            jsonif.checkJsonValue(o.value, jsonif.joinPath(path, "value"));
+        } else {
+            jsonif.missingKey(path, "value");
+        }
+        if (o.list_value !== undefined) {
+            jsonif.nullable(jsonif.checkArray)(o.list_value, jsonif.joinPath(path, "list_value"));
+        } else {
+            jsonif.missingKey(path, "list_value");
+        }
+        if (o.values !== undefined) {
+            jsonif.arrayOf(jsonif.checkJsonValue)(o.values, jsonif.joinPath(path, "values"));
+        } else {
+            jsonif.missingKey(path, "values");
+        }
+        if (o.structs !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.nullable(jsonif.checkObject))(o.structs, jsonif.joinPath(path, "structs"));
+        } else {
+            jsonif.missingKey(path, "structs");
+        }
+        if (o.oneof_value !== undefined) {
+            jsonif.checkJsonValue(o.oneof_value, jsonif.joinPath(path, "oneof_value"));
+        }
+        if (o.oneof_string !== undefined) {
+            jsonif.checkString(o.oneof_string, jsonif.joinPath(path, "oneof_string"));
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        } else {
+            jsonif.missingKey(path, "kind_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const structs: { [key: string]: { [key: string]: jsonif.JsonValue } | null } = {};
+        this.structs.forEach((v, k) => {
+            structs[String(k)] = v;
+        });
+        return {
+            struct_value: this.struct_value,
+            value: this.value,
+            list_value: this.list_value,
+            values: this.values,
+            structs: structs,
+            oneof_value: this.oneof_value,
+            oneof_string: this.oneof_string,
+            kind_case: this.kind_case,
+        };
+    }
+}
+
+jsonif.registerType(Test);
@@ -0,0 +1,213 @@
+import * as jsonif from "./jsonif";
+
+/**
+ * 各言語の予約語と同じ名前を使っても、コンパイルできるコードが生成されるか確認する用
+ */
+export enum Keyword {
+    default = 0,
+    class = 1,
+}
+
+export enum Test_KindCase {
+    NOT_SET = 0,
+    kNew = 10,
+    kThis = 11,
+}
+
+export type TestObject = {
+    class?: number;
+    default?: string;
+    delete?: boolean;
+    namespace?: string;
+    operator?: number;
+    object?: string;
+    string?: string;
+    restrict?: string[];
+    constructor?: Keyword;
+    new?: number;
+    this?: string;
+    int?: number | null;
+    kind_case?: Test_KindCase;
+}
+
+export class Test {
+    class: number = 0;
+    default: string = "";
+    delete: boolean = false;
+    namespace: string = "";
+    operator: number = 0;
+    object: string = "";
+    string: string = "";
+    restrict: string[] = [];
+    constructor_: Keyword = 0;
+    new: number = 0;
+    this: string = "";
+    int: number | null = null;
+    kind_case: Test_KindCase = Test_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test_KindCase.NOT_SET;
+        this.new = 0;
+        this.this = "";
+    }
+    setNew(value: number) {
+        this.kind_case = Test_KindCase.kNew;
+        this.new = value;
+    }
+    clearNew() {
+        if (this.kind_case === Test_KindCase.kNew) {
+            this.clearKind();
+        }
+    }
+    setThis(value: string) {
+        this.kind_case = Test_KindCase.kThis;
+        this.this = value;
+    }
+    clearThis() {
+        if (this.kind_case === Test_KindCase.kThis) {
+            this.clearKind();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.class !== undefined) {
+            this.class = obj.class;
+        }
+        if (obj.default !== undefined) {
+            this.default = obj.default;
+        }
+        if (obj.delete !== undefined) {
+            this.delete = obj.delete;
+        }
+        if (obj.namespace !== undefined) {
+            this.namespace = obj.namespace;
+        }
+        if (obj.operator !== undefined) {
+            this.operator = obj.operator;
+        }
+        if (obj.object !== undefined) {
+            this.object = obj.object;
+        }
+        if (obj.string !== undefined) {
+            this.string = obj.string;
+        }
+        if (obj.restrict !== undefined) {
+            this.restrict = obj.restrict;
+        }
+        if (obj.constructor !== undefined) {
+            this.constructor_ = obj.constructor;
+        }
+        if (obj.new !== undefined) {
+            this.new = obj.new;
+        }
+        if (obj.this !== undefined) {
+            this.this = obj.this;
+        }
+        if (obj.int !== undefined) {
+            if (obj.int !== null) {
+                this.int = obj.int;
+            }
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+    }
+    static readonly typeName: string = "keywords.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.class !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.class, jsonif.joinPath(path, "class"));
+        } else {
+            jsonif.missingKey(path, "class");
+        }
+        if (o.default !== undefined) {
+            jsonif.checkString(o.default, jsonif.joinPath(path, "default"));
+        } else {
+            jsonif.missingKey(path, "default");
+        }
+        if (o.delete !== undefined) {
+            jsonif.checkBoolean(o.delete, jsonif.joinPath(path, "delete"));
+        } else {
+            jsonif.missingKey(path, "delete");
+        }
+        if (o.namespace !== undefined) {
+            jsonif.checkString(o.namespace, jsonif.joinPath(path, "namespace"));
+        } else {
+            jsonif.missingKey(path, "namespace");
+        }
+        if (o.operator !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.operator, jsonif.joinPath(path, "operator"));
+        } else {
+            jsonif.missingKey(path, "operator");
+        }
+        if (o.object !== undefined) {
+            jsonif.checkString(o.object, jsonif.joinPath(path, "object"));
+        } else {
+            jsonif.missingKey(path, "object");
+        }
+        if (o.string !== undefined) {
+            jsonif.checkString(o.string, jsonif.joinPath(path, "string"));
+        } else {
+            jsonif.missingKey(path, "string");
+        }
+        if (o.restrict !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.restrict, jsonif.joinPath(path, "restrict"));
+        } else {
+            jsonif.missingKey(path, "restrict");
+        }
+        if (o.constructor !== undefined) {
+            jsonif.enumChecker(false)(o.constructor, jsonif.joinPath(path, "constructor"));
+        } else {
+            jsonif.missingKey(path, "constructor");
+        }
+        if (o.new !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.new, jsonif.joinPath(path, "new"));
+        }
+        if (o.this !== undefined) {
+            jsonif.checkString(o.this, jsonif.joinPath(path, "this"));
+        }
+        if (o.int !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.int, jsonif.joinPath(path, "int"));
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        } else {
+            jsonif.missingKey(path, "kind_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const obj: TestObject = {};
+        obj.class = this.class;
+        obj.default = this.default;
+        obj.delete = this.delete;
+        obj.namespace = this.namespace;
+        obj.operator = this.operator;
+        obj.object = this.object;
+        obj.string = this.string;
+        obj.restrict = this.restrict;
+        obj.constructor = this.constructor_;
+        obj.new = this.new;
+        obj.this = this.this;
+        if (this.int !== null) {
+            obj.int = this.int;
+        }
+        obj.kind_case = this.kind_case;
+        return obj;
+    }
+}
+
+jsonif.registerType(Test);
@@ -0,0 +1,192 @@
+import * as jsonif from "./jsonif";
+
+export enum Enum {
+    FOO = 0,
+    BAR = 1,
+}
+
+export type MessageObject = {
+    name?: string;
+}
+
+export class Message {
+    name: string = "";
+    constructor(obj: MessageObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+    }
+    static readonly typeName: string = "mappb.Message";
+    getType(): typeof Message {
+        return Message;
+    }
+    static fromJson(json: string): Message {
+        return Message.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+    }
+    static fromObject(obj: MessageObject): Message {
+        return new Message(obj);
+    }
+    toObject(): MessageObject {
+        return {
+            name: this.name,
+        };
+    }
+}
+
+export type TestObject = {
+    a?: { [key: string]: number };
+    b?: { [key: string]: string };
+    c?: { [key: string]: Enum };
+    d?: { [key: string]: MessageObject };
+    e?: { [key: string]: number };
+    f?: { [key: string]: boolean };
+}
+
+export class Test {
+    a: Map<string, number> = new Map();
+    b: Map<number, string> = new Map();
+    c: Map<number, Enum> = new Map();
+    d: Map<string, Message> = new Map();
+    e: Map<boolean, number> = new Map();
+    f: Map<number, boolean> = new Map();
+    constructor(obj: TestObject = {}) {
+        if (obj.a !== undefined) {
+            this.a = new Map();
+            for (const k of Object.keys(obj.a)) {
+                this.a.set(k, obj.a[k]);
+            }
+        }
+        if (obj.b !== undefined) {
+            this.b = new Map();
+            for (const k of Object.keys(obj.b)) {
+                this.b.set(Number(k), obj.b[k]);
+            }
+        }
+        if (obj.c !== undefined) {
+            this.c = new Map();
+            for (const k of Object.keys(obj.c)) {
+                this.c.set(Number(k), obj.c[k]);
+            }
+        }
+        if (obj.d !== undefined) {
+            this.d = new Map();
+            for (const k of Object.keys(obj.d)) {
+                this.d.set(k, Message.fromObject(obj.d[k]));
+            }
+        }
+        if (obj.e !== undefined) {
+            this.e = new Map();
+            for (const k of Object.keys(obj.e)) {
+                this.e.set(k === "true", obj.e[k]);
+            }
+        }
+        if (obj.f !== undefined) {
+            this.f = new Map();
+            for (const k of Object.keys(obj.f)) {
+                this.f.set(Number(k), obj.f[k]);
+            }
+        }
+    }
+    static readonly typeName: string = "mappb.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.a !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.integerChecker(-2147483648, 2147483647))(o.a, jsonif.joinPath(path, "a"));
+        } else {
+            jsonif.missingKey(path, "a");
+        }
+        if (o.b !== undefined) {
+            jsonif.mapOf(jsonif.integerKey(-2147483648, 2147483647), jsonif.checkString)(o.b, jsonif.joinPath(path, "b"));
+        } else {
+            jsonif.missingKey(path, "b");
+        }
+        if (o.c !== undefined) {
+            jsonif.mapOf(jsonif.integerKey(-9223372036854775808, 9223372036854775807), jsonif.enumChecker(false))(o.c, jsonif.joinPath(path, "c"));
+        } else {
+            jsonif.missingKey(path, "c");
+        }
+        if (o.d !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, Message.checkJson)(o.d, jsonif.joinPath(path, "d"));
+        } else {
+            jsonif.missingKey(path, "d");
+        }
+        if (o.e !== undefined) {
+            jsonif.mapOf(jsonif.booleanKey, jsonif.checkNumber)(o.e, jsonif.joinPath(path, "e"));
+        } else {
+            jsonif.missingKey(path, "e");
+        }
+        if (o.f !== undefined) {
+            jsonif.mapOf(jsonif.integerKey(0, 18446744073709551615), jsonif.checkBoolean)(o.f, jsonif.joinPath(path, "f"));
+        } else {
+            jsonif.missingKey(path, "f");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const a: { [key: string]: number } = {};
+        this.a.forEach((v, k) => {
+            a[String(k)] = v;
+        });
+        const b: { [key: string]: string } = {};
+        this.b.forEach((v, k) => {
+            b[String(k)] = v;
+        });
+        const c: { [key: string]: Enum } = {};
+        this.c.forEach((v, k) => {
+            c[String(k)] = v;
+        });
+        const d: { [key: string]: MessageObject } = {};
+        this.d.forEach((v, k) => {
+            d[String(k)] = v.toObject();
+        });
+        const e: { [key: string]: number } = {};
+        this.e.forEach((v, k) => {
+            e[String(k)] = v;
+        });
+        const f: { [key: string]: boolean } = {};
+        this.f.forEach((v, k) => {
+            f[String(k)] = v;
+        });
+        return {
+            a: a,
+            b: b,
+            c: c,
+            d: d,
+            e: e,
+            f: f,
+        };
+    }
+}
+
+jsonif.registerType(Message);
+jsonif.registerType(Test);
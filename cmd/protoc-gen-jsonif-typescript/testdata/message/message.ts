@@ -0,0 +1,57 @@
+import * as jsonif from "./jsonif";
+
+export type PersonObject = {
+    name?: string;
+    flag?: boolean;
+}
+
+export class Person {
+    name: string = "";
+    flag: boolean = false;
+    constructor(obj: PersonObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+        if (obj.flag !== undefined) {
+            this.flag = obj.flag;
+        }
+    }
+    static readonly typeName: string = "message.Person";
+    getType(): typeof Person {
+        return Person;
+    }
+    static fromJson(json: string): Person {
+        return Person.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+        if (o.flag !== undefined) {
+            jsonif.checkBoolean(o.flag, jsonif.joinPath(path, "flag"));
+        } else {
+            jsonif.missingKey(path, "flag");
+        }
+    }
+    static fromObject(obj: PersonObject): Person {
+        return new Person(obj);
+    }
+    toObject(): PersonObject {
+        return {
+            name: this.name,
+            flag: this.flag,
+        };
+    }
+}
+
+jsonif.registerType(Person);
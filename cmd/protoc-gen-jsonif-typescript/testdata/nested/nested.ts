@@ -0,0 +1,173 @@
+import * as jsonif from "./jsonif";
+
+export type Test_NestedMessageObject = {
+    name?: string;
+}
+
+export class Test_NestedMessage {
+    name: string = "";
+    constructor(obj: Test_NestedMessageObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+    }
+    static readonly typeName: string = "nested.nested.Test.NestedMessage";
+    getType(): typeof Test_NestedMessage {
+        return Test_NestedMessage;
+    }
+    static fromJson(json: string): Test_NestedMessage {
+        return Test_NestedMessage.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+    }
+    static fromObject(obj: Test_NestedMessageObject): Test_NestedMessage {
+        return new Test_NestedMessage(obj);
+    }
+    toObject(): Test_NestedMessageObject {
+        return {
+            name: this.name,
+        };
+    }
+}
+
+export type TestObject = {
+    nested_message?: Test_NestedMessageObject;
+    nested_enum?: Test_NestedEnum;
+}
+
+export enum Test_NestedEnum {
+    FOO = 0,
+    BAR = 1,
+    HOGE = 2,
+}
+
+export class Test {
+    nested_message: Test_NestedMessage = new Test_NestedMessage();
+    nested_enum: Test_NestedEnum = 0;
+    constructor(obj: TestObject = {}) {
+        if (obj.nested_message !== undefined) {
+            this.nested_message = Test_NestedMessage.fromObject(obj.nested_message);
+        }
+        if (obj.nested_enum !== undefined) {
+            this.nested_enum = obj.nested_enum;
+        }
+    }
+    static readonly typeName: string = "nested.nested.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.nested_message !== undefined) {
+            Test_NestedMessage.checkJson(o.nested_message, jsonif.joinPath(path, "nested_message"));
+        } else {
+            jsonif.missingKey(path, "nested_message");
+        }
+        if (o.nested_enum !== undefined) {
+            jsonif.enumChecker(false)(o.nested_enum, jsonif.joinPath(path, "nested_enum"));
+        } else {
+            jsonif.missingKey(path, "nested_enum");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+            nested_message: this.nested_message.toObject(),
+            nested_enum: this.nested_enum,
+        };
+    }
+}
+
+export type Test2Object = {
+    test?: TestObject;
+    nested_message?: Test_NestedMessageObject;
+    nested_enum?: Test_NestedEnum;
+}
+
+export class Test2 {
+    test: Test = new Test();
+    nested_message: Test_NestedMessage = new Test_NestedMessage();
+    nested_enum: Test_NestedEnum = 0;
+    constructor(obj: Test2Object = {}) {
+        if (obj.test !== undefined) {
+            this.test = Test.fromObject(obj.test);
+        }
+        if (obj.nested_message !== undefined) {
+            this.nested_message = Test_NestedMessage.fromObject(obj.nested_message);
+        }
+        if (obj.nested_enum !== undefined) {
+            this.nested_enum = obj.nested_enum;
+        }
+    }
+    static readonly typeName: string = "nested.nested.Test2";
+    getType(): typeof Test2 {
+        return Test2;
+    }
+    static fromJson(json: string): Test2 {
+        return Test2.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.test !== undefined) {
+            Test.checkJson(o.test, jsonif.joinPath(path, "test"));
+        } else {
+            jsonif.missingKey(path, "test");
+        }
+        if (o.nested_message !== undefined) {
+            Test_NestedMessage.checkJson(o.nested_message, jsonif.joinPath(path, "nested_message"));
+        } else {
+            jsonif.missingKey(path, "nested_message");
+        }
+        if (o.nested_enum !== undefined) {
+            jsonif.enumChecker(false)(o.nested_enum, jsonif.joinPath(path, "nested_enum"));
+        } else {
+            jsonif.missingKey(path, "nested_enum");
+        }
+    }
+    static fromObject(obj: Test2Object): Test2 {
+        return new Test2(obj);
+    }
+    toObject(): Test2Object {
+        return {
+            test: this.test.toObject(),
+            nested_message: this.nested_message.toObject(),
+            nested_enum: this.nested_enum,
+        };
+    }
+}
+
+jsonif.registerType(Test_NestedMessage);
+jsonif.registerType(Test);
+jsonif.registerType(Test2);
@@ -0,0 +1,342 @@
+import * as jsonif from "./jsonif";
+
+export type InnerObject = {
+    value?: number;
+}
+
+export class Inner {
+    value: number = 0;
+    constructor(obj: InnerObject = {}) {
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+    }
+    static readonly typeName: string = "oneof_active_only.Inner";
+    getType(): typeof Inner {
+        return Inner;
+    }
+    static fromJson(json: string): Inner {
+        return Inner.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        } else {
+            jsonif.missingKey(path, "value");
+        }
+    }
+    static fromObject(obj: InnerObject): Inner {
+        return new Inner(obj);
+    }
+    toObject(): InnerObject {
+        return {
+            value: this.value,
+        };
+    }
+}
+
+export enum Test_KindCase {
+    NOT_SET = 0,
+    kName = 1,
+    kNumber = 2,
+    kInner = 3,
+}
+
+/**
+ * メッセージの設定よりも oneof の設定が優先される
+ */
+export enum Test_LegacyCase {
+    NOT_SET = 0,
+    kLegacyName = 4,
+    kLegacyNumber = 5,
+}
+
+export type TestObject = {
+    name?: string;
+    number?: number;
+    inner?: InnerObject;
+    legacy_name?: string;
+    legacy_number?: number;
+    opt?: number | null;
+    value?: number;
+    kind_case?: Test_KindCase;
+    legacy_case?: Test_LegacyCase;
+}
+
+export class Test {
+    name: string = "";
+    number: number = 0;
+    inner: Inner = new Inner();
+    legacy_name: string = "";
+    legacy_number: number = 0;
+    opt: number | null = null;
+    value: number = 0;
+    kind_case: Test_KindCase = Test_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test_KindCase.NOT_SET;
+        this.name = "";
+        this.number = 0;
+        this.inner = new Inner();
+    }
+    setName(value: string) {
+        this.kind_case = Test_KindCase.kName;
+        this.name = value;
+    }
+    clearName() {
+        if (this.kind_case === Test_KindCase.kName) {
+            this.clearKind();
+        }
+    }
+    setNumber(value: number) {
+        this.kind_case = Test_KindCase.kNumber;
+        this.number = value;
+    }
+    clearNumber() {
+        if (this.kind_case === Test_KindCase.kNumber) {
+            this.clearKind();
+        }
+    }
+    setInner(value: Inner) {
+        this.kind_case = Test_KindCase.kInner;
+        this.inner = value;
+    }
+    clearInner() {
+        if (this.kind_case === Test_KindCase.kInner) {
+            this.clearKind();
+        }
+    }
+    legacy_case: Test_LegacyCase = Test_LegacyCase.NOT_SET;
+    clearLegacy() {
+        this.legacy_case = Test_LegacyCase.NOT_SET;
+        this.legacy_name = "";
+        this.legacy_number = 0;
+    }
+    setLegacyName(value: string) {
+        this.legacy_case = Test_LegacyCase.kLegacyName;
+        this.legacy_name = value;
+    }
+    clearLegacyName() {
+        if (this.legacy_case === Test_LegacyCase.kLegacyName) {
+            this.clearLegacy();
+        }
+    }
+    setLegacyNumber(value: number) {
+        this.legacy_case = Test_LegacyCase.kLegacyNumber;
+        this.legacy_number = value;
+    }
+    clearLegacyNumber() {
+        if (this.legacy_case === Test_LegacyCase.kLegacyNumber) {
+            this.clearLegacy();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+            this.kind_case = Test_KindCase.kName;
+        }
+        if (obj.number !== undefined) {
+            this.number = obj.number;
+            this.kind_case = Test_KindCase.kNumber;
+        }
+        if (obj.inner !== undefined) {
+            this.inner = Inner.fromObject(obj.inner);
+            this.kind_case = Test_KindCase.kInner;
+        }
+        if (obj.legacy_name !== undefined) {
+            this.legacy_name = obj.legacy_name;
+        }
+        if (obj.legacy_number !== undefined) {
+            this.legacy_number = obj.legacy_number;
+        }
+        if (obj.opt !== undefined) {
+            if (obj.opt !== null) {
+                this.opt = obj.opt;
+            }
+        }
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+        if (obj.legacy_case !== undefined) {
+            this.legacy_case = obj.legacy_case;
+        }
+    }
+    static readonly typeName: string = "oneof_active_only.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        }
+        if (o.number !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.number, jsonif.joinPath(path, "number"));
+        }
+        if (o.inner !== undefined) {
+            Inner.checkJson(o.inner, jsonif.joinPath(path, "inner"));
+        }
+        if (o.legacy_name !== undefined) {
+            jsonif.checkString(o.legacy_name, jsonif.joinPath(path, "legacy_name"));
+        }
+        if (o.legacy_number !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.legacy_number, jsonif.joinPath(path, "legacy_number"));
+        }
+        if (o.opt !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.opt, jsonif.joinPath(path, "opt"));
+        }
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        } else {
+            jsonif.missingKey(path, "value");
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        }
+        if (o.legacy_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.legacy_case, jsonif.joinPath(path, "legacy_case"));
+        } else {
+            jsonif.missingKey(path, "legacy_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const obj: TestObject = {};
+        if (this.kind_case === Test_KindCase.kName) {
+            obj.name = this.name;
+        }
+        if (this.kind_case === Test_KindCase.kNumber) {
+            obj.number = this.number;
+        }
+        if (this.kind_case === Test_KindCase.kInner) {
+            obj.inner = this.inner.toObject();
+        }
+        obj.legacy_name = this.legacy_name;
+        obj.legacy_number = this.legacy_number;
+        if (this.opt !== null) {
+            obj.opt = this.opt;
+        }
+        obj.value = this.value;
+        obj.legacy_case = this.legacy_case;
+        return obj;
+    }
+}
+
+export enum Test2_KindCase {
+    NOT_SET = 0,
+    kName = 1,
+    kNumber = 2,
+}
+
+export type Test2Object = {
+    name?: string;
+    number?: number;
+    kind_case?: Test2_KindCase;
+}
+
+export class Test2 {
+    name: string = "";
+    number: number = 0;
+    kind_case: Test2_KindCase = Test2_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test2_KindCase.NOT_SET;
+        this.name = "";
+        this.number = 0;
+    }
+    setName(value: string) {
+        this.kind_case = Test2_KindCase.kName;
+        this.name = value;
+    }
+    clearName() {
+        if (this.kind_case === Test2_KindCase.kName) {
+            this.clearKind();
+        }
+    }
+    setNumber(value: number) {
+        this.kind_case = Test2_KindCase.kNumber;
+        this.number = value;
+    }
+    clearNumber() {
+        if (this.kind_case === Test2_KindCase.kNumber) {
+            this.clearKind();
+        }
+    }
+    constructor(obj: Test2Object = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+            this.kind_case = Test2_KindCase.kName;
+        }
+        if (obj.number !== undefined) {
+            this.number = obj.number;
+            this.kind_case = Test2_KindCase.kNumber;
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+    }
+    static readonly typeName: string = "oneof_active_only.Test2";
+    getType(): typeof Test2 {
+        return Test2;
+    }
+    static fromJson(json: string): Test2 {
+        return Test2.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        }
+        if (o.number !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.number, jsonif.joinPath(path, "number"));
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        }
+    }
+    static fromObject(obj: Test2Object): Test2 {
+        return new Test2(obj);
+    }
+    toObject(): Test2Object {
+        const obj: Test2Object = {};
+        if (this.kind_case === Test2_KindCase.kName) {
+            obj.name = this.name;
+        }
+        if (this.kind_case === Test2_KindCase.kNumber) {
+            obj.number = this.number;
+        }
+        return obj;
+    }
+}
+
+jsonif.registerType(Inner);
+jsonif.registerType(Test);
+jsonif.registerType(Test2);
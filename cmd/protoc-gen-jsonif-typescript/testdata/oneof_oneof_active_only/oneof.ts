@@ -0,0 +1,191 @@
+import * as jsonif from "./jsonif";
+
+export enum Enum {
+    FOO = 0,
+    BAR = 1,
+}
+
+export type MessageObject = {
+    name?: string;
+}
+
+export class Message {
+    name: string = "";
+    constructor(obj: MessageObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+    }
+    static readonly typeName: string = "oneof.Message";
+    getType(): typeof Message {
+        return Message;
+    }
+    static fromJson(json: string): Message {
+        return Message.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+    }
+    static fromObject(obj: MessageObject): Message {
+        return new Message(obj);
+    }
+    toObject(): MessageObject {
+        return {
+            name: this.name,
+        };
+    }
+}
+
+export enum Test_TestOneofCase {
+    NOT_SET = 0,
+    kA = 1,
+    kB = 2,
+    kC = 3,
+    kD = 4,
+}
+
+export type TestObject = {
+    a?: number;
+    b?: string;
+    c?: Enum;
+    d?: MessageObject;
+    test_oneof_case?: Test_TestOneofCase;
+}
+
+export class Test {
+    a: number = 0;
+    b: string = "";
+    c: Enum = 0;
+    d: Message = new Message();
+    test_oneof_case: Test_TestOneofCase = Test_TestOneofCase.NOT_SET;
+    clearTestOneof() {
+        this.test_oneof_case = Test_TestOneofCase.NOT_SET;
+        this.a = 0;
+        this.b = "";
+        this.c = 0;
+        this.d = new Message();
+    }
+    setA(value: number) {
+        this.test_oneof_case = Test_TestOneofCase.kA;
+        this.a = value;
+    }
+    clearA() {
+        if (this.test_oneof_case === Test_TestOneofCase.kA) {
+            this.clearTestOneof();
+        }
+    }
+    setB(value: string) {
+        this.test_oneof_case = Test_TestOneofCase.kB;
+        this.b = value;
+    }
+    clearB() {
+        if (this.test_oneof_case === Test_TestOneofCase.kB) {
+            this.clearTestOneof();
+        }
+    }
+    setC(value: Enum) {
+        this.test_oneof_case = Test_TestOneofCase.kC;
+        this.c = value;
+    }
+    clearC() {
+        if (this.test_oneof_case === Test_TestOneofCase.kC) {
+            this.clearTestOneof();
+        }
+    }
+    setD(value: Message) {
+        this.test_oneof_case = Test_TestOneofCase.kD;
+        this.d = value;
+    }
+    clearD() {
+        if (this.test_oneof_case === Test_TestOneofCase.kD) {
+            this.clearTestOneof();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.a !== undefined) {
+            this.a = obj.a;
+            this.test_oneof_case = Test_TestOneofCase.kA;
+        }
+        if (obj.b !== undefined) {
+            this.b = obj.b;
+            this.test_oneof_case = Test_TestOneofCase.kB;
+        }
+        if (obj.c !== undefined) {
+            this.c = obj.c;
+            this.test_oneof_case = Test_TestOneofCase.kC;
+        }
+        if (obj.d !== undefined) {
+            this.d = Message.fromObject(obj.d);
+            this.test_oneof_case = Test_TestOneofCase.kD;
+        }
+        if (obj.test_oneof_case !== undefined) {
+            this.test_oneof_case = obj.test_oneof_case;
+        }
+    }
+    static readonly typeName: string = "oneof.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.a !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.a, jsonif.joinPath(path, "a"));
+        }
+        if (o.b !== undefined) {
+            jsonif.checkString(o.b, jsonif.joinPath(path, "b"));
+        }
+        if (o.c !== undefined) {
+            jsonif.enumChecker(false)(o.c, jsonif.joinPath(path, "c"));
+        }
+        if (o.d !== undefined) {
+            Message.checkJson(o.d, jsonif.joinPath(path, "d"));
+        }
+        if (o.test_oneof_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.test_oneof_case, jsonif.joinPath(path, "test_oneof_case"));
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const obj: TestObject = {};
+        if (this.test_oneof_case === Test_TestOneofCase.kA) {
+            obj.a = this.a;
+        }
+        if (this.test_oneof_case === Test_TestOneofCase.kB) {
+            obj.b = this.b;
+        }
+        if (this.test_oneof_case === Test_TestOneofCase.kC) {
+            obj.c = this.c;
+        }
+        if (this.test_oneof_case === Test_TestOneofCase.kD) {
+            obj.d = this.d.toObject();
+        }
+        return obj;
+    }
+}
+
+jsonif.registerType(Message);
+jsonif.registerType(Test);
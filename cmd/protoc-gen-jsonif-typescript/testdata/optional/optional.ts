@@ -0,0 +1,136 @@
+import * as jsonif from "./jsonif";
+
+export enum Enum {
+    FOO = 0,
+    BAR = 1,
+}
+
+export type MessageObject = {
+    name?: string;
+}
+
+export class Message {
+    name: string = "";
+    constructor(obj: MessageObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+    }
+    static readonly typeName: string = "optional.Message";
+    getType(): typeof Message {
+        return Message;
+    }
+    static fromJson(json: string): Message {
+        return Message.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+    }
+    static fromObject(obj: MessageObject): Message {
+        return new Message(obj);
+    }
+    toObject(): MessageObject {
+        return {
+            name: this.name,
+        };
+    }
+}
+
+export type TestObject = {
+    a?: number | null;
+    b?: string | null;
+    c?: Enum | null;
+    d?: MessageObject | null;
+}
+
+export class Test {
+    a: number | null = null;
+    b: string | null = null;
+    c: Enum | null = null;
+    d: Message | null = null;
+    constructor(obj: TestObject = {}) {
+        if (obj.a !== undefined) {
+            if (obj.a !== null) {
+                this.a = obj.a;
+            }
+        }
+        if (obj.b !== undefined) {
+            if (obj.b !== null) {
+                this.b = obj.b;
+            }
+        }
+        if (obj.c !== undefined) {
+            if (obj.c !== null) {
+                this.c = obj.c;
+            }
+        }
+        if (obj.d !== undefined) {
+            if (obj.d !== null) {
+                this.d = Message.fromObject(obj.d);
+            }
+        }
+    }
+    static readonly typeName: string = "optional.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.a !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-9223372036854775808, 9223372036854775807))(o.a, jsonif.joinPath(path, "a"));
+        }
+        if (o.b !== undefined) {
+            jsonif.nullable(jsonif.checkString)(o.b, jsonif.joinPath(path, "b"));
+        }
+        if (o.c !== undefined) {
+            jsonif.nullable(jsonif.enumChecker(false))(o.c, jsonif.joinPath(path, "c"));
+        }
+        if (o.d !== undefined) {
+            jsonif.nullable(Message.checkJson)(o.d, jsonif.joinPath(path, "d"));
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const obj: TestObject = {};
+        if (this.a !== null) {
+            obj.a = this.a;
+        }
+        if (this.b !== null) {
+            obj.b = this.b;
+        }
+        if (this.c !== null) {
+            obj.c = this.c;
+        }
+        if (this.d !== null) {
+            obj.d = this.d === null ? null : this.d.toObject();
+        }
+        return obj;
+    }
+}
+
+jsonif.registerType(Message);
+jsonif.registerType(Test);
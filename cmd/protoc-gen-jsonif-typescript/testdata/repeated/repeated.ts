@@ -0,0 +1,128 @@
+import * as jsonif from "./jsonif";
+
+export enum Enum {
+    FOO = 0,
+    BAR = 1,
+}
+
+export type MessageObject = {
+    name?: string;
+}
+
+export class Message {
+    name: string = "";
+    constructor(obj: MessageObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+    }
+    static readonly typeName: string = "repeated.Message";
+    getType(): typeof Message {
+        return Message;
+    }
+    static fromJson(json: string): Message {
+        return Message.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+    }
+    static fromObject(obj: MessageObject): Message {
+        return new Message(obj);
+    }
+    toObject(): MessageObject {
+        return {
+            name: this.name,
+        };
+    }
+}
+
+export type TestObject = {
+    a?: number[];
+    b?: string[];
+    c?: Enum[];
+    d?: MessageObject[];
+}
+
+export class Test {
+    a: number[] = [];
+    b: string[] = [];
+    c: Enum[] = [];
+    d: Message[] = [];
+    constructor(obj: TestObject = {}) {
+        if (obj.a !== undefined) {
+            this.a = obj.a;
+        }
+        if (obj.b !== undefined) {
+            this.b = obj.b;
+        }
+        if (obj.c !== undefined) {
+            this.c = obj.c;
+        }
+        if (obj.d !== undefined) {
+            this.d = obj.d.map((x) => Message.fromObject(x));
+        }
+    }
+    static readonly typeName: string = "repeated.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.a !== undefined) {
+            jsonif.arrayOf(jsonif.integerChecker(-2147483648, 2147483647))(o.a, jsonif.joinPath(path, "a"));
+        } else {
+            jsonif.missingKey(path, "a");
+        }
+        if (o.b !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.b, jsonif.joinPath(path, "b"));
+        } else {
+            jsonif.missingKey(path, "b");
+        }
+        if (o.c !== undefined) {
+            jsonif.arrayOf(jsonif.enumChecker(false))(o.c, jsonif.joinPath(path, "c"));
+        } else {
+            jsonif.missingKey(path, "c");
+        }
+        if (o.d !== undefined) {
+            jsonif.arrayOf(Message.checkJson)(o.d, jsonif.joinPath(path, "d"));
+        } else {
+            jsonif.missingKey(path, "d");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        return {
+            a: this.a,
+            b: this.b,
+            c: this.c,
+            d: this.d.map((x) => x.toObject()),
+        };
+    }
+}
+
+jsonif.registerType(Message);
+jsonif.registerType(Test);
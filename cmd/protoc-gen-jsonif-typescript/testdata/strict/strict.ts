@@ -0,0 +1,389 @@
+import * as jsonif from "./jsonif";
+
+export enum Color {
+    RED = 0,
+    GREEN = 1,
+}
+
+export type PersonObject = {
+    name?: string;
+    age?: number;
+    tags?: string[];
+    color?: Color;
+}
+
+export class Person {
+    name: string = "";
+    age: number = 0;
+    tags: string[] = [];
+    color: Color = 0;
+    static readonly knownKeys: ReadonlySet<string> = new Set<string>(["name", "age", "tags", "color"]);
+    constructor(obj: PersonObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+        if (obj.age !== undefined) {
+            this.age = obj.age;
+        }
+        if (obj.tags !== undefined) {
+            this.tags = obj.tags;
+        }
+        if (obj.color !== undefined) {
+            this.color = obj.color;
+        }
+    }
+    static readonly typeName: string = "strict.Person";
+    getType(): typeof Person {
+        return Person;
+    }
+    static fromJson(json: string): Person {
+        return Person.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        jsonif.checkUnknownKeys(o, Person.knownKeys, path);
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+        if (o.age !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.age, jsonif.joinPath(path, "age"));
+        } else {
+            jsonif.missingKey(path, "age");
+        }
+        if (o.tags !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.tags, jsonif.joinPath(path, "tags"));
+        } else {
+            jsonif.missingKey(path, "tags");
+        }
+        if (o.color !== undefined) {
+            jsonif.enumChecker(false)(o.color, jsonif.joinPath(path, "color"));
+        } else {
+            jsonif.missingKey(path, "color");
+        }
+    }
+    static fromObject(obj: PersonObject): Person {
+        return jsonif.readStrict(obj, Person.checkJson, () => new Person(obj));
+    }
+    toObject(): PersonObject {
+        return {
+            name: this.name,
+            age: this.age,
+            tags: this.tags,
+            color: this.color,
+        };
+    }
+}
+
+/**
+ * strict ではないので知らないキーは無視するが、型は調べる
+ */
+export type AddressObject = {
+    city?: string;
+}
+
+/**
+ * strict ではないので知らないキーは無視するが、型は調べる
+ */
+export class Address {
+    city: string = "";
+    constructor(obj: AddressObject = {}) {
+        if (obj.city !== undefined) {
+            this.city = obj.city;
+        }
+    }
+    static readonly typeName: string = "strict.Address";
+    getType(): typeof Address {
+        return Address;
+    }
+    static fromJson(json: string): Address {
+        return Address.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.city !== undefined) {
+            jsonif.checkString(o.city, jsonif.joinPath(path, "city"));
+        } else {
+            jsonif.missingKey(path, "city");
+        }
+    }
+    static fromObject(obj: AddressObject): Address {
+        return new Address(obj);
+    }
+    toObject(): AddressObject {
+        return {
+            city: this.city,
+        };
+    }
+}
+
+export enum Config_KindCase {
+    NOT_SET = 0,
+    kNumber = 5,
+    kText = 6,
+}
+
+/**
+ * 知らないキーや型の違う値をエラーにする
+ */
+export type ConfigObject = {
+    people?: PersonObject[];
+    by_name?: { [key: string]: PersonObject };
+    labels?: { [key: string]: string };
+    address?: AddressObject;
+    number?: number;
+    text?: string;
+    opt?: number | null;
+    big?: number;
+    limit?: number | null;
+    kind_case?: Config_KindCase;
+}
+
+/**
+ * 知らないキーや型の違う値をエラーにする
+ */
+export class Config {
+    people: Person[] = [];
+    by_name: Map<string, Person> = new Map();
+    labels: Map<number, string> = new Map();
+    address: Address = new Address();
+    number: number = 0;
+    text: string = "";
+    opt: number | null = null;
+    big: number = 0;
+    limit: number | null = null;
+    kind_case: Config_KindCase = Config_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Config_KindCase.NOT_SET;
+        this.number = 0;
+        this.text = "";
+    }
+    setNumber(value: number) {
+        this.kind_case = Config_KindCase.kNumber;
+        this.number = value;
+    }
+    clearNumber() {
+        if (this.kind_case === Config_KindCase.kNumber) {
+            this.clearKind();
+        }
+    }
+    setText(value: string) {
+        this.kind_case = Config_KindCase.kText;
+        this.text = value;
+    }
+    clearText() {
+        if (this.kind_case === Config_KindCase.kText) {
+            this.clearKind();
+        }
+    }
+    static readonly knownKeys: ReadonlySet<string> = new Set<string>(["people", "by_name", "labels", "address", "number", "text", "opt", "big", "limit", "kind_case"]);
+    constructor(obj: ConfigObject = {}) {
+        if (obj.people !== undefined) {
+            this.people = obj.people.map((x) => Person.fromObject(x));
+        }
+        if (obj.by_name !== undefined) {
+            this.by_name = new Map();
+            for (const k of Object.keys(obj.by_name)) {
+                this.by_name.set(k, Person.fromObject(obj.by_name[k]));
+            }
+        }
+        if (obj.labels !== undefined) {
+            this.labels = new Map();
+            for (const k of Object.keys(obj.labels)) {
+                this.labels.set(Number(k), obj.labels[k]);
+            }
+        }
+        if (obj.address !== undefined) {
+            this.address = Address.fromObject(obj.address);
+        }
+        if (obj.number !== undefined) {
+            this.number = obj.number;
+        }
+        if (obj.text !== undefined) {
+            this.text = obj.text;
+        }
+        if (obj.opt !== undefined) {
+            if (obj.opt !== null) {
+                this.opt = obj.opt;
+            }
+        }
+        if (obj.big !== undefined) {
+            this.big = obj.big;
+        }
+        if (obj.limit !== undefined) {
+            this.limit = obj.limit;
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+    }
+    static readonly typeName: string = "strict.Config";
+    getType(): typeof Config {
+        return Config;
+    }
+    static fromJson(json: string): Config {
+        return Config.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        jsonif.checkUnknownKeys(o, Config.knownKeys, path);
+        if (o.people !== undefined) {
+            jsonif.arrayOf(Person.checkJson)(o.people, jsonif.joinPath(path, "people"));
+        } else {
+            jsonif.missingKey(path, "people");
+        }
+        if (o.by_name !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, Person.checkJson)(o.by_name, jsonif.joinPath(path, "by_name"));
+        } else {
+            jsonif.missingKey(path, "by_name");
+        }
+        if (o.labels !== undefined) {
+            jsonif.mapOf(jsonif.integerKey(-2147483648, 2147483647), jsonif.checkString)(o.labels, jsonif.joinPath(path, "labels"));
+        } else {
+            jsonif.missingKey(path, "labels");
+        }
+        if (o.address !== undefined) {
+            Address.checkJson(o.address, jsonif.joinPath(path, "address"));
+        } else {
+            jsonif.missingKey(path, "address");
+        }
+        if (o.number !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.number, jsonif.joinPath(path, "number"));
+        }
+        if (o.text !== undefined) {
+            jsonif.checkString(o.text, jsonif.joinPath(path, "text"));
+        }
+        if (o.opt !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(0, 4294967295))(o.opt, jsonif.joinPath(path, "opt"));
+        }
+        if (o.big !== undefined) {
+            jsonif.integerChecker(-9223372036854775808, 9223372036854775807)(o.big, jsonif.joinPath(path, "big"));
+        } else {
+            jsonif.missingKey(path, "big");
+        }
+        if (o.limit !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.limit, jsonif.joinPath(path, "limit"));
+        } else {
+            jsonif.missingKey(path, "limit");
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        } else {
+            jsonif.missingKey(path, "kind_case");
+        }
+    }
+    static fromObject(obj: ConfigObject): Config {
+        return jsonif.readStrict(obj, Config.checkJson, () => new Config(obj));
+    }
+    toObject(): ConfigObject {
+        const by_name: { [key: string]: PersonObject } = {};
+        this.by_name.forEach((v, k) => {
+            by_name[String(k)] = v.toObject();
+        });
+        const labels: { [key: string]: string } = {};
+        this.labels.forEach((v, k) => {
+            labels[String(k)] = v;
+        });
+        const obj: ConfigObject = {};
+        obj.people = this.people.map((x) => x.toObject());
+        obj.by_name = by_name;
+        obj.labels = labels;
+        obj.address = this.address.toObject();
+        obj.number = this.number;
+        obj.text = this.text;
+        if (this.opt !== null) {
+            obj.opt = this.opt;
+        }
+        obj.big = this.big;
+        obj.limit = this.limit;
+        obj.kind_case = this.kind_case;
+        return obj;
+    }
+}
+
+/**
+ * strict ではないメッセージの中でも、strict なメッセージは調べる
+ */
+export type LooseObject = {
+    id?: number;
+    person?: PersonObject;
+}
+
+/**
+ * strict ではないメッセージの中でも、strict なメッセージは調べる
+ */
+export class Loose {
+    id: number = 0;
+    person: Person = new Person();
+    constructor(obj: LooseObject = {}) {
+        if (obj.id !== undefined) {
+            this.id = obj.id;
+        }
+        if (obj.person !== undefined) {
+            this.person = Person.fromObject(obj.person);
+        }
+    }
+    static readonly typeName: string = "strict.Loose";
+    getType(): typeof Loose {
+        return Loose;
+    }
+    static fromJson(json: string): Loose {
+        return Loose.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.id !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.id, jsonif.joinPath(path, "id"));
+        } else {
+            jsonif.missingKey(path, "id");
+        }
+        if (o.person !== undefined) {
+            Person.checkJson(o.person, jsonif.joinPath(path, "person"));
+        } else {
+            jsonif.missingKey(path, "person");
+        }
+    }
+    static fromObject(obj: LooseObject): Loose {
+        return jsonif.readStrict(obj, Loose.checkJson, () => new Loose(obj));
+    }
+    toObject(): LooseObject {
+        return {
+            id: this.id,
+            person: this.person.toObject(),
+        };
+    }
+}
+
+jsonif.registerType(Person);
+jsonif.registerType(Address);
+jsonif.registerType(Config);
+jsonif.registerType(Loose);
@@ -0,0 +1,365 @@
+import * as jsonif from "./jsonif";
+
+export type InnerObject = {
+    value?: number;
+}
+
+export class Inner {
+    value: number = 0;
+    /**
+     * JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+     */
+    extra: Record<string, unknown> = {};
+    static readonly knownKeys: ReadonlySet<string> = new Set<string>(["value"]);
+    constructor(obj: InnerObject = {}) {
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+        for (const k of Object.keys(obj)) {
+            if (!Inner.knownKeys.has(k)) {
+                this.extra[k] = (obj as Record<string, unknown>)[k];
+            }
+        }
+    }
+    static readonly typeName: string = "unknown_fields.Inner";
+    getType(): typeof Inner {
+        return Inner;
+    }
+    static fromJson(json: string): Inner {
+        return Inner.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        } else {
+            jsonif.missingKey(path, "value");
+        }
+    }
+    static fromObject(obj: InnerObject): Inner {
+        return new Inner(obj);
+    }
+    toObject(): InnerObject {
+        const obj: InnerObject = {
+            value: this.value,
+        };
+        for (const k of Object.keys(this.extra)) {
+            if (!Inner.knownKeys.has(k)) {
+                (obj as Record<string, unknown>)[k] = this.extra[k];
+            }
+        }
+        return obj;
+    }
+}
+
+export enum Test_KindCase {
+    NOT_SET = 0,
+    kNumber = 6,
+    kText = 7,
+}
+
+/**
+ * 知らないキーを保持して、そのまま出力する
+ */
+export type TestObject = {
+    id?: number;
+    name?: string;
+    inner?: InnerObject;
+    inners?: InnerObject[];
+    inner_map?: { [key: string]: InnerObject };
+    number?: number;
+    text?: string;
+    opt?: number | null;
+    kind_case?: Test_KindCase;
+}
+
+/**
+ * 知らないキーを保持して、そのまま出力する
+ */
+export class Test {
+    id: number = 0;
+    name: string = "";
+    inner: Inner = new Inner();
+    inners: Inner[] = [];
+    inner_map: Map<string, Inner> = new Map();
+    number: number = 0;
+    text: string = "";
+    opt: number | null = null;
+    kind_case: Test_KindCase = Test_KindCase.NOT_SET;
+    clearKind() {
+        this.kind_case = Test_KindCase.NOT_SET;
+        this.number = 0;
+        this.text = "";
+    }
+    setNumber(value: number) {
+        this.kind_case = Test_KindCase.kNumber;
+        this.number = value;
+    }
+    clearNumber() {
+        if (this.kind_case === Test_KindCase.kNumber) {
+            this.clearKind();
+        }
+    }
+    setText(value: string) {
+        this.kind_case = Test_KindCase.kText;
+        this.text = value;
+    }
+    clearText() {
+        if (this.kind_case === Test_KindCase.kText) {
+            this.clearKind();
+        }
+    }
+    /**
+     * JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+     */
+    extra: Record<string, unknown> = {};
+    static readonly knownKeys: ReadonlySet<string> = new Set<string>(["id", "name", "inner", "inners", "inner_map", "number", "text", "opt", "kind_case"]);
+    constructor(obj: TestObject = {}) {
+        if (obj.id !== undefined) {
+            this.id = obj.id;
+        }
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+        if (obj.inner !== undefined) {
+            this.inner = Inner.fromObject(obj.inner);
+        }
+        if (obj.inners !== undefined) {
+            this.inners = obj.inners.map((x) => Inner.fromObject(x));
+        }
+        if (obj.inner_map !== undefined) {
+            this.inner_map = new Map();
+            for (const k of Object.keys(obj.inner_map)) {
+                this.inner_map.set(k, Inner.fromObject(obj.inner_map[k]));
+            }
+        }
+        if (obj.number !== undefined) {
+            this.number = obj.number;
+        }
+        if (obj.text !== undefined) {
+            this.text = obj.text;
+        }
+        if (obj.opt !== undefined) {
+            if (obj.opt !== null) {
+                this.opt = obj.opt;
+            }
+        }
+        if (obj.kind_case !== undefined) {
+            this.kind_case = obj.kind_case;
+        }
+        for (const k of Object.keys(obj)) {
+            if (!Test.knownKeys.has(k)) {
+                this.extra[k] = (obj as Record<string, unknown>)[k];
+            }
+        }
+    }
+    static readonly typeName: string = "unknown_fields.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.id !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.id, jsonif.joinPath(path, "id"));
+        } else {
+            jsonif.missingKey(path, "id");
+        }
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+        if (o.inner !== undefined) {
+            Inner.checkJson(o.inner, jsonif.joinPath(path, "inner"));
+        } else {
+            jsonif.missingKey(path, "inner");
+        }
+        if (o.inners !== undefined) {
+            jsonif.arrayOf(Inner.checkJson)(o.inners, jsonif.joinPath(path, "inners"));
+        } else {
+            jsonif.missingKey(path, "inners");
+        }
+        if (o.inner_map !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, Inner.checkJson)(o.inner_map, jsonif.joinPath(path, "inner_map"));
+        } else {
+            jsonif.missingKey(path, "inner_map");
+        }
+        if (o.number !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.number, jsonif.joinPath(path, "number"));
+        }
+        if (o.text !== undefined) {
+            jsonif.checkString(o.text, jsonif.joinPath(path, "text"));
+        }
+        if (o.opt !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.opt, jsonif.joinPath(path, "opt"));
+        }
+        if (o.kind_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.kind_case, jsonif.joinPath(path, "kind_case"));
+        } else {
+            jsonif.missingKey(path, "kind_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const inner_map: { [key: string]: InnerObject } = {};
+        this.inner_map.forEach((v, k) => {
+            inner_map[String(k)] = v.toObject();
+        });
+        const obj: TestObject = {};
+        obj.id = this.id;
+        obj.name = this.name;
+        obj.inner = this.inner.toObject();
+        obj.inners = this.inners.map((x) => x.toObject());
+        obj.inner_map = inner_map;
+        obj.number = this.number;
+        obj.text = this.text;
+        if (this.opt !== null) {
+            obj.opt = this.opt;
+        }
+        obj.kind_case = this.kind_case;
+        for (const k of Object.keys(this.extra)) {
+            if (!Test.knownKeys.has(k)) {
+                (obj as Record<string, unknown>)[k] = this.extra[k];
+            }
+        }
+        return obj;
+    }
+}
+
+/**
+ * 知らないキーがあったら捨てる
+ */
+export type PlainObject = {
+    id?: number;
+    test?: TestObject;
+}
+
+/**
+ * 知らないキーがあったら捨てる
+ */
+export class Plain {
+    id: number = 0;
+    test: Test = new Test();
+    constructor(obj: PlainObject = {}) {
+        if (obj.id !== undefined) {
+            this.id = obj.id;
+        }
+        if (obj.test !== undefined) {
+            this.test = Test.fromObject(obj.test);
+        }
+    }
+    static readonly typeName: string = "unknown_fields.Plain";
+    getType(): typeof Plain {
+        return Plain;
+    }
+    static fromJson(json: string): Plain {
+        return Plain.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.id !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.id, jsonif.joinPath(path, "id"));
+        } else {
+            jsonif.missingKey(path, "id");
+        }
+        if (o.test !== undefined) {
+            Test.checkJson(o.test, jsonif.joinPath(path, "test"));
+        } else {
+            jsonif.missingKey(path, "test");
+        }
+    }
+    static fromObject(obj: PlainObject): Plain {
+        return new Plain(obj);
+    }
+    toObject(): PlainObject {
+        return {
+            id: this.id,
+            test: this.test.toObject(),
+        };
+    }
+}
+
+/**
+ * フィールドが無くても知らないキーは保持する
+ */
+export type EmptyObject = {
+}
+
+/**
+ * フィールドが無くても知らないキーは保持する
+ */
+export class Empty {
+    /**
+     * JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+     */
+    extra: Record<string, unknown> = {};
+    static readonly knownKeys: ReadonlySet<string> = new Set<string>([]);
+    constructor(obj: EmptyObject = {}) {
+        for (const k of Object.keys(obj)) {
+            if (!Empty.knownKeys.has(k)) {
+                this.extra[k] = (obj as Record<string, unknown>)[k];
+            }
+        }
+    }
+    static readonly typeName: string = "unknown_fields.Empty";
+    getType(): typeof Empty {
+        return Empty;
+    }
+    static fromJson(json: string): Empty {
+        return Empty.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+    }
+    static fromObject(obj: EmptyObject): Empty {
+        return new Empty(obj);
+    }
+    toObject(): EmptyObject {
+        const obj: EmptyObject = {
+        };
+        for (const k of Object.keys(this.extra)) {
+            if (!Empty.knownKeys.has(k)) {
+                (obj as Record<string, unknown>)[k] = this.extra[k];
+            }
+        }
+        return obj;
+    }
+}
+
+jsonif.registerType(Inner);
+jsonif.registerType(Test);
+jsonif.registerType(Plain);
+jsonif.registerType(Empty);
@@ -0,0 +1,538 @@
+import * as jsonif from "./jsonif";
+
+export enum Role {
+    ROLE_UNSPECIFIED = 0,
+    ROLE_ADMIN = 1,
+    ROLE_MEMBER = 2,
+}
+
+export type PersonObject = {
+    name?: string;
+    age?: number;
+    email?: string;
+    role?: Role;
+    /**
+     * 各要素の長さと、要素数を検証する
+     */
+    tags?: string[];
+    score?: number | null;
+    ratio?: number;
+}
+
+export class Person {
+    name: string = "";
+    age: number = 0;
+    email: string = "";
+    role: Role = 0;
+    /**
+     * 各要素の長さと、要素数を検証する
+     */
+    tags: string[] = [];
+    score: number | null = null;
+    ratio: number = 0;
+    constructor(obj: PersonObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+        if (obj.age !== undefined) {
+            this.age = obj.age;
+        }
+        if (obj.email !== undefined) {
+            this.email = obj.email;
+        }
+        if (obj.role !== undefined) {
+            this.role = obj.role;
+        }
+        if (obj.tags !== undefined) {
+            this.tags = obj.tags;
+        }
+        if (obj.score !== undefined) {
+            if (obj.score !== null) {
+                this.score = obj.score;
+            }
+        }
+        if (obj.ratio !== undefined) {
+            this.ratio = obj.ratio;
+        }
+    }
+    static readonly typeName: string = "validation.Person";
+    getType(): typeof Person {
+        return Person;
+    }
+    static fromJson(json: string): Person {
+        return Person.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+        if (o.age !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.age, jsonif.joinPath(path, "age"));
+        } else {
+            jsonif.missingKey(path, "age");
+        }
+        if (o.email !== undefined) {
+            jsonif.checkString(o.email, jsonif.joinPath(path, "email"));
+        } else {
+            jsonif.missingKey(path, "email");
+        }
+        if (o.role !== undefined) {
+            jsonif.enumChecker(false)(o.role, jsonif.joinPath(path, "role"));
+        } else {
+            jsonif.missingKey(path, "role");
+        }
+        if (o.tags !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.tags, jsonif.joinPath(path, "tags"));
+        } else {
+            jsonif.missingKey(path, "tags");
+        }
+        if (o.score !== undefined) {
+            jsonif.nullable(jsonif.checkNumber)(o.score, jsonif.joinPath(path, "score"));
+        }
+        if (o.ratio !== undefined) {
+            jsonif.checkNumber(o.ratio, jsonif.joinPath(path, "ratio"));
+        } else {
+            jsonif.missingKey(path, "ratio");
+        }
+    }
+    static fromObject(obj: PersonObject): Person {
+        return new Person(obj);
+    }
+    toObject(): PersonObject {
+        const obj: PersonObject = {};
+        obj.name = this.name;
+        obj.age = this.age;
+        obj.email = this.email;
+        obj.role = this.role;
+        obj.tags = this.tags;
+        if (this.score !== null) {
+            obj.score = this.score;
+        }
+        obj.ratio = this.ratio;
+        return obj;
+    }
+    validate(path: string = "", violations: jsonif.Violation[] = []): jsonif.Violation[] {
+        if (jsonif.stringLength(this.name) < 1) {
+            violations.push({ path: jsonif.joinPath(path, "name"), message: "length must be at least 1" });
+        }
+        if (jsonif.stringLength(this.name) > 8) {
+            violations.push({ path: jsonif.joinPath(path, "name"), message: "length must be at most 8" });
+        }
+        if (this.age < 0) {
+            violations.push({ path: jsonif.joinPath(path, "age"), message: "must be greater than or equal to 0" });
+        }
+        if (this.age > 150) {
+            violations.push({ path: jsonif.joinPath(path, "age"), message: "must be less than or equal to 150" });
+        }
+        if (!new RegExp("^[^@]+@[^@]+$").test(this.email)) {
+            violations.push({ path: jsonif.joinPath(path, "email"), message: "must match pattern \"^[^@]+@[^@]+$\"" });
+        }
+        if (!(this.role === Role.ROLE_UNSPECIFIED || this.role === Role.ROLE_ADMIN || this.role === Role.ROLE_MEMBER)) {
+            violations.push({ path: jsonif.joinPath(path, "role"), message: "must be a defined enum value" });
+        }
+        if (this.tags.length > 3) {
+            violations.push({ path: jsonif.joinPath(path, "tags"), message: "must have at most 3 items" });
+        }
+        for (let i = 0; i < this.tags.length; i++) {
+            if (jsonif.stringLength(this.tags[i]) < 1) {
+                violations.push({ path: jsonif.joinPath(path, "tags") + "[" + i + "]", message: "length must be at least 1" });
+            }
+        }
+        if (this.score === null) {
+            violations.push({ path: jsonif.joinPath(path, "score"), message: "is required" });
+        }
+        if (this.score !== null) {
+            if (this.score < 0.5) {
+                violations.push({ path: jsonif.joinPath(path, "score"), message: "must be greater than or equal to 0.5" });
+            }
+        }
+        if (this.ratio > 1) {
+            violations.push({ path: jsonif.joinPath(path, "ratio"), message: "must be less than or equal to 1" });
+        }
+        return violations;
+    }
+}
+
+export enum Team_ContactCase {
+    NOT_SET = 0,
+    kPhone = 6,
+    kContactPerson = 7,
+}
+
+/**
+ * 検証ルールのあるメッセージをフィールドに持つメッセージも検証する
+ */
+export type TeamObject = {
+    leader?: PersonObject;
+    members?: PersonObject[];
+    by_name?: { [key: string]: PersonObject };
+    limits?: { [key: string]: number };
+    size?: number | null;
+    phone?: string;
+    contact_person?: PersonObject;
+    contact_case?: Team_ContactCase;
+}
+
+/**
+ * 検証ルールのあるメッセージをフィールドに持つメッセージも検証する
+ */
+export class Team {
+    leader: Person = new Person();
+    members: Person[] = [];
+    by_name: Map<string, Person> = new Map();
+    limits: Map<number, number> = new Map();
+    size: number | null = null;
+    phone: string = "";
+    contact_person: Person = new Person();
+    contact_case: Team_ContactCase = Team_ContactCase.NOT_SET;
+    clearContact() {
+        this.contact_case = Team_ContactCase.NOT_SET;
+        this.phone = "";
+        this.contact_person = new Person();
+    }
+    setPhone(value: string) {
+        this.contact_case = Team_ContactCase.kPhone;
+        this.phone = value;
+    }
+    clearPhone() {
+        if (this.contact_case === Team_ContactCase.kPhone) {
+            this.clearContact();
+        }
+    }
+    setContactPerson(value: Person) {
+        this.contact_case = Team_ContactCase.kContactPerson;
+        this.contact_person = value;
+    }
+    clearContactPerson() {
+        if (this.contact_case === Team_ContactCase.kContactPerson) {
+            this.clearContact();
+        }
+    }
+    constructor(obj: TeamObject = {}) {
+        if (obj.leader !== undefined) {
+            this.leader = Person.fromObject(obj.leader);
+        }
+        if (obj.members !== undefined) {
+            this.members = obj.members.map((x) => Person.fromObject(x));
+        }
+        if (obj.by_name !== undefined) {
+            this.by_name = new Map();
+            for (const k of Object.keys(obj.by_name)) {
+                this.by_name.set(k, Person.fromObject(obj.by_name[k]));
+            }
+        }
+        if (obj.limits !== undefined) {
+            this.limits = new Map();
+            for (const k of Object.keys(obj.limits)) {
+                this.limits.set(Number(k), obj.limits[k]);
+            }
+        }
+        if (obj.size !== undefined) {
+            this.size = obj.size;
+        }
+        if (obj.phone !== undefined) {
+            this.phone = obj.phone;
+        }
+        if (obj.contact_person !== undefined) {
+            this.contact_person = Person.fromObject(obj.contact_person);
+        }
+        if (obj.contact_case !== undefined) {
+            this.contact_case = obj.contact_case;
+        }
+    }
+    static readonly typeName: string = "validation.Team";
+    getType(): typeof Team {
+        return Team;
+    }
+    static fromJson(json: string): Team {
+        return Team.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.leader !== undefined) {
+            Person.checkJson(o.leader, jsonif.joinPath(path, "leader"));
+        } else {
+            jsonif.missingKey(path, "leader");
+        }
+        if (o.members !== undefined) {
+            jsonif.arrayOf(Person.checkJson)(o.members, jsonif.joinPath(path, "members"));
+        } else {
+            jsonif.missingKey(path, "members");
+        }
+        if (o.by_name !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, Person.checkJson)(o.by_name, jsonif.joinPath(path, "by_name"));
+        } else {
+            jsonif.missingKey(path, "by_name");
+        }
+        if (o.limits !== undefined) {
+            jsonif.mapOf(jsonif.integerKey(-2147483648, 2147483647), jsonif.integerChecker(-9223372036854775808, 9223372036854775807))(o.limits, jsonif.joinPath(path, "limits"));
+        } else {
+            jsonif.missingKey(path, "limits");
+        }
+        if (o.size !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.size, jsonif.joinPath(path, "size"));
+        } else {
+            jsonif.missingKey(path, "size");
+        }
+        if (o.phone !== undefined) {
+            jsonif.checkString(o.phone, jsonif.joinPath(path, "phone"));
+        }
+        if (o.contact_person !== undefined) {
+            Person.checkJson(o.contact_person, jsonif.joinPath(path, "contact_person"));
+        }
+        if (o.contact_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.contact_case, jsonif.joinPath(path, "contact_case"));
+        } else {
+            jsonif.missingKey(path, "contact_case");
+        }
+    }
+    static fromObject(obj: TeamObject): Team {
+        return new Team(obj);
+    }
+    toObject(): TeamObject {
+        const by_name: { [key: string]: PersonObject } = {};
+        this.by_name.forEach((v, k) => {
+            by_name[String(k)] = v.toObject();
+        });
+        const limits: { [key: string]: number } = {};
+        this.limits.forEach((v, k) => {
+            limits[String(k)] = v;
+        });
+        return {
+            leader: this.leader.toObject(),
+            members: this.members.map((x) => x.toObject()),
+            by_name: by_name,
+            limits: limits,
+            size: this.size,
+            phone: this.phone,
+            contact_person: this.contact_person.toObject(),
+            contact_case: this.contact_case,
+        };
+    }
+    validate(path: string = "", violations: jsonif.Violation[] = []): jsonif.Violation[] {
+        if (this.leader.toJson() === new Person().toJson()) {
+            violations.push({ path: jsonif.joinPath(path, "leader"), message: "is required" });
+        }
+        this.leader.validate(jsonif.joinPath(path, "leader"), violations);
+        if (this.members.length < 1) {
+            violations.push({ path: jsonif.joinPath(path, "members"), message: "must have at least 1 items" });
+        }
+        for (let i = 0; i < this.members.length; i++) {
+            this.members[i].validate(jsonif.joinPath(path, "members") + "[" + i + "]", violations);
+        }
+        this.by_name.forEach((v, k) => {
+            v.validate(jsonif.joinPath(path, "by_name") + "[\"" + String(k) + "\"]", violations);
+        });
+        this.limits.forEach((v, k) => {
+            if (v < -10) {
+                violations.push({ path: jsonif.joinPath(path, "limits") + "[\"" + String(k) + "\"]", message: "must be greater than or equal to -10" });
+            }
+            if (v > 10) {
+                violations.push({ path: jsonif.joinPath(path, "limits") + "[\"" + String(k) + "\"]", message: "must be less than or equal to 10" });
+            }
+        });
+        if (this.size === null) {
+            violations.push({ path: jsonif.joinPath(path, "size"), message: "is required" });
+        }
+        if (this.contact_case === Team_ContactCase.kPhone) {
+            if (!new RegExp("^[0-9]+$").test(this.phone)) {
+                violations.push({ path: jsonif.joinPath(path, "phone"), message: "must match pattern \"^[0-9]+$\"" });
+            }
+        }
+        if (this.contact_case === Team_ContactCase.kContactPerson) {
+            this.contact_person.validate(jsonif.joinPath(path, "contact_person"), violations);
+        }
+        return violations;
+    }
+}
+
+/**
+ * 後で宣言されるメッセージをフィールドに持つメッセージも検証する
+ */
+export type LeagueObject = {
+    champion?: ClubObject;
+    clubs?: ClubObject[];
+}
+
+/**
+ * 後で宣言されるメッセージをフィールドに持つメッセージも検証する
+ */
+export class League {
+    champion: Club = new Club();
+    clubs: Club[] = [];
+    constructor(obj: LeagueObject = {}) {
+        if (obj.champion !== undefined) {
+            this.champion = Club.fromObject(obj.champion);
+        }
+        if (obj.clubs !== undefined) {
+            this.clubs = obj.clubs.map((x) => Club.fromObject(x));
+        }
+    }
+    static readonly typeName: string = "validation.League";
+    getType(): typeof League {
+        return League;
+    }
+    static fromJson(json: string): League {
+        return League.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.champion !== undefined) {
+            Club.checkJson(o.champion, jsonif.joinPath(path, "champion"));
+        } else {
+            jsonif.missingKey(path, "champion");
+        }
+        if (o.clubs !== undefined) {
+            jsonif.arrayOf(Club.checkJson)(o.clubs, jsonif.joinPath(path, "clubs"));
+        } else {
+            jsonif.missingKey(path, "clubs");
+        }
+    }
+    static fromObject(obj: LeagueObject): League {
+        return new League(obj);
+    }
+    toObject(): LeagueObject {
+        return {
+            champion: this.champion.toObject(),
+            clubs: this.clubs.map((x) => x.toObject()),
+        };
+    }
+    validate(path: string = "", violations: jsonif.Violation[] = []): jsonif.Violation[] {
+        if (this.champion.toJson() === new Club().toJson()) {
+            violations.push({ path: jsonif.joinPath(path, "champion"), message: "is required" });
+        }
+        this.champion.validate(jsonif.joinPath(path, "champion"), violations);
+        for (let i = 0; i < this.clubs.length; i++) {
+            this.clubs[i].validate(jsonif.joinPath(path, "clubs") + "[" + i + "]", violations);
+        }
+        return violations;
+    }
+}
+
+export type ClubObject = {
+    name?: string;
+}
+
+export class Club {
+    name: string = "";
+    constructor(obj: ClubObject = {}) {
+        if (obj.name !== undefined) {
+            this.name = obj.name;
+        }
+    }
+    static readonly typeName: string = "validation.Club";
+    getType(): typeof Club {
+        return Club;
+    }
+    static fromJson(json: string): Club {
+        return Club.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.name !== undefined) {
+            jsonif.checkString(o.name, jsonif.joinPath(path, "name"));
+        } else {
+            jsonif.missingKey(path, "name");
+        }
+    }
+    static fromObject(obj: ClubObject): Club {
+        return new Club(obj);
+    }
+    toObject(): ClubObject {
+        return {
+            name: this.name,
+        };
+    }
+    validate(path: string = "", violations: jsonif.Violation[] = []): jsonif.Violation[] {
+        if (jsonif.stringLength(this.name) < 1) {
+            violations.push({ path: jsonif.joinPath(path, "name"), message: "length must be at least 1" });
+        }
+        return violations;
+    }
+}
+
+/**
+ * 検証ルールが無いメッセージは validate を生成しない
+ */
+export type NoRulesObject = {
+    value?: number;
+}
+
+/**
+ * 検証ルールが無いメッセージは validate を生成しない
+ */
+export class NoRules {
+    value: number = 0;
+    constructor(obj: NoRulesObject = {}) {
+        if (obj.value !== undefined) {
+            this.value = obj.value;
+        }
+    }
+    static readonly typeName: string = "validation.NoRules";
+    getType(): typeof NoRules {
+        return NoRules;
+    }
+    static fromJson(json: string): NoRules {
+        return NoRules.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.value !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value, jsonif.joinPath(path, "value"));
+        } else {
+            jsonif.missingKey(path, "value");
+        }
+    }
+    static fromObject(obj: NoRulesObject): NoRules {
+        return new NoRules(obj);
+    }
+    toObject(): NoRulesObject {
+        return {
+            value: this.value,
+        };
+    }
+}
+
+jsonif.registerType(Person);
+jsonif.registerType(Team);
+jsonif.registerType(League);
+jsonif.registerType(Club);
+jsonif.registerType(NoRules);
@@ -0,0 +1,243 @@
+import * as jsonif from "./jsonif";
+
+export enum Test_ValueCase {
+    NOT_SET = 0,
+    kOneofDuration = 14,
+    kOneofString = 15,
+}
+
+export type TestObject = {
+    timestamp?: string;
+    duration?: string;
+    double_value?: number | null;
+    float_value?: number | null;
+    int64_value?: number | null;
+    uint64_value?: number | null;
+    int32_value?: number | null;
+    uint32_value?: number | null;
+    bool_value?: boolean | null;
+    string_value?: string | null;
+    timestamps?: string[];
+    int32_values?: (number | null)[];
+    durations?: { [key: string]: string };
+    oneof_duration?: string;
+    oneof_string?: string | null;
+    value_case?: Test_ValueCase;
+}
+
+export class Test {
+    timestamp: Date = new Date(0);
+    duration: number = 0;
+    double_value: number | null = null;
+    float_value: number | null = null;
+    int64_value: number | null = null;
+    uint64_value: number | null = null;
+    int32_value: number | null = null;
+    uint32_value: number | null = null;
+    bool_value: boolean | null = null;
+    string_value: string | null = null;
+    timestamps: Date[] = [];
+    int32_values: (number | null)[] = [];
+    durations: Map<string, number> = new Map();
+    oneof_duration: number = 0;
+    oneof_string: string | null = null;
+    value_case: Test_ValueCase = Test_ValueCase.NOT_SET;
+    clearValue() {
+        this.value_case = Test_ValueCase.NOT_SET;
+        this.oneof_duration = 0;
+        this.oneof_string = null;
+    }
+    setOneofDuration(value: number) {
+        this.value_case = Test_ValueCase.kOneofDuration;
+        this.oneof_duration = value;
+    }
+    clearOneofDuration() {
+        if (this.value_case === Test_ValueCase.kOneofDuration) {
+            this.clearValue();
+        }
+    }
+    setOneofString(value: string | null) {
+        this.value_case = Test_ValueCase.kOneofString;
+        this.oneof_string = value;
+    }
+    clearOneofString() {
+        if (this.value_case === Test_ValueCase.kOneofString) {
+            this.clearValue();
+        }
+    }
+    constructor(obj: TestObject = {}) {
+        if (obj.timestamp !== undefined) {
+            this.timestamp = jsonif.timestampFromJson(obj.timestamp);
+        }
+        if (obj.duration !== undefined) {
+            this.duration = jsonif.durationFromJson(obj.duration);
+        }
+        if (obj.double_value !== undefined) {
+            this.double_value = obj.double_value;
+        }
+        if (obj.float_value !== undefined) {
+            this.float_value = obj.float_value;
+        }
+        if (obj.int64_value !== undefined) {
+            this.int64_value = obj.int64_value;
+        }
+        if (obj.uint64_value !== undefined) {
+            this.uint64_value = obj.uint64_value;
+        }
+        if (obj.int32_value !== undefined) {
+            this.int32_value = obj.int32_value;
+        }
+        if (obj.uint32_value !== undefined) {
+            this.uint32_value = obj.uint32_value;
+        }
+        if (obj.bool_value !== undefined) {
+            this.bool_value = obj.bool_value;
+        }
+        if (obj.string_value !== undefined) {
+            this.string_value = obj.string_value;
+        }
+        if (obj.timestamps !== undefined) {
+            this.timestamps = obj.timestamps.map((x) => jsonif.timestampFromJson(x));
+        }
+        if (obj.int32_values !== undefined) {
+            this.int32_values = obj.int32_values;
+        }
+        if (obj.durations !== undefined) {
+            this.durations = new Map();
+            for (const k of Object.keys(obj.durations)) {
+                this.durations.set(k, jsonif.durationFromJson(obj.durations[k]));
+            }
+        }
+        if (obj.oneof_duration !== undefined) {
+            this.oneof_duration = jsonif.durationFromJson(obj.oneof_duration);
+        }
+        if (obj.oneof_string !== undefined) {
+            this.oneof_string = obj.oneof_string;
+        }
+        if (obj.value_case !== undefined) {
+            this.value_case = obj.value_case;
+        }
+    }
+    static readonly typeName: string = "wellknown.Test";
+    getType(): typeof Test {
+        return Test;
+    }
+    static fromJson(json: string): Test {
+        return Test.fromObject(JSON.parse(json));
+    }
+    toJson(): string {
+        return JSON.stringify(this.toObject());
+    }
+    /**
+     * JSON から変換したオブジェクトを読み込めるかどうかを調べて、問題があれば jsonif.ParseError を投げる
+     */
+    static checkJson(obj: unknown, path: string): void {
+        jsonif.checkObject(obj, path);
+        const o = obj as Record<string, unknown>;
+        if (o.timestamp !== undefined) {
+            jsonif.checkString(o.timestamp, jsonif.joinPath(path, "timestamp"));
+        } else {
+            jsonif.missingKey(path, "timestamp");
+        }
+        if (o.duration !== undefined) {
+            jsonif.checkString(o.duration, jsonif.joinPath(path, "duration"));
+        } else {
+            jsonif.missingKey(path, "duration");
+        }
+        if (o.double_value !== undefined) {
+            jsonif.nullable(jsonif.checkNumber)(o.double_value, jsonif.joinPath(path, "double_value"));
+        } else {
+            jsonif.missingKey(path, "double_value");
+        }
+        if (o.float_value !== undefined) {
+            jsonif.nullable(jsonif.checkNumber)(o.float_value, jsonif.joinPath(path, "float_value"));
+        } else {
+            jsonif.missingKey(path, "float_value");
+        }
+        if (o.int64_value !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-9223372036854775808, 9223372036854775807))(o.int64_value, jsonif.joinPath(path, "int64_value"));
+        } else {
+            jsonif.missingKey(path, "int64_value");
+        }
+        if (o.uint64_value !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(0, 18446744073709551615))(o.uint64_value, jsonif.joinPath(path, "uint64_value"));
+        } else {
+            jsonif.missingKey(path, "uint64_value");
+        }
+        if (o.int32_value !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647))(o.int32_value, jsonif.joinPath(path, "int32_value"));
+        } else {
+            jsonif.missingKey(path, "int32_value");
+        }
+        if (o.uint32_value !== undefined) {
+            jsonif.nullable(jsonif.integerChecker(0, 4294967295))(o.uint32_value, jsonif.joinPath(path, "uint32_value"));
+        } else {
+            jsonif.missingKey(path, "uint32_value");
+        }
+        if (o.bool_value !== undefined) {
+            jsonif.nullable(jsonif.checkBoolean)(o.bool_value, jsonif.joinPath(path, "bool_value"));
+        } else {
+            jsonif.missingKey(path, "bool_value");
+        }
+        if (o.string_value !== undefined) {
+            jsonif.nullable(jsonif.checkString)(o.string_value, jsonif.joinPath(path, "string_value"));
+        } else {
+            jsonif.missingKey(path, "string_value");
+        }
+        if (o.timestamps !== undefined) {
+            jsonif.arrayOf(jsonif.checkString)(o.timestamps, jsonif.joinPath(path, "timestamps"));
+        } else {
+            jsonif.missingKey(path, "timestamps");
+        }
+        if (o.int32_values !== undefined) {
+            jsonif.arrayOf(jsonif.nullable(jsonif.integerChecker(-2147483648, 2147483647)))(o.int32_values, jsonif.joinPath(path, "int32_values"));
+        } else {
+            jsonif.missingKey(path, "int32_values");
+        }
+        if (o.durations !== undefined) {
+            jsonif.mapOf(jsonif.stringKey, jsonif.checkString)(o.durations, jsonif.joinPath(path, "durations"));
+        } else {
+            jsonif.missingKey(path, "durations");
+        }
+        if (o.oneof_duration !== undefined) {
+            jsonif.checkString(o.oneof_duration, jsonif.joinPath(path, "oneof_duration"));
+        }
+        if (o.oneof_string !== undefined) {
+            jsonif.nullable(jsonif.checkString)(o.oneof_string, jsonif.joinPath(path, "oneof_string"));
+        }
+        if (o.value_case !== undefined) {
+            jsonif.integerChecker(-2147483648, 2147483647)(o.value_case, jsonif.joinPath(path, "value_case"));
+        } else {
+            jsonif.missingKey(path, "value_case");
+        }
+    }
+    static fromObject(obj: TestObject): Test {
+        return new Test(obj);
+    }
+    toObject(): TestObject {
+        const durations: { [key: string]: string } = {};
+        this.durations.forEach((v, k) => {
+            durations[String(k)] = jsonif.durationToJson(v);
+        });
+        return {
+            timestamp: jsonif.timestampToJson(this.timestamp),
+            duration: jsonif.durationToJson(this.duration),
+            double_value: this.double_value,
+            float_value: this.float_value,
+            int64_value: this.int64_value,
+            uint64_value: this.uint64_value,
+            int32_value: this.int32_value,
+            uint32_value: this.uint32_value,
+            bool_value: this.bool_value,
+            string_value: this.string_value,
+            timestamps: this.timestamps.map((x) => jsonif.timestampToJson(x)),
+            int32_values: this.int32_values,
+            durations: durations,
+            oneof_duration: jsonif.durationToJson(this.oneof_duration),
+            oneof_string: this.oneof_string,
+            value_case: this.value_case,
+        };
+    }
+}
+
+jsonif.registerType(Test);
@@ -13,14 +13,31 @@ import (
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
+// プラグインパラメータ
+type options struct {
+	internal.CommonOptions
+	// deprecated なフィールドのキーが JSON にあった場合に Jsonif.DeprecatedField.Report を呼ぶ
+	ReportDeprecated bool
+}
+
+func newOptionSet(opts *options) *internal.OptionSet {
+	s := &internal.OptionSet{}
+	opts.CommonOptions.Register(s)
+	s.Bool("report_deprecated", &opts.ReportDeprecated)
+	return s
+}
+
 type unityFile struct {
 	Top      internal.Formatter
 	Bottom   internal.Formatter
 	Typedefs internal.Formatter
+	Registry internal.Formatter
+	// Jsonif.TypeRegistry に登録するメッセージ
+	Messages []*internal.Message
 }
 
 func (u *unityFile) String() string {
-	return u.Top.String() + u.Typedefs.String() + u.Bottom.String()
+	return u.Top.String() + u.Typedefs.String() + u.Bottom.String() + u.Registry.String()
 }
 
 // foo.bar_baz を Foo.BarBaz に変換する
@@ -32,6 +49,32 @@ func packageToNamespace(pkg string) string {
 	return strings.Join(xs, ".")
 }
 
+// 予約語と同じ名前の場合は先頭に @ を付ける
+// JsonUtility は @ を除いた名前を JSON のキーにするので、JSON の形式は変わらない
+func escapeName(name string) string {
+	if internal.CSharpKeywords[name] {
+		return "@" + name
+	}
+	return name
+}
+
+// フィールドの変数名
+func toFieldName(field *internal.Field) string {
+	return escapeName(internal.ToSnakeCase(field.Name))
+}
+
+// TypeRegistry に登録したことを表すフィールドの名前
+// ファイルごとに異なる名前にする（foo/bar_baz.proto → registeredFoo_BarBaz_proto）
+func toRegisteredFieldName(file *internal.File) string {
+	name := strings.Map(func(c rune) rune {
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' {
+			return c
+		}
+		return '_'
+	}, pathToUpperCamel(file.Name))
+	return "registered" + name
+}
+
 // foo/bar_baz.txt を Foo/BarBaz.txt に変換する
 func pathToUpperCamel(pkg string) string {
 	xs := strings.Split(pkg, "/")
@@ -41,11 +84,23 @@ func pathToUpperCamel(pkg string) string {
 	return strings.Join(xs, "/")
 }
 
-func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, string, error) {
-	isRepeated := *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+func toTypeName(field *internal.Field) (string, string, error) {
+	if field.IsMap() {
+		keyTypeName, _, err := toTypeName(field.MapKey)
+		if err != nil {
+			return "", "", err
+		}
+		valueTypeName, _, err := toTypeName(field.MapValue)
+		if err != nil {
+			return "", "", err
+		}
+		typeName := fmt.Sprintf("Dictionary<%s, %s>", keyTypeName, valueTypeName)
+		return typeName, fmt.Sprintf("new %s()", typeName), nil
+	}
+
 	typeName := ""
 	defaultValue := ""
-	switch *field.Type {
+	switch field.Type {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
 		typeName = "double"
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
@@ -70,99 +125,471 @@ func toTypeName(field *descriptorpb.FieldDescriptorProto) (string, string, error
 		typeName = "string"
 		defaultValue = "\"\""
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		//typeName = "std::string"
-		return "", "", errors.New("bytes type not supported")
-	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
-		descriptorpb.FieldDescriptorProto_TYPE_GROUP,
-		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		typeName = "global::" + packageToNamespace((*field.TypeName)[1:])
+		typeName = "byte[]"
+		defaultValue = "new byte[0]"
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		typeName = "global::" + packageToNamespace(field.Enum.FullName)
+		defaultValue = fmt.Sprintf("new %s()", typeName)
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		if field.WellKnownType() != internal.NotWellKnown {
+			var err error
+			typeName, defaultValue, err = toWellKnownTypeName(field.Message)
+			if err != nil {
+				return "", "", err
+			}
+			break
+		}
+		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		typeName = "global::" + packageToNamespace(field.Message.FullName)
 		defaultValue = fmt.Sprintf("new %s()", typeName)
 	default:
 		return "", "", errors.New("invalid type")
 	}
 
-	if isRepeated {
+	if field.Repeated {
 		return fmt.Sprintf("List<%s>", typeName), fmt.Sprintf("new List<%s>()", typeName), nil
+	} else if field.HasDefault {
+		return typeName, toLiteral(field, field.Default, typeName), nil
 	} else {
 		return typeName, defaultValue, nil
 	}
 }
 
-func genEnum(enum *descriptorpb.EnumDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, u *unityFile) error {
+// 型に合わせて正規化した値（jsonif_default や jsonif_min の値）のリテラル
+func toLiteral(field *internal.Field, value string, typeName string) string {
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return internal.FloatLiteral(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return internal.FloatLiteral(value) + "f"
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return value + "L"
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return value + "U"
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return value + "UL"
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return toStringLiteral(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		// 負の値をキャストする場合は括弧が必要になる
+		if strings.HasPrefix(value, "-") {
+			return fmt.Sprintf("(%s)(%s)", typeName, value)
+		}
+		return fmt.Sprintf("(%s)%s", typeName, value)
+	}
+	return value
+}
+
+// C# の文字列リテラルにする
+func toStringLiteral(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f || r == 0x2028 || r == 0x2029:
+			// 改行として扱われる文字も含めて \uXXXX にする
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// well-known type の型名とデフォルト値を返す
+// Timestamp は DateTime、Duration は TimeSpan、ラッパー型は null 許容型になる
+// Struct, ListValue, Value は JsonReader が返すのと同じ Dictionary<string, object>, List<object>, object になる
+// Any は null を入れられる Jsonif.Any になる
+func toWellKnownTypeName(msg *internal.Message) (string, string, error) {
+	switch msg.WellKnownType() {
+	case internal.WellKnownAny:
+		return "global::Jsonif.Any", "", nil
+	case internal.WellKnownStruct:
+		return "Dictionary<string, object>", "", nil
+	case internal.WellKnownListValue:
+		return "List<object>", "", nil
+	case internal.WellKnownValue:
+		return "object", "", nil
+	case internal.WellKnownTimestamp:
+		return "System.DateTime", "new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc)", nil
+	case internal.WellKnownDuration:
+		return "System.TimeSpan", "", nil
+	case internal.WellKnownWrapper:
+		typeName, _, err := toTypeName(msg.WrapperValue())
+		if err != nil {
+			return "", "", err
+		}
+		// string と byte[] は元から null を入れられる
+		if typeName == "string" || typeName == "byte[]" {
+			return typeName, "", nil
+		}
+		return typeName + "?", "", nil
+	}
+	return "", "", errors.New("not well-known type")
+}
+
+// JSON の値 v を読み込む式（repeated の場合は要素を読み込む式）
+func toReadExpr(field *internal.Field, v string) (string, error) {
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadTimestamp(%s)", v), nil
+	case internal.WellKnownDuration:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadDuration(%s)", v), nil
+	case internal.WellKnownStruct:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadStruct(%s)", v), nil
+	case internal.WellKnownListValue:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadListValue(%s)", v), nil
+	case internal.WellKnownValue:
+		// JSON の値をそのまま保持する
+		return v, nil
+	case internal.WellKnownAny:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadAny(%s)", v), nil
+	case internal.WellKnownWrapper:
+		typeName, _, err := toWellKnownTypeName(field.Message)
+		if err != nil {
+			return "", err
+		}
+		value, err := toReadExpr(field.Message.WrapperValue(), v)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s == null ? (%s)null : %s)", v, typeName, value), nil
+	}
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadDouble(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadFloat(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadInt(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadLong(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadUInt(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadULong(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadBool(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadString(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadBytes(%s)", v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		if field.Enum.AsName {
+			return fmt.Sprintf("global::Jsonif.JsonReader.ReadEnum<global::%s>(%s)", packageToNamespace(field.Enum.FullName), v), nil
+		}
+		return fmt.Sprintf("(global::%s)global::Jsonif.JsonReader.ReadInt(%s)", packageToNamespace(field.Enum.FullName), v), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		return fmt.Sprintf("global::Jsonif.JsonReader.ReadObject<global::%s>(%s)", packageToNamespace(field.Message.FullName), v), nil
+	default:
+		return "", errors.New("invalid type")
+	}
+}
+
+// 値 v を書き出す JsonWriter のメソッド呼び出し
+func toWriteCall(field *internal.Field, v string) string {
+	if field.Type == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		if field.Enum.AsName {
+			return "WriteEnum(" + v + ")"
+		}
+		return "Write((int)" + v + ")"
+	}
+	if field.Int64AsString {
+		return "WriteAsString(" + v + ")"
+	}
+	if field.WellKnownType().IsJsonValue() {
+		return "WriteValue(" + v + ")"
+	}
+	// bytes は base64 の文字列にする
+	if isBytes(field) {
+		name := "Write"
+		if field.WellKnownType() == internal.WellKnownWrapper {
+			name = "WriteNullable"
+		}
+		if field.Base64URL {
+			name += "Base64Url"
+		}
+		return name + "(" + v + ")"
+	}
+	// null の string は "" として書き出すので、null を書き出せるメソッドを使う
+	if field.WellKnownType() == internal.WellKnownWrapper && field.Message.WrapperValue().Type == descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		return "WriteNullable(" + v + ")"
+	}
+	return "Write(" + v + ")"
+}
+
+// JSON のキー
+// canonical の場合は JSON のキー名（lowerCamelCase か json_name）、それ以外はエスケープする前のフィールド名を使う
+func toJsonKey(field *internal.Field, opts *options) string {
+	if opts.Canonical {
+		return field.JsonKey
+	}
+	return internal.ToSnakeCase(field.Name)
+}
+
+// canonical の WriteJson で、フィールドがデフォルト値ではない（出力する）条件
+func toNonDefaultCondition(field *internal.Field) (string, error) {
+	value := "this." + toFieldName(field)
+	if oneof := field.Oneof; oneof != nil && oneof.ActiveOnly {
+		oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		return fmt.Sprintf("this.%s == %s.k%s", oneofFieldName, oneofTypeName, internal.ToUpperCamel(field.Name)), nil
+	}
+	if field.HasDefault {
+		_, defaultValue, err := toTypeName(field)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s != %s", value, defaultValue), nil
+	}
+	if field.IsMap() || field.Repeated {
+		return value + ".Count != 0", nil
+	}
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		_, defaultValue, err := toTypeName(field)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s != %s", value, defaultValue), nil
+	case internal.WellKnownDuration:
+		return value + " != System.TimeSpan.Zero", nil
+	case internal.NotWellKnown:
+	default:
+		return value + " != null", nil
+	}
+	switch field.Type {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return value, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return fmt.Sprintf("!string.IsNullOrEmpty(%s)", value), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return fmt.Sprintf("%s != null && %s.Length != 0", value, value), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		return fmt.Sprintf("(int)%s != 0", value), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		_, defaultValue, err := toTypeName(field)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s != null && !%s.Equals(%s)", value, value, defaultValue), nil
+	}
+	return value + " != 0", nil
+}
+
+// null になることがあるフィールドかどうか
+func isNullable(field *internal.Field) bool {
+	return field.WellKnownType() == internal.WellKnownWrapper || field.WellKnownType() == internal.WellKnownAny
+}
+
+// bytes（google.protobuf.BytesValue を含む）かどうか
+// byte[] は参照で比較されるので、比較とハッシュ値は中身から求める
+func isBytes(field *internal.Field) bool {
+	if field.WellKnownType() == internal.WellKnownWrapper {
+		return field.Message.WrapperValue().Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	}
+	return field.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES
+}
+
+// 値 v のハッシュ値を取得する式
+// ラッパー型と Any は null になることがあるので、null の場合は 0 にする
+func toHashExpr(field *internal.Field, v string) string {
+	if field.WellKnownType().IsJsonValue() {
+		return fmt.Sprintf("global::Jsonif.Json.ValueHashCode(%s)", v)
+	}
+	if isBytes(field) {
+		return fmt.Sprintf("global::Jsonif.Json.BytesHashCode(%s)", v)
+	}
+	if isNullable(field) {
+		return fmt.Sprintf("(%s == null ? 0 : %s.GetHashCode())", v, v)
+	}
+	return v + ".GetHashCode()"
+}
+
+// 値を中身で比較する IEqualityComparer（デフォルトの比較でいい場合は空文字列）
+// Struct, ListValue, Value は JSON の値として比較し、bytes は要素を比較する
+func toValueComparer(field *internal.Field) (string, error) {
+	if isBytes(field) {
+		return "global::Jsonif.BytesComparer.Instance", nil
+	}
+	if !field.WellKnownType().IsJsonValue() {
+		return "", nil
+	}
+	typeName, _, err := toWellKnownTypeName(field.Message)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("global::Jsonif.JsonValueComparer<%s>.Instance", typeName), nil
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// proto のコメントを XML ドキュメントコメントとして出力する
+func genComment(f *internal.Formatter, comments []string) {
+	if len(comments) == 0 {
+		return
+	}
+	f.P("/// <summary>")
+	for _, line := range comments {
+		if len(line) == 0 {
+			f.P("///")
+		} else {
+			f.P("/// %s", xmlEscaper.Replace(line))
+		}
+	}
+	f.P("/// </summary>")
+}
+
+// deprecated な要素に付ける属性
+func genObsolete(f *internal.Formatter, deprecated bool) {
+	if deprecated {
+		f.P("[System.Obsolete]")
+	}
+}
+
+func genEnum(enum *internal.Enum, u *unityFile) error {
+	genComment(&u.Typedefs, enum.Comments)
 	u.Typedefs.P("[System.Serializable]")
-	u.Typedefs.P("public enum %s", *enum.Name)
+	u.Typedefs.P("public enum %s", escapeName(enum.Name))
 	u.Typedefs.PI("{")
-	for _, v := range enum.Value {
-		u.Typedefs.P("%s = %d,", *v.Name, *v.Number)
+	for _, v := range enum.Values {
+		genComment(&u.Typedefs, v.Comments)
+		genObsolete(&u.Typedefs, v.Deprecated)
+		u.Typedefs.P("%s = %d,", escapeName(v.Name), v.Number)
 	}
 	u.Typedefs.PD("}")
 	u.Typedefs.P("")
 	return nil
 }
 
-func genOneof(oneof *descriptorpb.OneofDescriptorProto, fields []*descriptorpb.FieldDescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, u *unityFile) error {
-	typeName := internal.ToUpperCamel(*oneof.Name) + "Case"
-	fieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
+func genOneof(oneof *internal.Oneof, u *unityFile) error {
+	typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+	fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+	genComment(&u.Typedefs, oneof.Comments)
 	u.Typedefs.P("[System.Serializable]")
 	u.Typedefs.P("public enum %s", typeName)
 	u.Typedefs.PI("{")
 	u.Typedefs.P("NOT_SET = 0,")
-	for _, field := range fields {
-		u.Typedefs.P("k%s = %d,", internal.ToUpperCamel(*field.Name), *field.Number)
+	for _, field := range oneof.Fields {
+		u.Typedefs.P("k%s = %d,", internal.ToUpperCamel(field.Name), field.Number)
 	}
 	u.Typedefs.PD("}")
 	u.Typedefs.P("public %s %s;", typeName, fieldName)
 	u.Typedefs.P("public void Clear%s()", typeName)
 	u.Typedefs.PI("{")
 	u.Typedefs.P("%s = %s.NOT_SET;", fieldName, typeName)
-	for _, field := range fields {
+	for _, field := range oneof.Fields {
 		fieldType, defaultValue, err := toTypeName(field)
 		if err != nil {
 			return err
 		}
 		if len(defaultValue) == 0 {
-			u.Typedefs.P("%s = default(%s);", internal.ToSnakeCase(*field.Name), fieldType)
+			u.Typedefs.P("%s = default(%s);", toFieldName(field), fieldType)
 		} else {
-			u.Typedefs.P("%s = %s;", internal.ToSnakeCase(*field.Name), defaultValue)
+			u.Typedefs.P("%s = %s;", toFieldName(field), defaultValue)
 		}
 	}
 	u.Typedefs.PD("}")
 	return nil
 }
 
-func genEquals(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, u *unityFile) error {
+func genEquals(msg *internal.Message, u *unityFile) error {
 	u.Typedefs.P("public override bool Equals(object obj)")
 	u.Typedefs.PI("{")
-	u.Typedefs.P("var v = obj as %s;", *desc.Name)
+	u.Typedefs.P("var v = obj as %s;", escapeName(msg.Name))
 	u.Typedefs.P("if (v == null) return false;")
 
 	// oneof 以外の比較
-	for _, field := range desc.Field {
-		if field.OneofIndex == nil {
-			fieldName := internal.ToSnakeCase(*field.Name)
-			if *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+	for _, field := range msg.Fields {
+		if field.Oneof == nil {
+			fieldName := toFieldName(field)
+			if field.IsMap() {
+				// Dictionary の場合は要素の順序を無視して比較する
+				comparer, err := toValueComparer(field.MapValue)
+				if err != nil {
+					return err
+				}
+				if len(comparer) != 0 {
+					u.Typedefs.P("if (!global::Jsonif.Json.DictionaryEquals(this.%s, v.%s, %s)) return false;", fieldName, fieldName, comparer)
+				} else {
+					u.Typedefs.P("if (!global::Jsonif.Json.DictionaryEquals(this.%s, v.%s)) return false;", fieldName, fieldName)
+				}
+			} else if field.Repeated {
 				// List の場合は SequenceEqual で比較する
-				u.Typedefs.P("if (!this.%s.SequenceEqual(v.%s)) return false;", fieldName, fieldName)
+				comparer, err := toValueComparer(field)
+				if err != nil {
+					return err
+				}
+				if len(comparer) != 0 {
+					u.Typedefs.P("if (!this.%s.SequenceEqual(v.%s, %s)) return false;", fieldName, fieldName, comparer)
+				} else {
+					u.Typedefs.P("if (!this.%s.SequenceEqual(v.%s)) return false;", fieldName, fieldName)
+				}
+			} else if field.WellKnownType().IsJsonValue() {
+				// JSON の値は中身を比較する
+				u.Typedefs.P("if (!global::Jsonif.Json.ValueEquals(this.%s, v.%s)) return false;", fieldName, fieldName)
+			} else if isBytes(field) {
+				// byte[] は中身を比較する（BytesValue の null も比較できる）
+				u.Typedefs.P("if (!global::Jsonif.Json.BytesEquals(this.%s, v.%s)) return false;", fieldName, fieldName)
+			} else if isNullable(field) {
+				// ラッパー型と Any は null になることがある
+				u.Typedefs.P("if (!object.Equals(this.%s, v.%s)) return false;", fieldName, fieldName)
 			} else {
 				u.Typedefs.P("if (!this.%s.Equals(v.%s)) return false;", fieldName, fieldName)
 			}
 		}
 	}
 	// oneof の比較
-	for i, oneof := range desc.OneofDecl {
-		oneofFieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		oneofTypeName := internal.ToUpperCamel(*oneof.Name) + "Case"
+	for _, oneof := range msg.Oneofs {
+		oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
 		u.Typedefs.P("if (!this.%s.Equals(v.%s)) return false;", oneofFieldName, oneofFieldName)
 
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fieldName := internal.ToSnakeCase(*field.Name)
-				enumFieldName := internal.ToUpperCamel(*field.Name)
+		for _, field := range oneof.Fields {
+			fieldName := toFieldName(field)
+			enumFieldName := internal.ToUpperCamel(field.Name)
+			if field.WellKnownType().IsJsonValue() {
+				u.Typedefs.P("if (this.%s == %s.k%s && !global::Jsonif.Json.ValueEquals(this.%s, v.%s)) return false;",
+					oneofFieldName, oneofTypeName, enumFieldName, fieldName, fieldName)
+			} else if isBytes(field) {
+				u.Typedefs.P("if (this.%s == %s.k%s && !global::Jsonif.Json.BytesEquals(this.%s, v.%s)) return false;",
+					oneofFieldName, oneofTypeName, enumFieldName, fieldName, fieldName)
+			} else if isNullable(field) {
+				u.Typedefs.P("if (this.%s == %s.k%s && !object.Equals(this.%s, v.%s)) return false;",
+					oneofFieldName, oneofTypeName, enumFieldName, fieldName, fieldName)
+			} else {
 				u.Typedefs.P("if (this.%s == %s.k%s && !this.%s.Equals(v.%s)) return false;",
 					oneofFieldName, oneofTypeName, enumFieldName, fieldName, fieldName)
 			}
 		}
 	}
+	if msg.KeepUnknownFields {
+		// 知らないキーは、キーと値の組の集合として比較する（キーの順序は無視する）
+		u.Typedefs.P("if (!global::Jsonif.Json.DictionaryEquals(this.jsonif_unknown_fields, v.jsonif_unknown_fields, global::Jsonif.JsonValueComparer<object>.Instance)) return false;")
+	}
 	u.Typedefs.P("return true;")
 	u.Typedefs.PD("}")
 	u.Typedefs.P("")
@@ -171,32 +598,36 @@ func genEquals(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descr
 	u.Typedefs.P("int hashcode = 1430287;")
 
 	// oneof 以外のハッシュ値
-	for _, field := range desc.Field {
-		if field.OneofIndex == nil {
-			fieldName := internal.ToSnakeCase(*field.Name)
-			if *field.Label == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+	for _, field := range msg.Fields {
+		if field.Oneof == nil {
+			fieldName := toFieldName(field)
+			if field.IsMap() {
+				// Dictionary の場合は要素の順序に依存しないように XOR で混ぜる
+				u.Typedefs.P("foreach (var kv in this.%s) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ %s);", fieldName, toHashExpr(field.MapValue, "kv.Value"))
+			} else if field.Repeated {
 				// List の場合は各要素のハッシュ値を取得する
-				u.Typedefs.P("foreach (var v in this.%s) hashcode = hashcode * 7302013 ^ v.GetHashCode();", fieldName)
+				u.Typedefs.P("foreach (var v in this.%s) hashcode = hashcode * 7302013 ^ %s;", fieldName, toHashExpr(field, "v"))
 			} else {
-				u.Typedefs.P("hashcode = hashcode * 7302013 ^ %s.GetHashCode();", fieldName)
+				u.Typedefs.P("hashcode = hashcode * 7302013 ^ %s;", toHashExpr(field, fieldName))
 			}
 		}
 	}
 	// oneof のハッシュ値
-	for i, oneof := range desc.OneofDecl {
-		oneofFieldName := internal.ToSnakeCase(*oneof.Name) + "_case"
-		oneofTypeName := internal.ToUpperCamel(*oneof.Name) + "Case"
+	for _, oneof := range msg.Oneofs {
+		oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
 		u.Typedefs.P("hashcode = hashcode * 7302013 ^ %s.GetHashCode();", oneofFieldName)
 
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fieldName := internal.ToSnakeCase(*field.Name)
-				enumFieldName := internal.ToUpperCamel(*field.Name)
-				u.Typedefs.P("if (%s == %s.k%s) hashcode = hashcode * 7302013 ^ %s.GetHashCode();",
-					oneofFieldName, oneofTypeName, enumFieldName, fieldName)
-			}
+		for _, field := range oneof.Fields {
+			fieldName := toFieldName(field)
+			enumFieldName := internal.ToUpperCamel(field.Name)
+			u.Typedefs.P("if (%s == %s.k%s) hashcode = hashcode * 7302013 ^ %s;",
+				oneofFieldName, oneofTypeName, enumFieldName, toHashExpr(field, fieldName))
 		}
 	}
+	if msg.KeepUnknownFields {
+		u.Typedefs.P("foreach (var kv in this.jsonif_unknown_fields) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ global::Jsonif.Json.ValueHashCode(kv.Value));")
+	}
 	u.Typedefs.P("return hashcode;")
 	u.Typedefs.PD("}")
 	u.Typedefs.P("")
@@ -204,63 +635,362 @@ func genEquals(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descr
 	return nil
 }
 
-func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*descriptorpb.DescriptorProto, u *unityFile) error {
-	u.Typedefs.P("[System.Serializable]")
-	u.Typedefs.P("public class %s", *desc.Name)
+// JSON の読み書き
+// JsonUtility と同じく、oneof の case、フィールドの順に、エスケープする前の名前をキーにする
+// canonical の場合は、デフォルト値のフィールドと設定されていない oneof のフィールドを出力しない
+func genSerializer(msg *internal.Message, u *unityFile, opts *options) error {
+	u.Typedefs.P("public void WriteJson(global::Jsonif.JsonWriter w)")
 	u.Typedefs.PI("{")
+	u.Typedefs.P("w.BeginObject();")
+	for _, oneof := range msg.Oneofs {
+		if oneof.ActiveOnly {
+			continue
+		}
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		u.Typedefs.P("w.Key(\"%s\");", fieldName)
+		u.Typedefs.P("w.Write((int)this.%s);", fieldName)
+	}
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		omittable := opts.Canonical || field.Oneof != nil && field.Oneof.ActiveOnly
+		if omittable {
+			cond, err := toNonDefaultCondition(field)
+			if err != nil {
+				return err
+			}
+			u.Typedefs.P("if (%s)", cond)
+			u.Typedefs.PI("{")
+		}
+		u.Typedefs.P("w.Key(\"%s\");", toJsonKey(field, opts))
+		if field.IsMap() {
+			u.Typedefs.P("w.BeginObject();")
+			u.Typedefs.P("foreach (var kv in this.%s)", fieldName)
+			u.Typedefs.PI("{")
+			u.Typedefs.P("w.Key(kv.Key);")
+			u.Typedefs.P("w.%s;", toWriteCall(field.MapValue, "kv.Value"))
+			u.Typedefs.PD("}")
+			u.Typedefs.P("w.EndObject();")
+		} else if field.Repeated {
+			u.Typedefs.P("w.BeginArray();")
+			u.Typedefs.P("foreach (var x in this.%s) w.%s;", fieldName, toWriteCall(field, "x"))
+			u.Typedefs.P("w.EndArray();")
+		} else {
+			u.Typedefs.P("w.%s;", toWriteCall(field, "this."+fieldName))
+		}
+		if omittable {
+			u.Typedefs.PD("}")
+		}
+	}
+	if msg.KeepUnknownFields {
+		// 知っているキーと同じキーは出力しない
+		u.Typedefs.P("foreach (var kv in this.jsonif_unknown_fields)")
+		u.Typedefs.PI("{")
+		u.Typedefs.P("if (JsonifKnownKeys.Contains(kv.Key)) continue;")
+		u.Typedefs.P("w.Key(kv.Key);")
+		u.Typedefs.P("w.WriteValue(kv.Value);")
+		u.Typedefs.PD("}")
+	}
+	u.Typedefs.P("w.EndObject();")
+	u.Typedefs.PD("}")
+	u.Typedefs.P("")
 
-	for _, enum := range desc.EnumType {
-		if err := genEnum(enum, pkg, append(parents, desc), u); err != nil {
-			return err
+	u.Typedefs.P("public void ReadJson(object json)")
+	u.Typedefs.PI("{")
+	u.Typedefs.P("var obj = json as Dictionary<string, object>;")
+	u.Typedefs.P("if (obj == null) throw new System.FormatException(\"expected object\");")
+	if len(msg.Oneofs) != 0 || len(msg.Fields) != 0 {
+		u.Typedefs.P("object v;")
+	}
+	for _, oneof := range msg.Oneofs {
+		if oneof.ActiveOnly {
+			continue
+		}
+		typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		u.Typedefs.P("if (obj.TryGetValue(\"%s\", out v)) this.%s = (%s)global::Jsonif.JsonReader.ReadInt(v);", fieldName, fieldName, typeName)
+	}
+	for _, field := range msg.Fields {
+		if opts.ReportDeprecated && field.Deprecated {
+			key := toJsonKey(field, opts)
+			u.Typedefs.P("if (obj.ContainsKey(\"%s\")) global::Jsonif.DeprecatedField.Report(\"%s\", \"%s\");", key, msg.FullName, key)
+		}
+		var expr string
+		if field.IsMap() {
+			key, err := toReadExpr(field.MapKey, "k")
+			if err != nil {
+				return err
+			}
+			value, err := toReadExpr(field.MapValue, "x")
+			if err != nil {
+				return err
+			}
+			expr = fmt.Sprintf("global::Jsonif.JsonReader.ReadDictionary(v, k => %s, x => %s)", key, value)
+		} else if field.Repeated {
+			value, err := toReadExpr(field, "x")
+			if err != nil {
+				return err
+			}
+			expr = fmt.Sprintf("global::Jsonif.JsonReader.ReadList(v, x => %s)", value)
+		} else {
+			var err error
+			expr, err = toReadExpr(field, "v")
+			if err != nil {
+				return err
+			}
+		}
+		if oneof := field.Oneof; oneof != nil && oneof.ActiveOnly {
+			// キーがあるフィールドが設定されているフィールドになる
+			oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			u.Typedefs.P("if (obj.TryGetValue(\"%s\", out v))", toJsonKey(field, opts))
+			u.Typedefs.PI("{")
+			u.Typedefs.P("this.%s = %s;", toFieldName(field), expr)
+			u.Typedefs.P("this.%s = %s.k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(field.Name))
+			u.Typedefs.PD("}")
+		} else {
+			u.Typedefs.P("if (obj.TryGetValue(\"%s\", out v)) this.%s = %s;", toJsonKey(field, opts), toFieldName(field), expr)
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		// optional はキーの有無だけで判断する
+		if !oneof.ActiveOnly || oneof.Synthetic {
+			continue
+		}
+		// 互換性のため、_case があればキーから判断した値よりも優先する
+		typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		fieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		u.Typedefs.P("if (obj.TryGetValue(\"%s\", out v)) this.%s = (%s)global::Jsonif.JsonReader.ReadInt(v);", fieldName, fieldName, typeName)
+	}
+	if msg.KeepUnknownFields {
+		u.Typedefs.P("this.jsonif_unknown_fields = new Dictionary<string, object>();")
+		u.Typedefs.P("foreach (var kv in obj)")
+		u.Typedefs.PI("{")
+		u.Typedefs.P("if (!JsonifKnownKeys.Contains(kv.Key)) this.jsonif_unknown_fields[kv.Key] = kv.Value;")
+		u.Typedefs.PD("}")
+	}
+	u.Typedefs.PD("}")
+	u.Typedefs.P("")
+	return nil
+}
+
+// ReadJson で読み込むキーの文字列リテラル
+func toKnownKeys(msg *internal.Message, opts *options) []string {
+	var keys []string
+	for _, oneof := range msg.Oneofs {
+		if oneof.Synthetic {
+			continue
 		}
+		keys = append(keys, fmt.Sprintf("\"%s_case\"", internal.ToSnakeCase(oneof.Name)))
 	}
+	for _, field := range msg.Fields {
+		keys = append(keys, fmt.Sprintf("\"%s\"", toJsonKey(field, opts)))
+	}
+	return keys
+}
+
+// jsonif_required のフィールドが設定されていない条件
+// optional は値が無い場合、メッセージ型はデフォルト値の場合に設定されていないとする
+func toMissingCondition(field *internal.Field) (string, error) {
+	value := "this." + toFieldName(field)
+	if oneof := field.Oneof; oneof != nil {
+		oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+		return fmt.Sprintf("this.%s != %s.k%s", oneofFieldName, oneofTypeName, internal.ToUpperCamel(field.Name)), nil
+	}
+	switch field.WellKnownType() {
+	case internal.WellKnownTimestamp:
+		_, defaultValue, err := toTypeName(field)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s == %s", value, defaultValue), nil
+	case internal.WellKnownDuration:
+		return value + " == System.TimeSpan.Zero", nil
+	case internal.NotWellKnown:
+	default:
+		return value + " == null", nil
+	}
+	_, defaultValue, err := toTypeName(field)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s == null || %s.Equals(%s)", value, value, defaultValue), nil
+}
 
-	for _, nested := range desc.NestedType {
-		if err := genDescriptor(nested, pkg, append(parents, desc), u); err != nil {
+// 値 expr の検証（repeated の場合は要素、map の場合は値ごとに呼ぶ）
+func genValueValidate(f *internal.Formatter, field *internal.Field, expr string, path string) error {
+	value := field.ValueField()
+	if v := field.Validation; v != nil {
+		push := func(cond string, message string) {
+			f.P("if (%s) violations.Add(new global::Jsonif.Violation(%s, %s));", cond, path, toStringLiteral(message))
+		}
+		typeName, _, err := toTypeName(value)
+		if err != nil {
 			return err
 		}
+		if len(v.Min) != 0 {
+			push(fmt.Sprintf("%s < %s", expr, toLiteral(value, v.Min, typeName)), v.MinMessage())
+		}
+		if len(v.Max) != 0 {
+			push(fmt.Sprintf("%s > %s", expr, toLiteral(value, v.Max, typeName)), v.MaxMessage())
+		}
+		length := fmt.Sprintf("global::Jsonif.Validation.StringLength(%s)", expr)
+		if value.Type == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			length = fmt.Sprintf("global::Jsonif.Validation.BytesLength(%s)", expr)
+		}
+		if v.MinLen >= 0 {
+			push(fmt.Sprintf("%s < %d", length, v.MinLen), v.MinLenMessage())
+		}
+		if v.MaxLen >= 0 {
+			push(fmt.Sprintf("%s > %d", length, v.MaxLen), v.MaxLenMessage())
+		}
+		if len(v.Pattern) != 0 {
+			push(fmt.Sprintf("!global::Jsonif.Validation.IsMatch(%s, %s)", expr, toStringLiteral(v.Pattern)), v.PatternMessage())
+		}
+		if v.EnumDefinedOnly {
+			push(fmt.Sprintf("!System.Enum.IsDefined(typeof(%s), %s)", typeName, expr), internal.EnumDefinedOnlyMessage)
+		}
 	}
+	if value.IsMessage() && value.WellKnownType() == internal.NotWellKnown && value.Message.HasValidation() {
+		f.P("if (%s != null) %s.Validate(%s, violations);", expr, expr, path)
+	}
+	return nil
+}
 
-	for i, oneof := range desc.OneofDecl {
-		var fields []*descriptorpb.FieldDescriptorProto
-		for _, field := range desc.Field {
-			if field.OneofIndex != nil && *field.OneofIndex == int32(i) {
-				fields = append(fields, field)
+// 検証ルールを満たしているかを調べて、違反を返す
+// 検証ルールのあるメッセージをフィールドに持つ場合は、そのメッセージの Validate も呼び出す
+func genValidate(msg *internal.Message, u *unityFile) error {
+	f := &u.Typedefs
+	f.P("public List<global::Jsonif.Violation> Validate()")
+	f.PI("{")
+	f.P("var violations = new List<global::Jsonif.Violation>();")
+	f.P("Validate(\"\", violations);")
+	f.P("return violations;")
+	f.PD("}")
+	f.P("public void Validate(string path, List<global::Jsonif.Violation> violations)")
+	f.PI("{")
+	for _, field := range msg.Fields {
+		fieldName := toFieldName(field)
+		path := fmt.Sprintf("global::Jsonif.Validation.Join(path, %s)", toStringLiteral(field.JsonKey))
+		// oneof（optional を含む）のフィールドは、設定されている場合だけ検証する
+		cond := ""
+		if oneof := field.Oneof; oneof != nil {
+			oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			cond = fmt.Sprintf("this.%s == %s.k%s", oneofFieldName, oneofTypeName, internal.ToUpperCamel(field.Name))
+		}
+		v := field.Validation
+		if v != nil && v.Required {
+			missing, err := toMissingCondition(field)
+			if err != nil {
+				return err
 			}
+			f.P("if (%s) violations.Add(new global::Jsonif.Violation(%s, \"%s\"));", missing, path, internal.RequiredMessage)
+		}
+		if v != nil && v.MinItems >= 0 {
+			f.P("if (this.%s.Count < %d) violations.Add(new global::Jsonif.Violation(%s, \"%s\"));", fieldName, v.MinItems, path, v.MinItemsMessage())
+		}
+		if v != nil && v.MaxItems >= 0 {
+			f.P("if (this.%s.Count > %d) violations.Add(new global::Jsonif.Violation(%s, \"%s\"));", fieldName, v.MaxItems, path, v.MaxItemsMessage())
+		}
+		value := field.ValueField()
+		if !(v != nil && v.HasValueRules()) && !(value.IsMessage() && value.WellKnownType() == internal.NotWellKnown && value.Message.HasValidation()) {
+			continue
+		}
+		if len(cond) != 0 {
+			f.P("if (%s)", cond)
+			f.PI("{")
+		}
+		var err error
+		if field.IsMap() {
+			f.P("foreach (var kv in this.%s)", fieldName)
+			f.PI("{")
+			err = genValueValidate(f, field, "kv.Value", fmt.Sprintf("global::Jsonif.Validation.Key(%s, kv.Key)", path))
+			f.PD("}")
+		} else if field.Repeated {
+			f.P("for (int i = 0; i < this.%s.Count; i++)", fieldName)
+			f.PI("{")
+			err = genValueValidate(f, field, fmt.Sprintf("this.%s[i]", fieldName), fmt.Sprintf("global::Jsonif.Validation.Index(%s, i)", path))
+			f.PD("}")
+		} else {
+			err = genValueValidate(f, field, "this."+fieldName, path)
+		}
+		if err != nil {
+			return err
+		}
+		if len(cond) != 0 {
+			f.PD("}")
+		}
+	}
+	f.PD("}")
+	f.P("")
+	return nil
+}
+
+func genDescriptor(msg *internal.Message, u *unityFile, opts *options) error {
+	genComment(&u.Typedefs, msg.Comments)
+	u.Typedefs.P("[System.Serializable]")
+	genObsolete(&u.Typedefs, msg.Deprecated)
+	u.Typedefs.P("public class %s : global::Jsonif.IJsonSerializable", escapeName(msg.Name))
+	u.Typedefs.PI("{")
+
+	for _, enum := range msg.Enums {
+		if err := genEnum(enum, u); err != nil {
+			return err
 		}
-		if err := genOneof(oneof, fields, pkg, append(parents, desc), u); err != nil {
+	}
+
+	for _, nested := range msg.Messages {
+		// map のエントリは Dictionary で表現するのでクラスを生成しない
+		if nested.MapEntry {
+			continue
+		}
+		if err := genDescriptor(nested, u, opts); err != nil {
+			return err
+		}
+	}
+
+	for _, oneof := range msg.Oneofs {
+		if err := genOneof(oneof, u); err != nil {
 			return err
 		}
 	}
 
-	for _, field := range desc.Field {
+	for _, field := range msg.Fields {
 		typeName, defaultValue, err := toTypeName(field)
 		if err != nil {
 			return err
 		}
-		fieldName := internal.ToSnakeCase(*field.Name)
+		fieldName := toFieldName(field)
+		genComment(&u.Typedefs, field.Comments)
+		genObsolete(&u.Typedefs, field.Deprecated)
 		if len(defaultValue) == 0 {
 			u.Typedefs.P("public %s %s;", typeName, fieldName)
 		} else {
 			u.Typedefs.P("public %s %s = %s;", typeName, fieldName, defaultValue)
 		}
 
-		if oneof := field.OneofIndex; oneof != nil {
-			oneofTypeName := internal.ToUpperCamel(*desc.OneofDecl[*oneof].Name) + "Case"
-			oneofFieldName := internal.ToSnakeCase(*desc.OneofDecl[*oneof].Name) + "_case"
-			u.Typedefs.P("public void Set%s(%s %s)", internal.ToUpperCamel(fieldName), typeName, fieldName)
+		if oneof := field.Oneof; oneof != nil {
+			oneofTypeName := internal.ToUpperCamel(oneof.Name) + "Case"
+			oneofFieldName := internal.ToSnakeCase(oneof.Name) + "_case"
+			// メソッド名にはエスケープする前の名前を使う
+			methodName := internal.ToUpperCamel(internal.ToSnakeCase(field.Name))
+			genObsolete(&u.Typedefs, field.Deprecated)
+			u.Typedefs.P("public void Set%s(%s %s)", methodName, typeName, fieldName)
 			u.Typedefs.PI("{")
 			u.Typedefs.P("Clear%s();", oneofTypeName)
-			u.Typedefs.P("%s = %s.k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(fieldName))
+			u.Typedefs.P("%s = %s.k%s;", oneofFieldName, oneofTypeName, methodName)
 			u.Typedefs.P("this.%s = %s;", fieldName, fieldName)
 			u.Typedefs.PD("}")
-			u.Typedefs.P("public bool Has%s()", internal.ToUpperCamel(fieldName))
+			genObsolete(&u.Typedefs, field.Deprecated)
+			u.Typedefs.P("public bool Has%s()", methodName)
 			u.Typedefs.PI("{")
-			u.Typedefs.P("return %s == %s.k%s;", oneofFieldName, oneofTypeName, internal.ToUpperCamel(fieldName))
+			u.Typedefs.P("return %s == %s.k%s;", oneofFieldName, oneofTypeName, methodName)
 			u.Typedefs.PD("}")
-			u.Typedefs.P("public void Clear%s()", internal.ToUpperCamel(fieldName))
+			genObsolete(&u.Typedefs, field.Deprecated)
+			u.Typedefs.P("public void Clear%s()", methodName)
 			u.Typedefs.PI("{")
-			u.Typedefs.P("if (%s == %s.k%s)", oneofFieldName, oneofTypeName, internal.ToUpperCamel(fieldName))
+			u.Typedefs.P("if (%s == %s.k%s)", oneofFieldName, oneofTypeName, methodName)
 			u.Typedefs.PI("{")
 			u.Typedefs.P("Clear%s();", oneofTypeName)
 			u.Typedefs.PD("}")
@@ -268,10 +998,30 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 		}
 	}
 
-	err := genEquals(desc, pkg, append(parents, desc), u)
+	if msg.KeepUnknownFields {
+		u.Typedefs.P("// JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）")
+		u.Typedefs.P("public Dictionary<string, object> jsonif_unknown_fields = new Dictionary<string, object>();")
+		if keys := toKnownKeys(msg, opts); len(keys) != 0 {
+			u.Typedefs.P("static readonly HashSet<string> JsonifKnownKeys = new HashSet<string> { %s };", strings.Join(keys, ", "))
+		} else {
+			u.Typedefs.P("static readonly HashSet<string> JsonifKnownKeys = new HashSet<string>();")
+		}
+	}
+
+	u.Messages = append(u.Messages, msg)
+
+	err := genEquals(msg, u)
 	if err != nil {
 		return err
 	}
+	if err := genSerializer(msg, u, opts); err != nil {
+		return err
+	}
+	if msg.HasValidation() {
+		if err := genValidate(msg, u); err != nil {
+			return err
+		}
+	}
 
 	u.Typedefs.PD("}")
 	u.Typedefs.P("")
@@ -279,17 +1029,24 @@ func genDescriptor(desc *descriptorpb.DescriptorProto, pkg *string, parents []*d
 	return nil
 }
 
-func genFile(file *descriptorpb.FileDescriptorProto) (*pluginpb.CodeGeneratorResponse_File, error) {
+func genFile(file *internal.File, opts *options) (*pluginpb.CodeGeneratorResponse_File, error) {
 	u := unityFile{}
 	u.Top.SetIndentUnit(4)
 	u.Bottom.SetIndentUnit(4)
 	u.Typedefs.SetIndentUnit(4)
+	u.Registry.SetIndentUnit(4)
 
+	useDeprecated := file.UsesDeprecated()
+	if useDeprecated {
+		// 生成したコード自身が deprecated な要素を参照した時の警告は出さない
+		u.Top.P("#pragma warning disable 612, 618")
+		u.Top.P("")
+	}
 	u.Top.P("using System.Collections.Generic;")
 	u.Top.P("using System.Linq;")
 
-	if file.Package != nil {
-		u.Top.P("namespace %s", packageToNamespace(*file.Package))
+	if len(file.Package) != 0 {
+		u.Top.P("namespace %s", packageToNamespace(file.Package))
 		u.Top.PI("{")
 		u.Top.P("")
 	}
@@ -297,20 +1054,44 @@ func genFile(file *descriptorpb.FileDescriptorProto) (*pluginpb.CodeGeneratorRes
 	u.Bottom.P("}")
 
 	u.Typedefs.Indent()
-	for _, enum := range file.EnumType {
-		if err := genEnum(enum, file.Package, nil, &u); err != nil {
+	for _, enum := range file.Enums {
+		if err := genEnum(enum, &u); err != nil {
 			return nil, err
 		}
 	}
-	for _, desc := range file.MessageType {
-		if err := genDescriptor(desc, file.Package, nil, &u); err != nil {
+	for _, msg := range file.Messages {
+		if err := genDescriptor(msg, &u, opts); err != nil {
 			return nil, err
 		}
 	}
 	u.Typedefs.Deindent()
 
+	// Any で使えるように、メッセージを Jsonif.TypeRegistry に登録する
+	// TypeRegistry は partial class なので、最初に使われた時に全てのファイルの登録処理が呼ばれる
+	if len(u.Messages) != 0 {
+		u.Registry.P("")
+		u.Registry.P("namespace Jsonif")
+		u.Registry.PI("{")
+		u.Registry.P("")
+		u.Registry.P("public static partial class TypeRegistry")
+		u.Registry.PI("{")
+		u.Registry.P("static readonly bool %s = Register(new Dictionary<string, System.Type>", toRegisteredFieldName(file))
+		u.Registry.PI("{")
+		for _, msg := range u.Messages {
+			u.Registry.P("{ \"%s\", typeof(global::%s) },", msg.FullName, packageToNamespace(msg.FullName))
+		}
+		u.Registry.PD("});")
+		u.Registry.PD("}")
+		u.Registry.P("")
+		u.Registry.PD("}")
+	}
+	if useDeprecated {
+		u.Registry.P("")
+		u.Registry.P("#pragma warning restore 612, 618")
+	}
+
 	// UpperCamel にして拡張子を取り除いて .cs を付ける
-	fileName := pathToUpperCamel(*file.Name)
+	fileName := pathToUpperCamel(file.Name)
 	fileName = fileName[:len(fileName)-len(filepath.Ext(fileName))]
 	fileName = fileName + ".cs"
 
@@ -326,24 +1107,804 @@ func genJsonif() (*pluginpb.CodeGeneratorResponse_File, error) {
 	f := internal.Formatter{}
 	f.SetIndentUnit(4)
 
+	f.P("using System.Collections.Generic;")
+	f.P("using System.Globalization;")
+	f.P("using System.Text;")
+	f.P("using System.Text.RegularExpressions;")
 	f.P("using UnityEngine;")
 	f.P("")
 	f.P("namespace Jsonif")
 	f.PI("{")
 	f.P("")
-	f.P("public static class Json")
+	f.P("// JsonUtility では Dictionary などを扱えないので、生成したクラスは自前でシリアライズする")
+	f.P("public interface IJsonSerializable")
 	f.PI("{")
-	f.P("public static string ToJson<T>(T v)")
-	f.PI("{")
-	f.P("return JsonUtility.ToJson(v);")
+	f.P("void WriteJson(JsonWriter w);")
+	f.P("void ReadJson(object json);")
 	f.PD("}")
-	f.P("public static T FromJson<T>(string s)")
+	f.P("")
+	f.P("public class JsonNumber")
 	f.PI("{")
-	f.P("return JsonUtility.FromJson<T>(s);")
+	f.P("public readonly string Text;")
+	f.P("public JsonNumber(string text)")
+	f.PI("{")
+	f.P("Text = text;")
 	f.PD("}")
 	f.PD("}")
 	f.P("")
-	f.PD("}")
+	f.P("// メッセージの完全修飾名と型の対応表")
+	f.P("// 生成したファイルごとに、partial class の静的フィールドの初期化でメッセージを登録する")
+	f.P("public static partial class TypeRegistry")
+	f.PI("{")
+	f.P("// 静的フィールドの初期化の順序はファイルごとに不定なので、初期化子は書かずに Register で作る")
+	f.P("static Dictionary<string, System.Type> types;")
+	f.P("static Dictionary<System.Type, string> names;")
+	f.P("")
+	f.P("static bool Register(Dictionary<string, System.Type> ts)")
+	f.PI("{")
+	f.P("if (types == null)")
+	f.PI("{")
+	f.P("types = new Dictionary<string, System.Type>();")
+	f.P("names = new Dictionary<System.Type, string>();")
+	f.PD("}")
+	f.P("foreach (var kv in ts)")
+	f.PI("{")
+	f.P("types[kv.Key] = kv.Value;")
+	f.P("names[kv.Value] = kv.Key;")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("// 登録されていない場合は null を返す")
+	f.P("public static System.Type Find(string name)")
+	f.PI("{")
+	f.P("System.Type t;")
+	f.P("if (types == null || !types.TryGetValue(name, out t)) return null;")
+	f.P("return t;")
+	f.PD("}")
+	f.P("public static string GetName(System.Type t)")
+	f.PI("{")
+	f.P("string name;")
+	f.P(`if (names == null || !names.TryGetValue(t, out name)) throw new System.InvalidOperationException(t + " is not registered");`)
+	f.P("return name;")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Any")
+	f.P("// \"@type\" を含む JSON のオブジェクトをそのまま保持する")
+	f.P("public class Any")
+	f.PI("{")
+	f.P("public readonly Dictionary<string, object> Value;")
+	f.P("")
+	f.P("public Any(Dictionary<string, object> value)")
+	f.PI("{")
+	f.P(`if (!(value["@type"] is string)) throw new System.ArgumentException("@type must be a string");`)
+	f.P("Value = value;")
+	f.PD("}")
+	f.P("")
+	f.P("public string TypeUrl { get { return (string)Value[\"@type\"]; } }")
+	f.P("// 型の URL から取り出したメッセージの完全修飾名")
+	f.P("public string TypeName { get { return TypeUrl.Substring(TypeUrl.LastIndexOf('/') + 1); } }")
+	f.P("")
+	f.P("// メッセージを Any に詰める")
+	f.P("public static Any Pack(IJsonSerializable v)")
+	f.PI("{")
+	f.P("var value = new Dictionary<string, object>();")
+	f.P(`value["@type"] = "type.googleapis.com/" + TypeRegistry.GetName(v.GetType());`)
+	f.P("var w = new JsonWriter();")
+	f.P("v.WriteJson(w);")
+	f.P("foreach (var kv in (Dictionary<string, object>)JsonReader.Parse(w.ToString())) value[kv.Key] = kv.Value;")
+	f.P("return new Any(value);")
+	f.PD("}")
+	f.P("public bool Is<T>() where T : IJsonSerializable")
+	f.PI("{")
+	f.P("return TypeName == TypeRegistry.GetName(typeof(T));")
+	f.PD("}")
+	f.P("// T ではない場合は InvalidOperationException を投げる")
+	f.P("public T Unpack<T>() where T : IJsonSerializable, new()")
+	f.PI("{")
+	f.P(`if (!Is<T>()) throw new System.InvalidOperationException("type mismatch: " + TypeName);`)
+	f.P("return JsonReader.ReadObject<T>(Value);")
+	f.PD("}")
+	f.P("// TypeRegistry から型を探して取り出す")
+	f.P("public IJsonSerializable Unpack()")
+	f.PI("{")
+	f.P("var t = TypeRegistry.Find(TypeName);")
+	f.P(`if (t == null) throw new System.InvalidOperationException("unknown type: " + TypeName);`)
+	f.P("var v = (IJsonSerializable)System.Activator.CreateInstance(t);")
+	f.P("v.ReadJson(Value);")
+	f.P("return v;")
+	f.PD("}")
+	f.P("")
+	f.P("public override bool Equals(object obj)")
+	f.PI("{")
+	f.P("var v = obj as Any;")
+	f.P("return v != null && Json.ValueEquals(Value, v.Value);")
+	f.PD("}")
+	f.P("public override int GetHashCode()")
+	f.PI("{")
+	f.P("return Json.ValueHashCode(Value);")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// google.protobuf.Struct, ListValue, Value の値を中身で比較する")
+	f.P("public class JsonValueComparer<T> : IEqualityComparer<T>")
+	f.PI("{")
+	f.P("public static readonly JsonValueComparer<T> Instance = new JsonValueComparer<T>();")
+	f.P("public bool Equals(T a, T b) { return Json.ValueEquals(a, b); }")
+	f.P("public int GetHashCode(T v) { return Json.ValueHashCode(v); }")
+	f.PD("}")
+	f.P("")
+	f.P("// bytes の値（byte[]）を中身で比較する")
+	f.P("public class BytesComparer : IEqualityComparer<byte[]>")
+	f.PI("{")
+	f.P("public static readonly BytesComparer Instance = new BytesComparer();")
+	f.P("public bool Equals(byte[] a, byte[] b) { return Json.BytesEquals(a, b); }")
+	f.P("public int GetHashCode(byte[] v) { return Json.BytesHashCode(v); }")
+	f.PD("}")
+	f.P("")
+	f.P("public class JsonWriter")
+	f.PI("{")
+	f.P("StringBuilder sb = new StringBuilder();")
+	f.P("bool comma = false;")
+	f.P("")
+	f.P("void Separate()")
+	f.PI("{")
+	f.P("if (comma) sb.Append(',');")
+	f.P("comma = false;")
+	f.PD("}")
+	f.P("void WriteString(string v)")
+	f.PI("{")
+	f.P(`sb.Append('"');`)
+	f.P("foreach (var c in v)")
+	f.PI("{")
+	f.P("switch (c)")
+	f.PI("{")
+	f.P(`case '"': sb.Append("\\\""); break;`)
+	f.P(`case '\\': sb.Append("\\\\"); break;`)
+	f.P(`case '\b': sb.Append("\\b"); break;`)
+	f.P(`case '\f': sb.Append("\\f"); break;`)
+	f.P(`case '\n': sb.Append("\\n"); break;`)
+	f.P(`case '\r': sb.Append("\\r"); break;`)
+	f.P(`case '\t': sb.Append("\\t"); break;`)
+	f.PI("default:")
+	f.P("if (c < 0x20)")
+	f.PI("{")
+	f.P(`sb.Append("\\u");`)
+	f.P(`sb.Append(((int)c).ToString("x4"));`)
+	f.PD("}")
+	f.P("else")
+	f.PI("{")
+	f.P("sb.Append(c);")
+	f.PD("}")
+	f.P("break;")
+	f.Deindent()
+	f.PD("}")
+	f.PD("}")
+	f.P(`sb.Append('"');`)
+	f.PD("}")
+	f.P("void WriteRaw(string v)")
+	f.PI("{")
+	f.P("Separate();")
+	f.P("sb.Append(v);")
+	f.P("comma = true;")
+	f.PD("}")
+	f.P("")
+	f.P("public void BeginObject()")
+	f.PI("{")
+	f.P("Separate();")
+	f.P("sb.Append('{');")
+	f.PD("}")
+	f.P("public void EndObject()")
+	f.PI("{")
+	f.P("sb.Append('}');")
+	f.P("comma = true;")
+	f.PD("}")
+	f.P("public void BeginArray()")
+	f.PI("{")
+	f.P("Separate();")
+	f.P("sb.Append('[');")
+	f.PD("}")
+	f.P("public void EndArray()")
+	f.PI("{")
+	f.P("sb.Append(']');")
+	f.P("comma = true;")
+	f.PD("}")
+	f.P("public void Key(string k)")
+	f.PI("{")
+	f.P("Separate();")
+	f.P("WriteString(k);")
+	f.P("sb.Append(':');")
+	f.PD("}")
+	f.P("// JSON のキーは文字列なので、map のキーは文字列に変換する")
+	f.P(`public void Key(bool k) { Key(k ? "true" : "false"); }`)
+	f.P("public void Key(int k) { Key(k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Key(uint k) { Key(k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Key(long k) { Key(k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Key(ulong k) { Key(k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Write(string v)")
+	f.PI("{")
+	f.P("Separate();")
+	f.P(`WriteString(v ?? "");`)
+	f.P("comma = true;")
+	f.PD("}")
+	f.P(`public void Write(bool v) { WriteRaw(v ? "true" : "false"); }`)
+	f.P("public void Write(int v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Write(uint v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Write(long v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void Write(ulong v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }")
+	f.P(`public void Write(float v) { WriteRaw(v.ToString("R", CultureInfo.InvariantCulture)); }`)
+	f.P(`public void Write(double v) { WriteRaw(v.ToString("R", CultureInfo.InvariantCulture)); }`)
+	f.P(`public void WriteNull() { WriteRaw("null"); }`)
+	f.P("// ラッパー型（google.protobuf.Int32Value など）の値が無い場合は null を書き出す")
+	f.P("public void Write(bool? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void Write(int? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void Write(uint? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void Write(long? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void Write(ulong? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void Write(float? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void Write(double? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }")
+	f.P("public void WriteNullable(string v) { if (v != null) Write(v); else WriteNull(); }")
+	f.P("// bytes はパディング付きの base64 の文字列にする")
+	f.P("public void Write(byte[] v) { Write(System.Convert.ToBase64String(v ?? new byte[0])); }")
+	f.P("// base64url の場合は URL セーフな base64（パディング無し）にする")
+	f.P("public void WriteBase64Url(byte[] v) { Write(System.Convert.ToBase64String(v ?? new byte[0]).TrimEnd('=').Replace('+', '-').Replace('/', '_')); }")
+	f.P("public void WriteNullable(byte[] v) { if (v != null) Write(v); else WriteNull(); }")
+	f.P("public void WriteNullableBase64Url(byte[] v) { if (v != null) WriteBase64Url(v); else WriteNull(); }")
+	f.P("// canonical の場合、int64 や uint64 は文字列にする")
+	f.P("public void WriteAsString(long v) { Write(v.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void WriteAsString(ulong v) { Write(v.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public void WriteAsString(long? v) { if (v.HasValue) WriteAsString(v.Value); else WriteNull(); }")
+	f.P("public void WriteAsString(ulong? v) { if (v.HasValue) WriteAsString(v.Value); else WriteNull(); }")
+	f.P("// canonical の場合、enum は値の名前にする（未知の値は数値のまま）")
+	f.P("public void WriteEnum<T>(T v) where T : struct")
+	f.PI("{")
+	f.P("if (System.Enum.IsDefined(typeof(T), v)) Write(v.ToString());")
+	f.P("else Write(System.Convert.ToInt32(v));")
+	f.PD("}")
+	f.P("// google.protobuf.Timestamp は RFC 3339 形式の UTC の文字列にする")
+	f.P("// DateTimeKind.Unspecified の場合は UTC として扱う")
+	f.P("public void Write(System.DateTime v)")
+	f.PI("{")
+	f.P("if (v.Kind == System.DateTimeKind.Local) v = v.ToUniversalTime();")
+	f.P("long nanos = v.Ticks %% System.TimeSpan.TicksPerSecond * 100;")
+	f.P(`Write(v.ToString("yyyy-MM-dd'T'HH:mm:ss", CultureInfo.InvariantCulture) + FormatNanos(nanos) + "Z");`)
+	f.PD("}")
+	f.P(`// google.protobuf.Duration は "1.5s" のような文字列にする`)
+	f.P("public void Write(System.TimeSpan v)")
+	f.PI("{")
+	f.P("ulong ticks = v.Ticks < 0 ? (ulong)(-(v.Ticks + 1)) + 1 : (ulong)v.Ticks;")
+	f.P("ulong seconds = ticks / System.TimeSpan.TicksPerSecond;")
+	f.P("long nanos = (long)(ticks %% System.TimeSpan.TicksPerSecond) * 100;")
+	f.P(`Write((v.Ticks < 0 ? "-" : "") + seconds.ToString(CultureInfo.InvariantCulture) + FormatNanos(nanos) + "s");`)
+	f.PD("}")
+	f.P("// 小数部は 0, 3, 6, 9 桁のいずれかにする")
+	f.P("static string FormatNanos(long nanos)")
+	f.PI("{")
+	f.P(`if (nanos == 0) return "";`)
+	f.P(`if (nanos %% 1000000 == 0) return "." + (nanos / 1000000).ToString("D3", CultureInfo.InvariantCulture);`)
+	f.P(`if (nanos %% 1000 == 0) return "." + (nanos / 1000).ToString("D6", CultureInfo.InvariantCulture);`)
+	f.P(`return "." + nanos.ToString("D9", CultureInfo.InvariantCulture);`)
+	f.PD("}")
+	f.P("// google.protobuf.Struct, ListValue, Value の値を書き出す")
+	f.P("// JsonReader が返すのと同じ型の木に加えて、数値型もそのまま書き出せる")
+	f.P("public void WriteValue(object v)")
+	f.PI("{")
+	f.P("if (v == null) { WriteNull(); return; }")
+	f.P("var s = v as string;")
+	f.P("if (s != null) { Write(s); return; }")
+	f.P("if (v is bool) { Write((bool)v); return; }")
+	f.P("var n = v as JsonNumber;")
+	f.P("if (n != null) { WriteRaw(n.Text); return; }")
+	f.P("var obj = v as Dictionary<string, object>;")
+	f.P("if (obj != null)")
+	f.PI("{")
+	f.P("BeginObject();")
+	f.P("foreach (var kv in obj)")
+	f.PI("{")
+	f.P("Key(kv.Key);")
+	f.P("WriteValue(kv.Value);")
+	f.PD("}")
+	f.P("EndObject();")
+	f.P("return;")
+	f.PD("}")
+	f.P("var arr = v as List<object>;")
+	f.P("if (arr != null)")
+	f.PI("{")
+	f.P("BeginArray();")
+	f.P("foreach (var x in arr) WriteValue(x);")
+	f.P("EndArray();")
+	f.P("return;")
+	f.PD("}")
+	f.P("if (v is double) { Write((double)v); return; }")
+	f.P("if (v is float) { Write((float)v); return; }")
+	f.P("if (Json.IsNumber(v)) { WriteRaw(System.Convert.ToString(v, CultureInfo.InvariantCulture)); return; }")
+	f.P(`throw new System.ArgumentException("unsupported json value: " + v.GetType());`)
+	f.PD("}")
+	f.P("public void Write(Any v) { if (v != null) WriteValue(v.Value); else WriteNull(); }")
+	f.P("public void Write(IJsonSerializable v)")
+	f.PI("{")
+	f.P("if (v == null)")
+	f.PI("{")
+	f.P("BeginObject();")
+	f.P("EndObject();")
+	f.PD("}")
+	f.P("else")
+	f.PI("{")
+	f.P("v.WriteJson(this);")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("public override string ToString()")
+	f.PI("{")
+	f.P("return sb.ToString();")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// JSON を Dictionary<string, object>, List<object>, string, JsonNumber, bool, null の木に変換して読み込む")
+	f.P("public static class JsonReader")
+	f.PI("{")
+	f.P("public static object Parse(string s)")
+	f.PI("{")
+	f.P("int i = 0;")
+	f.P("var v = ParseValue(s, ref i);")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P(`if (i != s.Length) throw new System.FormatException("unexpected character at " + i);`)
+	f.P("return v;")
+	f.PD("}")
+	f.P("")
+	f.P("static void SkipWhitespace(string s, ref int i)")
+	f.PI("{")
+	f.P(`while (i < s.Length && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r')) i++;`)
+	f.PD("}")
+	f.P("static void Expect(string s, ref int i, char c)")
+	f.PI("{")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P(`if (i >= s.Length || s[i] != c) throw new System.FormatException("expected '" + c + "' at " + i);`)
+	f.P("i++;")
+	f.PD("}")
+	f.P("static bool Consume(string s, ref int i, string word)")
+	f.PI("{")
+	f.P("if (string.CompareOrdinal(s, i, word, 0, word.Length) != 0) return false;")
+	f.P("i += word.Length;")
+	f.P("return true;")
+	f.PD("}")
+	f.P("static object ParseValue(string s, ref int i)")
+	f.PI("{")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P(`if (i >= s.Length) throw new System.FormatException("unexpected end of json");`)
+	f.P("char c = s[i];")
+	f.P("if (c == '{')")
+	f.PI("{")
+	f.P("i++;")
+	f.P("var obj = new Dictionary<string, object>();")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P("if (i < s.Length && s[i] == '}')")
+	f.PI("{")
+	f.P("i++;")
+	f.P("return obj;")
+	f.PD("}")
+	f.P("while (true)")
+	f.PI("{")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P("var key = ParseString(s, ref i);")
+	f.P("Expect(s, ref i, ':');")
+	f.P("obj[key] = ParseValue(s, ref i);")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P("if (i < s.Length && s[i] == ',')")
+	f.PI("{")
+	f.P("i++;")
+	f.P("continue;")
+	f.PD("}")
+	f.P("Expect(s, ref i, '}');")
+	f.P("return obj;")
+	f.PD("}")
+	f.PD("}")
+	f.P("if (c == '[')")
+	f.PI("{")
+	f.P("i++;")
+	f.P("var arr = new List<object>();")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P("if (i < s.Length && s[i] == ']')")
+	f.PI("{")
+	f.P("i++;")
+	f.P("return arr;")
+	f.PD("}")
+	f.P("while (true)")
+	f.PI("{")
+	f.P("arr.Add(ParseValue(s, ref i));")
+	f.P("SkipWhitespace(s, ref i);")
+	f.P("if (i < s.Length && s[i] == ',')")
+	f.PI("{")
+	f.P("i++;")
+	f.P("continue;")
+	f.PD("}")
+	f.P("Expect(s, ref i, ']');")
+	f.P("return arr;")
+	f.PD("}")
+	f.PD("}")
+	f.P(`if (c == '"') return ParseString(s, ref i);`)
+	f.P(`if (Consume(s, ref i, "true")) return true;`)
+	f.P(`if (Consume(s, ref i, "false")) return false;`)
+	f.P(`if (Consume(s, ref i, "null")) return null;`)
+	f.P("int start = i;")
+	f.P(`while (i < s.Length && "+-0123456789.eE".IndexOf(s[i]) >= 0) i++;`)
+	f.P(`if (start == i) throw new System.FormatException("unexpected character at " + i);`)
+	f.P("return new JsonNumber(s.Substring(start, i - start));")
+	f.PD("}")
+	f.P("static string ParseString(string s, ref int i)")
+	f.PI("{")
+	f.P(`Expect(s, ref i, '"');`)
+	f.P("var sb = new StringBuilder();")
+	f.P("while (true)")
+	f.PI("{")
+	f.P(`if (i >= s.Length) throw new System.FormatException("unterminated string");`)
+	f.P("char c = s[i++];")
+	f.P(`if (c == '"') return sb.ToString();`)
+	f.P(`if (c != '\\')`)
+	f.PI("{")
+	f.P("sb.Append(c);")
+	f.P("continue;")
+	f.PD("}")
+	f.P(`if (i >= s.Length) throw new System.FormatException("unterminated string");`)
+	f.P("c = s[i++];")
+	f.P("switch (c)")
+	f.PI("{")
+	f.P(`case '"': sb.Append('"'); break;`)
+	f.P(`case '\\': sb.Append('\\'); break;`)
+	f.P("case '/': sb.Append('/'); break;")
+	f.P(`case 'b': sb.Append('\b'); break;`)
+	f.P(`case 'f': sb.Append('\f'); break;`)
+	f.P(`case 'n': sb.Append('\n'); break;`)
+	f.P(`case 'r': sb.Append('\r'); break;`)
+	f.P(`case 't': sb.Append('\t'); break;`)
+	f.PI("case 'u':")
+	f.P(`if (i + 4 > s.Length) throw new System.FormatException("invalid escape at " + i);`)
+	f.P("sb.Append((char)int.Parse(s.Substring(i, 4), NumberStyles.HexNumber, CultureInfo.InvariantCulture));")
+	f.P("i += 4;")
+	f.P("break;")
+	f.Deindent()
+	f.PI("default:")
+	f.P(`throw new System.FormatException("invalid escape at " + i);`)
+	f.Deindent()
+	f.PD("}")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// 数値は JsonNumber、map のキーは string で渡される")
+	f.P("static string NumberText(object v)")
+	f.PI("{")
+	f.P("var n = v as JsonNumber;")
+	f.P("if (n != null) return n.Text;")
+	f.P("var s = v as string;")
+	f.P("if (s != null) return s;")
+	f.P(`throw new System.FormatException("expected number");`)
+	f.PD("}")
+	f.P("public static int ReadInt(object v) { return v == null ? 0 : int.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }")
+	f.P("public static uint ReadUInt(object v) { return v == null ? 0 : uint.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }")
+	f.P("public static long ReadLong(object v) { return v == null ? 0 : long.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }")
+	f.P("public static ulong ReadULong(object v) { return v == null ? 0 : ulong.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }")
+	f.P("public static float ReadFloat(object v) { return v == null ? 0 : float.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }")
+	f.P("public static double ReadDouble(object v) { return v == null ? 0 : double.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }")
+	f.P("public static bool ReadBool(object v)")
+	f.PI("{")
+	f.P("if (v == null) return false;")
+	f.P("if (v is bool) return (bool)v;")
+	f.P("var s = v as string;")
+	f.P(`if (s == "true") return true;`)
+	f.P(`if (s == "false") return false;`)
+	f.P(`throw new System.FormatException("expected bool");`)
+	f.PD("}")
+	f.P("public static string ReadString(object v)")
+	f.PI("{")
+	f.P(`if (v == null) return "";`)
+	f.P("var s = v as string;")
+	f.P(`if (s == null) throw new System.FormatException("expected string");`)
+	f.P("return s;")
+	f.PD("}")
+	f.P("// 通常の base64 と URL セーフな base64 のどちらも読み込める（パディングは省略できる）")
+	f.P("public static byte[] ReadBytes(object v)")
+	f.PI("{")
+	f.P("if (v == null) return new byte[0];")
+	f.P("var s = ReadString(v);")
+	f.P("var t = s.Replace('-', '+').Replace('_', '/');")
+	f.P("if (t.Length %% 4 == 2) t += \"==\";")
+	f.P("else if (t.Length %% 4 == 3) t += \"=\";")
+	f.P("try")
+	f.PI("{")
+	f.P("return System.Convert.FromBase64String(t);")
+	f.PD("}")
+	f.P("catch (System.FormatException)")
+	f.PI("{")
+	f.P(`throw new System.FormatException("invalid base64: " + s);`)
+	f.PD("}")
+	f.PD("}")
+	f.P(`static readonly Regex TimestampPattern = new Regex(@"^([0-9]{4})-([0-9]{2})-([0-9]{2})[Tt]([0-9]{2}):([0-9]{2}):([0-9]{2})(?:\.([0-9]{1,9}))?([Zz]|[+-][0-9]{2}:[0-9]{2})$");`)
+	f.P(`static readonly Regex DurationPattern = new Regex(@"^(-?)([0-9]+)(?:\.([0-9]{1,9}))?s$");`)
+	f.P("// 小数部を 100 ナノ秒単位の値にする（それより細かい部分は切り捨てる）")
+	f.P("static long FractionTicks(string s)")
+	f.PI("{")
+	f.P("if (s.Length == 0) return 0;")
+	f.P(`return long.Parse((s + "000000").Substring(0, 7), CultureInfo.InvariantCulture);`)
+	f.PD("}")
+	f.P("static int ParseInt(Group g) { return int.Parse(g.Value, CultureInfo.InvariantCulture); }")
+	f.P("public static System.DateTime ReadTimestamp(object v)")
+	f.PI("{")
+	f.P("if (v == null) return new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc);")
+	f.P("var s = ReadString(v);")
+	f.P("var m = TimestampPattern.Match(s);")
+	f.P(`if (!m.Success) throw new System.FormatException("invalid google.protobuf.Timestamp: " + s);`)
+	f.P("System.DateTime t;")
+	f.P("try")
+	f.PI("{")
+	f.P("t = new System.DateTime(ParseInt(m.Groups[1]), ParseInt(m.Groups[2]), ParseInt(m.Groups[3]), ParseInt(m.Groups[4]), ParseInt(m.Groups[5]), ParseInt(m.Groups[6]), System.DateTimeKind.Utc);")
+	f.P("t = t.AddTicks(FractionTicks(m.Groups[7].Value));")
+	f.P("var offset = m.Groups[8].Value;")
+	f.P(`if (offset != "Z" && offset != "z")`)
+	f.PI("{")
+	f.P("var d = new System.TimeSpan(int.Parse(offset.Substring(1, 2), CultureInfo.InvariantCulture), int.Parse(offset.Substring(4, 2), CultureInfo.InvariantCulture), 0);")
+	f.P("t = offset[0] == '+' ? t - d : t + d;")
+	f.PD("}")
+	f.PD("}")
+	f.P("catch (System.ArgumentOutOfRangeException)")
+	f.PI("{")
+	f.P(`throw new System.FormatException("invalid google.protobuf.Timestamp: " + s);`)
+	f.PD("}")
+	f.P("return t;")
+	f.PD("}")
+	f.P("public static System.TimeSpan ReadDuration(object v)")
+	f.PI("{")
+	f.P("if (v == null) return System.TimeSpan.Zero;")
+	f.P("var s = ReadString(v);")
+	f.P("var m = DurationPattern.Match(s);")
+	f.P(`if (!m.Success) throw new System.FormatException("invalid google.protobuf.Duration: " + s);`)
+	f.P("long ticks = checked(long.Parse(m.Groups[2].Value, CultureInfo.InvariantCulture) * System.TimeSpan.TicksPerSecond + FractionTicks(m.Groups[3].Value));")
+	f.P(`return new System.TimeSpan(m.Groups[1].Value == "-" ? -ticks : ticks);`)
+	f.PD("}")
+	f.P("public static Dictionary<string, object> ReadStruct(object v)")
+	f.PI("{")
+	f.P("if (v == null) return null;")
+	f.P("var obj = v as Dictionary<string, object>;")
+	f.P(`if (obj == null) throw new System.FormatException("expected object");`)
+	f.P("return obj;")
+	f.PD("}")
+	f.P("public static List<object> ReadListValue(object v)")
+	f.PI("{")
+	f.P("if (v == null) return null;")
+	f.P("var arr = v as List<object>;")
+	f.P(`if (arr == null) throw new System.FormatException("expected array");`)
+	f.P("return arr;")
+	f.PD("}")
+	f.P("public static Any ReadAny(object v)")
+	f.PI("{")
+	f.P("if (v == null) return null;")
+	f.P("var obj = v as Dictionary<string, object>;")
+	f.P(`if (obj == null) throw new System.FormatException("expected object");`)
+	f.P("object type;")
+	f.P(`if (!obj.TryGetValue("@type", out type) || !(type is string)) throw new System.FormatException("invalid google.protobuf.Any: @type is required");`)
+	f.P("return new Any(obj);")
+	f.PD("}")
+	f.P("// 名前と数値のどちらでも読み込める。未知の名前は 0 にする")
+	f.P("public static T ReadEnum<T>(object v) where T : struct")
+	f.PI("{")
+	f.P("var s = v as string;")
+	f.P("if (s == null) return (T)System.Enum.ToObject(typeof(T), ReadInt(v));")
+	f.P("T r;")
+	f.P("if (System.Enum.TryParse(s, out r) && System.Enum.IsDefined(typeof(T), r)) return r;")
+	f.P("return default(T);")
+	f.PD("}")
+	f.P("public static T ReadObject<T>(object v) where T : IJsonSerializable, new()")
+	f.PI("{")
+	f.P("var r = new T();")
+	f.P("if (v != null) r.ReadJson(v);")
+	f.P("return r;")
+	f.PD("}")
+	f.P("public static List<T> ReadList<T>(object v, System.Func<object, T> read)")
+	f.PI("{")
+	f.P("var r = new List<T>();")
+	f.P("if (v == null) return r;")
+	f.P("var arr = v as List<object>;")
+	f.P(`if (arr == null) throw new System.FormatException("expected array");`)
+	f.P("foreach (var x in arr) r.Add(read(x));")
+	f.P("return r;")
+	f.PD("}")
+	f.P("public static Dictionary<K, V> ReadDictionary<K, V>(object v, System.Func<object, K> readKey, System.Func<object, V> read)")
+	f.PI("{")
+	f.P("var r = new Dictionary<K, V>();")
+	f.P("if (v == null) return r;")
+	f.P("var obj = v as Dictionary<string, object>;")
+	f.P(`if (obj == null) throw new System.FormatException("expected object");`)
+	f.P("foreach (var kv in obj) r[readKey(kv.Key)] = read(kv.Value);")
+	f.P("return r;")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// 検証ルールの違反")
+	f.P("public class Violation")
+	f.PI("{")
+	f.P("// JSON でのフィールドの位置（people[3].name など）")
+	f.P("public readonly string Path;")
+	f.P("public readonly string Message;")
+	f.P("public Violation(string path, string message)")
+	f.PI("{")
+	f.P("Path = path;")
+	f.P("Message = message;")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// 生成したクラスの Validate で使う関数")
+	f.P("public static class Validation")
+	f.PI("{")
+	f.P("public static string Join(string path, string key)")
+	f.PI("{")
+	f.P(`return path == "" ? key : path + "." + key;`)
+	f.PD("}")
+	f.P("public static string Index(string path, int i)")
+	f.PI("{")
+	f.P(`return path + "[" + i.ToString(CultureInfo.InvariantCulture) + "]";`)
+	f.PD("}")
+	f.P("public static string Key(string path, string k)")
+	f.PI("{")
+	f.P(`return path + "[\"" + k + "\"]";`)
+	f.PD("}")
+	f.P(`public static string Key(string path, bool k) { return Key(path, k ? "true" : "false"); }`)
+	f.P("public static string Key(string path, int k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public static string Key(string path, uint k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public static string Key(string path, long k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("public static string Key(string path, ulong k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }")
+	f.P("// 文字列のコードポイント数（サロゲートペアは 1 文字として数える）")
+	f.P("public static int StringLength(string s)")
+	f.PI("{")
+	f.P("if (s == null) return 0;")
+	f.P("int n = 0;")
+	f.P("foreach (var c in s) if (!char.IsLowSurrogate(c)) n++;")
+	f.P("return n;")
+	f.PD("}")
+	f.P("// bytes の長さはバイト数")
+	f.P("public static int BytesLength(byte[] v)")
+	f.PI("{")
+	f.P("return v == null ? 0 : v.Length;")
+	f.PD("}")
+	f.P("// パターンは ECMAScript の構文で、マッチする部分を含んでいれば true")
+	f.P("public static bool IsMatch(string s, string pattern)")
+	f.PI("{")
+	f.P(`return Regex.IsMatch(s ?? "", pattern, RegexOptions.ECMAScript);`)
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("// report_deprecated を指定して生成したクラスで、deprecated なフィールドのキーが JSON にあった時の通知")
+	f.P("public static class DeprecatedField")
+	f.PI("{")
+	f.P("static long count;")
+	f.P("// メッセージの完全修飾名と JSON のキーを受け取る")
+	f.P("public static System.Action<string, string> Handler;")
+	f.P("// deprecated なフィールドのキーが JSON にあった回数")
+	f.P("public static long Count { get { return System.Threading.Interlocked.Read(ref count); } }")
+	f.P("public static void ResetCount() { System.Threading.Interlocked.Exchange(ref count, 0); }")
+	f.P("public static void Report(string message, string key)")
+	f.PI("{")
+	f.P("System.Threading.Interlocked.Increment(ref count);")
+	f.P("var handler = Handler;")
+	f.P("if (handler != null) handler(message, key);")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.P("public static class Json")
+	f.PI("{")
+	f.P("public static string ToJson<T>(T v)")
+	f.PI("{")
+	f.P("var s = v as IJsonSerializable;")
+	f.P("if (s != null)")
+	f.PI("{")
+	f.P("var w = new JsonWriter();")
+	f.P("s.WriteJson(w);")
+	f.P("return w.ToString();")
+	f.PD("}")
+	f.P("return JsonUtility.ToJson(v);")
+	f.PD("}")
+	f.P("public static T FromJson<T>(string s)")
+	f.PI("{")
+	f.P("if (typeof(IJsonSerializable).IsAssignableFrom(typeof(T)))")
+	f.PI("{")
+	f.P("var v = (IJsonSerializable)System.Activator.CreateInstance(typeof(T));")
+	f.P("v.ReadJson(JsonReader.Parse(s));")
+	f.P("return (T)v;")
+	f.PD("}")
+	f.P("return JsonUtility.FromJson<T>(s);")
+	f.PD("}")
+	f.P("")
+	f.P("// Dictionary の比較（要素の順序は無視する）")
+	f.P("public static bool DictionaryEquals<K, V>(Dictionary<K, V> a, Dictionary<K, V> b)")
+	f.PI("{")
+	f.P("return DictionaryEquals(a, b, EqualityComparer<V>.Default);")
+	f.PD("}")
+	f.P("public static bool DictionaryEquals<K, V>(Dictionary<K, V> a, Dictionary<K, V> b, IEqualityComparer<V> comparer)")
+	f.PI("{")
+	f.P("if (a.Count != b.Count) return false;")
+	f.P("foreach (var kv in a)")
+	f.PI("{")
+	f.P("V v;")
+	f.P("if (!b.TryGetValue(kv.Key, out v)) return false;")
+	f.P("if (!comparer.Equals(kv.Value, v)) return false;")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("")
+	f.P("// byte[] の比較（要素を比較する）")
+	f.P("public static bool BytesEquals(byte[] a, byte[] b)")
+	f.PI("{")
+	f.P("if (a == null || b == null) return a == null && b == null;")
+	f.P("if (a.Length != b.Length) return false;")
+	f.P("for (int i = 0; i < a.Length; i++)")
+	f.PI("{")
+	f.P("if (a[i] != b[i]) return false;")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("public static int BytesHashCode(byte[] v)")
+	f.PI("{")
+	f.P("if (v == null) return 0;")
+	f.P("int hashcode = 1430287;")
+	f.P("foreach (var x in v) hashcode = hashcode * 7302013 ^ x;")
+	f.P("return hashcode;")
+	f.PD("}")
+	f.P("")
+	f.P("// JSON の値の比較（数値は double にして比較する）")
+	f.P("public static bool IsNumber(object v)")
+	f.PI("{")
+	f.P("return v is JsonNumber || v is double || v is float || v is decimal || v is int || v is uint || v is long || v is ulong || v is short || v is ushort || v is byte || v is sbyte;")
+	f.PD("}")
+	f.P("static double ToDouble(object v)")
+	f.PI("{")
+	f.P("var n = v as JsonNumber;")
+	f.P("if (n != null) return double.Parse(n.Text, NumberStyles.Float, CultureInfo.InvariantCulture);")
+	f.P("return System.Convert.ToDouble(v, CultureInfo.InvariantCulture);")
+	f.PD("}")
+	f.P("public static bool ValueEquals(object a, object b)")
+	f.PI("{")
+	f.P("if (a == null || b == null) return a == null && b == null;")
+	f.P("if (IsNumber(a) && IsNumber(b)) return ToDouble(a) == ToDouble(b);")
+	f.P("var aobj = a as Dictionary<string, object>;")
+	f.P("if (aobj != null)")
+	f.PI("{")
+	f.P("var bobj = b as Dictionary<string, object>;")
+	f.P("return bobj != null && DictionaryEquals(aobj, bobj, JsonValueComparer<object>.Instance);")
+	f.PD("}")
+	f.P("var aarr = a as List<object>;")
+	f.P("if (aarr != null)")
+	f.PI("{")
+	f.P("var barr = b as List<object>;")
+	f.P("if (barr == null || aarr.Count != barr.Count) return false;")
+	f.P("for (int i = 0; i < aarr.Count; i++)")
+	f.PI("{")
+	f.P("if (!ValueEquals(aarr[i], barr[i])) return false;")
+	f.PD("}")
+	f.P("return true;")
+	f.PD("}")
+	f.P("return a.Equals(b);")
+	f.PD("}")
+	f.P("public static int ValueHashCode(object v)")
+	f.PI("{")
+	f.P("if (v == null) return 0;")
+	f.P("if (IsNumber(v)) return ToDouble(v).GetHashCode();")
+	f.P("int hashcode = 1430287;")
+	f.P("var obj = v as Dictionary<string, object>;")
+	f.P("if (obj != null)")
+	f.PI("{")
+	f.P("foreach (var kv in obj) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ ValueHashCode(kv.Value));")
+	f.P("return hashcode;")
+	f.PD("}")
+	f.P("var arr = v as List<object>;")
+	f.P("if (arr != null)")
+	f.PI("{")
+	f.P("foreach (var x in arr) hashcode = hashcode * 7302013 ^ ValueHashCode(x);")
+	f.P("return hashcode;")
+	f.PD("}")
+	f.P("return v.GetHashCode();")
+	f.PD("}")
+	f.PD("}")
+	f.P("")
+	f.PD("}")
 
 	fileName := "Jsonif.cs"
 
@@ -355,11 +1916,121 @@ func genJsonif() (*pluginpb.CodeGeneratorResponse_File, error) {
 	return resp, nil
 }
 
-func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+func checkMessageNames(msg *internal.Message, scope *internal.NameScope) error {
+	if err := scope.Add(msg.Name, msg); err != nil {
+		return err
+	}
+
+	// クラスのメンバ（クラスと同じ名前のメンバは定義できない）
+	members := &internal.NameScope{}
+	for _, name := range []string{msg.Name, "Equals", "GetHashCode", "WriteJson", "ReadJson"} {
+		if err := members.Add(name, msg); err != nil {
+			return err
+		}
+	}
+	if msg.HasValidation() {
+		if err := members.Add("Validate", msg); err != nil {
+			return err
+		}
+	}
+	if msg.KeepUnknownFields {
+		for _, name := range []string{"jsonif_unknown_fields", "JsonifKnownKeys"} {
+			if err := members.Add(name, msg); err != nil {
+				return err
+			}
+		}
+	}
+	for _, enum := range msg.Enums {
+		if err := members.Add(enum.Name, enum); err != nil {
+			return err
+		}
+	}
+	for _, nested := range msg.Messages {
+		if nested.MapEntry {
+			continue
+		}
+		if err := checkMessageNames(nested, members); err != nil {
+			return err
+		}
+	}
+	for _, field := range msg.Fields {
+		// C# では @ を付けても同じ名前として扱われるので、エスケープする前の名前で調べる
+		fieldName := internal.ToSnakeCase(field.Name)
+		names := []string{fieldName}
+		if field.Oneof != nil {
+			methodName := internal.ToUpperCamel(fieldName)
+			names = append(names, "Set"+methodName, "Has"+methodName, "Clear"+methodName)
+		}
+		for _, name := range names {
+			if err := members.Add(name, field); err != nil {
+				return err
+			}
+		}
+	}
+	for _, oneof := range msg.Oneofs {
+		typeName := internal.ToUpperCamel(oneof.Name) + "Case"
+		names := []string{typeName, internal.ToSnakeCase(oneof.Name) + "_case", "Clear" + typeName}
+		for _, name := range names {
+			if err := members.Add(name, oneof); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 生成する識別子が衝突していないか調べる
+func checkNames(schema *internal.Schema) error {
+	// 名前空間ごとのスコープ
+	// パッケージ名は UpperCamel に変換するので、foo_bar と fooBar は同じ名前空間になる
+	scopes := map[string]*internal.NameScope{}
+	scope := func(ns string) *internal.NameScope {
+		if _, ok := scopes[ns]; !ok {
+			scopes[ns] = &internal.NameScope{}
+		}
+		return scopes[ns]
+	}
+	for _, file := range schema.Files {
+		ns := ""
+		if len(file.Package) != 0 {
+			ns = packageToNamespace(file.Package)
+			xs := strings.Split(ns, ".")
+			for i := range xs {
+				if err := scope(strings.Join(xs[:i], ".")).Add(xs[i], internal.Namespace(strings.Join(xs[:i+1], "."))); err != nil {
+					return err
+				}
+			}
+		}
+		for _, enum := range file.Enums {
+			if err := scope(ns).Add(enum.Name, enum); err != nil {
+				return err
+			}
+		}
+		for _, msg := range file.Messages {
+			if err := checkMessageNames(msg, scope(ns)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func gen(req *pluginpb.CodeGeneratorRequest, opts *options) (*pluginpb.CodeGeneratorResponse, error) {
 	resp := &pluginpb.CodeGeneratorResponse{}
 	resp.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
-	for _, file := range req.ProtoFile {
-		respFile, err := genFile(file)
+	schema, err := internal.NewSchema(req.ProtoFile, &opts.CommonOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNames(schema); err != nil {
+		return nil, err
+	}
+	for _, file := range schema.FilesToGenerate(req, &opts.CommonOptions) {
+		// well-known type は組み込み型に変換するので、定義ファイルのコードは出力しない
+		if file.IsWellKnown() {
+			continue
+		}
+		respFile, err := genFile(file, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -377,7 +2048,10 @@ func gen(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, e
 }
 
 func main() {
-	err := internal.RunPlugin(gen)
+	opts := &options{}
+	err := internal.RunPlugin(newOptionSet(opts), func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return gen(req, opts)
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
 		os.Exit(1)
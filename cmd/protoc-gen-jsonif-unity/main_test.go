@@ -0,0 +1,86 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/melpon/protoc-gen-jsonif/cmd/internal/goldentest"
+)
+
+func TestGolden(t *testing.T) {
+	cases := []struct {
+		name      string
+		parameter string
+		files     []string
+	}{
+		{"bytes", "", []string{"bytes.proto"}},
+		{"empty", "", []string{"empty.proto"}},
+		{"enumpb", "", []string{"enumpb.proto"}},
+		{"importing", "", []string{"importing.proto"}},
+		{"message", "", []string{"message.proto"}},
+		{"nested", "", []string{"nested.proto"}},
+		{"oneof", "", []string{"oneof.proto"}},
+		{"optional", "", []string{"optional.proto"}},
+		{"repeated", "", []string{"repeated.proto"}},
+		{"keywords", "", []string{"keywords.proto"}},
+		{"comments", "", []string{"comments.proto"}},
+		{"map", "", []string{"map.proto"}},
+		{"wellknown", "", []string{"wellknown.proto"}},
+		{"jsonvalue", "", []string{"jsonvalue.proto"}},
+		{"any", "", []string{"any.proto"}},
+		{"enum_name", "", []string{"enum_name.proto"}},
+		{"oneof_active_only", "", []string{"oneof_active_only.proto"}},
+		{"default_value", "", []string{"default_value.proto"}},
+		{"validation", "", []string{"validation.proto"}},
+		{"deprecated", "", []string{"deprecated.proto"}},
+		{"unknown_fields", "", []string{"unknown_fields.proto"}},
+		{"base64url", "base64url", []string{"bytes.proto"}},
+		{"importing_include_imports", "include_imports", []string{"importing.proto"}},
+		{"enumpb_enum_as_name", "enum_as_name", []string{"enumpb.proto"}},
+		{"oneof_oneof_active_only", "oneof_active_only", []string{"oneof.proto"}},
+		{"canonical", "canonical", []string{"canonical.proto", "canonical_bytes.proto"}},
+		{"deprecated_report_deprecated", "report_deprecated", []string{"deprecated.proto"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := goldentest.NewRequest(t, c.parameter, c.files...)
+			opts := &options{}
+			if err := newOptionSet(opts).Parse(req.GetParameter()); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := gen(req, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			goldentest.Check(t, filepath.Join("testdata", c.name), resp)
+		})
+	}
+}
+
+func TestNameCollision(t *testing.T) {
+	cases := []struct {
+		name  string
+		files []string
+		err   string
+	}{
+		{"package", []string{"invalid/collision_package1.proto", "invalid/collision_package2.proto"}, ""},
+		{"nested", []string{"invalid/collision_nested.proto"}, ""},
+		{"oneof", []string{"invalid/collision_oneof.proto"}, "identifier collision: field collision_oneof.Test.test_oneof_case and oneof collision_oneof.Test.test_oneof both generate test_oneof_case"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := goldentest.NewRequest(t, "", c.files...)
+			_, err := gen(req, &options{})
+			if len(c.err) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("error = %v, want %q", err, c.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Anypb
+{
+    
+    [System.Serializable]
+    public class Payload : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public int count;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Payload;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            if (!this.count.Equals(v.count)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            hashcode = hashcode * 7302013 ^ count.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.Key("count");
+            w.Write(this.count);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("count", out v)) this.count = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public class Nested : global::Jsonif.IJsonSerializable
+        {
+            public List<string> tags = new List<string>();
+            public override bool Equals(object obj)
+            {
+                var v = obj as Nested;
+                if (v == null) return false;
+                if (!this.tags.SequenceEqual(v.tags)) return false;
+                return true;
+            }
+            
+            public override int GetHashCode()
+            {
+                int hashcode = 1430287;
+                foreach (var v in this.tags) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+                return hashcode;
+            }
+            
+            public void WriteJson(global::Jsonif.JsonWriter w)
+            {
+                w.BeginObject();
+                w.Key("tags");
+                w.BeginArray();
+                foreach (var x in this.tags) w.Write(x);
+                w.EndArray();
+                w.EndObject();
+            }
+            
+            public void ReadJson(object json)
+            {
+                var obj = json as Dictionary<string, object>;
+                if (obj == null) throw new System.FormatException("expected object");
+                object v;
+                if (obj.TryGetValue("tags", out v)) this.tags = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadString(x));
+            }
+            
+        }
+        
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kOneofAny = 4,
+            kOneofString = 5,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            oneof_any = default(global::Jsonif.Any);
+            oneof_string = "";
+        }
+        public global::Jsonif.Any any;
+        public List<global::Jsonif.Any> anys = new List<global::Jsonif.Any>();
+        public Dictionary<string, global::Jsonif.Any> any_map = new Dictionary<string, global::Jsonif.Any>();
+        public global::Jsonif.Any oneof_any;
+        public void SetOneofAny(global::Jsonif.Any oneof_any)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kOneofAny;
+            this.oneof_any = oneof_any;
+        }
+        public bool HasOneofAny()
+        {
+            return kind_case == KindCase.kOneofAny;
+        }
+        public void ClearOneofAny()
+        {
+            if (kind_case == KindCase.kOneofAny)
+            {
+                ClearKindCase();
+            }
+        }
+        public string oneof_string = "";
+        public void SetOneofString(string oneof_string)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kOneofString;
+            this.oneof_string = oneof_string;
+        }
+        public bool HasOneofString()
+        {
+            return kind_case == KindCase.kOneofString;
+        }
+        public void ClearOneofString()
+        {
+            if (kind_case == KindCase.kOneofString)
+            {
+                ClearKindCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!object.Equals(this.any, v.any)) return false;
+            if (!this.anys.SequenceEqual(v.anys)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.any_map, v.any_map)) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kOneofAny && !object.Equals(this.oneof_any, v.oneof_any)) return false;
+            if (this.kind_case == KindCase.kOneofString && !this.oneof_string.Equals(v.oneof_string)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ (any == null ? 0 : any.GetHashCode());
+            foreach (var v in this.anys) hashcode = hashcode * 7302013 ^ (v == null ? 0 : v.GetHashCode());
+            foreach (var kv in this.any_map) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ (kv.Value == null ? 0 : kv.Value.GetHashCode()));
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kOneofAny) hashcode = hashcode * 7302013 ^ (oneof_any == null ? 0 : oneof_any.GetHashCode());
+            if (kind_case == KindCase.kOneofString) hashcode = hashcode * 7302013 ^ oneof_string.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("kind_case");
+            w.Write((int)this.kind_case);
+            w.Key("any");
+            w.Write(this.any);
+            w.Key("anys");
+            w.BeginArray();
+            foreach (var x in this.anys) w.Write(x);
+            w.EndArray();
+            w.Key("any_map");
+            w.BeginObject();
+            foreach (var kv in this.any_map)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("oneof_any");
+            w.Write(this.oneof_any);
+            w.Key("oneof_string");
+            w.Write(this.oneof_string);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("any", out v)) this.any = global::Jsonif.JsonReader.ReadAny(v);
+            if (obj.TryGetValue("anys", out v)) this.anys = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadAny(x));
+            if (obj.TryGetValue("any_map", out v)) this.any_map = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadAny(x));
+            if (obj.TryGetValue("oneof_any", out v)) this.oneof_any = global::Jsonif.JsonReader.ReadAny(v);
+            if (obj.TryGetValue("oneof_string", out v)) this.oneof_string = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredAny_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "anypb.Payload", typeof(global::Anypb.Payload) },
+            { "anypb.Test.Nested", typeof(global::Anypb.Test.Nested) },
+            { "anypb.Test", typeof(global::Anypb.Test) },
+        });
+    }
+    
+}
@@ -0,0 +1,64 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Bytes
+{
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        public byte[] data = new byte[0];
+        public List<byte[]> rp_data = new List<byte[]>();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!global::Jsonif.Json.BytesEquals(this.data, v.data)) return false;
+            if (!this.rp_data.SequenceEqual(v.rp_data, global::Jsonif.BytesComparer.Instance)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ global::Jsonif.Json.BytesHashCode(data);
+            foreach (var v in this.rp_data) hashcode = hashcode * 7302013 ^ global::Jsonif.Json.BytesHashCode(v);
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("data");
+            w.Write(this.data);
+            w.Key("rp_data");
+            w.BeginArray();
+            foreach (var x in this.rp_data) w.Write(x);
+            w.EndArray();
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("data", out v)) this.data = global::Jsonif.JsonReader.ReadBytes(v);
+            if (obj.TryGetValue("rp_data", out v)) this.rp_data = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadBytes(x));
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredBytes_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "bytes.Test", typeof(global::Bytes.Test) },
+        });
+    }
+    
+}
@@ -0,0 +1,367 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Canonical
+{
+    
+    [System.Serializable]
+    public enum Color
+    {
+        COLOR_UNSPECIFIED = 0,
+        COLOR_RED = 1,
+        COLOR_BLUE = 2,
+    }
+    
+    [System.Serializable]
+    public class Inner : global::Jsonif.IJsonSerializable
+    {
+        public int value;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Inner;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            if (this.value != 0)
+            {
+                w.Key("value");
+                w.Write(this.value);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kKindName = 13,
+            kKindNumber = 14,
+            kKindInner = 15,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            kind_name = "";
+            kind_number = default(long);
+            kind_inner = new global::Canonical.Inner();
+        }
+        [System.Serializable]
+        public enum OptValueCase
+        {
+            NOT_SET = 0,
+            kOptValue = 12,
+        }
+        public OptValueCase _opt_value_case;
+        public void ClearOptValueCase()
+        {
+            _opt_value_case = OptValueCase.NOT_SET;
+            opt_value = default(int);
+        }
+        public int int_value;
+        public long int64_value;
+        public ulong uint64_value;
+        public List<long> int64_values = new List<long>();
+        public Dictionary<string, long> int64_map = new Dictionary<string, long>();
+        public string renamed = "";
+        public global::Canonical.Color color = new global::Canonical.Color();
+        public List<global::Canonical.Color> colors = new List<global::Canonical.Color>();
+        public Dictionary<int, global::Canonical.Color> color_map = new Dictionary<int, global::Canonical.Color>();
+        public global::Canonical.Inner inner = new global::Canonical.Inner();
+        public long? wrapped_int64;
+        public int opt_value;
+        public void SetOptValue(int opt_value)
+        {
+            ClearOptValueCase();
+            _opt_value_case = OptValueCase.kOptValue;
+            this.opt_value = opt_value;
+        }
+        public bool HasOptValue()
+        {
+            return _opt_value_case == OptValueCase.kOptValue;
+        }
+        public void ClearOptValue()
+        {
+            if (_opt_value_case == OptValueCase.kOptValue)
+            {
+                ClearOptValueCase();
+            }
+        }
+        public string kind_name = "";
+        public void SetKindName(string kind_name)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kKindName;
+            this.kind_name = kind_name;
+        }
+        public bool HasKindName()
+        {
+            return kind_case == KindCase.kKindName;
+        }
+        public void ClearKindName()
+        {
+            if (kind_case == KindCase.kKindName)
+            {
+                ClearKindCase();
+            }
+        }
+        public long kind_number;
+        public void SetKindNumber(long kind_number)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kKindNumber;
+            this.kind_number = kind_number;
+        }
+        public bool HasKindNumber()
+        {
+            return kind_case == KindCase.kKindNumber;
+        }
+        public void ClearKindNumber()
+        {
+            if (kind_case == KindCase.kKindNumber)
+            {
+                ClearKindCase();
+            }
+        }
+        public global::Canonical.Inner kind_inner = new global::Canonical.Inner();
+        public void SetKindInner(global::Canonical.Inner kind_inner)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kKindInner;
+            this.kind_inner = kind_inner;
+        }
+        public bool HasKindInner()
+        {
+            return kind_case == KindCase.kKindInner;
+        }
+        public void ClearKindInner()
+        {
+            if (kind_case == KindCase.kKindInner)
+            {
+                ClearKindCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.int_value.Equals(v.int_value)) return false;
+            if (!this.int64_value.Equals(v.int64_value)) return false;
+            if (!this.uint64_value.Equals(v.uint64_value)) return false;
+            if (!this.int64_values.SequenceEqual(v.int64_values)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.int64_map, v.int64_map)) return false;
+            if (!this.renamed.Equals(v.renamed)) return false;
+            if (!this.color.Equals(v.color)) return false;
+            if (!this.colors.SequenceEqual(v.colors)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.color_map, v.color_map)) return false;
+            if (!this.inner.Equals(v.inner)) return false;
+            if (!object.Equals(this.wrapped_int64, v.wrapped_int64)) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kKindName && !this.kind_name.Equals(v.kind_name)) return false;
+            if (this.kind_case == KindCase.kKindNumber && !this.kind_number.Equals(v.kind_number)) return false;
+            if (this.kind_case == KindCase.kKindInner && !this.kind_inner.Equals(v.kind_inner)) return false;
+            if (!this._opt_value_case.Equals(v._opt_value_case)) return false;
+            if (this._opt_value_case == OptValueCase.kOptValue && !this.opt_value.Equals(v.opt_value)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ int_value.GetHashCode();
+            hashcode = hashcode * 7302013 ^ int64_value.GetHashCode();
+            hashcode = hashcode * 7302013 ^ uint64_value.GetHashCode();
+            foreach (var v in this.int64_values) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var kv in this.int64_map) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ renamed.GetHashCode();
+            hashcode = hashcode * 7302013 ^ color.GetHashCode();
+            foreach (var v in this.colors) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var kv in this.color_map) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ inner.GetHashCode();
+            hashcode = hashcode * 7302013 ^ (wrapped_int64 == null ? 0 : wrapped_int64.GetHashCode());
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kKindName) hashcode = hashcode * 7302013 ^ kind_name.GetHashCode();
+            if (kind_case == KindCase.kKindNumber) hashcode = hashcode * 7302013 ^ kind_number.GetHashCode();
+            if (kind_case == KindCase.kKindInner) hashcode = hashcode * 7302013 ^ kind_inner.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _opt_value_case.GetHashCode();
+            if (_opt_value_case == OptValueCase.kOptValue) hashcode = hashcode * 7302013 ^ opt_value.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            if (this.int_value != 0)
+            {
+                w.Key("intValue");
+                w.Write(this.int_value);
+            }
+            if (this.int64_value != 0)
+            {
+                w.Key("int64Value");
+                w.WriteAsString(this.int64_value);
+            }
+            if (this.uint64_value != 0)
+            {
+                w.Key("uint64Value");
+                w.WriteAsString(this.uint64_value);
+            }
+            if (this.int64_values.Count != 0)
+            {
+                w.Key("int64Values");
+                w.BeginArray();
+                foreach (var x in this.int64_values) w.WriteAsString(x);
+                w.EndArray();
+            }
+            if (this.int64_map.Count != 0)
+            {
+                w.Key("int64Map");
+                w.BeginObject();
+                foreach (var kv in this.int64_map)
+                {
+                    w.Key(kv.Key);
+                    w.WriteAsString(kv.Value);
+                }
+                w.EndObject();
+            }
+            if (!string.IsNullOrEmpty(this.renamed))
+            {
+                w.Key("customName");
+                w.Write(this.renamed);
+            }
+            if ((int)this.color != 0)
+            {
+                w.Key("color");
+                w.WriteEnum(this.color);
+            }
+            if (this.colors.Count != 0)
+            {
+                w.Key("colors");
+                w.BeginArray();
+                foreach (var x in this.colors) w.WriteEnum(x);
+                w.EndArray();
+            }
+            if (this.color_map.Count != 0)
+            {
+                w.Key("colorMap");
+                w.BeginObject();
+                foreach (var kv in this.color_map)
+                {
+                    w.Key(kv.Key);
+                    w.WriteEnum(kv.Value);
+                }
+                w.EndObject();
+            }
+            if (this.inner != null && !this.inner.Equals(new global::Canonical.Inner()))
+            {
+                w.Key("inner");
+                w.Write(this.inner);
+            }
+            if (this.wrapped_int64 != null)
+            {
+                w.Key("wrappedInt64");
+                w.WriteAsString(this.wrapped_int64);
+            }
+            if (this._opt_value_case == OptValueCase.kOptValue)
+            {
+                w.Key("optValue");
+                w.Write(this.opt_value);
+            }
+            if (this.kind_case == KindCase.kKindName)
+            {
+                w.Key("kindName");
+                w.Write(this.kind_name);
+            }
+            if (this.kind_case == KindCase.kKindNumber)
+            {
+                w.Key("kindNumber");
+                w.WriteAsString(this.kind_number);
+            }
+            if (this.kind_case == KindCase.kKindInner)
+            {
+                w.Key("kindInner");
+                w.Write(this.kind_inner);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("intValue", out v)) this.int_value = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("int64Value", out v)) this.int64_value = global::Jsonif.JsonReader.ReadLong(v);
+            if (obj.TryGetValue("uint64Value", out v)) this.uint64_value = global::Jsonif.JsonReader.ReadULong(v);
+            if (obj.TryGetValue("int64Values", out v)) this.int64_values = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadLong(x));
+            if (obj.TryGetValue("int64Map", out v)) this.int64_map = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadLong(x));
+            if (obj.TryGetValue("customName", out v)) this.renamed = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("color", out v)) this.color = global::Jsonif.JsonReader.ReadEnum<global::Canonical.Color>(v);
+            if (obj.TryGetValue("colors", out v)) this.colors = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadEnum<global::Canonical.Color>(x));
+            if (obj.TryGetValue("colorMap", out v)) this.color_map = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadInt(k), x => global::Jsonif.JsonReader.ReadEnum<global::Canonical.Color>(x));
+            if (obj.TryGetValue("inner", out v)) this.inner = global::Jsonif.JsonReader.ReadObject<global::Canonical.Inner>(v);
+            if (obj.TryGetValue("wrappedInt64", out v)) this.wrapped_int64 = (v == null ? (long?)null : global::Jsonif.JsonReader.ReadLong(v));
+            if (obj.TryGetValue("optValue", out v))
+            {
+                this.opt_value = global::Jsonif.JsonReader.ReadInt(v);
+                this._opt_value_case = OptValueCase.kOptValue;
+            }
+            if (obj.TryGetValue("kindName", out v))
+            {
+                this.kind_name = global::Jsonif.JsonReader.ReadString(v);
+                this.kind_case = KindCase.kKindName;
+            }
+            if (obj.TryGetValue("kindNumber", out v))
+            {
+                this.kind_number = global::Jsonif.JsonReader.ReadLong(v);
+                this.kind_case = KindCase.kKindNumber;
+            }
+            if (obj.TryGetValue("kindInner", out v))
+            {
+                this.kind_inner = global::Jsonif.JsonReader.ReadObject<global::Canonical.Inner>(v);
+                this.kind_case = KindCase.kKindInner;
+            }
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredCanonical_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "canonical.Inner", typeof(global::Canonical.Inner) },
+            { "canonical.Test", typeof(global::Canonical.Test) },
+        });
+    }
+    
+}
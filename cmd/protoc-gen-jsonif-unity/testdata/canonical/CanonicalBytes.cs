@@ -0,0 +1,94 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Canonical
+{
+    
+    [System.Serializable]
+    public class BytesTest : global::Jsonif.IJsonSerializable
+    {
+        public byte[] data = new byte[0];
+        public List<byte[]> datas = new List<byte[]>();
+        public Dictionary<string, byte[]> data_map = new Dictionary<string, byte[]>();
+        public byte[] wrapped_data;
+        public override bool Equals(object obj)
+        {
+            var v = obj as BytesTest;
+            if (v == null) return false;
+            if (!global::Jsonif.Json.BytesEquals(this.data, v.data)) return false;
+            if (!this.datas.SequenceEqual(v.datas, global::Jsonif.BytesComparer.Instance)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.data_map, v.data_map, global::Jsonif.BytesComparer.Instance)) return false;
+            if (!global::Jsonif.Json.BytesEquals(this.wrapped_data, v.wrapped_data)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ global::Jsonif.Json.BytesHashCode(data);
+            foreach (var v in this.datas) hashcode = hashcode * 7302013 ^ global::Jsonif.Json.BytesHashCode(v);
+            foreach (var kv in this.data_map) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ global::Jsonif.Json.BytesHashCode(kv.Value));
+            hashcode = hashcode * 7302013 ^ global::Jsonif.Json.BytesHashCode(wrapped_data);
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            if (this.data != null && this.data.Length != 0)
+            {
+                w.Key("data");
+                w.Write(this.data);
+            }
+            if (this.datas.Count != 0)
+            {
+                w.Key("datas");
+                w.BeginArray();
+                foreach (var x in this.datas) w.Write(x);
+                w.EndArray();
+            }
+            if (this.data_map.Count != 0)
+            {
+                w.Key("dataMap");
+                w.BeginObject();
+                foreach (var kv in this.data_map)
+                {
+                    w.Key(kv.Key);
+                    w.Write(kv.Value);
+                }
+                w.EndObject();
+            }
+            if (this.wrapped_data != null)
+            {
+                w.Key("wrappedData");
+                w.WriteNullable(this.wrapped_data);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("data", out v)) this.data = global::Jsonif.JsonReader.ReadBytes(v);
+            if (obj.TryGetValue("datas", out v)) this.datas = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadBytes(x));
+            if (obj.TryGetValue("dataMap", out v)) this.data_map = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadBytes(x));
+            if (obj.TryGetValue("wrappedData", out v)) this.wrapped_data = (v == null ? (byte[])null : global::Jsonif.JsonReader.ReadBytes(v));
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredCanonicalBytes_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "canonical.BytesTest", typeof(global::Canonical.BytesTest) },
+        });
+    }
+    
+}
@@ -0,0 +1,240 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Comments
+{
+    
+    /// <summary>
+    /// 列挙型のコメント
+    /// </summary>
+    [System.Serializable]
+    public enum Kind
+    {
+        /// <summary>
+        /// 値のコメント
+        /// </summary>
+        KIND_UNKNOWN = 0,
+        /// <summary>
+        /// 後置コメント
+        /// </summary>
+        KIND_FOO = 1,
+    }
+    
+    /// <summary>
+    /// proto のコメントがドキュメントコメントとして出力されるか確認する用
+    ///
+    /// 複数行のコメントや &lt;記号&gt; &amp; */ も扱えること
+    /// </summary>
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        /// <summary>
+        /// ネストしたメッセージ
+        /// </summary>
+        [System.Serializable]
+        public class Nested : global::Jsonif.IJsonSerializable
+        {
+            /// <summary>
+            /// ネストしたメッセージのフィールド
+            /// </summary>
+            public bool flag;
+            public override bool Equals(object obj)
+            {
+                var v = obj as Nested;
+                if (v == null) return false;
+                if (!this.flag.Equals(v.flag)) return false;
+                return true;
+            }
+            
+            public override int GetHashCode()
+            {
+                int hashcode = 1430287;
+                hashcode = hashcode * 7302013 ^ flag.GetHashCode();
+                return hashcode;
+            }
+            
+            public void WriteJson(global::Jsonif.JsonWriter w)
+            {
+                w.BeginObject();
+                w.Key("flag");
+                w.Write(this.flag);
+                w.EndObject();
+            }
+            
+            public void ReadJson(object json)
+            {
+                var obj = json as Dictionary<string, object>;
+                if (obj == null) throw new System.FormatException("expected object");
+                object v;
+                if (obj.TryGetValue("flag", out v)) this.flag = global::Jsonif.JsonReader.ReadBool(v);
+            }
+            
+        }
+        
+        /// <summary>
+        /// oneof のコメント
+        /// </summary>
+        [System.Serializable]
+        public enum ValueCase
+        {
+            NOT_SET = 0,
+            kA = 4,
+            kB = 5,
+        }
+        public ValueCase value_case;
+        public void ClearValueCase()
+        {
+            value_case = ValueCase.NOT_SET;
+            a = default(int);
+            b = "";
+        }
+        /// <summary>
+        /// 前置コメント
+        /// </summary>
+        public int leading;
+        /// <summary>
+        /// 後置コメント
+        /// </summary>
+        public string trailing = "";
+        public List<int> none = new List<int>();
+        /// <summary>
+        /// oneof フィールドのコメント
+        /// </summary>
+        public int a;
+        public void SetA(int a)
+        {
+            ClearValueCase();
+            value_case = ValueCase.kA;
+            this.a = a;
+        }
+        public bool HasA()
+        {
+            return value_case == ValueCase.kA;
+        }
+        public void ClearA()
+        {
+            if (value_case == ValueCase.kA)
+            {
+                ClearValueCase();
+            }
+        }
+        public string b = "";
+        public void SetB(string b)
+        {
+            ClearValueCase();
+            value_case = ValueCase.kB;
+            this.b = b;
+        }
+        public bool HasB()
+        {
+            return value_case == ValueCase.kB;
+        }
+        public void ClearB()
+        {
+            if (value_case == ValueCase.kB)
+            {
+                ClearValueCase();
+            }
+        }
+        public global::Comments.Test.Nested nested = new global::Comments.Test.Nested();
+        /// <summary>
+        /// 離れたコメント
+        ///
+        /// 離れたコメントの後の前置コメント
+        /// </summary>
+        public int detached;
+        /// <summary>
+        /// 前置コメントと
+        ///
+        /// 後置コメントの両方
+        /// </summary>
+        public int both;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.leading.Equals(v.leading)) return false;
+            if (!this.trailing.Equals(v.trailing)) return false;
+            if (!this.none.SequenceEqual(v.none)) return false;
+            if (!this.nested.Equals(v.nested)) return false;
+            if (!this.detached.Equals(v.detached)) return false;
+            if (!this.both.Equals(v.both)) return false;
+            if (!this.value_case.Equals(v.value_case)) return false;
+            if (this.value_case == ValueCase.kA && !this.a.Equals(v.a)) return false;
+            if (this.value_case == ValueCase.kB && !this.b.Equals(v.b)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ leading.GetHashCode();
+            hashcode = hashcode * 7302013 ^ trailing.GetHashCode();
+            foreach (var v in this.none) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            hashcode = hashcode * 7302013 ^ nested.GetHashCode();
+            hashcode = hashcode * 7302013 ^ detached.GetHashCode();
+            hashcode = hashcode * 7302013 ^ both.GetHashCode();
+            hashcode = hashcode * 7302013 ^ value_case.GetHashCode();
+            if (value_case == ValueCase.kA) hashcode = hashcode * 7302013 ^ a.GetHashCode();
+            if (value_case == ValueCase.kB) hashcode = hashcode * 7302013 ^ b.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value_case");
+            w.Write((int)this.value_case);
+            w.Key("leading");
+            w.Write(this.leading);
+            w.Key("trailing");
+            w.Write(this.trailing);
+            w.Key("none");
+            w.BeginArray();
+            foreach (var x in this.none) w.Write(x);
+            w.EndArray();
+            w.Key("a");
+            w.Write(this.a);
+            w.Key("b");
+            w.Write(this.b);
+            w.Key("nested");
+            w.Write(this.nested);
+            w.Key("detached");
+            w.Write(this.detached);
+            w.Key("both");
+            w.Write(this.both);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value_case", out v)) this.value_case = (ValueCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("leading", out v)) this.leading = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("trailing", out v)) this.trailing = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("none", out v)) this.none = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadInt(x));
+            if (obj.TryGetValue("a", out v)) this.a = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("b", out v)) this.b = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("nested", out v)) this.nested = global::Jsonif.JsonReader.ReadObject<global::Comments.Test.Nested>(v);
+            if (obj.TryGetValue("detached", out v)) this.detached = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("both", out v)) this.both = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredComments_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "comments.Test.Nested", typeof(global::Comments.Test.Nested) },
+            { "comments.Test", typeof(global::Comments.Test) },
+        });
+    }
+    
+}
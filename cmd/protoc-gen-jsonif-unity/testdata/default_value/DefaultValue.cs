@@ -0,0 +1,190 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace DefaultValue
+{
+    
+    [System.Serializable]
+    public enum Color
+    {
+        COLOR_UNSPECIFIED = 0,
+        COLOR_RED = 1,
+        COLOR_BLUE = 2,
+    }
+    
+    [System.Serializable]
+    public class Inner : global::Jsonif.IJsonSerializable
+    {
+        public int value = 10;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Inner;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value");
+            w.Write(this.value);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    /// <summary>
+    /// キーが無い場合は jsonif_default の値になる
+    /// デフォルト値と同じ値のフィールドは出力しない
+    /// </summary>
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        public double d = 1.5;
+        public float f = 3.0f;
+        public double big = 1e+30;
+        public int i32 = -2147483648;
+        public long i64 = -9223372036854775808L;
+        public uint u32 = 4294967295U;
+        public ulong u64 = 12345678901234UL;
+        public bool b = true;
+        public string s = "hello \"jsonif\"\n";
+        public string empty = "";
+        /// <summary>
+        /// enum は値の名前でも数値でも指定できる
+        /// </summary>
+        public global::DefaultValue.Color color = (global::DefaultValue.Color)1;
+        public global::DefaultValue.Color color_num = (global::DefaultValue.Color)2;
+        public int no_default;
+        /// <summary>
+        /// メッセージのフィールドも、そのメッセージの jsonif_default の値で初期化される
+        /// </summary>
+        public global::DefaultValue.Inner inner = new global::DefaultValue.Inner();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.d.Equals(v.d)) return false;
+            if (!this.f.Equals(v.f)) return false;
+            if (!this.big.Equals(v.big)) return false;
+            if (!this.i32.Equals(v.i32)) return false;
+            if (!this.i64.Equals(v.i64)) return false;
+            if (!this.u32.Equals(v.u32)) return false;
+            if (!this.u64.Equals(v.u64)) return false;
+            if (!this.b.Equals(v.b)) return false;
+            if (!this.s.Equals(v.s)) return false;
+            if (!this.empty.Equals(v.empty)) return false;
+            if (!this.color.Equals(v.color)) return false;
+            if (!this.color_num.Equals(v.color_num)) return false;
+            if (!this.no_default.Equals(v.no_default)) return false;
+            if (!this.inner.Equals(v.inner)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ d.GetHashCode();
+            hashcode = hashcode * 7302013 ^ f.GetHashCode();
+            hashcode = hashcode * 7302013 ^ big.GetHashCode();
+            hashcode = hashcode * 7302013 ^ i32.GetHashCode();
+            hashcode = hashcode * 7302013 ^ i64.GetHashCode();
+            hashcode = hashcode * 7302013 ^ u32.GetHashCode();
+            hashcode = hashcode * 7302013 ^ u64.GetHashCode();
+            hashcode = hashcode * 7302013 ^ b.GetHashCode();
+            hashcode = hashcode * 7302013 ^ s.GetHashCode();
+            hashcode = hashcode * 7302013 ^ empty.GetHashCode();
+            hashcode = hashcode * 7302013 ^ color.GetHashCode();
+            hashcode = hashcode * 7302013 ^ color_num.GetHashCode();
+            hashcode = hashcode * 7302013 ^ no_default.GetHashCode();
+            hashcode = hashcode * 7302013 ^ inner.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("d");
+            w.Write(this.d);
+            w.Key("f");
+            w.Write(this.f);
+            w.Key("big");
+            w.Write(this.big);
+            w.Key("i32");
+            w.Write(this.i32);
+            w.Key("i64");
+            w.Write(this.i64);
+            w.Key("u32");
+            w.Write(this.u32);
+            w.Key("u64");
+            w.Write(this.u64);
+            w.Key("b");
+            w.Write(this.b);
+            w.Key("s");
+            w.Write(this.s);
+            w.Key("empty");
+            w.Write(this.empty);
+            w.Key("color");
+            w.Write((int)this.color);
+            w.Key("color_num");
+            w.Write((int)this.color_num);
+            w.Key("no_default");
+            w.Write(this.no_default);
+            w.Key("inner");
+            w.Write(this.inner);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("d", out v)) this.d = global::Jsonif.JsonReader.ReadDouble(v);
+            if (obj.TryGetValue("f", out v)) this.f = global::Jsonif.JsonReader.ReadFloat(v);
+            if (obj.TryGetValue("big", out v)) this.big = global::Jsonif.JsonReader.ReadDouble(v);
+            if (obj.TryGetValue("i32", out v)) this.i32 = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("i64", out v)) this.i64 = global::Jsonif.JsonReader.ReadLong(v);
+            if (obj.TryGetValue("u32", out v)) this.u32 = global::Jsonif.JsonReader.ReadUInt(v);
+            if (obj.TryGetValue("u64", out v)) this.u64 = global::Jsonif.JsonReader.ReadULong(v);
+            if (obj.TryGetValue("b", out v)) this.b = global::Jsonif.JsonReader.ReadBool(v);
+            if (obj.TryGetValue("s", out v)) this.s = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("empty", out v)) this.empty = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("color", out v)) this.color = (global::DefaultValue.Color)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("color_num", out v)) this.color_num = (global::DefaultValue.Color)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("no_default", out v)) this.no_default = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("inner", out v)) this.inner = global::Jsonif.JsonReader.ReadObject<global::DefaultValue.Inner>(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredDefaultValue_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "default_value.Inner", typeof(global::DefaultValue.Inner) },
+            { "default_value.Test", typeof(global::DefaultValue.Test) },
+        });
+    }
+    
+}
@@ -0,0 +1,316 @@
+#pragma warning disable 612, 618
+
+using System.Collections.Generic;
+using System.Linq;
+namespace Deprecated
+{
+    
+    [System.Serializable]
+    public enum Status
+    {
+        STATUS_UNKNOWN = 0,
+        STATUS_ACTIVE = 1,
+        /// <summary>
+        /// STATUS_ACTIVE を使うこと
+        /// </summary>
+        [System.Obsolete]
+        STATUS_ENABLED = 2,
+    }
+    
+    /// <summary>
+    /// Test に置き換えられた古いメッセージ
+    /// </summary>
+    [System.Serializable]
+    [System.Obsolete]
+    public class Legacy : global::Jsonif.IJsonSerializable
+    {
+        public int value;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Legacy;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value");
+            w.Write(this.value);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum ChoiceCase
+        {
+            NOT_SET = 0,
+            kNumber = 7,
+            kText = 8,
+        }
+        public ChoiceCase choice_case;
+        public void ClearChoiceCase()
+        {
+            choice_case = ChoiceCase.NOT_SET;
+            number = default(int);
+            text = "";
+        }
+        [System.Serializable]
+        public enum NoteCase
+        {
+            NOT_SET = 0,
+            kNote = 6,
+        }
+        public NoteCase _note_case;
+        public void ClearNoteCase()
+        {
+            _note_case = NoteCase.NOT_SET;
+            note = "";
+        }
+        public int id;
+        /// <summary>
+        /// id を使うこと
+        /// </summary>
+        [System.Obsolete]
+        public int old_id;
+        public global::Deprecated.Status status = new global::Deprecated.Status();
+        [System.Obsolete]
+        public global::Deprecated.Legacy legacy = new global::Deprecated.Legacy();
+        [System.Obsolete]
+        public List<string> tags = new List<string>();
+        [System.Obsolete]
+        public string note = "";
+        [System.Obsolete]
+        public void SetNote(string note)
+        {
+            ClearNoteCase();
+            _note_case = NoteCase.kNote;
+            this.note = note;
+        }
+        [System.Obsolete]
+        public bool HasNote()
+        {
+            return _note_case == NoteCase.kNote;
+        }
+        [System.Obsolete]
+        public void ClearNote()
+        {
+            if (_note_case == NoteCase.kNote)
+            {
+                ClearNoteCase();
+            }
+        }
+        public int number;
+        public void SetNumber(int number)
+        {
+            ClearChoiceCase();
+            choice_case = ChoiceCase.kNumber;
+            this.number = number;
+        }
+        public bool HasNumber()
+        {
+            return choice_case == ChoiceCase.kNumber;
+        }
+        public void ClearNumber()
+        {
+            if (choice_case == ChoiceCase.kNumber)
+            {
+                ClearChoiceCase();
+            }
+        }
+        [System.Obsolete]
+        public string text = "";
+        [System.Obsolete]
+        public void SetText(string text)
+        {
+            ClearChoiceCase();
+            choice_case = ChoiceCase.kText;
+            this.text = text;
+        }
+        [System.Obsolete]
+        public bool HasText()
+        {
+            return choice_case == ChoiceCase.kText;
+        }
+        [System.Obsolete]
+        public void ClearText()
+        {
+            if (choice_case == ChoiceCase.kText)
+            {
+                ClearChoiceCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.id.Equals(v.id)) return false;
+            if (!this.old_id.Equals(v.old_id)) return false;
+            if (!this.status.Equals(v.status)) return false;
+            if (!this.legacy.Equals(v.legacy)) return false;
+            if (!this.tags.SequenceEqual(v.tags)) return false;
+            if (!this.choice_case.Equals(v.choice_case)) return false;
+            if (this.choice_case == ChoiceCase.kNumber && !this.number.Equals(v.number)) return false;
+            if (this.choice_case == ChoiceCase.kText && !this.text.Equals(v.text)) return false;
+            if (!this._note_case.Equals(v._note_case)) return false;
+            if (this._note_case == NoteCase.kNote && !this.note.Equals(v.note)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ id.GetHashCode();
+            hashcode = hashcode * 7302013 ^ old_id.GetHashCode();
+            hashcode = hashcode * 7302013 ^ status.GetHashCode();
+            hashcode = hashcode * 7302013 ^ legacy.GetHashCode();
+            foreach (var v in this.tags) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            hashcode = hashcode * 7302013 ^ choice_case.GetHashCode();
+            if (choice_case == ChoiceCase.kNumber) hashcode = hashcode * 7302013 ^ number.GetHashCode();
+            if (choice_case == ChoiceCase.kText) hashcode = hashcode * 7302013 ^ text.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _note_case.GetHashCode();
+            if (_note_case == NoteCase.kNote) hashcode = hashcode * 7302013 ^ note.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("choice_case");
+            w.Write((int)this.choice_case);
+            w.Key("id");
+            w.Write(this.id);
+            w.Key("old_id");
+            w.Write(this.old_id);
+            w.Key("status");
+            w.Write((int)this.status);
+            w.Key("legacy");
+            w.Write(this.legacy);
+            w.Key("tags");
+            w.BeginArray();
+            foreach (var x in this.tags) w.Write(x);
+            w.EndArray();
+            if (this._note_case == NoteCase.kNote)
+            {
+                w.Key("note");
+                w.Write(this.note);
+            }
+            w.Key("number");
+            w.Write(this.number);
+            w.Key("text");
+            w.Write(this.text);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("choice_case", out v)) this.choice_case = (ChoiceCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("id", out v)) this.id = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("old_id", out v)) this.old_id = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("status", out v)) this.status = (global::Deprecated.Status)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("legacy", out v)) this.legacy = global::Jsonif.JsonReader.ReadObject<global::Deprecated.Legacy>(v);
+            if (obj.TryGetValue("tags", out v)) this.tags = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadString(x));
+            if (obj.TryGetValue("note", out v))
+            {
+                this.note = global::Jsonif.JsonReader.ReadString(v);
+                this._note_case = NoteCase.kNote;
+            }
+            if (obj.TryGetValue("number", out v)) this.number = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("text", out v)) this.text = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+    /// <summary>
+    /// deprecated なメッセージや enum の値を参照するだけのメッセージ
+    /// </summary>
+    [System.Serializable]
+    public class Holder : global::Jsonif.IJsonSerializable
+    {
+        public global::Deprecated.Status status = new global::Deprecated.Status();
+        public Dictionary<string, global::Deprecated.Status> statuses = new Dictionary<string, global::Deprecated.Status>();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Holder;
+            if (v == null) return false;
+            if (!this.status.Equals(v.status)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.statuses, v.statuses)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ status.GetHashCode();
+            foreach (var kv in this.statuses) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("status");
+            w.Write((int)this.status);
+            w.Key("statuses");
+            w.BeginObject();
+            foreach (var kv in this.statuses)
+            {
+                w.Key(kv.Key);
+                w.Write((int)kv.Value);
+            }
+            w.EndObject();
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("status", out v)) this.status = (global::Deprecated.Status)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("statuses", out v)) this.statuses = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => (global::Deprecated.Status)global::Jsonif.JsonReader.ReadInt(x));
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredDeprecated_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "deprecated.Legacy", typeof(global::Deprecated.Legacy) },
+            { "deprecated.Test", typeof(global::Deprecated.Test) },
+            { "deprecated.Holder", typeof(global::Deprecated.Holder) },
+        });
+    }
+    
+}
+
+#pragma warning restore 612, 618
@@ -0,0 +1,222 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace EnumName
+{
+    
+    [System.Serializable]
+    public enum Color
+    {
+        COLOR_UNSPECIFIED = 0,
+        COLOR_RED = 1,
+        COLOR_BLUE = 2,
+    }
+    
+    /// <summary>
+    /// enum ごとの設定がファイルの設定より優先される
+    /// </summary>
+    [System.Serializable]
+    public enum Number
+    {
+        NUMBER_ZERO = 0,
+        NUMBER_ONE = 1,
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum Nested
+        {
+            NESTED_FOO = 0,
+            NESTED_BAR = 1,
+        }
+        
+        [System.Serializable]
+        public enum ValueCase
+        {
+            NOT_SET = 0,
+            kOneofColor = 7,
+            kOneofInt = 8,
+        }
+        public ValueCase value_case;
+        public void ClearValueCase()
+        {
+            value_case = ValueCase.NOT_SET;
+            oneof_color = new global::EnumName.Color();
+            oneof_int = default(int);
+        }
+        [System.Serializable]
+        public enum OptColorCase
+        {
+            NOT_SET = 0,
+            kOptColor = 6,
+        }
+        public OptColorCase _opt_color_case;
+        public void ClearOptColorCase()
+        {
+            _opt_color_case = OptColorCase.NOT_SET;
+            opt_color = new global::EnumName.Color();
+        }
+        public global::EnumName.Color color = new global::EnumName.Color();
+        public List<global::EnumName.Color> colors = new List<global::EnumName.Color>();
+        public Dictionary<string, global::EnumName.Color> color_map = new Dictionary<string, global::EnumName.Color>();
+        public global::EnumName.Number number = new global::EnumName.Number();
+        public global::EnumName.Test.Nested nested = new global::EnumName.Test.Nested();
+        public global::EnumName.Color opt_color = new global::EnumName.Color();
+        public void SetOptColor(global::EnumName.Color opt_color)
+        {
+            ClearOptColorCase();
+            _opt_color_case = OptColorCase.kOptColor;
+            this.opt_color = opt_color;
+        }
+        public bool HasOptColor()
+        {
+            return _opt_color_case == OptColorCase.kOptColor;
+        }
+        public void ClearOptColor()
+        {
+            if (_opt_color_case == OptColorCase.kOptColor)
+            {
+                ClearOptColorCase();
+            }
+        }
+        public global::EnumName.Color oneof_color = new global::EnumName.Color();
+        public void SetOneofColor(global::EnumName.Color oneof_color)
+        {
+            ClearValueCase();
+            value_case = ValueCase.kOneofColor;
+            this.oneof_color = oneof_color;
+        }
+        public bool HasOneofColor()
+        {
+            return value_case == ValueCase.kOneofColor;
+        }
+        public void ClearOneofColor()
+        {
+            if (value_case == ValueCase.kOneofColor)
+            {
+                ClearValueCase();
+            }
+        }
+        public int oneof_int;
+        public void SetOneofInt(int oneof_int)
+        {
+            ClearValueCase();
+            value_case = ValueCase.kOneofInt;
+            this.oneof_int = oneof_int;
+        }
+        public bool HasOneofInt()
+        {
+            return value_case == ValueCase.kOneofInt;
+        }
+        public void ClearOneofInt()
+        {
+            if (value_case == ValueCase.kOneofInt)
+            {
+                ClearValueCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.color.Equals(v.color)) return false;
+            if (!this.colors.SequenceEqual(v.colors)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.color_map, v.color_map)) return false;
+            if (!this.number.Equals(v.number)) return false;
+            if (!this.nested.Equals(v.nested)) return false;
+            if (!this.value_case.Equals(v.value_case)) return false;
+            if (this.value_case == ValueCase.kOneofColor && !this.oneof_color.Equals(v.oneof_color)) return false;
+            if (this.value_case == ValueCase.kOneofInt && !this.oneof_int.Equals(v.oneof_int)) return false;
+            if (!this._opt_color_case.Equals(v._opt_color_case)) return false;
+            if (this._opt_color_case == OptColorCase.kOptColor && !this.opt_color.Equals(v.opt_color)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ color.GetHashCode();
+            foreach (var v in this.colors) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var kv in this.color_map) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ number.GetHashCode();
+            hashcode = hashcode * 7302013 ^ nested.GetHashCode();
+            hashcode = hashcode * 7302013 ^ value_case.GetHashCode();
+            if (value_case == ValueCase.kOneofColor) hashcode = hashcode * 7302013 ^ oneof_color.GetHashCode();
+            if (value_case == ValueCase.kOneofInt) hashcode = hashcode * 7302013 ^ oneof_int.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _opt_color_case.GetHashCode();
+            if (_opt_color_case == OptColorCase.kOptColor) hashcode = hashcode * 7302013 ^ opt_color.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value_case");
+            w.Write((int)this.value_case);
+            w.Key("color");
+            w.WriteEnum(this.color);
+            w.Key("colors");
+            w.BeginArray();
+            foreach (var x in this.colors) w.WriteEnum(x);
+            w.EndArray();
+            w.Key("color_map");
+            w.BeginObject();
+            foreach (var kv in this.color_map)
+            {
+                w.Key(kv.Key);
+                w.WriteEnum(kv.Value);
+            }
+            w.EndObject();
+            w.Key("number");
+            w.Write((int)this.number);
+            w.Key("nested");
+            w.WriteEnum(this.nested);
+            if (this._opt_color_case == OptColorCase.kOptColor)
+            {
+                w.Key("opt_color");
+                w.WriteEnum(this.opt_color);
+            }
+            w.Key("oneof_color");
+            w.WriteEnum(this.oneof_color);
+            w.Key("oneof_int");
+            w.Write(this.oneof_int);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value_case", out v)) this.value_case = (ValueCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("color", out v)) this.color = global::Jsonif.JsonReader.ReadEnum<global::EnumName.Color>(v);
+            if (obj.TryGetValue("colors", out v)) this.colors = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadEnum<global::EnumName.Color>(x));
+            if (obj.TryGetValue("color_map", out v)) this.color_map = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadEnum<global::EnumName.Color>(x));
+            if (obj.TryGetValue("number", out v)) this.number = (global::EnumName.Number)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("nested", out v)) this.nested = global::Jsonif.JsonReader.ReadEnum<global::EnumName.Test.Nested>(v);
+            if (obj.TryGetValue("opt_color", out v))
+            {
+                this.opt_color = global::Jsonif.JsonReader.ReadEnum<global::EnumName.Color>(v);
+                this._opt_color_case = OptColorCase.kOptColor;
+            }
+            if (obj.TryGetValue("oneof_color", out v)) this.oneof_color = global::Jsonif.JsonReader.ReadEnum<global::EnumName.Color>(v);
+            if (obj.TryGetValue("oneof_int", out v)) this.oneof_int = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredEnumName_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "enum_name.Test", typeof(global::EnumName.Test) },
+        });
+    }
+    
+}
@@ -0,0 +1,13 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Enumpb
+{
+    
+    [System.Serializable]
+    public enum Data
+    {
+        FOO = 0,
+        BAR = 1,
+    }
+    
+}
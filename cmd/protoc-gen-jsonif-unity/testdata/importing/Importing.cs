@@ -0,0 +1,56 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Importing
+{
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        public System.DateTime t = new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc);
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.t.Equals(v.t)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ t.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("t");
+            w.Write(this.t);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("t", out v)) this.t = global::Jsonif.JsonReader.ReadTimestamp(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredImporting_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "importing.Test", typeof(global::Importing.Test) },
+        });
+    }
+    
+}
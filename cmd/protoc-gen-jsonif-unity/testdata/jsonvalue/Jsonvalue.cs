@@ -0,0 +1,153 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Jsonvalue
+{
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kOneofValue = 6,
+            kOneofString = 7,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            oneof_value = default(object);
+            oneof_string = "";
+        }
+        public Dictionary<string, object> struct_value;
+        public object value;
+        public List<object> list_value;
+        public List<object> values = new List<object>();
+        public Dictionary<string, Dictionary<string, object>> structs = new Dictionary<string, Dictionary<string, object>>();
+        public object oneof_value;
+        public void SetOneofValue(object oneof_value)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kOneofValue;
+            this.oneof_value = oneof_value;
+        }
+        public bool HasOneofValue()
+        {
+            return kind_case == KindCase.kOneofValue;
+        }
+        public void ClearOneofValue()
+        {
+            if (kind_case == KindCase.kOneofValue)
+            {
+                ClearKindCase();
+            }
+        }
+        public string oneof_string = "";
+        public void SetOneofString(string oneof_string)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kOneofString;
+            this.oneof_string = oneof_string;
+        }
+        public bool HasOneofString()
+        {
+            return kind_case == KindCase.kOneofString;
+        }
+        public void ClearOneofString()
+        {
+            if (kind_case == KindCase.kOneofString)
+            {
+                ClearKindCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!global::Jsonif.Json.ValueEquals(this.struct_value, v.struct_value)) return false;
+            if (!global::Jsonif.Json.ValueEquals(this.value, v.value)) return false;
+            if (!global::Jsonif.Json.ValueEquals(this.list_value, v.list_value)) return false;
+            if (!this.values.SequenceEqual(v.values, global::Jsonif.JsonValueComparer<object>.Instance)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.structs, v.structs, global::Jsonif.JsonValueComparer<Dictionary<string, object>>.Instance)) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kOneofValue && !global::Jsonif.Json.ValueEquals(this.oneof_value, v.oneof_value)) return false;
+            if (this.kind_case == KindCase.kOneofString && !this.oneof_string.Equals(v.oneof_string)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ global::Jsonif.Json.ValueHashCode(struct_value);
+            hashcode = hashcode * 7302013 ^ global::Jsonif.Json.ValueHashCode(value);
+            hashcode = hashcode * 7302013 ^ global::Jsonif.Json.ValueHashCode(list_value);
+            foreach (var v in this.values) hashcode = hashcode * 7302013 ^ global::Jsonif.Json.ValueHashCode(v);
+            foreach (var kv in this.structs) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ global::Jsonif.Json.ValueHashCode(kv.Value));
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kOneofValue) hashcode = hashcode * 7302013 ^ global::Jsonif.Json.ValueHashCode(oneof_value);
+            if (kind_case == KindCase.kOneofString) hashcode = hashcode * 7302013 ^ oneof_string.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("kind_case");
+            w.Write((int)this.kind_case);
+            w.Key("struct_value");
+            w.WriteValue(this.struct_value);
+            w.Key("value");
+            w.WriteValue(this.value);
+            w.Key("list_value");
+            w.WriteValue(this.list_value);
+            w.Key("values");
+            w.BeginArray();
+            foreach (var x in this.values) w.WriteValue(x);
+            w.EndArray();
+            w.Key("structs");
+            w.BeginObject();
+            foreach (var kv in this.structs)
+            {
+                w.Key(kv.Key);
+                w.WriteValue(kv.Value);
+            }
+            w.EndObject();
+            w.Key("oneof_value");
+            w.WriteValue(this.oneof_value);
+            w.Key("oneof_string");
+            w.Write(this.oneof_string);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("struct_value", out v)) this.struct_value = global::Jsonif.JsonReader.ReadStruct(v);
+            if (obj.TryGetValue("value", out v)) this.value = v;
+            if (obj.TryGetValue("list_value", out v)) this.list_value = global::Jsonif.JsonReader.ReadListValue(v);
+            if (obj.TryGetValue("values", out v)) this.values = global::Jsonif.JsonReader.ReadList(v, x => x);
+            if (obj.TryGetValue("structs", out v)) this.structs = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadStruct(x));
+            if (obj.TryGetValue("oneof_value", out v)) this.oneof_value = v;
+            if (obj.TryGetValue("oneof_string", out v)) this.oneof_string = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredJsonvalue_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "jsonvalue.Test", typeof(global::Jsonvalue.Test) },
+        });
+    }
+    
+}
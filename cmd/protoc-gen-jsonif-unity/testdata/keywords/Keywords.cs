@@ -0,0 +1,225 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Keywords
+{
+    
+    /// <summary>
+    /// 各言語の予約語と同じ名前を使っても、コンパイルできるコードが生成されるか確認する用
+    /// </summary>
+    [System.Serializable]
+    public enum Keyword
+    {
+        @default = 0,
+        @class = 1,
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kNew = 10,
+            kThis = 11,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            @new = default(int);
+            @this = "";
+        }
+        [System.Serializable]
+        public enum IntCase
+        {
+            NOT_SET = 0,
+            kInt = 12,
+        }
+        public IntCase _int_case;
+        public void ClearIntCase()
+        {
+            _int_case = IntCase.NOT_SET;
+            @int = default(int);
+        }
+        public int @class;
+        public string @default = "";
+        public bool delete;
+        public string @namespace = "";
+        public int @operator;
+        public string @object = "";
+        public string @string = "";
+        public List<string> restrict = new List<string>();
+        public global::Keywords.Keyword constructor = new global::Keywords.Keyword();
+        public int @new;
+        public void SetNew(int @new)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kNew;
+            this.@new = @new;
+        }
+        public bool HasNew()
+        {
+            return kind_case == KindCase.kNew;
+        }
+        public void ClearNew()
+        {
+            if (kind_case == KindCase.kNew)
+            {
+                ClearKindCase();
+            }
+        }
+        public string @this = "";
+        public void SetThis(string @this)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kThis;
+            this.@this = @this;
+        }
+        public bool HasThis()
+        {
+            return kind_case == KindCase.kThis;
+        }
+        public void ClearThis()
+        {
+            if (kind_case == KindCase.kThis)
+            {
+                ClearKindCase();
+            }
+        }
+        public int @int;
+        public void SetInt(int @int)
+        {
+            ClearIntCase();
+            _int_case = IntCase.kInt;
+            this.@int = @int;
+        }
+        public bool HasInt()
+        {
+            return _int_case == IntCase.kInt;
+        }
+        public void ClearInt()
+        {
+            if (_int_case == IntCase.kInt)
+            {
+                ClearIntCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.@class.Equals(v.@class)) return false;
+            if (!this.@default.Equals(v.@default)) return false;
+            if (!this.delete.Equals(v.delete)) return false;
+            if (!this.@namespace.Equals(v.@namespace)) return false;
+            if (!this.@operator.Equals(v.@operator)) return false;
+            if (!this.@object.Equals(v.@object)) return false;
+            if (!this.@string.Equals(v.@string)) return false;
+            if (!this.restrict.SequenceEqual(v.restrict)) return false;
+            if (!this.constructor.Equals(v.constructor)) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kNew && !this.@new.Equals(v.@new)) return false;
+            if (this.kind_case == KindCase.kThis && !this.@this.Equals(v.@this)) return false;
+            if (!this._int_case.Equals(v._int_case)) return false;
+            if (this._int_case == IntCase.kInt && !this.@int.Equals(v.@int)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ @class.GetHashCode();
+            hashcode = hashcode * 7302013 ^ @default.GetHashCode();
+            hashcode = hashcode * 7302013 ^ delete.GetHashCode();
+            hashcode = hashcode * 7302013 ^ @namespace.GetHashCode();
+            hashcode = hashcode * 7302013 ^ @operator.GetHashCode();
+            hashcode = hashcode * 7302013 ^ @object.GetHashCode();
+            hashcode = hashcode * 7302013 ^ @string.GetHashCode();
+            foreach (var v in this.restrict) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            hashcode = hashcode * 7302013 ^ constructor.GetHashCode();
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kNew) hashcode = hashcode * 7302013 ^ @new.GetHashCode();
+            if (kind_case == KindCase.kThis) hashcode = hashcode * 7302013 ^ @this.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _int_case.GetHashCode();
+            if (_int_case == IntCase.kInt) hashcode = hashcode * 7302013 ^ @int.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("kind_case");
+            w.Write((int)this.kind_case);
+            w.Key("class");
+            w.Write(this.@class);
+            w.Key("default");
+            w.Write(this.@default);
+            w.Key("delete");
+            w.Write(this.delete);
+            w.Key("namespace");
+            w.Write(this.@namespace);
+            w.Key("operator");
+            w.Write(this.@operator);
+            w.Key("object");
+            w.Write(this.@object);
+            w.Key("string");
+            w.Write(this.@string);
+            w.Key("restrict");
+            w.BeginArray();
+            foreach (var x in this.restrict) w.Write(x);
+            w.EndArray();
+            w.Key("constructor");
+            w.Write((int)this.constructor);
+            w.Key("new");
+            w.Write(this.@new);
+            w.Key("this");
+            w.Write(this.@this);
+            if (this._int_case == IntCase.kInt)
+            {
+                w.Key("int");
+                w.Write(this.@int);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("class", out v)) this.@class = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("default", out v)) this.@default = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("delete", out v)) this.delete = global::Jsonif.JsonReader.ReadBool(v);
+            if (obj.TryGetValue("namespace", out v)) this.@namespace = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("operator", out v)) this.@operator = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("object", out v)) this.@object = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("string", out v)) this.@string = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("restrict", out v)) this.restrict = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadString(x));
+            if (obj.TryGetValue("constructor", out v)) this.constructor = (global::Keywords.Keyword)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("new", out v)) this.@new = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("this", out v)) this.@this = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("int", out v))
+            {
+                this.@int = global::Jsonif.JsonReader.ReadInt(v);
+                this._int_case = IntCase.kInt;
+            }
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredKeywords_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "keywords.Test", typeof(global::Keywords.Test) },
+        });
+    }
+    
+}
@@ -0,0 +1,167 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Mappb
+{
+    
+    [System.Serializable]
+    public enum Enum
+    {
+        FOO = 0,
+        BAR = 1,
+    }
+    
+    [System.Serializable]
+    public class Message : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public override bool Equals(object obj)
+        {
+            var v = obj as Message;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        public Dictionary<string, int> a = new Dictionary<string, int>();
+        public Dictionary<int, string> b = new Dictionary<int, string>();
+        public Dictionary<long, global::Mappb.Enum> c = new Dictionary<long, global::Mappb.Enum>();
+        public Dictionary<string, global::Mappb.Message> d = new Dictionary<string, global::Mappb.Message>();
+        public Dictionary<bool, double> e = new Dictionary<bool, double>();
+        public Dictionary<ulong, bool> f = new Dictionary<ulong, bool>();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.a, v.a)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.b, v.b)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.c, v.c)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.d, v.d)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.e, v.e)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.f, v.f)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            foreach (var kv in this.a) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            foreach (var kv in this.b) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            foreach (var kv in this.c) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            foreach (var kv in this.d) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            foreach (var kv in this.e) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            foreach (var kv in this.f) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("a");
+            w.BeginObject();
+            foreach (var kv in this.a)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("b");
+            w.BeginObject();
+            foreach (var kv in this.b)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("c");
+            w.BeginObject();
+            foreach (var kv in this.c)
+            {
+                w.Key(kv.Key);
+                w.Write((int)kv.Value);
+            }
+            w.EndObject();
+            w.Key("d");
+            w.BeginObject();
+            foreach (var kv in this.d)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("e");
+            w.BeginObject();
+            foreach (var kv in this.e)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("f");
+            w.BeginObject();
+            foreach (var kv in this.f)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("a", out v)) this.a = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadInt(x));
+            if (obj.TryGetValue("b", out v)) this.b = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadInt(k), x => global::Jsonif.JsonReader.ReadString(x));
+            if (obj.TryGetValue("c", out v)) this.c = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadLong(k), x => (global::Mappb.Enum)global::Jsonif.JsonReader.ReadInt(x));
+            if (obj.TryGetValue("d", out v)) this.d = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadObject<global::Mappb.Message>(x));
+            if (obj.TryGetValue("e", out v)) this.e = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadBool(k), x => global::Jsonif.JsonReader.ReadDouble(x));
+            if (obj.TryGetValue("f", out v)) this.f = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadULong(k), x => global::Jsonif.JsonReader.ReadBool(x));
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredMap_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "mappb.Message", typeof(global::Mappb.Message) },
+            { "mappb.Test", typeof(global::Mappb.Test) },
+        });
+    }
+    
+}
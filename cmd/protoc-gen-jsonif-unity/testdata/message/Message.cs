@@ -0,0 +1,62 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Message
+{
+    
+    [System.Serializable]
+    public class Person : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public bool flag;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Person;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            if (!this.flag.Equals(v.flag)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            hashcode = hashcode * 7302013 ^ flag.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.Key("flag");
+            w.Write(this.flag);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("flag", out v)) this.flag = global::Jsonif.JsonReader.ReadBool(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredMessage_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "message.Person", typeof(global::Message.Person) },
+        });
+    }
+    
+}
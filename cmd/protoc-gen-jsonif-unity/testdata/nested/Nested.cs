@@ -0,0 +1,158 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Nested.Nested
+{
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum NestedEnum
+        {
+            FOO = 0,
+            BAR = 1,
+            HOGE = 2,
+        }
+        
+        [System.Serializable]
+        public class NestedMessage : global::Jsonif.IJsonSerializable
+        {
+            public string name = "";
+            public override bool Equals(object obj)
+            {
+                var v = obj as NestedMessage;
+                if (v == null) return false;
+                if (!this.name.Equals(v.name)) return false;
+                return true;
+            }
+            
+            public override int GetHashCode()
+            {
+                int hashcode = 1430287;
+                hashcode = hashcode * 7302013 ^ name.GetHashCode();
+                return hashcode;
+            }
+            
+            public void WriteJson(global::Jsonif.JsonWriter w)
+            {
+                w.BeginObject();
+                w.Key("name");
+                w.Write(this.name);
+                w.EndObject();
+            }
+            
+            public void ReadJson(object json)
+            {
+                var obj = json as Dictionary<string, object>;
+                if (obj == null) throw new System.FormatException("expected object");
+                object v;
+                if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+            }
+            
+        }
+        
+        public global::Nested.Nested.Test.NestedMessage nested_message = new global::Nested.Nested.Test.NestedMessage();
+        public global::Nested.Nested.Test.NestedEnum nested_enum = new global::Nested.Nested.Test.NestedEnum();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.nested_message.Equals(v.nested_message)) return false;
+            if (!this.nested_enum.Equals(v.nested_enum)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ nested_message.GetHashCode();
+            hashcode = hashcode * 7302013 ^ nested_enum.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("nested_message");
+            w.Write(this.nested_message);
+            w.Key("nested_enum");
+            w.Write((int)this.nested_enum);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("nested_message", out v)) this.nested_message = global::Jsonif.JsonReader.ReadObject<global::Nested.Nested.Test.NestedMessage>(v);
+            if (obj.TryGetValue("nested_enum", out v)) this.nested_enum = (global::Nested.Nested.Test.NestedEnum)global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test2 : global::Jsonif.IJsonSerializable
+    {
+        public global::Nested.Nested.Test test = new global::Nested.Nested.Test();
+        public global::Nested.Nested.Test.NestedMessage nested_message = new global::Nested.Nested.Test.NestedMessage();
+        public global::Nested.Nested.Test.NestedEnum nested_enum = new global::Nested.Nested.Test.NestedEnum();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test2;
+            if (v == null) return false;
+            if (!this.test.Equals(v.test)) return false;
+            if (!this.nested_message.Equals(v.nested_message)) return false;
+            if (!this.nested_enum.Equals(v.nested_enum)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ test.GetHashCode();
+            hashcode = hashcode * 7302013 ^ nested_message.GetHashCode();
+            hashcode = hashcode * 7302013 ^ nested_enum.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("test");
+            w.Write(this.test);
+            w.Key("nested_message");
+            w.Write(this.nested_message);
+            w.Key("nested_enum");
+            w.Write((int)this.nested_enum);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("test", out v)) this.test = global::Jsonif.JsonReader.ReadObject<global::Nested.Nested.Test>(v);
+            if (obj.TryGetValue("nested_message", out v)) this.nested_message = global::Jsonif.JsonReader.ReadObject<global::Nested.Nested.Test.NestedMessage>(v);
+            if (obj.TryGetValue("nested_enum", out v)) this.nested_enum = (global::Nested.Nested.Test.NestedEnum)global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredNested_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "nested.nested.Test.NestedMessage", typeof(global::Nested.Nested.Test.NestedMessage) },
+            { "nested.nested.Test", typeof(global::Nested.Nested.Test) },
+            { "nested.nested.Test2", typeof(global::Nested.Nested.Test2) },
+        });
+    }
+    
+}
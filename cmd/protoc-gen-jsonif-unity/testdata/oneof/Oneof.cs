@@ -0,0 +1,210 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Oneof
+{
+    
+    [System.Serializable]
+    public enum Enum
+    {
+        FOO = 0,
+        BAR = 1,
+    }
+    
+    [System.Serializable]
+    public class Message : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public override bool Equals(object obj)
+        {
+            var v = obj as Message;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum TestOneofCase
+        {
+            NOT_SET = 0,
+            kA = 1,
+            kB = 2,
+            kC = 3,
+            kD = 4,
+        }
+        public TestOneofCase test_oneof_case;
+        public void ClearTestOneofCase()
+        {
+            test_oneof_case = TestOneofCase.NOT_SET;
+            a = default(int);
+            b = "";
+            c = new global::Oneof.Enum();
+            d = new global::Oneof.Message();
+        }
+        public int a;
+        public void SetA(int a)
+        {
+            ClearTestOneofCase();
+            test_oneof_case = TestOneofCase.kA;
+            this.a = a;
+        }
+        public bool HasA()
+        {
+            return test_oneof_case == TestOneofCase.kA;
+        }
+        public void ClearA()
+        {
+            if (test_oneof_case == TestOneofCase.kA)
+            {
+                ClearTestOneofCase();
+            }
+        }
+        public string b = "";
+        public void SetB(string b)
+        {
+            ClearTestOneofCase();
+            test_oneof_case = TestOneofCase.kB;
+            this.b = b;
+        }
+        public bool HasB()
+        {
+            return test_oneof_case == TestOneofCase.kB;
+        }
+        public void ClearB()
+        {
+            if (test_oneof_case == TestOneofCase.kB)
+            {
+                ClearTestOneofCase();
+            }
+        }
+        public global::Oneof.Enum c = new global::Oneof.Enum();
+        public void SetC(global::Oneof.Enum c)
+        {
+            ClearTestOneofCase();
+            test_oneof_case = TestOneofCase.kC;
+            this.c = c;
+        }
+        public bool HasC()
+        {
+            return test_oneof_case == TestOneofCase.kC;
+        }
+        public void ClearC()
+        {
+            if (test_oneof_case == TestOneofCase.kC)
+            {
+                ClearTestOneofCase();
+            }
+        }
+        public global::Oneof.Message d = new global::Oneof.Message();
+        public void SetD(global::Oneof.Message d)
+        {
+            ClearTestOneofCase();
+            test_oneof_case = TestOneofCase.kD;
+            this.d = d;
+        }
+        public bool HasD()
+        {
+            return test_oneof_case == TestOneofCase.kD;
+        }
+        public void ClearD()
+        {
+            if (test_oneof_case == TestOneofCase.kD)
+            {
+                ClearTestOneofCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.test_oneof_case.Equals(v.test_oneof_case)) return false;
+            if (this.test_oneof_case == TestOneofCase.kA && !this.a.Equals(v.a)) return false;
+            if (this.test_oneof_case == TestOneofCase.kB && !this.b.Equals(v.b)) return false;
+            if (this.test_oneof_case == TestOneofCase.kC && !this.c.Equals(v.c)) return false;
+            if (this.test_oneof_case == TestOneofCase.kD && !this.d.Equals(v.d)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ test_oneof_case.GetHashCode();
+            if (test_oneof_case == TestOneofCase.kA) hashcode = hashcode * 7302013 ^ a.GetHashCode();
+            if (test_oneof_case == TestOneofCase.kB) hashcode = hashcode * 7302013 ^ b.GetHashCode();
+            if (test_oneof_case == TestOneofCase.kC) hashcode = hashcode * 7302013 ^ c.GetHashCode();
+            if (test_oneof_case == TestOneofCase.kD) hashcode = hashcode * 7302013 ^ d.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("test_oneof_case");
+            w.Write((int)this.test_oneof_case);
+            w.Key("a");
+            w.Write(this.a);
+            w.Key("b");
+            w.Write(this.b);
+            w.Key("c");
+            w.Write((int)this.c);
+            w.Key("d");
+            w.Write(this.d);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("test_oneof_case", out v)) this.test_oneof_case = (TestOneofCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("a", out v)) this.a = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("b", out v)) this.b = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("c", out v)) this.c = (global::Oneof.Enum)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("d", out v)) this.d = global::Jsonif.JsonReader.ReadObject<global::Oneof.Message>(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredOneof_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "oneof.Message", typeof(global::Oneof.Message) },
+            { "oneof.Test", typeof(global::Oneof.Test) },
+        });
+    }
+    
+}
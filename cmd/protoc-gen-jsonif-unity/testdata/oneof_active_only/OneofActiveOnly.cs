@@ -0,0 +1,424 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace OneofActiveOnly
+{
+    
+    [System.Serializable]
+    public class Inner : global::Jsonif.IJsonSerializable
+    {
+        public int value;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Inner;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value");
+            w.Write(this.value);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kName = 1,
+            kNumber = 2,
+            kInner = 3,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            name = "";
+            number = default(int);
+            inner = new global::OneofActiveOnly.Inner();
+        }
+        /// <summary>
+        /// メッセージの設定よりも oneof の設定が優先される
+        /// </summary>
+        [System.Serializable]
+        public enum LegacyCase
+        {
+            NOT_SET = 0,
+            kLegacyName = 4,
+            kLegacyNumber = 5,
+        }
+        public LegacyCase legacy_case;
+        public void ClearLegacyCase()
+        {
+            legacy_case = LegacyCase.NOT_SET;
+            legacy_name = "";
+            legacy_number = default(int);
+        }
+        [System.Serializable]
+        public enum OptCase
+        {
+            NOT_SET = 0,
+            kOpt = 6,
+        }
+        public OptCase _opt_case;
+        public void ClearOptCase()
+        {
+            _opt_case = OptCase.NOT_SET;
+            opt = default(int);
+        }
+        public string name = "";
+        public void SetName(string name)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kName;
+            this.name = name;
+        }
+        public bool HasName()
+        {
+            return kind_case == KindCase.kName;
+        }
+        public void ClearName()
+        {
+            if (kind_case == KindCase.kName)
+            {
+                ClearKindCase();
+            }
+        }
+        public int number;
+        public void SetNumber(int number)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kNumber;
+            this.number = number;
+        }
+        public bool HasNumber()
+        {
+            return kind_case == KindCase.kNumber;
+        }
+        public void ClearNumber()
+        {
+            if (kind_case == KindCase.kNumber)
+            {
+                ClearKindCase();
+            }
+        }
+        public global::OneofActiveOnly.Inner inner = new global::OneofActiveOnly.Inner();
+        public void SetInner(global::OneofActiveOnly.Inner inner)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kInner;
+            this.inner = inner;
+        }
+        public bool HasInner()
+        {
+            return kind_case == KindCase.kInner;
+        }
+        public void ClearInner()
+        {
+            if (kind_case == KindCase.kInner)
+            {
+                ClearKindCase();
+            }
+        }
+        public string legacy_name = "";
+        public void SetLegacyName(string legacy_name)
+        {
+            ClearLegacyCase();
+            legacy_case = LegacyCase.kLegacyName;
+            this.legacy_name = legacy_name;
+        }
+        public bool HasLegacyName()
+        {
+            return legacy_case == LegacyCase.kLegacyName;
+        }
+        public void ClearLegacyName()
+        {
+            if (legacy_case == LegacyCase.kLegacyName)
+            {
+                ClearLegacyCase();
+            }
+        }
+        public int legacy_number;
+        public void SetLegacyNumber(int legacy_number)
+        {
+            ClearLegacyCase();
+            legacy_case = LegacyCase.kLegacyNumber;
+            this.legacy_number = legacy_number;
+        }
+        public bool HasLegacyNumber()
+        {
+            return legacy_case == LegacyCase.kLegacyNumber;
+        }
+        public void ClearLegacyNumber()
+        {
+            if (legacy_case == LegacyCase.kLegacyNumber)
+            {
+                ClearLegacyCase();
+            }
+        }
+        public int opt;
+        public void SetOpt(int opt)
+        {
+            ClearOptCase();
+            _opt_case = OptCase.kOpt;
+            this.opt = opt;
+        }
+        public bool HasOpt()
+        {
+            return _opt_case == OptCase.kOpt;
+        }
+        public void ClearOpt()
+        {
+            if (_opt_case == OptCase.kOpt)
+            {
+                ClearOptCase();
+            }
+        }
+        public int value;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kName && !this.name.Equals(v.name)) return false;
+            if (this.kind_case == KindCase.kNumber && !this.number.Equals(v.number)) return false;
+            if (this.kind_case == KindCase.kInner && !this.inner.Equals(v.inner)) return false;
+            if (!this.legacy_case.Equals(v.legacy_case)) return false;
+            if (this.legacy_case == LegacyCase.kLegacyName && !this.legacy_name.Equals(v.legacy_name)) return false;
+            if (this.legacy_case == LegacyCase.kLegacyNumber && !this.legacy_number.Equals(v.legacy_number)) return false;
+            if (!this._opt_case.Equals(v._opt_case)) return false;
+            if (this._opt_case == OptCase.kOpt && !this.opt.Equals(v.opt)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kName) hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            if (kind_case == KindCase.kNumber) hashcode = hashcode * 7302013 ^ number.GetHashCode();
+            if (kind_case == KindCase.kInner) hashcode = hashcode * 7302013 ^ inner.GetHashCode();
+            hashcode = hashcode * 7302013 ^ legacy_case.GetHashCode();
+            if (legacy_case == LegacyCase.kLegacyName) hashcode = hashcode * 7302013 ^ legacy_name.GetHashCode();
+            if (legacy_case == LegacyCase.kLegacyNumber) hashcode = hashcode * 7302013 ^ legacy_number.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _opt_case.GetHashCode();
+            if (_opt_case == OptCase.kOpt) hashcode = hashcode * 7302013 ^ opt.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("legacy_case");
+            w.Write((int)this.legacy_case);
+            if (this.kind_case == KindCase.kName)
+            {
+                w.Key("name");
+                w.Write(this.name);
+            }
+            if (this.kind_case == KindCase.kNumber)
+            {
+                w.Key("number");
+                w.Write(this.number);
+            }
+            if (this.kind_case == KindCase.kInner)
+            {
+                w.Key("inner");
+                w.Write(this.inner);
+            }
+            w.Key("legacy_name");
+            w.Write(this.legacy_name);
+            w.Key("legacy_number");
+            w.Write(this.legacy_number);
+            if (this._opt_case == OptCase.kOpt)
+            {
+                w.Key("opt");
+                w.Write(this.opt);
+            }
+            w.Key("value");
+            w.Write(this.value);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("legacy_case", out v)) this.legacy_case = (LegacyCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("name", out v))
+            {
+                this.name = global::Jsonif.JsonReader.ReadString(v);
+                this.kind_case = KindCase.kName;
+            }
+            if (obj.TryGetValue("number", out v))
+            {
+                this.number = global::Jsonif.JsonReader.ReadInt(v);
+                this.kind_case = KindCase.kNumber;
+            }
+            if (obj.TryGetValue("inner", out v))
+            {
+                this.inner = global::Jsonif.JsonReader.ReadObject<global::OneofActiveOnly.Inner>(v);
+                this.kind_case = KindCase.kInner;
+            }
+            if (obj.TryGetValue("legacy_name", out v)) this.legacy_name = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("legacy_number", out v)) this.legacy_number = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("opt", out v))
+            {
+                this.opt = global::Jsonif.JsonReader.ReadInt(v);
+                this._opt_case = OptCase.kOpt;
+            }
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test2 : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kName = 1,
+            kNumber = 2,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            name = "";
+            number = default(int);
+        }
+        public string name = "";
+        public void SetName(string name)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kName;
+            this.name = name;
+        }
+        public bool HasName()
+        {
+            return kind_case == KindCase.kName;
+        }
+        public void ClearName()
+        {
+            if (kind_case == KindCase.kName)
+            {
+                ClearKindCase();
+            }
+        }
+        public int number;
+        public void SetNumber(int number)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kNumber;
+            this.number = number;
+        }
+        public bool HasNumber()
+        {
+            return kind_case == KindCase.kNumber;
+        }
+        public void ClearNumber()
+        {
+            if (kind_case == KindCase.kNumber)
+            {
+                ClearKindCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test2;
+            if (v == null) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kName && !this.name.Equals(v.name)) return false;
+            if (this.kind_case == KindCase.kNumber && !this.number.Equals(v.number)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kName) hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            if (kind_case == KindCase.kNumber) hashcode = hashcode * 7302013 ^ number.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            if (this.kind_case == KindCase.kName)
+            {
+                w.Key("name");
+                w.Write(this.name);
+            }
+            if (this.kind_case == KindCase.kNumber)
+            {
+                w.Key("number");
+                w.Write(this.number);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v))
+            {
+                this.name = global::Jsonif.JsonReader.ReadString(v);
+                this.kind_case = KindCase.kName;
+            }
+            if (obj.TryGetValue("number", out v))
+            {
+                this.number = global::Jsonif.JsonReader.ReadInt(v);
+                this.kind_case = KindCase.kNumber;
+            }
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredOneofActiveOnly_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "oneof_active_only.Inner", typeof(global::OneofActiveOnly.Inner) },
+            { "oneof_active_only.Test", typeof(global::OneofActiveOnly.Test) },
+            { "oneof_active_only.Test2", typeof(global::OneofActiveOnly.Test2) },
+        });
+    }
+    
+}
@@ -0,0 +1,271 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Optional
+{
+    
+    [System.Serializable]
+    public enum Enum
+    {
+        FOO = 0,
+        BAR = 1,
+    }
+    
+    [System.Serializable]
+    public class Message : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public override bool Equals(object obj)
+        {
+            var v = obj as Message;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum ACase
+        {
+            NOT_SET = 0,
+            kA = 1,
+        }
+        public ACase _a_case;
+        public void ClearACase()
+        {
+            _a_case = ACase.NOT_SET;
+            a = default(long);
+        }
+        [System.Serializable]
+        public enum BCase
+        {
+            NOT_SET = 0,
+            kB = 3,
+        }
+        public BCase _b_case;
+        public void ClearBCase()
+        {
+            _b_case = BCase.NOT_SET;
+            b = "";
+        }
+        [System.Serializable]
+        public enum CCase
+        {
+            NOT_SET = 0,
+            kC = 4,
+        }
+        public CCase _c_case;
+        public void ClearCCase()
+        {
+            _c_case = CCase.NOT_SET;
+            c = new global::Optional.Enum();
+        }
+        [System.Serializable]
+        public enum DCase
+        {
+            NOT_SET = 0,
+            kD = 5,
+        }
+        public DCase _d_case;
+        public void ClearDCase()
+        {
+            _d_case = DCase.NOT_SET;
+            d = new global::Optional.Message();
+        }
+        public long a;
+        public void SetA(long a)
+        {
+            ClearACase();
+            _a_case = ACase.kA;
+            this.a = a;
+        }
+        public bool HasA()
+        {
+            return _a_case == ACase.kA;
+        }
+        public void ClearA()
+        {
+            if (_a_case == ACase.kA)
+            {
+                ClearACase();
+            }
+        }
+        public string b = "";
+        public void SetB(string b)
+        {
+            ClearBCase();
+            _b_case = BCase.kB;
+            this.b = b;
+        }
+        public bool HasB()
+        {
+            return _b_case == BCase.kB;
+        }
+        public void ClearB()
+        {
+            if (_b_case == BCase.kB)
+            {
+                ClearBCase();
+            }
+        }
+        public global::Optional.Enum c = new global::Optional.Enum();
+        public void SetC(global::Optional.Enum c)
+        {
+            ClearCCase();
+            _c_case = CCase.kC;
+            this.c = c;
+        }
+        public bool HasC()
+        {
+            return _c_case == CCase.kC;
+        }
+        public void ClearC()
+        {
+            if (_c_case == CCase.kC)
+            {
+                ClearCCase();
+            }
+        }
+        public global::Optional.Message d = new global::Optional.Message();
+        public void SetD(global::Optional.Message d)
+        {
+            ClearDCase();
+            _d_case = DCase.kD;
+            this.d = d;
+        }
+        public bool HasD()
+        {
+            return _d_case == DCase.kD;
+        }
+        public void ClearD()
+        {
+            if (_d_case == DCase.kD)
+            {
+                ClearDCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this._a_case.Equals(v._a_case)) return false;
+            if (this._a_case == ACase.kA && !this.a.Equals(v.a)) return false;
+            if (!this._b_case.Equals(v._b_case)) return false;
+            if (this._b_case == BCase.kB && !this.b.Equals(v.b)) return false;
+            if (!this._c_case.Equals(v._c_case)) return false;
+            if (this._c_case == CCase.kC && !this.c.Equals(v.c)) return false;
+            if (!this._d_case.Equals(v._d_case)) return false;
+            if (this._d_case == DCase.kD && !this.d.Equals(v.d)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ _a_case.GetHashCode();
+            if (_a_case == ACase.kA) hashcode = hashcode * 7302013 ^ a.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _b_case.GetHashCode();
+            if (_b_case == BCase.kB) hashcode = hashcode * 7302013 ^ b.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _c_case.GetHashCode();
+            if (_c_case == CCase.kC) hashcode = hashcode * 7302013 ^ c.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _d_case.GetHashCode();
+            if (_d_case == DCase.kD) hashcode = hashcode * 7302013 ^ d.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            if (this._a_case == ACase.kA)
+            {
+                w.Key("a");
+                w.Write(this.a);
+            }
+            if (this._b_case == BCase.kB)
+            {
+                w.Key("b");
+                w.Write(this.b);
+            }
+            if (this._c_case == CCase.kC)
+            {
+                w.Key("c");
+                w.Write((int)this.c);
+            }
+            if (this._d_case == DCase.kD)
+            {
+                w.Key("d");
+                w.Write(this.d);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("a", out v))
+            {
+                this.a = global::Jsonif.JsonReader.ReadLong(v);
+                this._a_case = ACase.kA;
+            }
+            if (obj.TryGetValue("b", out v))
+            {
+                this.b = global::Jsonif.JsonReader.ReadString(v);
+                this._b_case = BCase.kB;
+            }
+            if (obj.TryGetValue("c", out v))
+            {
+                this.c = (global::Optional.Enum)global::Jsonif.JsonReader.ReadInt(v);
+                this._c_case = CCase.kC;
+            }
+            if (obj.TryGetValue("d", out v))
+            {
+                this.d = global::Jsonif.JsonReader.ReadObject<global::Optional.Message>(v);
+                this._d_case = DCase.kD;
+            }
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredOptional_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "optional.Message", typeof(global::Optional.Message) },
+            { "optional.Test", typeof(global::Optional.Test) },
+        });
+    }
+    
+}
@@ -0,0 +1,127 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Repeated
+{
+    
+    [System.Serializable]
+    public enum Enum
+    {
+        FOO = 0,
+        BAR = 1,
+    }
+    
+    [System.Serializable]
+    public class Message : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public override bool Equals(object obj)
+        {
+            var v = obj as Message;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        public List<int> a = new List<int>();
+        public List<string> b = new List<string>();
+        public List<global::Repeated.Enum> c = new List<global::Repeated.Enum>();
+        public List<global::Repeated.Message> d = new List<global::Repeated.Message>();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.a.SequenceEqual(v.a)) return false;
+            if (!this.b.SequenceEqual(v.b)) return false;
+            if (!this.c.SequenceEqual(v.c)) return false;
+            if (!this.d.SequenceEqual(v.d)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            foreach (var v in this.a) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var v in this.b) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var v in this.c) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var v in this.d) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("a");
+            w.BeginArray();
+            foreach (var x in this.a) w.Write(x);
+            w.EndArray();
+            w.Key("b");
+            w.BeginArray();
+            foreach (var x in this.b) w.Write(x);
+            w.EndArray();
+            w.Key("c");
+            w.BeginArray();
+            foreach (var x in this.c) w.Write((int)x);
+            w.EndArray();
+            w.Key("d");
+            w.BeginArray();
+            foreach (var x in this.d) w.Write(x);
+            w.EndArray();
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("a", out v)) this.a = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadInt(x));
+            if (obj.TryGetValue("b", out v)) this.b = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadString(x));
+            if (obj.TryGetValue("c", out v)) this.c = global::Jsonif.JsonReader.ReadList(v, x => (global::Repeated.Enum)global::Jsonif.JsonReader.ReadInt(x));
+            if (obj.TryGetValue("d", out v)) this.d = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadObject<global::Repeated.Message>(x));
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredRepeated_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "repeated.Message", typeof(global::Repeated.Message) },
+            { "repeated.Test", typeof(global::Repeated.Test) },
+        });
+    }
+    
+}
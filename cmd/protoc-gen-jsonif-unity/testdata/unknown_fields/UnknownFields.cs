@@ -0,0 +1,367 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace UnknownFields
+{
+    
+    [System.Serializable]
+    public class Inner : global::Jsonif.IJsonSerializable
+    {
+        public int value;
+        // JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+        public Dictionary<string, object> jsonif_unknown_fields = new Dictionary<string, object>();
+        static readonly HashSet<string> JsonifKnownKeys = new HashSet<string> { "value" };
+        public override bool Equals(object obj)
+        {
+            var v = obj as Inner;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.jsonif_unknown_fields, v.jsonif_unknown_fields, global::Jsonif.JsonValueComparer<object>.Instance)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            foreach (var kv in this.jsonif_unknown_fields) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ global::Jsonif.Json.ValueHashCode(kv.Value));
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value");
+            w.Write(this.value);
+            foreach (var kv in this.jsonif_unknown_fields)
+            {
+                if (JsonifKnownKeys.Contains(kv.Key)) continue;
+                w.Key(kv.Key);
+                w.WriteValue(kv.Value);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+            this.jsonif_unknown_fields = new Dictionary<string, object>();
+            foreach (var kv in obj)
+            {
+                if (!JsonifKnownKeys.Contains(kv.Key)) this.jsonif_unknown_fields[kv.Key] = kv.Value;
+            }
+        }
+        
+    }
+    
+    /// <summary>
+    /// 知らないキーを保持して、そのまま出力する
+    /// </summary>
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum KindCase
+        {
+            NOT_SET = 0,
+            kNumber = 6,
+            kText = 7,
+        }
+        public KindCase kind_case;
+        public void ClearKindCase()
+        {
+            kind_case = KindCase.NOT_SET;
+            number = default(int);
+            text = "";
+        }
+        [System.Serializable]
+        public enum OptCase
+        {
+            NOT_SET = 0,
+            kOpt = 8,
+        }
+        public OptCase _opt_case;
+        public void ClearOptCase()
+        {
+            _opt_case = OptCase.NOT_SET;
+            opt = default(int);
+        }
+        public int id;
+        public string name = "";
+        public global::UnknownFields.Inner inner = new global::UnknownFields.Inner();
+        public List<global::UnknownFields.Inner> inners = new List<global::UnknownFields.Inner>();
+        public Dictionary<string, global::UnknownFields.Inner> inner_map = new Dictionary<string, global::UnknownFields.Inner>();
+        public int number;
+        public void SetNumber(int number)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kNumber;
+            this.number = number;
+        }
+        public bool HasNumber()
+        {
+            return kind_case == KindCase.kNumber;
+        }
+        public void ClearNumber()
+        {
+            if (kind_case == KindCase.kNumber)
+            {
+                ClearKindCase();
+            }
+        }
+        public string text = "";
+        public void SetText(string text)
+        {
+            ClearKindCase();
+            kind_case = KindCase.kText;
+            this.text = text;
+        }
+        public bool HasText()
+        {
+            return kind_case == KindCase.kText;
+        }
+        public void ClearText()
+        {
+            if (kind_case == KindCase.kText)
+            {
+                ClearKindCase();
+            }
+        }
+        public int opt;
+        public void SetOpt(int opt)
+        {
+            ClearOptCase();
+            _opt_case = OptCase.kOpt;
+            this.opt = opt;
+        }
+        public bool HasOpt()
+        {
+            return _opt_case == OptCase.kOpt;
+        }
+        public void ClearOpt()
+        {
+            if (_opt_case == OptCase.kOpt)
+            {
+                ClearOptCase();
+            }
+        }
+        // JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+        public Dictionary<string, object> jsonif_unknown_fields = new Dictionary<string, object>();
+        static readonly HashSet<string> JsonifKnownKeys = new HashSet<string> { "kind_case", "id", "name", "inner", "inners", "inner_map", "number", "text", "opt" };
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.id.Equals(v.id)) return false;
+            if (!this.name.Equals(v.name)) return false;
+            if (!this.inner.Equals(v.inner)) return false;
+            if (!this.inners.SequenceEqual(v.inners)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.inner_map, v.inner_map)) return false;
+            if (!this.kind_case.Equals(v.kind_case)) return false;
+            if (this.kind_case == KindCase.kNumber && !this.number.Equals(v.number)) return false;
+            if (this.kind_case == KindCase.kText && !this.text.Equals(v.text)) return false;
+            if (!this._opt_case.Equals(v._opt_case)) return false;
+            if (this._opt_case == OptCase.kOpt && !this.opt.Equals(v.opt)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.jsonif_unknown_fields, v.jsonif_unknown_fields, global::Jsonif.JsonValueComparer<object>.Instance)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ id.GetHashCode();
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            hashcode = hashcode * 7302013 ^ inner.GetHashCode();
+            foreach (var v in this.inners) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var kv in this.inner_map) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ kind_case.GetHashCode();
+            if (kind_case == KindCase.kNumber) hashcode = hashcode * 7302013 ^ number.GetHashCode();
+            if (kind_case == KindCase.kText) hashcode = hashcode * 7302013 ^ text.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _opt_case.GetHashCode();
+            if (_opt_case == OptCase.kOpt) hashcode = hashcode * 7302013 ^ opt.GetHashCode();
+            foreach (var kv in this.jsonif_unknown_fields) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ global::Jsonif.Json.ValueHashCode(kv.Value));
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("kind_case");
+            w.Write((int)this.kind_case);
+            w.Key("id");
+            w.Write(this.id);
+            w.Key("name");
+            w.Write(this.name);
+            w.Key("inner");
+            w.Write(this.inner);
+            w.Key("inners");
+            w.BeginArray();
+            foreach (var x in this.inners) w.Write(x);
+            w.EndArray();
+            w.Key("inner_map");
+            w.BeginObject();
+            foreach (var kv in this.inner_map)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("number");
+            w.Write(this.number);
+            w.Key("text");
+            w.Write(this.text);
+            if (this._opt_case == OptCase.kOpt)
+            {
+                w.Key("opt");
+                w.Write(this.opt);
+            }
+            foreach (var kv in this.jsonif_unknown_fields)
+            {
+                if (JsonifKnownKeys.Contains(kv.Key)) continue;
+                w.Key(kv.Key);
+                w.WriteValue(kv.Value);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("kind_case", out v)) this.kind_case = (KindCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("id", out v)) this.id = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("inner", out v)) this.inner = global::Jsonif.JsonReader.ReadObject<global::UnknownFields.Inner>(v);
+            if (obj.TryGetValue("inners", out v)) this.inners = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadObject<global::UnknownFields.Inner>(x));
+            if (obj.TryGetValue("inner_map", out v)) this.inner_map = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadObject<global::UnknownFields.Inner>(x));
+            if (obj.TryGetValue("number", out v)) this.number = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("text", out v)) this.text = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("opt", out v))
+            {
+                this.opt = global::Jsonif.JsonReader.ReadInt(v);
+                this._opt_case = OptCase.kOpt;
+            }
+            this.jsonif_unknown_fields = new Dictionary<string, object>();
+            foreach (var kv in obj)
+            {
+                if (!JsonifKnownKeys.Contains(kv.Key)) this.jsonif_unknown_fields[kv.Key] = kv.Value;
+            }
+        }
+        
+    }
+    
+    /// <summary>
+    /// 知らないキーがあったら捨てる
+    /// </summary>
+    [System.Serializable]
+    public class Plain : global::Jsonif.IJsonSerializable
+    {
+        public int id;
+        public global::UnknownFields.Test test = new global::UnknownFields.Test();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Plain;
+            if (v == null) return false;
+            if (!this.id.Equals(v.id)) return false;
+            if (!this.test.Equals(v.test)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ id.GetHashCode();
+            hashcode = hashcode * 7302013 ^ test.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("id");
+            w.Write(this.id);
+            w.Key("test");
+            w.Write(this.test);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("id", out v)) this.id = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("test", out v)) this.test = global::Jsonif.JsonReader.ReadObject<global::UnknownFields.Test>(v);
+        }
+        
+    }
+    
+    /// <summary>
+    /// フィールドが無くても知らないキーは保持する
+    /// </summary>
+    [System.Serializable]
+    public class Empty : global::Jsonif.IJsonSerializable
+    {
+        // JSON から読み込んだ時の知らないキーとその値（JSON に変換する際にそのまま出力する）
+        public Dictionary<string, object> jsonif_unknown_fields = new Dictionary<string, object>();
+        static readonly HashSet<string> JsonifKnownKeys = new HashSet<string>();
+        public override bool Equals(object obj)
+        {
+            var v = obj as Empty;
+            if (v == null) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.jsonif_unknown_fields, v.jsonif_unknown_fields, global::Jsonif.JsonValueComparer<object>.Instance)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            foreach (var kv in this.jsonif_unknown_fields) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ global::Jsonif.Json.ValueHashCode(kv.Value));
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            foreach (var kv in this.jsonif_unknown_fields)
+            {
+                if (JsonifKnownKeys.Contains(kv.Key)) continue;
+                w.Key(kv.Key);
+                w.WriteValue(kv.Value);
+            }
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            this.jsonif_unknown_fields = new Dictionary<string, object>();
+            foreach (var kv in obj)
+            {
+                if (!JsonifKnownKeys.Contains(kv.Key)) this.jsonif_unknown_fields[kv.Key] = kv.Value;
+            }
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredUnknownFields_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "unknown_fields.Inner", typeof(global::UnknownFields.Inner) },
+            { "unknown_fields.Test", typeof(global::UnknownFields.Test) },
+            { "unknown_fields.Plain", typeof(global::UnknownFields.Plain) },
+            { "unknown_fields.Empty", typeof(global::UnknownFields.Empty) },
+        });
+    }
+    
+}
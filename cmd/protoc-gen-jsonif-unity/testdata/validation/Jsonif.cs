@@ -0,0 +1,795 @@
+using System.Collections.Generic;
+using System.Globalization;
+using System.Text;
+using System.Text.RegularExpressions;
+using UnityEngine;
+
+namespace Jsonif
+{
+    
+    // JsonUtility では Dictionary などを扱えないので、生成したクラスは自前でシリアライズする
+    public interface IJsonSerializable
+    {
+        void WriteJson(JsonWriter w);
+        void ReadJson(object json);
+    }
+    
+    public class JsonNumber
+    {
+        public readonly string Text;
+        public JsonNumber(string text)
+        {
+            Text = text;
+        }
+    }
+    
+    // メッセージの完全修飾名と型の対応表
+    // 生成したファイルごとに、partial class の静的フィールドの初期化でメッセージを登録する
+    public static partial class TypeRegistry
+    {
+        // 静的フィールドの初期化の順序はファイルごとに不定なので、初期化子は書かずに Register で作る
+        static Dictionary<string, System.Type> types;
+        static Dictionary<System.Type, string> names;
+        
+        static bool Register(Dictionary<string, System.Type> ts)
+        {
+            if (types == null)
+            {
+                types = new Dictionary<string, System.Type>();
+                names = new Dictionary<System.Type, string>();
+            }
+            foreach (var kv in ts)
+            {
+                types[kv.Key] = kv.Value;
+                names[kv.Value] = kv.Key;
+            }
+            return true;
+        }
+        
+        // 登録されていない場合は null を返す
+        public static System.Type Find(string name)
+        {
+            System.Type t;
+            if (types == null || !types.TryGetValue(name, out t)) return null;
+            return t;
+        }
+        public static string GetName(System.Type t)
+        {
+            string name;
+            if (names == null || !names.TryGetValue(t, out name)) throw new System.InvalidOperationException(t + " is not registered");
+            return name;
+        }
+    }
+    
+    // google.protobuf.Any
+    // "@type" を含む JSON のオブジェクトをそのまま保持する
+    public class Any
+    {
+        public readonly Dictionary<string, object> Value;
+        
+        public Any(Dictionary<string, object> value)
+        {
+            if (!(value["@type"] is string)) throw new System.ArgumentException("@type must be a string");
+            Value = value;
+        }
+        
+        public string TypeUrl { get { return (string)Value["@type"]; } }
+        // 型の URL から取り出したメッセージの完全修飾名
+        public string TypeName { get { return TypeUrl.Substring(TypeUrl.LastIndexOf('/') + 1); } }
+        
+        // メッセージを Any に詰める
+        public static Any Pack(IJsonSerializable v)
+        {
+            var value = new Dictionary<string, object>();
+            value["@type"] = "type.googleapis.com/" + TypeRegistry.GetName(v.GetType());
+            var w = new JsonWriter();
+            v.WriteJson(w);
+            foreach (var kv in (Dictionary<string, object>)JsonReader.Parse(w.ToString())) value[kv.Key] = kv.Value;
+            return new Any(value);
+        }
+        public bool Is<T>() where T : IJsonSerializable
+        {
+            return TypeName == TypeRegistry.GetName(typeof(T));
+        }
+        // T ではない場合は InvalidOperationException を投げる
+        public T Unpack<T>() where T : IJsonSerializable, new()
+        {
+            if (!Is<T>()) throw new System.InvalidOperationException("type mismatch: " + TypeName);
+            return JsonReader.ReadObject<T>(Value);
+        }
+        // TypeRegistry から型を探して取り出す
+        public IJsonSerializable Unpack()
+        {
+            var t = TypeRegistry.Find(TypeName);
+            if (t == null) throw new System.InvalidOperationException("unknown type: " + TypeName);
+            var v = (IJsonSerializable)System.Activator.CreateInstance(t);
+            v.ReadJson(Value);
+            return v;
+        }
+        
+        public override bool Equals(object obj)
+        {
+            var v = obj as Any;
+            return v != null && Json.ValueEquals(Value, v.Value);
+        }
+        public override int GetHashCode()
+        {
+            return Json.ValueHashCode(Value);
+        }
+    }
+    
+    // google.protobuf.Struct, ListValue, Value の値を中身で比較する
+    public class JsonValueComparer<T> : IEqualityComparer<T>
+    {
+        public static readonly JsonValueComparer<T> Instance = new JsonValueComparer<T>();
+        public bool Equals(T a, T b) { return Json.ValueEquals(a, b); }
+        public int GetHashCode(T v) { return Json.ValueHashCode(v); }
+    }
+    
+    // bytes の値（byte[]）を中身で比較する
+    public class BytesComparer : IEqualityComparer<byte[]>
+    {
+        public static readonly BytesComparer Instance = new BytesComparer();
+        public bool Equals(byte[] a, byte[] b) { return Json.BytesEquals(a, b); }
+        public int GetHashCode(byte[] v) { return Json.BytesHashCode(v); }
+    }
+    
+    public class JsonWriter
+    {
+        StringBuilder sb = new StringBuilder();
+        bool comma = false;
+        
+        void Separate()
+        {
+            if (comma) sb.Append(',');
+            comma = false;
+        }
+        void WriteString(string v)
+        {
+            sb.Append('"');
+            foreach (var c in v)
+            {
+                switch (c)
+                {
+                    case '"': sb.Append("\\\""); break;
+                    case '\\': sb.Append("\\\\"); break;
+                    case '\b': sb.Append("\\b"); break;
+                    case '\f': sb.Append("\\f"); break;
+                    case '\n': sb.Append("\\n"); break;
+                    case '\r': sb.Append("\\r"); break;
+                    case '\t': sb.Append("\\t"); break;
+                    default:
+                        if (c < 0x20)
+                        {
+                            sb.Append("\\u");
+                            sb.Append(((int)c).ToString("x4"));
+                        }
+                        else
+                        {
+                            sb.Append(c);
+                        }
+                        break;
+                }
+            }
+            sb.Append('"');
+        }
+        void WriteRaw(string v)
+        {
+            Separate();
+            sb.Append(v);
+            comma = true;
+        }
+        
+        public void BeginObject()
+        {
+            Separate();
+            sb.Append('{');
+        }
+        public void EndObject()
+        {
+            sb.Append('}');
+            comma = true;
+        }
+        public void BeginArray()
+        {
+            Separate();
+            sb.Append('[');
+        }
+        public void EndArray()
+        {
+            sb.Append(']');
+            comma = true;
+        }
+        public void Key(string k)
+        {
+            Separate();
+            WriteString(k);
+            sb.Append(':');
+        }
+        // JSON のキーは文字列なので、map のキーは文字列に変換する
+        public void Key(bool k) { Key(k ? "true" : "false"); }
+        public void Key(int k) { Key(k.ToString(CultureInfo.InvariantCulture)); }
+        public void Key(uint k) { Key(k.ToString(CultureInfo.InvariantCulture)); }
+        public void Key(long k) { Key(k.ToString(CultureInfo.InvariantCulture)); }
+        public void Key(ulong k) { Key(k.ToString(CultureInfo.InvariantCulture)); }
+        public void Write(string v)
+        {
+            Separate();
+            WriteString(v ?? "");
+            comma = true;
+        }
+        public void Write(bool v) { WriteRaw(v ? "true" : "false"); }
+        public void Write(int v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }
+        public void Write(uint v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }
+        public void Write(long v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }
+        public void Write(ulong v) { WriteRaw(v.ToString(CultureInfo.InvariantCulture)); }
+        public void Write(float v) { WriteRaw(v.ToString("R", CultureInfo.InvariantCulture)); }
+        public void Write(double v) { WriteRaw(v.ToString("R", CultureInfo.InvariantCulture)); }
+        public void WriteNull() { WriteRaw("null"); }
+        // ラッパー型（google.protobuf.Int32Value など）の値が無い場合は null を書き出す
+        public void Write(bool? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void Write(int? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void Write(uint? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void Write(long? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void Write(ulong? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void Write(float? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void Write(double? v) { if (v.HasValue) Write(v.Value); else WriteNull(); }
+        public void WriteNullable(string v) { if (v != null) Write(v); else WriteNull(); }
+        // bytes はパディング付きの base64 の文字列にする
+        public void Write(byte[] v) { Write(System.Convert.ToBase64String(v ?? new byte[0])); }
+        // base64url の場合は URL セーフな base64（パディング無し）にする
+        public void WriteBase64Url(byte[] v) { Write(System.Convert.ToBase64String(v ?? new byte[0]).TrimEnd('=').Replace('+', '-').Replace('/', '_')); }
+        public void WriteNullable(byte[] v) { if (v != null) Write(v); else WriteNull(); }
+        public void WriteNullableBase64Url(byte[] v) { if (v != null) WriteBase64Url(v); else WriteNull(); }
+        // canonical の場合、int64 や uint64 は文字列にする
+        public void WriteAsString(long v) { Write(v.ToString(CultureInfo.InvariantCulture)); }
+        public void WriteAsString(ulong v) { Write(v.ToString(CultureInfo.InvariantCulture)); }
+        public void WriteAsString(long? v) { if (v.HasValue) WriteAsString(v.Value); else WriteNull(); }
+        public void WriteAsString(ulong? v) { if (v.HasValue) WriteAsString(v.Value); else WriteNull(); }
+        // canonical の場合、enum は値の名前にする（未知の値は数値のまま）
+        public void WriteEnum<T>(T v) where T : struct
+        {
+            if (System.Enum.IsDefined(typeof(T), v)) Write(v.ToString());
+            else Write(System.Convert.ToInt32(v));
+        }
+        // google.protobuf.Timestamp は RFC 3339 形式の UTC の文字列にする
+        // DateTimeKind.Unspecified の場合は UTC として扱う
+        public void Write(System.DateTime v)
+        {
+            if (v.Kind == System.DateTimeKind.Local) v = v.ToUniversalTime();
+            long nanos = v.Ticks % System.TimeSpan.TicksPerSecond * 100;
+            Write(v.ToString("yyyy-MM-dd'T'HH:mm:ss", CultureInfo.InvariantCulture) + FormatNanos(nanos) + "Z");
+        }
+        // google.protobuf.Duration は "1.5s" のような文字列にする
+        public void Write(System.TimeSpan v)
+        {
+            ulong ticks = v.Ticks < 0 ? (ulong)(-(v.Ticks + 1)) + 1 : (ulong)v.Ticks;
+            ulong seconds = ticks / System.TimeSpan.TicksPerSecond;
+            long nanos = (long)(ticks % System.TimeSpan.TicksPerSecond) * 100;
+            Write((v.Ticks < 0 ? "-" : "") + seconds.ToString(CultureInfo.InvariantCulture) + FormatNanos(nanos) + "s");
+        }
+        // 小数部は 0, 3, 6, 9 桁のいずれかにする
+        static string FormatNanos(long nanos)
+        {
+            if (nanos == 0) return "";
+            if (nanos % 1000000 == 0) return "." + (nanos / 1000000).ToString("D3", CultureInfo.InvariantCulture);
+            if (nanos % 1000 == 0) return "." + (nanos / 1000).ToString("D6", CultureInfo.InvariantCulture);
+            return "." + nanos.ToString("D9", CultureInfo.InvariantCulture);
+        }
+        // google.protobuf.Struct, ListValue, Value の値を書き出す
+        // JsonReader が返すのと同じ型の木に加えて、数値型もそのまま書き出せる
+        public void WriteValue(object v)
+        {
+            if (v == null) { WriteNull(); return; }
+            var s = v as string;
+            if (s != null) { Write(s); return; }
+            if (v is bool) { Write((bool)v); return; }
+            var n = v as JsonNumber;
+            if (n != null) { WriteRaw(n.Text); return; }
+            var obj = v as Dictionary<string, object>;
+            if (obj != null)
+            {
+                BeginObject();
+                foreach (var kv in obj)
+                {
+                    Key(kv.Key);
+                    WriteValue(kv.Value);
+                }
+                EndObject();
+                return;
+            }
+            var arr = v as List<object>;
+            if (arr != null)
+            {
+                BeginArray();
+                foreach (var x in arr) WriteValue(x);
+                EndArray();
+                return;
+            }
+            if (v is double) { Write((double)v); return; }
+            if (v is float) { Write((float)v); return; }
+            if (Json.IsNumber(v)) { WriteRaw(System.Convert.ToString(v, CultureInfo.InvariantCulture)); return; }
+            throw new System.ArgumentException("unsupported json value: " + v.GetType());
+        }
+        public void Write(Any v) { if (v != null) WriteValue(v.Value); else WriteNull(); }
+        public void Write(IJsonSerializable v)
+        {
+            if (v == null)
+            {
+                BeginObject();
+                EndObject();
+            }
+            else
+            {
+                v.WriteJson(this);
+            }
+        }
+        
+        public override string ToString()
+        {
+            return sb.ToString();
+        }
+    }
+    
+    // JSON を Dictionary<string, object>, List<object>, string, JsonNumber, bool, null の木に変換して読み込む
+    public static class JsonReader
+    {
+        public static object Parse(string s)
+        {
+            int i = 0;
+            var v = ParseValue(s, ref i);
+            SkipWhitespace(s, ref i);
+            if (i != s.Length) throw new System.FormatException("unexpected character at " + i);
+            return v;
+        }
+        
+        static void SkipWhitespace(string s, ref int i)
+        {
+            while (i < s.Length && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r')) i++;
+        }
+        static void Expect(string s, ref int i, char c)
+        {
+            SkipWhitespace(s, ref i);
+            if (i >= s.Length || s[i] != c) throw new System.FormatException("expected '" + c + "' at " + i);
+            i++;
+        }
+        static bool Consume(string s, ref int i, string word)
+        {
+            if (string.CompareOrdinal(s, i, word, 0, word.Length) != 0) return false;
+            i += word.Length;
+            return true;
+        }
+        static object ParseValue(string s, ref int i)
+        {
+            SkipWhitespace(s, ref i);
+            if (i >= s.Length) throw new System.FormatException("unexpected end of json");
+            char c = s[i];
+            if (c == '{')
+            {
+                i++;
+                var obj = new Dictionary<string, object>();
+                SkipWhitespace(s, ref i);
+                if (i < s.Length && s[i] == '}')
+                {
+                    i++;
+                    return obj;
+                }
+                while (true)
+                {
+                    SkipWhitespace(s, ref i);
+                    var key = ParseString(s, ref i);
+                    Expect(s, ref i, ':');
+                    obj[key] = ParseValue(s, ref i);
+                    SkipWhitespace(s, ref i);
+                    if (i < s.Length && s[i] == ',')
+                    {
+                        i++;
+                        continue;
+                    }
+                    Expect(s, ref i, '}');
+                    return obj;
+                }
+            }
+            if (c == '[')
+            {
+                i++;
+                var arr = new List<object>();
+                SkipWhitespace(s, ref i);
+                if (i < s.Length && s[i] == ']')
+                {
+                    i++;
+                    return arr;
+                }
+                while (true)
+                {
+                    arr.Add(ParseValue(s, ref i));
+                    SkipWhitespace(s, ref i);
+                    if (i < s.Length && s[i] == ',')
+                    {
+                        i++;
+                        continue;
+                    }
+                    Expect(s, ref i, ']');
+                    return arr;
+                }
+            }
+            if (c == '"') return ParseString(s, ref i);
+            if (Consume(s, ref i, "true")) return true;
+            if (Consume(s, ref i, "false")) return false;
+            if (Consume(s, ref i, "null")) return null;
+            int start = i;
+            while (i < s.Length && "+-0123456789.eE".IndexOf(s[i]) >= 0) i++;
+            if (start == i) throw new System.FormatException("unexpected character at " + i);
+            return new JsonNumber(s.Substring(start, i - start));
+        }
+        static string ParseString(string s, ref int i)
+        {
+            Expect(s, ref i, '"');
+            var sb = new StringBuilder();
+            while (true)
+            {
+                if (i >= s.Length) throw new System.FormatException("unterminated string");
+                char c = s[i++];
+                if (c == '"') return sb.ToString();
+                if (c != '\\')
+                {
+                    sb.Append(c);
+                    continue;
+                }
+                if (i >= s.Length) throw new System.FormatException("unterminated string");
+                c = s[i++];
+                switch (c)
+                {
+                    case '"': sb.Append('"'); break;
+                    case '\\': sb.Append('\\'); break;
+                    case '/': sb.Append('/'); break;
+                    case 'b': sb.Append('\b'); break;
+                    case 'f': sb.Append('\f'); break;
+                    case 'n': sb.Append('\n'); break;
+                    case 'r': sb.Append('\r'); break;
+                    case 't': sb.Append('\t'); break;
+                    case 'u':
+                        if (i + 4 > s.Length) throw new System.FormatException("invalid escape at " + i);
+                        sb.Append((char)int.Parse(s.Substring(i, 4), NumberStyles.HexNumber, CultureInfo.InvariantCulture));
+                        i += 4;
+                        break;
+                    default:
+                        throw new System.FormatException("invalid escape at " + i);
+                }
+            }
+        }
+        
+        // 数値は JsonNumber、map のキーは string で渡される
+        static string NumberText(object v)
+        {
+            var n = v as JsonNumber;
+            if (n != null) return n.Text;
+            var s = v as string;
+            if (s != null) return s;
+            throw new System.FormatException("expected number");
+        }
+        public static int ReadInt(object v) { return v == null ? 0 : int.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }
+        public static uint ReadUInt(object v) { return v == null ? 0 : uint.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }
+        public static long ReadLong(object v) { return v == null ? 0 : long.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }
+        public static ulong ReadULong(object v) { return v == null ? 0 : ulong.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }
+        public static float ReadFloat(object v) { return v == null ? 0 : float.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }
+        public static double ReadDouble(object v) { return v == null ? 0 : double.Parse(NumberText(v), NumberStyles.Float, CultureInfo.InvariantCulture); }
+        public static bool ReadBool(object v)
+        {
+            if (v == null) return false;
+            if (v is bool) return (bool)v;
+            var s = v as string;
+            if (s == "true") return true;
+            if (s == "false") return false;
+            throw new System.FormatException("expected bool");
+        }
+        public static string ReadString(object v)
+        {
+            if (v == null) return "";
+            var s = v as string;
+            if (s == null) throw new System.FormatException("expected string");
+            return s;
+        }
+        // 通常の base64 と URL セーフな base64 のどちらも読み込める（パディングは省略できる）
+        public static byte[] ReadBytes(object v)
+        {
+            if (v == null) return new byte[0];
+            var s = ReadString(v);
+            var t = s.Replace('-', '+').Replace('_', '/');
+            if (t.Length % 4 == 2) t += "==";
+            else if (t.Length % 4 == 3) t += "=";
+            try
+            {
+                return System.Convert.FromBase64String(t);
+            }
+            catch (System.FormatException)
+            {
+                throw new System.FormatException("invalid base64: " + s);
+            }
+        }
+        static readonly Regex TimestampPattern = new Regex(@"^([0-9]{4})-([0-9]{2})-([0-9]{2})[Tt]([0-9]{2}):([0-9]{2}):([0-9]{2})(?:\.([0-9]{1,9}))?([Zz]|[+-][0-9]{2}:[0-9]{2})$");
+        static readonly Regex DurationPattern = new Regex(@"^(-?)([0-9]+)(?:\.([0-9]{1,9}))?s$");
+        // 小数部を 100 ナノ秒単位の値にする（それより細かい部分は切り捨てる）
+        static long FractionTicks(string s)
+        {
+            if (s.Length == 0) return 0;
+            return long.Parse((s + "000000").Substring(0, 7), CultureInfo.InvariantCulture);
+        }
+        static int ParseInt(Group g) { return int.Parse(g.Value, CultureInfo.InvariantCulture); }
+        public static System.DateTime ReadTimestamp(object v)
+        {
+            if (v == null) return new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc);
+            var s = ReadString(v);
+            var m = TimestampPattern.Match(s);
+            if (!m.Success) throw new System.FormatException("invalid google.protobuf.Timestamp: " + s);
+            System.DateTime t;
+            try
+            {
+                t = new System.DateTime(ParseInt(m.Groups[1]), ParseInt(m.Groups[2]), ParseInt(m.Groups[3]), ParseInt(m.Groups[4]), ParseInt(m.Groups[5]), ParseInt(m.Groups[6]), System.DateTimeKind.Utc);
+                t = t.AddTicks(FractionTicks(m.Groups[7].Value));
+                var offset = m.Groups[8].Value;
+                if (offset != "Z" && offset != "z")
+                {
+                    var d = new System.TimeSpan(int.Parse(offset.Substring(1, 2), CultureInfo.InvariantCulture), int.Parse(offset.Substring(4, 2), CultureInfo.InvariantCulture), 0);
+                    t = offset[0] == '+' ? t - d : t + d;
+                }
+            }
+            catch (System.ArgumentOutOfRangeException)
+            {
+                throw new System.FormatException("invalid google.protobuf.Timestamp: " + s);
+            }
+            return t;
+        }
+        public static System.TimeSpan ReadDuration(object v)
+        {
+            if (v == null) return System.TimeSpan.Zero;
+            var s = ReadString(v);
+            var m = DurationPattern.Match(s);
+            if (!m.Success) throw new System.FormatException("invalid google.protobuf.Duration: " + s);
+            long ticks = checked(long.Parse(m.Groups[2].Value, CultureInfo.InvariantCulture) * System.TimeSpan.TicksPerSecond + FractionTicks(m.Groups[3].Value));
+            return new System.TimeSpan(m.Groups[1].Value == "-" ? -ticks : ticks);
+        }
+        public static Dictionary<string, object> ReadStruct(object v)
+        {
+            if (v == null) return null;
+            var obj = v as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            return obj;
+        }
+        public static List<object> ReadListValue(object v)
+        {
+            if (v == null) return null;
+            var arr = v as List<object>;
+            if (arr == null) throw new System.FormatException("expected array");
+            return arr;
+        }
+        public static Any ReadAny(object v)
+        {
+            if (v == null) return null;
+            var obj = v as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object type;
+            if (!obj.TryGetValue("@type", out type) || !(type is string)) throw new System.FormatException("invalid google.protobuf.Any: @type is required");
+            return new Any(obj);
+        }
+        // 名前と数値のどちらでも読み込める。未知の名前は 0 にする
+        public static T ReadEnum<T>(object v) where T : struct
+        {
+            var s = v as string;
+            if (s == null) return (T)System.Enum.ToObject(typeof(T), ReadInt(v));
+            T r;
+            if (System.Enum.TryParse(s, out r) && System.Enum.IsDefined(typeof(T), r)) return r;
+            return default(T);
+        }
+        public static T ReadObject<T>(object v) where T : IJsonSerializable, new()
+        {
+            var r = new T();
+            if (v != null) r.ReadJson(v);
+            return r;
+        }
+        public static List<T> ReadList<T>(object v, System.Func<object, T> read)
+        {
+            var r = new List<T>();
+            if (v == null) return r;
+            var arr = v as List<object>;
+            if (arr == null) throw new System.FormatException("expected array");
+            foreach (var x in arr) r.Add(read(x));
+            return r;
+        }
+        public static Dictionary<K, V> ReadDictionary<K, V>(object v, System.Func<object, K> readKey, System.Func<object, V> read)
+        {
+            var r = new Dictionary<K, V>();
+            if (v == null) return r;
+            var obj = v as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            foreach (var kv in obj) r[readKey(kv.Key)] = read(kv.Value);
+            return r;
+        }
+    }
+    
+    // 検証ルールの違反
+    public class Violation
+    {
+        // JSON でのフィールドの位置（people[3].name など）
+        public readonly string Path;
+        public readonly string Message;
+        public Violation(string path, string message)
+        {
+            Path = path;
+            Message = message;
+        }
+    }
+    
+    // 生成したクラスの Validate で使う関数
+    public static class Validation
+    {
+        public static string Join(string path, string key)
+        {
+            return path == "" ? key : path + "." + key;
+        }
+        public static string Index(string path, int i)
+        {
+            return path + "[" + i.ToString(CultureInfo.InvariantCulture) + "]";
+        }
+        public static string Key(string path, string k)
+        {
+            return path + "[\"" + k + "\"]";
+        }
+        public static string Key(string path, bool k) { return Key(path, k ? "true" : "false"); }
+        public static string Key(string path, int k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }
+        public static string Key(string path, uint k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }
+        public static string Key(string path, long k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }
+        public static string Key(string path, ulong k) { return Key(path, k.ToString(CultureInfo.InvariantCulture)); }
+        // 文字列のコードポイント数（サロゲートペアは 1 文字として数える）
+        public static int StringLength(string s)
+        {
+            if (s == null) return 0;
+            int n = 0;
+            foreach (var c in s) if (!char.IsLowSurrogate(c)) n++;
+            return n;
+        }
+        // bytes の長さはバイト数
+        public static int BytesLength(byte[] v)
+        {
+            return v == null ? 0 : v.Length;
+        }
+        // パターンは ECMAScript の構文で、マッチする部分を含んでいれば true
+        public static bool IsMatch(string s, string pattern)
+        {
+            return Regex.IsMatch(s ?? "", pattern, RegexOptions.ECMAScript);
+        }
+    }
+    
+    // report_deprecated を指定して生成したクラスで、deprecated なフィールドのキーが JSON にあった時の通知
+    public static class DeprecatedField
+    {
+        static long count;
+        // メッセージの完全修飾名と JSON のキーを受け取る
+        public static System.Action<string, string> Handler;
+        // deprecated なフィールドのキーが JSON にあった回数
+        public static long Count { get { return System.Threading.Interlocked.Read(ref count); } }
+        public static void ResetCount() { System.Threading.Interlocked.Exchange(ref count, 0); }
+        public static void Report(string message, string key)
+        {
+            System.Threading.Interlocked.Increment(ref count);
+            var handler = Handler;
+            if (handler != null) handler(message, key);
+        }
+    }
+    
+    public static class Json
+    {
+        public static string ToJson<T>(T v)
+        {
+            var s = v as IJsonSerializable;
+            if (s != null)
+            {
+                var w = new JsonWriter();
+                s.WriteJson(w);
+                return w.ToString();
+            }
+            return JsonUtility.ToJson(v);
+        }
+        public static T FromJson<T>(string s)
+        {
+            if (typeof(IJsonSerializable).IsAssignableFrom(typeof(T)))
+            {
+                var v = (IJsonSerializable)System.Activator.CreateInstance(typeof(T));
+                v.ReadJson(JsonReader.Parse(s));
+                return (T)v;
+            }
+            return JsonUtility.FromJson<T>(s);
+        }
+        
+        // Dictionary の比較（要素の順序は無視する）
+        public static bool DictionaryEquals<K, V>(Dictionary<K, V> a, Dictionary<K, V> b)
+        {
+            return DictionaryEquals(a, b, EqualityComparer<V>.Default);
+        }
+        public static bool DictionaryEquals<K, V>(Dictionary<K, V> a, Dictionary<K, V> b, IEqualityComparer<V> comparer)
+        {
+            if (a.Count != b.Count) return false;
+            foreach (var kv in a)
+            {
+                V v;
+                if (!b.TryGetValue(kv.Key, out v)) return false;
+                if (!comparer.Equals(kv.Value, v)) return false;
+            }
+            return true;
+        }
+        
+        // byte[] の比較（要素を比較する）
+        public static bool BytesEquals(byte[] a, byte[] b)
+        {
+            if (a == null || b == null) return a == null && b == null;
+            if (a.Length != b.Length) return false;
+            for (int i = 0; i < a.Length; i++)
+            {
+                if (a[i] != b[i]) return false;
+            }
+            return true;
+        }
+        public static int BytesHashCode(byte[] v)
+        {
+            if (v == null) return 0;
+            int hashcode = 1430287;
+            foreach (var x in v) hashcode = hashcode * 7302013 ^ x;
+            return hashcode;
+        }
+        
+        // JSON の値の比較（数値は double にして比較する）
+        public static bool IsNumber(object v)
+        {
+            return v is JsonNumber || v is double || v is float || v is decimal || v is int || v is uint || v is long || v is ulong || v is short || v is ushort || v is byte || v is sbyte;
+        }
+        static double ToDouble(object v)
+        {
+            var n = v as JsonNumber;
+            if (n != null) return double.Parse(n.Text, NumberStyles.Float, CultureInfo.InvariantCulture);
+            return System.Convert.ToDouble(v, CultureInfo.InvariantCulture);
+        }
+        public static bool ValueEquals(object a, object b)
+        {
+            if (a == null || b == null) return a == null && b == null;
+            if (IsNumber(a) && IsNumber(b)) return ToDouble(a) == ToDouble(b);
+            var aobj = a as Dictionary<string, object>;
+            if (aobj != null)
+            {
+                var bobj = b as Dictionary<string, object>;
+                return bobj != null && DictionaryEquals(aobj, bobj, JsonValueComparer<object>.Instance);
+            }
+            var aarr = a as List<object>;
+            if (aarr != null)
+            {
+                var barr = b as List<object>;
+                if (barr == null || aarr.Count != barr.Count) return false;
+                for (int i = 0; i < aarr.Count; i++)
+                {
+                    if (!ValueEquals(aarr[i], barr[i])) return false;
+                }
+                return true;
+            }
+            return a.Equals(b);
+        }
+        public static int ValueHashCode(object v)
+        {
+            if (v == null) return 0;
+            if (IsNumber(v)) return ToDouble(v).GetHashCode();
+            int hashcode = 1430287;
+            var obj = v as Dictionary<string, object>;
+            if (obj != null)
+            {
+                foreach (var kv in obj) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ ValueHashCode(kv.Value));
+                return hashcode;
+            }
+            var arr = v as List<object>;
+            if (arr != null)
+            {
+                foreach (var x in arr) hashcode = hashcode * 7302013 ^ ValueHashCode(x);
+                return hashcode;
+            }
+            return v.GetHashCode();
+        }
+    }
+    
+}
@@ -0,0 +1,504 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Validation
+{
+    
+    [System.Serializable]
+    public enum Role
+    {
+        ROLE_UNSPECIFIED = 0,
+        ROLE_ADMIN = 1,
+        ROLE_MEMBER = 2,
+    }
+    
+    [System.Serializable]
+    public class Person : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum ScoreCase
+        {
+            NOT_SET = 0,
+            kScore = 6,
+        }
+        public ScoreCase _score_case;
+        public void ClearScoreCase()
+        {
+            _score_case = ScoreCase.NOT_SET;
+            score = default(double);
+        }
+        public string name = "";
+        public int age;
+        public string email = "";
+        public global::Validation.Role role = new global::Validation.Role();
+        /// <summary>
+        /// 各要素の長さと、要素数を検証する
+        /// </summary>
+        public List<string> tags = new List<string>();
+        public double score;
+        public void SetScore(double score)
+        {
+            ClearScoreCase();
+            _score_case = ScoreCase.kScore;
+            this.score = score;
+        }
+        public bool HasScore()
+        {
+            return _score_case == ScoreCase.kScore;
+        }
+        public void ClearScore()
+        {
+            if (_score_case == ScoreCase.kScore)
+            {
+                ClearScoreCase();
+            }
+        }
+        public float ratio;
+        public override bool Equals(object obj)
+        {
+            var v = obj as Person;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            if (!this.age.Equals(v.age)) return false;
+            if (!this.email.Equals(v.email)) return false;
+            if (!this.role.Equals(v.role)) return false;
+            if (!this.tags.SequenceEqual(v.tags)) return false;
+            if (!this.ratio.Equals(v.ratio)) return false;
+            if (!this._score_case.Equals(v._score_case)) return false;
+            if (this._score_case == ScoreCase.kScore && !this.score.Equals(v.score)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            hashcode = hashcode * 7302013 ^ age.GetHashCode();
+            hashcode = hashcode * 7302013 ^ email.GetHashCode();
+            hashcode = hashcode * 7302013 ^ role.GetHashCode();
+            foreach (var v in this.tags) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            hashcode = hashcode * 7302013 ^ ratio.GetHashCode();
+            hashcode = hashcode * 7302013 ^ _score_case.GetHashCode();
+            if (_score_case == ScoreCase.kScore) hashcode = hashcode * 7302013 ^ score.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.Key("age");
+            w.Write(this.age);
+            w.Key("email");
+            w.Write(this.email);
+            w.Key("role");
+            w.Write((int)this.role);
+            w.Key("tags");
+            w.BeginArray();
+            foreach (var x in this.tags) w.Write(x);
+            w.EndArray();
+            if (this._score_case == ScoreCase.kScore)
+            {
+                w.Key("score");
+                w.Write(this.score);
+            }
+            w.Key("ratio");
+            w.Write(this.ratio);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("age", out v)) this.age = global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("email", out v)) this.email = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("role", out v)) this.role = (global::Validation.Role)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("tags", out v)) this.tags = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadString(x));
+            if (obj.TryGetValue("score", out v))
+            {
+                this.score = global::Jsonif.JsonReader.ReadDouble(v);
+                this._score_case = ScoreCase.kScore;
+            }
+            if (obj.TryGetValue("ratio", out v)) this.ratio = global::Jsonif.JsonReader.ReadFloat(v);
+        }
+        
+        public List<global::Jsonif.Violation> Validate()
+        {
+            var violations = new List<global::Jsonif.Violation>();
+            Validate("", violations);
+            return violations;
+        }
+        public void Validate(string path, List<global::Jsonif.Violation> violations)
+        {
+            if (global::Jsonif.Validation.StringLength(this.name) < 1) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "name"), "length must be at least 1"));
+            if (global::Jsonif.Validation.StringLength(this.name) > 8) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "name"), "length must be at most 8"));
+            if (this.age < 0) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "age"), "must be greater than or equal to 0"));
+            if (this.age > 150) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "age"), "must be less than or equal to 150"));
+            if (!global::Jsonif.Validation.IsMatch(this.email, "^[^@]+@[^@]+$")) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "email"), "must match pattern \"^[^@]+@[^@]+$\""));
+            if (!System.Enum.IsDefined(typeof(global::Validation.Role), this.role)) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "role"), "must be a defined enum value"));
+            if (this.tags.Count > 3) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "tags"), "must have at most 3 items"));
+            for (int i = 0; i < this.tags.Count; i++)
+            {
+                if (global::Jsonif.Validation.StringLength(this.tags[i]) < 1) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Index(global::Jsonif.Validation.Join(path, "tags"), i), "length must be at least 1"));
+            }
+            if (this._score_case != ScoreCase.kScore) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "score"), "is required"));
+            if (this._score_case == ScoreCase.kScore)
+            {
+                if (this.score < 0.5) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "score"), "must be greater than or equal to 0.5"));
+            }
+            if (this.ratio > 1.0f) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "ratio"), "must be less than or equal to 1"));
+        }
+        
+    }
+    
+    /// <summary>
+    /// 検証ルールのあるメッセージをフィールドに持つメッセージも検証する
+    /// </summary>
+    [System.Serializable]
+    public class Team : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum ContactCase
+        {
+            NOT_SET = 0,
+            kPhone = 6,
+            kContactPerson = 7,
+        }
+        public ContactCase contact_case;
+        public void ClearContactCase()
+        {
+            contact_case = ContactCase.NOT_SET;
+            phone = "";
+            contact_person = new global::Validation.Person();
+        }
+        public global::Validation.Person leader = new global::Validation.Person();
+        public List<global::Validation.Person> members = new List<global::Validation.Person>();
+        public Dictionary<string, global::Validation.Person> by_name = new Dictionary<string, global::Validation.Person>();
+        public Dictionary<int, long> limits = new Dictionary<int, long>();
+        public int? size;
+        public string phone = "";
+        public void SetPhone(string phone)
+        {
+            ClearContactCase();
+            contact_case = ContactCase.kPhone;
+            this.phone = phone;
+        }
+        public bool HasPhone()
+        {
+            return contact_case == ContactCase.kPhone;
+        }
+        public void ClearPhone()
+        {
+            if (contact_case == ContactCase.kPhone)
+            {
+                ClearContactCase();
+            }
+        }
+        public global::Validation.Person contact_person = new global::Validation.Person();
+        public void SetContactPerson(global::Validation.Person contact_person)
+        {
+            ClearContactCase();
+            contact_case = ContactCase.kContactPerson;
+            this.contact_person = contact_person;
+        }
+        public bool HasContactPerson()
+        {
+            return contact_case == ContactCase.kContactPerson;
+        }
+        public void ClearContactPerson()
+        {
+            if (contact_case == ContactCase.kContactPerson)
+            {
+                ClearContactCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Team;
+            if (v == null) return false;
+            if (!this.leader.Equals(v.leader)) return false;
+            if (!this.members.SequenceEqual(v.members)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.by_name, v.by_name)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.limits, v.limits)) return false;
+            if (!object.Equals(this.size, v.size)) return false;
+            if (!this.contact_case.Equals(v.contact_case)) return false;
+            if (this.contact_case == ContactCase.kPhone && !this.phone.Equals(v.phone)) return false;
+            if (this.contact_case == ContactCase.kContactPerson && !this.contact_person.Equals(v.contact_person)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ leader.GetHashCode();
+            foreach (var v in this.members) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var kv in this.by_name) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            foreach (var kv in this.limits) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (size == null ? 0 : size.GetHashCode());
+            hashcode = hashcode * 7302013 ^ contact_case.GetHashCode();
+            if (contact_case == ContactCase.kPhone) hashcode = hashcode * 7302013 ^ phone.GetHashCode();
+            if (contact_case == ContactCase.kContactPerson) hashcode = hashcode * 7302013 ^ contact_person.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("contact_case");
+            w.Write((int)this.contact_case);
+            w.Key("leader");
+            w.Write(this.leader);
+            w.Key("members");
+            w.BeginArray();
+            foreach (var x in this.members) w.Write(x);
+            w.EndArray();
+            w.Key("by_name");
+            w.BeginObject();
+            foreach (var kv in this.by_name)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("limits");
+            w.BeginObject();
+            foreach (var kv in this.limits)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("size");
+            w.Write(this.size);
+            w.Key("phone");
+            w.Write(this.phone);
+            w.Key("contact_person");
+            w.Write(this.contact_person);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("contact_case", out v)) this.contact_case = (ContactCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("leader", out v)) this.leader = global::Jsonif.JsonReader.ReadObject<global::Validation.Person>(v);
+            if (obj.TryGetValue("members", out v)) this.members = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadObject<global::Validation.Person>(x));
+            if (obj.TryGetValue("by_name", out v)) this.by_name = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadObject<global::Validation.Person>(x));
+            if (obj.TryGetValue("limits", out v)) this.limits = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadInt(k), x => global::Jsonif.JsonReader.ReadLong(x));
+            if (obj.TryGetValue("size", out v)) this.size = (v == null ? (int?)null : global::Jsonif.JsonReader.ReadInt(v));
+            if (obj.TryGetValue("phone", out v)) this.phone = global::Jsonif.JsonReader.ReadString(v);
+            if (obj.TryGetValue("contact_person", out v)) this.contact_person = global::Jsonif.JsonReader.ReadObject<global::Validation.Person>(v);
+        }
+        
+        public List<global::Jsonif.Violation> Validate()
+        {
+            var violations = new List<global::Jsonif.Violation>();
+            Validate("", violations);
+            return violations;
+        }
+        public void Validate(string path, List<global::Jsonif.Violation> violations)
+        {
+            if (this.leader == null || this.leader.Equals(new global::Validation.Person())) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "leader"), "is required"));
+            if (this.leader != null) this.leader.Validate(global::Jsonif.Validation.Join(path, "leader"), violations);
+            if (this.members.Count < 1) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "members"), "must have at least 1 items"));
+            for (int i = 0; i < this.members.Count; i++)
+            {
+                if (this.members[i] != null) this.members[i].Validate(global::Jsonif.Validation.Index(global::Jsonif.Validation.Join(path, "members"), i), violations);
+            }
+            foreach (var kv in this.by_name)
+            {
+                if (kv.Value != null) kv.Value.Validate(global::Jsonif.Validation.Key(global::Jsonif.Validation.Join(path, "by_name"), kv.Key), violations);
+            }
+            foreach (var kv in this.limits)
+            {
+                if (kv.Value < -10L) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Key(global::Jsonif.Validation.Join(path, "limits"), kv.Key), "must be greater than or equal to -10"));
+                if (kv.Value > 10L) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Key(global::Jsonif.Validation.Join(path, "limits"), kv.Key), "must be less than or equal to 10"));
+            }
+            if (this.size == null) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "size"), "is required"));
+            if (this.contact_case == ContactCase.kPhone)
+            {
+                if (!global::Jsonif.Validation.IsMatch(this.phone, "^[0-9]+$")) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "phone"), "must match pattern \"^[0-9]+$\""));
+            }
+            if (this.contact_case == ContactCase.kContactPerson)
+            {
+                if (this.contact_person != null) this.contact_person.Validate(global::Jsonif.Validation.Join(path, "contact_person"), violations);
+            }
+        }
+        
+    }
+    
+    /// <summary>
+    /// 後で宣言されるメッセージをフィールドに持つメッセージも検証する
+    /// </summary>
+    [System.Serializable]
+    public class League : global::Jsonif.IJsonSerializable
+    {
+        public global::Validation.Club champion = new global::Validation.Club();
+        public List<global::Validation.Club> clubs = new List<global::Validation.Club>();
+        public override bool Equals(object obj)
+        {
+            var v = obj as League;
+            if (v == null) return false;
+            if (!this.champion.Equals(v.champion)) return false;
+            if (!this.clubs.SequenceEqual(v.clubs)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ champion.GetHashCode();
+            foreach (var v in this.clubs) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("champion");
+            w.Write(this.champion);
+            w.Key("clubs");
+            w.BeginArray();
+            foreach (var x in this.clubs) w.Write(x);
+            w.EndArray();
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("champion", out v)) this.champion = global::Jsonif.JsonReader.ReadObject<global::Validation.Club>(v);
+            if (obj.TryGetValue("clubs", out v)) this.clubs = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadObject<global::Validation.Club>(x));
+        }
+        
+        public List<global::Jsonif.Violation> Validate()
+        {
+            var violations = new List<global::Jsonif.Violation>();
+            Validate("", violations);
+            return violations;
+        }
+        public void Validate(string path, List<global::Jsonif.Violation> violations)
+        {
+            if (this.champion == null || this.champion.Equals(new global::Validation.Club())) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "champion"), "is required"));
+            if (this.champion != null) this.champion.Validate(global::Jsonif.Validation.Join(path, "champion"), violations);
+            for (int i = 0; i < this.clubs.Count; i++)
+            {
+                if (this.clubs[i] != null) this.clubs[i].Validate(global::Jsonif.Validation.Index(global::Jsonif.Validation.Join(path, "clubs"), i), violations);
+            }
+        }
+        
+    }
+    
+    [System.Serializable]
+    public class Club : global::Jsonif.IJsonSerializable
+    {
+        public string name = "";
+        public override bool Equals(object obj)
+        {
+            var v = obj as Club;
+            if (v == null) return false;
+            if (!this.name.Equals(v.name)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ name.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("name");
+            w.Write(this.name);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("name", out v)) this.name = global::Jsonif.JsonReader.ReadString(v);
+        }
+        
+        public List<global::Jsonif.Violation> Validate()
+        {
+            var violations = new List<global::Jsonif.Violation>();
+            Validate("", violations);
+            return violations;
+        }
+        public void Validate(string path, List<global::Jsonif.Violation> violations)
+        {
+            if (global::Jsonif.Validation.StringLength(this.name) < 1) violations.Add(new global::Jsonif.Violation(global::Jsonif.Validation.Join(path, "name"), "length must be at least 1"));
+        }
+        
+    }
+    
+    /// <summary>
+    /// 検証ルールが無いメッセージは validate を生成しない
+    /// </summary>
+    [System.Serializable]
+    public class NoRules : global::Jsonif.IJsonSerializable
+    {
+        public int value;
+        public override bool Equals(object obj)
+        {
+            var v = obj as NoRules;
+            if (v == null) return false;
+            if (!this.value.Equals(v.value)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ value.GetHashCode();
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value");
+            w.Write(this.value);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value", out v)) this.value = global::Jsonif.JsonReader.ReadInt(v);
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredValidation_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "validation.Person", typeof(global::Validation.Person) },
+            { "validation.Team", typeof(global::Validation.Team) },
+            { "validation.League", typeof(global::Validation.League) },
+            { "validation.Club", typeof(global::Validation.Club) },
+            { "validation.NoRules", typeof(global::Validation.NoRules) },
+        });
+    }
+    
+}
@@ -0,0 +1,203 @@
+using System.Collections.Generic;
+using System.Linq;
+namespace Wellknown
+{
+    
+    [System.Serializable]
+    public class Test : global::Jsonif.IJsonSerializable
+    {
+        [System.Serializable]
+        public enum ValueCase
+        {
+            NOT_SET = 0,
+            kOneofDuration = 14,
+            kOneofString = 15,
+        }
+        public ValueCase value_case;
+        public void ClearValueCase()
+        {
+            value_case = ValueCase.NOT_SET;
+            oneof_duration = default(System.TimeSpan);
+            oneof_string = default(string);
+        }
+        public System.DateTime timestamp = new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc);
+        public System.TimeSpan duration;
+        public double? double_value;
+        public float? float_value;
+        public long? int64_value;
+        public ulong? uint64_value;
+        public int? int32_value;
+        public uint? uint32_value;
+        public bool? bool_value;
+        public string string_value;
+        public List<System.DateTime> timestamps = new List<System.DateTime>();
+        public List<int?> int32_values = new List<int?>();
+        public Dictionary<string, System.TimeSpan> durations = new Dictionary<string, System.TimeSpan>();
+        public System.TimeSpan oneof_duration;
+        public void SetOneofDuration(System.TimeSpan oneof_duration)
+        {
+            ClearValueCase();
+            value_case = ValueCase.kOneofDuration;
+            this.oneof_duration = oneof_duration;
+        }
+        public bool HasOneofDuration()
+        {
+            return value_case == ValueCase.kOneofDuration;
+        }
+        public void ClearOneofDuration()
+        {
+            if (value_case == ValueCase.kOneofDuration)
+            {
+                ClearValueCase();
+            }
+        }
+        public string oneof_string;
+        public void SetOneofString(string oneof_string)
+        {
+            ClearValueCase();
+            value_case = ValueCase.kOneofString;
+            this.oneof_string = oneof_string;
+        }
+        public bool HasOneofString()
+        {
+            return value_case == ValueCase.kOneofString;
+        }
+        public void ClearOneofString()
+        {
+            if (value_case == ValueCase.kOneofString)
+            {
+                ClearValueCase();
+            }
+        }
+        public override bool Equals(object obj)
+        {
+            var v = obj as Test;
+            if (v == null) return false;
+            if (!this.timestamp.Equals(v.timestamp)) return false;
+            if (!this.duration.Equals(v.duration)) return false;
+            if (!object.Equals(this.double_value, v.double_value)) return false;
+            if (!object.Equals(this.float_value, v.float_value)) return false;
+            if (!object.Equals(this.int64_value, v.int64_value)) return false;
+            if (!object.Equals(this.uint64_value, v.uint64_value)) return false;
+            if (!object.Equals(this.int32_value, v.int32_value)) return false;
+            if (!object.Equals(this.uint32_value, v.uint32_value)) return false;
+            if (!object.Equals(this.bool_value, v.bool_value)) return false;
+            if (!object.Equals(this.string_value, v.string_value)) return false;
+            if (!this.timestamps.SequenceEqual(v.timestamps)) return false;
+            if (!this.int32_values.SequenceEqual(v.int32_values)) return false;
+            if (!global::Jsonif.Json.DictionaryEquals(this.durations, v.durations)) return false;
+            if (!this.value_case.Equals(v.value_case)) return false;
+            if (this.value_case == ValueCase.kOneofDuration && !this.oneof_duration.Equals(v.oneof_duration)) return false;
+            if (this.value_case == ValueCase.kOneofString && !object.Equals(this.oneof_string, v.oneof_string)) return false;
+            return true;
+        }
+        
+        public override int GetHashCode()
+        {
+            int hashcode = 1430287;
+            hashcode = hashcode * 7302013 ^ timestamp.GetHashCode();
+            hashcode = hashcode * 7302013 ^ duration.GetHashCode();
+            hashcode = hashcode * 7302013 ^ (double_value == null ? 0 : double_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (float_value == null ? 0 : float_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (int64_value == null ? 0 : int64_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (uint64_value == null ? 0 : uint64_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (int32_value == null ? 0 : int32_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (uint32_value == null ? 0 : uint32_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (bool_value == null ? 0 : bool_value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ (string_value == null ? 0 : string_value.GetHashCode());
+            foreach (var v in this.timestamps) hashcode = hashcode * 7302013 ^ v.GetHashCode();
+            foreach (var v in this.int32_values) hashcode = hashcode * 7302013 ^ (v == null ? 0 : v.GetHashCode());
+            foreach (var kv in this.durations) hashcode = hashcode ^ (kv.Key.GetHashCode() * 7302013 ^ kv.Value.GetHashCode());
+            hashcode = hashcode * 7302013 ^ value_case.GetHashCode();
+            if (value_case == ValueCase.kOneofDuration) hashcode = hashcode * 7302013 ^ oneof_duration.GetHashCode();
+            if (value_case == ValueCase.kOneofString) hashcode = hashcode * 7302013 ^ (oneof_string == null ? 0 : oneof_string.GetHashCode());
+            return hashcode;
+        }
+        
+        public void WriteJson(global::Jsonif.JsonWriter w)
+        {
+            w.BeginObject();
+            w.Key("value_case");
+            w.Write((int)this.value_case);
+            w.Key("timestamp");
+            w.Write(this.timestamp);
+            w.Key("duration");
+            w.Write(this.duration);
+            w.Key("double_value");
+            w.Write(this.double_value);
+            w.Key("float_value");
+            w.Write(this.float_value);
+            w.Key("int64_value");
+            w.Write(this.int64_value);
+            w.Key("uint64_value");
+            w.Write(this.uint64_value);
+            w.Key("int32_value");
+            w.Write(this.int32_value);
+            w.Key("uint32_value");
+            w.Write(this.uint32_value);
+            w.Key("bool_value");
+            w.Write(this.bool_value);
+            w.Key("string_value");
+            w.WriteNullable(this.string_value);
+            w.Key("timestamps");
+            w.BeginArray();
+            foreach (var x in this.timestamps) w.Write(x);
+            w.EndArray();
+            w.Key("int32_values");
+            w.BeginArray();
+            foreach (var x in this.int32_values) w.Write(x);
+            w.EndArray();
+            w.Key("durations");
+            w.BeginObject();
+            foreach (var kv in this.durations)
+            {
+                w.Key(kv.Key);
+                w.Write(kv.Value);
+            }
+            w.EndObject();
+            w.Key("oneof_duration");
+            w.Write(this.oneof_duration);
+            w.Key("oneof_string");
+            w.WriteNullable(this.oneof_string);
+            w.EndObject();
+        }
+        
+        public void ReadJson(object json)
+        {
+            var obj = json as Dictionary<string, object>;
+            if (obj == null) throw new System.FormatException("expected object");
+            object v;
+            if (obj.TryGetValue("value_case", out v)) this.value_case = (ValueCase)global::Jsonif.JsonReader.ReadInt(v);
+            if (obj.TryGetValue("timestamp", out v)) this.timestamp = global::Jsonif.JsonReader.ReadTimestamp(v);
+            if (obj.TryGetValue("duration", out v)) this.duration = global::Jsonif.JsonReader.ReadDuration(v);
+            if (obj.TryGetValue("double_value", out v)) this.double_value = (v == null ? (double?)null : global::Jsonif.JsonReader.ReadDouble(v));
+            if (obj.TryGetValue("float_value", out v)) this.float_value = (v == null ? (float?)null : global::Jsonif.JsonReader.ReadFloat(v));
+            if (obj.TryGetValue("int64_value", out v)) this.int64_value = (v == null ? (long?)null : global::Jsonif.JsonReader.ReadLong(v));
+            if (obj.TryGetValue("uint64_value", out v)) this.uint64_value = (v == null ? (ulong?)null : global::Jsonif.JsonReader.ReadULong(v));
+            if (obj.TryGetValue("int32_value", out v)) this.int32_value = (v == null ? (int?)null : global::Jsonif.JsonReader.ReadInt(v));
+            if (obj.TryGetValue("uint32_value", out v)) this.uint32_value = (v == null ? (uint?)null : global::Jsonif.JsonReader.ReadUInt(v));
+            if (obj.TryGetValue("bool_value", out v)) this.bool_value = (v == null ? (bool?)null : global::Jsonif.JsonReader.ReadBool(v));
+            if (obj.TryGetValue("string_value", out v)) this.string_value = (v == null ? (string)null : global::Jsonif.JsonReader.ReadString(v));
+            if (obj.TryGetValue("timestamps", out v)) this.timestamps = global::Jsonif.JsonReader.ReadList(v, x => global::Jsonif.JsonReader.ReadTimestamp(x));
+            if (obj.TryGetValue("int32_values", out v)) this.int32_values = global::Jsonif.JsonReader.ReadList(v, x => (x == null ? (int?)null : global::Jsonif.JsonReader.ReadInt(x)));
+            if (obj.TryGetValue("durations", out v)) this.durations = global::Jsonif.JsonReader.ReadDictionary(v, k => global::Jsonif.JsonReader.ReadString(k), x => global::Jsonif.JsonReader.ReadDuration(x));
+            if (obj.TryGetValue("oneof_duration", out v)) this.oneof_duration = global::Jsonif.JsonReader.ReadDuration(v);
+            if (obj.TryGetValue("oneof_string", out v)) this.oneof_string = (v == null ? (string)null : global::Jsonif.JsonReader.ReadString(v));
+        }
+        
+    }
+    
+}
+
+namespace Jsonif
+{
+    
+    public static partial class TypeRegistry
+    {
+        static readonly bool registeredWellknown_proto = Register(new Dictionary<string, System.Type>
+        {
+            { "wellknown.Test", typeof(global::Wellknown.Test) },
+        });
+    }
+    
+}
@@ -2,4 +2,9 @@ module github.com/melpon/protoc-gen-jsonif
 
 go 1.20
 
-require google.golang.org/protobuf v1.34.2
+require (
+	github.com/bufbuild/protocompile v0.8.0
+	google.golang.org/protobuf v1.34.2
+)
+
+require golang.org/x/sync v0.5.0 // indirect
@@ -9,10 +9,12 @@ PROTO_DIR="`pwd`/proto"
 rm -rf $BUILD_DIR/test/cpp
 rm -rf $BUILD_DIR/test/c
 rm -rf $BUILD_DIR/test/typescript
+rm -rf $BUILD_DIR/test/jsonschema
 rm -rf test/unity/JsonifUnityTest/Assets/Generated
 mkdir -p $BUILD_DIR/test/cpp
 mkdir -p $BUILD_DIR/test/c
 mkdir -p $BUILD_DIR/test/typescript
+mkdir -p $BUILD_DIR/test/jsonschema
 mkdir -p test/unity/JsonifUnityTest/Assets/Generated
 
 $INSTALL_DIR/protoc/bin/protoc -I$PROTO_DIR --go_out=. $PROTO_DIR/extensions.proto
@@ -21,6 +23,7 @@ go build -o $BUILD_DIR/test/protoc-gen-jsonif-cpp cmd/protoc-gen-jsonif-cpp/main
 go build -o $BUILD_DIR/test/protoc-gen-jsonif-c cmd/protoc-gen-jsonif-c/main.go
 go build -o $BUILD_DIR/test/protoc-gen-jsonif-unity cmd/protoc-gen-jsonif-unity/main.go
 go build -o $BUILD_DIR/test/protoc-gen-jsonif-typescript cmd/protoc-gen-jsonif-typescript/main.go
+go build -o $BUILD_DIR/test/protoc-gen-jsonif-jsonschema cmd/protoc-gen-jsonif-jsonschema/main.go
 
 pushd test/proto
   $INSTALL_DIR/protoc/bin/protoc \
@@ -28,6 +31,7 @@ pushd test/proto
     -I$PROTO_DIR \
     --plugin=protoc-gen-jsonif-cpp=$BUILD_DIR/test/protoc-gen-jsonif-cpp \
     --jsonif-cpp_out=$BUILD_DIR/test/cpp \
+    --jsonif-cpp_opt=include_imports,report_deprecated,try_from_json \
     bytes.proto \
     empty.proto \
     enumpb.proto \
@@ -39,14 +43,28 @@ pushd test/proto
     size.proto \
     jsonfield.proto \
     optimistic.proto \
+    keywords.proto \
+    comments.proto \
     optional.proto \
     discard_if_default.proto \
-    no_serializer.proto
+    no_serializer.proto \
+    map.proto \
+    wellknown.proto \
+    jsonvalue.proto \
+    any.proto \
+    enum_name.proto \
+    oneof_active_only.proto \
+    default_value.proto \
+    validation.proto \
+    deprecated.proto \
+    unknown_fields.proto \
+    strict.proto
   $INSTALL_DIR/protoc/bin/protoc \
     -I. \
     -I$PROTO_DIR \
     --plugin=protoc-gen-jsonif-c=$BUILD_DIR/test/protoc-gen-jsonif-c \
     --jsonif-c_out=$BUILD_DIR/test/c \
+    --jsonif-c_opt=include_imports \
     bytes.proto \
     empty.proto \
     enumpb.proto \
@@ -54,47 +72,177 @@ pushd test/proto
     message.proto \
     nested.proto \
     oneof.proto \
+    keywords.proto \
+    comments.proto \
     optional.proto \
     repeated.proto \
-    size.proto
+    size.proto \
+    map.proto \
+    wellknown.proto \
+    jsonvalue.proto \
+    any.proto \
+    enum_name.proto \
+    oneof_active_only.proto \
+    default_value.proto \
+    validation.proto \
+    deprecated.proto \
+    unknown_fields.proto \
+    strict.proto
   $INSTALL_DIR/protoc/bin/protoc \
+    -I. \
+    -I$PROTO_DIR \
     --plugin=protoc-gen-jsonif-unity=$BUILD_DIR/test/protoc-gen-jsonif-unity \
     --jsonif-unity_out=../unity/JsonifUnityTest/Assets/Generated \
+    --jsonif-unity_opt=include_imports,report_deprecated \
+    bytes.proto \
     empty.proto \
     enumpb.proto \
     importing.proto \
     message.proto \
     nested.proto \
     oneof.proto \
+    keywords.proto \
+    comments.proto \
     optional.proto \
-    repeated.proto
+    repeated.proto \
+    map.proto \
+    wellknown.proto \
+    jsonvalue.proto \
+    any.proto \
+    enum_name.proto \
+    oneof_active_only.proto \
+    default_value.proto \
+    validation.proto \
+    deprecated.proto \
+    unknown_fields.proto
   $INSTALL_DIR/protoc/bin/protoc \
+    -I. \
+    -I$PROTO_DIR \
     --plugin=protoc-gen-jsonif-typescript=$BUILD_DIR/test/protoc-gen-jsonif-typescript \
     --jsonif-typescript_out=$BUILD_DIR/test/typescript \
+    --jsonif-typescript_opt=include_imports,report_deprecated \
+    bytes.proto \
     empty.proto \
     enumpb.proto \
     importing.proto \
     message.proto \
     nested.proto \
     oneof.proto \
+    keywords.proto \
+    comments.proto \
     optional.proto \
-    repeated.proto
+    repeated.proto \
+    map.proto \
+    wellknown.proto \
+    jsonvalue.proto \
+    any.proto \
+    enum_name.proto \
+    oneof_active_only.proto \
+    default_value.proto \
+    validation.proto \
+    deprecated.proto \
+    unknown_fields.proto \
+    strict.proto
+  $INSTALL_DIR/protoc/bin/protoc \
+    -I. \
+    -I$PROTO_DIR \
+    --plugin=protoc-gen-jsonif-jsonschema=$BUILD_DIR/test/protoc-gen-jsonif-jsonschema \
+    --jsonif-jsonschema_out=$BUILD_DIR/test/jsonschema \
+    --jsonif-jsonschema_opt=include_imports \
+    bytes.proto \
+    empty.proto \
+    enumpb.proto \
+    importing.proto \
+    message.proto \
+    nested.proto \
+    oneof.proto \
+    repeated.proto \
+    size.proto \
+    jsonfield.proto \
+    optimistic.proto \
+    keywords.proto \
+    comments.proto \
+    optional.proto \
+    map.proto \
+    wellknown.proto \
+    jsonvalue.proto \
+    any.proto \
+    enum_name.proto \
+    oneof_active_only.proto \
+    default_value.proto \
+    validation.proto \
+    deprecated.proto \
+    unknown_fields.proto \
+    strict.proto
+  # canonical は別のオプションで生成する
+  for target in cpp c typescript jsonschema; do
+    # C 用のコードは C++ 用のコードの jsonif::try_from_json を使う
+    extra_opt=""
+    if [ $target = cpp ]; then
+      extra_opt=",try_from_json"
+    fi
+    $INSTALL_DIR/protoc/bin/protoc \
+      -I. \
+      -I$PROTO_DIR \
+      --plugin=protoc-gen-jsonif-$target=$BUILD_DIR/test/protoc-gen-jsonif-$target \
+      --jsonif-${target}_out=$BUILD_DIR/test/$target \
+      --jsonif-${target}_opt=include_imports,canonical$extra_opt \
+      canonical.proto \
+      canonical_bytes.proto
+    # base64url も別のオプションで生成する
+    $INSTALL_DIR/protoc/bin/protoc \
+      -I. \
+      -I$PROTO_DIR \
+      --plugin=protoc-gen-jsonif-$target=$BUILD_DIR/test/protoc-gen-jsonif-$target \
+      --jsonif-${target}_out=$BUILD_DIR/test/$target \
+      --jsonif-${target}_opt=include_imports,base64url$extra_opt \
+      base64url.proto
+  done
+  $INSTALL_DIR/protoc/bin/protoc \
+    -I. \
+    -I$PROTO_DIR \
+    --plugin=protoc-gen-jsonif-unity=$BUILD_DIR/test/protoc-gen-jsonif-unity \
+    --jsonif-unity_out=../unity/JsonifUnityTest/Assets/Generated \
+    --jsonif-unity_opt=include_imports,canonical \
+    canonical.proto \
+    canonical_bytes.proto
+  $INSTALL_DIR/protoc/bin/protoc \
+    -I. \
+    -I$PROTO_DIR \
+    --plugin=protoc-gen-jsonif-unity=$BUILD_DIR/test/protoc-gen-jsonif-unity \
+    --jsonif-unity_out=../unity/JsonifUnityTest/Assets/Generated \
+    --jsonif-unity_opt=include_imports,base64url \
+    base64url.proto
 popd
 
-g++ test/cpp/main.cpp \
+g++ -std=c++17 test/cpp/main.cpp \
   -I $BUILD_DIR/test/cpp \
   -I $INSTALL_DIR/boost/include/ \
   -o $BUILD_DIR/test/cpp/test
 $BUILD_DIR/test/cpp/test
 
-g++ test/cpp/main.cpp \
+g++ -std=c++17 test/cpp/main.cpp \
   -I $BUILD_DIR/test/cpp \
   -I $INSTALL_DIR/json/include/ \
   -o $BUILD_DIR/test/cpp/test_nlohmann \
   -DJSONIF_USE_NLOHMANN_JSON
 $BUILD_DIR/test/cpp/test_nlohmann
 
-g++ -g \
+# jsonif::try_from_json が -fno-exceptions で使えることを確認する
+g++ -std=c++17 -fno-exceptions test/cpp/noexcept.cpp \
+  -I $BUILD_DIR/test/cpp \
+  -I $INSTALL_DIR/boost/include/ \
+  -o $BUILD_DIR/test/cpp/test_noexcept
+$BUILD_DIR/test/cpp/test_noexcept
+
+g++ -std=c++17 -fno-exceptions test/cpp/noexcept.cpp \
+  -I $BUILD_DIR/test/cpp \
+  -I $INSTALL_DIR/json/include/ \
+  -o $BUILD_DIR/test/cpp/test_noexcept_nlohmann \
+  -DJSONIF_USE_NLOHMANN_JSON
+$BUILD_DIR/test/cpp/test_noexcept_nlohmann
+
+g++ -std=c++17 -g \
   test/c/main.cpp \
   $BUILD_DIR/test/c/*.cpp \
   $BUILD_DIR/test/c/google/protobuf/*.cpp \
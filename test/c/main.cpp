@@ -14,6 +14,21 @@
 #include "importing.json.c.h"
 #include "bytes.json.c.h"
 #include "size.json.c.h"
+#include "map.json.c.h"
+#include "wellknown.json.c.h"
+#include "jsonvalue.json.c.h"
+#include "any.json.c.h"
+#include "canonical.json.c.h"
+#include "canonical_bytes.json.c.h"
+#include "enum_name.json.c.h"
+#include "oneof_active_only.json.c.h"
+#include "default_value.json.c.h"
+#include "validation.json.c.h"
+#include "deprecated.json.c.h"
+// C の _from_json は C++ のデシリアライザを使うので、report_deprecated の通知は C++ の関数で受け取る
+#include "deprecated.json.h"
+#include "unknown_fields.json.c.h"
+#include "strict.json.c.h"
 // #include "jsonfield.json.h"
 // #include "optimistic.json.h"
 // #include "discard_if_default.json.h"
@@ -302,6 +317,11 @@ void test_bytes() {
   assert(b.rp_data_len == 2);
   assert(b.rp_data_lens[0] == v.size() && memcmp(b.rp_data[0], v.data(), v.size()) == 0);
   assert(b.rp_data_lens[1] == v2.size() && memcmp(b.rp_data[1], v2.data(), v2.size()) == 0);
+
+  // bytes は base64 の文字列になる
+  std::string json(bytes_Test_to_json_size(&b) - 1, 0);
+  bytes_Test_to_json(&b, &json[0]);
+  assert(json.find(R"("data":"AAECAw==")") != std::string::npos);
 }
 
 void test_size() {
@@ -315,6 +335,460 @@ void test_size() {
   assert(p->v == 100 && q->v == 100);
 }
 
+void test_map() {
+  mappb_Test a;
+  mappb_Test_init(&a);
+  mappb_Test_alloc_a(&a, 2);
+  mappb_Test_AEntry_set_key(&a.a[0], "foo");
+  mappb_Test_AEntry_set_value(&a.a[0], 1);
+  mappb_Test_AEntry_set_key(&a.a[1], "bar");
+  mappb_Test_AEntry_set_value(&a.a[1], 2);
+  mappb_Message m;
+  mappb_Message_init(&m);
+  mappb_Message_set_name(&m, "hoge");
+  mappb_Test_alloc_d(&a, 1);
+  mappb_Test_DEntry_set_key(&a.d[0], "baz");
+  mappb_Test_DEntry_set_value(&a.d[0], &m);
+  mappb_Test_alloc_e(&a, 1);
+  mappb_Test_EEntry_set_key(&a.e[0], true);
+  mappb_Test_EEntry_set_value(&a.e[0], 1.5);
+  mappb_Test b;
+  mappb_Test_init(&b);
+  TEST_IDENTIFY(mappb_Test, &a, &b);
+  // std::map になるのでキーの順に並ぶ
+  assert(b.a_len == 2);
+  assert(strcmp(b.a[0].key, "bar") == 0 && b.a[0].value == 2);
+  assert(strcmp(b.a[1].key, "foo") == 0 && b.a[1].value == 1);
+  assert(b.d_len == 1 && strcmp(b.d[0].key, "baz") == 0 && strcmp(b.d[0].value.name, "hoge") == 0);
+  assert(b.e_len == 1 && b.e[0].key == true && b.e[0].value == 1.5);
+
+  mappb_Message_destroy(&m);
+  mappb_Test_destroy(&a);
+  mappb_Test_destroy(&b);
+}
+
+void test_wellknown() {
+  wellknown_Test a;
+  wellknown_Test_init(&a);
+  a.timestamp.seconds = -1;
+  a.timestamp.nanos = 500000000;
+  a.duration.seconds = -1;
+  a.duration.nanos = -500000000;
+  google_protobuf_Int32Value v;
+  google_protobuf_Int32Value_init(&v);
+  assert(!v.has_value);
+  google_protobuf_Int32Value_set_value(&v, 0);
+  assert(v.has_value);
+  wellknown_Test_set_int32_value(&a, &v);
+  google_protobuf_StringValue s;
+  google_protobuf_StringValue_init(&s);
+  google_protobuf_StringValue_set_value(&s, "hoge");
+  wellknown_Test_set_string_value(&a, &s);
+  wellknown_Test_alloc_int32_values(&a, 2);
+  wellknown_Test_set_int32_values(&a, 1, &v);
+  wellknown_Test b;
+  wellknown_Test_init(&b);
+  TEST_IDENTIFY(wellknown_Test, &a, &b);
+  assert(b.timestamp.seconds == -1 && b.timestamp.nanos == 500000000);
+  assert(b.duration.seconds == -1 && b.duration.nanos == -500000000);
+  assert(b.int32_value.has_value && b.int32_value.value == 0);
+  assert(!b.int64_value.has_value);
+  assert(b.string_value.has_value && strcmp(b.string_value.value, "hoge") == 0);
+  assert(b.int32_values_len == 2 && !b.int32_values[0].has_value && b.int32_values[1].has_value);
+
+  std::string str(wellknown_Test_to_json_size(&a) - 1, '\0');
+  wellknown_Test_to_json(&a, &str[0]);
+  assert(str.find(R"("timestamp":"1969-12-31T23:59:59.500Z")") != std::string::npos);
+  assert(str.find(R"("duration":"-1.500s")") != std::string::npos);
+  assert(str.find(R"("int64_value":null)") != std::string::npos);
+
+  google_protobuf_Int32Value_destroy(&v);
+  google_protobuf_StringValue_destroy(&s);
+  wellknown_Test_destroy(&a);
+  wellknown_Test_destroy(&b);
+}
+
+void test_jsonvalue() {
+  jsonvalue_Test a;
+  jsonvalue_Test_init(&a);
+  std::string str(jsonvalue_Test_to_json_size(&a) - 1, '\0');
+  jsonvalue_Test_to_json(&a, &str[0]);
+  assert(str.find(R"("struct_value":null)") != std::string::npos);
+  assert(str.find(R"("value":null)") != std::string::npos);
+
+  // JSON の文字列で設定する
+  jsonvalue_Test_set_struct_value(&a, R"({"foo": [1, "bar", null]})");
+  jsonvalue_Test_set_value(&a, "1.5");
+  jsonvalue_Test_set_list_value(&a, "[true]");
+  jsonvalue_Test_alloc_values(&a, 2);
+  jsonvalue_Test_set_values(&a, 1, R"("hoge")");
+  jsonvalue_Test_alloc_structs(&a, 1);
+  jsonvalue_Test_StructsEntry_set_key(&a.structs[0], "s");
+  jsonvalue_Test_StructsEntry_set_value(&a.structs[0], R"({"n":-1})");
+  jsonvalue_Test_set_oneof_value(&a, "false");
+  jsonvalue_Test b;
+  jsonvalue_Test_init(&b);
+  TEST_IDENTIFY(jsonvalue_Test, &a, &b);
+  // 読み込んだ値は空白を含まない JSON の文字列になる
+  assert(strcmp(b.struct_value, R"({"foo":[1,"bar",null]})") == 0);
+  assert(b.struct_value_len == (int)strlen(b.struct_value));
+  assert(strcmp(b.value, "1.5") == 0);
+  assert(strcmp(b.list_value, "[true]") == 0);
+  // JSON の null は NULL になる
+  assert(b.values_len == 2 && b.values[0] == NULL && strcmp(b.values[1], R"("hoge")") == 0);
+  assert(b.structs_len == 1 && strcmp(b.structs[0].value, R"({"n":-1})") == 0);
+  assert(b.kind_case == jsonvalue_Test_KindCase_kOneofValue && strcmp(b.oneof_value, "false") == 0);
+
+  // JSON の文字列を jsonif_json の木にして辿る
+  jsonif_json j;
+  assert(jsonif_json_parse(b.struct_value, &j));
+  assert(j.type == JSONIF_JSON_OBJECT && j.len == 1 && strcmp(j.keys[0], "foo") == 0);
+  const jsonif_json* foo = jsonif_json_get(&j, "foo");
+  assert(foo != NULL && foo->type == JSONIF_JSON_ARRAY && foo->len == 3);
+  assert(jsonif_json_at(foo, 0)->type == JSONIF_JSON_NUMBER && jsonif_json_at(foo, 0)->number_value == 1);
+  assert(jsonif_json_at(foo, 1)->type == JSONIF_JSON_STRING && strcmp(jsonif_json_at(foo, 1)->string_value, "bar") == 0);
+  assert(jsonif_json_at(foo, 2)->type == JSONIF_JSON_NULL);
+  assert(jsonif_json_at(foo, 3) == NULL);
+  assert(jsonif_json_get(&j, "bar") == NULL);
+  assert(jsonif_json_get(foo, "foo") == NULL);
+  // 木を JSON の文字列に戻して設定する
+  char* s = jsonif_json_to_string(&j);
+  assert(strcmp(s, R"({"foo":[1,"bar",null]})") == 0);
+  jsonvalue_Test_set_struct_value(&a, s);
+  free(s);
+  jsonif_json_free(&j);
+  assert(j.type == JSONIF_JSON_NULL && j.len == 0);
+
+  assert(jsonif_json_parse(b.value, &j));
+  assert(j.type == JSONIF_JSON_NUMBER && j.number_value == 1.5);
+  s = jsonif_json_to_string(&j);
+  assert(strcmp(s, "1.5") == 0);
+  free(s);
+  jsonif_json_free(&j);
+  assert(jsonif_json_parse(b.list_value, &j));
+  assert(j.type == JSONIF_JSON_ARRAY && j.keys == NULL && jsonif_json_at(&j, 0)->type == JSONIF_JSON_BOOL &&
+         jsonif_json_at(&j, 0)->bool_value);
+  jsonif_json_free(&j);
+  // NULL は JSON の null になり、null は NULL に戻る
+  assert(jsonif_json_parse(b.values[0], &j));
+  assert(j.type == JSONIF_JSON_NULL && jsonif_json_to_string(&j) == NULL);
+  // 読み込めない場合は false を返す
+  assert(!jsonif_json_parse("{", &j));
+  assert(j.type == JSONIF_JSON_NULL);
+
+  jsonvalue_Test_destroy(&a);
+  jsonvalue_Test_destroy(&b);
+}
+
+void test_any() {
+  // Any は "@type" を含む JSON の文字列で設定する
+  anypb_Payload p;
+  anypb_Payload_init(&p);
+  anypb_Payload_set_name(&p, "foo");
+  std::string ps(anypb_Payload_to_json_size(&p) - 1, '\0');
+  anypb_Payload_to_json(&p, &ps[0]);
+  std::string any = R"({"@type":"type.googleapis.com/anypb.Payload",)" + ps.substr(1);
+
+  anypb_Test a;
+  anypb_Test_init(&a);
+  anypb_Test_set_any(&a, any.c_str());
+  anypb_Test_alloc_anys(&a, 1);
+  anypb_Test b;
+  anypb_Test_init(&b);
+  TEST_IDENTIFY(anypb_Test, &a, &b);
+  assert(b.any != NULL && strstr(b.any, R"("@type":"type.googleapis.com/anypb.Payload")") != NULL);
+  assert(b.anys_len == 1 && b.anys[0] == NULL);
+
+  // "@type" は無視して読み込める
+  anypb_Payload q;
+  anypb_Payload_init(&q);
+  anypb_Payload_from_json(b.any, &q);
+  assert(anypb_Payload_is_equal(&p, &q));
+
+  anypb_Payload_destroy(&p);
+  anypb_Payload_destroy(&q);
+  anypb_Test_destroy(&a);
+  anypb_Test_destroy(&b);
+}
+
+void test_canonical() {
+  // canonical で生成した C++ のコードを経由して変換する
+  canonical_Test a;
+  canonical_Test_init(&a);
+  canonical_Test_set_int64_value(&a, -9007199254740993);
+  canonical_Test_set_color(&a, canonical_COLOR_RED);
+  canonical_Test_set_kind_number(&a, 7);
+  canonical_Test b;
+  canonical_Test_init(&b);
+  TEST_IDENTIFY(canonical_Test, &a, &b);
+  assert(b.int64_value == -9007199254740993);
+  assert(b.kind_case == canonical_Test_KindCase_kKindNumber);
+
+  std::string str(canonical_Test_to_json_size(&a) - 1, '\0');
+  canonical_Test_to_json(&a, &str[0]);
+  assert(str.find(R"("int64Value":"-9007199254740993")") != std::string::npos);
+  assert(str.find(R"("color":"COLOR_RED")") != std::string::npos);
+  assert(str.find(R"("kindNumber":"7")") != std::string::npos);
+  assert(str.find(R"("intValue")") == std::string::npos);
+  assert(str.find(R"(_case)") == std::string::npos);
+
+  std::string v("\x00\xff\x10", 3);
+  canonical_BytesTest c;
+  canonical_BytesTest_init(&c);
+  canonical_BytesTest_set_data(&c, (const uint8_t*)v.data(), v.size());
+  canonical_BytesTest d;
+  canonical_BytesTest_init(&d);
+  TEST_IDENTIFY(canonical_BytesTest, &c, &d);
+  assert(d.data_len == v.size() && memcmp(d.data, v.data(), v.size()) == 0);
+  str.assign(canonical_BytesTest_to_json_size(&c) - 1, '\0');
+  canonical_BytesTest_to_json(&c, &str[0]);
+  assert(str.find(R"("data":"AP8Q")") != std::string::npos);
+
+  canonical_Test_destroy(&a);
+  canonical_Test_destroy(&b);
+  canonical_BytesTest_destroy(&c);
+  canonical_BytesTest_destroy(&d);
+}
+
+void test_enum_name() {
+  enum_name_Test a;
+  enum_name_Test_init(&a);
+  enum_name_Test_set_color(&a, enum_name_COLOR_RED);
+  enum_name_Test_alloc_colors(&a, 2);
+  enum_name_Test_set_colors(&a, 0, enum_name_COLOR_BLUE);
+  enum_name_Test_set_colors(&a, 1, (enum_name_Color)100);
+  enum_name_Test_set_number(&a, enum_name_NUMBER_ONE);
+  enum_name_Test_set_nested(&a, enum_name_Test_NESTED_BAR);
+  enum_name_Test b;
+  enum_name_Test_init(&b);
+  TEST_IDENTIFY(enum_name_Test, &a, &b);
+  assert(b.color == enum_name_COLOR_RED);
+  assert(b.colors_len == 2 && b.colors[1] == (enum_name_Color)100);
+
+  std::string str(enum_name_Test_to_json_size(&a) - 1, '\0');
+  enum_name_Test_to_json(&a, &str[0]);
+  assert(str.find(R"("color":"COLOR_RED")") != std::string::npos);
+  assert(str.find(R"("colors":["COLOR_BLUE",100])") != std::string::npos);
+  assert(str.find(R"("number":1)") != std::string::npos);
+  assert(str.find(R"("nested":"NESTED_BAR")") != std::string::npos);
+
+  enum_name_Test_destroy(&a);
+  enum_name_Test_destroy(&b);
+}
+
+void test_oneof_active_only() {
+  oneof_active_only_Test a;
+  oneof_active_only_Test_init(&a);
+  oneof_active_only_Test_set_number(&a, 0);
+  oneof_active_only_Test b;
+  oneof_active_only_Test_init(&b);
+  TEST_IDENTIFY(oneof_active_only_Test, &a, &b);
+  assert(b.kind_case == oneof_active_only_Test_KindCase_kNumber);
+
+  std::string str(oneof_active_only_Test_to_json_size(&a) - 1, '\0');
+  oneof_active_only_Test_to_json(&a, &str[0]);
+  assert(str.find(R"("number":0)") != std::string::npos);
+  assert(str.find(R"("name")") == std::string::npos);
+  assert(str.find(R"("kind_case")") == std::string::npos);
+
+  // _case がある以前の形式の JSON も読み込める
+  oneof_active_only_Test c;
+  oneof_active_only_Test_init(&c);
+  oneof_active_only_Test_from_json(
+      R"({"kind_case":1,"name":"foo","number":0,"inner":{"value":0},"legacy_case":0,"legacy_name":"","legacy_number":0,"value":0})",
+      &c);
+  assert(c.kind_case == oneof_active_only_Test_KindCase_kName);
+  assert(strcmp(c.name, "foo") == 0);
+
+  oneof_active_only_Test_destroy(&a);
+  oneof_active_only_Test_destroy(&b);
+  oneof_active_only_Test_destroy(&c);
+}
+
+void test_default_value() {
+  default_value_Test a;
+  default_value_Test_init(&a);
+  assert(a.d == 1.5);
+  assert(a.f == 3.0f);
+  assert(a.i64 == INT64_MIN);
+  assert(a.u32 == UINT32_MAX);
+  assert(a.b);
+  assert(strcmp(a.s, "hello \"jsonif\"\n") == 0);
+  assert(a.s_len == 15);
+  assert(a.empty == nullptr);
+  assert(a.color == default_value_COLOR_RED);
+  assert(a.color_num == default_value_COLOR_BLUE);
+  assert(a.inner.value == 10);
+
+  // デフォルト値のフィールドは出力しない
+  std::string str(default_value_Test_to_json_size(&a) - 1, '\0');
+  default_value_Test_to_json(&a, &str[0]);
+  assert(str == "{}");
+
+  // キーが無い場合はデフォルト値になり、ある場合は空文字でも上書きする
+  default_value_Test b;
+  default_value_Test_init(&b);
+  default_value_Test_from_json(R"({"i32":0,"s":"","b":false})", &b);
+  assert(b.i32 == 0);
+  assert(b.s == nullptr && b.s_len == 0);
+  assert(!b.b);
+  assert(b.d == 1.5);
+  assert(b.inner.value == 10);
+
+  default_value_Test c;
+  default_value_Test_init(&c);
+  TEST_IDENTIFY(default_value_Test, &b, &c);
+
+  default_value_Test_destroy(&a);
+  default_value_Test_destroy(&b);
+  default_value_Test_destroy(&c);
+}
+
+void test_validation() {
+  validation_Person a;
+  validation_Person_init(&a);
+  validation_Person_from_json(
+      R"({"name":"foo","age":1,"email":"foo@example.com","role":1,"tags":[],"score":1,"ratio":0})",
+      &a);
+  assert(validation_Person_validate(&a, NULL) == 0);
+  jsonif_violation* vs = (jsonif_violation*)1;
+  assert(validation_Person_validate(&a, &vs) == 0);
+  assert(vs == NULL);
+
+  validation_Person_set_age(&a, 151);
+  validation_Person_set_email(&a, "foo");
+  assert(validation_Person_validate(&a, NULL) == 2);
+  int n = validation_Person_validate(&a, &vs);
+  assert(n == 2);
+  assert(strcmp(vs[0].path, "age") == 0);
+  assert(strcmp(vs[0].message, "must be less than or equal to 150") == 0);
+  assert(strcmp(vs[1].path, "email") == 0);
+  assert(strcmp(vs[1].message, R"(must match pattern "^[^@]+@[^@]+$")") == 0);
+  jsonif_violations_free(vs, n);
+  validation_Person_destroy(&a);
+
+  validation_Team t;
+  validation_Team_init(&t);
+  n = validation_Team_validate(&t, &vs);
+  assert(n == 6);
+  assert(strcmp(vs[0].path, "leader") == 0);
+  assert(strcmp(vs[0].message, "is required") == 0);
+  assert(strcmp(vs[1].path, "leader.name") == 0);
+  assert(strcmp(vs[4].path, "members") == 0);
+  assert(strcmp(vs[5].path, "size") == 0);
+  jsonif_violations_free(vs, n);
+  validation_Team_destroy(&t);
+}
+
+void test_deprecated() {
+  deprecated_Test a;
+  deprecated_Test_init(&a);
+  deprecated_Test_set_id(&a, 10);
+  deprecated_Test_set_status(&a, deprecated_STATUS_ACTIVE);
+  deprecated_Test_set_number(&a, 5);
+  int size = deprecated_Test_to_json_size(&a);
+  std::string json(size - 1, 0);
+  deprecated_Test_to_json(&a, &json[0]);
+  deprecated_Test b;
+  deprecated_Test_init(&b);
+  deprecated_Test_from_json(json.c_str(), &b);
+  assert(deprecated_Test_is_equal(&a, &b));
+  deprecated_Test_destroy(&b);
+
+  jsonif::deprecated_field_count() = 0;
+  deprecated_Test_from_json(
+      R"({"id":1,"old_id":2,"status":1,"legacy":{"value":3},"tags":[],"choice_case":7,"number":1})", &a);
+  assert(a.id == 1);
+  assert(jsonif::deprecated_field_count() == 3);
+  jsonif::deprecated_field_count() = 0;
+  deprecated_Test_destroy(&a);
+}
+
+void test_unknown_fields() {
+  // C の構造体は知らないキーを保持しないので、読み込んで書き出すと無くなる
+  unknown_fields_Inner a;
+  unknown_fields_Inner_init(&a);
+  unknown_fields_Inner_from_json(R"({"value":2,"x":[1,"y"]})", &a);
+  assert(a.value == 2);
+  int size = unknown_fields_Inner_to_json_size(&a);
+  std::string json(size - 1, 0);
+  unknown_fields_Inner_to_json(&a, &json[0]);
+  assert(json == R"({"value":2})");
+  unknown_fields_Inner_destroy(&a);
+}
+
+void test_strict() {
+  strict_Person a;
+  strict_Person_init(&a);
+  jsonif_error error = {NULL, NULL};
+  assert(strict_Person_try_from_json(R"({"name":"a","age":1,"tags":["x"],"color":1})", &a, &error));
+  assert(strcmp(a.name, "a") == 0);
+  assert(a.color == strict_GREEN);
+  assert(error.path == NULL && error.message == NULL);
+
+  // C++ と同じく、位置と理由を返す
+  assert(!strict_Person_try_from_json(R"({"name":"a","age":1,"tags":[1],"color":1})", &a, &error));
+  assert(strcmp(error.path, "tags[0]") == 0);
+  assert(strcmp(error.message, "expected string, got number") == 0);
+  jsonif_error_free(&error);
+  assert(!strict_Person_try_from_json(R"({"name":"a","age":1,"tags":[],"color":1,"x":1})", &a, &error));
+  assert(strcmp(error.path, "x") == 0);
+  assert(strcmp(error.message, "unknown key") == 0);
+  // 解放せずに続けて使っても、前のエラーは解放してから設定する
+  assert(!strict_Person_try_from_json(R"({"name":1,"age":1,"tags":[],"color":1})", &a, &error));
+  assert(strcmp(error.path, "name") == 0);
+  jsonif_error_free(&error);
+
+  // 不正な JSON は位置の無いエラーになる
+  assert(!strict_Person_try_from_json("{", &a, &error));
+  assert(strcmp(error.path, "") == 0);
+  assert(strlen(error.message) != 0);
+  jsonif_error_free(&error);
+
+  // error は NULL でもいい
+  assert(!strict_Person_try_from_json("[]", &a, NULL));
+  // 失敗した場合は変更しない
+  assert(strcmp(a.name, "a") == 0);
+  strict_Person_destroy(&a);
+}
+
+void test_from_json_error() {
+  message_Person a;
+  message_Person_init(&a);
+  assert(message_Person_from_json(R"({"name":"foo","flag":true})", &a) == 0);
+  assert(strcmp(a.name, "foo") == 0);
+  assert(jsonif_last_error()->path == NULL && jsonif_last_error()->message == NULL);
+
+  // 読み込めない場合は例外を投げずに 0 以外を返して、jsonif_last_error で理由を取得できる
+  assert(message_Person_from_json(R"({"name":1,"flag":true})", &a) != 0);
+  assert(strcmp(jsonif_last_error()->path, "name") == 0);
+  assert(strcmp(jsonif_last_error()->message, "expected string, got number") == 0);
+  assert(message_Person_from_json("{", &a) != 0);
+  assert(strcmp(jsonif_last_error()->path, "") == 0);
+  assert(strlen(jsonif_last_error()->message) != 0);
+  // 失敗した場合は変更しない
+  assert(strcmp(a.name, "foo") == 0);
+  // _copy は jsonif_last_error を変更しない
+  message_Person c;
+  message_Person_init(&c);
+  message_Person_copy(&a, &c);
+  assert(strcmp(c.name, "foo") == 0);
+  assert(strlen(jsonif_last_error()->message) != 0);
+  message_Person_destroy(&c);
+
+  // 別のファイルのメッセージのエラーも同じ関数で取得できる
+  wellknown_Test b;
+  wellknown_Test_init(&b);
+  assert(wellknown_Test_from_json(R"({"timestamp":"x"})", &b) != 0);
+  assert(strcmp(jsonif_last_error()->path, "timestamp") == 0);
+  assert(strcmp(jsonif_last_error()->message, "invalid timestamp") == 0);
+  assert(message_Person_from_json(R"({"name":"bar","flag":false})", &a) == 0);
+  assert(jsonif_last_error()->message == NULL);
+
+  wellknown_Test_destroy(&b);
+  message_Person_destroy(&a);
+}
+
 int main() {
   test_empty();
   test_message();
@@ -326,6 +800,19 @@ int main() {
   test_importing();
   test_bytes();
   test_size();
+  test_map();
+  test_wellknown();
+  test_jsonvalue();
+  test_any();
+  test_canonical();
+  test_enum_name();
+  test_oneof_active_only();
+  test_default_value();
+  test_validation();
+  test_deprecated();
+  test_unknown_fields();
+  test_strict();
+  test_from_json_error();
 
   std::cout << "C Test passed" << std::endl;
 }
\ No newline at end of file
@@ -18,6 +18,21 @@
 #include "optimistic.json.h"
 #include "discard_if_default.json.h"
 #include "no_serializer.json.h"
+#include "map.json.h"
+#include "wellknown.json.h"
+#include "jsonvalue.json.h"
+#include "any.json.h"
+#include "canonical.json.h"
+#include "canonical_bytes.json.h"
+#include "enum_name.json.h"
+#include "oneof_active_only.json.h"
+#include "default_value.json.h"
+#include "validation.json.h"
+#include "deprecated.json.h"
+#include "unknown_fields.json.h"
+#include "strict.json.h"
+#include "base64url.json.h"
+#include "size.json.h"
 
 template<class T>
 T identify(T v) {
@@ -161,10 +176,13 @@ void test_optional() {
   assert(!a.has_b());
   assert(!a.has_c());
   assert(!a.has_d());
+  // 設定されていないフィールドはキーを出力せず、_case も出力しない
+  assert(jsonif::to_json(a) == "{}");
 
   a.set_a(1);
   assert(a.has_a());
   assert(a.a == 1);
+  assert(jsonif::to_json(a) == R"({"a":1})");
   a = identify(a);
   assert(a.has_a());
   assert(a.a == 1);
@@ -198,13 +216,17 @@ void test_optional() {
   assert(!a.has_c());
   a.clear_d();
   assert(!a.has_d());
+
+  // キーがあるフィールドが設定されているフィールドになる
+  a = jsonif::from_json<optional::Test>(R"({"b":"x"})");
+  assert(!a.has_a() && a.has_b() && a.b == "x");
 }
 
 void test_importing() {
   importing::Test a;
-  assert(a.t.nanos == 0);
+  assert(a.t.time_since_epoch().count() == 0);
   a = identify(a);
-  assert(a.t.nanos == 0);
+  assert(a.t.time_since_epoch().count() == 0);
 }
 
 void test_bytes() {
@@ -218,6 +240,31 @@ void test_bytes() {
   assert(a.data == v);
   assert(a.rp_data.at(0) == v);
   assert(a.rp_data.at(1) == v2);
+
+  // bytes はパディング付きの base64 の文字列になる
+  bytes::Test b;
+  b.data = std::string("\x00\xff\x10", 3);
+  b.rp_data.push_back("a");
+  assert(jsonif::to_json(b) == R"({"data":"AP8Q","rp_data":["YQ=="]})");
+  // URL セーフな base64 やパディングの無い base64 も読み込める
+  b = jsonif::from_json<bytes::Test>(R"({"data":"-_8","rp_data":["YQ"]})");
+  assert(b.data == "\xfb\xff");
+  assert(b.rp_data.at(0) == "a");
+}
+
+void test_base64url() {
+  base64url::Test a;
+  a.data = "\xfb\xff";
+  a.datas.push_back("a");
+  a.data_map["x"] = "ab";
+  a.wrapped_data = "\xff";
+  a = identify(a);
+  // パディング無しの URL セーフな base64 になる
+  std::string json = jsonif::to_json(a);
+  assert(json.find(R"("data":"-_8")") != std::string::npos);
+  assert(json.find(R"("datas":["YQ"])") != std::string::npos);
+  assert(json.find(R"("data_map":{"x":"YWI"})") != std::string::npos);
+  assert(json.find(R"("wrapped_data":"_w")") != std::string::npos);
 }
 
 void test_jsonfield() {
@@ -295,6 +342,642 @@ void test_no_serializer() {
   assert(a.a == "hoge");
 }
 
+void test_map() {
+  mappb::Test a;
+  assert(a.a.empty());
+  a = identify(a);
+  assert(a.a.empty());
+
+  a.a["foo"] = 1;
+  a.b[-2] = "bar";
+  a.c[3] = mappb::BAR;
+  a.d["baz"] = mappb::Message{"hoge"};
+  a.e[true] = 1.5;
+  a.f[18446744073709551615ULL] = true;
+  a = identify(a);
+  assert(a.a.size() == 1 && a.a.at("foo") == 1);
+  assert(a.b.size() == 1 && a.b.at(-2) == "bar");
+  assert(a.c.size() == 1 && a.c.at(3) == mappb::BAR);
+  assert(a.d.size() == 1 && a.d.at("baz").name == "hoge");
+  assert(a.e.size() == 1 && a.e.at(true) == 1.5);
+  assert(a.f.size() == 1 && a.f.at(18446744073709551615ULL) == true);
+
+  // map は JSON のオブジェクトになる
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("b":{"-2":"bar"})") != std::string::npos);
+  assert(str.find(R"("e":{"true":1.5})") != std::string::npos);
+}
+
+void test_wellknown() {
+  wellknown::Test a;
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("timestamp":"1970-01-01T00:00:00Z")") != std::string::npos);
+  assert(str.find(R"("duration":"0s")") != std::string::npos);
+  assert(str.find(R"("int32_value":null)") != std::string::npos);
+  a = identify(a);
+
+  a.timestamp = decltype(a.timestamp)(std::chrono::seconds(63108020) + std::chrono::milliseconds(21));
+  a.duration = std::chrono::milliseconds(-1500);
+  a.int32_value = 0;
+  a.string_value = "hoge";
+  a.timestamps.push_back(a.timestamp);
+  a.int32_values.push_back(std::nullopt);
+  a.int32_values.push_back(1);
+  a.durations["foo"] = std::chrono::seconds(3) + std::chrono::nanoseconds(1);
+  a = identify(a);
+  assert(a.int32_value && *a.int32_value == 0);
+  assert(!a.int64_value);
+  assert(a.int32_values.size() == 2 && !a.int32_values[0] && *a.int32_values[1] == 1);
+
+  str = jsonif::to_json(a);
+  assert(str.find(R"("timestamp":"1972-01-01T10:00:20.021Z")") != std::string::npos);
+  assert(str.find(R"("duration":"-1.500s")") != std::string::npos);
+  assert(str.find(R"("int32_value":0)") != std::string::npos);
+  assert(str.find(R"("string_value":"hoge")") != std::string::npos);
+  assert(str.find(R"("int32_values":[null,1])") != std::string::npos);
+  assert(str.find(R"("durations":{"foo":"3.000000001s"})") != std::string::npos);
+
+  // タイムゾーン付きの時刻も読める
+  auto pos = str.find("1972-01-01T10:00:20.021Z");
+  str.replace(pos, 24, "1972-01-01T19:00:20.021+09:00");
+  auto b = jsonif::from_json<wellknown::Test>(str);
+  assert(b.timestamp == a.timestamp);
+
+  // 不正な形式は例外になる
+  pos = str.find("1972-01-01T19:00:20.021+09:00");
+  str.replace(pos, 29, "1972-01-01");
+  bool thrown = false;
+  try {
+    jsonif::from_json<wellknown::Test>(str);
+  } catch (const std::exception&) {
+    thrown = true;
+  }
+  assert(thrown);
+}
+
+void test_jsonvalue() {
+  jsonvalue::Test a;
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("struct_value":null)") != std::string::npos);
+  assert(str.find(R"("value":null)") != std::string::npos);
+  assert(str.find(R"("list_value":null)") != std::string::npos);
+  a = identify(a);
+
+  // nlohmann::json と boost::json でオブジェクトのキーの順序が異なるので、キーは１つにしておく
+  a.struct_value = jsonif::from_json<::jsonif::json_value>(R"({"foo":[1,"bar",null]})");
+  a.value = jsonif::from_json<::jsonif::json_value>("1.5");
+  a.list_value = jsonif::from_json<::jsonif::json_value>(R"([true,{"x":{}}])");
+  a.values.push_back(jsonif::from_json<::jsonif::json_value>("null"));
+  a.values.push_back(jsonif::from_json<::jsonif::json_value>(R"("hoge")"));
+  a.structs["s"] = jsonif::from_json<::jsonif::json_value>(R"({"n":-1})");
+  a.oneof_value = jsonif::from_json<::jsonif::json_value>("false");
+  a.kind_case = jsonvalue::Test::KindCase::kOneofValue;
+  a = identify(a);
+
+  str = jsonif::to_json(a);
+  assert(str.find(R"("struct_value":{"foo":[1,"bar",null]})") != std::string::npos);
+  assert(str.find(R"("value":1.5)") != std::string::npos);
+  assert(str.find(R"("list_value":[true,{"x":{}}])") != std::string::npos);
+  assert(str.find(R"("values":[null,"hoge"])") != std::string::npos);
+  assert(str.find(R"("structs":{"s":{"n":-1}})") != std::string::npos);
+  assert(str.find(R"("oneof_value":false)") != std::string::npos);
+}
+
+void test_any() {
+  anypb::Test a;
+  assert(jsonif::to_json(a).find(R"("any":null)") != std::string::npos);
+  a = identify(a);
+
+  anypb::Payload p;
+  p.name = "foo";
+  p.count = 3;
+  a.any = jsonif::pack(p);
+  anypb::Test::Nested n;
+  n.tags.push_back("bar");
+  a.anys.push_back(jsonif::pack(n));
+  a.anys.push_back(jsonif::any());
+  a.any_map["p"] = jsonif::pack(p);
+  a = identify(a);
+
+  assert(a.any.type_name() == "anypb.Payload");
+  assert(jsonif::is<anypb::Payload>(a.any));
+  assert(!jsonif::is<anypb::Test::Nested>(a.any));
+  assert(!jsonif::unpack<anypb::Test::Nested>(a.any));
+  auto q = jsonif::unpack<anypb::Payload>(a.any);
+  assert(q && *q == p);
+  auto m = jsonif::unpack<anypb::Test::Nested>(a.anys[0]);
+  assert(m && m->tags.size() == 1 && m->tags[0] == "bar");
+  assert(a.anys[1].value.is_null());
+  assert(jsonif::unpack<anypb::Payload>(a.any_map["p"]) == p);
+
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("any":{"@type":"type.googleapis.com/anypb.Payload",)") != std::string::npos);
+  assert(str.find(R"("@type":"type.googleapis.com/anypb.Test.Nested")") != std::string::npos);
+
+  // "@type" の無いオブジェクトはエラーになる
+  bool thrown = false;
+  try {
+    jsonif::from_json<anypb::Test>(R"({"any":{"name":"foo"},"anys":[],"any_map":{},"kind_case":0})");
+  } catch (const std::exception&) {
+    thrown = true;
+  }
+  assert(thrown);
+}
+
+void test_canonical() {
+  canonical::Test a;
+  assert(jsonif::to_json(a) == "{}");
+  a = identify(a);
+
+  a.int64_value = -9007199254740993;
+  a.uint64_value = 18446744073709551615ull;
+  a.int64_values.push_back(1);
+  a.int64_values.push_back(-2);
+  a.int64_map["a"] = 3;
+  a.renamed = "foo";
+  a.color = canonical::COLOR_RED;
+  a.colors.push_back(canonical::COLOR_BLUE);
+  a.colors.push_back((canonical::Color)100);
+  a.color_map[1] = canonical::COLOR_BLUE;
+  a.inner.value = 5;
+  a.wrapped_int64 = 6;
+  a.set_opt_value(0);
+  a.set_kind_number(7);
+  a = identify(a);
+
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("intValue")") == std::string::npos);
+  assert(str.find(R"("int64Value":"-9007199254740993")") != std::string::npos);
+  assert(str.find(R"("uint64Value":"18446744073709551615")") != std::string::npos);
+  assert(str.find(R"("int64Values":["1","-2"])") != std::string::npos);
+  assert(str.find(R"("int64Map":{"a":"3"})") != std::string::npos);
+  assert(str.find(R"("customName":"foo")") != std::string::npos);
+  assert(str.find(R"("color":"COLOR_RED")") != std::string::npos);
+  assert(str.find(R"("colors":["COLOR_BLUE",100])") != std::string::npos);
+  assert(str.find(R"("colorMap":{"1":"COLOR_BLUE"})") != std::string::npos);
+  assert(str.find(R"("inner":{"value":5})") != std::string::npos);
+  assert(str.find(R"("wrappedInt64":"6")") != std::string::npos);
+  assert(str.find(R"("optValue":0)") != std::string::npos);
+  assert(str.find(R"("kindNumber":"7")") != std::string::npos);
+  assert(str.find(R"(_case)") == std::string::npos);
+  assert(str.find(R"("kindName")") == std::string::npos);
+
+  // 数値の int64 や enum も読み込める
+  auto b = jsonif::from_json<canonical::Test>(R"({"int64Value":10,"color":2,"kindName":"bar","optValue":3})");
+  assert(b.int64_value == 10);
+  assert(b.color == canonical::COLOR_BLUE);
+  assert(b.kind_case == canonical::Test::KindCase::kKindName);
+  assert(b.kind_name == "bar");
+  assert(b.has_opt_value() && b.opt_value == 3);
+  assert(b.int_value == 0);
+
+  canonical::BytesTest c;
+  c.data = std::string("\x00\xff\x10", 3);
+  c.datas.push_back("a");
+  c.datas.push_back("ab");
+  c.data_map["x"] = "abc";
+  c.wrapped_data = "";
+  c = identify(c);
+  str = jsonif::to_json(c);
+  assert(str.find(R"("data":"AP8Q")") != std::string::npos);
+  assert(str.find(R"("datas":["YQ==","YWI="])") != std::string::npos);
+  assert(str.find(R"("dataMap":{"x":"YWJj"})") != std::string::npos);
+  assert(str.find(R"("wrappedData":"")") != std::string::npos);
+
+  // URL セーフな base64 やパディングの無い base64 も読み込める
+  auto d = jsonif::from_json<canonical::BytesTest>(R"({"data":"AP8Q","datas":["-_8","YQ"]})");
+  assert(d.data == std::string("\x00\xff\x10", 3));
+  assert(d.datas.size() == 2 && d.datas[0] == "\xfb\xff" && d.datas[1] == "a");
+}
+
+void test_enum_name() {
+  enum_name::Test a;
+  a = identify(a);
+
+  a.color = enum_name::COLOR_RED;
+  a.colors.push_back(enum_name::COLOR_BLUE);
+  a.colors.push_back((enum_name::Color)100);
+  a.color_map["a"] = enum_name::COLOR_BLUE;
+  a.number = enum_name::NUMBER_ONE;
+  a.nested = enum_name::Test::NESTED_BAR;
+  a.set_opt_color(enum_name::COLOR_BLUE);
+  a.set_oneof_color(enum_name::COLOR_RED);
+  a = identify(a);
+
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("color":"COLOR_RED")") != std::string::npos);
+  // 未知の値は数値のまま出力する
+  assert(str.find(R"("colors":["COLOR_BLUE",100])") != std::string::npos);
+  assert(str.find(R"("color_map":{"a":"COLOR_BLUE"})") != std::string::npos);
+  // jsonif_enum_as_name = false の enum は数値になる
+  assert(str.find(R"("number":1)") != std::string::npos);
+  assert(str.find(R"("nested":"NESTED_BAR")") != std::string::npos);
+  assert(str.find(R"("opt_color":"COLOR_BLUE")") != std::string::npos);
+  assert(str.find(R"("oneof_color":"COLOR_RED")") != std::string::npos);
+
+  // 数値も読み込めて、未知の名前は 0 になる
+  auto b = jsonif::from_json<enum_name::Test>(R"({"value_case":0,"color":2,"colors":["COLOR_RED","UNKNOWN",100],"nested":"NESTED_BAR"})");
+  assert(b.color == enum_name::COLOR_BLUE);
+  assert(b.colors.size() == 3);
+  assert(b.colors[0] == enum_name::COLOR_RED);
+  assert(b.colors[1] == enum_name::COLOR_UNSPECIFIED);
+  assert(b.colors[2] == (enum_name::Color)100);
+  assert(b.nested == enum_name::Test::NESTED_BAR);
+}
+
+void test_oneof_active_only() {
+  oneof_active_only::Test a;
+  a = identify(a);
+  auto str = jsonif::to_json(a);
+  assert(str.find(R"("kind_case")") == std::string::npos);
+  assert(str.find(R"("name")") == std::string::npos);
+  assert(str.find(R"("opt")") == std::string::npos);
+  // oneof の設定で無効にしたものは今まで通り出力する
+  assert(str.find(R"("legacy_case":0)") != std::string::npos);
+  assert(str.find(R"("legacy_name":"")") != std::string::npos);
+
+  a.set_number(0);
+  a.set_opt(0);
+  a = identify(a);
+  assert(a.kind_case == oneof_active_only::Test::KindCase::kNumber);
+  assert(a.has_opt() && a.opt == 0);
+  str = jsonif::to_json(a);
+  assert(str.find(R"("number":0)") != std::string::npos);
+  assert(str.find(R"("opt":0)") != std::string::npos);
+  assert(str.find(R"("name")") == std::string::npos);
+  assert(str.find(R"("inner")") == std::string::npos);
+
+  a.inner.value = 10;
+  a.set_name("foo");
+  a = identify(a);
+  assert(a.kind_case == oneof_active_only::Test::KindCase::kName);
+  assert(a.name == "foo");
+  str = jsonif::to_json(a);
+  assert(str.find(R"("name":"foo")") != std::string::npos);
+  assert(str.find(R"("inner")") == std::string::npos);
+
+  // _case がある以前の形式の JSON も読み込める
+  auto b = jsonif::from_json<oneof_active_only::Test>(
+      R"({"kind_case":3,"name":"","number":0,"inner":{"value":5},"legacy_case":4,"legacy_name":"bar","legacy_number":0,"value":1})");
+  assert(b.kind_case == oneof_active_only::Test::KindCase::kInner);
+  assert(b.inner.value == 5);
+  assert(b.legacy_case == oneof_active_only::Test::LegacyCase::kLegacyName);
+  assert(b.legacy_name == "bar");
+  assert(!b.has_opt());
+  assert(b.value == 1);
+
+  oneof_active_only::Test2 c;
+  assert(jsonif::to_json(c) == "{}");
+  c.set_number(3);
+  c = identify(c);
+  assert(jsonif::to_json(c) == R"({"number":3})");
+}
+
+void test_default_value() {
+  default_value::Test a;
+  assert(a.d == 1.5);
+  assert(a.f == 3.0f);
+  assert(a.big == 1e30);
+  assert(a.i32 == INT32_MIN);
+  assert(a.i64 == INT64_MIN);
+  assert(a.u32 == UINT32_MAX);
+  assert(a.u64 == 12345678901234ULL);
+  assert(a.b);
+  assert(a.s == "hello \"jsonif\"\n");
+  assert(a.empty == "");
+  assert(a.color == default_value::Color::COLOR_RED);
+  assert(a.color_num == default_value::Color::COLOR_BLUE);
+  assert(a.no_default == 0);
+  assert(a.inner.value == 10);
+  // デフォルト値のフィールドは出力しない
+  assert(jsonif::to_json(a) == "{}");
+  a = identify(a);
+  assert(a.i64 == INT64_MIN);
+  assert(a.inner.value == 10);
+
+  // キーが無い場合はデフォルト値になる
+  auto b = jsonif::from_json<default_value::Test>("{}");
+  assert(b == default_value::Test());
+  b = jsonif::from_json<default_value::Test>(R"({"i32":0,"s":"","b":false,"color":0,"inner":{}})");
+  assert(b.i32 == 0);
+  assert(b.s == "");
+  assert(!b.b);
+  assert(b.color == default_value::Color::COLOR_UNSPECIFIED);
+  assert(b.inner.value == 10);
+  assert(b.d == 1.5);
+  // デフォルト値と異なる値は出力する
+  auto str = jsonif::to_json(b);
+  assert(str.find(R"("i32":0)") != std::string::npos);
+  assert(str.find(R"("s":"")") != std::string::npos);
+  assert(str.find(R"("b":false)") != std::string::npos);
+  assert(str.find(R"("color":0)") != std::string::npos);
+  assert(str.find(R"("d")") == std::string::npos);
+  b = identify(b);
+  assert(b.i32 == 0 && b.s == "" && !b.b);
+}
+
+void test_validation() {
+  validation::Person a;
+  a.name = "foo";
+  a.email = "foo@example.com";
+  a.set_score(1.0);
+  assert(jsonif::validate(a).empty());
+
+  a.name = "あいうえおかきく";
+  assert(jsonif::validate(a).empty());
+  a.name = "あいうえおかきくけ";
+  a.age = 151;
+  a.email = "foo";
+  a.role = (validation::Role)100;
+  a.tags = {"a", "", "c", "d"};
+  a.clear_score();
+  a.ratio = 1.5f;
+  auto r = jsonif::validate(a);
+  assert(r.size() == 8);
+  assert(r[0].path == "name" && r[0].message == "length must be at most 8");
+  assert(r[1].path == "age" && r[1].message == "must be less than or equal to 150");
+  assert(r[2].path == "email" && r[2].message == R"(must match pattern "^[^@]+@[^@]+$")");
+  assert(r[3].path == "role" && r[3].message == "must be a defined enum value");
+  assert(r[4].path == "tags" && r[4].message == "must have at most 3 items");
+  assert(r[5].path == "tags[1]" && r[5].message == "length must be at least 1");
+  assert(r[6].path == "score" && r[6].message == "is required");
+  assert(r[7].path == "ratio");
+
+  // ネストしたメッセージはパスで位置が分かる
+  validation::Team t;
+  r = jsonif::validate(t);
+  assert(r.size() == 6);
+  assert(r[0].path == "leader" && r[0].message == "is required");
+  assert(r[1].path == "leader.name");
+  assert(r[2].path == "leader.email");
+  assert(r[3].path == "leader.score");
+  assert(r[4].path == "members" && r[4].message == "must have at least 1 items");
+  assert(r[5].path == "size" && r[5].message == "is required");
+
+  t = jsonif::from_json<validation::Team>(R"({
+    "leader": {"name":"a","age":1,"email":"a@b","role":1,"tags":[],"score":1,"ratio":0},
+    "members": [
+      {"name":"a","age":1,"email":"a@b","role":1,"tags":[],"score":1,"ratio":0},
+      {"name":"b","age":-1,"email":"a@b","role":1,"tags":[],"score":1,"ratio":0}
+    ],
+    "by_name": {"x": {"name":"a","age":1,"email":"a@b","role":1,"tags":[],"score":0,"ratio":0}},
+    "limits": {"1": 11},
+    "size": 0,
+    "contact_case": 6,
+    "phone": "03-1234",
+    "contact_person": {"name":"","age":0,"email":"","role":0,"tags":[],"ratio":0}
+  })");
+  r = jsonif::validate(t);
+  assert(r.size() == 4);
+  assert(r[0].path == "members[1].age");
+  assert(r[1].path == R"(by_name["x"].score)" && r[1].message == "must be greater than or equal to 0.5");
+  assert(r[2].path == R"(limits["1"])" && r[2].message == "must be less than or equal to 10");
+  assert(r[3].path == "phone");
+
+  // 後で宣言されるメッセージも検証する
+  validation::League l;
+  l.clubs.resize(2);
+  l.clubs[1].name = "a";
+  l = identify(l);
+  r = jsonif::validate(l);
+  assert(r.size() == 3);
+  assert(r[0].path == "champion" && r[0].message == "is required");
+  assert(r[1].path == "champion.name");
+  assert(r[2].path == "clubs[0].name" && r[2].message == "length must be at least 1");
+}
+
+void test_deprecated() {
+  // deprecated な要素を使わなければ警告は出ない（-Werror=deprecated-declarations でビルドできる）
+  deprecated::Test a;
+  a.id = 10;
+  a.status = deprecated::Status::STATUS_ACTIVE;
+  a.set_number(5);
+  a = identify(a);
+  deprecated::Holder h;
+  h.statuses["x"] = deprecated::Status::STATUS_ACTIVE;
+  h = identify(h);
+
+  // deprecated なフィールドのキーが JSON にあったら通知される
+  std::vector<std::string> reported;
+  jsonif::deprecated_field_handler() = [&reported](const char* message, const char* key) {
+    reported.push_back(std::string(message) + "." + key);
+  };
+  jsonif::deprecated_field_count() = 0;
+  a = jsonif::from_json<deprecated::Test>(
+      R"({"id":1,"old_id":2,"status":1,"legacy":{"value":3},"tags":[],"choice_case":8,"text":"x"})");
+  assert(a.id == 1);
+  assert(jsonif::deprecated_field_count() == 4);
+  assert(reported.size() == 4);
+  assert(reported[0] == "deprecated.Test.old_id");
+  assert(reported[1] == "deprecated.Test.legacy");
+  assert(reported[2] == "deprecated.Test.tags");
+  assert(reported[3] == "deprecated.Test.text");
+  // optional のフィールドはキーがある場合だけ通知される
+  a = jsonif::from_json<deprecated::Test>(
+      R"({"id":1,"old_id":2,"status":1,"legacy":{"value":3},"tags":[],"choice_case":7,"number":1,"note":"y"})");
+  assert(jsonif::deprecated_field_count() == 8);
+  assert(reported[7] == "deprecated.Test.note");
+  jsonif::deprecated_field_handler() = nullptr;
+  jsonif::deprecated_field_count() = 0;
+}
+
+void test_unknown_fields() {
+  // 知らないキーは保持して、そのまま出力する
+  std::string json =
+      R"({"id":1,"name":"a","inner":{"value":2,"x":[1,"y"]},"inners":[{"value":3,"z":null}],)"
+      R"("inner_map":{"k":{"value":4,"w":true}},"kind_case":6,"number":5,"text":"","opt":0,"extra":{"a":1},"more":"b"})";
+  auto a = jsonif::from_json<unknown_fields::Test>(json);
+  assert(a.id == 1);
+  assert(a.number == 5);
+  assert(a.jsonif_unknown_fields.size() == 2);
+  assert(a.inner.jsonif_unknown_fields.size() == 1);
+  assert(a.inners[0].jsonif_unknown_fields.size() == 1);
+  assert(a.inner_map["k"].jsonif_unknown_fields.size() == 1);
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+  assert(a.jsonif_unknown_fields.at("more") == "b");
+  assert(nlohmann::json::parse(jsonif::to_json(a)) == nlohmann::json::parse(json));
+#else
+  assert(a.jsonif_unknown_fields.at("more").as_string() == "b");
+  assert(boost::json::parse(jsonif::to_json(a)) == boost::json::parse(json));
+#endif
+  a = identify(a);
+
+  // 知らないキーも比較する
+  auto b = a;
+  b.jsonif_unknown_fields.erase("more");
+  assert(a != b);
+  b = a;
+  b.inner.jsonif_unknown_fields.clear();
+  assert(a != b);
+
+  // 知っているキーと同じキーは出力しない
+  b = a;
+  b.jsonif_unknown_fields["id"] = 100;
+  assert(jsonif::from_json<unknown_fields::Test>(jsonif::to_json(b)).id == 1);
+
+  // 知らないキーを持たないメッセージでは捨てる
+  auto c = jsonif::from_json<unknown_fields::Plain>(
+      R"({"id":1,"test":{"id":2,"name":"","inner":{"value":0},"inners":[],"inner_map":{},"kind_case":0,"x":1},"y":2})");
+  assert(c.test.jsonif_unknown_fields.size() == 1);
+  assert(jsonif::to_json(c).find("\"y\"") == std::string::npos);
+
+  // フィールドが無いメッセージ
+  auto d = jsonif::from_json<unknown_fields::Empty>(R"({"a":1})");
+  assert(d.jsonif_unknown_fields.size() == 1);
+  assert(jsonif::to_json(d) == R"({"a":1})");
+  assert(jsonif::to_json(unknown_fields::Empty()) == "{}");
+}
+
+// JSON を読み込んだ時の parse_error の what()
+template<class T>
+std::string strict_error(const std::string& json) {
+  try {
+    jsonif::from_json<T>(json);
+  } catch (const jsonif::parse_error& e) {
+    return e.what();
+  }
+  return "";
+}
+
+void test_strict() {
+  std::string json =
+      R"({"people":[{"name":"a","age":1,"tags":["x"],"color":1}],"by_name":{"b":{"name":"b","age":2,"tags":[],"color":0}},)"
+      R"("labels":{"-1":"m"},"address":{"city":"c","zip":"ignored"},"kind_case":5,"number":3,"opt":4,"big":5,"limit":null})";
+  auto a = jsonif::from_json<strict::Config>(json);
+  assert(a.people.size() == 1 && a.people[0].name == "a");
+  assert(a.labels.at(-1) == "m");
+  assert(a.address.city == "c");
+  assert(a.number == 3 && a.opt == 4 && !a.limit);
+  assert(jsonif::from_json<strict::Config>(jsonif::to_json(a)) == a);
+
+  // 型の違う値は位置付きのエラーになる
+  assert(strict_error<strict::Person>(R"({"name":1,"age":1,"tags":[],"color":0})") == "name: expected string, got number");
+  assert(strict_error<strict::Config>(
+             R"({"people":[{"name":"a","age":1,"tags":[],"color":0},{"name":"b","age":1,"tags":[2],"color":0}],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"big":0,"limit":null})") ==
+         "people[1].tags[0]: expected string, got number");
+  assert(strict_error<strict::Config>(
+             R"({"people":[],"by_name":{"x":{"name":"x","age":"1","tags":[],"color":0}},"labels":{},"address":{"city":""},"kind_case":0,"big":0,"limit":null})") ==
+         R"(by_name["x"].age: expected integer, got string)");
+  assert(strict_error<strict::Config>(
+             R"({"people":[],"by_name":{},"labels":{"a":""},"address":{"city":""},"kind_case":0,"big":0,"limit":null})") ==
+         R"(labels["a"]: invalid map key)");
+  assert(strict_error<strict::Config>(
+             R"({"people":[],"by_name":{},"labels":{},"address":{"city":1},"kind_case":0,"big":0,"limit":null})") ==
+         "address.city: expected string, got number");
+  assert(strict_error<strict::Config>(
+             R"({"people":[],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"opt":-1,"big":0,"limit":null})") ==
+         "opt: out of range");
+  assert(strict_error<strict::Config>(
+             R"({"people":[],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"big":1.5,"limit":null})") ==
+         "big: expected integer, got number");
+  assert(strict_error<strict::Config>(
+             R"({"people":[],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"big":0,"limit":"1"})") ==
+         "limit: expected integer, got string");
+  assert(strict_error<strict::Config>("[]") == "expected object, got array");
+
+  // 知らないキーと必須のキーが無い場合もエラーになる
+  assert(strict_error<strict::Person>(R"({"name":"a","age":1,"tags":[],"color":0,"nmae":"b"})") == "nmae: unknown key");
+  assert(strict_error<strict::Person>(R"({"name":"a","tags":[],"color":0})") == "age: missing key");
+  assert(strict_error<strict::Config>(
+             R"({"people":[{"name":"a","age":1,"tags":[],"color":0,"x":1}],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"big":0,"limit":null})") ==
+         "people[0].x: unknown key");
+
+  // strict ではないメッセージの中にある strict なメッセージも調べる
+  auto b = jsonif::from_json<strict::Loose>(R"({"id":1,"person":{"name":"a","age":1,"tags":[],"color":0},"x":1})");
+  assert(b.id == 1 && b.person.name == "a");
+  // 位置は一番外側のメッセージからの位置になる
+  assert(strict_error<strict::Loose>(R"({"id":1,"person":{"name":"a","age":1,"tags":[],"color":0,"x":1}})") ==
+         "person.x: unknown key");
+  assert(strict_error<strict::Loose>(R"({"id":1,"person":{"name":1,"age":1,"tags":[],"color":0}})") ==
+         "person.name: expected string, got number");
+
+  // parse_error から位置と理由を取り出せる
+  try {
+    jsonif::from_json<strict::Person>(R"({"name":"a","age":1,"tags":[],"color":"RED"})");
+    assert(false);
+  } catch (const jsonif::parse_error& e) {
+    assert(e.path() == "color");
+    assert(e.message() == "expected integer, got string");
+  }
+}
+
+// JSON の中の from を to に置き換える
+std::string replace(std::string s, const std::string& from, const std::string& to) {
+  size_t pos = s.find(from);
+  assert(pos != std::string::npos);
+  return s.replace(pos, from.size(), to);
+}
+
+// try_from_json と from_json で同じ JSON を読み込んで、結果が食い違わないことを確認する
+// try_from_json で読み込めた JSON は from_json でも同じ値で読み込めて、from_json で読み込めない JSON は try_from_json でも読み込めない
+// （-fno-exceptions の場合、try_from_json で調べた後の from_json が例外を投げると abort してしまう）
+// try_from_json で読み込めたかどうかを返す
+template<class T>
+bool agree(const std::string& json) {
+  T a;
+  jsonif::error err;
+  bool ok = jsonif::try_from_json(json, a, err);
+  try {
+    T b = jsonif::from_json<T>(json);
+    assert(!ok || a == b);
+  } catch (const std::exception&) {
+    assert(!ok);
+  }
+  return ok;
+}
+
+void test_try_from_json() {
+  assert(agree<message::Person>(R"({"name":"a","flag":true})"));
+  assert(!agree<message::Person>(R"({"name":1,"flag":true})"));
+  assert(!agree<message::Person>(R"({"name":"a","flag":1})"));
+  assert(!agree<message::Person>(R"({"name":"a"})"));
+  assert(!agree<message::Person>("[]"));
+  assert(!agree<message::Person>("{"));
+
+  // 整数として表せる小数は、どちらも整数として読み込む
+  assert(agree<size::Test>(R"({"v":1})"));
+  assert(agree<size::Test>(R"({"v":1.0})"));
+  assert(agree<size::Test>(R"({"v":-1e3})"));
+  assert(agree<size::Test>(R"({"v":-9223372036854775808})"));
+  assert(agree<size::Test>(R"({"v":9223372036854775807})"));
+  assert(jsonif::from_json<size::Test>(R"({"v":1.0})").v == 1);
+  // nlohmann/json の from_json は小数や範囲外の値も読み込んでしまうが、try_from_json はエラーにする
+  assert(!agree<size::Test>(R"({"v":1.5})"));
+  assert(!agree<size::Test>(R"({"v":9223372036854775808})"));
+  assert(!agree<size::Test>(R"({"v":"1"})"));
+
+  assert(agree<repeated::Test>(R"({"a":[1,2.0],"b":["x"],"c":[1,2],"d":[{"name":"x"}]})"));
+  assert(!agree<repeated::Test>(R"({"a":[1,"x"],"b":[],"c":[],"d":[]})"));
+  assert(!agree<repeated::Test>(R"({"a":{},"b":[],"c":[],"d":[]})"));
+  assert(!agree<repeated::Test>(R"({"a":[],"b":[],"c":[],"d":[{"name":1}]})"));
+
+  assert(agree<oneof::Test>(R"({"test_oneof_case":1,"a":1.0})"));
+  assert(agree<oneof::Test>(R"({"test_oneof_case":4,"d":{"name":"x"}})"));
+  assert(!agree<oneof::Test>(R"({"test_oneof_case":2,"b":1})"));
+  assert(!agree<oneof::Test>(R"({"test_oneof_case":"1","a":1})"));
+
+  assert(agree<mappb::Test>(R"({"a":{"x":1},"b":{"-1":"y"},"c":{"2":1},"d":{"z":{"name":"z"}},"e":{"true":1.5},"f":{"18446744073709551615":true}})"));
+  assert(!agree<mappb::Test>(R"({"a":{"x":"1"},"b":{},"c":{},"d":{},"e":{},"f":{}})"));
+  // from_json は map のキーを緩く読み込むが、try_from_json はエラーにする
+  assert(!agree<mappb::Test>(R"({"a":{},"b":{"x":"y"},"c":{},"d":{},"e":{},"f":{}})"));
+  assert(!agree<mappb::Test>(R"({"a":{},"b":{},"c":{},"d":{},"e":{"yes":1},"f":{}})"));
+
+  std::string w = jsonif::to_json(wellknown::Test());
+  assert(agree<wellknown::Test>(w));
+  assert(!agree<wellknown::Test>(replace(w, R"("timestamp":"1970-01-01T00:00:00Z")", R"("timestamp":"x")")));
+  assert(!agree<wellknown::Test>(replace(w, R"("timestamp":"1970-01-01T00:00:00Z")", R"("timestamp":1)")));
+  assert(agree<wellknown::Test>(replace(w, R"("int32_value":null)", R"("int32_value":1.0)")));
+  assert(!agree<wellknown::Test>(replace(w, R"("int32_value":null)", R"("int32_value":"1")")));
+
+  assert(agree<bytes::Test>(R"({"data":"AAE=","rp_data":["", "AA=="]})"));
+  assert(!agree<bytes::Test>(R"({"data":"!!","rp_data":[]})"));
+  assert(!agree<bytes::Test>(R"({"data":"","rp_data":[1]})"));
+
+  assert(agree<strict::Loose>(R"({"id":1,"person":{"name":"a","age":2.0,"tags":[],"color":0},"x":1})"));
+  assert(!agree<strict::Loose>(R"({"id":1,"person":{"name":"a","age":1,"tags":[],"color":0,"x":1}})"));
+  assert(!agree<strict::Loose>(R"({"id":1,"person":{"name":1,"age":1,"tags":[],"color":0}})"));
+}
+
 int main() {
   test_empty();
   test_message();
@@ -305,10 +988,24 @@ int main() {
   test_optional();
   test_importing();
   test_bytes();
+  test_base64url();
   test_jsonfield();
   test_optimistic();
   test_discard_if_default();
   test_no_serializer();
+  test_map();
+  test_wellknown();
+  test_jsonvalue();
+  test_any();
+  test_canonical();
+  test_enum_name();
+  test_oneof_active_only();
+  test_default_value();
+  test_validation();
+  test_deprecated();
+  test_unknown_fields();
+  test_strict();
+  test_try_from_json();
 
   std::cout << "C++ Test passed" << std::endl;
 }
\ No newline at end of file
@@ -0,0 +1,118 @@
+// -fno-exceptions でコンパイルして、jsonif::try_from_json が例外を使わずに動くことを確認する
+#include <iostream>
+#include <cassert>
+#include <cstdlib>
+#if defined(JSONIF_USE_NLOHMANN_JSON)
+#else
+#include <boost/json/src.hpp>
+
+// BOOST_NO_EXCEPTIONS の場合は利用側で定義する必要がある
+namespace boost {
+void throw_exception(const std::exception&) {
+  std::abort();
+}
+void throw_exception(const std::exception&, const boost::source_location&) {
+  std::abort();
+}
+}
+#endif
+
+#include "message.json.h"
+#include "wellknown.json.h"
+#include "any.json.h"
+#include "canonical_bytes.json.h"
+#include "strict.json.h"
+
+// JSON の中の from を to に置き換える
+std::string replace(std::string s, const std::string& from, const std::string& to) {
+  size_t pos = s.find(from);
+  assert(pos != std::string::npos);
+  return s.replace(pos, from.size(), to);
+}
+
+template<class T>
+std::string try_error(const std::string& json) {
+  T v;
+  jsonif::error err;
+  bool ok = jsonif::try_from_json(json, v, err);
+  assert(!ok);
+  return err.path.empty() ? err.message : err.path + ": " + err.message;
+}
+
+void test_message() {
+  message::Person a;
+  jsonif::error err;
+  assert(jsonif::try_from_json(R"({"name":"foo","flag":true})", a, err));
+  assert(a.name == "foo" && a.flag);
+
+  assert(try_error<message::Person>("{").rfind("invalid JSON", 0) == 0);
+  assert(try_error<message::Person>("[]") == "expected object, got array");
+  assert(try_error<message::Person>(R"({"name":1,"flag":true})") == "name: expected string, got number");
+  assert(try_error<message::Person>(R"({"name":"foo"})") == "flag: missing key");
+}
+
+void test_wellknown() {
+  wellknown::Test a;
+  a.timestamps.push_back(jsonif::detail::timestamp(std::chrono::seconds(1)));
+  std::string json = jsonif::to_json(a);
+  wellknown::Test b;
+  jsonif::error err;
+  assert(jsonif::try_from_json(json, b, err));
+  assert(a == b);
+
+  assert(try_error<wellknown::Test>(replace(json, R"("1970-01-01T00:00:01Z")", R"("x")")) ==
+         "timestamps[0]: invalid timestamp");
+  assert(try_error<wellknown::Test>(replace(json, R"("1970-01-01T00:00:01Z")", R"("9999-01-01T00:00:00Z")")) ==
+         "timestamps[0]: out of range");
+  assert(try_error<wellknown::Test>(replace(json, R"("duration":"0s")", R"("duration":"1")")) ==
+         "duration: invalid duration");
+  assert(try_error<wellknown::Test>(replace(json, R"("int32_value":null)", R"("int32_value":"1")")) ==
+         "int32_value: expected integer, got string");
+}
+
+void test_any() {
+  anypb::Test a;
+  a.any = jsonif::pack(message::Person());
+  std::string json = jsonif::to_json(a);
+  anypb::Test b;
+  jsonif::error err;
+  assert(jsonif::try_from_json(json, b, err));
+  assert(a == b);
+
+  assert(try_error<anypb::Test>(replace(json, R"("@type":"type.googleapis.com/message.Person",)", "")) ==
+         "any.@type: missing key");
+  assert(try_error<anypb::Test>(replace(json, "type.googleapis.com/message.Person", "type.googleapis.com/")) ==
+         "any.@type: invalid type URL");
+}
+
+void test_base64() {
+  canonical::BytesTest a;
+  jsonif::error err;
+  assert(jsonif::try_from_json(R"({"data":"AAEC","datas":["-_8"]})", a, err));
+  assert(a.data == std::string("\x00\x01\x02", 3));
+  assert(a.datas.size() == 1 && a.datas[0] == "\xfb\xff");
+
+  assert(try_error<canonical::BytesTest>(R"({"data":"!!"})") == "data: invalid base64");
+  assert(try_error<canonical::BytesTest>(R"({"dataMap":{"a":"A"}})") == R"(dataMap["a"]: invalid base64)");
+}
+
+void test_strict() {
+  strict::Person a;
+  jsonif::error err;
+  assert(jsonif::try_from_json(R"({"name":"a","age":1,"tags":["x"],"color":1})", a, err));
+  assert(a.name == "a" && a.tags.size() == 1);
+
+  assert(try_error<strict::Person>(R"({"name":"a","age":1,"tags":[],"color":0,"nmae":"b"})") == "nmae: unknown key");
+  // strict ではないメッセージの中にある strict なメッセージも調べる
+  assert(try_error<strict::Loose>(R"({"id":1,"person":{"name":"a","age":1,"tags":[],"color":0,"x":1}})") ==
+         "person.x: unknown key");
+}
+
+int main() {
+  test_message();
+  test_wellknown();
+  test_any();
+  test_base64();
+  test_strict();
+  std::cout << "C++ noexcept Test passed" << std::endl;
+}
@@ -6,7 +6,22 @@ import * as repeated from "gen/repeated";
 import * as oneof from "gen/oneof";
 import * as optional from "gen/optional";
 import * as importing from "gen/importing";
-import { Jsonif, getType, fromJson, toJson } from "gen/jsonif";
+import * as mappb from "gen/map";
+import * as wellknown from "gen/wellknown";
+import * as jsonvalue from "gen/jsonvalue";
+import * as anypb from "gen/any";
+import * as canonical from "gen/canonical";
+import * as canonical_bytes from "gen/canonical_bytes";
+import * as enum_name from "gen/enum_name";
+import * as oneof_active_only from "gen/oneof_active_only";
+import * as default_value from "gen/default_value";
+import * as validation from "gen/validation";
+import * as deprecated from "gen/deprecated";
+import * as unknown_fields from "gen/unknown_fields";
+import * as strict from "gen/strict";
+import * as bytes from "gen/bytes";
+import * as base64url from "gen/base64url";
+import { Jsonif, getType, fromJson, toJson, pack, unpack, is, setDeprecatedFieldHandler, getDeprecatedFieldCount, resetDeprecatedFieldCount, ParseError } from "gen/jsonif";
 
 function assertEqual<T>(a: T, b: T) {
     if (a !== b) {
@@ -160,9 +175,12 @@ function testOptional() {
   assertEqual(a.b, null);
   assertEqual(a.c, null);
   assertEqual(a.d, null);
+  // 設定されていないフィールドはキーを出力しない
+  assertEqual(a.toJson(), "{}");
 
   a.a = 1;
   assertEqual(a.a, 1);
+  assertEqual(a.toJson(), `{"a":1}`);
   a = identify(a);
   assertEqual(a.a, 1);
 
@@ -189,13 +207,480 @@ function testOptional() {
   assertEqual(a.c, null);
   a.d = null;
   assertEqual(a.d, null);
+
+  // キーがあるフィールドが設定されているフィールドになる
+  a = optional.Test.fromJson(`{"b":"x"}`);
+  assertEqual(a.a, null);
+  assertEqual(a.b, "x");
 }
 
 function testImporting() {
   var a = new importing.Test();
-  assertEqual(a.t.nanos, 0);
+  assertEqual(a.t.getTime(), 0);
+  a = identify(a);
+  assertEqual(a.t.getTime(), 0);
+}
+
+function testMap() {
+  var a = new mappb.Test();
+  assertEqual(a.a.size, 0);
+  a = identify(a);
+  assertEqual(a.a.size, 0);
+
+  a.a.set("foo", 1);
+  a.b.set(-2, "bar");
+  a.c.set(3, mappb.Enum.BAR);
+  a.d.set("baz", new mappb.Message({name: "hoge"}));
+  a.e.set(true, 1.5);
+  a = identify(a);
+  assertEqual(a.a.get("foo"), 1);
+  assertEqual(a.b.get(-2), "bar");
+  assertEqual(a.c.get(3), mappb.Enum.BAR);
+  assertEqual(a.d.get("baz")?.name, "hoge");
+  assertEqual(a.e.get(true), 1.5);
+
+  // map は JSON のオブジェクトになる
+  assertEqual(JSON.stringify(a.toObject().b), '{"-2":"bar"}');
+}
+
+function testWellknown() {
+  var a = new wellknown.Test();
+  assertEqual(a.timestamp.getTime(), 0);
+  assertEqual(a.int32_value, null);
+  a = identify(a);
+  assertEqual(a.toObject().timestamp, "1970-01-01T00:00:00Z");
+  assertEqual(a.toObject().duration, "0s");
+
+  a.timestamp = new Date(Date.UTC(1972, 0, 1, 10, 0, 20, 21));
+  a.duration = -1500;
+  a.int32_value = 0;
+  a.string_value = "hoge";
+  a.int32_values = [null, 1];
+  a.durations.set("foo", 3001);
+  a = identify(a);
+  assertEqual(a.timestamp.getTime(), Date.UTC(1972, 0, 1, 10, 0, 20, 21));
+  assertEqual(a.duration, -1500);
+  assertEqual(a.int32_value, 0);
+  assertEqual(a.int64_value, null);
+  assertEqual(a.string_value, "hoge");
+  assertEqual(a.int32_values[0], null);
+  assertEqual(a.durations.get("foo"), 3001);
+
+  const obj = a.toObject();
+  assertEqual(obj.timestamp, "1972-01-01T10:00:20.021Z");
+  assertEqual(obj.duration, "-1.500s");
+  assertEqual(JSON.stringify(obj.durations), '{"foo":"3.001s"}');
+
+  // タイムゾーン付きの時刻も読める
+  const b = wellknown.Test.fromObject({timestamp: "1972-01-01T19:00:20.021+09:00"});
+  assertEqual(b.timestamp.getTime(), a.timestamp.getTime());
+}
+
+function testJsonvalue() {
+  var a = new jsonvalue.Test();
+  assertEqual(a.struct_value, null);
+  assertEqual(a.value, null);
+  assertEqual(a.list_value, null);
+  a = identify(a);
+
+  a.struct_value = {foo: [1, "bar", null]};
+  a.value = 1.5;
+  a.list_value = [true, {x: {}}];
+  a.values = [null, "hoge"];
+  a.structs.set("s", {n: -1});
+  a = identify(a);
+  assertEqual(JSON.stringify(a.struct_value), '{"foo":[1,"bar",null]}');
+  assertEqual(a.value, 1.5);
+  assertEqual(JSON.stringify(a.list_value), '[true,{"x":{}}]');
+  assertEqual(JSON.stringify(a.values), '[null,"hoge"]');
+  assertEqual(JSON.stringify(a.structs.get("s")), '{"n":-1}');
+}
+
+function testAny() {
+  var a = new anypb.Test();
+  assertEqual(a.any, null);
+  a = identify(a);
+
+  const p = new anypb.Payload({name: "foo", count: 3});
+  a.any = pack(p);
+  a.anys = [pack(new anypb.Test_Nested({tags: ["bar"]})), null];
+  a = identify(a);
+  assertEqual(a.any!["@type"], "type.googleapis.com/anypb.Payload");
+  assertEqual(is(a.any!, anypb.Payload), true);
+  assertEqual(is(a.any!, anypb.Test_Nested), false);
+  assertEqual(unpack(a.any!, anypb.Payload).toJson(), p.toJson());
+  // 型を指定しない場合は登録されたクラスから探す
+  const n = unpack(a.anys[0]!);
+  assertEqual(n instanceof anypb.Test_Nested, true);
+  assertEqual((n as anypb.Test_Nested).tags[0], "bar");
+  assertEqual(a.anys[1], null);
+
+  let thrown = false;
+  try {
+    unpack(a.any!, anypb.Test_Nested);
+  } catch (e) {
+    thrown = true;
+  }
+  assertEqual(thrown, true);
+}
+
+function testCanonical() {
+  var a = new canonical.Test();
+  assertEqual(a.toJson(), "{}");
+  a = identify(a);
+
+  a.int64_value = -9007199254740991;
+  a.int64_values = [1, -2];
+  a.int64_map.set("a", 3);
+  a.renamed = "foo";
+  a.color = canonical.Color.COLOR_RED;
+  a.colors = [canonical.Color.COLOR_BLUE, 100];
+  a.color_map.set(1, canonical.Color.COLOR_BLUE);
+  a.inner.value = 5;
+  a.wrapped_int64 = 6;
+  a.opt_value = 0;
+  a.setKindNumber(7);
+  a = identify(a);
+  assertEqual(a.toJson(), JSON.stringify({
+    int64Value: "-9007199254740991",
+    int64Values: ["1", "-2"],
+    int64Map: {a: "3"},
+    customName: "foo",
+    color: "COLOR_RED",
+    colors: ["COLOR_BLUE", 100],
+    colorMap: {"1": "COLOR_BLUE"},
+    inner: {value: 5},
+    wrappedInt64: "6",
+    optValue: 0,
+    kindNumber: "7",
+  }));
+
+  // 数値の int64 や enum も読み込める
+  const b = canonical.Test.fromJson(`{"int64Value":10,"color":2,"kindName":"bar","optValue":3}`);
+  assertEqual(b.int64_value, 10);
+  assertEqual(b.color, canonical.Color.COLOR_BLUE);
+  assertEqual(b.kind_case, canonical.Test_KindCase.kKindName);
+  assertEqual(b.kind_name, "bar");
+  assertEqual(b.opt_value, 3);
+  assertEqual(b.int_value, 0);
+
+  var c = new canonical_bytes.BytesTest();
+  c.data = new Uint8Array([0, 255, 16]);
+  c.datas = [new Uint8Array([97]), new Uint8Array([97, 98])];
+  c.data_map.set("x", new Uint8Array([97, 98, 99]));
+  c.wrapped_data = new Uint8Array(0);
+  c = identify(c);
+  assertEqual(c.toJson(), JSON.stringify({
+    data: "AP8Q",
+    datas: ["YQ==", "YWI="],
+    dataMap: {x: "YWJj"},
+    wrappedData: "",
+  }));
+
+  // URL セーフな base64 やパディングの無い base64 も読み込める
+  const d = canonical_bytes.BytesTest.fromJson(`{"datas":["-_8","YQ"]}`);
+  assertEqual(d.datas[0].join(","), "251,255");
+  assertEqual(d.datas[1].join(","), "97");
+}
+
+function testBytes() {
+  var a = new bytes.Test();
+  a.data = new Uint8Array([0, 255, 16]);
+  a.rp_data = [new Uint8Array([97])];
+  a = identify(a);
+  assertEqual(a.toJson(), JSON.stringify({data: "AP8Q", rp_data: ["YQ=="]}));
+  assertEqual(a.data.join(","), "0,255,16");
+
+  var b = new base64url.Test();
+  b.data = new Uint8Array([251, 255]);
+  b.datas = [new Uint8Array([97])];
+  b.data_map.set("x", new Uint8Array([97, 98]));
+  b.wrapped_data = new Uint8Array([255]);
+  b = identify(b);
+  // パディング無しの URL セーフな base64 になる
+  assertEqual(b.toJson(), JSON.stringify({data: "-_8", datas: ["YQ"], data_map: {x: "YWI"}, wrapped_data: "_w"}));
+  assertEqual(b.data.join(","), "251,255");
+}
+
+function testEnumName() {
+  var a = new enum_name.Test();
+  a = identify(a);
+
+  a.color = enum_name.Color.COLOR_RED;
+  a.colors = [enum_name.Color.COLOR_BLUE, 100];
+  a.color_map.set("a", enum_name.Color.COLOR_BLUE);
+  a.number = enum_name.Number.NUMBER_ONE;
+  a.nested = enum_name.Test_Nested.NESTED_BAR;
+  a.opt_color = enum_name.Color.COLOR_BLUE;
+  a.setOneofColor(enum_name.Color.COLOR_RED);
+  a = identify(a);
+  const obj = JSON.parse(a.toJson());
+  assertEqual(obj.color, "COLOR_RED");
+  // 未知の値は数値のまま出力する
+  assertEqual(JSON.stringify(obj.colors), `["COLOR_BLUE",100]`);
+  assertEqual(obj.color_map.a, "COLOR_BLUE");
+  // jsonif_enum_as_name = false の enum は数値になる
+  assertEqual(obj.number, 1);
+  assertEqual(obj.nested, "NESTED_BAR");
+  assertEqual(obj.opt_color, "COLOR_BLUE");
+  assertEqual(obj.oneof_color, "COLOR_RED");
+
+  // 数値も読み込めて、未知の名前は 0 になる
+  const b = enum_name.Test.fromJson(`{"color":2,"colors":["COLOR_RED","UNKNOWN",100],"nested":"NESTED_BAR"}`);
+  assertEqual(b.color, enum_name.Color.COLOR_BLUE);
+  assertEqual(b.colors.join(","), "1,0,100");
+  assertEqual(b.nested, enum_name.Test_Nested.NESTED_BAR);
+}
+
+function testOneofActiveOnly() {
+  var a = new oneof_active_only.Test();
+  a = identify(a);
+  // oneof の設定で無効にしたものは今まで通り出力する
+  assertEqual(a.toJson(), JSON.stringify({
+    legacy_name: "",
+    legacy_number: 0,
+    value: 0,
+    legacy_case: 0,
+  }));
+
+  a.setNumber(0);
+  a.opt = 0;
   a = identify(a);
-  assertEqual(a.t.nanos, 0);
+  assertEqual(a.kind_case, oneof_active_only.Test_KindCase.kNumber);
+  assertEqual(a.opt, 0);
+  assertEqual(a.toJson(), JSON.stringify({
+    number: 0,
+    legacy_name: "",
+    legacy_number: 0,
+    opt: 0,
+    value: 0,
+    legacy_case: 0,
+  }));
+
+  // _case がある以前の形式の JSON も読み込める
+  const b = oneof_active_only.Test.fromJson(`{"kind_case":3,"name":"","number":0,"inner":{"value":5},"legacy_case":4,"legacy_name":"bar","legacy_number":0,"opt":null,"value":1}`);
+  assertEqual(b.kind_case, oneof_active_only.Test_KindCase.kInner);
+  assertEqual(b.inner.value, 5);
+  assertEqual(b.legacy_case, oneof_active_only.Test_LegacyCase.kLegacyName);
+  assertEqual(b.legacy_name, "bar");
+  assertEqual(b.opt, null);
+
+  var c = new oneof_active_only.Test2();
+  assertEqual(c.toJson(), "{}");
+  c.setNumber(3);
+  c = identify(c);
+  assertEqual(c.toJson(), `{"number":3}`);
+}
+
+function testDefaultValue() {
+  var a = new default_value.Test();
+  assertEqual(a.d, 1.5);
+  assertEqual(a.f, 3);
+  assertEqual(a.i64, -9223372036854775808);
+  assertEqual(a.b, true);
+  assertEqual(a.s, "hello \"jsonif\"\n");
+  assertEqual(a.color, default_value.Color.COLOR_RED);
+  assertEqual(a.color_num, default_value.Color.COLOR_BLUE);
+  assertEqual(a.inner.value, 10);
+  a = identify(a);
+  assertEqual(a.s, "hello \"jsonif\"\n");
+
+  // キーが無い場合はデフォルト値になる
+  const b = default_value.Test.fromJson(`{"i32":0,"s":"","b":false,"inner":{}}`);
+  assertEqual(b.i32, 0);
+  assertEqual(b.s, "");
+  assertEqual(b.b, false);
+  assertEqual(b.d, 1.5);
+  assertEqual(b.color, default_value.Color.COLOR_RED);
+  assertEqual(b.inner.value, 10);
+}
+
+function testValidation() {
+  const a = new validation.Person();
+  a.name = "foo";
+  a.email = "foo@example.com";
+  a.score = 1;
+  assertEqual(a.validate().length, 0);
+
+  // サロゲートペアも 1 文字として数える
+  a.name = "あいうえおかき😀";
+  assertEqual(a.validate().length, 0);
+  a.name = "あいうえおかきく😀";
+  a.age = 151;
+  a.email = "foo";
+  a.role = 100;
+  a.tags = ["a", "", "c", "d"];
+  a.score = null;
+  a.ratio = 1.5;
+  let r = a.validate();
+  assertEqual(r.length, 8);
+  assertEqual(r[0].path, "name");
+  assertEqual(r[0].message, "length must be at most 8");
+  assertEqual(r[1].path, "age");
+  assertEqual(r[1].message, "must be less than or equal to 150");
+  assertEqual(r[2].path, "email");
+  assertEqual(r[2].message, `must match pattern "^[^@]+@[^@]+$"`);
+  assertEqual(r[3].path, "role");
+  assertEqual(r[3].message, "must be a defined enum value");
+  assertEqual(r[4].path, "tags");
+  assertEqual(r[4].message, "must have at most 3 items");
+  assertEqual(r[5].path, "tags[1]");
+  assertEqual(r[5].message, "length must be at least 1");
+  assertEqual(r[6].path, "score");
+  assertEqual(r[6].message, "is required");
+  assertEqual(r[7].path, "ratio");
+
+  // ネストしたメッセージはパスで位置が分かる
+  r = new validation.Team().validate();
+  assertEqual(r.length, 6);
+  assertEqual(r[0].path, "leader");
+  assertEqual(r[0].message, "is required");
+  assertEqual(r[1].path, "leader.name");
+  assertEqual(r[2].path, "leader.email");
+  assertEqual(r[3].path, "leader.score");
+  assertEqual(r[4].path, "members");
+  assertEqual(r[4].message, "must have at least 1 items");
+  assertEqual(r[5].path, "size");
+  assertEqual(r[5].message, "is required");
+
+  const person = `"name":"a","age":1,"email":"a@b","role":1,"tags":[],"ratio":0`;
+  const t = validation.Team.fromJson(`{
+    "leader": {${person},"score":1},
+    "members": [{${person},"score":1}, {${person},"score":1,"age":-1}],
+    "by_name": {"x": {${person},"score":0}},
+    "limits": {"1": 11},
+    "size": 0,
+    "contact_case": 6,
+    "phone": "03-1234",
+    "contact_person": {}
+  }`);
+  r = t.validate();
+  assertEqual(r.length, 4);
+  assertEqual(r[0].path, "members[1].age");
+  assertEqual(r[1].path, `by_name["x"].score`);
+  assertEqual(r[1].message, "must be greater than or equal to 0.5");
+  assertEqual(r[2].path, `limits["1"]`);
+  assertEqual(r[2].message, "must be less than or equal to 10");
+  assertEqual(r[3].path, "phone");
+}
+
+function testDeprecated() {
+  const a = new deprecated.Test();
+  a.id = 10;
+  a.status = deprecated.Status.STATUS_ACTIVE;
+  a.setNumber(5);
+  identify(a);
+
+  // deprecated なフィールドのキーが JSON にあったら通知される
+  const reported: string[] = [];
+  setDeprecatedFieldHandler((typeName, key) => {
+    reported.push(typeName + "." + key);
+  });
+  resetDeprecatedFieldCount();
+  const b = deprecated.Test.fromJson(`{"id":1,"old_id":2,"status":1,"legacy":{"value":3},"tags":[],"choice_case":8,"text":"x"}`);
+  assertEqual(b.id, 1);
+  assertEqual(getDeprecatedFieldCount(), 4);
+  assertEqual(reported.length, 4);
+  assertEqual(reported[0], "deprecated.Test.old_id");
+  assertEqual(reported[1], "deprecated.Test.legacy");
+  assertEqual(reported[2], "deprecated.Test.tags");
+  assertEqual(reported[3], "deprecated.Test.text");
+  // キーが無ければ通知されない
+  deprecated.Test.fromJson(`{"id":1}`);
+  assertEqual(getDeprecatedFieldCount(), 4);
+  setDeprecatedFieldHandler(null);
+  resetDeprecatedFieldCount();
+}
+
+function testUnknownFields() {
+  // 知らないキーは保持して、そのまま出力する
+  const json = `{"id":1,"name":"a","inner":{"value":2,"x":[1,"y"]},"inners":[{"value":3,"z":null}],"inner_map":{"k":{"value":4,"w":true}},"number":5,"text":"","kind_case":6,"extra":{"a":1},"more":"b"}`;
+  const a = unknown_fields.Test.fromJson(json);
+  assertEqual(a.id, 1);
+  assertEqual(a.number, 5);
+  assertEqual(Object.keys(a.extra).length, 2);
+  assertEqual(a.extra["more"], "b");
+  assertEqual(Object.keys(a.inner.extra).length, 1);
+  assertEqual(Object.keys(a.inners[0].extra).length, 1);
+  assertEqual(Object.keys(a.inner_map.get("k")!.extra).length, 1);
+  assertEqual(a.toJson(), json);
+  identify(a);
+
+  // 知っているキーと同じキーは出力しない
+  const b = unknown_fields.Test.fromJson(json);
+  b.extra["id"] = 100;
+  assertEqual(unknown_fields.Test.fromJson(b.toJson()).id, 1);
+
+  // 知らないキーを持たないメッセージでは捨てる
+  const c = unknown_fields.Plain.fromJson(`{"id":1,"test":{"id":2,"x":1},"y":2}`);
+  assertEqual(Object.keys(c.test.extra).length, 1);
+  assertEqual(c.toJson().indexOf(`"y"`), -1);
+
+  // フィールドが無いメッセージ
+  const d = unknown_fields.Empty.fromJson(`{"a":1}`);
+  assertEqual(Object.keys(d.extra).length, 1);
+  assertEqual(d.toJson(), `{"a":1}`);
+  assertEqual(new unknown_fields.Empty().toJson(), `{}`);
+}
+
+// JSON を読み込んだ時の ParseError の message
+function strictError(f: () => void): string {
+  try {
+    f();
+  } catch (e) {
+    if (e instanceof ParseError) {
+      return e.message;
+    }
+    throw e;
+  }
+  return "";
+}
+
+function testStrict() {
+  const json = `{"people":[{"name":"a","age":1,"tags":["x"],"color":1}],"by_name":{"b":{"name":"b","age":2,"tags":[],"color":0}},"labels":{"-1":"m"},"address":{"city":"c","zip":"ignored"},"number":3,"opt":4,"big":5,"limit":null,"kind_case":5}`;
+  const a = strict.Config.fromJson(json);
+  assertEqual(a.people[0].name, "a");
+  assertEqual(a.labels.get(-1), "m");
+  assertEqual(a.address.city, "c");
+  assertEqual(a.opt, 4);
+  identify(a);
+
+  // 型の違う値は C++ と同じ位置付きのエラーになる
+  assertEqual(strictError(() => strict.Person.fromJson(`{"name":1,"age":1,"tags":[],"color":0}`)), "name: expected string, got number");
+  assertEqual(strictError(() => strict.Config.fromJson(`{"people":[{"name":"a","age":1,"tags":[],"color":0},{"name":"b","age":1,"tags":[2],"color":0}],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"big":0,"limit":null}`)),
+    "people[1].tags[0]: expected string, got number");
+  assertEqual(strictError(() => strict.Config.fromJson(`{"people":[],"by_name":{"x":{"name":"x","age":"1","tags":[],"color":0}},"labels":{},"address":{"city":""},"kind_case":0,"big":0,"limit":null}`)),
+    `by_name["x"].age: expected integer, got string`);
+  assertEqual(strictError(() => strict.Config.fromJson(`{"people":[],"by_name":{},"labels":{"a":""},"address":{"city":""},"kind_case":0,"big":0,"limit":null}`)),
+    `labels["a"]: invalid map key`);
+  assertEqual(strictError(() => strict.Config.fromJson(`{"people":[],"by_name":{},"labels":{},"address":{"city":1},"kind_case":0,"big":0,"limit":null}`)),
+    "address.city: expected string, got number");
+  assertEqual(strictError(() => strict.Config.fromJson(`{"people":[],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"opt":-1,"big":0,"limit":null}`)),
+    "opt: out of range");
+  assertEqual(strictError(() => strict.Config.fromJson(`{"people":[],"by_name":{},"labels":{},"address":{"city":""},"kind_case":0,"big":1.5,"limit":null}`)),
+    "big: expected integer, got number");
+  assertEqual(strictError(() => strict.Config.fromJson(`[]`)), "expected object, got array");
+
+  // 知らないキーと必須のキーが無い場合もエラーになる
+  assertEqual(strictError(() => strict.Person.fromJson(`{"name":"a","age":1,"tags":[],"color":0,"nmae":"b"}`)), "nmae: unknown key");
+  assertEqual(strictError(() => strict.Person.fromJson(`{"name":"a","tags":[],"color":0}`)), "age: missing key");
+
+  // strict ではないメッセージの中にある strict なメッセージも調べる
+  const b = strict.Loose.fromJson(`{"id":1,"person":{"name":"a","age":1,"tags":[],"color":0},"x":1}`);
+  assertEqual(b.person.name, "a");
+  // 位置は一番外側のメッセージからの位置になる
+  assertEqual(strictError(() => strict.Loose.fromJson(`{"id":1,"person":{"name":"a","age":1,"tags":[],"color":0,"x":1}}`)), "person.x: unknown key");
+  assertEqual(strictError(() => strict.Loose.fromJson(`{"id":1,"person":{"name":1,"age":1,"tags":[],"color":0}}`)), "person.name: expected string, got number");
+
+  // ParseError から位置と理由を取り出せる
+  try {
+    strict.Person.fromJson(`{"name":"a","age":1,"tags":[],"color":"RED"}`);
+    throw new Error("not thrown");
+  } catch (e) {
+    if (!(e instanceof ParseError)) throw e;
+    assertEqual(e.path, "color");
+    assertEqual(e.reason, "expected integer, got string");
+  }
 }
 
 testEmpty();
@@ -206,3 +691,16 @@ testRepeated();
 testOneof();
 testOptional();
 testImporting();
+testMap();
+testWellknown();
+testJsonvalue();
+testAny();
+testCanonical();
+testBytes();
+testEnumName();
+testOneofActiveOnly();
+testDefaultValue();
+testValidation();
+testDeprecated();
+testUnknownFields();
+testStrict();
@@ -98,6 +98,70 @@ public class Test : MonoBehaviour
         D.Assert(a.d.Count == 1 && a.d[0].name == "bar");
     }
 
+    void TestBytes()
+    {
+        var a = new Bytes.Test();
+        D.Assert(a.data.Length == 0);
+        D.Assert(a.rp_data.Count == 0);
+        a = Identify(a);
+        D.Assert(a.data.Length == 0);
+        D.Assert(Json.ToJson(a) == "{\"data\":\"\",\"rp_data\":[]}");
+
+        a.data = new byte[] { 0, 1, 2 };
+        a.rp_data.Add(new byte[] { 0xfb, 0xff });
+        a = Identify(a);
+        D.Assert(a.data.Length == 3 && a.data[2] == 2);
+        D.Assert(a.rp_data.Count == 1 && a.rp_data[0][1] == 0xff);
+        // base64 の文字列になる
+        D.Assert(Json.ToJson(a) == "{\"data\":\"AAEC\",\"rp_data\":[\"+/8=\"]}");
+
+        // byte[] は中身で比較する
+        var b = new Bytes.Test();
+        b.data = new byte[] { 0, 1, 2 };
+        b.rp_data.Add(new byte[] { 0xfb, 0xff });
+        D.Assert(a.Equals(b));
+        D.Assert(a.GetHashCode() == b.GetHashCode());
+        b.data[2] = 3;
+        D.Assert(!a.Equals(b));
+
+        // URL セーフな base64 やパディングの無い base64 も読み込める
+        var c = Json.FromJson<Bytes.Test>("{\"data\":\"AAEC\",\"rp_data\":[\"-_8\"]}");
+        D.Assert(a.Equals(c));
+
+        bool thrown = false;
+        try
+        {
+            Json.FromJson<Bytes.Test>("{\"data\":\"!!\"}");
+        }
+        catch (System.FormatException)
+        {
+            thrown = true;
+        }
+        D.Assert(thrown);
+    }
+
+    void TestBase64Url()
+    {
+        var a = new Base64url.Test();
+        a.data = new byte[] { 0xfb, 0xff };
+        a.datas.Add(new byte[] { (byte)'a' });
+        a.data_map["x"] = new byte[] { (byte)'a', (byte)'b' };
+        a.wrapped_data = new byte[] { 0xff };
+        a = Identify(a);
+        // パディング無しの URL セーフな base64 になる
+        var json = Json.ToJson(a);
+        D.Assert(json.Contains("\"data\":\"-_8\""));
+        D.Assert(json.Contains("\"datas\":[\"YQ\"]"));
+        D.Assert(json.Contains("\"data_map\":{\"x\":\"YWI\"}"));
+        D.Assert(json.Contains("\"wrapped_data\":\"_w\""));
+
+        // BytesValue は null にできる
+        a.wrapped_data = null;
+        a = Identify(a);
+        D.Assert(a.wrapped_data == null);
+        D.Assert(Json.ToJson(a).Contains("\"wrapped_data\":null"));
+    }
+
     void TestOneof()
     {
         var a = new Oneof.Test();
@@ -149,10 +213,13 @@ public class Test : MonoBehaviour
         D.Assert(!a.HasB());
         D.Assert(!a.HasC());
         D.Assert(!a.HasD());
+        // 設定されていないフィールドはキーを出力せず、_case も出力しない
+        D.Assert(Json.ToJson(a) == "{}");
 
         a.SetA(1);
         D.Assert(a.HasA());
         D.Assert(a.a == 1);
+        D.Assert(Json.ToJson(a) == "{\"a\":1}");
         a = Identify(a);
         D.Assert(a.HasA());
         D.Assert(a.a == 1);
@@ -186,14 +253,466 @@ public class Test : MonoBehaviour
         D.Assert(!a.HasC());
         a.ClearD();
         D.Assert(!a.HasD());
+
+        // キーがあるフィールドが設定されているフィールドになる
+        a = Json.FromJson<Optional.Test>("{\"b\":\"x\"}");
+        D.Assert(!a.HasA() && a.HasB() && a.b == "x");
     }
 
     void TestImporting()
     {
+        var epoch = new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc);
         var a = new Importing.Test();
-        D.Assert(a.t.nanos == 0);
+        D.Assert(a.t == epoch);
+        a = Identify(a);
+        D.Assert(a.t == epoch);
+    }
+
+    void TestMap()
+    {
+        var a = new Mappb.Test();
+        D.Assert(a.a.Count == 0);
+        a = Identify(a);
+        D.Assert(a.a.Count == 0);
+
+        a.a["foo"] = 1;
+        a.b[-2] = "bar";
+        a.c[3] = Mappb.Enum.BAR;
+        a.d["baz"] = new Mappb.Message() { name = "hoge" };
+        a.e[true] = 1.5;
+        a = Identify(a);
+        D.Assert(a.a["foo"] == 1);
+        D.Assert(a.b[-2] == "bar");
+        D.Assert(a.c[3] == Mappb.Enum.BAR);
+        D.Assert(a.d["baz"].name == "hoge");
+        D.Assert(a.e[true] == 1.5);
+
+        // Dictionary は JSON のオブジェクトになる
+        D.Assert(Json.ToJson(a).Contains("\"b\":{\"-2\":\"bar\"}"));
+    }
+
+    void TestWellknown()
+    {
+        var a = new Wellknown.Test();
+        D.Assert(a.int32_value == null);
+        a = Identify(a);
+        D.Assert(Json.ToJson(a).Contains("\"timestamp\":\"1970-01-01T00:00:00Z\""));
+        D.Assert(Json.ToJson(a).Contains("\"duration\":\"0s\""));
+        D.Assert(Json.ToJson(a).Contains("\"int32_value\":null"));
+
+        a.timestamp = new System.DateTime(1972, 1, 1, 10, 0, 20, 21, System.DateTimeKind.Utc);
+        a.duration = System.TimeSpan.FromMilliseconds(-1500);
+        a.int32_value = 0;
+        a.string_value = "hoge";
+        a.int32_values.Add(null);
+        a.int32_values.Add(1);
+        a.durations["foo"] = System.TimeSpan.FromSeconds(3);
+        a = Identify(a);
+        D.Assert(a.int32_value == 0);
+        D.Assert(a.int64_value == null);
+        D.Assert(a.string_value == "hoge");
+        D.Assert(a.int32_values[0] == null && a.int32_values[1] == 1);
+
+        var json = Json.ToJson(a);
+        D.Assert(json.Contains("\"timestamp\":\"1972-01-01T10:00:20.021Z\""));
+        D.Assert(json.Contains("\"duration\":\"-1.500s\""));
+        D.Assert(json.Contains("\"durations\":{\"foo\":\"3s\"}"));
+
+        // タイムゾーン付きの時刻も読める
+        var b = Json.FromJson<Wellknown.Test>(json.Replace("1972-01-01T10:00:20.021Z", "1972-01-01T19:00:20.021+09:00"));
+        D.Assert(b.timestamp == a.timestamp);
+    }
+
+    void TestJsonvalue()
+    {
+        var a = new Jsonvalue.Test();
+        D.Assert(a.struct_value == null);
+        D.Assert(a.value == null);
+        D.Assert(a.list_value == null);
+        a = Identify(a);
+        D.Assert(Json.ToJson(a).Contains("\"struct_value\":null"));
+
+        a.struct_value = new System.Collections.Generic.Dictionary<string, object>();
+        a.struct_value["foo"] = new System.Collections.Generic.List<object> { 1, "bar", null };
+        a.value = 1.5;
+        a.list_value = new System.Collections.Generic.List<object> { true };
+        a.values.Add(null);
+        a.values.Add("hoge");
+        a.structs["s"] = new System.Collections.Generic.Dictionary<string, object> { { "n", -1L } };
+        a.SetOneofValue(false);
+        // 数値は読み込むと JsonNumber になるが、値が同じなら等しい
+        a = Identify(a);
+        D.Assert(a.value is JsonNumber && ((JsonNumber)a.value).Text == "1.5");
+        D.Assert((string)a.values[1] == "hoge");
+
+        var json = Json.ToJson(a);
+        D.Assert(json.Contains("\"struct_value\":{\"foo\":[1,\"bar\",null]}"));
+        D.Assert(json.Contains("\"value\":1.5"));
+        D.Assert(json.Contains("\"list_value\":[true]"));
+        D.Assert(json.Contains("\"values\":[null,\"hoge\"]"));
+        D.Assert(json.Contains("\"structs\":{\"s\":{\"n\":-1}}"));
+        D.Assert(json.Contains("\"oneof_value\":false"));
+
+        // Struct にオブジェクト以外を渡すとエラーになる
+        bool thrown = false;
+        try
+        {
+            Json.FromJson<Jsonvalue.Test>("{\"struct_value\":[]}");
+        }
+        catch (System.FormatException)
+        {
+            thrown = true;
+        }
+        D.Assert(thrown);
+    }
+
+    void TestAny()
+    {
+        var a = new Anypb.Test();
+        D.Assert(a.any == null);
+        a = Identify(a);
+
+        var p = new Anypb.Payload();
+        p.name = "foo";
+        p.count = 3;
+        var n = new Anypb.Test.Nested();
+        n.tags.Add("bar");
+        a.any = Any.Pack(p);
+        a.anys.Add(Any.Pack(n));
+        a.anys.Add(null);
+        a.any_map["p"] = Any.Pack(p);
+        a.SetOneofAny(Any.Pack(n));
+        a = Identify(a);
+        D.Assert(a.any.TypeUrl == "type.googleapis.com/anypb.Payload");
+        D.Assert(a.any.TypeName == "anypb.Payload");
+        D.Assert(a.any.Is<Anypb.Payload>());
+        D.Assert(!a.any.Is<Anypb.Test.Nested>());
+        D.Assert(a.any.Unpack<Anypb.Payload>().Equals(p));
+        D.Assert(a.any.Equals(a.any_map["p"]));
+        // 型を指定しない場合は TypeRegistry に登録された型から探す
+        D.Assert(a.anys[0].Unpack() is Anypb.Test.Nested);
+        D.Assert(a.anys[0].Unpack().Equals(n));
+        D.Assert(a.anys[1] == null);
+        D.Assert(a.oneof_any.Is<Anypb.Test.Nested>());
+
+        var json = Json.ToJson(a);
+        D.Assert(json.Contains("\"any\":{\"@type\":\"type.googleapis.com/anypb.Payload\",\"name\":\"foo\",\"count\":3}"));
+
+        // 型が違う場合はエラーになる
+        bool thrown = false;
+        try
+        {
+            a.any.Unpack<Anypb.Test.Nested>();
+        }
+        catch (System.InvalidOperationException)
+        {
+            thrown = true;
+        }
+        D.Assert(thrown);
+
+        // "@type" が無い場合もエラーになる
+        thrown = false;
+        try
+        {
+            Json.FromJson<Anypb.Test>("{\"any\":{\"name\":\"foo\"}}");
+        }
+        catch (System.FormatException)
+        {
+            thrown = true;
+        }
+        D.Assert(thrown);
+    }
+
+    void TestCanonical()
+    {
+        var a = new Canonical.Test();
+        a = Identify(a);
+        // デフォルト値のフィールドは出力しない
+        D.Assert(Json.ToJson(a) == "{}");
+
+        a.int_value = 1;
+        a.int64_value = -9007199254740993;
+        a.uint64_value = 18446744073709551615;
+        a.int64_values.Add(10);
+        a.int64_map["a"] = 20;
+        a.renamed = "foo";
+        a.color = Canonical.Color.COLOR_RED;
+        a.colors.Add(Canonical.Color.COLOR_BLUE);
+        a.colors.Add((Canonical.Color)100);
+        a.color_map[3] = Canonical.Color.COLOR_BLUE;
+        a.inner.value = 5;
+        a.wrapped_int64 = 30;
+        a.SetOptValue(0);
+        a.SetKindNumber(40);
+        a = Identify(a);
+
+        var json = Json.ToJson(a);
+        D.Assert(json.Contains("\"intValue\":1"));
+        D.Assert(json.Contains("\"int64Value\":\"-9007199254740993\""));
+        D.Assert(json.Contains("\"uint64Value\":\"18446744073709551615\""));
+        D.Assert(json.Contains("\"int64Values\":[\"10\"]"));
+        D.Assert(json.Contains("\"int64Map\":{\"a\":\"20\"}"));
+        D.Assert(json.Contains("\"customName\":\"foo\""));
+        D.Assert(json.Contains("\"color\":\"COLOR_RED\""));
+        // 未知の enum の値は数値のまま出力する
+        D.Assert(json.Contains("\"colors\":[\"COLOR_BLUE\",100]"));
+        D.Assert(json.Contains("\"colorMap\":{\"3\":\"COLOR_BLUE\"}"));
+        D.Assert(json.Contains("\"inner\":{\"value\":5}"));
+        D.Assert(json.Contains("\"wrappedInt64\":\"30\""));
+        // optional と oneof は設定されていればデフォルト値でも出力する
+        D.Assert(json.Contains("\"optValue\":0"));
+        D.Assert(json.Contains("\"kindNumber\":\"40\""));
+        D.Assert(!json.Contains("_case"));
+        D.Assert(!json.Contains("kindName"));
+
+        // 数値や enum の数値も読み込める
+        var b = Json.FromJson<Canonical.Test>("{\"int64Value\":12,\"color\":2,\"colors\":[\"COLOR_RED\",\"UNKNOWN\"],\"kindName\":\"\"}");
+        D.Assert(b.int64_value == 12);
+        D.Assert(b.color == Canonical.Color.COLOR_BLUE);
+        D.Assert(b.colors.Count == 2);
+        D.Assert(b.colors[0] == Canonical.Color.COLOR_RED);
+        D.Assert(b.colors[1] == Canonical.Color.COLOR_UNSPECIFIED);
+        D.Assert(b.HasKindName());
+
+        var c = new Canonical.BytesTest();
+        c = Identify(c);
+        D.Assert(Json.ToJson(c) == "{}");
+        c.data = new byte[] { 0, 1, 2 };
+        c.datas.Add(new byte[] { 0xfb, 0xff });
+        c.data_map["a"] = new byte[] { 1 };
+        c.wrapped_data = new byte[0];
+        c = Identify(c);
+        json = Json.ToJson(c);
+        D.Assert(json.Contains("\"data\":\"AAEC\""));
+        D.Assert(json.Contains("\"datas\":[\"+/8=\"]"));
+        D.Assert(json.Contains("\"dataMap\":{\"a\":\"AQ==\"}"));
+        // ラッパー型は空でも null でなければ出力する
+        D.Assert(json.Contains("\"wrappedData\":\"\""));
+    }
+
+    void TestEnumName()
+    {
+        var a = new EnumName.Test();
+        a = Identify(a);
+
+        a.color = EnumName.Color.COLOR_RED;
+        a.colors.Add(EnumName.Color.COLOR_BLUE);
+        a.colors.Add((EnumName.Color)100);
+        a.color_map["a"] = EnumName.Color.COLOR_BLUE;
+        a.number = EnumName.Number.NUMBER_ONE;
+        a.nested = EnumName.Test.Nested.NESTED_BAR;
+        a.SetOptColor(EnumName.Color.COLOR_BLUE);
+        a.SetOneofColor(EnumName.Color.COLOR_RED);
+        a = Identify(a);
+
+        var json = Json.ToJson(a);
+        D.Assert(json.Contains("\"color\":\"COLOR_RED\""));
+        // 未知の値は数値のまま出力する
+        D.Assert(json.Contains("\"colors\":[\"COLOR_BLUE\",100]"));
+        D.Assert(json.Contains("\"color_map\":{\"a\":\"COLOR_BLUE\"}"));
+        // jsonif_enum_as_name = false の enum は数値になる
+        D.Assert(json.Contains("\"number\":1"));
+        D.Assert(json.Contains("\"nested\":\"NESTED_BAR\""));
+        D.Assert(json.Contains("\"opt_color\":\"COLOR_BLUE\""));
+        D.Assert(json.Contains("\"oneof_color\":\"COLOR_RED\""));
+
+        // 数値も読み込めて、未知の名前は 0 になる
+        var b = Json.FromJson<EnumName.Test>("{\"color\":2,\"colors\":[\"COLOR_RED\",\"UNKNOWN\",100],\"nested\":\"NESTED_BAR\"}");
+        D.Assert(b.color == EnumName.Color.COLOR_BLUE);
+        D.Assert(b.colors.Count == 3);
+        D.Assert(b.colors[0] == EnumName.Color.COLOR_RED);
+        D.Assert(b.colors[1] == EnumName.Color.COLOR_UNSPECIFIED);
+        D.Assert(b.colors[2] == (EnumName.Color)100);
+        D.Assert(b.nested == EnumName.Test.Nested.NESTED_BAR);
+    }
+
+    void TestOneofActiveOnly()
+    {
+        var a = new OneofActiveOnly.Test();
+        a = Identify(a);
+        var json = Json.ToJson(a);
+        D.Assert(!json.Contains("\"kind_case\""));
+        D.Assert(!json.Contains("\"name\""));
+        D.Assert(!json.Contains("\"opt\""));
+        // oneof の設定で無効にしたものは今まで通り出力する
+        D.Assert(json.Contains("\"legacy_case\":0"));
+        D.Assert(json.Contains("\"legacy_name\":\"\""));
+
+        a.SetNumber(0);
+        a.SetOpt(0);
+        a = Identify(a);
+        D.Assert(a.kind_case == OneofActiveOnly.Test.KindCase.kNumber);
+        D.Assert(a.HasOpt() && a.opt == 0);
+        json = Json.ToJson(a);
+        D.Assert(json.Contains("\"number\":0"));
+        D.Assert(json.Contains("\"opt\":0"));
+        D.Assert(!json.Contains("\"inner\""));
+
+        // _case がある以前の形式の JSON も読み込める
+        var b = Json.FromJson<OneofActiveOnly.Test>("{\"kind_case\":3,\"name\":\"\",\"number\":0,\"inner\":{\"value\":5},\"legacy_case\":4,\"legacy_name\":\"bar\",\"legacy_number\":0,\"value\":1}");
+        D.Assert(b.kind_case == OneofActiveOnly.Test.KindCase.kInner);
+        D.Assert(b.inner.value == 5);
+        D.Assert(b.legacy_case == OneofActiveOnly.Test.LegacyCase.kLegacyName);
+        D.Assert(b.legacy_name == "bar");
+        D.Assert(!b.HasOpt());
+        D.Assert(b.value == 1);
+
+        var c = new OneofActiveOnly.Test2();
+        D.Assert(Json.ToJson(c) == "{}");
+        c.SetNumber(3);
+        c = Identify(c);
+        D.Assert(Json.ToJson(c) == "{\"number\":3}");
+    }
+
+    void TestDefaultValue()
+    {
+        var a = new DefaultValue.Test();
+        D.Assert(a.d == 1.5);
+        D.Assert(a.f == 3.0f);
+        D.Assert(a.i32 == int.MinValue);
+        D.Assert(a.i64 == long.MinValue);
+        D.Assert(a.u32 == uint.MaxValue);
+        D.Assert(a.b);
+        D.Assert(a.s == "hello \"jsonif\"\n");
+        D.Assert(a.color == DefaultValue.Color.COLOR_RED);
+        D.Assert(a.color_num == DefaultValue.Color.COLOR_BLUE);
+        D.Assert(a.inner.value == 10);
         a = Identify(a);
-        D.Assert(a.t.nanos == 0);
+        D.Assert(a.i64 == long.MinValue);
+
+        // キーが無い場合はデフォルト値になる
+        var b = Json.FromJson<DefaultValue.Test>("{\"i32\":0,\"s\":\"\",\"b\":false,\"inner\":{}}");
+        D.Assert(b.i32 == 0);
+        D.Assert(b.s == "");
+        D.Assert(!b.b);
+        D.Assert(b.d == 1.5);
+        D.Assert(b.color == DefaultValue.Color.COLOR_RED);
+        D.Assert(b.inner.value == 10);
+    }
+
+    void TestValidation()
+    {
+        var a = new Validation.Person();
+        a.name = "foo";
+        a.email = "foo@example.com";
+        a.SetScore(1.0);
+        D.Assert(a.Validate().Count == 0);
+
+        // サロゲートペアも 1 文字として数える
+        a.name = "あいうえおかき😀";
+        D.Assert(a.Validate().Count == 0);
+        a.name = "あいうえおかきく😀";
+        a.age = 151;
+        a.email = "foo";
+        a.role = (Validation.Role)100;
+        a.tags = new System.Collections.Generic.List<string> { "a", "", "c", "d" };
+        a.ClearScore();
+        a.ratio = 1.5f;
+        var r = a.Validate();
+        D.Assert(r.Count == 8);
+        D.Assert(r[0].Path == "name" && r[0].Message == "length must be at most 8");
+        D.Assert(r[1].Path == "age" && r[1].Message == "must be less than or equal to 150");
+        D.Assert(r[2].Path == "email" && r[2].Message == "must match pattern \"^[^@]+@[^@]+$\"");
+        D.Assert(r[3].Path == "role" && r[3].Message == "must be a defined enum value");
+        D.Assert(r[4].Path == "tags" && r[4].Message == "must have at most 3 items");
+        D.Assert(r[5].Path == "tags[1]" && r[5].Message == "length must be at least 1");
+        D.Assert(r[6].Path == "score" && r[6].Message == "is required");
+        D.Assert(r[7].Path == "ratio");
+
+        // ネストしたメッセージはパスで位置が分かる
+        r = new Validation.Team().Validate();
+        D.Assert(r.Count == 6);
+        D.Assert(r[0].Path == "leader" && r[0].Message == "is required");
+        D.Assert(r[1].Path == "leader.name");
+        D.Assert(r[2].Path == "leader.email");
+        D.Assert(r[3].Path == "leader.score");
+        D.Assert(r[4].Path == "members" && r[4].Message == "must have at least 1 items");
+        D.Assert(r[5].Path == "size" && r[5].Message == "is required");
+
+        var person = "\"name\":\"a\",\"age\":1,\"email\":\"a@b\",\"role\":1,\"tags\":[],\"ratio\":0";
+        var t = Json.FromJson<Validation.Team>("{" +
+            "\"leader\":{" + person + ",\"score\":1}," +
+            "\"members\":[{" + person + ",\"score\":1},{" + person + ",\"score\":1,\"age\":-1}]," +
+            "\"by_name\":{\"x\":{" + person + ",\"score\":0}}," +
+            "\"limits\":{\"1\":11}," +
+            "\"size\":0," +
+            "\"contact_case\":6," +
+            "\"phone\":\"03-1234\"" +
+            "}");
+        r = t.Validate();
+        D.Assert(r.Count == 4);
+        D.Assert(r[0].Path == "members[1].age");
+        D.Assert(r[1].Path == "by_name[\"x\"].score" && r[1].Message == "must be greater than or equal to 0.5");
+        D.Assert(r[2].Path == "limits[\"1\"]" && r[2].Message == "must be less than or equal to 10");
+        D.Assert(r[3].Path == "phone");
+    }
+
+    void TestDeprecated()
+    {
+        var a = new Deprecated.Test();
+        a.id = 10;
+        a.status = Deprecated.Status.STATUS_ACTIVE;
+        a.SetNumber(5);
+        a = Identify(a);
+
+        // deprecated なフィールドのキーが JSON にあったら通知される
+        var reported = new System.Collections.Generic.List<string>();
+        DeprecatedField.Handler = (message, key) => reported.Add(message + "." + key);
+        DeprecatedField.ResetCount();
+        var b = Json.FromJson<Deprecated.Test>("{\"id\":1,\"old_id\":2,\"status\":1,\"legacy\":{\"value\":3},\"tags\":[],\"choice_case\":8,\"text\":\"x\"}");
+        D.Assert(b.id == 1);
+        D.Assert(DeprecatedField.Count == 4);
+        D.Assert(reported.Count == 4);
+        D.Assert(reported[0] == "deprecated.Test.old_id");
+        D.Assert(reported[1] == "deprecated.Test.legacy");
+        D.Assert(reported[2] == "deprecated.Test.tags");
+        D.Assert(reported[3] == "deprecated.Test.text");
+        // キーが無ければ通知されない
+        Json.FromJson<Deprecated.Test>("{\"id\":1}");
+        D.Assert(DeprecatedField.Count == 4);
+        DeprecatedField.Handler = null;
+        DeprecatedField.ResetCount();
+    }
+
+    void TestUnknownFields()
+    {
+        // 知らないキーは保持して、そのまま出力する
+        var json = "{\"id\":1,\"name\":\"a\",\"inner\":{\"value\":2,\"x\":[1,\"y\"]},\"inners\":[{\"value\":3,\"z\":null}],\"inner_map\":{\"k\":{\"value\":4,\"w\":true}},\"kind_case\":6,\"number\":5,\"extra\":{\"a\":1},\"more\":\"b\"}";
+        var a = Json.FromJson<UnknownFields.Test>(json);
+        D.Assert(a.id == 1);
+        D.Assert(a.number == 5);
+        D.Assert(a.jsonif_unknown_fields.Count == 2);
+        D.Assert((string)a.jsonif_unknown_fields["more"] == "b");
+        D.Assert(a.inner.jsonif_unknown_fields.Count == 1);
+        D.Assert(a.inners[0].jsonif_unknown_fields.Count == 1);
+        D.Assert(a.inner_map["k"].jsonif_unknown_fields.Count == 1);
+        a = Identify(a);
+        var b = Json.FromJson<UnknownFields.Test>(Json.ToJson(a));
+        D.Assert(a.Equals(b));
+        D.Assert(a.GetHashCode() == b.GetHashCode());
+
+        // 知らないキーも比較する
+        b.jsonif_unknown_fields.Remove("more");
+        D.Assert(!a.Equals(b));
+        b = Json.FromJson<UnknownFields.Test>(Json.ToJson(a));
+        b.inner.jsonif_unknown_fields.Clear();
+        D.Assert(!a.Equals(b));
+
+        // 知っているキーと同じキーは出力しない
+        b = Json.FromJson<UnknownFields.Test>(Json.ToJson(a));
+        b.jsonif_unknown_fields["id"] = 100;
+        D.Assert(Json.FromJson<UnknownFields.Test>(Json.ToJson(b)).id == 1);
+
+        // 知らないキーを持たないメッセージでは捨てる
+        var c = Json.FromJson<UnknownFields.Plain>("{\"id\":1,\"test\":{\"id\":2,\"x\":1},\"y\":2}");
+        D.Assert(c.test.jsonif_unknown_fields.Count == 1);
+        D.Assert(!Json.ToJson(c).Contains("\"y\""));
+
+        // フィールドが無いメッセージ
+        var d = Json.FromJson<UnknownFields.Empty>("{\"a\":1}");
+        D.Assert(d.jsonif_unknown_fields.Count == 1);
+        D.Assert(Json.ToJson(d) == "{\"a\":1}");
+        D.Assert(Json.ToJson(new UnknownFields.Empty()) == "{}");
     }
 
     void Start()
@@ -203,8 +722,21 @@ public class Test : MonoBehaviour
         TestEnumpb();
         TestNested();
         TestRepeated();
+        TestBytes();
+        TestBase64Url();
         TestOneof();
         TestImporting();
+        TestMap();
+        TestWellknown();
+        TestJsonvalue();
+        TestAny();
+        TestCanonical();
+        TestEnumName();
+        TestOneofActiveOnly();
+        TestDefaultValue();
+        TestValidation();
+        TestDeprecated();
+        TestUnknownFields();
 
         Debug.Log("Unity Test passed");
     }